@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"github.com/ugorji/go/codec"
+)
+
+const (
+	// DefaultMaxPooledPayload is the default size of the largest payload buffer
+	// a DecodeArena retains for reuse.
+	DefaultMaxPooledPayload = 64 * 1024
+
+	// DefaultMaxPooledPayloads is the default number of payload buffers a
+	// DecodeArena retains for reuse.
+	DefaultMaxPooledPayloads = 64
+)
+
+var (
+	// arenaJSONHandle is the same as jsonHandle, except that map keys are
+	// interned by the decoder.
+	arenaJSONHandle = codec.JsonHandle{
+		// nolint:staticcheck
+		BasicHandle: codec.BasicHandle{
+			TypeInfos: codec.NewTypeInfos([]string{"json"}),
+			DecodeOptions: codec.DecodeOptions{
+				InternString: true,
+			},
+		},
+		IntegerAsString: 'L',
+	}
+
+	// arenaMsgpackHandle is the same as msgpackHandle, except that map keys are
+	// interned by the decoder.
+	arenaMsgpackHandle = codec.MsgpackHandle{
+		WriteExt: true,
+		// nolint:staticcheck
+		BasicHandle: codec.BasicHandle{
+			TypeInfos: codec.NewTypeInfos([]string{"json"}),
+			DecodeOptions: codec.DecodeOptions{
+				InternString: true,
+			},
+		},
+	}
+)
+
+// arenaHandle looks up the codec.Handle used by a DecodeArena for this format.
+// This method panics if the format is not a valid value.
+func (f Format) arenaHandle() codec.Handle {
+	switch f {
+	case Msgpack:
+		return &arenaMsgpackHandle
+	case JSON:
+		return &arenaJSONHandle
+	}
+
+	// let handle() produce the panic
+	return f.handle()
+}
+
+// DecodeArenaOption is a functional option for a DecodeArena.
+type DecodeArenaOption interface {
+	apply(*DecodeArena)
+}
+
+type decodeArenaOptionFunc func(*DecodeArena)
+
+func (f decodeArenaOptionFunc) apply(a *DecodeArena) {
+	f(a)
+}
+
+// MaxPooledPayload sets the capacity of the largest payload buffer the arena
+// retains for reuse.  Larger buffers are left to the garbage collector so that
+// an occasional huge message doesn't pin memory.  Values less than 1 disable
+// payload pooling.
+func MaxPooledPayload(size int) DecodeArenaOption {
+	return decodeArenaOptionFunc(func(a *DecodeArena) {
+		a.maxPayload = size
+	})
+}
+
+// MaxPooledPayloads sets the number of payload buffers the arena retains for
+// reuse.  Values less than 1 disable payload pooling.
+func MaxPooledPayloads(count int) DecodeArenaOption {
+	return decodeArenaOptionFunc(func(a *DecodeArena) {
+		a.maxPayloads = count
+	})
+}
+
+// DecodeArena decodes Messages while reusing memory across decodes.  It is
+// intended for relay workloads that decode a very large number of messages
+// and release each one shortly after.
+//
+// A DecodeArena reuses a single decoder, which interns the map keys it
+// encounters (e.g. metadata names) for as long as the arena lives, and it
+// keeps a free list of Payload buffers returned via Release.
+//
+// A DecodeArena is not safe for concurrent use.  Use one arena per goroutine.
+type DecodeArena struct {
+	format      Format
+	decoder     *codec.Decoder
+	payloads    [][]byte
+	maxPayload  int
+	maxPayloads int
+	interner    *Interner
+}
+
+// NewDecodeArena creates a DecodeArena for the given format.  This function
+// panics if the format is not a valid value.
+func NewDecodeArena(f Format, opts ...DecodeArenaOption) *DecodeArena {
+	a := DecodeArena{
+		format:      f,
+		decoder:     codec.NewDecoderBytes(nil, f.arenaHandle()),
+		maxPayload:  DefaultMaxPooledPayload,
+		maxPayloads: DefaultMaxPooledPayloads,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt.apply(&a)
+		}
+	}
+
+	return &a
+}
+
+// Format returns the format this arena decodes.
+func (a *DecodeArena) Format() Format {
+	return a.format
+}
+
+// Decode decodes the input into msg, replacing any existing contents of msg.
+// If the message has a payload, it is stored in a buffer taken from the arena
+// when one is available.
+//
+// The input is not retained by the arena or the message.
+func (a *DecodeArena) Decode(input []byte, msg *Message) error {
+	buf := a.take()
+	*msg = Message{
+		Payload: buf,
+	}
+
+	a.decoder.ResetBytes(input)
+	err := a.decoder.Decode(msg)
+
+	if len(msg.Payload) == 0 {
+		msg.Payload = nil
+	}
+
+	if a.interner != nil && err == nil {
+		a.interner.Message(msg)
+	}
+
+	// the buffer was not used if the payload was absent or too large for it
+	if cap(buf) > 0 && (len(msg.Payload) == 0 || &buf[:1][0] != &msg.Payload[0]) {
+		a.put(buf)
+	}
+
+	return err
+}
+
+// Release returns the message's payload buffer to the arena so a later
+// Decode can reuse it.  The message's Payload is set to nil, and no other
+// references to the payload may be used after calling Release.
+func (a *DecodeArena) Release(msg *Message) {
+	if msg == nil {
+		return
+	}
+
+	a.put(msg.Payload)
+	msg.Payload = nil
+}
+
+// take returns an empty buffer from the free list, or nil if there isn't one.
+func (a *DecodeArena) take() []byte {
+	n := len(a.payloads)
+	if n == 0 {
+		return nil
+	}
+
+	buf := a.payloads[n-1]
+	a.payloads[n-1] = nil
+	a.payloads = a.payloads[:n-1]
+	return buf[:0]
+}
+
+// put adds a buffer to the free list if it is worth keeping.
+func (a *DecodeArena) put(buf []byte) {
+	if cap(buf) == 0 || cap(buf) > a.maxPayload || len(a.payloads) >= a.maxPayloads {
+		return
+	}
+
+	a.payloads = append(a.payloads, buf[:0])
+}
@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func arenaTestMessages() []Message {
+	metadata := make(map[string]string)
+	for i := 0; i < 16; i++ {
+		metadata[fmt.Sprintf("/key-%02d", i)] = fmt.Sprintf("value-%02d", i)
+	}
+
+	return []Message{
+		{
+			Type:        SimpleEventMessageType,
+			Source:      "mac:112233445566/service",
+			Destination: "event:device-status/mac:112233445566/online",
+			Metadata:    metadata,
+			PartnerIDs:  []string{"comcast"},
+			Payload:     []byte("some payload"),
+		}, {
+			Type:            SimpleRequestResponseMessageType,
+			Source:          "dns:example.com",
+			Destination:     "mac:112233445566/config",
+			TransactionUUID: "1234",
+		}, {
+			Type:        SimpleEventMessageType,
+			Source:      "mac:112233445566/service",
+			Destination: "event:big",
+			Payload:     bytes.Repeat([]byte{0xde, 0xad}, 1024),
+		},
+	}
+}
+
+func TestDecodeArena_Decode(t *testing.T) {
+	for _, f := range AllFormats() {
+		t.Run(f.String(), func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			a := NewDecodeArena(f)
+			assert.Equal(f, a.Format())
+
+			for round := 0; round < 3; round++ {
+				for _, want := range arenaTestMessages() {
+					var got Message
+					require.NoError(a.Decode(MustEncode(&want, f), &got))
+					assert.Equal(want, got)
+					a.Release(&got)
+					assert.Nil(got.Payload)
+				}
+			}
+
+			var got Message
+			assert.Error(a.Decode([]byte{0xc1, 0x00}, &got))
+		})
+	}
+}
+
+func TestDecodeArena_reuse(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	a := NewDecodeArena(Msgpack)
+	encoded := MustEncode(&Message{Type: SimpleEventMessageType, Payload: []byte("payload")}, Msgpack)
+
+	var first Message
+	require.NoError(a.Decode(encoded, &first))
+	backing := &first.Payload[0]
+	a.Release(&first)
+
+	// a message without a payload must not consume the buffer
+	var empty Message
+	require.NoError(a.Decode(MustEncode(&Message{Type: SimpleEventMessageType}, Msgpack), &empty))
+	assert.Nil(empty.Payload)
+
+	var second Message
+	require.NoError(a.Decode(encoded, &second))
+	assert.Equal([]byte("payload"), second.Payload)
+	assert.Same(backing, &second.Payload[0])
+
+	// a nil message is ignored
+	a.Release(nil)
+}
+
+func TestDecodeArena_options(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []DecodeArenaOption
+		payload int
+		pooled  int
+	}{
+		{
+			desc:    "defaults",
+			payload: 10,
+			pooled:  1,
+		}, {
+			desc:    "nil option",
+			opts:    []DecodeArenaOption{nil},
+			payload: 10,
+			pooled:  1,
+		}, {
+			desc:    "payload too large",
+			opts:    []DecodeArenaOption{MaxPooledPayload(5)},
+			payload: 10,
+		}, {
+			desc:    "pooling disabled",
+			opts:    []DecodeArenaOption{MaxPooledPayloads(0)},
+			payload: 10,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			a := NewDecodeArena(Msgpack, tc.opts...)
+			msg := Message{Payload: make([]byte, tc.payload)}
+			a.Release(&msg)
+			assert.Len(t, a.payloads, tc.pooled)
+		})
+	}
+}
+
+func TestDecodeArena_allocations(t *testing.T) {
+	msg := arenaTestMessages()[0]
+	encoded := MustEncode(&msg, Msgpack)
+
+	standard := testing.AllocsPerRun(100, func() {
+		var m Message
+		_ = NewDecoderBytes(encoded, Msgpack).Decode(&m)
+	})
+
+	a := NewDecodeArena(Msgpack)
+	arena := testing.AllocsPerRun(100, func() {
+		var m Message
+		_ = a.Decode(encoded, &m)
+		a.Release(&m)
+	})
+
+	assert.Less(t, arena, standard)
+}
+
+func BenchmarkDecodeArena(b *testing.B) {
+	msg := arenaTestMessages()[0]
+
+	for _, f := range AllFormats() {
+		encoded := MustEncode(&msg, f)
+
+		b.Run(f.String()+"/standard", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var m Message
+				if err := NewDecoderBytes(encoded, f).Decode(&m); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(f.String()+"/arena", func(b *testing.B) {
+			a := NewDecodeArena(f)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var m Message
+				if err := a.Decode(encoded, &m); err != nil {
+					b.Fatal(err)
+				}
+				a.Release(&m)
+			}
+		})
+	}
+}
@@ -0,0 +1,406 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// The defaults used by NewAuditor.
+const (
+	DefaultAuditBatchSize     = 100
+	DefaultAuditFlushInterval = time.Second
+	DefaultAuditQueueSize     = 16
+)
+
+var ErrInvalidAudit = errors.New("invalid audit configuration")
+
+// AuditOutcome is the result of validating an audited message.
+type AuditOutcome string
+
+const (
+	// AuditUnchecked means the message was not validated.
+	AuditUnchecked AuditOutcome = "unchecked"
+
+	// AuditValid means the message passed validation.
+	AuditValid AuditOutcome = "valid"
+
+	// AuditInvalid means the message failed validation.
+	AuditInvalid AuditOutcome = "invalid"
+)
+
+// AuditRecord describes who sent which message to whom, and when.
+type AuditRecord struct {
+	// Time is when the message was audited.
+	Time time.Time `json:"time"`
+
+	Type            MessageType `json:"msg_type"`
+	Source          string      `json:"source"`
+	Destination     string      `json:"dest"`
+	TransactionUUID string      `json:"transaction_uuid,omitempty"`
+	PartnerIDs      []string    `json:"partner_ids,omitempty"`
+
+	// Size is the size of the message in the msgpack format.
+	Size int `json:"size"`
+
+	// Outcome is the result of validating the message.
+	Outcome AuditOutcome `json:"outcome"`
+
+	// Reason is the validation error of an AuditInvalid message.
+	Reason string `json:"reason,omitempty"`
+}
+
+// AuditSink receives batches of audit records, e.g. to forward them to a
+// compliance pipeline.  The records are owned by the sink once passed.
+type AuditSink interface {
+	WriteAudit(context.Context, []AuditRecord) error
+}
+
+// AuditSinkFunc is a convenience type to define an AuditSink using a function.
+type AuditSinkFunc func(context.Context, []AuditRecord) error
+
+func (f AuditSinkFunc) WriteAudit(ctx context.Context, records []AuditRecord) error {
+	return f(ctx, records)
+}
+
+// AuditorOption is a functional option for configuring an Auditor.
+type AuditorOption interface {
+	apply(*Auditor) error
+}
+
+type auditorOptionFunc func(*Auditor) error
+
+func (f auditorOptionFunc) apply(a *Auditor) error {
+	return f(a)
+}
+
+// AuditBatchSize sets the number of records sent to the sink at once.  The
+// default is DefaultAuditBatchSize.
+func AuditBatchSize(n int) AuditorOption {
+	return auditorOptionFunc(func(a *Auditor) error {
+		if n < 1 {
+			return fmt.Errorf("%w: batch size %d", ErrInvalidAudit, n)
+		}
+		a.batchSize = n
+		return nil
+	})
+}
+
+// AuditFlushInterval sets how often a partial batch is sent to the sink.  A
+// zero interval disables periodic flushing.  The default is
+// DefaultAuditFlushInterval.
+func AuditFlushInterval(d time.Duration) AuditorOption {
+	return auditorOptionFunc(func(a *Auditor) error {
+		if d < 0 {
+			return fmt.Errorf("%w: flush interval %s", ErrInvalidAudit, d)
+		}
+		a.interval = d
+		return nil
+	})
+}
+
+// AuditQueueSize sets the number of full batches that may wait for the sink.
+// Batches that do not fit are dropped and counted.  The default is
+// DefaultAuditQueueSize.
+func AuditQueueSize(n int) AuditorOption {
+	return auditorOptionFunc(func(a *Auditor) error {
+		if n < 1 {
+			return fmt.Errorf("%w: queue size %d", ErrInvalidAudit, n)
+		}
+		a.queueSize = n
+		return nil
+	})
+}
+
+// AuditValidator sets the function used to determine the Outcome of each
+// record.  Without it, every record is AuditUnchecked.
+func AuditValidator(validate func(Message) error) AuditorOption {
+	return auditorOptionFunc(func(a *Auditor) error {
+		a.validate = validate
+		return nil
+	})
+}
+
+// AuditOnError sets a function that is called with the errors returned by
+// the sink from background flushes.
+func AuditOnError(onError func(error)) AuditorOption {
+	return auditorOptionFunc(func(a *Auditor) error {
+		a.onError = onError
+		return nil
+	})
+}
+
+// AuditClock sets the clock used to timestamp records.  The default is
+// SystemClock.  Batches are flushed on a real ticker, whatever the clock.
+func AuditClock(clock Clock) AuditorOption {
+	return auditorOptionFunc(func(a *Auditor) error {
+		if clock != nil {
+			a.clock = clock
+		}
+		return nil
+	})
+}
+
+// AuditNow sets the function used to timestamp records.  It is the same as
+// AuditClock(ClockFunc(now)).
+func AuditNow(now func() time.Time) AuditorOption {
+	if now == nil {
+		return AuditClock(nil)
+	}
+
+	return AuditClock(ClockFunc(now))
+}
+
+// Auditor is a Processor that emits an AuditRecord for every message to an
+// AuditSink.  Records are batched, and full batches are written to the sink by
+// a background goroutine so that a slow sink does not hold up messages.  Every
+// message results in ErrNotHandled so the Auditor can be placed at the front of
+// a Processors chain.
+//
+// An Auditor is safe for concurrent use.  Close must be called to flush the
+// remaining records and stop the background goroutine.
+type Auditor struct {
+	sink      AuditSink
+	validate  func(Message) error
+	onError   func(error)
+	clock     Clock
+	batchSize int
+	queueSize int
+	interval  time.Duration
+
+	m       sync.Mutex
+	batch   []AuditRecord
+	closed  bool
+	batches chan []AuditRecord
+	stop    chan struct{}
+	done    chan struct{}
+	dropped atomic.Int64
+}
+
+var _ Processor = (*Auditor)(nil)
+
+// NewAuditor creates an Auditor that writes records to the sink.
+func NewAuditor(sink AuditSink, opts ...AuditorOption) (*Auditor, error) {
+	if sink == nil {
+		return nil, fmt.Errorf("%w: nil sink", ErrInvalidAudit)
+	}
+
+	a := Auditor{
+		sink:      sink,
+		onError:   func(error) {},
+		clock:     SystemClock,
+		batchSize: DefaultAuditBatchSize,
+		queueSize: DefaultAuditQueueSize,
+		interval:  DefaultAuditFlushInterval,
+	}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt.apply(&a); err != nil {
+			return nil, err
+		}
+	}
+
+	if a.onError == nil {
+		a.onError = func(error) {}
+	}
+
+	a.batches = make(chan []AuditRecord, a.queueSize)
+	a.stop = make(chan struct{})
+	a.done = make(chan struct{})
+	go a.run()
+
+	return &a, nil
+}
+
+// ProcessWRP records the message and returns ErrNotHandled.  Messages
+// processed after Close are not recorded.
+func (a *Auditor) ProcessWRP(_ context.Context, msg Message) error {
+	if a.isClosed() {
+		return ErrNotHandled
+	}
+
+	record := a.record(&msg)
+
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	if a.closed {
+		return ErrNotHandled
+	}
+
+	a.batch = append(a.batch, record)
+	if len(a.batch) >= a.batchSize {
+		select {
+		case a.batches <- a.batch:
+		default:
+			a.dropped.Add(int64(len(a.batch)))
+		}
+		a.batch = nil
+	}
+
+	return ErrNotHandled
+}
+
+// Dropped returns the number of records dropped because the sink could not
+// keep up.
+func (a *Auditor) Dropped() int64 {
+	return a.dropped.Load()
+}
+
+// Flush writes the records of the current partial batch to the sink.
+func (a *Auditor) Flush(ctx context.Context) error {
+	batch := a.take()
+	if len(batch) == 0 {
+		return nil
+	}
+
+	return a.sink.WriteAudit(ctx, batch)
+}
+
+// Close stops the Auditor, waiting for queued batches to be written, and
+// writes the remaining records.  Close returns the context's error if it ends
+// before the queued batches are written.
+func (a *Auditor) Close(ctx context.Context) error {
+	a.m.Lock()
+	if a.closed {
+		a.m.Unlock()
+		return nil
+	}
+	a.closed = true
+	batch := a.batch
+	a.batch = nil
+	a.m.Unlock()
+
+	close(a.stop)
+	select {
+	case <-a.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	return a.sink.WriteAudit(ctx, batch)
+}
+
+func (a *Auditor) record(msg *Message) AuditRecord {
+	r := AuditRecord{
+		Time:            a.clock.Now(),
+		Type:            msg.Type,
+		Source:          msg.Source,
+		Destination:     msg.Destination,
+		TransactionUUID: msg.TransactionUUID,
+		PartnerIDs:      slices.Clone(msg.PartnerIDs),
+		Size:            msg.EncodedSizeEstimate(Msgpack),
+		Outcome:         AuditUnchecked,
+	}
+
+	if a.validate != nil {
+		r.Outcome = AuditValid
+		if err := a.validate(*msg); err != nil {
+			r.Outcome = AuditInvalid
+			r.Reason = err.Error()
+		}
+	}
+
+	return r
+}
+
+func (a *Auditor) isClosed() bool {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	return a.closed
+}
+
+// take removes and returns the current partial batch.
+func (a *Auditor) take() []AuditRecord {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	batch := a.batch
+	a.batch = nil
+	return batch
+}
+
+func (a *Auditor) write(batch []AuditRecord) {
+	if err := a.sink.WriteAudit(context.Background(), batch); err != nil {
+		a.onError(err)
+	}
+}
+
+func (a *Auditor) run() {
+	defer close(a.done)
+
+	var tick <-chan time.Time
+	if a.interval > 0 {
+		ticker := time.NewTicker(a.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case batch := <-a.batches:
+			a.write(batch)
+
+		case <-tick:
+			if batch := a.take(); len(batch) > 0 {
+				a.write(batch)
+			}
+
+		case <-a.stop:
+			// no batches are queued once closed, so drain what is left
+			for {
+				select {
+				case batch := <-a.batches:
+					a.write(batch)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// MemoryAuditSink is an AuditSink that keeps records in memory, for tests.
+// The zero value is ready to use.
+type MemoryAuditSink struct {
+	m       sync.Mutex
+	records []AuditRecord
+}
+
+var _ AuditSink = (*MemoryAuditSink)(nil)
+
+func (s *MemoryAuditSink) WriteAudit(_ context.Context, records []AuditRecord) error {
+	s.m.Lock()
+	s.records = append(s.records, records...)
+	s.m.Unlock()
+	return nil
+}
+
+// Records returns a copy of the records written so far.
+func (s *MemoryAuditSink) Records() []AuditRecord {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return slices.Clone(s.records)
+}
+
+// Reset discards the records written so far.
+func (s *MemoryAuditSink) Reset() {
+	s.m.Lock()
+	s.records = nil
+	s.m.Unlock()
+}
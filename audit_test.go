@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditor(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var (
+		now        = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		errInvalid = errors.New("missing partner")
+		sink       MemoryAuditSink
+	)
+
+	a, err := NewAuditor(&sink,
+		AuditBatchSize(2),
+		AuditFlushInterval(0),
+		AuditNow(func() time.Time { return now }),
+		AuditValidator(func(msg Message) error {
+			if len(msg.PartnerIDs) == 0 {
+				return errInvalid
+			}
+			return nil
+		}),
+		nil,
+	)
+	require.NoError(err)
+
+	valid := Message{
+		Type:            SimpleRequestResponseMessageType,
+		Source:          "dns:example.com",
+		Destination:     "mac:112233445566/config",
+		TransactionUUID: "1234",
+		PartnerIDs:      []string{"comcast"},
+		Payload:         []byte("payload"),
+	}
+	invalid := Message{
+		Type:        SimpleEventMessageType,
+		Source:      "mac:112233445566",
+		Destination: "event:device-status",
+	}
+
+	ctx := context.Background()
+	assert.ErrorIs(a.ProcessWRP(ctx, valid), ErrNotHandled)
+	assert.ErrorIs(a.ProcessWRP(ctx, invalid), ErrNotHandled)
+	assert.ErrorIs(a.ProcessWRP(ctx, valid), ErrNotHandled)
+
+	// the full batch is written in the background, the third record waits
+	assert.Eventually(func() bool { return len(sink.Records()) == 2 }, time.Second, time.Millisecond)
+
+	require.NoError(a.Close(ctx))
+	assert.NoError(a.Close(ctx))
+	assert.ErrorIs(a.ProcessWRP(ctx, valid), ErrNotHandled)
+
+	records := sink.Records()
+	require.Len(records, 3)
+	assert.Equal(AuditRecord{
+		Time:            now,
+		Type:            SimpleRequestResponseMessageType,
+		Source:          "dns:example.com",
+		Destination:     "mac:112233445566/config",
+		TransactionUUID: "1234",
+		PartnerIDs:      []string{"comcast"},
+		Size:            valid.Size(Msgpack),
+		Outcome:         AuditValid,
+	}, records[0])
+	assert.Equal(AuditInvalid, records[1].Outcome)
+	assert.Equal("missing partner", records[1].Reason)
+	assert.Equal(AuditValid, records[2].Outcome)
+	assert.Zero(a.Dropped())
+
+	sink.Reset()
+	assert.Empty(sink.Records())
+}
+
+func TestAuditor_unchecked(t *testing.T) {
+	var sink MemoryAuditSink
+	a, err := NewAuditor(&sink, AuditFlushInterval(0))
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, a.ProcessWRP(context.Background(), Message{Type: SimpleEventMessageType}), ErrNotHandled)
+	require.NoError(t, a.Flush(context.Background()))
+	require.NoError(t, a.Flush(context.Background()))
+
+	records := sink.Records()
+	require.Len(t, records, 1)
+	assert.Equal(t, AuditUnchecked, records[0].Outcome)
+	assert.Empty(t, records[0].Reason)
+	require.NoError(t, a.Close(context.Background()))
+}
+
+func TestAuditor_closed(t *testing.T) {
+	var (
+		sink      MemoryAuditSink
+		validated int
+	)
+
+	a, err := NewAuditor(&sink, AuditFlushInterval(0), AuditValidator(func(Message) error {
+		validated++
+		return nil
+	}))
+	require.NoError(t, err)
+	require.NoError(t, a.Close(context.Background()))
+
+	assert.ErrorIs(t, a.ProcessWRP(context.Background(), Message{Type: SimpleEventMessageType}), ErrNotHandled)
+	assert.Zero(t, validated)
+	assert.Empty(t, sink.Records())
+}
+
+func TestAuditClock(t *testing.T) {
+	var (
+		now  = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		sink MemoryAuditSink
+	)
+
+	a, err := NewAuditor(&sink, AuditFlushInterval(0), AuditClock(ClockFunc(func() time.Time { return now })))
+	require.NoError(t, err)
+
+	_ = a.ProcessWRP(context.Background(), Message{Type: SimpleEventMessageType})
+	require.NoError(t, a.Close(context.Background()))
+
+	records := sink.Records()
+	require.Len(t, records, 1)
+	assert.Equal(t, now, records[0].Time)
+}
+
+func TestAuditor_flushInterval(t *testing.T) {
+	var sink MemoryAuditSink
+	a, err := NewAuditor(&sink, AuditFlushInterval(time.Millisecond))
+	require.NoError(t, err)
+	defer a.Close(context.Background())
+
+	_ = a.ProcessWRP(context.Background(), Message{Type: SimpleEventMessageType})
+	assert.Eventually(t, func() bool { return len(sink.Records()) == 1 }, time.Second, time.Millisecond)
+}
+
+func TestAuditor_slowSink(t *testing.T) {
+	var (
+		release = make(chan struct{})
+		errs    = make(chan error, 10)
+		errSink = errors.New("sink failed")
+	)
+
+	sink := AuditSinkFunc(func(ctx context.Context, _ []AuditRecord) error {
+		select {
+		case <-release:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		return errSink
+	})
+
+	a, err := NewAuditor(sink,
+		AuditBatchSize(1),
+		AuditQueueSize(1),
+		AuditFlushInterval(0),
+		AuditOnError(func(err error) { errs <- err }),
+	)
+	require.NoError(t, err)
+
+	// the first batch is taken by the writer, the second is queued, and the
+	// rest are dropped without blocking
+	for i := 0; i < 5; i++ {
+		assert.ErrorIs(t, a.ProcessWRP(context.Background(), Message{}), ErrNotHandled)
+		time.Sleep(time.Millisecond)
+	}
+	assert.GreaterOrEqual(t, a.Dropped(), int64(3))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, a.Close(ctx), context.DeadlineExceeded)
+
+	close(release)
+	assert.ErrorIs(t, <-errs, errSink)
+}
+
+func TestNewAuditor_invalid(t *testing.T) {
+	tests := []struct {
+		desc string
+		sink AuditSink
+		opts []AuditorOption
+	}{
+		{
+			desc: "nil sink",
+		}, {
+			desc: "batch size",
+			sink: new(MemoryAuditSink),
+			opts: []AuditorOption{AuditBatchSize(0)},
+		}, {
+			desc: "queue size",
+			sink: new(MemoryAuditSink),
+			opts: []AuditorOption{AuditQueueSize(0)},
+		}, {
+			desc: "flush interval",
+			sink: new(MemoryAuditSink),
+			opts: []AuditorOption{AuditFlushInterval(-time.Second)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			a, err := NewAuditor(tc.sink, tc.opts...)
+			assert.Nil(t, a)
+			assert.ErrorIs(t, err, ErrInvalidAudit)
+		})
+	}
+}
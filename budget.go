@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrMessageTooLarge = errors.New("message too large")
+	ErrInvalidBudget   = errors.New("invalid byte budget")
+)
+
+// ByteBudget is a Processor that rejects messages whose msgpack encoding is
+// larger than a fixed number of bytes.  The size comes from
+// EncodedSizeEstimate, so the message is never encoded.
+//
+// Messages within the budget result in ErrNotHandled so the ByteBudget can be
+// placed at the front of a Processors chain.  Messages over the budget result
+// in an *Error wrapping ErrMessageTooLarge, which tells apart the two ways a
+// message can exceed the budget:
+//
+//   - If the message would fit without its payload, the code is
+//     CodePayloadTooLarge, the field is "Payload" and the error also wraps
+//     ErrPayloadTooLarge.
+//   - Otherwise the metadata of the message, i.e. every field other than the
+//     payload, is over the budget by itself.  The code is CodeMessageTooLarge
+//     and the field is the largest of the other fields, typically "Metadata"
+//     or "Headers".
+//
+// A ByteBudget is safe for concurrent use.
+type ByteBudget struct {
+	max int
+}
+
+var _ Processor = (*ByteBudget)(nil)
+
+// NewByteBudget creates a ByteBudget that allows messages of up to max bytes.
+// A max less than 1 results in an error wrapping ErrInvalidBudget.
+func NewByteBudget(max int) (*ByteBudget, error) {
+	if max < 1 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidBudget, max)
+	}
+
+	return &ByteBudget{max: max}, nil
+}
+
+// Max returns the number of bytes allowed.
+func (b *ByteBudget) Max() int {
+	return b.max
+}
+
+// Check returns nil if the message is within the budget, or the *Error
+// describing the overage otherwise.
+func (b *ByteBudget) Check(msg *Message) error {
+	sizes := estimateSizes(msg, Msgpack)
+	total := sizes.total()
+	if total <= b.max {
+		return nil
+	}
+
+	payload := sizes.fields[PayloadField]
+	if total-payload <= b.max {
+		return newError(CodePayloadTooLarge, PayloadField.String(),
+			fmt.Errorf("%w: %w: %d bytes exceeds the budget of %d bytes, the payload uses %d bytes",
+				ErrMessageTooLarge, ErrPayloadTooLarge, total, b.max, payload))
+	}
+
+	largest := sizes.largest(PayloadField)
+	return newError(CodeMessageTooLarge, largest.String(),
+		fmt.Errorf("%w: %d bytes without the payload exceeds the budget of %d bytes, %s uses %d bytes",
+			ErrMessageTooLarge, total-payload, b.max, largest, sizes.fields[largest]))
+}
+
+// ProcessWRP implements Processor.
+func (b *ByteBudget) ProcessWRP(_ context.Context, msg Message) error {
+	if err := b.Check(&msg); err != nil {
+		return err
+	}
+
+	return ErrNotHandled
+}
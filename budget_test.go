@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestByteBudget(t *testing.T) {
+	base := Message{
+		Type:        SimpleEventMessageType,
+		Source:      "mac:112233445566",
+		Destination: "event:device-status",
+	}
+	size := base.EncodedSizeEstimate(Msgpack)
+
+	withPayload := base
+	withPayload.Payload = make([]byte, 100)
+
+	withMetadata := base
+	withMetadata.Metadata = map[string]string{"/key": strings.Repeat("v", 100)}
+	withMetadata.Payload = []byte("x")
+
+	withHeaders := base
+	withHeaders.Headers = []string{strings.Repeat("h", 200)}
+	withHeaders.Metadata = map[string]string{"/key": strings.Repeat("v", 100)}
+
+	tests := []struct {
+		desc     string
+		msg      Message
+		expected error
+		code     ErrorCode
+		field    string
+	}{
+		{
+			desc:     "within the budget",
+			msg:      base,
+			expected: ErrNotHandled,
+		}, {
+			desc:     "payload overage",
+			msg:      withPayload,
+			expected: ErrPayloadTooLarge,
+			code:     CodePayloadTooLarge,
+			field:    "Payload",
+		}, {
+			desc:     "metadata overage",
+			msg:      withMetadata,
+			expected: ErrMessageTooLarge,
+			code:     CodeMessageTooLarge,
+			field:    "Metadata",
+		}, {
+			desc:     "headers overage",
+			msg:      withHeaders,
+			expected: ErrMessageTooLarge,
+			code:     CodeMessageTooLarge,
+			field:    "Headers",
+		},
+	}
+
+	b, err := NewByteBudget(size + 50)
+	require.NoError(t, err)
+	assert.Equal(t, size+50, b.Max())
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := b.ProcessWRP(context.Background(), tc.msg)
+			assert.ErrorIs(t, err, tc.expected)
+			assert.Equal(t, tc.code, ErrorCodeOf(err))
+
+			var e *Error
+			if errors.As(err, &e) {
+				assert.ErrorIs(t, err, ErrMessageTooLarge)
+				assert.Equal(t, tc.field, e.Field)
+			} else {
+				assert.Empty(t, tc.field)
+			}
+		})
+	}
+
+	// the message must not be larger than the budget
+	b, err = NewByteBudget(size)
+	require.NoError(t, err)
+	assert.NoError(t, b.Check(&base))
+
+	b, err = NewByteBudget(size - 1)
+	require.NoError(t, err)
+	assert.ErrorIs(t, b.Check(&base), ErrMessageTooLarge)
+}
+
+func TestNewByteBudget_invalid(t *testing.T) {
+	b, err := NewByteBudget(0)
+	assert.Nil(t, b)
+	assert.ErrorIs(t, err, ErrInvalidBudget)
+}
@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import "context"
+
+// CanonicalizeLocators returns a Modifier that rewrites the Source and
+// Destination of each message into canonical form, see Locator.Canonical.
+// The scheme is lowercased, MAC addresses are lowercased with separators
+// removed, and whitespace is trimmed from each part, so that downstream string
+// comparisons of locators are reliable.
+//
+// Locators that cannot be parsed are left as is; combine this with
+// ValidateSource or ValidateDestination to reject them.
+//
+// If onChange is not nil, it is called each time a field is rewritten with the
+// name of the field, i.e. "source" or "dest", and its values before and after.
+func CanonicalizeLocators(onChange func(field, before, after string)) Modifier {
+	return ModifierFunc(func(_ context.Context, msg Message) (Message, error) {
+		msg.Source = canonicalLocator("source", msg.Source, onChange)
+		msg.Destination = canonicalLocator("dest", msg.Destination, onChange)
+		return msg, nil
+	})
+}
+
+func canonicalLocator(field, s string, onChange func(field, before, after string)) string {
+	l, err := ParseLocator(s)
+	if err != nil {
+		return s
+	}
+
+	c := l.Canonical()
+	if c != s && onChange != nil {
+		onChange(field, s, c)
+	}
+
+	return c
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalizeLocators(t *testing.T) {
+	tests := []struct {
+		desc     string
+		msg      Message
+		expected Message
+		changed  []string
+	}{
+		{
+			desc:     "already canonical",
+			msg:      Message{Source: "mac:112233445566", Destination: "event:device-status"},
+			expected: Message{Source: "mac:112233445566", Destination: "event:device-status"},
+		}, {
+			desc:     "both rewritten",
+			msg:      Message{Source: "Mac:11-22-33-44-55-66/config", Destination: "EVENT: online "},
+			expected: Message{Source: "mac:112233445566/config", Destination: "event:online"},
+			changed:  []string{"source", "dest"},
+		}, {
+			desc:     "invalid locators are left alone",
+			msg:      Message{Source: "invalid", Destination: "Mac:11-22-33-44-55-66"},
+			expected: Message{Source: "invalid", Destination: "mac:112233445566"},
+			changed:  []string{"dest"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var changed []string
+			m := CanonicalizeLocators(func(field, before, after string) {
+				changed = append(changed, field)
+				assert.NotEqual(before, after)
+			})
+
+			got, err := m.ModifyWRP(context.Background(), tc.msg)
+			assert.NoError(err)
+			assert.Equal(tc.expected, got)
+			assert.Equal(tc.changed, changed)
+		})
+	}
+
+	got, err := CanonicalizeLocators(nil).ModifyWRP(context.Background(), Message{Source: "MAC:112233445566"})
+	assert.NoError(t, err)
+	assert.Equal(t, "mac:112233445566", got.Source)
+}
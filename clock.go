@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Clock is the source of the current time for components whose behavior
+// depends on it, such as rate limiting and expiry checks.  Tests can replace
+// it with a fake clock, e.g. a *wrptest.FakeClock, to make that behavior
+// deterministic.
+//
+// A Clock only supplies timestamps.  Waiting is always done in real time, so
+// timers and tickers, such as those that pace keepalives, hedge requests,
+// flush audit and metric batches and poll for asynchronous results, are not
+// affected by a Clock.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc is a function that implements Clock.
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock is the Clock that returns the real time.
+var SystemClock Clock = ClockFunc(time.Now)
+
+// IDGenerator is the source of new identifiers, such as the transaction UUIDs
+// generated for messages that do not have one.  Tests can replace it with a
+// fake generator, e.g. a *wrptest.SequentialIDs, to make the identifiers
+// predictable.
+type IDGenerator interface {
+	NewID() (string, error)
+}
+
+// IDGeneratorFunc is a function that implements IDGenerator.
+type IDGeneratorFunc func() (string, error)
+
+func (f IDGeneratorFunc) NewID() (string, error) {
+	return f()
+}
+
+// UUIDGenerator is the IDGenerator that returns random (version 4) UUIDs.
+var UUIDGenerator IDGenerator = IDGeneratorFunc(func() (string, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+})
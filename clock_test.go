@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errUnknown = errors.New("unknown error")
+
+func TestSystemClock(t *testing.T) {
+	before := time.Now()
+	now := SystemClock.Now()
+	assert.False(t, now.Before(before))
+	assert.False(t, now.After(time.Now()))
+}
+
+func TestUUIDGenerator(t *testing.T) {
+	require := require.New(t)
+
+	first, err := UUIDGenerator.NewID()
+	require.NoError(err)
+	second, err := UUIDGenerator.NewID()
+	require.NoError(err)
+
+	assert.NotEqual(t, first, second)
+	for _, id := range []string{first, second} {
+		u, err := uuid.Parse(id)
+		require.NoError(err)
+		assert.Equal(t, uuid.Version(4), u.Version())
+	}
+}
@@ -0,0 +1,389 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+// wrpgen generates the MsgType, FromMessage, ToMessage and Validate methods
+// that make a struct a wrp.Union, a view holding only the Message fields a service uses.
+//
+// Each field of the view that is part of the message is tagged with the wire
+// name of a Message field, along with the required option if Validate must
+// reject the zero value:
+//
+//	type Reboot struct {
+//		Source  string `wrp:"source,required"`
+//		Reason  string `wrp:"path"`
+//		Payload []byte `wrp:"payload"`
+//	}
+//
+// The type of the field must be the type of the Message field.  A field
+// tagged msg_type holds the type of the message; otherwise the view always
+// has the first of its message types.
+//
+// Usage, typically from a go:generate directive in the file of the view:
+//
+//	go run github.com/xmidt-org/wrp-go/v3/cmd/wrpgen -type Reboot -msgtype SimpleEvent
+//
+// The -msgtype flag takes a comma separated list of message types, e.g.
+// Create,Update.  The output defaults to <type>_wrp.go, in lower case, next to
+// the input file.
+//
+// Generated views are used like the message type specific structs of the wrp
+// package, e.g. with wrp.As and wrp.Is:
+//
+//	reboot, err := wrp.As[Reboot](msg)
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+const wrpImportPath = "github.com/xmidt-org/wrp-go/v3"
+
+// deprecated are the deprecated Message fields, whose uses need a nolint
+// directive.
+var deprecated = map[string]bool{
+	"Spans":        true,
+	"IncludeSpans": true,
+}
+
+type view struct {
+	pkg       string
+	name      string
+	wrp       string // the local name of the wrp package
+	imported  bool   // whether the input file imports the wrp package
+	msgTypes  []wrp.MessageType
+	typeField string // the field tagged msg_type, if any
+	fields    []viewField
+}
+
+type viewField struct {
+	name     string // the name of the field of the view
+	message  string // the name of the Message field
+	typ      string // the Go type of the Message field
+	zero     string // the condition that the field is not set, with %s for the field
+	required bool
+}
+
+func main() {
+	input := flag.String("i", os.Getenv("GOFILE"), "the file containing the view struct")
+	typeName := flag.String("type", "", "the name of the view struct")
+	msgTypes := flag.String("msgtype", "", "the comma separated message types of the view")
+	output := flag.String("o", "", "the generated file, <type>_wrp.go by default")
+	flag.Parse()
+
+	if *output == "" {
+		*output = filepath.Join(filepath.Dir(*input), strings.ToLower(*typeName)+"_wrp.go")
+	}
+
+	src, err := run(*input, *typeName, *msgTypes)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "wrpgen:", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(*output, src, 0644); err != nil { // nolint:gosec
+		fmt.Fprintln(os.Stderr, "wrpgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(filename, typeName, msgTypes string) ([]byte, error) {
+	types, err := parseMessageTypes(msgTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := parseView(filename, typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	v.msgTypes = types
+	return generate(v)
+}
+
+// parseMessageTypes parses a comma separated list of message types, in any
+// of the forms accepted by wrp.StringToMessageType.
+func parseMessageTypes(list string) ([]wrp.MessageType, error) {
+	if list == "" {
+		return nil, fmt.Errorf("no message types, use -msgtype")
+	}
+
+	var types []wrp.MessageType
+	for _, s := range strings.Split(list, ",") {
+		mt := wrp.StringToMessageType(strings.TrimSpace(s))
+		if mt <= wrp.Invalid1MessageType || mt >= wrp.LastMessageType {
+			return nil, fmt.Errorf("invalid message type `%s`", s)
+		}
+		types = append(types, mt)
+	}
+
+	return types, nil
+}
+
+// parseView returns the tagged fields of the view struct in the file.
+func parseView(filename, typeName string) (*view, error) {
+	if typeName == "" {
+		return nil, fmt.Errorf("no type, use -type")
+	}
+
+	f, err := parser.ParseFile(token.NewFileSet(), filename, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	v := view{
+		pkg:  f.Name.Name,
+		name: typeName,
+		wrp:  "wrp",
+	}
+
+	for _, imp := range f.Imports {
+		if path, _ := strconv.Unquote(imp.Path.Value); path == wrpImportPath {
+			v.imported = true
+			if imp.Name != nil {
+				v.wrp = imp.Name.Name
+			}
+		}
+	}
+
+	obj := f.Scope.Lookup(typeName)
+	if obj == nil || obj.Kind != ast.Typ {
+		return nil, fmt.Errorf("no %s type in %s", typeName, filename)
+	}
+
+	st, ok := obj.Decl.(*ast.TypeSpec).Type.(*ast.StructType)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a struct in %s", typeName, filename)
+	}
+
+	schema, err := wrp.WireSchemaOf(wrp.Message{})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, field := range st.Fields.List {
+		if field.Tag == nil {
+			continue
+		}
+
+		tag, _ := strconv.Unquote(field.Tag.Value)
+		name, options, _ := strings.Cut(reflect.StructTag(tag).Get("wrp"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		if len(field.Names) != 1 {
+			return nil, fmt.Errorf("%s: tagged fields must have a single name", name)
+		}
+
+		vf, err := messageField(&schema, v.wrp, name, options)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", typeName, field.Names[0].Name, err)
+		}
+
+		if seen[name] {
+			return nil, fmt.Errorf("%s.%s: %s is tagged more than once", typeName, field.Names[0].Name, name)
+		}
+		seen[name] = true
+
+		if actual := exprString(field.Type); actual != vf.typ {
+			return nil, fmt.Errorf("%s.%s: the type of %s is %s, not %s", typeName, field.Names[0].Name, name, vf.typ, actual)
+		}
+
+		vf.name = field.Names[0].Name
+		if vf.message == "Type" {
+			v.typeField = vf.name
+		}
+
+		v.fields = append(v.fields, vf)
+	}
+
+	if len(v.fields) > 0 && !v.imported {
+		return nil, fmt.Errorf("%s does not import %s", filename, wrpImportPath)
+	}
+
+	return &v, nil
+}
+
+// messageField returns the Message field with the wire name, with the types
+// of the wrp package qualified with pkg.
+func messageField(schema *wrp.WireSchema, pkg, name, options string) (viewField, error) {
+	var vf viewField
+	for _, o := range strings.Split(options, ",") {
+		switch o {
+		case "":
+		case "required":
+			vf.required = true
+		default:
+			return vf, fmt.Errorf("unknown option `%s`", o)
+		}
+	}
+
+	for _, wf := range schema.Fields {
+		if wf.Name == name {
+			sf, _ := reflect.TypeOf(wrp.Message{}).FieldByName(wf.GoName)
+			vf.message = wf.GoName
+			vf.typ = goType(sf.Type, pkg)
+			vf.zero = zeroCondition(sf.Type)
+			return vf, nil
+		}
+	}
+
+	return vf, fmt.Errorf("unknown WRP field `%s`", name)
+}
+
+// goType returns the Go source of the type, qualifying the types of the wrp
+// package with pkg.
+func goType(t reflect.Type, pkg string) string {
+	switch {
+	case t.Name() != "" && t.PkgPath() != "":
+		return pkg + "." + t.Name()
+	case t.Name() != "":
+		return t.Name()
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return "*" + goType(t.Elem(), pkg)
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "[]byte"
+		}
+		return "[]" + goType(t.Elem(), pkg)
+	case reflect.Map:
+		return "map[" + goType(t.Key(), pkg) + "]" + goType(t.Elem(), pkg)
+	}
+
+	return t.String()
+}
+
+// zeroCondition returns the condition that a field of the type is not set.
+func zeroCondition(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return "%s == nil"
+	case reflect.Slice, reflect.Map:
+		return "len(%s) == 0"
+	case reflect.String:
+		return `%s == ""`
+	}
+
+	return "%s == 0"
+}
+
+func exprString(e ast.Expr) string {
+	var buf bytes.Buffer
+	_ = format.Node(&buf, token.NewFileSet(), e)
+	return buf.String()
+}
+
+func generate(v *view) ([]byte, error) {
+	var buf bytes.Buffer
+	p := func(format string, args ...any) {
+		fmt.Fprintf(&buf, format, args...)
+	}
+
+	q := v.wrp
+	types := make([]string, len(v.msgTypes))
+	for i, mt := range v.msgTypes {
+		types[i] = q + "." + mt.String()
+	}
+
+	p("// Code generated by wrpgen; DO NOT EDIT.\n\n")
+	p("package %s\n\n", v.pkg)
+	if q == "wrp" {
+		p("import %q\n\n", wrpImportPath)
+	} else {
+		p("import %s %q\n\n", q, wrpImportPath)
+	}
+
+	p("var _ %s.Union = (*%s)(nil)\n\n", q, v.name)
+
+	p("// MsgType returns the message type of the view.\n")
+	p("func (v *%s) MsgType() %s.MessageType {\n", v.name, q)
+	if v.typeField != "" {
+		p("\tif v.%s != 0 {\n\t\treturn v.%s\n\t}\n\n", v.typeField, v.typeField)
+	}
+	p("\treturn %s\n", types[0])
+	p("}\n\n")
+
+	p("// FromMessage fills in the view from the message and validates it.\n")
+	p("func (v *%s) FromMessage(msg *%s.Message) error {\n", v.name, q)
+	p("\tif err := %s.CheckUnionType(msg.Type, %s); err != nil {\n", q, strings.Join(types, ", "))
+	p("\t\treturn err\n")
+	p("\t}\n\n")
+	for _, f := range v.fields {
+		p("\tv.%s = msg.%s%s\n", f.name, f.message, nolint(f))
+	}
+	p("\n\treturn v.Validate()\n")
+	p("}\n\n")
+
+	p("// ToMessage validates the view and replaces the message with its fields.\n")
+	p("func (v *%s) ToMessage(msg *%s.Message) error {\n", v.name, q)
+	p("\tif err := v.Validate(); err != nil {\n")
+	p("\t\treturn err\n")
+	p("\t}\n\n")
+	p("\t*msg = %s.Message{\n", q)
+	p("\t\tType: v.MsgType(),\n")
+	for _, f := range v.fields {
+		if f.message != "Type" {
+			p("\t\t%s: v.%s,%s\n", f.message, f.name, nolint(f))
+		}
+	}
+	p("\t}\n\n")
+	p("\treturn nil\n")
+	p("}\n\n")
+
+	p("// Validate checks the message type and the required fields of the view.\n")
+	p("func (v *%s) Validate() error {\n", v.name)
+	if v.typeField != "" {
+		p("\tif err := %s.CheckUnionType(v.MsgType(), %s); err != nil {\n", q, strings.Join(types, ", "))
+		p("\t\treturn err\n")
+		p("\t}\n\n")
+	}
+
+	var required bool
+	for _, f := range v.fields {
+		if !f.required {
+			continue
+		}
+		if !required {
+			p("\tvar missing []string\n")
+			required = true
+		}
+		p("\tif "+f.zero+" {\n", "v."+f.name)
+		p("\t\tmissing = append(missing, %q)\n", f.message)
+		p("\t}\n")
+	}
+
+	if required {
+		p("\n\treturn %s.CheckUnionFields(v.MsgType(), missing...)\n", q)
+	} else {
+		p("\treturn nil\n")
+	}
+	p("}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+func nolint(f viewField) string {
+	if deprecated[f.message] {
+		return " // nolint:staticcheck"
+	}
+
+	return ""
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerated checks that the generated views of internal/unionexample are
+// up to date.
+func TestGenerated(t *testing.T) {
+	tests := []struct {
+		typeName string
+		msgTypes string
+		output   string
+	}{
+		{typeName: "Reboot", msgTypes: "SimpleEvent", output: "reboot_wrp.go"},
+		{typeName: "Config", msgTypes: "Create,Update", output: "config_wrp.go"},
+	}
+
+	dir := filepath.Join("..", "..", "internal", "unionexample")
+	for _, tc := range tests {
+		t.Run(tc.typeName, func(t *testing.T) {
+			expected, err := os.ReadFile(filepath.Join(dir, tc.output))
+			require.NoError(t, err)
+
+			actual, err := run(filepath.Join(dir, "view.go"), tc.typeName, tc.msgTypes)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(actual), "run go generate ./internal/unionexample")
+		})
+	}
+}
+
+func TestRun_alias(t *testing.T) {
+	src, err := run(writeView(t, `package views
+
+import w "github.com/xmidt-org/wrp-go/v3"
+
+type View struct {
+	Type   w.MessageType `+"`wrp:\"msg_type\"`"+`
+	Status *int64        `+"`wrp:\"status,required\"`"+`
+}
+`), "View", "4")
+	require.NoError(t, err)
+	assert.Contains(t, string(src), `import w "github.com/xmidt-org/wrp-go/v3"`)
+	assert.Contains(t, string(src), "return w.SimpleEventMessageType")
+	assert.Contains(t, string(src), "if v.Status == nil {")
+}
+
+func TestRun_invalid(t *testing.T) {
+	tests := []struct {
+		desc     string
+		src      string
+		typeName string
+		msgTypes string
+	}{
+		{
+			desc:     "no message types",
+			src:      "package views\n\ntype View struct{}\n",
+			typeName: "View",
+		}, {
+			desc:     "invalid message type",
+			src:      "package views\n\ntype View struct{}\n",
+			typeName: "View",
+			msgTypes: "SimpleEvent,Bogus",
+		}, {
+			desc:     "no type",
+			src:      "package views\n\ntype View struct{}\n",
+			msgTypes: "SimpleEvent",
+		}, {
+			desc:     "missing type",
+			src:      "package views\n\ntype View struct{}\n",
+			typeName: "Other",
+			msgTypes: "SimpleEvent",
+		}, {
+			desc:     "not a struct",
+			src:      "package views\n\ntype View int\n",
+			typeName: "View",
+			msgTypes: "SimpleEvent",
+		}, {
+			desc:     "unknown field",
+			src:      "package views\n\ntype View struct {\n\tX string `wrp:\"bogus\"`\n}\n",
+			typeName: "View",
+			msgTypes: "SimpleEvent",
+		}, {
+			desc:     "unknown option",
+			src:      "package views\n\ntype View struct {\n\tX string `wrp:\"source,optional\"`\n}\n",
+			typeName: "View",
+			msgTypes: "SimpleEvent",
+		}, {
+			desc:     "wrong type",
+			src:      "package views\n\ntype View struct {\n\tX []byte `wrp:\"source\"`\n}\n",
+			typeName: "View",
+			msgTypes: "SimpleEvent",
+		}, {
+			desc:     "tagged twice",
+			src:      "package views\n\ntype View struct {\n\tX string `wrp:\"source\"`\n\tY string `wrp:\"source\"`\n}\n",
+			typeName: "View",
+			msgTypes: "SimpleEvent",
+		}, {
+			desc:     "several names",
+			src:      "package views\n\ntype View struct {\n\tX, Y string `wrp:\"source\"`\n}\n",
+			typeName: "View",
+			msgTypes: "SimpleEvent",
+		}, {
+			desc:     "no wrp import",
+			src:      "package views\n\ntype View struct {\n\tX string `wrp:\"source\"`\n}\n",
+			typeName: "View",
+			msgTypes: "SimpleEvent",
+		}, {
+			desc:     "syntax error",
+			src:      "package views\n\ntype View struct {\n",
+			typeName: "View",
+			msgTypes: "SimpleEvent",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			src, err := run(writeView(t, tc.src), tc.typeName, tc.msgTypes)
+			assert.Error(t, err)
+			assert.Nil(t, src)
+		})
+	}
+}
+
+func writeView(t *testing.T, src string) string {
+	filename := filepath.Join(t.TempDir(), "view.go")
+	require.NoError(t, os.WriteFile(filename, []byte(src), 0600))
+	return filename
+}
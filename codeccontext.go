@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"time"
+)
+
+// contextChunkSize is the most that is read or written between checks of the
+// context, so that a cancellation interrupts the copying of a large payload.
+const contextChunkSize = 64 * 1024
+
+// contextReader fails reads once its context is done.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := contextDone(cr.ctx); err != nil {
+		return 0, err
+	}
+
+	if len(p) > contextChunkSize {
+		p = p[:contextChunkSize]
+	}
+
+	return cr.r.Read(p)
+}
+
+// contextWriter fails writes once its context is done.
+type contextWriter struct {
+	ctx context.Context
+	w   io.Writer
+}
+
+func (cw *contextWriter) Write(p []byte) (n int, err error) {
+	for len(p) > 0 && err == nil {
+		if err = contextDone(cw.ctx); err != nil {
+			break
+		}
+
+		chunk := p
+		if len(chunk) > contextChunkSize {
+			chunk = chunk[:contextChunkSize]
+		}
+
+		var written int
+		written, err = cw.w.Write(chunk)
+		n += written
+		p = p[written:]
+	}
+
+	return n, err
+}
+
+// writeDeadliner is implemented by writers whose writes can be bounded by a
+// deadline, e.g. a net.Conn.
+type writeDeadliner interface {
+	SetWriteDeadline(time.Time) error
+}
+
+// boundWrites sets the context's deadline as the write deadline of w, if w is
+// a writeDeadliner, and interrupts blocked writes if the context is canceled.
+// The returned function clears the write deadline.  Other writers are only
+// interrupted between writes.
+func boundWrites(ctx context.Context, w io.Writer) func() {
+	wd, ok := w.(writeDeadliner)
+	if !ok {
+		return func() {}
+	}
+
+	deadline, _ := ctx.Deadline()
+	_ = wd.SetWriteDeadline(deadline)
+	stop := context.AfterFunc(ctx, func() {
+		// any time in the past interrupts a blocked write
+		_ = wd.SetWriteDeadline(time.Unix(1, 0))
+	})
+
+	return func() {
+		stop()
+		_ = wd.SetWriteDeadline(time.Time{})
+	}
+}
+
+// contextDone is a cheaper ctx.Err() for the common case where the context
+// is not done.
+func contextDone(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		return nil
+	}
+}
+
+// contextErr returns the context's error in preference to err, since the
+// codec does not preserve the error returned by the reader or writer.
+func contextErr(ctx context.Context, err error) error {
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			// the connection's deadline may pass just before the context's
+			if d, ok := ctx.Deadline(); ok && !time.Now().Before(d) {
+				return context.DeadlineExceeded
+			}
+		}
+	}
+
+	return err
+}
+
+// EncodeContext encodes the value to the output in the given format.  If the
+// context is cancelled or times out before the encoding is complete, the
+// encoding stops and the context's error, e.g. context.Canceled, is returned.
+// In that case, some of the value may already have been written to the output.
+//
+// If the output has a SetWriteDeadline method, as a net.Conn does, a write
+// blocked by a slow peer is also interrupted: the context's deadline is the
+// write deadline during the encoding, and cancelling the context expires it.
+// The write deadline is cleared before EncodeContext returns.
+func EncodeContext(ctx context.Context, output io.Writer, f Format, value interface{}) error {
+	if err := contextDone(ctx); err != nil {
+		return err
+	}
+
+	defer boundWrites(ctx, output)()
+	return contextErr(ctx, NewEncoder(&contextWriter{ctx: ctx, w: output}, f).Encode(value))
+}
+
+// EncodeBytesContext is like EncodeContext, except that the value is encoded
+// to a new []byte, which is sized with EncodedSizeHint as the buffers of
+// NewEncoderBytes are.
+func EncodeBytesContext(ctx context.Context, f Format, value interface{}) ([]byte, error) {
+	output := bytes.NewBuffer(make([]byte, 0, EncodedSizeHint(value, f)))
+	if err := EncodeContext(ctx, output, f, value); err != nil {
+		return nil, err
+	}
+
+	return output.Bytes(), nil
+}
+
+// DecodeContext decodes the value from the input in the given format.  If the
+// context is cancelled or times out before the decoding is complete, the
+// decoding stops and the context's error, e.g. context.Canceled, is returned.
+func DecodeContext(ctx context.Context, input io.Reader, f Format, value interface{}) error {
+	if err := contextDone(ctx); err != nil {
+		return err
+	}
+
+	return contextErr(ctx, NewDecoder(&contextReader{ctx: ctx, r: input}, f).Decode(value))
+}
+
+// DecodeBytesContext is like DecodeContext, except that the value is decoded
+// from a []byte.
+func DecodeBytesContext(ctx context.Context, input []byte, f Format, value interface{}) error {
+	if ctx.Done() == nil {
+		// the context can never be cancelled, so use the faster bytes decoder
+		return NewDecoderBytes(input, f).Decode(value)
+	}
+
+	return DecodeContext(ctx, bytes.NewReader(input), f, value)
+}
@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cancelAfter calls cancel after n reads or writes.
+type cancelAfter struct {
+	r      io.Reader
+	w      io.Writer
+	n      int
+	cancel context.CancelFunc
+	calls  int
+}
+
+func (c *cancelAfter) count() {
+	c.calls++
+	if c.calls == c.n {
+		c.cancel()
+	}
+}
+
+func (c *cancelAfter) Read(p []byte) (int, error) {
+	c.count()
+	return c.r.Read(p)
+}
+
+func (c *cancelAfter) Write(p []byte) (int, error) {
+	c.count()
+	return c.w.Write(p)
+}
+
+func testLargeMessage() *Message {
+	return &Message{
+		Type:        SimpleEventMessageType,
+		Source:      "dns:example.com",
+		Destination: "event:large",
+		Payload:     bytes.Repeat([]byte("x"), 10*contextChunkSize),
+	}
+}
+
+func TestEncodeDecodeContext(t *testing.T) {
+	for _, f := range AllFormats() {
+		t.Run(f.String(), func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			msg := testLargeMessage()
+			encoded, err := EncodeBytesContext(ctx, f, msg)
+			require.NoError(err)
+			assert.Equal(MustEncode(msg, f), encoded)
+
+			var out bytes.Buffer
+			require.NoError(EncodeContext(ctx, &out, f, msg))
+			assert.Equal(encoded, out.Bytes())
+
+			var decoded Message
+			require.NoError(DecodeBytesContext(ctx, encoded, f, &decoded))
+			assert.Equal(*msg, decoded)
+
+			decoded = Message{}
+			require.NoError(DecodeBytesContext(context.Background(), encoded, f, &decoded))
+			assert.Equal(*msg, decoded)
+		})
+	}
+}
+
+func TestEncodeDecodeContext_cancelled(t *testing.T) {
+	for _, f := range AllFormats() {
+		t.Run(f.String(), func(t *testing.T) {
+			assert := assert.New(t)
+			msg := testLargeMessage()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			_, err := EncodeBytesContext(ctx, f, msg)
+			assert.ErrorIs(err, context.Canceled)
+			assert.ErrorIs(DecodeBytesContext(ctx, MustEncode(msg, f), f, new(Message)), context.Canceled)
+		})
+
+		t.Run(f.String()+"/during encode", func(t *testing.T) {
+			assert := assert.New(t)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var out bytes.Buffer
+			w := &cancelAfter{w: &out, n: 2, cancel: cancel}
+			err := EncodeContext(ctx, w, f, testLargeMessage())
+			assert.ErrorIs(err, context.Canceled)
+			assert.Less(out.Len(), len(testLargeMessage().Payload))
+		})
+
+		t.Run(f.String()+"/during decode", func(t *testing.T) {
+			assert := assert.New(t)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			input := bytes.NewReader(MustEncode(testLargeMessage(), f))
+			r := &cancelAfter{r: input, n: 2, cancel: cancel}
+			err := DecodeContext(ctx, r, f, new(Message))
+			assert.ErrorIs(err, context.Canceled)
+			assert.Positive(input.Len())
+		})
+	}
+}
+
+func TestEncodeBytesContext_sized(t *testing.T) {
+	msg := testLargeMessage()
+	for _, f := range AllFormats() {
+		t.Run(f.String(), func(t *testing.T) {
+			encoded, err := EncodeBytesContext(context.Background(), f, msg)
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, cap(encoded), EncodedSizeHint(msg, f))
+			assert.LessOrEqual(t, cap(encoded), 2*len(encoded), "the buffer must not have been grown")
+		})
+	}
+}
+
+func TestEncodeContext_conn(t *testing.T) {
+	msg := testLargeMessage()
+
+	t.Run("deadline", func(t *testing.T) {
+		// nothing reads from the other end, so the writes block
+		w, r := net.Pipe()
+		defer w.Close()
+		defer r.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		assert.ErrorIs(t, EncodeContext(ctx, w, Msgpack, msg), context.DeadlineExceeded)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		w, r := net.Pipe()
+		defer w.Close()
+		defer r.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		assert.ErrorIs(t, EncodeContext(ctx, w, Msgpack, msg), context.Canceled)
+	})
+
+	t.Run("written", func(t *testing.T) {
+		w, r := net.Pipe()
+		defer w.Close()
+		defer r.Close()
+
+		expected := MustEncode(msg, Msgpack)
+		read := make(chan []byte)
+		go func() {
+			b, _ := io.ReadAll(io.LimitReader(r, int64(len(expected)+1)))
+			read <- b
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		require.NoError(t, EncodeContext(ctx, w, Msgpack, msg))
+
+		// the write deadline is cleared, so the connection outlives the
+		// context
+		cancel()
+		_, err := w.Write([]byte{0xc0})
+		require.NoError(t, err)
+		assert.Equal(t, append(expected, 0xc0), <-read)
+	})
+}
+
+func TestDecodeContext_deadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	err := DecodeContext(ctx, bytes.NewReader(MustEncode(testLargeMessage(), Msgpack)), Msgpack, new(Message))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestDecodeContext_error(t *testing.T) {
+	err := DecodeContext(context.Background(), bytes.NewReader([]byte{0xc1}), Msgpack, new(Message))
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, context.Canceled))
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import "context"
+
+type (
+	messageContextKey    struct{}
+	deviceIDContextKey   struct{}
+	partnerIDsContextKey struct{}
+)
+
+// ContextWithMessage returns a context that carries the message.  Every
+// transport in this module adds the message it receives to the request
+// context, so middleware can use MessageFromContext regardless of transport.
+func ContextWithMessage(ctx context.Context, msg *Message) context.Context {
+	return context.WithValue(ctx, messageContextKey{}, msg)
+}
+
+// MessageFromContext returns the message carried by the context, if any.
+func MessageFromContext(ctx context.Context) (*Message, bool) {
+	msg, ok := ctx.Value(messageContextKey{}).(*Message)
+	return msg, ok && msg != nil
+}
+
+// ContextWithDeviceID returns a context that carries the device ID.  This is
+// used when the transport knows the device independently of the message, e.g.
+// from an authenticated connection.
+func ContextWithDeviceID(ctx context.Context, id DeviceID) context.Context {
+	return context.WithValue(ctx, deviceIDContextKey{}, id)
+}
+
+// DeviceIDFromContext returns the device ID associated with the context.  A
+// device ID added with ContextWithDeviceID takes precedence.  Otherwise, the
+// device ID is the first of the message's Source or Destination locators that
+// contains a device ID, excluding `self:` locators.
+func DeviceIDFromContext(ctx context.Context) (DeviceID, bool) {
+	if id, ok := ctx.Value(deviceIDContextKey{}).(DeviceID); ok {
+		return id, true
+	}
+
+	if msg, ok := MessageFromContext(ctx); ok {
+		return messageDeviceID(msg)
+	}
+
+	return "", false
+}
+
+// ContextWithPartnerIDs returns a context that carries the partner IDs.  This
+// is used when the transport knows the partners independently of the message,
+// e.g. from the claims of a token.
+func ContextWithPartnerIDs(ctx context.Context, ids []string) context.Context {
+	return context.WithValue(ctx, partnerIDsContextKey{}, ids)
+}
+
+// PartnerIDsFromContext returns the partner IDs associated with the context.
+// Partner IDs added with ContextWithPartnerIDs take precedence over the
+// trimmed PartnerIDs of the message.
+func PartnerIDsFromContext(ctx context.Context) ([]string, bool) {
+	if ids, ok := ctx.Value(partnerIDsContextKey{}).([]string); ok {
+		return ids, true
+	}
+
+	if msg, ok := MessageFromContext(ctx); ok {
+		ids := msg.TrimmedPartnerIDs()
+		return ids, len(ids) > 0
+	}
+
+	return nil, false
+}
+
+// messageDeviceID returns the first device ID in the message's Source or
+// Destination, excluding `self:` locators.
+func messageDeviceID(msg *Message) (DeviceID, bool) {
+	for _, s := range []string{msg.Source, msg.Destination} {
+		l, err := ParseLocator(s)
+		if err != nil || !l.HasDeviceID() || l.IsSelf() {
+			continue
+		}
+		return l.ID, true
+	}
+
+	return "", false
+}
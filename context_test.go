@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageFromContext(t *testing.T) {
+	assert := assert.New(t)
+
+	msg, ok := MessageFromContext(context.Background())
+	assert.False(ok)
+	assert.Nil(msg)
+
+	msg, ok = MessageFromContext(ContextWithMessage(context.Background(), nil))
+	assert.False(ok)
+	assert.Nil(msg)
+
+	expected := &Message{Type: SimpleEventMessageType}
+	msg, ok = MessageFromContext(ContextWithMessage(context.Background(), expected))
+	assert.True(ok)
+	assert.Same(expected, msg)
+}
+
+func TestDeviceIDFromContext(t *testing.T) {
+	tests := []struct {
+		desc     string
+		ctx      context.Context
+		expected DeviceID
+	}{
+		{
+			desc: "empty",
+			ctx:  context.Background(),
+		}, {
+			desc: "source",
+			ctx: ContextWithMessage(context.Background(), &Message{
+				Source:      "mac:112233445566/service",
+				Destination: "mac:aabbccddeeff/service",
+			}),
+			expected: "mac:112233445566",
+		}, {
+			desc: "destination",
+			ctx: ContextWithMessage(context.Background(), &Message{
+				Source:      "dns:example.com",
+				Destination: "mac:aabbccddeeff/service",
+			}),
+			expected: "mac:aabbccddeeff",
+		}, {
+			desc: "self is skipped",
+			ctx: ContextWithMessage(context.Background(), &Message{
+				Source:      "self:/service",
+				Destination: "event:device-status",
+			}),
+		}, {
+			desc: "explicit device ID wins",
+			ctx: ContextWithDeviceID(
+				ContextWithMessage(context.Background(), &Message{Source: "mac:112233445566"}),
+				"uuid:1234",
+			),
+			expected: "uuid:1234",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			id, ok := DeviceIDFromContext(tc.ctx)
+			assert.Equal(t, tc.expected, id)
+			assert.Equal(t, tc.expected != "", ok)
+		})
+	}
+}
+
+func TestPartnerIDsFromContext(t *testing.T) {
+	tests := []struct {
+		desc     string
+		ctx      context.Context
+		expected []string
+		ok       bool
+	}{
+		{
+			desc: "empty",
+			ctx:  context.Background(),
+		}, {
+			desc:     "no partners",
+			ctx:      ContextWithMessage(context.Background(), &Message{PartnerIDs: []string{""}}),
+			expected: []string{},
+		}, {
+			desc:     "message",
+			ctx:      ContextWithMessage(context.Background(), &Message{PartnerIDs: []string{"", "comcast"}}),
+			expected: []string{"comcast"},
+			ok:       true,
+		}, {
+			desc: "explicit partners win",
+			ctx: ContextWithPartnerIDs(
+				ContextWithMessage(context.Background(), &Message{PartnerIDs: []string{"comcast"}}),
+				[]string{"sky"},
+			),
+			expected: []string{"sky"},
+			ok:       true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ids, ok := PartnerIDsFromContext(tc.ctx)
+			assert.Equal(t, tc.expected, ids)
+			assert.Equal(t, tc.ok, ok)
+		})
+	}
+}
@@ -0,0 +1,364 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrMessageTypeMismatch = errors.New("message type does not match the target type")
+
+// Union is implemented by the structs a Message can be converted to and from:
+// the message type specific structs of this package, e.g. SimpleEvent and
+// CRUD, and message views, structs that hold only the fields of a Message that
+// a service uses.  The methods of a view are usually generated by cmd/wrpgen
+// from the wrp struct tags of its fields, e.g.
+//
+//	//go:generate go run github.com/xmidt-org/wrp-go/v3/cmd/wrpgen -type Reboot -msgtype SimpleEvent
+//	type Reboot struct {
+//		Source  string `wrp:"source,required"`
+//		Payload []byte `wrp:"payload"`
+//	}
+type Union interface {
+	// MsgType returns the message type of the struct.
+	MsgType() MessageType
+
+	// FromMessage fills in the struct from the message.  The message's Type
+	// must be one of the types of the struct, otherwise an error wrapping
+	// ErrMessageTypeMismatch is returned.
+	FromMessage(*Message) error
+
+	// ToMessage replaces the message with the fields of the struct.
+	ToMessage(*Message) error
+
+	// Validate checks the message type and the required fields of the
+	// struct.
+	Validate() error
+}
+
+// As allocates the Union T and fills it in from the message in one call, e.g.
+//
+//	event, err := wrp.As[wrp.SimpleEvent](msg)
+//
+// The message's Type must match T, e.g. a SimpleEvent can only be produced
+// from a message of SimpleEventMessageType, while a CRUD can be produced from
+// any of the four CRUD message types.  Otherwise, an error wrapping
+// ErrMessageTypeMismatch is returned.
+//
+// The conversion is shallow: slices, maps and pointers are shared with msg.
+// Fields of msg that T does not have are dropped.
+func As[T any, U interface {
+	*T
+	Union
+}](msg *Message) (*T, error) {
+	if msg == nil {
+		return nil, fmt.Errorf("%w: nil message", ErrMessageTypeMismatch)
+	}
+
+	var out T
+	if err := U(&out).FromMessage(msg); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// Is tests if the message can be converted to the Union T with As.
+func Is[T any, U interface {
+	*T
+	Union
+}](msg *Message) bool {
+	_, err := As[T, U](msg)
+	return err == nil
+}
+
+var (
+	_ Union = (*SimpleRequestResponse)(nil)
+	_ Union = (*SimpleEvent)(nil)
+	_ Union = (*CRUD)(nil)
+	_ Union = (*ServiceRegistration)(nil)
+	_ Union = (*ServiceAlive)(nil)
+	_ Union = (*Unknown)(nil)
+)
+
+// MsgType returns SimpleRequestResponseMessageType.
+func (msg *SimpleRequestResponse) MsgType() MessageType {
+	return SimpleRequestResponseMessageType
+}
+
+// FromMessage fills in the struct from a message of
+// SimpleRequestResponseMessageType.
+func (msg *SimpleRequestResponse) FromMessage(m *Message) error {
+	if err := CheckUnionType(m.Type, SimpleRequestResponseMessageType); err != nil {
+		return err
+	}
+
+	*msg = SimpleRequestResponse{
+		Type:                    m.Type,
+		Source:                  m.Source,
+		Destination:             m.Destination,
+		ContentType:             m.ContentType,
+		Accept:                  m.Accept,
+		TransactionUUID:         m.TransactionUUID,
+		Status:                  m.Status,
+		RequestDeliveryResponse: m.RequestDeliveryResponse,
+		Headers:                 m.Headers,
+		Metadata:                m.Metadata,
+		Spans:                   m.Spans,        // nolint:staticcheck
+		IncludeSpans:            m.IncludeSpans, // nolint:staticcheck
+		Payload:                 m.Payload,
+		PartnerIDs:              m.PartnerIDs,
+		SessionID:               m.SessionID,
+	}
+
+	return nil
+}
+
+// ToMessage replaces the message with the fields of the struct.
+func (msg *SimpleRequestResponse) ToMessage(m *Message) error {
+	*m = Message{
+		Type:                    msg.MsgType(),
+		Source:                  msg.Source,
+		Destination:             msg.Destination,
+		ContentType:             msg.ContentType,
+		Accept:                  msg.Accept,
+		TransactionUUID:         msg.TransactionUUID,
+		Status:                  msg.Status,
+		RequestDeliveryResponse: msg.RequestDeliveryResponse,
+		Headers:                 msg.Headers,
+		Metadata:                msg.Metadata,
+		Spans:                   msg.Spans,        // nolint:staticcheck
+		IncludeSpans:            msg.IncludeSpans, // nolint:staticcheck
+		Payload:                 msg.Payload,
+		PartnerIDs:              msg.PartnerIDs,
+		SessionID:               msg.SessionID,
+	}
+
+	return nil
+}
+
+// Validate always returns nil, since the struct has no required fields.
+func (msg *SimpleRequestResponse) Validate() error {
+	return nil
+}
+
+// MsgType returns SimpleEventMessageType.
+func (msg *SimpleEvent) MsgType() MessageType {
+	return SimpleEventMessageType
+}
+
+// FromMessage fills in the struct from a message of SimpleEventMessageType.
+func (msg *SimpleEvent) FromMessage(m *Message) error {
+	if err := CheckUnionType(m.Type, SimpleEventMessageType); err != nil {
+		return err
+	}
+
+	*msg = SimpleEvent{
+		Type:        m.Type,
+		Source:      m.Source,
+		Destination: m.Destination,
+		ContentType: m.ContentType,
+		Headers:     m.Headers,
+		Metadata:    m.Metadata,
+		Payload:     m.Payload,
+		PartnerIDs:  m.PartnerIDs,
+		SessionID:   m.SessionID,
+	}
+
+	return nil
+}
+
+// ToMessage replaces the message with the fields of the struct.
+func (msg *SimpleEvent) ToMessage(m *Message) error {
+	*m = Message{
+		Type:        msg.MsgType(),
+		Source:      msg.Source,
+		Destination: msg.Destination,
+		ContentType: msg.ContentType,
+		Headers:     msg.Headers,
+		Metadata:    msg.Metadata,
+		Payload:     msg.Payload,
+		PartnerIDs:  msg.PartnerIDs,
+		SessionID:   msg.SessionID,
+	}
+
+	return nil
+}
+
+// Validate always returns nil, since the struct has no required fields.
+func (msg *SimpleEvent) Validate() error {
+	return nil
+}
+
+// MsgType returns the Type of the struct, which must be set to one of the
+// CRUD message types.
+func (msg *CRUD) MsgType() MessageType {
+	return msg.Type
+}
+
+// FromMessage fills in the struct from a message of any of the CRUD message
+// types.
+func (msg *CRUD) FromMessage(m *Message) error {
+	if err := checkCRUDType(m.Type); err != nil {
+		return err
+	}
+
+	*msg = CRUD{
+		Type:                    m.Type,
+		Source:                  m.Source,
+		Destination:             m.Destination,
+		TransactionUUID:         m.TransactionUUID,
+		ContentType:             m.ContentType,
+		Headers:                 m.Headers,
+		Metadata:                m.Metadata,
+		Spans:                   m.Spans,        // nolint:staticcheck
+		IncludeSpans:            m.IncludeSpans, // nolint:staticcheck
+		Status:                  m.Status,
+		RequestDeliveryResponse: m.RequestDeliveryResponse,
+		Path:                    m.Path,
+		Payload:                 m.Payload,
+		PartnerIDs:              m.PartnerIDs,
+		SessionID:               m.SessionID,
+	}
+
+	return nil
+}
+
+// ToMessage validates the struct and replaces the message with its fields.
+func (msg *CRUD) ToMessage(m *Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+
+	*m = Message{
+		Type:                    msg.MsgType(),
+		Source:                  msg.Source,
+		Destination:             msg.Destination,
+		TransactionUUID:         msg.TransactionUUID,
+		ContentType:             msg.ContentType,
+		Headers:                 msg.Headers,
+		Metadata:                msg.Metadata,
+		Spans:                   msg.Spans,        // nolint:staticcheck
+		IncludeSpans:            msg.IncludeSpans, // nolint:staticcheck
+		Status:                  msg.Status,
+		RequestDeliveryResponse: msg.RequestDeliveryResponse,
+		Path:                    msg.Path,
+		Payload:                 msg.Payload,
+		PartnerIDs:              msg.PartnerIDs,
+		SessionID:               msg.SessionID,
+	}
+
+	return nil
+}
+
+// Validate checks that the Type is one of the CRUD message types.
+func (msg *CRUD) Validate() error {
+	return checkCRUDType(msg.Type)
+}
+
+func checkCRUDType(mt MessageType) error {
+	return CheckUnionType(mt, crudMessageTypes...)
+}
+
+// MsgType returns ServiceRegistrationMessageType.
+func (msg *ServiceRegistration) MsgType() MessageType {
+	return ServiceRegistrationMessageType
+}
+
+// FromMessage fills in the struct from a message of
+// ServiceRegistrationMessageType.
+func (msg *ServiceRegistration) FromMessage(m *Message) error {
+	if err := CheckUnionType(m.Type, ServiceRegistrationMessageType); err != nil {
+		return err
+	}
+
+	*msg = ServiceRegistration{
+		Type:        m.Type,
+		ServiceName: m.ServiceName,
+		URL:         m.URL,
+	}
+
+	return nil
+}
+
+// ToMessage replaces the message with the fields of the struct.
+func (msg *ServiceRegistration) ToMessage(m *Message) error {
+	*m = Message{
+		Type:        msg.MsgType(),
+		ServiceName: msg.ServiceName,
+		URL:         msg.URL,
+	}
+
+	return nil
+}
+
+// Validate always returns nil, since the struct has no required fields.
+func (msg *ServiceRegistration) Validate() error {
+	return nil
+}
+
+// MsgType returns ServiceAliveMessageType.
+func (msg *ServiceAlive) MsgType() MessageType {
+	return ServiceAliveMessageType
+}
+
+// FromMessage fills in the struct from a message of ServiceAliveMessageType.
+func (msg *ServiceAlive) FromMessage(m *Message) error {
+	if err := CheckUnionType(m.Type, ServiceAliveMessageType); err != nil {
+		return err
+	}
+
+	*msg = ServiceAlive{
+		Type: m.Type,
+	}
+
+	return nil
+}
+
+// ToMessage replaces the message with the fields of the struct.
+func (msg *ServiceAlive) ToMessage(m *Message) error {
+	*m = Message{
+		Type: msg.MsgType(),
+	}
+
+	return nil
+}
+
+// Validate always returns nil, since the struct has no required fields.
+func (msg *ServiceAlive) Validate() error {
+	return nil
+}
+
+// MsgType returns UnknownMessageType.
+func (msg *Unknown) MsgType() MessageType {
+	return UnknownMessageType
+}
+
+// FromMessage fills in the struct from a message of UnknownMessageType.
+func (msg *Unknown) FromMessage(m *Message) error {
+	if err := CheckUnionType(m.Type, UnknownMessageType); err != nil {
+		return err
+	}
+
+	*msg = Unknown{
+		Type: m.Type,
+	}
+
+	return nil
+}
+
+// ToMessage replaces the message with the fields of the struct.
+func (msg *Unknown) ToMessage(m *Message) error {
+	*m = Message{
+		Type: msg.MsgType(),
+	}
+
+	return nil
+}
+
+// Validate always returns nil, since the struct has no required fields.
+func (msg *Unknown) Validate() error {
+	return nil
+}
@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConvertMessage(mt MessageType) *Message {
+	status := int64(200)
+	rdr := int64(0)
+	include := true
+
+	return &Message{
+		Type:                    mt,
+		Source:                  "dns:example.com",
+		Destination:             "mac:112233445566/config",
+		TransactionUUID:         "1234",
+		ContentType:             "application/json",
+		Accept:                  "application/json",
+		Status:                  &status,
+		RequestDeliveryResponse: &rdr,
+		Headers:                 []string{"X-Test: true"},
+		Metadata:                map[string]string{"/key": "value"},
+		Spans:                   [][]string{{"parent", "name", "1", "2", "0"}},
+		IncludeSpans:            &include,
+		Path:                    "/a/b",
+		Payload:                 []byte(`{"a":1}`),
+		ServiceName:             "config",
+		URL:                     "tcp://127.0.0.1:6666",
+		PartnerIDs:              []string{"comcast"},
+		SessionID:               "session",
+	}
+}
+
+// toByTranscoding is the slow path that As replaces.
+func toByTranscoding[T any](t *testing.T, msg *Message) *T {
+	var out T
+	require.NoError(t, NewDecoderBytes(MustEncode(msg, Msgpack), Msgpack).Decode(&out))
+	return &out
+}
+
+func TestAs(t *testing.T) {
+	t.Run("SimpleRequestResponse", func(t *testing.T) {
+		msg := testConvertMessage(SimpleRequestResponseMessageType)
+		actual, err := As[SimpleRequestResponse](msg)
+		require.NoError(t, err)
+
+		// the generated codec does not encode session_id for this struct
+		expected := toByTranscoding[SimpleRequestResponse](t, msg)
+		expected.SessionID = msg.SessionID
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("SimpleEvent", func(t *testing.T) {
+		msg := testConvertMessage(SimpleEventMessageType)
+		actual, err := As[SimpleEvent](msg)
+		require.NoError(t, err)
+		assert.Equal(t, toByTranscoding[SimpleEvent](t, msg), actual)
+	})
+
+	for _, mt := range []MessageType{CreateMessageType, RetrieveMessageType, UpdateMessageType, DeleteMessageType} {
+		t.Run("CRUD/"+mt.FriendlyName(), func(t *testing.T) {
+			msg := testConvertMessage(mt)
+			actual, err := As[CRUD](msg)
+			require.NoError(t, err)
+
+			// the generated codec does not encode session_id for this struct
+			expected := toByTranscoding[CRUD](t, msg)
+			expected.SessionID = msg.SessionID
+			assert.Equal(t, expected, actual)
+		})
+	}
+
+	t.Run("ServiceRegistration", func(t *testing.T) {
+		msg := testConvertMessage(ServiceRegistrationMessageType)
+		actual, err := As[ServiceRegistration](msg)
+		require.NoError(t, err)
+		assert.Equal(t, toByTranscoding[ServiceRegistration](t, msg), actual)
+	})
+
+	t.Run("ServiceAlive", func(t *testing.T) {
+		actual, err := As[ServiceAlive](testConvertMessage(ServiceAliveMessageType))
+		require.NoError(t, err)
+		assert.Equal(t, &ServiceAlive{Type: ServiceAliveMessageType}, actual)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		actual, err := As[Unknown](testConvertMessage(UnknownMessageType))
+		require.NoError(t, err)
+		assert.Equal(t, &Unknown{Type: UnknownMessageType}, actual)
+	})
+
+	t.Run("Shallow", func(t *testing.T) {
+		msg := testConvertMessage(SimpleEventMessageType)
+		actual, err := As[SimpleEvent](msg)
+		require.NoError(t, err)
+		assert.Same(t, &msg.Payload[0], &actual.Payload[0])
+	})
+}
+
+func TestAs_mismatch(t *testing.T) {
+	tests := []struct {
+		desc string
+		to   func(*Message) (any, error)
+		msg  *Message
+	}{
+		{
+			desc: "nil message",
+			to:   func(m *Message) (any, error) { return As[SimpleEvent](m) },
+		}, {
+			desc: "SimpleEvent from a request",
+			to:   func(m *Message) (any, error) { return As[SimpleEvent](m) },
+			msg:  testConvertMessage(SimpleRequestResponseMessageType),
+		}, {
+			desc: "SimpleRequestResponse from an event",
+			to:   func(m *Message) (any, error) { return As[SimpleRequestResponse](m) },
+			msg:  testConvertMessage(SimpleEventMessageType),
+		}, {
+			desc: "CRUD from an event",
+			to:   func(m *Message) (any, error) { return As[CRUD](m) },
+			msg:  testConvertMessage(SimpleEventMessageType),
+		}, {
+			desc: "ServiceRegistration from an invalid type",
+			to:   func(m *Message) (any, error) { return As[ServiceRegistration](m) },
+			msg:  testConvertMessage(Invalid0MessageType),
+		}, {
+			desc: "ServiceAlive from a registration",
+			to:   func(m *Message) (any, error) { return As[ServiceAlive](m) },
+			msg:  testConvertMessage(ServiceRegistrationMessageType),
+		}, {
+			desc: "Unknown from an alive",
+			to:   func(m *Message) (any, error) { return As[Unknown](m) },
+			msg:  testConvertMessage(ServiceAliveMessageType),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			actual, err := tc.to(tc.msg)
+			assert.ErrorIs(t, err, ErrMessageTypeMismatch)
+			assert.Nil(t, actual)
+		})
+	}
+}
+
+func TestIs(t *testing.T) {
+	assert := assert.New(t)
+
+	msg := testConvertMessage(UpdateMessageType)
+	assert.True(Is[CRUD](msg))
+	assert.False(Is[SimpleEvent](msg))
+	assert.False(Is[CRUD](nil))
+}
+
+func TestUnion_roundTrip(t *testing.T) {
+	tests := []struct {
+		desc  string
+		msg   *Message
+		union Union
+	}{
+		{
+			desc:  "SimpleRequestResponse",
+			msg:   testConvertMessage(SimpleRequestResponseMessageType),
+			union: new(SimpleRequestResponse),
+		}, {
+			desc:  "SimpleEvent",
+			msg:   testConvertMessage(SimpleEventMessageType),
+			union: new(SimpleEvent),
+		}, {
+			desc:  "CRUD",
+			msg:   testConvertMessage(DeleteMessageType),
+			union: new(CRUD),
+		}, {
+			desc:  "ServiceRegistration",
+			msg:   testConvertMessage(ServiceRegistrationMessageType),
+			union: new(ServiceRegistration),
+		}, {
+			desc:  "ServiceAlive",
+			msg:   testConvertMessage(ServiceAliveMessageType),
+			union: new(ServiceAlive),
+		}, {
+			desc:  "Unknown",
+			msg:   testConvertMessage(UnknownMessageType),
+			union: new(Unknown),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			require.NoError(tc.union.FromMessage(tc.msg))
+			assert.Equal(tc.msg.Type, tc.union.MsgType())
+			require.NoError(tc.union.Validate())
+
+			var out Message
+			require.NoError(tc.union.ToMessage(&out))
+			assert.Equal(tc.msg.Type, out.Type)
+
+			// the fields the struct has survive the round trip
+			again := reflect.New(reflect.TypeOf(tc.union).Elem()).Interface().(Union)
+			require.NoError(again.FromMessage(&out))
+			assert.Equal(tc.union, again)
+		})
+	}
+
+	// a CRUD must have one of the CRUD types
+	var out Message
+	assert.ErrorIs(t, (&CRUD{}).ToMessage(&out), ErrMessageTypeMismatch)
+	assert.ErrorIs(t, (&CRUD{Type: SimpleEventMessageType}).Validate(), ErrMessageTypeMismatch)
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnsupportedFieldsSet  = errors.New("unsupported fields set")
+	ErrRequiredFieldsMissing = errors.New("required fields missing")
+)
+
+// fieldsError creates the error for the offending fields of a message.  The
+// Field of the *Error is only set if there is a single offending field.
+func fieldsError(code ErrorCode, sentinel error, mt MessageType, fields ...string) error {
+	var field string
+	if len(fields) == 1 {
+		field = fields[0]
+	}
+
+	return newError(code, field,
+		fmt.Errorf("%w for %s messages: %s", sentinel, friendlyName(mt), strings.Join(fields, ", ")))
+}
+
+func friendlyName(mt MessageType) string {
+	if name := mt.FriendlyName(); name != "" {
+		return name
+	}
+
+	return mt.String()
+}
+
+// AcceptOnlyOnRequests returns a Processor that rejects messages with the
+// Accept field set unless they are requests, i.e. SimpleRequestResponse or
+// CRUD messages, since Accept describes the expected response.  Valid
+// messages result in ErrNotHandled.
+func AcceptOnlyOnRequests() Processor {
+	return ProcessorFunc(func(_ context.Context, msg Message) error {
+		if msg.Accept != "" && !msg.Type.RequiresTransaction() {
+			return fieldsError(CodeUnsupportedField, ErrUnsupportedFieldsSet, msg.Type, "Accept")
+		}
+
+		return ErrNotHandled
+	})
+}
+
+// PathRequiredForCRUD returns a Processor that rejects CRUD messages without a
+// Path.  Valid messages result in ErrNotHandled.
+func PathRequiredForCRUD() Processor {
+	return ProcessorFunc(func(_ context.Context, msg Message) error {
+		if IsCRUD(msg.Type) && msg.Path == "" {
+			return fieldsError(CodeMissingField, ErrRequiredFieldsMissing, msg.Type, "Path")
+		}
+
+		return ErrNotHandled
+	})
+}
+
+// StatusOnlyOnResponses returns a Processor that rejects messages with the
+// Status field set unless they are of a type that has responses, i.e.
+// SimpleRequestResponse or CRUD, or are Authorization messages, which carry a
+// status.  A request and its response share a message type, so this cannot
+// reject a Status set on a request.  Valid messages result in ErrNotHandled.
+func StatusOnlyOnResponses() Processor {
+	return ProcessorFunc(func(_ context.Context, msg Message) error {
+		if msg.Status != nil && msg.Type != AuthorizationMessageType && !msg.Type.RequiresTransaction() {
+			return fieldsError(CodeUnsupportedField, ErrUnsupportedFieldsSet, msg.Type, "Status")
+		}
+
+		return ErrNotHandled
+	})
+}
+
+// ServiceOnlyOnRegistration returns a Processor that rejects messages with the
+// ServiceName or URL fields set unless they are ServiceRegistration messages.
+// Valid messages result in ErrNotHandled.
+func ServiceOnlyOnRegistration() Processor {
+	return ProcessorFunc(func(_ context.Context, msg Message) error {
+		if msg.Type == ServiceRegistrationMessageType {
+			return ErrNotHandled
+		}
+
+		var fields []string
+		if msg.ServiceName != "" {
+			fields = append(fields, "ServiceName")
+		}
+		if msg.URL != "" {
+			fields = append(fields, "URL")
+		}
+
+		if len(fields) > 0 {
+			return fieldsError(CodeUnsupportedField, ErrUnsupportedFieldsSet, msg.Type, fields...)
+		}
+
+		return ErrNotHandled
+	})
+}
+
+// CrossFieldRules returns all of the standard cross field Processors:
+// AcceptOnlyOnRequests, PathRequiredForCRUD, StatusOnlyOnResponses and
+// ServiceOnlyOnRegistration.  The first rule a message breaks is reported.
+func CrossFieldRules() Processors {
+	return Processors{
+		AcceptOnlyOnRequests(),
+		PathRequiredForCRUD(),
+		StatusOnlyOnResponses(),
+		ServiceOnlyOnRegistration(),
+	}
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrossFieldRules(t *testing.T) {
+	status := int64(200)
+
+	tests := []struct {
+		desc        string
+		msg         Message
+		expectedErr error
+		code        ErrorCode
+		field       string
+		contains    string
+	}{
+		{
+			desc: "valid request",
+			msg:  Message{Type: SimpleRequestResponseMessageType, Accept: "application/json", Status: &status},
+		}, {
+			desc: "valid crud",
+			msg:  Message{Type: RetrieveMessageType, Path: "/config", Accept: "application/json"},
+		}, {
+			desc: "valid event",
+			msg:  Message{Type: SimpleEventMessageType},
+		}, {
+			desc: "valid authorization",
+			msg:  Message{Type: AuthorizationMessageType, Status: &status},
+		}, {
+			desc: "valid registration",
+			msg:  Message{Type: ServiceRegistrationMessageType, ServiceName: "config", URL: "tcp://127.0.0.1:6666"},
+		}, {
+			desc:        "accept on an event",
+			msg:         Message{Type: SimpleEventMessageType, Accept: "application/json"},
+			expectedErr: ErrUnsupportedFieldsSet,
+			code:        CodeUnsupportedField,
+			field:       "Accept",
+			contains:    "SimpleEvent",
+		}, {
+			desc:        "crud without a path",
+			msg:         Message{Type: UpdateMessageType},
+			expectedErr: ErrRequiredFieldsMissing,
+			code:        CodeMissingField,
+			field:       "Path",
+		}, {
+			desc:        "status on an event",
+			msg:         Message{Type: SimpleEventMessageType, Status: &status},
+			expectedErr: ErrUnsupportedFieldsSet,
+			code:        CodeUnsupportedField,
+			field:       "Status",
+		}, {
+			desc:        "service name on a request",
+			msg:         Message{Type: SimpleRequestResponseMessageType, ServiceName: "config"},
+			expectedErr: ErrUnsupportedFieldsSet,
+			code:        CodeUnsupportedField,
+			field:       "ServiceName",
+		}, {
+			desc:        "service name and url on an alive",
+			msg:         Message{Type: ServiceAliveMessageType, ServiceName: "config", URL: "tcp://127.0.0.1:6666"},
+			expectedErr: ErrUnsupportedFieldsSet,
+			code:        CodeUnsupportedField,
+			contains:    "ServiceName, URL",
+		}, {
+			desc:        "invalid message type",
+			msg:         Message{Type: LastMessageType + 1, Accept: "application/json"},
+			expectedErr: ErrUnsupportedFieldsSet,
+			code:        CodeUnsupportedField,
+			field:       "Accept",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			err := CrossFieldRules().ProcessWRP(context.Background(), tc.msg)
+			if tc.expectedErr == nil {
+				assert.ErrorIs(err, ErrNotHandled)
+				return
+			}
+
+			assert.ErrorIs(err, tc.expectedErr)
+			assert.Equal(tc.code, ErrorCodeOf(err))
+			assert.Equal(tc.field, ErrorFieldOf(err))
+			if tc.contains != "" {
+				assert.Contains(err.Error(), tc.contains)
+			}
+		})
+	}
+}
@@ -0,0 +1,451 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ugorji/go/codec"
+)
+
+var (
+	// ErrDuplicateKey indicates that a msgpack map had the same key more than
+	// once, and RejectDuplicateKeys was used.
+	ErrDuplicateKey = errors.New("duplicate map key")
+
+	errMsgpackDepth = errors.New("msgpack value is nested too deeply")
+)
+
+// maxMsgpackDepth is the deepest nesting of maps and arrays a Decoder scans
+// for duplicate keys.
+const maxMsgpackDepth = 1000
+
+// DuplicateKeys determines how a Decoder handles a msgpack map, e.g. the
+// message itself or its Metadata, that has the same key more than once.
+// Producers disagree on which of the values is meant, so services that
+// consume messages from several producers can choose explicitly.
+//
+// The JSON format is not affected.
+type DuplicateKeys int
+
+const (
+	// LastDuplicateKeyWins decodes the last value of a duplicated key.  This
+	// is the default, and how a Decoder has always behaved.
+	LastDuplicateKeyWins DuplicateKeys = iota
+
+	// FirstDuplicateKeyWins decodes the first value of a duplicated key.
+	FirstDuplicateKeyWins
+
+	// RejectDuplicateKeys fails the decoding with ErrDuplicateKey.
+	RejectDuplicateKeys
+)
+
+// DecoderOption is a functional option for a Decoder.
+type DecoderOption interface {
+	apply(*decoderOptions)
+}
+
+type decoderOptionFunc func(*decoderOptions)
+
+func (f decoderOptionFunc) apply(o *decoderOptions) {
+	f(o)
+}
+
+// WithDuplicateKeys sets how duplicate keys in msgpack maps are decoded.
+func WithDuplicateKeys(dk DuplicateKeys) DecoderOption {
+	return decoderOptionFunc(func(o *decoderOptions) {
+		o.duplicateKeys = dk
+	})
+}
+
+type decoderOptions struct {
+	duplicateKeys DuplicateKeys
+}
+
+func newDecoderOptions(opts []DecoderOption) decoderOptions {
+	var o decoderOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt.apply(&o)
+		}
+	}
+
+	return o
+}
+
+// scans returns true if msgpack values must be scanned before they are
+// decoded.
+func (o decoderOptions) scans(f Format) bool {
+	return f == Msgpack && o.duplicateKeys != LastDuplicateKeyWins
+}
+
+// NewDecoderWithOptions is like NewDecoder, but with options.
+func NewDecoderWithOptions(input io.Reader, f Format, opts ...DecoderOption) Decoder {
+	o := newDecoderOptions(opts)
+	if !o.scans(f) {
+		return NewDecoder(input, f)
+	}
+
+	d := &decoderDecorator{
+		Decoder: codec.NewDecoderBytes(nil, f.handle()),
+		options: o,
+	}
+	d.Reset(input)
+	return d
+}
+
+// NewDecoderBytesWithOptions is like NewDecoderBytes, but with options.
+func NewDecoderBytesWithOptions(input []byte, f Format, opts ...DecoderOption) Decoder {
+	o := newDecoderOptions(opts)
+	if !o.scans(f) {
+		return NewDecoderBytes(input, f)
+	}
+
+	d := &decoderDecorator{
+		Decoder: codec.NewDecoderBytes(nil, f.handle()),
+		options: o,
+	}
+	d.ResetBytes(input)
+	return d
+}
+
+// decoderDecorator wraps a ugorji Decoder, and scans each msgpack value for
+// duplicate keys before decoding it.
+type decoderDecorator struct {
+	*codec.Decoder
+	options decoderOptions
+
+	// Exactly one of r and input is the source of the values.
+	r     io.Reader
+	input []byte
+}
+
+func (d *decoderDecorator) Reset(r io.Reader) {
+	if _, ok := r.(io.ByteReader); !ok {
+		r = bufio.NewReader(r)
+	}
+
+	d.r, d.input = r, nil
+}
+
+func (d *decoderDecorator) ResetBytes(input []byte) {
+	d.r, d.input = nil, input
+}
+
+func (d *decoderDecorator) Decode(v interface{}) error {
+	raw, err := d.next()
+	if err != nil {
+		return err
+	}
+
+	s := msgpackScanner{
+		input:         raw,
+		duplicateKeys: d.options.duplicateKeys,
+	}
+
+	scanned, err := s.value(0)
+	if err != nil {
+		return err
+	}
+
+	d.Decoder.ResetBytes(scanned)
+	return d.Decoder.Decode(v)
+}
+
+// next returns the encoding of the next value of the source.
+func (d *decoderDecorator) next() ([]byte, error) {
+	if d.r == nil {
+		if len(d.input) == 0 {
+			return nil, io.EOF
+		}
+
+		s := msgpackScanner{input: d.input}
+		if err := s.skip(0); err != nil {
+			return nil, err
+		}
+
+		raw := d.input[:s.pos]
+		d.input = d.input[s.pos:]
+		return raw, nil
+	}
+
+	var (
+		buf  bytes.Buffer
+		read = func(n int) ([]byte, error) {
+			start := buf.Len()
+			if _, err := io.CopyN(&buf, d.r, int64(n)); err != nil {
+				if err == io.EOF && start > 0 {
+					err = io.ErrUnexpectedEOF
+				}
+				return nil, err
+			}
+
+			return buf.Bytes()[start:], nil
+		}
+	)
+
+	if err := skipMsgpack(read, 0); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// msgpackHeader reads the rest of the header of a msgpack value whose first
+// byte is b, and returns the number of bytes of data and the number of nested
+// values that follow the header.  The nested values of a map are its keys and
+// values.
+func msgpackHeader(b byte, read func(int) ([]byte, error)) (size, count int, err error) {
+	length := func(n int) (int, error) {
+		p, err := read(n)
+		if err != nil {
+			return 0, err
+		}
+
+		switch n {
+		case 1:
+			return int(p[0]), nil
+		case 2:
+			return int(binary.BigEndian.Uint16(p)), nil
+		default:
+			return int(binary.BigEndian.Uint32(p)), nil
+		}
+	}
+
+	switch {
+	case b <= 0x7f, b >= 0xe0, b == 0xc0, b == 0xc2, b == 0xc3:
+		return 0, 0, nil
+	case b <= 0x8f:
+		return 0, 2 * int(b&0x0f), nil
+	case b <= 0x9f:
+		return 0, int(b & 0x0f), nil
+	case b <= 0xbf:
+		return int(b & 0x1f), 0, nil
+	}
+
+	switch b {
+	case 0xc4, 0xd9:
+		size, err = length(1)
+	case 0xc5, 0xda:
+		size, err = length(2)
+	case 0xc6, 0xdb:
+		size, err = length(4)
+	case 0xc7:
+		size, err = length(1)
+		size++
+	case 0xc8:
+		size, err = length(2)
+		size++
+	case 0xc9:
+		size, err = length(4)
+		size++
+	case 0xca, 0xce, 0xd2:
+		size = 4
+	case 0xcb, 0xcf, 0xd3:
+		size = 8
+	case 0xcc, 0xd0:
+		size = 1
+	case 0xcd, 0xd1:
+		size = 2
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		size = 1 + 1<<(b-0xd4)
+	case 0xdc:
+		count, err = length(2)
+	case 0xdd:
+		count, err = length(4)
+	case 0xde:
+		count, err = length(2)
+		count *= 2
+	case 0xdf:
+		count, err = length(4)
+		count *= 2
+	default:
+		err = fmt.Errorf("invalid msgpack type 0x%x", b)
+	}
+
+	return size, count, err
+}
+
+func isMsgpackMap(b byte) bool {
+	return (b >= 0x80 && b <= 0x8f) || b == 0xde || b == 0xdf
+}
+
+func isMsgpackString(b byte) bool {
+	return (b >= 0xa0 && b <= 0xbf) || (b >= 0xc4 && b <= 0xc6) || (b >= 0xd9 && b <= 0xdb)
+}
+
+// skipMsgpack reads one complete msgpack value.
+func skipMsgpack(read func(int) ([]byte, error), depth int) error {
+	if depth > maxMsgpackDepth {
+		return errMsgpackDepth
+	}
+
+	p, err := read(1)
+	if err != nil {
+		return err
+	}
+
+	size, count, err := msgpackHeader(p[0], read)
+	if err == nil && size > 0 {
+		_, err = read(size)
+	}
+
+	for i := 0; err == nil && i < count; i++ {
+		err = skipMsgpack(read, depth+1)
+	}
+
+	return err
+}
+
+// msgpackScanner scans msgpack values in a buffer.
+type msgpackScanner struct {
+	input         []byte
+	pos           int
+	duplicateKeys DuplicateKeys
+}
+
+func (s *msgpackScanner) read(n int) ([]byte, error) {
+	if n > len(s.input)-s.pos {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	p := s.input[s.pos : s.pos+n]
+	s.pos += n
+	return p, nil
+}
+
+func (s *msgpackScanner) skip(depth int) error {
+	return skipMsgpack(s.read, depth)
+}
+
+// value returns the encoding of the next value with the duplicate keys of its
+// maps handled.  Values that need no changes are returned as is.
+func (s *msgpackScanner) value(depth int) ([]byte, error) {
+	if depth > maxMsgpackDepth {
+		return nil, errMsgpackDepth
+	}
+
+	start := s.pos
+	p, err := s.read(1)
+	if err != nil {
+		return nil, err
+	}
+
+	size, count, err := msgpackHeader(p[0], s.read)
+	if err == nil && size > 0 {
+		_, err = s.read(size)
+	}
+
+	switch {
+	case err != nil:
+		return nil, err
+	case count == 0:
+		return s.input[start:s.pos], nil
+	case !isMsgpackMap(p[0]):
+		return s.array(start, count, depth)
+	}
+
+	var (
+		header  = s.input[start:s.pos]
+		pairs   []byte
+		kept    int
+		changed bool
+		seen    = make(map[string]bool, count/2)
+	)
+
+	for i := 0; i < count; i += 2 {
+		pairStart := s.pos
+		key, err := s.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := s.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		k := msgpackKey(key)
+		if seen[k] {
+			if s.duplicateKeys == RejectDuplicateKeys {
+				return nil, fmt.Errorf("%w: `%s`", ErrDuplicateKey, k)
+			}
+
+			changed = true
+			continue
+		}
+
+		seen[k] = true
+		kept++
+		if len(key)+len(value) != s.pos-pairStart {
+			changed = true
+		}
+		pairs = append(append(pairs, key...), value...)
+	}
+
+	if !changed {
+		return s.input[start:s.pos], nil
+	}
+
+	if kept != count/2 {
+		header = msgpackMapHeader(kept)
+	}
+
+	return append(append([]byte(nil), header...), pairs...), nil
+}
+
+// array returns the encoding of an array whose header spans from start to
+// the current position.
+func (s *msgpackScanner) array(start, count, depth int) ([]byte, error) {
+	var (
+		encoded = append([]byte(nil), s.input[start:s.pos]...)
+		changed bool
+	)
+
+	for i := 0; i < count; i++ {
+		valueStart := s.pos
+		v, err := s.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		changed = changed || len(v) != s.pos-valueStart
+		encoded = append(encoded, v...)
+	}
+
+	if !changed {
+		return s.input[start:s.pos], nil
+	}
+
+	return encoded, nil
+}
+
+// msgpackKey returns the key of a map entry, so that keys are compared by
+// their contents regardless of how they are encoded.
+func msgpackKey(encoded []byte) string {
+	if !isMsgpackString(encoded[0]) {
+		return string(encoded)
+	}
+
+	s := msgpackScanner{input: encoded, pos: 1}
+	size, _, _ := msgpackHeader(encoded[0], s.read)
+
+	return string(encoded[len(encoded)-size:])
+}
+
+func msgpackMapHeader(n int) []byte {
+	switch {
+	case n < 16:
+		return []byte{0x80 | byte(n)}
+	case n <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{0xde}, uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32([]byte{0xdf}, uint32(n))
+	}
+}
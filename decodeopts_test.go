@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// msgpackMap encodes the key value pairs, in order, as a msgpack map.
+func msgpackMap(pairs ...interface{}) []byte {
+	encoded := msgpackMapHeader(len(pairs) / 2)
+	for _, v := range pairs {
+		encoded = append(encoded, MustEncode(v, Msgpack)...)
+	}
+
+	return encoded
+}
+
+func duplicateKeysMessage() []byte {
+	metadata := append(msgpackMapHeader(3), MustEncode("/a", Msgpack)...)
+	metadata = append(metadata, MustEncode("1", Msgpack)...)
+	metadata = append(metadata, MustEncode("/b", Msgpack)...)
+	metadata = append(metadata, MustEncode("2", Msgpack)...)
+	metadata = append(metadata, MustEncode("/a", Msgpack)...)
+	metadata = append(metadata, MustEncode("3", Msgpack)...)
+
+	encoded := msgpackMap(
+		"msg_type", int64(SimpleEventMessageType),
+		"source", "first",
+		"dest", "event:test",
+		"source", "last",
+	)
+	encoded[0]++ // one more pair, the metadata
+	encoded = append(encoded, MustEncode("metadata", Msgpack)...)
+	return append(encoded, metadata...)
+}
+
+func TestWithDuplicateKeys(t *testing.T) {
+	input := duplicateKeysMessage()
+
+	tests := []struct {
+		desc     string
+		opts     []DecoderOption
+		expected *Message
+		err      error
+	}{
+		{
+			desc: "default",
+			expected: &Message{
+				Type:        SimpleEventMessageType,
+				Source:      "last",
+				Destination: "event:test",
+				Metadata:    map[string]string{"/a": "3", "/b": "2"},
+			},
+		}, {
+			desc: "last wins",
+			opts: []DecoderOption{nil, WithDuplicateKeys(LastDuplicateKeyWins)},
+			expected: &Message{
+				Type:        SimpleEventMessageType,
+				Source:      "last",
+				Destination: "event:test",
+				Metadata:    map[string]string{"/a": "3", "/b": "2"},
+			},
+		}, {
+			desc: "first wins",
+			opts: []DecoderOption{WithDuplicateKeys(FirstDuplicateKeyWins)},
+			expected: &Message{
+				Type:        SimpleEventMessageType,
+				Source:      "first",
+				Destination: "event:test",
+				Metadata:    map[string]string{"/a": "1", "/b": "2"},
+			},
+		}, {
+			desc: "reject",
+			opts: []DecoderOption{WithDuplicateKeys(RejectDuplicateKeys)},
+			err:  ErrDuplicateKey,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			for _, d := range []Decoder{
+				NewDecoderBytesWithOptions(input, Msgpack, tc.opts...),
+				NewDecoderWithOptions(bytes.NewReader(input), Msgpack, tc.opts...),
+				NewDecoderWithOptions(io.MultiReader(bytes.NewReader(input)), Msgpack, tc.opts...),
+			} {
+				var actual Message
+				err := d.Decode(&actual)
+				if tc.err != nil {
+					assert.ErrorIs(t, err, tc.err)
+					continue
+				}
+
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, &actual)
+			}
+		})
+	}
+}
+
+func TestWithDuplicateKeys_nested(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// the duplicate is in a map within an array within a map
+	inner := msgpackMap("a", 1, "a", 2, "b", strings.Repeat("x", 40))
+	outer := append(msgpackMap("list"), 0x92)
+	outer[0]++
+	outer = append(outer, inner...)
+	outer = append(outer, MustEncode(true, Msgpack)...)
+
+	var actual map[string][]interface{}
+	require.NoError(NewDecoderBytesWithOptions(outer, Msgpack, WithDuplicateKeys(FirstDuplicateKeyWins)).Decode(&actual))
+	require.Len(actual["list"], 2)
+	assert.EqualValues(1, actual["list"][0].(map[interface{}]interface{})["a"])
+	assert.Equal(true, actual["list"][1])
+
+	err := NewDecoderBytesWithOptions(outer, Msgpack, WithDuplicateKeys(RejectDuplicateKeys)).Decode(&actual)
+	assert.ErrorIs(err, ErrDuplicateKey)
+	assert.ErrorContains(err, "`a`")
+
+	// large maps get a new header when duplicates are dropped
+	var pairs []interface{}
+	for i := 0; i < 20; i++ {
+		pairs = append(pairs, string(rune('a'+i)), i)
+	}
+	pairs = append(pairs, "a", 100)
+
+	var large map[string]int
+	require.NoError(NewDecoderBytesWithOptions(msgpackMap(pairs...), Msgpack, WithDuplicateKeys(FirstDuplicateKeyWins)).Decode(&large))
+	assert.Len(large, 20)
+	assert.Equal(0, large["a"])
+}
+
+func TestWithDuplicateKeys_stream(t *testing.T) {
+	assert := assert.New(t)
+
+	first := Message{Type: SimpleEventMessageType, Source: "one", Payload: []byte("payload")}
+	second := Message{Type: SimpleEventMessageType, Source: "two"}
+	input := append(MustEncode(&first, Msgpack), MustEncode(&second, Msgpack)...)
+
+	for _, d := range []Decoder{
+		NewDecoderBytesWithOptions(input, Msgpack, WithDuplicateKeys(RejectDuplicateKeys)),
+		NewDecoderWithOptions(bytes.NewReader(input), Msgpack, WithDuplicateKeys(RejectDuplicateKeys)),
+	} {
+		var actual Message
+		assert.NoError(d.Decode(&actual))
+		assert.Equal(first, actual)
+
+		actual = Message{}
+		assert.NoError(d.Decode(&actual))
+		assert.Equal(second, actual)
+
+		assert.Equal(io.EOF, d.Decode(&actual))
+
+		d.ResetBytes(input)
+		assert.NoError(d.Decode(&actual))
+		d.Reset(bytes.NewReader(input[:len(input)-1]))
+		assert.NoError(d.Decode(&actual))
+		assert.ErrorIs(d.Decode(&actual), io.ErrUnexpectedEOF)
+	}
+}
+
+func TestWithDuplicateKeys_invalid(t *testing.T) {
+	tests := []struct {
+		desc  string
+		input []byte
+	}{
+		{desc: "invalid type", input: []byte{0xc1}},
+		{desc: "truncated length", input: []byte{0xd9}},
+		{desc: "truncated map", input: []byte{0x81, 0xa1, 'a'}},
+		{desc: "too deep", input: bytes.Repeat([]byte{0x91}, maxMsgpackDepth+2)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var v interface{}
+			assert.Error(t, NewDecoderBytesWithOptions(tc.input, Msgpack, WithDuplicateKeys(FirstDuplicateKeyWins)).Decode(&v))
+			assert.Error(t, NewDecoderWithOptions(bytes.NewReader(tc.input), Msgpack, WithDuplicateKeys(FirstDuplicateKeyWins)).Decode(&v))
+		})
+	}
+}
+
+func TestWithDuplicateKeys_json(t *testing.T) {
+	input := []byte(`{"msg_type":4,"source":"first","source":"last"}`)
+
+	var actual Message
+	require.NoError(t, NewDecoderBytesWithOptions(input, JSON, WithDuplicateKeys(RejectDuplicateKeys)).Decode(&actual))
+	assert.Equal(t, "last", actual.Source)
+}
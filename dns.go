@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	maxHostnameLength = 253
+	maxLabelLength    = 63
+)
+
+var (
+	ErrInvalidHostname    = errors.New("invalid hostname")
+	ErrHostnameNotAllowed = errors.New("hostname not allowed")
+)
+
+// ValidateHostname checks that the name is a valid hostname following the
+// syntax of RFC 1123: at most 253 characters made up of dot separated labels
+// of 1 to 63 letters, digits and hyphens, where a label does not start or end
+// with a hyphen.  A single trailing dot is permitted.
+func ValidateHostname(name string) error {
+	host := strings.TrimSuffix(name, ".")
+	if host == "" {
+		return fmt.Errorf("%w: empty hostname", ErrInvalidHostname)
+	}
+
+	if len(host) > maxHostnameLength {
+		return fmt.Errorf("%w: `%s` is longer than %d characters", ErrInvalidHostname, name, maxHostnameLength)
+	}
+
+	for _, label := range strings.Split(host, ".") {
+		if err := validateLabel(label); err != nil {
+			return fmt.Errorf("%w: `%s` %s", ErrInvalidHostname, name, err)
+		}
+	}
+
+	return nil
+}
+
+// validateLabel returns a description of the problem with the label, or nil.
+func validateLabel(label string) error {
+	switch {
+	case label == "":
+		return errors.New("has an empty label")
+	case len(label) > maxLabelLength:
+		return fmt.Errorf("has a label longer than %d characters", maxLabelLength)
+	case label[0] == '-' || label[len(label)-1] == '-':
+		return fmt.Errorf("has a label `%s` that starts or ends with a hyphen", label)
+	}
+
+	for _, r := range label {
+		if r != '-' && (r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r))) {
+			return fmt.Errorf("has an invalid character %q", r)
+		}
+	}
+
+	return nil
+}
+
+// hostnameHasSuffix returns true if the hostname is one of the suffixes or a
+// subdomain of one.  The comparison ignores case and trailing dots.
+func hostnameHasSuffix(name string, suffixes []string) bool {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	for _, s := range suffixes {
+		s = strings.ToLower(strings.Trim(s, "."))
+		if name == s || strings.HasSuffix(name, "."+s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateStrictDNS validates the locator if it uses the dns scheme.  Locators
+// with other schemes are ignored.
+func validateStrictDNS(locator string, suffixes []string) error {
+	l, err := ParseLocator(locator)
+	if err != nil {
+		return err
+	}
+
+	if l.Scheme != SchemeDNS {
+		return nil
+	}
+
+	// ParseLocator trims whitespace around each part.
+	if strings.IndexFunc(locator, unicode.IsSpace) >= 0 {
+		return fmt.Errorf("%w: `%s` contains whitespace", ErrInvalidHostname, locator)
+	}
+
+	if err := ValidateHostname(l.Authority); err != nil {
+		return err
+	}
+
+	if len(suffixes) > 0 && !hostnameHasSuffix(l.Authority, suffixes) {
+		return fmt.Errorf("%w: `%s` is not in an allowed domain", ErrHostnameNotAllowed, l.Authority)
+	}
+
+	return nil
+}
+
+// ValidateSourceDNS is a stricter form of ValidateSource for sources that use
+// the dns scheme.  The authority must be a valid RFC 1123 hostname and the
+// locator must not contain whitespace.  If any suffixes are given, the
+// authority must also be one of the suffixes or a subdomain of one, e.g. the
+// suffix "example.com" allows "example.com" and "api.example.com" but not
+// "badexample.com".  Sources with other schemes are only checked to be valid
+// locators.
+func ValidateSourceDNS(suffixes ...string) NormifierOption {
+	return optionFunc(func(m *Message) error {
+		if err := validateStrictDNS(m.Source, suffixes); err != nil {
+			return newError(CodeInvalidLocator, "Source", errors.Join(err, ErrInvalidSource))
+		}
+		return nil
+	})
+}
+
+// ValidateDestinationDNS is a stricter form of ValidateDestination for
+// destinations that use the dns scheme.  The authority must be a valid RFC 1123
+// hostname and the locator must not contain whitespace.  Destinations with
+// other schemes are only checked to be valid locators.
+func ValidateDestinationDNS() NormifierOption {
+	return optionFunc(func(m *Message) error {
+		if err := validateStrictDNS(m.Destination, nil); err != nil {
+			return newError(CodeInvalidLocator, "Destination", errors.Join(err, ErrInvalidDest))
+		}
+		return nil
+	})
+}
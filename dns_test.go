@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateHostname(t *testing.T) {
+	tests := []struct {
+		desc    string
+		name    string
+		invalid bool
+	}{
+		{desc: "simple", name: "example.com"},
+		{desc: "single label", name: "localhost"},
+		{desc: "trailing dot", name: "example.com."},
+		{desc: "leading digit", name: "1example.com"},
+		{desc: "hyphen", name: "my-host.example.com"},
+		{desc: "mixed case", name: "Example.COM"},
+		{desc: "max label", name: strings.Repeat("a", 63) + ".com"},
+		{desc: "empty", name: "", invalid: true},
+		{desc: "only a dot", name: ".", invalid: true},
+		{desc: "empty label", name: "example..com", invalid: true},
+		{desc: "leading dot", name: ".example.com", invalid: true},
+		{desc: "leading hyphen", name: "-example.com", invalid: true},
+		{desc: "trailing hyphen", name: "example-.com", invalid: true},
+		{desc: "underscore", name: "my_host.example.com", invalid: true},
+		{desc: "space", name: "my host.example.com", invalid: true},
+		{desc: "port", name: "example.com:8080", invalid: true},
+		{desc: "non ascii", name: "exämple.com", invalid: true},
+		{desc: "label too long", name: strings.Repeat("a", 64) + ".com", invalid: true},
+		{desc: "name too long", name: strings.Repeat("a.", 127) + "com", invalid: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := ValidateHostname(tc.name)
+			if tc.invalid {
+				assert.ErrorIs(t, err, ErrInvalidHostname)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateSourceDNS(t *testing.T) {
+	tests := []struct {
+		desc        string
+		suffixes    []string
+		source      string
+		expectedErr []error
+	}{
+		{
+			desc:   "valid",
+			source: "dns:talaria.example.com/service",
+		}, {
+			desc:   "other schemes are not checked",
+			source: "mac:112233445566",
+		}, {
+			desc:     "other schemes ignore the suffixes",
+			suffixes: []string{"example.com"},
+			source:   "event:device-status",
+		}, {
+			desc:     "allowed suffix",
+			suffixes: []string{"example.org", ".example.com"},
+			source:   "dns:talaria.EXAMPLE.com",
+		}, {
+			desc:     "exact suffix",
+			suffixes: []string{"example.com"},
+			source:   "dns:example.com",
+		}, {
+			desc:        "invalid locator",
+			source:      "invalid",
+			expectedErr: []error{ErrInvalidSource, ErrorInvalidLocator},
+		}, {
+			desc:        "invalid hostname",
+			source:      "dns:bad_host.example.com",
+			expectedErr: []error{ErrInvalidSource, ErrInvalidHostname},
+		}, {
+			desc:        "embedded whitespace",
+			source:      "dns:example.com /service",
+			expectedErr: []error{ErrInvalidSource, ErrInvalidHostname},
+		}, {
+			desc:        "surrounding whitespace",
+			source:      "dns: example.com",
+			expectedErr: []error{ErrInvalidSource, ErrInvalidHostname},
+		}, {
+			desc:        "suffix is not a label boundary",
+			suffixes:    []string{"example.com"},
+			source:      "dns:badexample.com",
+			expectedErr: []error{ErrInvalidSource, ErrHostnameNotAllowed},
+		}, {
+			desc:        "not allowed",
+			suffixes:    []string{"example.com"},
+			source:      "dns:example.org",
+			expectedErr: []error{ErrInvalidSource, ErrHostnameNotAllowed},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := NewNormifier(ValidateSourceDNS(tc.suffixes...)).Normify(&Message{Source: tc.source})
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			for _, e := range tc.expectedErr {
+				assert.ErrorIs(t, err, e)
+			}
+		})
+	}
+}
+
+func TestValidateDestinationDNS(t *testing.T) {
+	n := NewNormifier(ValidateDestinationDNS())
+
+	assert.NoError(t, n.Normify(&Message{Destination: "dns:example.com/service"}))
+	assert.NoError(t, n.Normify(&Message{Destination: "event:device-status"}))
+
+	err := n.Normify(&Message{Destination: "dns:-example.com"})
+	assert.ErrorIs(t, err, ErrInvalidDest)
+	assert.ErrorIs(t, err, ErrInvalidHostname)
+}
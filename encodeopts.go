@@ -0,0 +1,275 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/ugorji/go/codec"
+)
+
+var (
+	// ErrUnsafeJSONInteger indicates that an integer was outside the range
+	// that JavaScript numbers represent exactly, and SafeJSONIntegers was used.
+	ErrUnsafeJSONInteger = errors.New("integer is outside the safe range of JSON numbers")
+)
+
+// EmptyCollections determines how an Encoder writes the Headers, Metadata and
+// PartnerIDs of a Message when they are nil or empty.
+type EmptyCollections int
+
+const (
+	// OmitEmptyCollections leaves empty collections out of the encoding in
+	// both formats, so that an empty collection and a nil collection encode
+	// to the same bytes.  This is the default.
+	OmitEmptyCollections EmptyCollections = iota
+
+	// IncludeEmptyCollections always writes the collections, as an empty
+	// array or map if they are nil or empty, in both formats.
+	IncludeEmptyCollections
+)
+
+// JSONIntegers determines how an Encoder writes the Status and
+// RequestDeliveryResponse of a Message in the JSON format.  JavaScript, and
+// other consumers that treat JSON numbers as float64 values, lose precision
+// for integers beyond 2^53.
+//
+// Decoders accept both numbers and strings for these fields, whatever
+// encoding was used.
+type JSONIntegers int
+
+const (
+	// LargeJSONIntegersAsStrings writes integers as JSON numbers, except those
+	// beyond 2^53 in magnitude, which are written as strings.  This is the
+	// default.
+	LargeJSONIntegersAsStrings JSONIntegers = iota
+
+	// JSONIntegersAsStrings always writes the integers as JSON strings.
+	JSONIntegersAsStrings
+
+	// SafeJSONIntegers writes integers as JSON numbers, and fails the encoding
+	// with ErrUnsafeJSONInteger if an integer is beyond 2^53-1 in magnitude.
+	SafeJSONIntegers
+)
+
+// maxSafeJSONInteger is the largest integer that JavaScript numbers represent
+// exactly, along with every integer smaller in magnitude.
+const maxSafeJSONInteger = 1<<53 - 1
+
+// EncoderOption is a functional option for an Encoder.
+type EncoderOption interface {
+	apply(*encoderOptions)
+}
+
+type encoderOptionFunc func(*encoderOptions)
+
+func (f encoderOptionFunc) apply(o *encoderOptions) {
+	f(o)
+}
+
+// WithEmptyCollections sets how empty Headers, Metadata and PartnerIDs are
+// encoded.  It applies to Message values, and pointers to them, passed to the
+// Encoder.  The deprecated message structs always omit empty collections.
+func WithEmptyCollections(ec EmptyCollections) EncoderOption {
+	return encoderOptionFunc(func(o *encoderOptions) {
+		o.emptyCollections = ec
+	})
+}
+
+// WithJSONIntegers sets how Status and RequestDeliveryResponse are encoded in
+// the JSON format.  It applies to Message values, and pointers to them, passed
+// to the Encoder.  The msgpack format is not affected.
+func WithJSONIntegers(ji JSONIntegers) EncoderOption {
+	return encoderOptionFunc(func(o *encoderOptions) {
+		o.jsonIntegers = ji
+	})
+}
+
+type encoderOptions struct {
+	format           Format
+	emptyCollections EmptyCollections
+	jsonIntegers     JSONIntegers
+}
+
+// NewEncoderWithOptions is like NewEncoder, but with options.
+func NewEncoderWithOptions(output io.Writer, f Format, opts ...EncoderOption) Encoder {
+	return &encoderDecorator{
+		Encoder: codec.NewEncoder(output, f.handle()),
+		options: newEncoderOptions(f, opts),
+	}
+}
+
+// NewEncoderBytesWithOptions is like NewEncoderBytes, but with options.
+func NewEncoderBytesWithOptions(output *[]byte, f Format, opts ...EncoderOption) Encoder {
+	return newEncoderBytes(output, newEncoderOptions(f, opts))
+}
+
+func newEncoderOptions(f Format, opts []EncoderOption) encoderOptions {
+	o := encoderOptions{
+		format: f,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt.apply(&o)
+		}
+	}
+
+	return o
+}
+
+// prepare returns the value that is actually encoded.
+func (o encoderOptions) prepare(value interface{}) (interface{}, error) {
+	var msg *Message
+	switch v := value.(type) {
+	case *PreservingMessage:
+		return o.preserving(v)
+	case PreservingMessage:
+		return o.preserving(&v)
+	case *Message:
+		msg = v
+	case Message:
+		msg = &v
+	}
+
+	if msg == nil {
+		return value, nil
+	}
+
+	jsonIntegers := LargeJSONIntegersAsStrings
+	if o.format == JSON {
+		jsonIntegers = o.jsonIntegers
+	}
+
+	switch jsonIntegers {
+	case JSONIntegersAsStrings:
+		if msg.Status != nil || msg.RequestDeliveryResponse != nil {
+			return o.withStringIntegers(msg), nil
+		}
+	case SafeJSONIntegers:
+		if err := checkSafeJSONInteger("status", msg.Status); err != nil {
+			return nil, err
+		}
+		if err := checkSafeJSONInteger("rdr", msg.RequestDeliveryResponse); err != nil {
+			return nil, err
+		}
+	}
+
+	if o.emptyCollections == IncludeEmptyCollections {
+		return withCollections(*msg), nil
+	}
+
+	return value, nil
+}
+
+func checkSafeJSONInteger(name string, v *int64) error {
+	if v != nil && (*v > maxSafeJSONInteger || *v < -maxSafeJSONInteger) {
+		return fmt.Errorf("%w: %s %d", ErrUnsafeJSONInteger, name, *v)
+	}
+
+	return nil
+}
+
+// messageWithCollections has the same fields as Message, but the collections
+// are not tagged with omitempty.  It has none of Message's generated codec
+// methods, so it is encoded by reflection.
+type messageWithCollections struct {
+	Type                    MessageType       `json:"msg_type"`
+	Source                  string            `json:"source,omitempty"`
+	Destination             string            `json:"dest,omitempty"`
+	TransactionUUID         string            `json:"transaction_uuid,omitempty"`
+	ContentType             string            `json:"content_type,omitempty"`
+	Accept                  string            `json:"accept,omitempty"`
+	Status                  *int64            `json:"status,omitempty"`
+	RequestDeliveryResponse *int64            `json:"rdr,omitempty"`
+	Headers                 []string          `json:"headers"`
+	Metadata                map[string]string `json:"metadata"`
+	Spans                   [][]string        `json:"spans,omitempty"`
+	IncludeSpans            *bool             `json:"include_spans,omitempty"`
+	Path                    string            `json:"path,omitempty"`
+	Payload                 []byte            `json:"payload,omitempty"`
+	ServiceName             string            `json:"service_name,omitempty"`
+	URL                     string            `json:"url,omitempty"`
+	PartnerIDs              []string          `json:"partner_ids"`
+	SessionID               string            `json:"session_id,omitempty"`
+	QualityOfService        QOSValue          `json:"qos"`
+}
+
+func withCollections(msg Message) *messageWithCollections {
+	m := messageWithCollections(msg)
+	if m.Headers == nil {
+		m.Headers = []string{}
+	}
+	if m.Metadata == nil {
+		m.Metadata = map[string]string{}
+	}
+	if m.PartnerIDs == nil {
+		m.PartnerIDs = []string{}
+	}
+
+	return &m
+}
+
+// jsonStringInt64 is an int64 that is encoded as a string.
+type jsonStringInt64 int64
+
+func newJSONStringInt64(v *int64) *jsonStringInt64 {
+	if v == nil {
+		return nil
+	}
+
+	s := jsonStringInt64(*v)
+	return &s
+}
+
+func (i *jsonStringInt64) CodecEncodeSelf(e *codec.Encoder) {
+	e.MustEncode(strconv.FormatInt(int64(*i), 10))
+}
+
+func (i *jsonStringInt64) CodecDecodeSelf(d *codec.Decoder) {
+	d.MustDecode((*int64)(i))
+}
+
+// messageWithStringIntegers is a Message that is encoded with its Status and
+// RequestDeliveryResponse as strings.  The fields shadow those of the embedded
+// message.
+type messageWithStringIntegers struct {
+	messageFields
+	Status                  *jsonStringInt64 `json:"status,omitempty"`
+	RequestDeliveryResponse *jsonStringInt64 `json:"rdr,omitempty"`
+}
+
+// messageWithCollectionsAndStringIntegers is the same as
+// messageWithStringIntegers, but with the collections of
+// messageWithCollections.
+type messageWithCollectionsAndStringIntegers struct {
+	messageWithCollections
+	Status                  *jsonStringInt64 `json:"status,omitempty"`
+	RequestDeliveryResponse *jsonStringInt64 `json:"rdr,omitempty"`
+}
+
+// messageFields has the fields of Message, but none of its generated codec
+// methods, so it can be embedded and encoded by reflection.
+type messageFields Message
+
+func (o encoderOptions) withStringIntegers(msg *Message) interface{} {
+	status := newJSONStringInt64(msg.Status)
+	rdr := newJSONStringInt64(msg.RequestDeliveryResponse)
+
+	if o.emptyCollections == IncludeEmptyCollections {
+		return &messageWithCollectionsAndStringIntegers{
+			messageWithCollections:  *withCollections(*msg),
+			Status:                  status,
+			RequestDeliveryResponse: rdr,
+		}
+	}
+
+	return &messageWithStringIntegers{
+		messageFields:           messageFields(*msg),
+		Status:                  status,
+		RequestDeliveryResponse: rdr,
+	}
+}
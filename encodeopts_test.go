@@ -0,0 +1,220 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeWithOptions(t *testing.T, v interface{}, f Format, opts ...EncoderOption) []byte {
+	var output []byte
+	require.NoError(t, NewEncoderBytesWithOptions(&output, f, opts...).Encode(v))
+
+	var buf bytes.Buffer
+	require.NoError(t, NewEncoderWithOptions(&buf, f, opts...).Encode(v))
+	require.Equal(t, output, buf.Bytes())
+
+	return output
+}
+
+func TestWithEmptyCollections(t *testing.T) {
+	var (
+		nilCollections = Message{
+			Type:   SimpleEventMessageType,
+			Source: "dns:example.com",
+		}
+
+		emptyCollections = Message{
+			Type:       SimpleEventMessageType,
+			Source:     "dns:example.com",
+			Headers:    []string{},
+			Metadata:   map[string]string{},
+			PartnerIDs: []string{},
+		}
+
+		fullCollections = Message{
+			Type:       SimpleEventMessageType,
+			Source:     "dns:example.com",
+			Headers:    []string{"X-Test: 1"},
+			Metadata:   map[string]string{"/key": "value"},
+			PartnerIDs: []string{"comcast"},
+		}
+	)
+
+	for _, f := range AllFormats() {
+		t.Run(f.String(), func(t *testing.T) {
+			t.Run("omit", func(t *testing.T) {
+				assert := assert.New(t)
+
+				var expected []byte
+				require.NoError(t, NewEncoderBytes(&expected, f).Encode(&nilCollections))
+
+				assert.Equal(expected, encodeWithOptions(t, &emptyCollections, f))
+				assert.Equal(expected, encodeWithOptions(t, &emptyCollections, f, WithEmptyCollections(OmitEmptyCollections)))
+				assert.Equal(expected, encodeWithOptions(t, nilCollections, f, nil))
+			})
+
+			t.Run("include", func(t *testing.T) {
+				assert := assert.New(t)
+				include := WithEmptyCollections(IncludeEmptyCollections)
+
+				fromNil := encodeWithOptions(t, &nilCollections, f, include)
+				assert.Equal(fromNil, encodeWithOptions(t, emptyCollections, f, include))
+				assert.Contains(string(fromNil), "headers")
+				assert.Contains(string(fromNil), "metadata")
+				assert.Contains(string(fromNil), "partner_ids")
+
+				var decoded Message
+				require.NoError(t, NewDecoderBytes(fromNil, f).Decode(&decoded))
+				assert.Equal(nilCollections.Source, decoded.Source)
+				assert.Empty(decoded.Headers)
+				assert.Empty(decoded.Metadata)
+				assert.Empty(decoded.PartnerIDs)
+
+				// the collections are added to a copy
+				assert.Nil(nilCollections.Headers)
+
+				// non-empty collections decode the same as the default encoding
+				decoded = Message{}
+				require.NoError(t, NewDecoderBytes(encodeWithOptions(t, &fullCollections, f, include), f).Decode(&decoded))
+				assert.Equal(fullCollections, decoded)
+			})
+		})
+	}
+}
+
+func TestWithEmptyCollections_json(t *testing.T) {
+	assert := assert.New(t)
+
+	actual := encodeWithOptions(t, &Message{Type: SimpleEventMessageType}, JSON, WithEmptyCollections(IncludeEmptyCollections))
+	assert.JSONEq(`{"msg_type":4,"headers":[],"metadata":{},"partner_ids":[],"qos":0}`, string(actual))
+}
+
+func TestWithEmptyCollections_otherValues(t *testing.T) {
+	assert := assert.New(t)
+	include := WithEmptyCollections(IncludeEmptyCollections)
+
+	for _, f := range AllFormats() {
+		// nolint:staticcheck
+		event := SimpleEvent{Source: "dns:example.com", Destination: "event:test"}
+
+		var expected []byte
+		require.NoError(t, NewEncoderBytes(&expected, f).Encode(&event))
+		assert.Equal(expected, encodeWithOptions(t, &event, f, include))
+
+		assert.Equal(MustEncode((*Message)(nil), f), encodeWithOptions(t, (*Message)(nil), f, include))
+	}
+}
+
+func TestWithJSONIntegers(t *testing.T) {
+	var (
+		status int64 = 200
+		large  int64 = 1 << 60
+		small  int64 = -(1 << 53)
+	)
+
+	tests := []struct {
+		desc     string
+		msg      Message
+		opts     []EncoderOption
+		expected string
+		err      error
+	}{
+		{
+			desc:     "default",
+			msg:      Message{Type: SimpleEventMessageType, Status: &status, RequestDeliveryResponse: &large},
+			expected: `{"msg_type":4,"status":200,"rdr":"1152921504606846976","qos":0}`,
+		}, {
+			desc:     "large as strings",
+			msg:      Message{Type: SimpleEventMessageType, Status: &status, RequestDeliveryResponse: &large},
+			opts:     []EncoderOption{WithJSONIntegers(LargeJSONIntegersAsStrings)},
+			expected: `{"msg_type":4,"status":200,"rdr":"1152921504606846976","qos":0}`,
+		}, {
+			desc:     "as strings",
+			msg:      Message{Type: SimpleEventMessageType, Status: &status, RequestDeliveryResponse: &large},
+			opts:     []EncoderOption{WithJSONIntegers(JSONIntegersAsStrings)},
+			expected: `{"msg_type":4,"qos":0,"status":"200","rdr":"1152921504606846976"}`,
+		}, {
+			desc:     "as strings without rdr",
+			msg:      Message{Type: SimpleEventMessageType, Status: &status},
+			opts:     []EncoderOption{WithJSONIntegers(JSONIntegersAsStrings)},
+			expected: `{"msg_type":4,"qos":0,"status":"200"}`,
+		}, {
+			desc:     "as strings without integers",
+			msg:      Message{Type: SimpleEventMessageType},
+			opts:     []EncoderOption{WithJSONIntegers(JSONIntegersAsStrings)},
+			expected: `{"msg_type":4,"qos":0}`,
+		}, {
+			desc:     "as strings with collections",
+			msg:      Message{Type: SimpleEventMessageType, Status: &status},
+			opts:     []EncoderOption{WithJSONIntegers(JSONIntegersAsStrings), WithEmptyCollections(IncludeEmptyCollections)},
+			expected: `{"msg_type":4,"headers":[],"metadata":{},"partner_ids":[],"qos":0,"status":"200"}`,
+		}, {
+			desc:     "safe",
+			msg:      Message{Type: SimpleEventMessageType, Status: &status},
+			opts:     []EncoderOption{WithJSONIntegers(SafeJSONIntegers)},
+			expected: `{"msg_type":4,"status":200,"qos":0}`,
+		}, {
+			desc: "safe with large rdr",
+			msg:  Message{Type: SimpleEventMessageType, Status: &status, RequestDeliveryResponse: &large},
+			opts: []EncoderOption{WithJSONIntegers(SafeJSONIntegers)},
+			err:  ErrUnsafeJSONInteger,
+		}, {
+			desc: "safe with small status",
+			msg:  Message{Type: SimpleEventMessageType, Status: &small},
+			opts: []EncoderOption{WithJSONIntegers(SafeJSONIntegers)},
+			err:  ErrUnsafeJSONInteger,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			if tc.err != nil {
+				var output []byte
+				err := NewEncoderBytesWithOptions(&output, JSON, tc.opts...).Encode(&tc.msg)
+				assert.ErrorIs(err, tc.err)
+				return
+			}
+
+			encoded := encodeWithOptions(t, &tc.msg, JSON, tc.opts...)
+			assert.JSONEq(tc.expected, string(encoded))
+			assert.Equal(encoded, encodeWithOptions(t, tc.msg, JSON, tc.opts...))
+
+			var decoded Message
+			require.NoError(t, NewDecoderBytes(encoded, JSON).Decode(&decoded))
+			assert.Equal(tc.msg.Status, decoded.Status)
+			assert.Equal(tc.msg.RequestDeliveryResponse, decoded.RequestDeliveryResponse)
+
+			// the msgpack format is not affected
+			defaults := append(append([]EncoderOption{}, tc.opts...), WithJSONIntegers(LargeJSONIntegersAsStrings))
+			assert.Equal(
+				encodeWithOptions(t, &tc.msg, Msgpack, defaults...),
+				encodeWithOptions(t, &tc.msg, Msgpack, tc.opts...),
+			)
+		})
+	}
+}
+
+func TestJSONIntegersDecode(t *testing.T) {
+	var (
+		status int64 = 200
+		large  int64 = 1 << 60
+	)
+
+	for _, input := range []string{
+		`{"msg_type":4,"status":200,"rdr":1152921504606846976}`,
+		`{"msg_type":4,"status":"200","rdr":"1152921504606846976"}`,
+	} {
+		var msg Message
+		require.NoError(t, NewDecoderBytes([]byte(input), JSON).Decode(&msg), input)
+		assert.Equal(t, &status, msg.Status, input)
+		assert.Equal(t, &large, msg.RequestDeliveryResponse, input)
+	}
+}
@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+)
+
+// jsonPayloadChunk is the number of payload bytes base64 encoded at a time by
+// EncodeTo, a multiple of 3 so that only the last chunk is padded.
+const jsonPayloadChunk = 48 * 1024
+
+var errUnexpectedEncoding = errors.New("unexpected encoding")
+
+// EncodeTo writes the message to w in the given format without building the
+// encoded message in a single buffer.  The other fields are encoded first and
+// the Payload is written separately, using writev where w supports it (e.g. a
+// *net.TCPConn), so encoding a message with a very large payload does not copy
+// the payload.  For JSON, the payload is base64 encoded in chunks.
+//
+// The output decodes to the same message as that of an Encoder, although the
+// payload is the last field written.  The number of bytes written is returned.
+// This method panics if the format is not a valid value.
+func (msg *Message) EncodeTo(w io.Writer, f Format) (int64, error) {
+	header := *msg
+	header.Payload = nil
+	return encodeTo(w, f, &header, msg.Payload)
+}
+
+// EncodeTo writes the message to w in the given format in the same way as
+// Message.EncodeTo, so producers of events need not convert them to a Message
+// first.  The Type is always SimpleEventMessageType.
+func (msg *SimpleEvent) EncodeTo(w io.Writer, f Format) (int64, error) {
+	header := *msg
+	header.Payload = nil
+	return encodeTo(w, f, &header, msg.Payload)
+}
+
+// EncodeTo writes the message to w in the given format in the same way as
+// Message.EncodeTo, so producers of requests and responses need not convert
+// them to a Message first.  The Type is always
+// SimpleRequestResponseMessageType.
+func (msg *SimpleRequestResponse) EncodeTo(w io.Writer, f Format) (int64, error) {
+	header := *msg
+	header.Payload = nil
+	return encodeTo(w, f, &header, msg.Payload)
+}
+
+// encodeTo writes the header, a message without its payload, followed by the
+// payload.
+func encodeTo(w io.Writer, f Format, header interface{}, payload []byte) (int64, error) {
+	var encoded []byte
+	if err := NewEncoderBytes(&encoded, f).Encode(header); err != nil {
+		return 0, err
+	}
+
+	if len(payload) == 0 {
+		n, err := w.Write(encoded)
+		return int64(n), err
+	}
+
+	switch f {
+	case Msgpack:
+		return encodeMsgpackTo(w, encoded, payload)
+	default:
+		return encodeJSONTo(w, encoded, payload)
+	}
+}
+
+// encodeMsgpackTo adds a payload entry to the encoded msgpack map and writes
+// the map followed by the payload.
+func encodeMsgpackTo(w io.Writer, encoded, payload []byte) (int64, error) {
+	var (
+		prefix []byte
+		rest   []byte
+	)
+
+	// increment the map size, switching from a fixmap to a map16 if needed
+	switch b := encoded[0]; {
+	case b >= 0x80 && b < 0x8f:
+		prefix = []byte{b + 1}
+		rest = encoded[1:]
+	case b == 0x8f:
+		prefix = []byte{0xde, 0x00, 0x10}
+		rest = encoded[1:]
+	case b == 0xde && len(encoded) >= 3:
+		prefix = binary.BigEndian.AppendUint16([]byte{0xde}, binary.BigEndian.Uint16(encoded[1:])+1)
+		rest = encoded[3:]
+	default:
+		return 0, fmt.Errorf("%w: msgpack map header 0x%x", errUnexpectedEncoding, b)
+	}
+
+	// the payload key, followed by a bin header
+	entry := append(rest, 0xa7, 'p', 'a', 'y', 'l', 'o', 'a', 'd')
+	switch n := len(payload); {
+	case n <= math.MaxUint8:
+		entry = append(entry, 0xc4, byte(n))
+	case n <= math.MaxUint16:
+		entry = binary.BigEndian.AppendUint16(append(entry, 0xc5), uint16(n))
+	case uint64(n) <= math.MaxUint32:
+		entry = binary.BigEndian.AppendUint32(append(entry, 0xc6), uint32(n))
+	default:
+		return 0, fmt.Errorf("%w: %d byte payload", ErrPayloadTooLarge, n)
+	}
+
+	buffers := net.Buffers{prefix, entry, payload}
+	return buffers.WriteTo(w)
+}
+
+// encodeJSONTo adds a payload member to the encoded JSON object and writes the
+// object with the payload base64 encoded in chunks.
+func encodeJSONTo(w io.Writer, encoded, payload []byte) (int64, error) {
+	if len(encoded) < 2 || encoded[len(encoded)-1] != '}' {
+		return 0, fmt.Errorf("%w: JSON object", errUnexpectedEncoding)
+	}
+
+	head := encoded[:len(encoded)-1]
+	if len(head) > 1 {
+		head = append(head, ',')
+	}
+	head = append(head, `"payload":"`...)
+
+	cw := countingWriter{w: w}
+	if _, err := cw.Write(head); err != nil {
+		return cw.n, err
+	}
+
+	chunk := make([]byte, base64.StdEncoding.EncodedLen(min(len(payload), jsonPayloadChunk)))
+	for len(payload) > 0 {
+		p := payload[:min(len(payload), jsonPayloadChunk)]
+		payload = payload[len(p):]
+
+		out := chunk[:base64.StdEncoding.EncodedLen(len(p))]
+		base64.StdEncoding.Encode(out, p)
+		if _, err := cw.Write(out); err != nil {
+			return cw.n, err
+		}
+	}
+
+	_, err := cw.Write([]byte(`"}`))
+	return cw.n, err
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(b []byte) (int, error) {
+	n, err := cw.w.Write(b)
+	cw.n += int64(n)
+	return n, err
+}
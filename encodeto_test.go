@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage_EncodeTo(t *testing.T) {
+	// fifteen fields besides the payload, the most a msgpack fixmap can hold
+	fifteen := func() Message {
+		return *(&Message{
+			Type:             SimpleRequestResponseMessageType,
+			Source:           "dns:example.com",
+			Destination:      "mac:112233445566/config",
+			TransactionUUID:  "1234",
+			ContentType:      "application/octet-stream",
+			Accept:           "application/json",
+			Headers:          []string{"X-Test: 1"},
+			Metadata:         map[string]string{"/key": "value"},
+			Path:             "/config",
+			ServiceName:      "config",
+			URL:              "tcp://127.0.0.1:6666",
+			PartnerIDs:       []string{"comcast"},
+			QualityOfService: 24,
+		}).SetStatus(200).SetRequestDeliveryResponse(0)
+	}
+
+	messages := []struct {
+		desc string
+		msg  func() Message
+	}{
+		{
+			desc: "minimal",
+			msg:  func() Message { return Message{Type: SimpleEventMessageType} },
+		}, {
+			desc: "fifteen fields",
+			msg:  fifteen,
+		}, {
+			desc: "sixteen fields",
+			msg: func() Message {
+				msg := fifteen()
+				msg.SessionID = "session"
+				return msg
+			},
+		},
+	}
+
+	for _, f := range AllFormats() {
+		for _, m := range messages {
+			for _, size := range []int{0, 1, 255, 256, 65535, 65536, 200000} {
+				t.Run(f.String()+"/"+m.desc+"/"+strconv.Itoa(size), func(t *testing.T) {
+					assert := assert.New(t)
+					require := require.New(t)
+
+					msg := m.msg()
+					if size > 0 {
+						msg.Payload = bytes.Repeat([]byte{0xa5, 'x', 0x00}, size)[:size]
+					}
+
+					var b bytes.Buffer
+					n, err := msg.EncodeTo(&b, f)
+					require.NoError(err)
+					assert.Equal(int64(b.Len()), n)
+
+					var decoded Message
+					require.NoError(NewDecoderBytes(b.Bytes(), f).Decode(&decoded))
+					assert.Equal(msg, decoded)
+
+					if f == JSON {
+						var expected []byte
+						require.NoError(NewEncoderBytes(&expected, f).Encode(&msg))
+						assert.JSONEq(string(expected), b.String())
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestSimpleMessages_EncodeTo(t *testing.T) {
+	status, rdr, spans := int64(200), int64(0), true
+	messages := []struct {
+		desc    string
+		msg     func(payload []byte) EncodeListener
+		encoder func(EncodeListener, io.Writer, Format) (int64, error)
+	}{
+		{
+			desc: "SimpleEvent",
+			msg: func(payload []byte) EncodeListener {
+				return &SimpleEvent{
+					Source:      "mac:112233445566",
+					Destination: "event:device-status",
+					ContentType: "application/json",
+					Headers:     []string{"X-Test: 1"},
+					Metadata:    map[string]string{"/key": "value"},
+					PartnerIDs:  []string{"comcast"},
+					SessionID:   "session",
+					Payload:     payload,
+				}
+			},
+			encoder: func(msg EncodeListener, w io.Writer, f Format) (int64, error) {
+				return msg.(*SimpleEvent).EncodeTo(w, f)
+			},
+		}, {
+			desc: "SimpleRequestResponse",
+			msg: func(payload []byte) EncodeListener {
+				return &SimpleRequestResponse{
+					Source:                  "dns:example.com",
+					Destination:             "mac:112233445566/config",
+					ContentType:             "application/json",
+					Accept:                  "application/json",
+					TransactionUUID:         "1234",
+					Status:                  &status,
+					RequestDeliveryResponse: &rdr,
+					Headers:                 []string{"X-Test: 1"},
+					Metadata:                map[string]string{"/key": "value"},
+					Spans:                   [][]string{{"span", "1", "2"}},
+					IncludeSpans:            &spans,
+					PartnerIDs:              []string{"comcast"},
+					SessionID:               "session",
+					Payload:                 payload,
+				}
+			},
+			encoder: func(msg EncodeListener, w io.Writer, f Format) (int64, error) {
+				return msg.(*SimpleRequestResponse).EncodeTo(w, f)
+			},
+		},
+	}
+
+	for _, f := range AllFormats() {
+		for _, m := range messages {
+			for _, size := range []int{0, 1, 65536} {
+				t.Run(f.String()+"/"+m.desc+"/"+strconv.Itoa(size), func(t *testing.T) {
+					assert := assert.New(t)
+					require := require.New(t)
+
+					var payload []byte
+					if size > 0 {
+						payload = bytes.Repeat([]byte{0xa5}, size)
+					}
+
+					var b bytes.Buffer
+					n, err := m.encoder(m.msg(payload), &b, f)
+					require.NoError(err)
+					assert.Equal(int64(b.Len()), n)
+
+					var expected []byte
+					require.NoError(NewEncoderBytes(&expected, f).Encode(m.msg(payload)))
+
+					var decoded, decodedExpected Message
+					require.NoError(NewDecoderBytes(b.Bytes(), f).Decode(&decoded))
+					require.NoError(NewDecoderBytes(expected, f).Decode(&decodedExpected))
+					assert.Equal(decodedExpected, decoded)
+					assert.NotEqual(Invalid0MessageType, decoded.Type)
+				})
+			}
+		}
+	}
+}
+
+func TestMessage_EncodeTo_writeError(t *testing.T) {
+	errWrite := errors.New("expected")
+	msg := Message{Type: SimpleEventMessageType, Payload: make([]byte, 100000)}
+
+	for _, f := range AllFormats() {
+		t.Run(f.String(), func(t *testing.T) {
+			_, err := msg.EncodeTo(errWriter{err: errWrite}, f)
+			assert.ErrorIs(t, err, errWrite)
+
+			_, err = (&Message{Type: SimpleEventMessageType}).EncodeTo(errWriter{err: errWrite}, f)
+			assert.ErrorIs(t, err, errWrite)
+		})
+	}
+}
+
+func TestMessage_EncodeTo_allocations(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping large payload test in short mode")
+	}
+
+	msg := Message{
+		Type:        SimpleEventMessageType,
+		Source:      "mac:112233445566",
+		Destination: "event:device-status",
+		Payload:     make([]byte, 32*1024*1024),
+	}
+
+	for _, f := range AllFormats() {
+		t.Run(f.String(), func(t *testing.T) {
+			allocs := testing.Benchmark(func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					_, _ = msg.EncodeTo(io.Discard, f)
+				}
+			}).AllocedBytesPerOp()
+
+			assert.Less(t, allocs, int64(1024*1024))
+		})
+	}
+}
+
+type errWriter struct {
+	err error
+}
+
+func (w errWriter) Write([]byte) (int, error) {
+	return 0, w.err
+}
@@ -0,0 +1,252 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+)
+
+// An envelope frames an encoded message so that a stream of messages, e.g. an
+// archive or a socket stream, is self-describing: its header holds
+// EnvelopeMagic, a byte identifying the format of the message, the length of
+// the encoded message and its CRC-32C, each as 4 bytes in big endian order.
+//
+//	+-------+--------+--------+--------+-----------------+
+//	| "WRP" | format | length | CRC32C | encoded message |
+//	+-------+--------+--------+--------+-----------------+
+//	   3       1        4        4        length
+//
+// The format byte is 'M' for msgpack and 'J' for JSON.
+const EnvelopeMagic = "WRP"
+
+// envelopeHeaderSize is the size of the header that precedes the message.
+const envelopeHeaderSize = len(EnvelopeMagic) + 1 + 4 + 4
+
+var (
+	ErrEnvelopeTooLarge = errors.New("envelope too large")
+	ErrCorruptEnvelope  = errors.New("corrupt envelope")
+)
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+var envelopeFormats = [...]byte{
+	Msgpack: 'M',
+	JSON:    'J',
+}
+
+func envelopeFormat(b byte) (Format, bool) {
+	for f, id := range envelopeFormats {
+		if id == b {
+			return Format(f), true
+		}
+	}
+
+	return 0, false
+}
+
+// WriteEnvelope encodes the message in the given format and writes it in an
+// envelope with a single call.  This function panics if the format is not a
+// valid value.
+func WriteEnvelope(w io.Writer, msg *Message, f Format) error {
+	encoded := make([]byte, 0, msg.EncodedSizeEstimate(f))
+	if err := NewEncoderBytes(&encoded, f).Encode(msg); err != nil {
+		return err
+	}
+
+	if uint64(len(encoded)) > math.MaxUint32 {
+		return fmt.Errorf("%w: %d bytes", ErrEnvelopeTooLarge, len(encoded))
+	}
+
+	b := make([]byte, envelopeHeaderSize, envelopeHeaderSize+len(encoded))
+	copy(b, EnvelopeMagic)
+	b[len(EnvelopeMagic)] = envelopeFormats[f]
+	binary.BigEndian.PutUint32(b[len(EnvelopeMagic)+1:], uint32(len(encoded)))
+	binary.BigEndian.PutUint32(b[len(EnvelopeMagic)+5:], crc32.Checksum(encoded, crc32c))
+
+	_, err := w.Write(append(b, encoded...))
+	return err
+}
+
+// envelopeHeader is a parsed envelope header.
+type envelopeHeader struct {
+	format Format
+	size   uint32
+	crc    uint32
+}
+
+// parseEnvelopeHeader parses the header, checking it against the largest
+// message size.
+func parseEnvelopeHeader(b []byte, max int) (envelopeHeader, error) {
+	if string(b[:len(EnvelopeMagic)]) != EnvelopeMagic {
+		return envelopeHeader{}, fmt.Errorf("%w: no magic", ErrCorruptEnvelope)
+	}
+
+	f, ok := envelopeFormat(b[len(EnvelopeMagic)])
+	if !ok {
+		return envelopeHeader{}, fmt.Errorf("%w: unknown format 0x%02x", ErrCorruptEnvelope, b[len(EnvelopeMagic)])
+	}
+
+	h := envelopeHeader{
+		format: f,
+		size:   binary.BigEndian.Uint32(b[len(EnvelopeMagic)+1:]),
+		crc:    binary.BigEndian.Uint32(b[len(EnvelopeMagic)+5:]),
+	}
+
+	if uint64(h.size) > uint64(max) {
+		return envelopeHeader{}, fmt.Errorf("%w: %d bytes is more than %d", ErrEnvelopeTooLarge, h.size, max)
+	}
+
+	return h, nil
+}
+
+// decodeEnvelope checks the message of the envelope and decodes it.
+func decodeEnvelope(h envelopeHeader, encoded []byte) (*Message, error) {
+	if crc32.Checksum(encoded, crc32c) != h.crc {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrCorruptEnvelope)
+	}
+
+	var msg Message
+	if err := NewDecoderBytes(encoded, h.format).Decode(&msg); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorruptEnvelope, err)
+	}
+
+	return &msg, nil
+}
+
+// ReadEnvelope reads a single envelope and decodes its message, returning the
+// format it was encoded in.  A message larger than max bytes results in
+// ErrEnvelopeTooLarge, and an envelope that is not intact in
+// ErrCorruptEnvelope; after either, the reader is no longer positioned at the
+// start of an envelope.  Use an EnvelopeReader to skip corrupted envelopes.
+func ReadEnvelope(r io.Reader, max int) (*Message, Format, error) {
+	var header [envelopeHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, 0, err
+	}
+
+	h, err := parseEnvelopeHeader(header[:], max)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	encoded := make([]byte, h.size)
+	if _, err := io.ReadFull(r, encoded); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, 0, err
+	}
+
+	msg, err := decodeEnvelope(h, encoded)
+	return msg, h.format, err
+}
+
+// EnvelopeReader reads the envelopes of a stream, skipping anything that is
+// not an intact envelope, e.g. envelopes damaged by a failing disk or the
+// rest of an envelope that was cut short by a crash.  After a damaged
+// envelope it resynchronizes on the next EnvelopeMagic.
+type EnvelopeReader struct {
+	r       *bufio.Reader
+	max     int
+	skipped int64
+}
+
+// NewEnvelopeReader creates an EnvelopeReader for messages of at most max
+// bytes.  The reader buffers up to a whole envelope, so max should not be
+// larger than needed.
+func NewEnvelopeReader(r io.Reader, max int) *EnvelopeReader {
+	return &EnvelopeReader{
+		r:   bufio.NewReaderSize(r, envelopeHeaderSize+max),
+		max: max,
+	}
+}
+
+// Skipped returns the number of bytes skipped so far.
+func (er *EnvelopeReader) Skipped() int64 {
+	return er.skipped
+}
+
+// Read returns the message of the next intact envelope and its format.  At
+// the end of the stream, io.EOF is returned, even if bytes were skipped before
+// it.
+func (er *EnvelopeReader) Read() (*Message, Format, error) {
+	for {
+		if err := er.seek(); err != nil {
+			return nil, 0, err
+		}
+
+		msg, f, err := er.next()
+		if err == nil {
+			return msg, f, nil
+		}
+		if !errors.Is(err, ErrCorruptEnvelope) && !errors.Is(err, ErrEnvelopeTooLarge) {
+			return nil, 0, err
+		}
+
+		// skip the magic, so the next envelope is looked for after it
+		er.skip(1)
+	}
+}
+
+// seek skips to the next EnvelopeMagic.
+func (er *EnvelopeReader) seek() error {
+	for {
+		b, err := er.r.Peek(len(EnvelopeMagic))
+		switch {
+		case string(b) == EnvelopeMagic:
+			return nil
+		case err != nil:
+			er.skip(len(b))
+			return err
+		default:
+			er.skip(1)
+		}
+	}
+}
+
+// next decodes the envelope at the current position, discarding it only if it
+// is intact.  An envelope cut short by the end of the stream is corrupt.
+func (er *EnvelopeReader) next() (*Message, Format, error) {
+	header, err := er.r.Peek(envelopeHeaderSize)
+	if err != nil {
+		return nil, 0, eofAsCorrupt(err)
+	}
+
+	h, err := parseEnvelopeHeader(header, er.max)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	b, err := er.r.Peek(envelopeHeaderSize + int(h.size))
+	if err != nil {
+		return nil, 0, eofAsCorrupt(err)
+	}
+
+	msg, err := decodeEnvelope(h, b[envelopeHeaderSize:])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	_, _ = er.r.Discard(len(b))
+	return msg, h.format, nil
+}
+
+func (er *EnvelopeReader) skip(n int) {
+	n, _ = er.r.Discard(n)
+	er.skipped += int64(n)
+}
+
+func eofAsCorrupt(err error) error {
+	if errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: truncated", ErrCorruptEnvelope)
+	}
+
+	return err
+}
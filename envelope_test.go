@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func envelopeMessage(tid string) *Message {
+	return &Message{
+		Type:            SimpleEventMessageType,
+		Source:          "mac:112233445566",
+		Destination:     "event:device-status",
+		TransactionUUID: tid,
+		Payload:         []byte("payload " + tid),
+	}
+}
+
+func envelope(t *testing.T, msg *Message, f Format) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, WriteEnvelope(&buf, msg, f))
+	return buf.Bytes()
+}
+
+func TestEnvelope_roundTrip(t *testing.T) {
+	for _, f := range AllFormats() {
+		t.Run(f.String(), func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			b := envelope(t, envelopeMessage("1"), f)
+			assert.Equal(EnvelopeMagic, string(b[:3]))
+
+			msg, format, err := ReadEnvelope(bytes.NewReader(b), 1024)
+			require.NoError(err)
+			assert.Equal(f, format)
+			assert.Equal(envelopeMessage("1"), msg)
+		})
+	}
+}
+
+func TestReadEnvelope_invalid(t *testing.T) {
+	valid := envelope(t, envelopeMessage("1"), Msgpack)
+	modify := func(i int, b byte) []byte {
+		c := bytes.Clone(valid)
+		c[i] = b
+		return c
+	}
+
+	tests := []struct {
+		desc     string
+		input    []byte
+		max      int
+		expected error
+	}{
+		{
+			desc:     "empty",
+			expected: io.EOF,
+		}, {
+			desc:     "short header",
+			input:    valid[:5],
+			expected: io.ErrUnexpectedEOF,
+		}, {
+			desc:     "truncated",
+			input:    valid[:len(valid)-1],
+			expected: io.ErrUnexpectedEOF,
+		}, {
+			desc:     "no magic",
+			input:    modify(0, 'X'),
+			expected: ErrCorruptEnvelope,
+		}, {
+			desc:     "unknown format",
+			input:    modify(3, 'X'),
+			expected: ErrCorruptEnvelope,
+		}, {
+			desc:     "checksum",
+			input:    modify(len(valid)-1, 'X'),
+			expected: ErrCorruptEnvelope,
+		}, {
+			desc:     "too large",
+			input:    valid,
+			max:      10,
+			expected: ErrEnvelopeTooLarge,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			max := tc.max
+			if max == 0 {
+				max = 1024
+			}
+
+			msg, _, err := ReadEnvelope(bytes.NewReader(tc.input), max)
+			assert.ErrorIs(t, err, tc.expected)
+			assert.Nil(t, msg)
+		})
+	}
+}
+
+func TestEnvelopeReader(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	corrupt := envelope(t, envelopeMessage("corrupt"), Msgpack)
+	corrupt[len(corrupt)-2] ^= 0xff
+
+	// the length of this envelope claims more than the rest of the stream
+	truncated := envelope(t, envelopeMessage("truncated"), JSON)
+
+	var stream []byte
+	stream = append(stream, "garbage WR"...)
+	stream = append(stream, envelope(t, envelopeMessage("1"), Msgpack)...)
+	stream = append(stream, corrupt...)
+	stream = append(stream, envelope(t, envelopeMessage("2"), JSON)...)
+	stream = append(stream, "WRPX"...)
+	stream = append(stream, envelope(t, envelopeMessage("3"), Msgpack)...)
+	stream = append(stream, truncated[:len(truncated)/2]...)
+
+	er := NewEnvelopeReader(bytes.NewReader(stream), 1024)
+
+	var (
+		messages []*Message
+		formats  []Format
+	)
+
+	for {
+		msg, f, err := er.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(err)
+		messages = append(messages, msg)
+		formats = append(formats, f)
+	}
+
+	assert.Equal([]*Message{envelopeMessage("1"), envelopeMessage("2"), envelopeMessage("3")}, messages)
+	assert.Equal([]Format{Msgpack, JSON, Msgpack}, formats)
+	assert.Equal(int64(len("garbage WR")+len(corrupt)+len("WRPX")+len(truncated)/2), er.Skipped())
+}
+
+func TestEnvelopeReader_readError(t *testing.T) {
+	errRead := errors.New("expected")
+	er := NewEnvelopeReader(io.MultiReader(
+		bytes.NewReader(envelope(t, envelopeMessage("1"), Msgpack)[:8]),
+		iotestErrReader{err: errRead},
+	), 1024)
+
+	_, _, err := er.Read()
+	assert.ErrorIs(t, err, errRead)
+	assert.Zero(t, er.Skipped())
+}
+
+type iotestErrReader struct {
+	err error
+}
+
+func (r iotestErrReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
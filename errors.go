@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorCode is a machine readable classification of an Error, suitable for
+// metrics labels and for switching on in services.
+type ErrorCode string
+
+const (
+	// CodeInvalidLocator indicates a locator, such as a Source or
+	// Destination, that could not be parsed or failed validation.
+	CodeInvalidLocator ErrorCode = "invalid_locator"
+
+	// CodePayloadTooLarge indicates a payload larger than the allowed size.
+	CodePayloadTooLarge ErrorCode = "payload_too_large"
+
+	// CodeMessageTooLarge indicates a message larger than the allowed size
+	// even without its payload.
+	CodeMessageTooLarge ErrorCode = "message_too_large"
+
+	// CodeNotUTF8 indicates a string field that is not valid UTF-8.
+	CodeNotUTF8 ErrorCode = "not_utf8"
+
+	// CodeUnsupportedField indicates a field that is unknown or cannot be
+	// used in the given context.
+	CodeUnsupportedField ErrorCode = "unsupported_field"
+
+	// CodeInvalidMessageType indicates a message type that is not valid.
+	CodeInvalidMessageType ErrorCode = "invalid_message_type"
+
+	// CodeMissingField indicates a field that is required but not set.
+	CodeMissingField ErrorCode = "missing_field"
+
+	// CodeInvalidValue indicates a field, or a metadata entry, whose value
+	// cannot be parsed.
+	CodeInvalidValue ErrorCode = "invalid_value"
+
+	// CodeExpired indicates a message that is past its expiry.
+	CodeExpired ErrorCode = "expired"
+
+	// CodeInvalidPartner indicates a message without an allowed partner ID.
+	CodeInvalidPartner ErrorCode = "invalid_partner"
+)
+
+var ErrPayloadTooLarge = errors.New("payload too large")
+
+// Error is an error produced by this package and its subpackages that carries
+// a machine readable code and the name of the offending field, if any, along
+// with the underlying cause.  The cause is available through errors.Is and
+// errors.As as usual, so existing sentinel errors such as ErrNotUTF8 continue
+// to match.
+//
+// Use ErrorCodeOf to get the code of any error.
+type Error struct {
+	// Code classifies the error.
+	Code ErrorCode
+
+	// Field is the name of the Message field that caused the error, e.g.
+	// "Source", or the empty string if the error is not about a single field.
+	Field string
+
+	// Err is the underlying cause.
+	Err error
+}
+
+// newError creates an *Error for the given cause.
+func newError(code ErrorCode, field string, err error) *Error {
+	return &Error{
+		Code:  code,
+		Field: field,
+		Err:   err,
+	}
+}
+
+// Error returns the message of the underlying cause.
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+
+	if e.Field != "" {
+		return fmt.Sprintf("%s: %s", e.Code, e.Field)
+	}
+
+	return string(e.Code)
+}
+
+// Unwrap returns the underlying cause.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is returns true if the target is an *Error with the same code and either the
+// same field or no field.  This allows, for example:
+//
+//	errors.Is(err, &wrp.Error{Code: wrp.CodeNotUTF8})
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+
+	return t.Code == e.Code && (t.Field == "" || t.Field == e.Field)
+}
+
+// ErrorCodeOf returns the code of the first *Error in the error's tree, or the
+// empty string if there is none.
+func ErrorCodeOf(err error) ErrorCode {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+
+	return ""
+}
+
+// ErrorFieldOf returns the field of the first *Error in the error's tree, or
+// the empty string if there is none.
+func ErrorFieldOf(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Field
+	}
+
+	return ""
+}
@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError(t *testing.T) {
+	assert := assert.New(t)
+
+	cause := errors.New("cause")
+	err := fmt.Errorf("wrapped: %w", newError(CodeNotUTF8, "Source", cause))
+
+	assert.ErrorIs(err, cause)
+	assert.ErrorIs(err, &Error{Code: CodeNotUTF8})
+	assert.ErrorIs(err, &Error{Code: CodeNotUTF8, Field: "Source"})
+	assert.NotErrorIs(err, &Error{Code: CodeNotUTF8, Field: "Destination"})
+	assert.NotErrorIs(err, &Error{Code: CodeInvalidLocator})
+	assert.Equal("wrapped: cause", err.Error())
+
+	assert.Equal(CodeNotUTF8, ErrorCodeOf(err))
+	assert.Equal("Source", ErrorFieldOf(err))
+	assert.Empty(ErrorCodeOf(cause))
+	assert.Empty(ErrorFieldOf(cause))
+	assert.Empty(ErrorCodeOf(nil))
+
+	assert.Equal("not_utf8: Source", (&Error{Code: CodeNotUTF8, Field: "Source"}).Error())
+	assert.Equal("not_utf8", (&Error{Code: CodeNotUTF8}).Error())
+}
+
+func TestErrorCodes(t *testing.T) {
+	tests := []struct {
+		desc     string
+		err      error
+		code     ErrorCode
+		field    string
+		sentinel error
+	}{
+		{
+			desc:     "UTF8",
+			err:      UTF8(Message{Destination: invalidUTF8}),
+			code:     CodeNotUTF8,
+			field:    "Destination",
+			sentinel: ErrNotUTF8,
+		}, {
+			desc:     "UTF8Sanitizer",
+			err:      UTF8Sanitizer{}.Sanitize(&Message{Headers: []string{invalidUTF8}}),
+			code:     CodeNotUTF8,
+			field:    "Headers",
+			sentinel: ErrNotUTF8,
+		}, {
+			desc: "ParseLocator",
+			err: func() error {
+				_, err := ParseLocator("invalid")
+				return err
+			}(),
+			code:     CodeInvalidLocator,
+			sentinel: ErrorInvalidLocator,
+		}, {
+			desc:     "ValidateSource",
+			err:      NewNormifier(ValidateSource()).Normify(&Message{Source: "invalid"}),
+			code:     CodeInvalidLocator,
+			field:    "Source",
+			sentinel: ErrInvalidSource,
+		}, {
+			desc:     "ValidateDestinationDNS",
+			err:      NewNormifier(ValidateDestinationDNS()).Normify(&Message{Destination: "dns:-bad"}),
+			code:     CodeInvalidLocator,
+			field:    "Destination",
+			sentinel: ErrInvalidHostname,
+		}, {
+			desc:     "ValidateMessageType",
+			err:      NewNormifier(ValidateMessageType()).Normify(&Message{}),
+			code:     CodeInvalidMessageType,
+			field:    "Type",
+			sentinel: ErrInvalidMessageType,
+		}, {
+			desc:     "ValidatePayloadSize",
+			err:      NewNormifier(ValidatePayloadSize(2)).Normify(&Message{Payload: []byte("abc")}),
+			code:     CodePayloadTooLarge,
+			field:    "Payload",
+			sentinel: ErrPayloadTooLarge,
+		}, {
+			desc:     "ValidateIsPartner",
+			err:      NewNormifier(ValidateIsPartner("comcast")).Normify(&Message{PartnerIDs: []string{"sky"}}),
+			code:     CodeInvalidPartner,
+			field:    "PartnerIDs",
+			sentinel: ErrInvalidPartnerID,
+		}, {
+			desc:     "ValidateHasPartner",
+			err:      NewNormifier(ValidateHasPartner("comcast")).Normify(&Message{}),
+			code:     CodeInvalidPartner,
+			field:    "PartnerIDs",
+			sentinel: ErrInvalidPartnerID,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			assert.ErrorIs(tc.err, tc.sentinel)
+			assert.Equal(tc.code, ErrorCodeOf(tc.err))
+			assert.Equal(tc.field, ErrorFieldOf(tc.err))
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const upperHex = "0123456789ABCDEF"
+
+// EncodeLocatorSegment percent-encodes the characters that cannot appear as is
+// in the authority or service of a locator: `/`, which separates the parts of a
+// locator, `%`, which starts an escape, whitespace, which is trimmed when a
+// locator is parsed, and control characters.  Each byte of such a character is
+// written as %XX using upper case hex digits.  All other characters, including
+// non-ASCII ones, are kept, so typical segments are unchanged.
+func EncodeLocatorSegment(s string) string {
+	if strings.IndexFunc(s, needsEscape) < 0 && utf8.ValidString(s) {
+		return s
+	}
+
+	var buf strings.Builder
+	buf.Grow(len(s) + 8)
+	for len(s) > 0 {
+		r, size := utf8.DecodeRuneInString(s)
+		if r == utf8.RuneError && size == 1 || needsEscape(r) {
+			for i := 0; i < size; i++ {
+				buf.WriteByte('%')
+				buf.WriteByte(upperHex[s[i]>>4])
+				buf.WriteByte(upperHex[s[i]&0x0f])
+			}
+		} else {
+			buf.WriteString(s[:size])
+		}
+
+		s = s[size:]
+	}
+
+	return buf.String()
+}
+
+func needsEscape(r rune) bool {
+	return r == '/' || r == '%' || unicode.IsSpace(r) || unicode.IsControl(r)
+}
+
+// DecodeLocatorSegment reverses EncodeLocatorSegment.  Any %XX escape is
+// decoded, so segments encoded by other implementations are also accepted.  A
+// malformed escape results in an *Error with the code CodeInvalidLocator.
+func DecodeLocatorSegment(s string) (string, error) {
+	if !strings.Contains(s, "%") {
+		return s, nil
+	}
+
+	decoded, err := url.PathUnescape(s)
+	if err != nil {
+		return "", newError(CodeInvalidLocator, "", fmt.Errorf("%w: %w", ErrorInvalidLocator, err))
+	}
+
+	return decoded, nil
+}
+
+// Encode returns the string form of the locator with its authority and
+// service encoded by EncodeLocatorSegment, so that the result can always be
+// parsed by DecodeLocator, even if the authority or service contain `/` or
+// whitespace, as serial numbers and event names sometimes do.  The ignored
+// part is written as is.
+func (l Locator) Encode() string {
+	l.Authority = EncodeLocatorSegment(l.Authority)
+	l.Service = EncodeLocatorSegment(l.Service)
+	return l.String()
+}
+
+// DecodeLocator parses a locator written by Locator.Encode.  It is the same as
+// ParseLocator, except that the authority and service are decoded with
+// DecodeLocatorSegment, and the device ID, if any, is made from the decoded
+// authority.  Any error is an *Error with the code CodeInvalidLocator.
+func DecodeLocator(locator string) (Locator, error) {
+	l, err := ParseLocator(locator)
+	if err != nil {
+		return Locator{}, err
+	}
+
+	if l.Authority, err = DecodeLocatorSegment(l.Authority); err != nil {
+		return Locator{}, err
+	}
+
+	if l.Service, err = DecodeLocatorSegment(l.Service); err != nil {
+		return Locator{}, err
+	}
+
+	if l.HasDeviceID() {
+		if l.ID, err = makeDeviceID(l.Scheme, l.Authority); err != nil {
+			return Locator{}, newError(CodeInvalidLocator, "", fmt.Errorf("%w: unable to make a device ID with scheme `%s` and authority `%s`", err, l.Scheme, l.Authority))
+		}
+	}
+
+	return l, nil
+}
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeLocatorSegment(t *testing.T) {
+	tests := []struct {
+		desc    string
+		segment string
+		encoded string
+	}{
+		{
+			desc: "empty",
+		}, {
+			desc:    "unchanged",
+			segment: "ABC-123_x.y:z",
+			encoded: "ABC-123_x.y:z",
+		}, {
+			desc:    "non-ascii is kept",
+			segment: "café",
+			encoded: "café",
+		}, {
+			desc:    "slash",
+			segment: "AB/12",
+			encoded: "AB%2F12",
+		}, {
+			desc:    "percent",
+			segment: "100%",
+			encoded: "100%25",
+		}, {
+			desc:    "whitespace",
+			segment: " a\tb\u00a0",
+			encoded: "%20a%09b%C2%A0",
+		}, {
+			desc:    "control",
+			segment: "a\x00b\x7f",
+			encoded: "a%00b%7F",
+		}, {
+			desc:    "invalid utf8",
+			segment: "a\xffb",
+			encoded: "a%FFb",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			assert.Equal(tc.encoded, EncodeLocatorSegment(tc.segment))
+
+			decoded, err := DecodeLocatorSegment(tc.encoded)
+			assert.NoError(err)
+			assert.Equal(tc.segment, decoded)
+		})
+	}
+}
+
+func TestDecodeLocatorSegment_invalid(t *testing.T) {
+	for _, s := range []string{"%", "%2", "%zz", "a%2Fb%"} {
+		t.Run(s, func(t *testing.T) {
+			_, err := DecodeLocatorSegment(s)
+			assert.ErrorIs(t, err, ErrorInvalidLocator)
+			assert.Equal(t, CodeInvalidLocator, ErrorCodeOf(err))
+		})
+	}
+}
+
+func TestLocator_Encode(t *testing.T) {
+	tests := []struct {
+		desc     string
+		locator  Locator
+		encoded  string
+		expected Locator
+	}{
+		{
+			desc:     "plain locators are unchanged",
+			locator:  Locator{Scheme: SchemeMAC, Authority: "112233445566", Service: "config", Ignored: "/a/b"},
+			encoded:  "mac:112233445566/config/a/b",
+			expected: Locator{Scheme: SchemeMAC, Authority: "112233445566", Service: "config", Ignored: "/a/b", ID: "mac:112233445566"},
+		}, {
+			desc:     "serial with a slash and a space",
+			locator:  Locator{Scheme: SchemeSerial, Authority: "AB/12 34", Service: "config"},
+			encoded:  "serial:AB%2F12%2034/config",
+			expected: Locator{Scheme: SchemeSerial, Authority: "AB/12 34", Service: "config", ID: "serial:AB/12 34"},
+		}, {
+			desc:     "service with a slash",
+			locator:  Locator{Scheme: SchemeDNS, Authority: "example.com", Service: "v1/api", Ignored: "/rest"},
+			encoded:  "dns:example.com/v1%2Fapi/rest",
+			expected: Locator{Scheme: SchemeDNS, Authority: "example.com", Service: "v1/api", Ignored: "/rest"},
+		}, {
+			desc:     "event name with whitespace",
+			locator:  Locator{Scheme: SchemeEvent, Authority: " device status ", Ignored: "/mac:112233445566"},
+			encoded:  "event:%20device%20status%20/mac:112233445566",
+			expected: Locator{Scheme: SchemeEvent, Authority: " device status ", Ignored: "/mac:112233445566"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			encoded := tc.locator.Encode()
+			assert.Equal(tc.encoded, encoded)
+
+			decoded, err := DecodeLocator(encoded)
+			require.NoError(err)
+			assert.Equal(tc.expected, decoded)
+			assert.Equal(encoded, decoded.Encode())
+		})
+	}
+}
+
+func TestLocator_String_unparseable(t *testing.T) {
+	// String does not escape, so this locator does not survive a round trip
+	l := Locator{Scheme: SchemeSerial, Authority: "AB/12", Service: "config"}
+	parsed, err := ParseLocator(l.String())
+	require.NoError(t, err)
+	assert.NotEqual(t, l.Authority, parsed.Authority)
+
+	decoded, err := DecodeLocator(l.Encode())
+	require.NoError(t, err)
+	assert.Equal(t, l.Authority, decoded.Authority)
+}
+
+func TestDecodeLocator_invalid(t *testing.T) {
+	for _, s := range []string{"unknown:thing", "serial:AB%zz", "dns:example.com/bad%", "mac:%20"} {
+		t.Run(s, func(t *testing.T) {
+			_, err := DecodeLocator(s)
+			assert.Equal(t, CodeInvalidLocator, ErrorCodeOf(err))
+		})
+	}
+}
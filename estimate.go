@@ -0,0 +1,333 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"encoding/base64"
+	"math"
+	"strconv"
+	"unicode/utf8"
+)
+
+// EncodedSizeEstimate returns the size of the message when encoded in the
+// given format, computed from the lengths of its fields without encoding the
+// message.  It is much cheaper than Size, so queueing layers can use it to
+// make admission decisions and to size buffers before encoding.
+//
+// The estimate is exact for the default encoding of each format.  Encoder
+// options that change the encoding, such as WithJSONIntegers, are not taken
+// into account.  This method panics if the format is not a valid value.
+func (msg *Message) EncodedSizeEstimate(f Format) int {
+	sizes := estimateSizes(msg, f)
+	return sizes.total()
+}
+
+// EncodedSizeHint returns the capacity of a buffer to encode the value into in
+// the given format, or 0 if there is no hint for the value.  Messages, as
+// values or pointers, are sized with EncodedSizeEstimate, as is the Message of
+// a PreservingMessage.  Encoders created with NewEncoderBytes use the hint to
+// size their buffer, so large messages are encoded without growing it.
+func EncodedSizeHint(value interface{}, f Format) int {
+	switch v := value.(type) {
+	case *Message:
+		if v != nil {
+			return v.EncodedSizeEstimate(f)
+		}
+	case Message:
+		return v.EncodedSizeEstimate(f)
+	case *PreservingMessage:
+		if v != nil {
+			return v.Message.EncodedSizeEstimate(f)
+		}
+	case PreservingMessage:
+		return v.Message.EncodedSizeEstimate(f)
+	}
+
+	return 0
+}
+
+// messageSizes holds the encoded size of each field of a message, including
+// its key, and the size of the rest of the encoding, e.g. the msgpack map
+// header or the JSON braces and commas.
+type messageSizes struct {
+	overhead int
+	fields   [lastField]int
+}
+
+func (s *messageSizes) total() int {
+	n := s.overhead
+	for _, f := range s.fields {
+		n += f
+	}
+
+	return n
+}
+
+// largest returns the largest field, other than the excluded one.
+func (s *messageSizes) largest(exclude Field) Field {
+	largest := exclude
+	for i, n := range s.fields {
+		f := Field(i)
+		if f != exclude && (largest == exclude || n > s.fields[largest]) {
+			largest = f
+		}
+	}
+
+	return largest
+}
+
+// sizer computes the encoded size of values in a single format.
+type sizer interface {
+	str(v string) int
+	bin(n int) int
+	integer(v int64) int
+	boolean(v bool) int
+
+	// array returns the size of an array of n elements, whose sizes add up
+	// to elems.
+	array(n, elems int) int
+
+	// object returns the size of a map of n entries, whose sizes add up to
+	// entries.
+	object(n, entries int) int
+
+	// entry returns the size of a map entry, given the sizes of its key and
+	// value.
+	entry(key, value int) int
+}
+
+func sizerOf(f Format) sizer {
+	switch f {
+	case Msgpack:
+		return msgpackSizer{}
+	case JSON:
+		return jsonSizer{}
+	}
+
+	panic("Invalid format")
+}
+
+// estimateSizes computes the size of each field of the message as encoded in
+// the format.  Empty fields are omitted, except for the Type and
+// QualityOfService, which are always encoded.
+func estimateSizes(msg *Message, f Format) messageSizes {
+	var (
+		s  messageSizes
+		sz = sizerOf(f)
+	)
+
+	optionalStr := func(v string) int {
+		if v == "" {
+			return 0
+		}
+		return sz.str(v)
+	}
+
+	strs := func(v []string) int {
+		var n int
+		for _, s := range v {
+			n += sz.str(s)
+		}
+		return sz.array(len(v), n)
+	}
+
+	s.fields[TypeField] = sz.integer(int64(msg.Type))
+	s.fields[SourceField] = optionalStr(msg.Source)
+	s.fields[DestinationField] = optionalStr(msg.Destination)
+	s.fields[TransactionUUIDField] = optionalStr(msg.TransactionUUID)
+	s.fields[ContentTypeField] = optionalStr(msg.ContentType)
+	s.fields[AcceptField] = optionalStr(msg.Accept)
+	if msg.Status != nil {
+		s.fields[StatusField] = sz.integer(*msg.Status)
+	}
+	if msg.RequestDeliveryResponse != nil {
+		s.fields[RequestDeliveryResponseField] = sz.integer(*msg.RequestDeliveryResponse)
+	}
+	if len(msg.Headers) > 0 {
+		s.fields[HeadersField] = strs(msg.Headers)
+	}
+	if len(msg.Metadata) > 0 {
+		var n int
+		for k, v := range msg.Metadata {
+			n += sz.entry(sz.str(k), sz.str(v))
+		}
+		s.fields[MetadataField] = sz.object(len(msg.Metadata), n)
+	}
+	if len(msg.Spans) > 0 {
+		var n int
+		for _, span := range msg.Spans {
+			n += strs(span)
+		}
+		s.fields[SpansField] = sz.array(len(msg.Spans), n)
+	}
+	if msg.IncludeSpans != nil {
+		s.fields[IncludeSpansField] = sz.boolean(*msg.IncludeSpans)
+	}
+	s.fields[PathField] = optionalStr(msg.Path)
+	if len(msg.Payload) > 0 {
+		s.fields[PayloadField] = sz.bin(len(msg.Payload))
+	}
+	s.fields[ServiceNameField] = optionalStr(msg.ServiceName)
+	s.fields[URLField] = optionalStr(msg.URL)
+	if len(msg.PartnerIDs) > 0 {
+		s.fields[PartnerIDsField] = strs(msg.PartnerIDs)
+	}
+	s.fields[SessionIDField] = optionalStr(msg.SessionID)
+	s.fields[QualityOfServiceField] = sz.integer(int64(msg.QualityOfService))
+
+	var present int
+	schema := messageWireSchema()
+	for i, n := range s.fields {
+		if n > 0 {
+			s.fields[i] = sz.entry(sz.str(schema.Fields[i].Name), n)
+			present++
+		}
+	}
+	s.overhead = sz.object(present, 0)
+
+	return s
+}
+
+// msgpackSizer computes sizes for the msgpack handle of this package.
+type msgpackSizer struct{}
+
+func (msgpackSizer) str(v string) int {
+	n := len(v)
+	switch {
+	case n < 32:
+		return 1 + n
+	case n <= math.MaxUint8:
+		return 2 + n
+	case n <= math.MaxUint16:
+		return 3 + n
+	}
+
+	return 5 + n
+}
+
+func (msgpackSizer) bin(n int) int {
+	switch {
+	case n <= math.MaxUint8:
+		return 2 + n
+	case n <= math.MaxUint16:
+		return 3 + n
+	}
+
+	return 5 + n
+}
+
+// integer returns the size of a signed integer, which is encoded with the
+// smallest signed representation that holds it.
+func (msgpackSizer) integer(v int64) int {
+	switch {
+	case v >= -32 && v <= math.MaxInt8:
+		return 1
+	case v >= math.MinInt8 && v < 0:
+		return 2
+	case v >= math.MinInt16 && v <= math.MaxInt16:
+		return 3
+	case v >= math.MinInt32 && v <= math.MaxInt32:
+		return 5
+	}
+
+	return 9
+}
+
+func (msgpackSizer) boolean(bool) int {
+	return 1
+}
+
+func (msgpackSizer) array(n, elems int) int {
+	return msgpackCollectionHeader(n) + elems
+}
+
+func (msgpackSizer) object(n, entries int) int {
+	return msgpackCollectionHeader(n) + entries
+}
+
+func (msgpackSizer) entry(key, value int) int {
+	return key + value
+}
+
+// msgpackCollectionHeader returns the size of an array or map header.
+func msgpackCollectionHeader(n int) int {
+	switch {
+	case n < 16:
+		return 1
+	case n <= math.MaxUint16:
+		return 3
+	}
+
+	return 5
+}
+
+// jsonSizer computes sizes for the JSON handle of this package.
+type jsonSizer struct{}
+
+// str returns the size of a quoted string.  The HTML characters <, > and &,
+// the line and paragraph separators, control characters and invalid UTF-8
+// are escaped as \uXXXX.
+func (jsonSizer) str(v string) int {
+	n := 2
+	for i := 0; i < len(v); {
+		c := v[i]
+		if c < utf8.RuneSelf {
+			switch {
+			case c == '"' || c == '\\' || c == '\b' || c == '\f' || c == '\n' || c == '\r' || c == '\t':
+				n += 2
+			case c < 0x20 || c == '<' || c == '>' || c == '&':
+				n += 6
+			default:
+				n++
+			}
+			i++
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(v[i:])
+		if (r == utf8.RuneError && size == 1) || r == '\u2028' || r == '\u2029' {
+			n += 6
+		} else {
+			n += size
+		}
+		i += size
+	}
+
+	return n
+}
+
+func (jsonSizer) bin(n int) int {
+	return 2 + base64.StdEncoding.EncodedLen(n)
+}
+
+// integer returns the size of an integer, which is quoted if it is beyond the
+// integers a JavaScript number holds exactly.
+func (jsonSizer) integer(v int64) int {
+	n := len(strconv.AppendInt(make([]byte, 0, 20), v, 10))
+	if v > maxSafeJSONInteger+1 || v < -(maxSafeJSONInteger+1) {
+		n += 2
+	}
+
+	return n
+}
+
+func (jsonSizer) boolean(v bool) int {
+	if v {
+		return 4
+	}
+
+	return 5
+}
+
+func (jsonSizer) array(n, elems int) int {
+	return 2 + elems + max(n-1, 0)
+}
+
+func (jsonSizer) object(n, entries int) int {
+	return 2 + entries + max(n-1, 0)
+}
+
+func (jsonSizer) entry(key, value int) int {
+	return key + 1 + value
+}
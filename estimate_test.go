@@ -0,0 +1,214 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessage_EncodedSizeEstimate(t *testing.T) {
+	many := func(n int) []string {
+		s := make([]string, n)
+		for i := range s {
+			s[i] = strconv.Itoa(i)
+		}
+		return s
+	}
+
+	manyMetadata := make(map[string]string)
+	for i := 0; i < 20; i++ {
+		manyMetadata["/key-"+strconv.Itoa(i)] = strings.Repeat("v", i*20)
+	}
+
+	tests := []struct {
+		desc string
+		msg  Message
+	}{
+		{
+			desc: "empty",
+		}, {
+			desc: "simple request",
+			msg: Message{
+				Type:            SimpleRequestResponseMessageType,
+				Source:          "dns:talaria",
+				Destination:     "mac:112233445566/service",
+				TransactionUUID: "b3b9d3ba-2f8c-4d5c-9b3c-1a8e5f0a0b1c",
+				ContentType:     "application/json",
+				Accept:          "application/json",
+				Payload:         []byte(`{"command":"GET"}`),
+				PartnerIDs:      []string{"comcast"},
+			},
+		}, {
+			desc: "every field",
+			msg: Message{
+				Type:                    SimpleEventMessageType,
+				Source:                  "mac:112233445566",
+				Destination:             "event:device-status",
+				TransactionUUID:         "1234",
+				ContentType:             "text/plain",
+				Accept:                  "text/plain",
+				Status:                  int64Ptr(-1),
+				RequestDeliveryResponse: int64Ptr(-40000),
+				Headers:                 []string{"X-A: 1", "X-B: 2"},
+				Metadata:                map[string]string{"/boot-time": "1700000000", "/hw-model": "x"},
+				Spans:                   [][]string{{"a", "1", "2"}, {}},
+				IncludeSpans:            ptrBool(false),
+				Path:                    "/config",
+				Payload:                 []byte{0x00, 0xff},
+				ServiceName:             "config",
+				URL:                     "http://example.com/",
+				PartnerIDs:              []string{"a", "b"},
+				SessionID:               "session",
+				QualityOfService:        99,
+			},
+		}, {
+			desc: "integer boundaries",
+			msg: Message{
+				Type:                    MessageType(200),
+				Status:                  int64Ptr(32768),
+				RequestDeliveryResponse: int64Ptr(1 << 40),
+				QualityOfService:        QOSValue(-100),
+			},
+		}, {
+			desc: "more integer boundaries",
+			msg: Message{
+				Status:                  int64Ptr(-(1 << 40)),
+				RequestDeliveryResponse: int64Ptr(-32),
+				QualityOfService:        QOSValue(300),
+			},
+		}, {
+			desc: "large integers",
+			msg: Message{
+				Status:                  int64Ptr(1 << 53),
+				RequestDeliveryResponse: int64Ptr(1<<53 + 1),
+			},
+		}, {
+			desc: "large negative integers",
+			msg: Message{
+				Status:                  int64Ptr(-(1 << 53)),
+				RequestDeliveryResponse: int64Ptr(-(1<<53 + 1)),
+				IncludeSpans:            ptrBool(true),
+			},
+		}, {
+			desc: "escaped strings",
+			msg: Message{
+				Source:      "<script>&\"quoted\"\\\b\f\n\r\t\x01\x1f\x7f",
+				Destination: "caf\u00e9 \u2028\u2029 \xff\xfe 日本",
+				Metadata:    map[string]string{"/a<b": "\x00"},
+			},
+		}, {
+			desc: "long strings",
+			msg: Message{
+				Source:          strings.Repeat("s", 31),
+				Destination:     strings.Repeat("d", 32),
+				TransactionUUID: strings.Repeat("t", 255),
+				ContentType:     strings.Repeat("c", 256),
+				Path:            strings.Repeat("p", 65535),
+				URL:             strings.Repeat("u", 65536),
+			},
+		}, {
+			desc: "small payload",
+			msg:  Message{Payload: make([]byte, 255)},
+		}, {
+			desc: "medium payload",
+			msg:  Message{Payload: make([]byte, 256)},
+		}, {
+			desc: "large payload",
+			msg:  Message{Payload: make([]byte, 70000)},
+		}, {
+			desc: "long collections",
+			msg: Message{
+				Headers:    many(16),
+				PartnerIDs: many(15),
+				Metadata:   manyMetadata,
+				Spans:      [][]string{many(20)},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		for _, f := range AllFormats() {
+			t.Run(tc.desc+"/"+f.String(), func(t *testing.T) {
+				assert.Equal(t, tc.msg.Size(f), tc.msg.EncodedSizeEstimate(f))
+			})
+		}
+	}
+
+	assert.Panics(t, func() {
+		new(Message).EncodedSizeEstimate(Format(-1))
+	})
+}
+
+func BenchmarkMessage_EncodedSizeEstimate(b *testing.B) {
+	msg := Message{
+		Type:            SimpleRequestResponseMessageType,
+		Source:          "dns:talaria",
+		Destination:     "mac:112233445566/service",
+		TransactionUUID: "b3b9d3ba-2f8c-4d5c-9b3c-1a8e5f0a0b1c",
+		Metadata:        map[string]string{"/boot-time": "1700000000"},
+		Payload:         make([]byte, 1024),
+	}
+
+	for _, f := range AllFormats() {
+		b.Run("EncodedSizeEstimate/"+f.String(), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = msg.EncodedSizeEstimate(f)
+			}
+		})
+
+		b.Run("Size/"+f.String(), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = msg.Size(f)
+			}
+		})
+	}
+}
+
+func TestEncodedSizeHint(t *testing.T) {
+	metadata := make(map[string]string)
+	for i := 0; i < 50; i++ {
+		metadata["/key-"+strconv.Itoa(i)] = strings.Repeat("v", 100)
+	}
+
+	msg := Message{
+		Type:     SimpleEventMessageType,
+		Source:   "dns:talaria",
+		Metadata: metadata,
+	}
+
+	for _, f := range []Format{Msgpack, JSON} {
+		t.Run(f.String(), func(t *testing.T) {
+			assert := assert.New(t)
+
+			expected := msg.EncodedSizeEstimate(f)
+			assert.Equal(expected, EncodedSizeHint(msg, f))
+			assert.Equal(expected, EncodedSizeHint(&msg, f))
+			assert.Equal(expected, EncodedSizeHint(PreservingMessage{Message: msg}, f))
+			assert.Equal(expected, EncodedSizeHint(&PreservingMessage{Message: msg}, f))
+			assert.Zero(EncodedSizeHint((*Message)(nil), f))
+			assert.Zero(EncodedSizeHint("not a message", f))
+
+			var output []byte
+			encoder := NewEncoderBytes(&output, f)
+			assert.NoError(encoder.Encode(&msg))
+			assert.Len(output, expected)
+			assert.Equal(expected, cap(output))
+
+			// A buffer with enough capacity is reused.
+			buffer := make([]byte, 0, 2*expected)
+			encoder.ResetBytes(&buffer)
+			assert.NoError(encoder.Encode(msg))
+			assert.Len(buffer, expected)
+			assert.Equal(2*expected, cap(buffer))
+
+			var decoded Message
+			assert.NoError(NewDecoderBytes(buffer, f).Decode(&decoded))
+			assert.Equal(msg, decoded)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+var ErrEmptyGroup = errors.New("group locator has no members")
+
+// Expander resolves a group locator, a destination that stands for a set of
+// devices, into the locators of its members.  The routing layer implements
+// Expander, since only it knows the members of a group.
+type Expander interface {
+	// Expand returns the members of the group.  The locators returned may
+	// omit the service, in which case the service of the group is used.
+	Expand(ctx context.Context, group Locator) ([]Locator, error)
+}
+
+// ExpanderFunc is a convenience type to define an Expander using a function.
+type ExpanderFunc func(context.Context, Locator) ([]Locator, error)
+
+func (f ExpanderFunc) Expand(ctx context.Context, group Locator) ([]Locator, error) {
+	return f(ctx, group)
+}
+
+// IsGroupLocator returns true if the locator may stand for a set of devices,
+// i.e. it uses the dns or event scheme.
+func IsGroupLocator(l Locator) bool {
+	return l.Scheme == SchemeDNS || l.Scheme == SchemeEvent
+}
+
+// ExpandOption is a functional option for ExpandMessage.
+type ExpandOption interface {
+	apply(*expandOptions)
+}
+
+type expandOptionFunc func(*expandOptions)
+
+func (f expandOptionFunc) apply(o *expandOptions) {
+	f(o)
+}
+
+// ExpandIDs sets the generator of the transaction UUIDs of clones of messages
+// that require a transaction but have none.  The default is UUIDGenerator.
+func ExpandIDs(ids IDGenerator) ExpandOption {
+	return expandOptionFunc(func(o *expandOptions) {
+		if ids != nil {
+			o.ids = ids
+		}
+	})
+}
+
+type expandOptions struct {
+	ids IDGenerator
+}
+
+// ExpandMessage fans the message out to the members of its Destination.  If
+// the Destination is a group locator, a clone of the message is returned for
+// each distinct member returned by the Expander, with the canonical form of
+// the member as its Destination.  Otherwise, the message itself is returned as
+// the only element.
+//
+// Each clone of a message with a TransactionUUID gets the transaction UUID
+// returned by ExpandedTransactionUUID, so that responses from the members can
+// be told apart.  Clones of a message whose type requires a transaction, but
+// that has none, each get a new transaction UUID from the IDGenerator,
+// UUIDGenerator by default.
+//
+// An Expander error is returned as is.  A group without members is an *Error
+// with the code CodeInvalidLocator wrapping ErrEmptyGroup.
+func ExpandMessage(ctx context.Context, msg *Message, e Expander, opts ...ExpandOption) ([]*Message, error) {
+	o := expandOptions{
+		ids: UUIDGenerator,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt.apply(&o)
+		}
+	}
+
+	group, err := ParseLocator(msg.Destination)
+	if err != nil {
+		return nil, newError(CodeInvalidLocator, "Destination", err)
+	}
+
+	if !IsGroupLocator(group) {
+		return []*Message{msg}, nil
+	}
+
+	members, err := e.Expand(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(members))
+	expanded := make([]*Message, 0, len(members))
+	for _, m := range members {
+		if m.Service == "" {
+			m.Service = group.Service
+		}
+
+		dest := m.Canonical()
+		if _, dup := seen[dest]; dup {
+			continue
+		}
+		seen[dest] = struct{}{}
+
+		clone := msg.ReadOnly().Clone()
+		clone.Destination = dest
+
+		switch {
+		case msg.TransactionUUID != "":
+			clone.TransactionUUID = ExpandedTransactionUUID(msg.TransactionUUID, dest)
+		case msg.Type.RequiresTransaction():
+			id, err := o.ids.NewID()
+			if err != nil {
+				return nil, err
+			}
+			clone.TransactionUUID = id
+		}
+
+		expanded = append(expanded, clone)
+	}
+
+	if len(expanded) == 0 {
+		return nil, newError(CodeInvalidLocator, "Destination",
+			fmt.Errorf("%w: `%s`", ErrEmptyGroup, msg.Destination))
+	}
+
+	return expanded, nil
+}
+
+// ExpandedTransactionUUID returns the transaction UUID of the clone of a
+// message, with the given transaction UUID, sent to the member.  The result
+// is a name based UUID derived from both, so the same inputs always produce
+// the same result.  It is a one way SHA-1 hash: the original transaction UUID
+// cannot be recovered from it, so a router that correlates the responses of
+// the members with the original transaction must record the mapping when it
+// sends the clones.  The member should be in its canonical form.
+func ExpandedTransactionUUID(transactionUUID, member string) string {
+	space, err := uuid.Parse(transactionUUID)
+	if err != nil {
+		// transaction UUIDs are not required to be UUIDs
+		space = uuid.NewSHA1(uuid.NameSpaceOID, []byte(transactionUUID))
+	}
+
+	return uuid.NewSHA1(space, []byte(member)).String()
+}
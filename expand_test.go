@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustLocators(t *testing.T, locators ...string) []Locator {
+	var list []Locator
+	for _, s := range locators {
+		l, err := ParseLocator(s)
+		require.NoError(t, err)
+		list = append(list, l)
+	}
+
+	return list
+}
+
+func TestExpandMessage(t *testing.T) {
+	errExpand := errors.New("expand failed")
+	const txID = "c07ee5e1-70be-444c-a156-097c767ad8aa"
+
+	tests := []struct {
+		desc    string
+		msg     Message
+		members []string
+		expErr  error
+		want    []string
+		wantErr error
+		code    ErrorCode
+	}{
+		{
+			desc:    "dns group",
+			msg:     Message{Type: SimpleRequestResponseMessageType, Destination: "dns:group.example.com/config", TransactionUUID: txID},
+			members: []string{"mac:112233445566", "mac:66:55:44:33:22:11/other"},
+			want:    []string{"mac:112233445566/config", "mac:665544332211/other"},
+		}, {
+			desc:    "event group",
+			msg:     Message{Type: SimpleEventMessageType, Destination: "event:device-status/online"},
+			members: []string{"uuid:b1ef2bd4-3e6f-4e0c-9a6e-9c42b4a1c7d0/iot"},
+			want:    []string{"uuid:b1ef2bd4-3e6f-4e0c-9a6e-9c42b4a1c7d0/iot"},
+		}, {
+			desc:    "duplicate members",
+			msg:     Message{Type: SimpleEventMessageType, Destination: "dns:group.example.com"},
+			members: []string{"mac:112233445566", "mac:11:22:33:44:55:66", "MAC:112233445566"},
+			want:    []string{"mac:112233445566"},
+		}, {
+			desc:    "no members",
+			msg:     Message{Type: SimpleEventMessageType, Destination: "dns:group.example.com"},
+			wantErr: ErrEmptyGroup,
+			code:    CodeInvalidLocator,
+		}, {
+			desc:    "expander error",
+			msg:     Message{Type: SimpleEventMessageType, Destination: "dns:group.example.com"},
+			expErr:  errExpand,
+			wantErr: errExpand,
+		}, {
+			desc:    "invalid destination",
+			msg:     Message{Type: SimpleEventMessageType, Destination: "invalid"},
+			wantErr: ErrorInvalidLocator,
+			code:    CodeInvalidLocator,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			var got Locator
+			e := ExpanderFunc(func(_ context.Context, group Locator) ([]Locator, error) {
+				got = group
+				return mustLocators(t, tc.members...), tc.expErr
+			})
+
+			msg := tc.msg
+			expanded, err := ExpandMessage(context.Background(), &msg, e)
+			if tc.wantErr != nil {
+				assert.ErrorIs(err, tc.wantErr)
+				if tc.code != "" {
+					var wrpErr *Error
+					require.ErrorAs(err, &wrpErr)
+					assert.Equal(tc.code, wrpErr.Code)
+				}
+				assert.Nil(expanded)
+				return
+			}
+
+			require.NoError(err)
+			assert.Equal(tc.msg, msg, "the original must not be modified")
+			assert.Equal(tc.msg.Destination, got.String())
+
+			var dests []string
+			for _, clone := range expanded {
+				dests = append(dests, clone.Destination)
+
+				want := tc.msg
+				want.Destination = clone.Destination
+				if tc.msg.TransactionUUID != "" {
+					want.TransactionUUID = ExpandedTransactionUUID(tc.msg.TransactionUUID, clone.Destination)
+				}
+				assert.Equal(want, *clone)
+			}
+
+			assert.Equal(tc.want, dests)
+		})
+	}
+}
+
+func TestExpandMessage_notGroup(t *testing.T) {
+	msg := Message{Type: SimpleEventMessageType, Destination: "mac:112233445566"}
+	expanded, err := ExpandMessage(context.Background(), &msg, ExpanderFunc(func(context.Context, Locator) ([]Locator, error) {
+		panic("a device locator must not be expanded")
+	}))
+
+	require.NoError(t, err)
+	require.Len(t, expanded, 1)
+	assert.Same(t, &msg, expanded[0])
+}
+
+func TestExpandMessage_transactionUUIDs(t *testing.T) {
+	assert := assert.New(t)
+	e := ExpanderFunc(func(context.Context, Locator) ([]Locator, error) {
+		return mustLocators(t, "mac:112233445566", "mac:665544332211"), nil
+	})
+
+	// a type that requires a transaction gets random transaction UUIDs
+	msg := Message{Type: CreateMessageType, Destination: "dns:group.example.com", Payload: []byte("p")}
+	expanded, err := ExpandMessage(context.Background(), &msg, e)
+	require.NoError(t, err)
+	require.Len(t, expanded, 2)
+	assert.NotEqual(expanded[0].TransactionUUID, expanded[1].TransactionUUID)
+	for _, clone := range expanded {
+		_, err := uuid.Parse(clone.TransactionUUID)
+		assert.NoError(err)
+	}
+
+	// the clones are deep copies
+	expanded[0].Payload[0] = 'x'
+	assert.Equal([]byte("p"), msg.Payload)
+	assert.Equal([]byte("p"), expanded[1].Payload)
+}
+
+func TestExpandMessage_ids(t *testing.T) {
+	assert := assert.New(t)
+	e := ExpanderFunc(func(context.Context, Locator) ([]Locator, error) {
+		return mustLocators(t, "mac:112233445566", "mac:665544332211"), nil
+	})
+
+	var n int
+	ids := IDGeneratorFunc(func() (string, error) {
+		n++
+		return fmt.Sprintf("id-%d", n), nil
+	})
+
+	msg := Message{Type: CreateMessageType, Destination: "dns:group.example.com"}
+	expanded, err := ExpandMessage(context.Background(), &msg, e, ExpandIDs(ids), nil)
+	require.NoError(t, err)
+	require.Len(t, expanded, 2)
+	assert.Equal("id-1", expanded[0].TransactionUUID)
+	assert.Equal("id-2", expanded[1].TransactionUUID)
+
+	errIDs := errors.New("no ids")
+	expanded, err = ExpandMessage(context.Background(), &msg, e, ExpandIDs(IDGeneratorFunc(func() (string, error) {
+		return "", errIDs
+	})))
+	assert.ErrorIs(err, errIDs)
+	assert.Nil(expanded)
+}
+
+func TestExpandedTransactionUUID(t *testing.T) {
+	assert := assert.New(t)
+
+	a := ExpandedTransactionUUID("c07ee5e1-70be-444c-a156-097c767ad8aa", "mac:112233445566")
+	assert.Equal(a, ExpandedTransactionUUID("c07ee5e1-70be-444c-a156-097c767ad8aa", "mac:112233445566"))
+	assert.NotEqual(a, ExpandedTransactionUUID("c07ee5e1-70be-444c-a156-097c767ad8aa", "mac:665544332211"))
+	assert.NotEqual(a, ExpandedTransactionUUID("1e5ca5b2-37f7-4d3b-8b52-4bd9aa4cb4f5", "mac:112233445566"))
+
+	// transaction UUIDs that aren't UUIDs are supported
+	b := ExpandedTransactionUUID("1", "mac:112233445566")
+	_, err := uuid.Parse(b)
+	assert.NoError(err)
+	assert.NotEqual(b, ExpandedTransactionUUID("2", "mac:112233445566"))
+}
+
+func TestIsGroupLocator(t *testing.T) {
+	for s, expected := range map[string]bool{
+		"dns:example.com":                           true,
+		"event:device-status":                       true,
+		"mac:112233445566":                          false,
+		"self:/config":                              false,
+		"serial:1234/config":                        false,
+		"uuid:b1ef2bd4-3e6f-4e0c-9a6e-9c42b4a1c7d0": false,
+	} {
+		l, err := ParseLocator(s)
+		require.NoError(t, err)
+		assert.Equal(t, expected, IsGroupLocator(l), s)
+	}
+}
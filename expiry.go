@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+)
+
+// ExpiresKey is the Metadata key that carries the absolute time after which a
+// message should no longer be delivered or processed.  The WRP spec has no
+// dedicated field for a message's time to live, so the expiry is carried in
+// the Metadata, formatted using RFC3339 with fractional seconds, in UTC.
+const ExpiresKey = "/wrp-expires"
+
+var (
+	ErrExpired       = errors.New("message expired")
+	ErrInvalidExpiry = errors.New("invalid expiry")
+)
+
+// Expiry returns the expiry of the message.  The bool is false if the message
+// has no expiry.  An expiry that cannot be parsed results in an *Error with
+// CodeInvalidValue.
+func (msg *Message) Expiry() (time.Time, bool, error) {
+	v, ok := msg.Metadata[ExpiresKey]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return time.Time{}, false, newError(CodeInvalidValue, "Metadata",
+			fmt.Errorf("%w: %s: %w", ErrInvalidExpiry, ExpiresKey, err))
+	}
+
+	return t, true, nil
+}
+
+// SetExpiry sets the expiry of the message.  The Metadata is copied before it
+// is changed, so other references to the original Metadata are unaffected.
+func (msg *Message) SetExpiry(t time.Time) *Message {
+	metadata := make(map[string]string, len(msg.Metadata)+1)
+	maps.Copy(metadata, msg.Metadata)
+	metadata[ExpiresKey] = t.UTC().Format(time.RFC3339Nano)
+
+	msg.Metadata = metadata
+	return msg
+}
+
+// SetTTL sets the expiry of the message to ttl after now.
+func (msg *Message) SetTTL(now time.Time, ttl time.Duration) *Message {
+	return msg.SetExpiry(now.Add(ttl))
+}
+
+// IsExpired returns true if the message has an expiry that is not after now.
+// A message without an expiry, or with an expiry that cannot be parsed, never
+// expires; use RejectExpired to also reject the latter.
+func (msg *Message) IsExpired(now time.Time) bool {
+	t, ok, err := msg.Expiry()
+	return err == nil && ok && !t.After(now)
+}
+
+// RejectExpired returns a Processor that rejects expired messages with an
+// *Error with CodeExpired, and messages with an expiry that cannot be parsed
+// with an *Error with CodeInvalidValue.  Other messages result in
+// ErrNotHandled.  now is usually the Now method of a Clock; if now is nil,
+// SystemClock is used.
+func RejectExpired(now func() time.Time) Processor {
+	if now == nil {
+		now = SystemClock.Now
+	}
+
+	return ProcessorFunc(func(_ context.Context, msg Message) error {
+		t, ok, err := msg.Expiry()
+		switch {
+		case err != nil:
+			return err
+		case ok && !t.After(now()):
+			return newError(CodeExpired, "Metadata",
+				fmt.Errorf("%w: expired at %s", ErrExpired, t.Format(time.RFC3339Nano)))
+		}
+
+		return ErrNotHandled
+	})
+}
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage_Expiry(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var (
+		now      = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		original = map[string]string{"/boot-time": "1"}
+		msg      = Message{Metadata: original}
+	)
+
+	_, ok, err := msg.Expiry()
+	assert.False(ok)
+	assert.NoError(err)
+	assert.False(msg.IsExpired(now))
+
+	local := time.FixedZone("local", -5*60*60)
+	msg.SetExpiry(now.Add(1500 * time.Millisecond).In(local))
+	assert.Equal("2025-06-01T12:00:01.5Z", msg.Metadata[ExpiresKey])
+	assert.Equal("1", msg.Metadata["/boot-time"])
+	assert.NotContains(original, ExpiresKey)
+
+	expiry, ok, err := msg.Expiry()
+	require.NoError(err)
+	assert.True(ok)
+	assert.True(now.Add(1500 * time.Millisecond).Equal(expiry))
+
+	assert.False(msg.IsExpired(now))
+	assert.True(msg.IsExpired(now.Add(1500 * time.Millisecond)))
+	assert.True(msg.IsExpired(now.Add(time.Hour)))
+
+	msg.SetTTL(now, time.Minute)
+	assert.Equal("2025-06-01T12:01:00Z", msg.Metadata[ExpiresKey])
+
+	msg.Metadata[ExpiresKey] = "tomorrow"
+	_, ok, err = msg.Expiry()
+	assert.False(ok)
+	assert.ErrorIs(err, ErrInvalidExpiry)
+	assert.Equal(CodeInvalidValue, ErrorCodeOf(err))
+	assert.False(msg.IsExpired(now))
+}
+
+func TestMessage_Expiry_encoding(t *testing.T) {
+	expiry := time.Date(2025, 6, 1, 12, 0, 0, 123456789, time.UTC)
+	msg := new(Message).SetExpiry(expiry)
+
+	for _, f := range AllFormats() {
+		var decoded Message
+		require.NoError(t, NewDecoderBytes(MustEncode(msg, f), f).Decode(&decoded))
+
+		actual, ok, err := decoded.Expiry()
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.True(t, expiry.Equal(actual), f.String())
+	}
+}
+
+func TestRejectExpired(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		desc     string
+		msg      Message
+		expected error
+		code     ErrorCode
+	}{
+		{
+			desc:     "no expiry",
+			msg:      Message{},
+			expected: ErrNotHandled,
+		}, {
+			desc:     "not expired",
+			msg:      *new(Message).SetExpiry(now.Add(time.Second)),
+			expected: ErrNotHandled,
+		}, {
+			desc:     "expired",
+			msg:      *new(Message).SetExpiry(now),
+			expected: ErrExpired,
+			code:     CodeExpired,
+		}, {
+			desc:     "invalid",
+			msg:      Message{Metadata: map[string]string{ExpiresKey: "never"}},
+			expected: ErrInvalidExpiry,
+			code:     CodeInvalidValue,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := RejectExpired(func() time.Time { return now }).ProcessWRP(context.Background(), tc.msg)
+			assert.ErrorIs(t, err, tc.expected)
+			assert.Equal(t, tc.code, ErrorCodeOf(err))
+		})
+	}
+
+	// the default clock is the current time
+	msg := new(Message).SetExpiry(time.Now().Add(-time.Second))
+	assert.ErrorIs(t, RejectExpired(nil).ProcessWRP(context.Background(), *msg), ErrExpired)
+}
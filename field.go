@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+//go:generate go run ./internal/fieldgen -i messages.go -o field_gen.go
+
+var ErrUnknownField = errors.New("unknown field")
+
+// Field identifies a single field of a Message.  The constants, such as
+// SourceField and PayloadField, are generated from the Message struct.
+type Field int
+
+// String returns the name of the Message field.
+func (f Field) String() string {
+	if f.valid() {
+		return fieldNames[f]
+	}
+
+	return "Field(" + strconv.Itoa(int(f)) + ")"
+}
+
+func (f Field) valid() bool {
+	return f >= 0 && f < lastField
+}
+
+// AllFields returns all of the fields of a Message, in declaration order.
+func AllFields() []Field {
+	fields := make([]Field, lastField)
+	for i := range fields {
+		fields[i] = Field(i)
+	}
+
+	return fields
+}
+
+// ParseField returns the Field with the given Message field name, e.g.
+// "Payload".
+func ParseField(name string) (Field, error) {
+	for i, n := range fieldNames {
+		if n == name {
+			return Field(i), nil
+		}
+	}
+
+	return 0, newError(CodeUnsupportedField, name, fmt.Errorf("%w: `%s`", ErrUnknownField, name))
+}
+
+// Copy copies the given fields from src to dst.  Other fields of dst are not
+// changed.  The copy is shallow, so slices, maps and pointers are shared with
+// src.  Invalid fields are ignored.
+func Copy(dst, src *Message, fields ...Field) {
+	for _, f := range fields {
+		if f.valid() {
+			fieldCopiers[f](dst, src)
+		}
+	}
+}
+
+// Mask sets the given fields of the message to their zero values, e.g. to
+// strip the Payload from a message before it is logged.  Invalid fields are
+// ignored.
+func Mask(msg *Message, fields ...Field) {
+	Copy(msg, &Message{}, fields...)
+}
+
+// Project returns a new message with only the given fields copied from src.
+func Project(src *Message, fields ...Field) *Message {
+	var dst Message
+	Copy(&dst, src, fields...)
+	return &dst
+}
@@ -0,0 +1,71 @@
+// Code generated by "go run ./internal/fieldgen"; DO NOT EDIT.
+
+package wrp
+
+// The fields of a Message.
+const (
+	TypeField Field = iota
+	SourceField
+	DestinationField
+	TransactionUUIDField
+	ContentTypeField
+	AcceptField
+	StatusField
+	RequestDeliveryResponseField
+	HeadersField
+	MetadataField
+	SpansField
+	IncludeSpansField
+	PathField
+	PayloadField
+	ServiceNameField
+	URLField
+	PartnerIDsField
+	SessionIDField
+	QualityOfServiceField
+	lastField
+)
+
+var fieldNames = [...]string{
+	TypeField:                    "Type",
+	SourceField:                  "Source",
+	DestinationField:             "Destination",
+	TransactionUUIDField:         "TransactionUUID",
+	ContentTypeField:             "ContentType",
+	AcceptField:                  "Accept",
+	StatusField:                  "Status",
+	RequestDeliveryResponseField: "RequestDeliveryResponse",
+	HeadersField:                 "Headers",
+	MetadataField:                "Metadata",
+	SpansField:                   "Spans",
+	IncludeSpansField:            "IncludeSpans",
+	PathField:                    "Path",
+	PayloadField:                 "Payload",
+	ServiceNameField:             "ServiceName",
+	URLField:                     "URL",
+	PartnerIDsField:              "PartnerIDs",
+	SessionIDField:               "SessionID",
+	QualityOfServiceField:        "QualityOfService",
+}
+
+var fieldCopiers = [...]func(dst, src *Message){
+	TypeField:                    func(dst, src *Message) { dst.Type = src.Type },
+	SourceField:                  func(dst, src *Message) { dst.Source = src.Source },
+	DestinationField:             func(dst, src *Message) { dst.Destination = src.Destination },
+	TransactionUUIDField:         func(dst, src *Message) { dst.TransactionUUID = src.TransactionUUID },
+	ContentTypeField:             func(dst, src *Message) { dst.ContentType = src.ContentType },
+	AcceptField:                  func(dst, src *Message) { dst.Accept = src.Accept },
+	StatusField:                  func(dst, src *Message) { dst.Status = src.Status },
+	RequestDeliveryResponseField: func(dst, src *Message) { dst.RequestDeliveryResponse = src.RequestDeliveryResponse },
+	HeadersField:                 func(dst, src *Message) { dst.Headers = src.Headers },
+	MetadataField:                func(dst, src *Message) { dst.Metadata = src.Metadata },
+	SpansField:                   func(dst, src *Message) { dst.Spans = src.Spans },               // nolint:staticcheck
+	IncludeSpansField:            func(dst, src *Message) { dst.IncludeSpans = src.IncludeSpans }, // nolint:staticcheck
+	PathField:                    func(dst, src *Message) { dst.Path = src.Path },
+	PayloadField:                 func(dst, src *Message) { dst.Payload = src.Payload },
+	ServiceNameField:             func(dst, src *Message) { dst.ServiceName = src.ServiceName },
+	URLField:                     func(dst, src *Message) { dst.URL = src.URL },
+	PartnerIDsField:              func(dst, src *Message) { dst.PartnerIDs = src.PartnerIDs },
+	SessionIDField:               func(dst, src *Message) { dst.SessionID = src.SessionID },
+	QualityOfServiceField:        func(dst, src *Message) { dst.QualityOfService = src.QualityOfService },
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldsMatchMessage(t *testing.T) {
+	// catches a Message change without regenerating field_gen.go
+	mt := reflect.TypeOf(Message{})
+	require.Equal(t, mt.NumField(), len(AllFields()))
+
+	for i, f := range AllFields() {
+		assert.Equal(t, mt.Field(i).Name, f.String())
+	}
+}
+
+func TestField(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("Payload", PayloadField.String())
+	assert.Equal("Field(-1)", Field(-1).String())
+	assert.Equal("Field(100)", Field(100).String())
+
+	f, err := ParseField("PartnerIDs")
+	assert.NoError(err)
+	assert.Equal(PartnerIDsField, f)
+
+	_, err = ParseField("partner_ids")
+	assert.ErrorIs(err, ErrUnknownField)
+	assert.Equal(CodeUnsupportedField, ErrorCodeOf(err))
+}
+
+func TestCopy(t *testing.T) {
+	assert := assert.New(t)
+	src := testConvertMessage(SimpleRequestResponseMessageType)
+	src.QualityOfService = 50
+
+	var all Message
+	Copy(&all, src, AllFields()...)
+	assert.Equal(*src, all)
+
+	dst := Message{Source: "dns:other.example.com", Path: "/keep"}
+	Copy(&dst, src, SourceField, PayloadField, Field(-1), Field(100))
+	assert.Equal(Message{Source: src.Source, Path: "/keep", Payload: src.Payload}, dst)
+
+	// the copy is shallow
+	assert.Same(&src.Payload[0], &dst.Payload[0])
+
+	Copy(&dst, src)
+	assert.Equal(Message{Source: src.Source, Path: "/keep", Payload: src.Payload}, dst)
+}
+
+func TestMask(t *testing.T) {
+	msg := testConvertMessage(SimpleEventMessageType)
+	Mask(msg, PayloadField, MetadataField)
+
+	expected := testConvertMessage(SimpleEventMessageType)
+	expected.Payload = nil
+	expected.Metadata = nil
+	assert.Equal(t, expected, msg)
+}
+
+func TestProject(t *testing.T) {
+	src := testConvertMessage(SimpleEventMessageType)
+	assert.Equal(t,
+		&Message{Type: src.Type, Source: src.Source, Destination: src.Destination},
+		Project(src, TypeField, SourceField, DestinationField),
+	)
+	assert.Equal(t, &Message{}, Project(src))
+}
@@ -46,6 +46,8 @@ var (
 		// nolint:staticcheck
 		BasicHandle: codec.BasicHandle{
 			TypeInfos: codec.NewTypeInfos([]string{"json"}),
+			// Raw allows the encoders to write a PreservingMessage
+			EncodeOptions: codec.EncodeOptions{Raw: true},
 		},
 		IntegerAsString: 'L',
 	}
@@ -58,7 +60,8 @@ var (
 		// TODO replace `codec.BasicHandle` since it's not meant to be used directly
 		// nolint:staticcheck
 		BasicHandle: codec.BasicHandle{
-			TypeInfos: codec.NewTypeInfos([]string{"json"}),
+			TypeInfos:     codec.NewTypeInfos([]string{"json"}),
+			EncodeOptions: codec.EncodeOptions{Raw: true},
 		},
 	}
 )
@@ -79,6 +82,10 @@ func (f Format) ContentType() string {
 // the appropriate Format.  This function returns an error if the given
 // Content-Type did not map to a WRP format.
 //
+// The media types listed by MediaTypes are recognized first, as with
+// ParseMediaTypes.  For backwards compatibility, any other value containing
+// "json" or "msgpack" is also accepted.
+//
 // The optional fallback is used if contentType is the empty string.  Only
 // the first fallback value is used.  The rest are ignored.  This approach allows
 // simple usages such as:
@@ -93,6 +100,10 @@ func FormatFromContentType(contentType string, fallback ...Format) (Format, erro
 		return Format(-1), errors.New("Missing content type")
 	}
 
+	if f, err := ParseMediaTypes(contentType); err == nil {
+		return f, nil
+	}
+
 	if strings.Contains(contentType, "json") {
 		return JSON, nil
 	} else if strings.Contains(contentType, "msgpack") {
@@ -132,6 +143,33 @@ type Encoder interface {
 // encoderDecorator wraps a ugorji Encoder and implements the wrp.Encoder interface.
 type encoderDecorator struct {
 	*codec.Encoder
+	options encoderOptions
+
+	// output is the buffer of an encoder of bytes that has not encoded a
+	// value yet.  Its ugorji Encoder is reset to it before the first value is
+	// encoded, so the buffer can be sized from the value.
+	output *[]byte
+}
+
+// newEncoderBytes produces an encoderDecorator of bytes.  The ugorji Encoder
+// starts with an empty placeholder, so it does not allocate a buffer of its
+// own before the first value is encoded.
+func newEncoderBytes(output *[]byte, o encoderOptions) *encoderDecorator {
+	placeholder := []byte{}
+	return &encoderDecorator{
+		Encoder: codec.NewEncoderBytes(&placeholder, o.format.handle()),
+		options: o,
+		output:  output,
+	}
+}
+
+func (ed *encoderDecorator) Reset(output io.Writer) {
+	ed.output = nil
+	ed.Encoder.Reset(output)
+}
+
+func (ed *encoderDecorator) ResetBytes(output *[]byte) {
+	ed.output = output
 }
 
 // Encode checks to see if value implements EncoderTo and if it does, uses the
@@ -144,7 +182,21 @@ func (ed *encoderDecorator) Encode(value interface{}) error {
 		}
 	}
 
-	return ed.Encoder.Encode(value)
+	prepared, err := ed.options.prepare(value)
+	if err != nil {
+		return err
+	}
+
+	if ed.output != nil {
+		if hint := EncodedSizeHint(value, ed.options.format); hint > cap(*ed.output) {
+			*ed.output = make([]byte, 0, hint)
+		}
+
+		ed.Encoder.ResetBytes(ed.output)
+		ed.output = nil
+	}
+
+	return ed.Encoder.Encode(prepared)
 }
 
 // Decoder represents the underlying ugorji behavior that WRP supports
@@ -158,16 +210,17 @@ type Decoder interface {
 // for the given format
 func NewEncoder(output io.Writer, f Format) Encoder {
 	return &encoderDecorator{
-		codec.NewEncoder(output, f.handle()),
+		Encoder: codec.NewEncoder(output, f.handle()),
+		options: newEncoderOptions(f, nil),
 	}
 }
 
 // NewEncoderBytes produces a ugorji Encoder using the appropriate WRP configuration
-// for the given format
+// for the given format.  The buffer of the first value encoded after the
+// Encoder is created or reset is sized with EncodedSizeHint, unless output
+// already has the capacity.
 func NewEncoderBytes(output *[]byte, f Format) Encoder {
-	return &encoderDecorator{
-		codec.NewEncoderBytes(output, f.handle()),
-	}
+	return newEncoderBytes(output, newEncoderOptions(f, nil))
 }
 
 // NewDecoder produces a ugorji Decoder using the appropriate WRP configuration
@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// splitHeader splits a Headers entry of the form "Key: value" into its
+// canonical key and trimmed value.  Entries without a colon are not headers.
+func splitHeader(entry string) (string, string, bool) {
+	key, value, ok := strings.Cut(entry, ":")
+	if !ok {
+		return "", "", false
+	}
+
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return "", "", false
+	}
+
+	return http.CanonicalHeaderKey(key), strings.TrimSpace(value), true
+}
+
+func canonicalHeaderKey(key string) string {
+	return http.CanonicalHeaderKey(strings.TrimSpace(key))
+}
+
+// GetHeader returns the value of the first entry of Headers with the given
+// key.  Headers entries are "Key: value" strings by convention, and keys are
+// compared in their canonical form, as with http.Header.  Entries that are
+// not in that form are ignored.
+func (msg *Message) GetHeader(key string) (string, bool) {
+	key = canonicalHeaderKey(key)
+	for _, entry := range msg.Headers {
+		if k, v, ok := splitHeader(entry); ok && k == key {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// SetHeader sets the entry of Headers with the given key to the value,
+// replacing any existing entries with that key.  The entry takes the place of
+// the first existing one, or is appended.  Headers is replaced, not modified,
+// so copies of the message are not affected.  The message is returned to
+// allow chaining.
+func (msg *Message) SetHeader(key, value string) *Message {
+	key = canonicalHeaderKey(key)
+	entry := key + ": " + value
+
+	headers := make([]string, 0, len(msg.Headers)+1)
+	set := false
+	for _, e := range msg.Headers {
+		if k, _, ok := splitHeader(e); ok && k == key {
+			if !set {
+				headers = append(headers, entry)
+				set = true
+			}
+			continue
+		}
+		headers = append(headers, e)
+	}
+
+	if !set {
+		headers = append(headers, entry)
+	}
+
+	msg.Headers = headers
+	return msg
+}
+
+// DelHeader removes the entries of Headers with the given key.  Headers is
+// replaced, not modified, and is set to nil if no entries remain.  The message
+// is returned to allow chaining.
+func (msg *Message) DelHeader(key string) *Message {
+	key = canonicalHeaderKey(key)
+	if !slices.ContainsFunc(msg.Headers, func(e string) bool {
+		k, _, ok := splitHeader(e)
+		return ok && k == key
+	}) {
+		return msg
+	}
+
+	headers := make([]string, 0, len(msg.Headers))
+	for _, e := range msg.Headers {
+		if k, _, ok := splitHeader(e); ok && k == key {
+			continue
+		}
+		headers = append(headers, e)
+	}
+
+	if len(headers) == 0 {
+		headers = nil
+	}
+
+	msg.Headers = headers
+	return msg
+}
+
+// ToHTTPHeader returns the entries of Headers as an http.Header.  Entries with
+// the same key become multiple values, in order.  Entries that are not of the
+// form "Key: value" are skipped.
+func (msg *Message) ToHTTPHeader() http.Header {
+	h := make(http.Header, len(msg.Headers))
+	for _, entry := range msg.Headers {
+		if k, v, ok := splitHeader(entry); ok {
+			h[k] = append(h[k], v)
+		}
+	}
+
+	return h
+}
+
+// FromHTTPHeader replaces Headers with one "Key: value" entry for each value
+// in h.  Keys are canonicalized and sorted, so the result does not depend on
+// map order.  An empty h results in nil Headers.  The message is returned to
+// allow chaining.
+func (msg *Message) FromHTTPHeader(h http.Header) *Message {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		return strings.Compare(canonicalHeaderKey(a), canonicalHeaderKey(b))
+	})
+
+	var headers []string
+	for _, k := range keys {
+		ck := canonicalHeaderKey(k)
+		for _, v := range h[k] {
+			headers = append(headers, ck+": "+v)
+		}
+	}
+
+	msg.Headers = headers
+	return msg
+}
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessage_GetHeader(t *testing.T) {
+	msg := Message{
+		Headers: []string{"not a header", "x-device-id:  abc ", "X-Device-ID: second", ": empty key", "Accept-Language: en"},
+	}
+
+	tests := []struct {
+		key      string
+		expected string
+		found    bool
+	}{
+		{key: "X-Device-Id", expected: "abc", found: true},
+		{key: "x-device-id", expected: "abc", found: true},
+		{key: " accept-language ", expected: "en", found: true},
+		{key: "not a header"},
+		{key: "Missing"},
+		{key: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.key, func(t *testing.T) {
+			v, ok := msg.GetHeader(tc.key)
+			assert.Equal(t, tc.expected, v)
+			assert.Equal(t, tc.found, ok)
+		})
+	}
+}
+
+func TestMessage_SetHeader(t *testing.T) {
+	assert := assert.New(t)
+
+	original := []string{"A: 1", "x-trace: old", "raw", "X-Trace: older"}
+	msg := Message{Headers: original}
+
+	before := msg
+	msg.SetHeader("X-TRACE", "new").SetHeader("b", "2")
+	assert.Equal([]string{"A: 1", "X-Trace: new", "raw", "B: 2"}, msg.Headers)
+	assert.Equal([]string{"A: 1", "x-trace: old", "raw", "X-Trace: older"}, before.Headers, "copies must not be affected")
+
+	msg = Message{}
+	msg.SetHeader("a", "1")
+	assert.Equal([]string{"A: 1"}, msg.Headers)
+}
+
+func TestMessage_DelHeader(t *testing.T) {
+	assert := assert.New(t)
+
+	original := []string{"A: 1", "x-trace: old", "raw", "X-Trace: older"}
+	msg := Message{Headers: original}
+
+	before := msg
+	msg.DelHeader("X-Trace")
+	assert.Equal([]string{"A: 1", "raw"}, msg.Headers)
+	assert.Equal([]string{"A: 1", "x-trace: old", "raw", "X-Trace: older"}, before.Headers, "copies must not be affected")
+
+	msg.DelHeader("missing")
+	assert.Equal([]string{"A: 1", "raw"}, msg.Headers)
+
+	msg.DelHeader("a").DelHeader("raw")
+	assert.Equal([]string{"raw"}, msg.Headers)
+
+	msg = Message{Headers: []string{"A: 1"}}
+	msg.DelHeader("A")
+	assert.Nil(msg.Headers)
+}
+
+func TestMessage_HTTPHeader(t *testing.T) {
+	assert := assert.New(t)
+
+	msg := Message{Headers: []string{"x-a: 1", "raw", "X-B: 2", "X-A: 3", "X-Empty:"}}
+	h := msg.ToHTTPHeader()
+	assert.Equal(http.Header{
+		"X-A":     {"1", "3"},
+		"X-B":     {"2"},
+		"X-Empty": {""},
+	}, h)
+
+	var round Message
+	round.FromHTTPHeader(h)
+	assert.Equal([]string{"X-A: 1", "X-A: 3", "X-B: 2", "X-Empty: "}, round.Headers)
+	assert.Equal(h, round.ToHTTPHeader())
+
+	// keys that are not canonical are canonicalized
+	round.FromHTTPHeader(http.Header{"x-lower": {"v"}, "Accept": {"*/*"}})
+	assert.Equal([]string{"Accept: */*", "X-Lower: v"}, round.Headers)
+
+	round.FromHTTPHeader(nil)
+	assert.Nil(round.Headers)
+	assert.Empty(round.ToHTTPHeader())
+}
@@ -0,0 +1,225 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HopKeyPrefix is the prefix of the Metadata keys that record the services a
+// message passed through.  The hops are numbered from 0 in the order they
+// were recorded, and each hop has three keys, formatted by HopKey:
+//
+//	/wrp-hop/0/service   the name of the service
+//	/wrp-hop/0/received  when the service received the message
+//	/wrp-hop/0/sent      when the service sent the message on
+//
+// The times are formatted using RFC3339 with fractional seconds, in UTC.
+// Latencies computed from the hops of different services are only as accurate
+// as the synchronization of their clocks.
+const HopKeyPrefix = "/wrp-hop/"
+
+// The fields of a hop, used with HopKey.
+const (
+	HopServiceField  = "service"
+	HopReceivedField = "received"
+	HopSentField     = "sent"
+)
+
+var ErrInvalidHop = errors.New("invalid hop")
+
+type hopReceivedContextKey struct{}
+
+// HopKey returns the Metadata key of the field of the nth hop.
+func HopKey(n int, field string) string {
+	return HopKeyPrefix + strconv.Itoa(n) + "/" + field
+}
+
+// Hop is the timing of a message within one service.
+type Hop struct {
+	// Service is the name of the service.
+	Service string
+
+	// Received is when the service received the message.
+	Received time.Time
+
+	// Sent is when the service sent the message on.
+	Sent time.Time
+}
+
+// Duration returns the time the message spent in the service.
+func (h Hop) Duration() time.Duration {
+	return h.Sent.Sub(h.Received)
+}
+
+// Hops returns the hops recorded in the message, in order.  A hop with a
+// missing or unparsable time results in an *Error with CodeInvalidValue.
+func (msg *Message) Hops() ([]Hop, error) {
+	var hops []Hop
+	for n := 0; ; n++ {
+		service, ok := msg.Metadata[HopKey(n, HopServiceField)]
+		if !ok {
+			return hops, nil
+		}
+
+		h := Hop{Service: service}
+		for _, f := range []struct {
+			field string
+			t     *time.Time
+		}{
+			{field: HopReceivedField, t: &h.Received},
+			{field: HopSentField, t: &h.Sent},
+		} {
+			key := HopKey(n, f.field)
+			v, ok := msg.Metadata[key]
+			if !ok {
+				return nil, newError(CodeInvalidValue, "Metadata",
+					fmt.Errorf("%w: %s is missing", ErrInvalidHop, key))
+			}
+
+			t, err := time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				return nil, newError(CodeInvalidValue, "Metadata",
+					fmt.Errorf("%w: %s: %w", ErrInvalidHop, key, err))
+			}
+			*f.t = t
+		}
+
+		hops = append(hops, h)
+	}
+}
+
+// AppendHop records the hop after the hops already in the message.  The
+// Metadata is copied before it is changed, so other references to the
+// original Metadata are unaffected.
+func (msg *Message) AppendHop(h Hop) *Message {
+	n := 0
+	for {
+		if _, ok := msg.Metadata[HopKey(n, HopServiceField)]; !ok {
+			break
+		}
+		n++
+	}
+
+	metadata := make(map[string]string, len(msg.Metadata)+3)
+	maps.Copy(metadata, msg.Metadata)
+	metadata[HopKey(n, HopServiceField)] = h.Service
+	metadata[HopKey(n, HopReceivedField)] = h.Received.UTC().Format(time.RFC3339Nano)
+	metadata[HopKey(n, HopSentField)] = h.Sent.UTC().Format(time.RFC3339Nano)
+
+	msg.Metadata = metadata
+	return msg
+}
+
+// ClearHops removes the hops from the message, e.g. before a message leaves
+// the part of the system whose latency is measured.  The Metadata is copied
+// before it is changed.
+func (msg *Message) ClearHops() *Message {
+	metadata := make(map[string]string, len(msg.Metadata))
+	for k, v := range msg.Metadata {
+		if !strings.HasPrefix(k, HopKeyPrefix) {
+			metadata[k] = v
+		}
+	}
+
+	msg.Metadata = metadata
+	return msg
+}
+
+// ContextWithReceived returns a context that carries when the message being
+// handled was received, so that StampHop can record the time the message spent
+// in the service.  The handlers of wrphttp and the Servers of wrpsock add it.
+// Code that receives messages itself, e.g. from a wrpnano socket, must add it
+// before passing the message on.
+func ContextWithReceived(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, hopReceivedContextKey{}, t)
+}
+
+// ReceivedFromContext returns when the message being handled was received, if
+// the context carries it.
+func ReceivedFromContext(ctx context.Context) (time.Time, bool) {
+	t, ok := ctx.Value(hopReceivedContextKey{}).(time.Time)
+	return t, ok
+}
+
+// StampHop returns a Modifier that appends a hop for the service to each
+// message.  The hop is received at the time carried by the context, or now if
+// the context has none, and sent now.  If c is nil, SystemClock is used.
+func StampHop(service string, c Clock) Modifier {
+	if c == nil {
+		c = SystemClock
+	}
+
+	return ModifierFunc(func(ctx context.Context, msg Message) (Message, error) {
+		now := c.Now()
+		received, ok := ReceivedFromContext(ctx)
+		if !ok {
+			received = now
+		}
+
+		msg.AppendHop(Hop{
+			Service:  service,
+			Received: received,
+			Sent:     now,
+		})
+		return msg, nil
+	})
+}
+
+// HopLatency is the latency added by one hop.
+type HopLatency struct {
+	// Service is the name of the service.
+	Service string
+
+	// Transit is the time from when the previous service sent the message to
+	// when this service received it.  It is 0 for the first hop.
+	Transit time.Duration
+
+	// Processing is the time the message spent in the service.
+	Processing time.Duration
+}
+
+// Latency is the latency of a message across its hops.
+type Latency struct {
+	// EndToEnd is the time from when the first service received the message
+	// to when the last service sent it on.
+	EndToEnd time.Duration
+
+	// Hops are the latencies of each hop, in order.
+	Hops []HopLatency
+}
+
+// MeasureLatency computes the latency of the message from its hops.  The bool
+// is false if the message has no hops.  Invalid hops result in the error
+// returned by Hops.
+func MeasureLatency(msg *Message) (Latency, bool, error) {
+	hops, err := msg.Hops()
+	if err != nil || len(hops) == 0 {
+		return Latency{}, false, err
+	}
+
+	l := Latency{
+		EndToEnd: hops[len(hops)-1].Sent.Sub(hops[0].Received),
+		Hops:     make([]HopLatency, len(hops)),
+	}
+
+	for i, h := range hops {
+		l.Hops[i] = HopLatency{
+			Service:    h.Service,
+			Processing: h.Duration(),
+		}
+
+		if i > 0 {
+			l.Hops[i].Transit = h.Received.Sub(hops[i-1].Sent)
+		}
+	}
+
+	return l, true, nil
+}
@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage_Hops(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var (
+		start    = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		original = map[string]string{"/boot-time": "1"}
+		msg      = Message{Metadata: original}
+	)
+
+	hops, err := msg.Hops()
+	assert.NoError(err)
+	assert.Empty(hops)
+
+	local := time.FixedZone("local", -5*60*60)
+	msg.AppendHop(Hop{
+		Service:  "talaria",
+		Received: start.In(local),
+		Sent:     start.Add(1500 * time.Microsecond),
+	}).AppendHop(Hop{
+		Service:  "scytale",
+		Received: start.Add(10 * time.Millisecond),
+		Sent:     start.Add(12 * time.Millisecond),
+	})
+
+	assert.Equal(map[string]string{
+		"/boot-time":          "1",
+		"/wrp-hop/0/service":  "talaria",
+		"/wrp-hop/0/received": "2025-06-01T12:00:00Z",
+		"/wrp-hop/0/sent":     "2025-06-01T12:00:00.0015Z",
+		"/wrp-hop/1/service":  "scytale",
+		"/wrp-hop/1/received": "2025-06-01T12:00:00.01Z",
+		"/wrp-hop/1/sent":     "2025-06-01T12:00:00.012Z",
+	}, msg.Metadata)
+	assert.Len(original, 1)
+
+	hops, err = msg.Hops()
+	require.NoError(err)
+	require.Len(hops, 2)
+	assert.Equal("talaria", hops[0].Service)
+	assert.True(start.Equal(hops[0].Received))
+	assert.Equal(1500*time.Microsecond, hops[0].Duration())
+	assert.Equal(2*time.Millisecond, hops[1].Duration())
+
+	latency, ok, err := MeasureLatency(&msg)
+	require.NoError(err)
+	assert.True(ok)
+	assert.Equal(Latency{
+		EndToEnd: 12 * time.Millisecond,
+		Hops: []HopLatency{
+			{Service: "talaria", Processing: 1500 * time.Microsecond},
+			{Service: "scytale", Transit: 8500 * time.Microsecond, Processing: 2 * time.Millisecond},
+		},
+	}, latency)
+
+	withHops := msg.Metadata
+	msg.ClearHops()
+	assert.Equal(original, msg.Metadata)
+	assert.Len(withHops, 7)
+
+	_, ok, err = MeasureLatency(&msg)
+	assert.False(ok)
+	assert.NoError(err)
+}
+
+func TestMessage_Hops_invalid(t *testing.T) {
+	tests := []struct {
+		desc     string
+		metadata map[string]string
+	}{
+		{
+			desc: "missing time",
+			metadata: map[string]string{
+				"/wrp-hop/0/service":  "talaria",
+				"/wrp-hop/0/received": "2025-06-01T12:00:00Z",
+			},
+		}, {
+			desc: "invalid time",
+			metadata: map[string]string{
+				"/wrp-hop/0/service":  "talaria",
+				"/wrp-hop/0/received": "2025-06-01T12:00:00Z",
+				"/wrp-hop/0/sent":     "later",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			msg := Message{Metadata: tc.metadata}
+
+			hops, err := msg.Hops()
+			assert.Nil(t, hops)
+			assert.ErrorIs(t, err, ErrInvalidHop)
+			assert.Equal(t, CodeInvalidValue, ErrorCodeOf(err))
+
+			_, ok, err := MeasureLatency(&msg)
+			assert.False(t, ok)
+			assert.ErrorIs(t, err, ErrInvalidHop)
+		})
+	}
+}
+
+func TestStampHop(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var (
+		received = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		now      = received.Add(time.Second)
+		m        = StampHop("petasos", ClockFunc(func() time.Time { return now }))
+	)
+
+	msg, err := m.ModifyWRP(ContextWithReceived(context.Background(), received), Message{})
+	require.NoError(err)
+	msg, err = m.ModifyWRP(context.Background(), msg)
+	require.NoError(err)
+
+	hops, err := msg.Hops()
+	require.NoError(err)
+	require.Len(hops, 2)
+	assert.True(received.Equal(hops[0].Received))
+	assert.True(now.Equal(hops[0].Sent))
+	assert.True(now.Equal(hops[1].Received))
+	assert.True(now.Equal(hops[1].Sent))
+
+	_, ok := ReceivedFromContext(context.Background())
+	assert.False(ok)
+	assert.NotNil(StampHop("petasos", nil))
+}
+
+func TestMessage_Hops_encoding(t *testing.T) {
+	start := time.Date(2025, 6, 1, 12, 0, 0, 123456789, time.UTC)
+	msg := new(Message).AppendHop(Hop{Service: "talaria", Received: start, Sent: start.Add(time.Millisecond)})
+
+	for _, f := range AllFormats() {
+		var decoded Message
+		require.NoError(t, NewDecoderBytes(MustEncode(msg, f), f).Decode(&decoded))
+
+		hops, err := decoded.Hops()
+		require.NoError(t, err)
+		require.Len(t, hops, 1, f.String())
+		assert.True(t, start.Equal(hops[0].Received), f.String())
+	}
+}
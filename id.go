@@ -132,11 +132,21 @@ type Locator struct {
 	ID DeviceID
 }
 
-// ParseLocator parses a raw locator string into a canonicalized locator.
+// ParseLocator parses a raw locator string into a canonicalized locator.  Any
+// error is an *Error with the code CodeInvalidLocator.  Locators of schemes
+// added with RegisterScheme are parsed by the SchemeParser of the scheme.
 func ParseLocator(locator string) (Locator, error) {
 	match := LocatorPattern.FindStringSubmatch(locator)
 	if match == nil {
-		return Locator{}, fmt.Errorf("%w: `%s` does not match expected locator pattern", ErrorInvalidLocator, locator)
+		match = customLocatorPattern.FindStringSubmatch(locator)
+		if match != nil {
+			if _, ok := lookupScheme(strings.ToLower(match[1])); !ok {
+				match = nil
+			}
+		}
+	}
+	if match == nil {
+		return Locator{}, newError(CodeInvalidLocator, "", fmt.Errorf("%w: `%s` does not match expected locator pattern", ErrorInvalidLocator, locator))
 	}
 
 	var l Locator
@@ -154,11 +164,11 @@ func ParseLocator(locator string) (Locator, error) {
 	switch l.Scheme {
 	case SchemeDNS:
 		if l.Authority == "" {
-			return Locator{}, fmt.Errorf("%w: empty authority", ErrorInvalidLocator)
+			return Locator{}, newError(CodeInvalidLocator, "", fmt.Errorf("%w: empty authority", ErrorInvalidLocator))
 		}
 	case SchemeEvent:
 		if l.Authority == "" {
-			return Locator{}, fmt.Errorf("%w: empty authority", ErrorInvalidLocator)
+			return Locator{}, newError(CodeInvalidLocator, "", fmt.Errorf("%w: empty authority", ErrorInvalidLocator))
 		}
 		if l.Service != "" {
 			l.Ignored = "/" + l.Service + l.Ignored
@@ -167,10 +177,17 @@ func ParseLocator(locator string) (Locator, error) {
 	case SchemeMAC, SchemeUUID, SchemeSerial, SchemeSelf:
 		id, err := makeDeviceID(l.Scheme, l.Authority)
 		if err != nil {
-			return Locator{}, fmt.Errorf("%w: unable to make a device ID with scheme `%s` and authority `%s`", err, l.Scheme, l.Authority)
+			return Locator{}, newError(CodeInvalidLocator, "", fmt.Errorf("%w: unable to make a device ID with scheme `%s` and authority `%s`", err, l.Scheme, l.Authority))
 		}
 		l.ID = id
 	default:
+		s, ok := lookupScheme(l.Scheme)
+		if !ok {
+			break
+		}
+		if err := s.parse(&l); err != nil {
+			return Locator{}, newError(CodeInvalidLocator, "", err)
+		}
 	}
 
 	return l, nil
@@ -186,6 +203,9 @@ func (l Locator) IsSelf() bool {
 	return l.Scheme == SchemeSelf
 }
 
+// String returns the locator in its textual form.  The authority and service
+// are written as is, so a locator whose authority or service contains `/` or
+// whitespace does not parse back to the same locator; use Encode for those.
 func (l Locator) String() string {
 	var buf strings.Builder
 
@@ -204,6 +224,18 @@ func (l Locator) String() string {
 	return buf.String()
 }
 
+// Canonical returns the canonical string form of the locator.  It is the same
+// as String, except that the authority of a device locator is replaced with the
+// normalized device identifier, e.g. a MAC address is lowercased and has its
+// separators removed.
+func (l Locator) Canonical() string {
+	if l.HasDeviceID() {
+		l.Authority = l.ID.ID()
+	}
+
+	return l.String()
+}
+
 func makeDeviceID(prefix, idPart string) (DeviceID, error) {
 	prefix = strings.ToLower(prefix)
 	switch prefix {
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseDeviceID(t *testing.T) {
@@ -295,3 +296,41 @@ func TestLocatorDeviceID(t *testing.T) {
 	assert.True(l.HasDeviceID())
 	assert.NotEqual(l.ID, "")
 }
+
+func TestLocatorCanonical(t *testing.T) {
+	tests := []struct {
+		description string
+		locator     string
+		want        string
+	}{
+		{
+			description: "mac with separators",
+			locator:     "MAC:11:22:33:AA:BB:CC/service/ignored",
+			want:        "mac:112233aabbcc/service/ignored",
+		}, {
+			description: "already canonical",
+			locator:     "mac:112233aabbcc",
+			want:        "mac:112233aabbcc",
+		}, {
+			description: "event with whitespace",
+			locator:     "Event: device-status /foo",
+			want:        "event:device-status/foo",
+		}, {
+			description: "dns",
+			locator:     "DNS:example.com/service",
+			want:        "dns:example.com/service",
+		}, {
+			description: "self",
+			locator:     "SELF:/service",
+			want:        "self:/service",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			l, err := ParseLocator(tc.locator)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, l.Canonical())
+		})
+	}
+}
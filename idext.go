@@ -0,0 +1,274 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// DeviceID and Locator values, e.g. in typed fields of structs or in the
+// Unknown fields of a PreservingMessage, are always written in canonical form
+// by the encoders of this package.  In msgpack they are the extensions
+// MsgpackDeviceIDExt and MsgpackLocatorExt, with a compact binary form: a
+// MAC address takes its 6, 8 or 20 bytes rather than its hex digits.  In JSON
+// they are strings, a Locator in the form written by Locator.Encode.
+//
+// Decoding accepts both the extensions and strings, which are canonicalized,
+// so values written by other implementations as strings are understood.
+const (
+	MsgpackDeviceIDExt = 1
+	MsgpackLocatorExt  = 2
+)
+
+var errInvalidIDExt = errors.New("invalid device ID or locator extension")
+
+// The scheme codes of the binary forms.  schemeRaw is followed by the string
+// form, for values that do not parse.
+const (
+	schemeRaw byte = iota
+	schemeMAC
+	schemeUUID
+	schemeDNS
+	schemeSerial
+	schemeSelf
+	schemeEvent
+)
+
+var schemeCodes = map[string]byte{
+	SchemeMAC:    schemeMAC,
+	SchemeUUID:   schemeUUID,
+	SchemeDNS:    schemeDNS,
+	SchemeSerial: schemeSerial,
+	SchemeSelf:   schemeSelf,
+	SchemeEvent:  schemeEvent,
+}
+
+var schemeNames = [...]string{
+	schemeMAC:    SchemeMAC,
+	schemeUUID:   SchemeUUID,
+	schemeDNS:    SchemeDNS,
+	schemeSerial: SchemeSerial,
+	schemeSelf:   SchemeSelf,
+	schemeEvent:  SchemeEvent,
+}
+
+func init() {
+	must := func(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	must(msgpackHandle.SetBytesExt(reflect.TypeOf(DeviceID("")), MsgpackDeviceIDExt, deviceIDExt{}))
+	must(msgpackHandle.SetBytesExt(reflect.TypeOf(Locator{}), MsgpackLocatorExt, locatorExt{}))
+	must(jsonHandle.SetInterfaceExt(reflect.TypeOf(DeviceID("")), MsgpackDeviceIDExt, deviceIDExt{}))
+	must(jsonHandle.SetInterfaceExt(reflect.TypeOf(Locator{}), MsgpackLocatorExt, locatorExt{}))
+}
+
+// canonicalDeviceID returns the canonical form of the device ID, or the ID as
+// is if it does not parse.
+func canonicalDeviceID(id DeviceID) (DeviceID, bool) {
+	canonical, err := ParseDeviceID(string(id))
+	if err != nil {
+		return id, false
+	}
+
+	return canonical, true
+}
+
+// withCanonicalAuthority returns the locator with the authority of a device locator
+// replaced by its device ID.
+func withCanonicalAuthority(l Locator) Locator {
+	if l.HasDeviceID() {
+		if id, ok := canonicalDeviceID(l.ID); ok {
+			l.ID = id
+			l.Authority = id.ID()
+		}
+	}
+
+	return l
+}
+
+func decodeCanonicalLocator(s string) (Locator, error) {
+	l, err := DecodeLocator(s)
+	return withCanonicalAuthority(l), err
+}
+
+// appendAuthority appends the binary form of the authority of a scheme.
+func appendAuthority(b []byte, code byte, authority string) ([]byte, bool) {
+	if code != schemeMAC {
+		return append(b, authority...), true
+	}
+
+	mac, err := hex.DecodeString(authority)
+	return append(b, mac...), err == nil
+}
+
+func authorityOf(code byte, b []byte) string {
+	if code == schemeMAC {
+		return hex.EncodeToString(b)
+	}
+
+	return string(b)
+}
+
+type deviceIDExt struct{}
+
+// WriteExt writes the scheme code followed by the ID part.
+func (deviceIDExt) WriteExt(v interface{}) []byte {
+	id := v.(DeviceID)
+	if id == "" {
+		return nil
+	}
+
+	if canonical, ok := canonicalDeviceID(id); ok {
+		code := schemeCodes[canonical.Prefix()]
+		if b, ok := appendAuthority([]byte{code}, code, canonical.ID()); ok {
+			return b
+		}
+	}
+
+	return append([]byte{schemeRaw}, id...)
+}
+
+func (deviceIDExt) ReadExt(dst interface{}, src []byte) {
+	id := dst.(*DeviceID)
+	switch {
+	case len(src) == 0:
+		*id = ""
+	case src[0] >= ' ':
+		// a string written by another implementation
+		*id, _ = canonicalDeviceID(DeviceID(src))
+	case src[0] == schemeRaw:
+		*id = DeviceID(src[1:])
+	case int(src[0]) < len(schemeNames) && src[0] != schemeEvent:
+		*id = DeviceID(schemeNames[src[0]] + ":" + authorityOf(src[0], src[1:]))
+	default:
+		panic(fmt.Errorf("%w: scheme %d", errInvalidIDExt, src[0]))
+	}
+}
+
+func (deviceIDExt) ConvertExt(v interface{}) interface{} {
+	id, _ := canonicalDeviceID(v.(DeviceID))
+	return string(id)
+}
+
+func (deviceIDExt) UpdateExt(dst interface{}, src interface{}) {
+	s, _ := src.(string)
+	*dst.(*DeviceID), _ = canonicalDeviceID(DeviceID(s))
+}
+
+type locatorExt struct{}
+
+// WriteExt writes the scheme code followed by the authority and the service,
+// each prefixed with its length as a uvarint, and then the ignored part.
+func (locatorExt) WriteExt(v interface{}) []byte {
+	l := withCanonicalAuthority(*v.(*Locator))
+	if l == (Locator{}) {
+		return nil
+	}
+
+	code, known := schemeCodes[l.Scheme]
+	if known {
+		if authority, ok := appendAuthority(nil, code, l.Authority); ok {
+			b := []byte{code}
+			b = binary.AppendUvarint(b, uint64(len(authority)))
+			b = append(b, authority...)
+			b = binary.AppendUvarint(b, uint64(len(l.Service)))
+			b = append(b, l.Service...)
+			return append(b, l.Ignored...)
+		}
+	}
+
+	return append([]byte{schemeRaw}, l.Encode()...)
+}
+
+func (locatorExt) ReadExt(dst interface{}, src []byte) {
+	l := dst.(*Locator)
+	if len(src) == 0 {
+		*l = Locator{}
+		return
+	}
+
+	var err error
+	switch code := src[0]; {
+	case code >= ' ':
+		// a string written by another implementation
+		*l, err = decodeCanonicalLocator(string(src))
+	case code == schemeRaw:
+		*l, err = decodeCanonicalLocator(string(src[1:]))
+	case int(code) < len(schemeNames):
+		*l, err = readLocator(code, src[1:])
+	default:
+		err = fmt.Errorf("%w: scheme %d", errInvalidIDExt, code)
+	}
+
+	if err != nil {
+		panic(err)
+	}
+}
+
+func readLocator(code byte, b []byte) (Locator, error) {
+	next := func() (string, bool) {
+		n, size := binary.Uvarint(b)
+		if size <= 0 || n > uint64(len(b)-size) {
+			return "", false
+		}
+
+		s := b[size : size+int(n)]
+		b = b[size+int(n):]
+		return string(s), true
+	}
+
+	authority, ok1 := next()
+	service, ok2 := next()
+	if !ok1 || !ok2 {
+		return Locator{}, fmt.Errorf("%w: truncated", errInvalidIDExt)
+	}
+
+	l := Locator{
+		Scheme:    schemeNames[code],
+		Authority: authorityOf(code, []byte(authority)),
+		Service:   service,
+		Ignored:   string(b),
+	}
+
+	if code != schemeDNS && code != schemeEvent {
+		id, err := makeDeviceID(l.Scheme, l.Authority)
+		if err != nil {
+			return Locator{}, fmt.Errorf("%w: %w", errInvalidIDExt, err)
+		}
+		l.ID = id
+	}
+
+	return l, nil
+}
+
+func (locatorExt) ConvertExt(v interface{}) interface{} {
+	l := withCanonicalAuthority(*v.(*Locator))
+	if l == (Locator{}) {
+		return ""
+	}
+
+	return l.Encode()
+}
+
+func (locatorExt) UpdateExt(dst interface{}, src interface{}) {
+	s, _ := src.(string)
+	if s == "" {
+		*dst.(*Locator) = Locator{}
+		return
+	}
+
+	l, err := decodeCanonicalLocator(s)
+	if err != nil {
+		panic(err)
+	}
+
+	*dst.(*Locator) = l
+}
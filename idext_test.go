@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type typedIDs struct {
+	ID      DeviceID `json:"id"`
+	Source  Locator  `json:"source"`
+	Dest    Locator  `json:"dest"`
+	Missing Locator  `json:"missing"`
+}
+
+type stringIDs struct {
+	ID      string `json:"id"`
+	Source  string `json:"source"`
+	Dest    string `json:"dest"`
+	Missing string `json:"missing"`
+}
+
+func mustLocator(t *testing.T, s string) Locator {
+	l, err := ParseLocator(s)
+	require.NoError(t, err)
+	return l
+}
+
+func TestIDExt(t *testing.T) {
+	tests := []struct {
+		desc     string
+		id       DeviceID
+		source   string
+		dest     string
+		expected stringIDs
+	}{
+		{
+			desc:   "mac",
+			id:     "MAC:11-22-33-44-55-66",
+			source: "mac:11:22:33:44:55:66/config/ignored",
+			dest:   "dns:example.com/service",
+			expected: stringIDs{
+				ID:     "mac:112233445566",
+				Source: "mac:112233445566/config/ignored",
+				Dest:   "dns:example.com/service",
+			},
+		}, {
+			desc:   "long mac",
+			id:     "mac:112233445566778899aabbccddeeff0011223344",
+			source: "mac:1122334455667788",
+			dest:   "event:device-status/mac:112233445566/online",
+			expected: stringIDs{
+				ID:     "mac:112233445566778899aabbccddeeff0011223344",
+				Source: "mac:1122334455667788",
+				Dest:   "event:device-status/mac:112233445566/online",
+			},
+		}, {
+			desc:   "other schemes",
+			id:     "uuid:1234",
+			source: "serial:ABC/with%2Fslash",
+			dest:   "self:/service",
+			expected: stringIDs{
+				ID:     "uuid:1234",
+				Source: "serial:ABC/with%2Fslash",
+				Dest:   "self:/service",
+			},
+		}, {
+			desc:     "invalid device ID",
+			id:       "bogus",
+			source:   "uuid:1234",
+			dest:     "dns:example.com",
+			expected: stringIDs{ID: "bogus", Source: "uuid:1234", Dest: "dns:example.com"},
+		},
+	}
+
+	for _, tc := range tests {
+		for _, f := range AllFormats() {
+			t.Run(tc.desc+"/"+f.String(), func(t *testing.T) {
+				assert := assert.New(t)
+				require := require.New(t)
+
+				source, err := DecodeLocator(tc.source)
+				require.NoError(err)
+				in := typedIDs{ID: tc.id, Source: source, Dest: mustLocator(t, tc.dest)}
+
+				encoded := MustEncode(&in, f)
+
+				var typed typedIDs
+				require.NoError(NewDecoderBytes(encoded, f).Decode(&typed))
+				assert.Equal(tc.expected.ID, string(typed.ID))
+				assert.Equal(tc.expected.Source, typed.Source.Encode())
+				assert.Equal(tc.expected.Dest, typed.Dest.Encode())
+				assert.Equal(Locator{}, typed.Missing)
+				assert.Equal(in.Source.ID, typed.Source.ID)
+
+				// strings written by other implementations are canonicalized
+				var typedFromStrings typedIDs
+				require.NoError(NewDecoderBytes(MustEncode(stringIDs{
+					ID:     string(tc.id),
+					Source: tc.source,
+					Dest:   tc.dest,
+				}, f), f).Decode(&typedFromStrings))
+				assert.Equal(typed, typedFromStrings)
+
+				if f == JSON {
+					var strings stringIDs
+					require.NoError(NewDecoderBytes(encoded, f).Decode(&strings))
+					assert.Equal(tc.expected, strings)
+				}
+			})
+		}
+	}
+}
+
+func TestIDExt_compact(t *testing.T) {
+	typed := MustEncode(&typedIDs{
+		ID:     "mac:112233445566",
+		Source: mustLocator(t, "mac:112233445566/config"),
+	}, Msgpack)
+	strings := MustEncode(&stringIDs{
+		ID:     "mac:112233445566",
+		Source: "mac:112233445566/config",
+	}, Msgpack)
+
+	assert.Less(t, len(typed), len(strings))
+}
+
+func TestIDExt_preserving(t *testing.T) {
+	msg := PreservingMessage{
+		Message: Message{Type: SimpleEventMessageType},
+		Unknown: map[string]interface{}{
+			"device": DeviceID("mac:112233445566"),
+			"target": mustLocator(t, "mac:112233445566/config"),
+		},
+	}
+
+	var decoded PreservingMessage
+	require.NoError(t, DecodePreserving(MustEncode(&msg, Msgpack), Msgpack, &decoded))
+	assert.Equal(t, msg.Unknown, decoded.Unknown)
+}
+
+func TestIDExt_invalid(t *testing.T) {
+	tests := []struct {
+		desc    string
+		encoded []byte
+		target  interface{}
+	}{
+		{desc: "device ID scheme", encoded: []byte{0xc7, 0x02, MsgpackDeviceIDExt, 0x1f, 'x'}, target: new(DeviceID)},
+		{desc: "locator scheme", encoded: []byte{0xc7, 0x02, MsgpackLocatorExt, 0x1f, 'x'}, target: new(Locator)},
+		{desc: "truncated locator", encoded: []byte{0xc7, 0x03, MsgpackLocatorExt, schemeDNS, 0x05, 'x'}, target: new(Locator)},
+		{desc: "invalid mac", encoded: []byte{0xc7, 0x04, MsgpackLocatorExt, schemeMAC, 0x01, 0x11, 0x00}, target: new(Locator)},
+		{desc: "invalid locator string", encoded: []byte{0xa5, 'b', 'o', 'g', 'u', 's'}, target: new(Locator)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Error(t, NewDecoderBytes(tc.encoded, Msgpack).Decode(tc.target))
+		})
+	}
+
+	var l Locator
+	assert.Error(t, NewDecoderBytes([]byte(`"bogus"`), JSON).Decode(&l))
+}
@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"sync"
+)
+
+const (
+	// DefaultInternerMaxEntries is the default maximum number of strings an
+	// Interner holds.
+	DefaultInternerMaxEntries = 4096
+
+	// DefaultInternerMaxLength is the default length of the longest string an
+	// Interner holds.
+	DefaultInternerMaxLength = 128
+)
+
+// InternerOption is a functional option for an Interner.
+type InternerOption interface {
+	apply(*Interner)
+}
+
+type internerOptionFunc func(*Interner)
+
+func (f internerOptionFunc) apply(i *Interner) {
+	f(i)
+}
+
+// InternerMaxEntries sets the maximum number of strings the Interner holds.
+// Once the table is full, strings that are not already present are returned
+// as is.  Values less than 1 disable interning.
+func InternerMaxEntries(n int) InternerOption {
+	return internerOptionFunc(func(i *Interner) {
+		i.maxEntries = n
+	})
+}
+
+// InternerMaxLength sets the length of the longest string the Interner holds.
+// Longer strings are unlikely to repeat and are returned as is.
+func InternerMaxLength(n int) InternerOption {
+	return internerOptionFunc(func(i *Interner) {
+		i.maxLength = n
+	})
+}
+
+// Interner is a bounded table of strings that are frequently repeated across
+// messages, such as metadata keys and partner IDs.  Interning replaces each
+// copy of such a string with a single shared copy, which reduces the steady
+// state heap of long running routers that hold on to many messages.
+//
+// The table only grows: strings are added until the table is full, and are
+// never evicted.  An Interner is safe for concurrent use, and a single
+// Interner is normally shared by everything decoding messages in a process.
+type Interner struct {
+	maxEntries int
+	maxLength  int
+
+	m     sync.RWMutex
+	table map[string]string
+}
+
+// NewInterner creates an Interner with the given options.
+func NewInterner(opts ...InternerOption) *Interner {
+	i := Interner{
+		maxEntries: DefaultInternerMaxEntries,
+		maxLength:  DefaultInternerMaxLength,
+		table:      make(map[string]string),
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt.apply(&i)
+		}
+	}
+
+	return &i
+}
+
+// Len returns the number of strings in the table.
+func (i *Interner) Len() int {
+	i.m.RLock()
+	defer i.m.RUnlock()
+	return len(i.table)
+}
+
+// Intern returns the shared copy of s.  If s is not in the table and the
+// table has room, s becomes the shared copy.
+func (i *Interner) Intern(s string) string {
+	if len(s) == 0 || len(s) > i.maxLength {
+		return s
+	}
+
+	i.m.RLock()
+	shared, ok := i.table[s]
+	i.m.RUnlock()
+	if ok {
+		return shared
+	}
+
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	if shared, ok = i.table[s]; ok {
+		return shared
+	}
+
+	if len(i.table) >= i.maxEntries {
+		return s
+	}
+
+	i.table[s] = s
+	return s
+}
+
+// InternBytes is like Intern, except that it only allocates a string when b
+// is not already in the table.
+func (i *Interner) InternBytes(b []byte) string {
+	if len(b) > 0 && len(b) <= i.maxLength {
+		i.m.RLock()
+		// the compiler avoids allocating for string(b) in a map lookup
+		shared, ok := i.table[string(b)]
+		i.m.RUnlock()
+		if ok {
+			return shared
+		}
+	}
+
+	return i.Intern(string(b))
+}
+
+// Message interns the strings of the message that are expected to repeat
+// across messages: the metadata keys, the partner IDs, the content type and
+// the accept type.  Metadata values and locators are not interned since they
+// are normally unique to a device or message.
+func (i *Interner) Message(msg *Message) {
+	if msg == nil {
+		return
+	}
+
+	msg.ContentType = i.Intern(msg.ContentType)
+	msg.Accept = i.Intern(msg.Accept)
+
+	for j, id := range msg.PartnerIDs {
+		msg.PartnerIDs[j] = i.Intern(id)
+	}
+
+	// Assigning to an existing string key replaces the stored key, so this
+	// swaps each key for its shared copy without rebuilding the map.
+	for k, v := range msg.Metadata {
+		msg.Metadata[i.Intern(k)] = v
+	}
+}
+
+// InterningDecoder returns a Decoder that interns every *Message it decodes
+// using the given Interner.  Values of other types are decoded unchanged.
+func InterningDecoder(d Decoder, i *Interner) Decoder {
+	return &interningDecoder{
+		Decoder:  d,
+		interner: i,
+	}
+}
+
+type interningDecoder struct {
+	Decoder
+	interner *Interner
+}
+
+func (id *interningDecoder) Decode(v interface{}) error {
+	err := id.Decoder.Decode(v)
+	if msg, ok := v.(*Message); ok && err == nil {
+		id.interner.Message(msg)
+	}
+
+	return err
+}
+
+// ArenaInterner configures a DecodeArena to intern every message it decodes
+// using the given Interner.  Unlike the decoder of a single arena, the
+// Interner can be shared by all of the arenas in a process.
+func ArenaInterner(i *Interner) DecodeArenaOption {
+	return decodeArenaOptionFunc(func(a *DecodeArena) {
+		a.interner = i
+	})
+}
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sameString reports if two strings share the same backing storage.
+func sameString(a, b string) bool {
+	return len(a) == len(b) && unsafe.StringData(a) == unsafe.StringData(b)
+}
+
+func TestInterner_Intern(t *testing.T) {
+	assert := assert.New(t)
+
+	i := NewInterner(nil, InternerMaxEntries(2), InternerMaxLength(8))
+
+	first := strings.Clone("/boot")
+	second := strings.Clone(first)
+	assert.False(sameString(first, second))
+
+	assert.True(sameString(first, i.Intern(first)))
+	assert.True(sameString(first, i.Intern(second)))
+	assert.True(sameString(first, i.InternBytes([]byte(second))))
+	assert.Equal(1, i.Len())
+
+	// empty and too long strings are not interned
+	assert.Equal("", i.Intern(""))
+	long := "0123456789"
+	assert.True(sameString(long, i.Intern(long)))
+	assert.Equal(1, i.Len())
+
+	// once full, new strings are returned as is
+	assert.Equal("b", i.Intern("b"))
+	c := strings.Clone("c")
+	assert.True(sameString(c, i.Intern(c)))
+	assert.False(sameString(c, i.InternBytes([]byte("c"))))
+	assert.Equal(2, i.Len())
+}
+
+func TestInterner_Message(t *testing.T) {
+	assert := assert.New(t)
+	i := NewInterner()
+
+	newMsg := func() *Message {
+		return &Message{
+			ContentType: strings.Clone(MimeTypeJson),
+			Accept:      strings.Clone(MimeTypeJson),
+			PartnerIDs:  []string{strings.Clone("comcast")},
+			Metadata: map[string]string{
+				strings.Clone("/boot-time"): "1542834188",
+			},
+		}
+	}
+
+	a := newMsg()
+	b := newMsg()
+	i.Message(a)
+	i.Message(b)
+	i.Message(nil)
+
+	assert.Equal(newMsg(), b)
+	assert.True(sameString(a.ContentType, b.ContentType))
+	assert.True(sameString(a.ContentType, b.Accept))
+	assert.True(sameString(a.PartnerIDs[0], b.PartnerIDs[0]))
+	for ka := range a.Metadata {
+		for kb := range b.Metadata {
+			assert.True(sameString(ka, kb))
+		}
+	}
+}
+
+func TestInterningDecoder(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	i := NewInterner()
+	want := Message{
+		Type:       SimpleEventMessageType,
+		PartnerIDs: []string{"comcast"},
+		Metadata:   map[string]string{"/boot-time": "1542834188"},
+	}
+	encoded := MustEncode(&want, Msgpack)
+
+	var a, b Message
+	require.NoError(InterningDecoder(NewDecoderBytes(encoded, Msgpack), i).Decode(&a))
+	require.NoError(InterningDecoder(NewDecoderBytes(encoded, Msgpack), i).Decode(&b))
+	assert.Equal(want, a)
+	assert.True(sameString(a.PartnerIDs[0], b.PartnerIDs[0]))
+
+	// other types are decoded as usual
+	var sr SimpleRequestResponse
+	assert.NoError(InterningDecoder(NewDecoderBytes(encoded, Msgpack), i).Decode(&sr))
+
+	assert.Error(InterningDecoder(NewDecoderBytes([]byte{0xc1}, Msgpack), i).Decode(&a))
+}
+
+func TestArenaInterner(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	i := NewInterner()
+	want := Message{
+		Type:       SimpleEventMessageType,
+		PartnerIDs: []string{"comcast"},
+	}
+	encoded := MustEncode(&want, JSON)
+
+	var a, b Message
+	require.NoError(NewDecodeArena(JSON, ArenaInterner(i)).Decode(encoded, &a))
+	require.NoError(NewDecodeArena(JSON, ArenaInterner(i)).Decode(encoded, &b))
+	assert.Equal(want, a)
+	assert.True(sameString(a.PartnerIDs[0], b.PartnerIDs[0]))
+}
+
+func TestInterner_concurrent(t *testing.T) {
+	i := NewInterner()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				i.Intern(strings.Clone("partner"))
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, i.Len())
+}
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+// fieldgen generates the Field enumeration of the wrp package, along with the
+// tables used to name and copy each field, from the definition of Message.
+//
+// Usage, from the wrp package directory:
+//
+//	go run ./internal/fieldgen -i messages.go -o field_gen.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+)
+
+type field struct {
+	name       string
+	deprecated bool
+}
+
+func main() {
+	input := flag.String("i", "messages.go", "the file containing the Message struct")
+	output := flag.String("o", "field_gen.go", "the generated file")
+	flag.Parse()
+
+	fields, err := messageFields(*input)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	src, err := generate(fields)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(*output, src, 0644); err != nil { // nolint:gosec
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// messageFields returns the exported fields of the Message struct, in order.
+func messageFields(filename string) ([]field, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), filename, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := f.Scope.Lookup("Message")
+	if obj == nil {
+		return nil, fmt.Errorf("no Message type in %s", filename)
+	}
+
+	st, ok := obj.Decl.(*ast.TypeSpec).Type.(*ast.StructType)
+	if !ok {
+		return nil, fmt.Errorf("Message is not a struct in %s", filename)
+	}
+
+	var fields []field
+	for _, f := range st.Fields.List {
+		deprecated := f.Doc != nil && strings.Contains(f.Doc.Text(), "Deprecated:")
+		for _, name := range f.Names {
+			if name.IsExported() {
+				fields = append(fields, field{name: name.Name, deprecated: deprecated})
+			}
+		}
+	}
+
+	return fields, nil
+}
+
+func generate(fields []field) ([]byte, error) {
+	var buf bytes.Buffer
+	p := func(format string, args ...any) {
+		fmt.Fprintf(&buf, format, args...)
+	}
+
+	p("// Code generated by \"go run ./internal/fieldgen\"; DO NOT EDIT.\n\n")
+	p("package wrp\n\n")
+
+	p("// The fields of a Message.\n")
+	p("const (\n")
+	for i, f := range fields {
+		if i == 0 {
+			p("\t%sField Field = iota\n", f.name)
+		} else {
+			p("\t%sField\n", f.name)
+		}
+	}
+	p("\tlastField\n")
+	p(")\n\n")
+
+	p("var fieldNames = [...]string{\n")
+	for _, f := range fields {
+		p("\t%sField: %q,\n", f.name, f.name)
+	}
+	p("}\n\n")
+
+	p("var fieldCopiers = [...]func(dst, src *Message){\n")
+	for _, f := range fields {
+		nolint := ""
+		if f.deprecated {
+			nolint = " // nolint:staticcheck"
+		}
+		p("\t%sField: func(dst, src *Message) { dst.%s = src.%s },%s\n", f.name, f.name, f.name, nolint)
+	}
+	p("}\n")
+
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracecontext parses the W3C trace context and B3 formats of trace
+// context.  It has no dependencies, so both the wrp package, which logs the
+// trace IDs of messages, and wrptrace, which propagates trace context, use the
+// same parser.
+package tracecontext
+
+import (
+	"encoding/hex"
+	"strings"
+)
+
+// The keys of the trace context.  The same names are used for HTTP headers
+// and, in lower case, for Metadata keys.
+const (
+	TraceParentKey = "traceparent"
+	TraceStateKey  = "tracestate"
+	B3Key          = "b3"
+	B3TraceIDKey   = "x-b3-traceid"
+	B3SpanIDKey    = "x-b3-spanid"
+	B3SampledKey   = "x-b3-sampled"
+	B3FlagsKey     = "x-b3-flags"
+)
+
+// SpanContext identifies a span of a trace.
+type SpanContext struct {
+	// TraceID is the 32 lower case hex digits of the trace.
+	TraceID string
+
+	// SpanID is the 16 lower case hex digits of the span.
+	SpanID string
+
+	// Sampled is true if the trace is sampled.
+	Sampled bool
+
+	// TraceState is the W3C tracestate, which carries vendor specific data.
+	// It is only propagated in the W3C format.
+	TraceState string
+}
+
+// IsValid returns true if the trace and span IDs are valid and not all zeros.
+func (sc SpanContext) IsValid() bool {
+	return isID(sc.TraceID, 32) && isID(sc.SpanID, 16)
+}
+
+func isID(s string, n int) bool {
+	return len(s) == n && isLowerHex(s) && strings.Trim(s, "0") != ""
+}
+
+func isLowerHex(s string) bool {
+	_, err := hex.DecodeString(s)
+	return err == nil && strings.ToLower(s) == s
+}
+
+// Extract returns the span context of the keys returned by get, which returns
+// an empty string for a missing key.  The formats are tried in the order W3C,
+// B3 single and B3 multi.  The bool is false if there is no valid span
+// context.
+func Extract(get func(key string) string) (SpanContext, bool) {
+	for _, extract := range []func(func(string) string) (SpanContext, bool){W3C, B3Single, B3Multi} {
+		if sc, ok := extract(get); ok {
+			return sc, true
+		}
+	}
+
+	return SpanContext{}, false
+}
+
+// W3C extracts a traceparent.  Versions other than 00 are accepted as long as
+// they start with the version 00 fields, as the specification requires.
+func W3C(get func(key string) string) (SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(get(TraceParentKey)), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || !isLowerHex(parts[0]) || parts[0] == "ff" ||
+		len(parts[3]) != 2 || !isLowerHex(parts[3]) || (parts[0] == "00" && len(parts) != 4) {
+		return SpanContext{}, false
+	}
+
+	flags, _ := hex.DecodeString(parts[3])
+	sc := SpanContext{
+		TraceID:    parts[1],
+		SpanID:     parts[2],
+		Sampled:    flags[0]&1 == 1,
+		TraceState: get(TraceStateKey),
+	}
+
+	return sc, sc.IsValid()
+}
+
+// B3Single extracts a b3 value of the form
+// {trace id}-{span id}[-{sampled}[-{parent span id}]].
+func B3Single(get func(key string) string) (SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(get(B3Key)), "-")
+	if len(parts) < 2 || len(parts) > 4 {
+		return SpanContext{}, false
+	}
+
+	sc := SpanContext{
+		TraceID: b3TraceID(parts[0]),
+		SpanID:  parts[1],
+	}
+
+	if len(parts) > 2 {
+		switch parts[2] {
+		case "1", "d":
+			sc.Sampled = true
+		case "0":
+		default:
+			return SpanContext{}, false
+		}
+	}
+
+	return sc, sc.IsValid()
+}
+
+// B3Multi extracts the x-b3-* values.
+func B3Multi(get func(key string) string) (SpanContext, bool) {
+	sc := SpanContext{
+		TraceID: b3TraceID(get(B3TraceIDKey)),
+		SpanID:  get(B3SpanIDKey),
+		Sampled: get(B3SampledKey) == "1" || get(B3FlagsKey) == "1",
+	}
+
+	return sc, sc.IsValid()
+}
+
+// b3TraceID returns the 32 digits of a B3 trace ID, which may also be 16
+// digits long.
+func b3TraceID(id string) string {
+	if len(id) == 16 {
+		return strings.Repeat("0", 16) + id
+	}
+
+	return id
+}
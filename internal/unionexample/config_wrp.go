@@ -0,0 +1,87 @@
+// Code generated by wrpgen; DO NOT EDIT.
+
+package unionexample
+
+import "github.com/xmidt-org/wrp-go/v3"
+
+var _ wrp.Union = (*Config)(nil)
+
+// MsgType returns the message type of the view.
+func (v *Config) MsgType() wrp.MessageType {
+	if v.Type != 0 {
+		return v.Type
+	}
+
+	return wrp.CreateMessageType
+}
+
+// FromMessage fills in the view from the message and validates it.
+func (v *Config) FromMessage(msg *wrp.Message) error {
+	if err := wrp.CheckUnionType(msg.Type, wrp.CreateMessageType, wrp.UpdateMessageType); err != nil {
+		return err
+	}
+
+	v.Type = msg.Type
+	v.Source = msg.Source
+	v.Destination = msg.Destination
+	v.TransactionUUID = msg.TransactionUUID
+	v.Path = msg.Path
+	v.Status = msg.Status
+	v.PartnerIDs = msg.PartnerIDs
+	v.Spans = msg.Spans // nolint:staticcheck
+	v.QOS = msg.QualityOfService
+	v.Payload = msg.Payload
+
+	return v.Validate()
+}
+
+// ToMessage validates the view and replaces the message with its fields.
+func (v *Config) ToMessage(msg *wrp.Message) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+
+	*msg = wrp.Message{
+		Type:             v.MsgType(),
+		Source:           v.Source,
+		Destination:      v.Destination,
+		TransactionUUID:  v.TransactionUUID,
+		Path:             v.Path,
+		Status:           v.Status,
+		PartnerIDs:       v.PartnerIDs,
+		Spans:            v.Spans, // nolint:staticcheck
+		QualityOfService: v.QOS,
+		Payload:          v.Payload,
+	}
+
+	return nil
+}
+
+// Validate checks the message type and the required fields of the view.
+func (v *Config) Validate() error {
+	if err := wrp.CheckUnionType(v.MsgType(), wrp.CreateMessageType, wrp.UpdateMessageType); err != nil {
+		return err
+	}
+
+	var missing []string
+	if v.Source == "" {
+		missing = append(missing, "Source")
+	}
+	if v.Destination == "" {
+		missing = append(missing, "Destination")
+	}
+	if v.TransactionUUID == "" {
+		missing = append(missing, "TransactionUUID")
+	}
+	if v.Path == "" {
+		missing = append(missing, "Path")
+	}
+	if len(v.PartnerIDs) == 0 {
+		missing = append(missing, "PartnerIDs")
+	}
+	if len(v.Payload) == 0 {
+		missing = append(missing, "Payload")
+	}
+
+	return wrp.CheckUnionFields(v.MsgType(), missing...)
+}
@@ -0,0 +1,56 @@
+// Code generated by wrpgen; DO NOT EDIT.
+
+package unionexample
+
+import "github.com/xmidt-org/wrp-go/v3"
+
+var _ wrp.Union = (*Reboot)(nil)
+
+// MsgType returns the message type of the view.
+func (v *Reboot) MsgType() wrp.MessageType {
+	return wrp.SimpleEventMessageType
+}
+
+// FromMessage fills in the view from the message and validates it.
+func (v *Reboot) FromMessage(msg *wrp.Message) error {
+	if err := wrp.CheckUnionType(msg.Type, wrp.SimpleEventMessageType); err != nil {
+		return err
+	}
+
+	v.Source = msg.Source
+	v.Destination = msg.Destination
+	v.Metadata = msg.Metadata
+	v.Payload = msg.Payload
+
+	return v.Validate()
+}
+
+// ToMessage validates the view and replaces the message with its fields.
+func (v *Reboot) ToMessage(msg *wrp.Message) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+
+	*msg = wrp.Message{
+		Type:        v.MsgType(),
+		Source:      v.Source,
+		Destination: v.Destination,
+		Metadata:    v.Metadata,
+		Payload:     v.Payload,
+	}
+
+	return nil
+}
+
+// Validate checks the message type and the required fields of the view.
+func (v *Reboot) Validate() error {
+	var missing []string
+	if v.Source == "" {
+		missing = append(missing, "Source")
+	}
+	if v.Destination == "" {
+		missing = append(missing, "Destination")
+	}
+
+	return wrp.CheckUnionFields(v.MsgType(), missing...)
+}
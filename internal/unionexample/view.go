@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+// Package unionexample holds message views generated by cmd/wrpgen, which
+// keep the generator and its output under test.
+package unionexample
+
+import "github.com/xmidt-org/wrp-go/v3"
+
+//go:generate go run ../../cmd/wrpgen -type Reboot -msgtype SimpleEvent
+//go:generate go run ../../cmd/wrpgen -type Config -msgtype Create,Update
+
+// Reboot is the view of a device reboot event.
+type Reboot struct {
+	Source      string            `wrp:"source,required"`
+	Destination string            `wrp:"dest,required"`
+	Metadata    map[string]string `wrp:"metadata"`
+	Payload     []byte            `wrp:"payload"`
+
+	// Received is not part of the message.
+	Received bool
+}
+
+// Config is the view of a request that changes a configuration.
+type Config struct {
+	Type            wrp.MessageType `wrp:"msg_type"`
+	Source          string          `wrp:"source,required"`
+	Destination     string          `wrp:"dest,required"`
+	TransactionUUID string          `wrp:"transaction_uuid,required"`
+	Path            string          `wrp:"path,required"`
+	Status          *int64          `wrp:"status"`
+	PartnerIDs      []string        `wrp:"partner_ids,required"`
+	Spans           [][]string      `wrp:"spans"`
+	QOS             wrp.QOSValue    `wrp:"qos"`
+	Payload         []byte          `wrp:"payload,required"`
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package unionexample
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestReboot(t *testing.T) {
+	msg := wrp.Message{
+		Type:            wrp.SimpleEventMessageType,
+		Source:          "mac:112233445566",
+		Destination:     "event:device-status/mac:112233445566/reboot",
+		TransactionUUID: "dropped",
+		Metadata:        map[string]string{"/boot-time": "1"},
+		Payload:         []byte("payload"),
+	}
+
+	var r Reboot
+	require.NoError(t, r.FromMessage(&msg))
+	assert.Equal(t, Reboot{
+		Source:      msg.Source,
+		Destination: msg.Destination,
+		Metadata:    msg.Metadata,
+		Payload:     msg.Payload,
+	}, r)
+
+	var out wrp.Message
+	require.NoError(t, r.ToMessage(&out))
+	msg.TransactionUUID = ""
+	assert.Equal(t, msg, out)
+
+	msg.Type = wrp.SimpleRequestResponseMessageType
+	err := r.FromMessage(&msg)
+	assert.ErrorIs(t, err, wrp.ErrMessageTypeMismatch)
+	assert.Equal(t, wrp.CodeInvalidMessageType, wrp.ErrorCodeOf(err))
+
+	err = (&Reboot{Source: "mac:112233445566"}).ToMessage(&out)
+	assert.ErrorIs(t, err, wrp.ErrRequiredFieldsMissing)
+	assert.ErrorIs(t, err, &wrp.Error{Code: wrp.CodeMissingField, Field: "Destination"})
+}
+
+func TestConfig(t *testing.T) {
+	msg := wrp.Message{
+		Type:             wrp.UpdateMessageType,
+		Source:           "dns:example.com",
+		Destination:      "mac:112233445566/config",
+		TransactionUUID:  "1234",
+		Path:             "/config",
+		PartnerIDs:       []string{"comcast"},
+		QualityOfService: wrp.QOSHighValue,
+		Payload:          []byte("{}"),
+	}
+
+	var c Config
+	require.NoError(t, c.FromMessage(&msg))
+	assert.Equal(t, wrp.UpdateMessageType, c.MsgType())
+
+	var out wrp.Message
+	require.NoError(t, c.ToMessage(&out))
+	assert.Equal(t, msg, out)
+
+	// the first message type is the default
+	c.Type = 0
+	require.NoError(t, c.ToMessage(&out))
+	assert.Equal(t, wrp.CreateMessageType, out.Type)
+
+	c.Type = wrp.DeleteMessageType
+	assert.ErrorIs(t, c.Validate(), wrp.ErrMessageTypeMismatch)
+
+	err := (&Config{}).Validate()
+	assert.ErrorIs(t, err, wrp.ErrRequiredFieldsMissing)
+	assert.Empty(t, wrp.ErrorFieldOf(err), "several fields are missing")
+}
+
+func TestUnionJSON(t *testing.T) {
+	r := Reboot{
+		Source:      "mac:112233445566",
+		Destination: "event:device-status/mac:112233445566/reboot",
+		Payload:     []byte("payload"),
+	}
+
+	b, err := wrp.MarshalUnionJSON(&r)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"msg_type": 4,
+		"source": "mac:112233445566",
+		"dest": "event:device-status/mac:112233445566/reboot",
+		"payload": "cGF5bG9hZA==",
+		"qos": 0
+	}`, string(b))
+
+	var out Reboot
+	require.NoError(t, wrp.UnmarshalUnionJSON(b, &out))
+	assert.Equal(t, r, out)
+
+	err = wrp.UnmarshalUnionJSON(b, &Config{})
+	assert.ErrorIs(t, err, wrp.ErrMessageTypeMismatch)
+
+	_, err = wrp.MarshalUnionJSON(&Reboot{})
+	assert.ErrorIs(t, err, wrp.ErrRequiredFieldsMissing)
+}
+
+func TestAs(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	msg := wrp.Message{
+		Type:        wrp.SimpleEventMessageType,
+		Source:      "mac:112233445566",
+		Destination: "event:device-status/mac:112233445566/reboot",
+	}
+
+	r, err := wrp.As[Reboot](&msg)
+	require.NoError(err)
+	assert.Equal(&Reboot{Source: msg.Source, Destination: msg.Destination}, r)
+	assert.True(wrp.Is[Reboot](&msg))
+	assert.True(wrp.Is[wrp.SimpleEvent](&msg))
+
+	// a view also checks its required fields
+	assert.False(wrp.Is[Config](&msg))
+	msg.Destination = ""
+	_, err = wrp.As[Reboot](&msg)
+	assert.ErrorIs(err, wrp.ErrRequiredFieldsMissing)
+}
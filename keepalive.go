@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	// DefaultKeepaliveInterval is the default time between ServiceAlive
+	// messages.
+	DefaultKeepaliveInterval = 30 * time.Second
+
+	// DefaultKeepaliveJitter is the default fraction of each delay that is
+	// randomized.
+	DefaultKeepaliveJitter = 0.1
+
+	// DefaultKeepaliveMinBackoff is the default delay after the first failed
+	// send.
+	DefaultKeepaliveMinBackoff = time.Second
+)
+
+var ErrInvalidKeepalive = errors.New("invalid keepalive configuration")
+
+// KeepaliveSendFunc sends a single message.  A non-nil error is a failed send.
+type KeepaliveSendFunc func(context.Context, Message) error
+
+// KeepaliveOption is a functional option for configuring a Keepalive.
+type KeepaliveOption interface {
+	apply(*Keepalive) error
+}
+
+type keepaliveOptionFunc func(*Keepalive) error
+
+func (f keepaliveOptionFunc) apply(k *Keepalive) error {
+	return f(k)
+}
+
+// KeepaliveInterval sets the time between ServiceAlive messages while sends
+// are succeeding.  The interval must be positive.
+func KeepaliveInterval(d time.Duration) KeepaliveOption {
+	return keepaliveOptionFunc(func(k *Keepalive) error {
+		if d <= 0 {
+			return fmt.Errorf("%w: interval %s", ErrInvalidKeepalive, d)
+		}
+		k.interval = d
+		return nil
+	})
+}
+
+// KeepaliveJitter sets the fraction of each delay that is randomized, so that
+// a fleet of clients started together does not send in lock step.  A jitter of
+// 0.1 spreads each delay evenly across +/- 10%.  The jitter must be in the range
+// [0, 1).
+func KeepaliveJitter(fraction float64) KeepaliveOption {
+	return keepaliveOptionFunc(func(k *Keepalive) error {
+		if !(fraction >= 0 && fraction < 1) {
+			return fmt.Errorf("%w: jitter %v", ErrInvalidKeepalive, fraction)
+		}
+		k.jitter = fraction
+		return nil
+	})
+}
+
+// KeepaliveBackoff sets the delays used after failed sends.  The first retry
+// waits min, and each consecutive failure doubles the delay up to max.  The
+// first successful send returns to the regular interval.  A max of zero, the
+// default, caps the backoff at the interval.
+func KeepaliveBackoff(min, max time.Duration) KeepaliveOption {
+	return keepaliveOptionFunc(func(k *Keepalive) error {
+		if min <= 0 || (max != 0 && max < min) {
+			return fmt.Errorf("%w: backoff min=%s max=%s", ErrInvalidKeepalive, min, max)
+		}
+		k.minBackoff = min
+		k.maxBackoff = max
+		return nil
+	})
+}
+
+// KeepaliveMessage sets the template for the messages sent, e.g. to set the
+// Source.  The Type is always ServiceAliveMessageType.
+func KeepaliveMessage(msg Message) KeepaliveOption {
+	return keepaliveOptionFunc(func(k *Keepalive) error {
+		k.msg = msg
+		return nil
+	})
+}
+
+// KeepaliveOnFailure sets a function that is called with the error and the
+// number of consecutive failures each time a send fails.
+func KeepaliveOnFailure(f func(err error, failures int)) KeepaliveOption {
+	return keepaliveOptionFunc(func(k *Keepalive) error {
+		k.onFailure = f
+		return nil
+	})
+}
+
+// Keepalive periodically emits ServiceAlive messages over a send function.
+// It is intended for clients that hold a connection to a WRP server, e.g.
+// parodus, and must keep that connection alive.
+type Keepalive struct {
+	send       KeepaliveSendFunc
+	msg        Message
+	interval   time.Duration
+	jitter     float64
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	onFailure  func(error, int)
+
+	// random and sleep are replaceable for testing
+	random func() float64
+	sleep  func(context.Context, time.Duration) error
+}
+
+// NewKeepalive creates a Keepalive that emits messages with the given send
+// function.
+func NewKeepalive(send KeepaliveSendFunc, opts ...KeepaliveOption) (*Keepalive, error) {
+	if send == nil {
+		return nil, fmt.Errorf("%w: nil send function", ErrInvalidKeepalive)
+	}
+
+	k := Keepalive{
+		send:       send,
+		interval:   DefaultKeepaliveInterval,
+		jitter:     DefaultKeepaliveJitter,
+		minBackoff: DefaultKeepaliveMinBackoff,
+		random:     rand.Float64,
+		sleep:      sleepContext,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			if err := opt.apply(&k); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if k.maxBackoff == 0 {
+		k.maxBackoff = k.interval
+	}
+	if k.maxBackoff < k.minBackoff {
+		k.maxBackoff = k.minBackoff
+	}
+
+	k.msg.Type = ServiceAliveMessageType
+	return &k, nil
+}
+
+// Run sends a ServiceAlive message immediately, then continues to send them
+// until the context is canceled.  Run blocks, and always returns the context's
+// error.
+func (k *Keepalive) Run(ctx context.Context) error {
+	failures := 0
+	for {
+		if err := k.send(ctx, k.msg); err != nil {
+			failures++
+			if k.onFailure != nil {
+				k.onFailure(err, failures)
+			}
+		} else {
+			failures = 0
+		}
+
+		if err := k.sleep(ctx, k.delay(failures)); err != nil {
+			return err
+		}
+	}
+}
+
+// delay returns the jittered time to wait before the next send.
+func (k *Keepalive) delay(failures int) time.Duration {
+	d := k.interval
+	if failures > 0 {
+		// cap the exponent to avoid overflow; the max is reached long before
+		shift := math.Min(float64(failures-1), 62)
+		d = time.Duration(math.Min(
+			float64(k.minBackoff)*math.Pow(2, shift),
+			float64(k.maxBackoff),
+		))
+	}
+
+	return d + time.Duration(float64(d)*k.jitter*(2*k.random()-1))
+}
+
+// sleepContext waits for the duration or until the context is canceled.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,227 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKeepalive(t *testing.T) {
+	send := func(context.Context, Message) error { return nil }
+
+	tests := []struct {
+		desc       string
+		send       KeepaliveSendFunc
+		opts       []KeepaliveOption
+		interval   time.Duration
+		maxBackoff time.Duration
+		invalid    bool
+	}{
+		{
+			desc:       "defaults",
+			send:       send,
+			interval:   DefaultKeepaliveInterval,
+			maxBackoff: DefaultKeepaliveInterval,
+		}, {
+			desc:       "nil option",
+			send:       send,
+			opts:       []KeepaliveOption{nil},
+			interval:   DefaultKeepaliveInterval,
+			maxBackoff: DefaultKeepaliveInterval,
+		}, {
+			desc: "custom",
+			send: send,
+			opts: []KeepaliveOption{
+				KeepaliveInterval(time.Minute),
+				KeepaliveJitter(0.5),
+				KeepaliveBackoff(time.Second, 10*time.Minute),
+			},
+			interval:   time.Minute,
+			maxBackoff: 10 * time.Minute,
+		}, {
+			desc: "backoff larger than interval",
+			send: send,
+			opts: []KeepaliveOption{
+				KeepaliveInterval(time.Second),
+				KeepaliveBackoff(5*time.Second, 0),
+			},
+			interval:   time.Second,
+			maxBackoff: 5 * time.Second,
+		}, {
+			desc:    "nil send",
+			invalid: true,
+		}, {
+			desc:    "invalid interval",
+			send:    send,
+			opts:    []KeepaliveOption{KeepaliveInterval(0)},
+			invalid: true,
+		}, {
+			desc:    "negative jitter",
+			send:    send,
+			opts:    []KeepaliveOption{KeepaliveJitter(-0.1)},
+			invalid: true,
+		}, {
+			desc:    "jitter too large",
+			send:    send,
+			opts:    []KeepaliveOption{KeepaliveJitter(1)},
+			invalid: true,
+		}, {
+			desc:    "invalid backoff min",
+			send:    send,
+			opts:    []KeepaliveOption{KeepaliveBackoff(0, time.Second)},
+			invalid: true,
+		}, {
+			desc:    "backoff max less than min",
+			send:    send,
+			opts:    []KeepaliveOption{KeepaliveBackoff(time.Minute, time.Second)},
+			invalid: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			k, err := NewKeepalive(tc.send, tc.opts...)
+			if tc.invalid {
+				assert.ErrorIs(err, ErrInvalidKeepalive)
+				assert.Nil(k)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(tc.interval, k.interval)
+			assert.Equal(tc.maxBackoff, k.maxBackoff)
+			assert.Equal(ServiceAliveMessageType, k.msg.Type)
+		})
+	}
+}
+
+func TestKeepalive_Run(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	errSend := errors.New("send failed")
+
+	// fail three times, then succeed
+	results := []error{nil, errSend, errSend, errSend, nil, nil}
+
+	var (
+		sent     []Message
+		delays   []time.Duration
+		failures []int
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	k, err := NewKeepalive(
+		func(_ context.Context, msg Message) error {
+			err := results[len(sent)]
+			sent = append(sent, msg)
+			return err
+		},
+		KeepaliveInterval(time.Minute),
+		KeepaliveBackoff(time.Second, 3*time.Second),
+		KeepaliveJitter(0),
+		KeepaliveMessage(Message{Type: SimpleEventMessageType, Source: "mac:112233445566"}),
+		KeepaliveOnFailure(func(err error, n int) {
+			assert.ErrorIs(err, errSend)
+			failures = append(failures, n)
+		}),
+	)
+	require.NoError(err)
+
+	k.sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		if len(delays) == len(results) {
+			cancel()
+			return ctx.Err()
+		}
+		return nil
+	}
+
+	assert.ErrorIs(k.Run(ctx), context.Canceled)
+
+	require.Len(sent, len(results))
+	for _, msg := range sent {
+		assert.Equal(ServiceAliveMessageType, msg.Type)
+		assert.Equal("mac:112233445566", msg.Source)
+	}
+
+	assert.Equal([]int{1, 2, 3}, failures)
+	assert.Equal(
+		[]time.Duration{
+			time.Minute,
+			time.Second,
+			2 * time.Second,
+			3 * time.Second,
+			time.Minute,
+			time.Minute,
+		},
+		delays,
+	)
+}
+
+func TestKeepalive_delay(t *testing.T) {
+	tests := []struct {
+		desc     string
+		random   float64
+		failures int
+		expected time.Duration
+	}{
+		{
+			desc:     "no jitter",
+			random:   0.5,
+			expected: 100 * time.Second,
+		}, {
+			desc:     "minimum jitter",
+			random:   0,
+			expected: 90 * time.Second,
+		}, {
+			desc:     "maximum jitter",
+			random:   1,
+			expected: 110 * time.Second,
+		}, {
+			desc:     "backoff",
+			random:   0,
+			failures: 2,
+			expected: 1800 * time.Millisecond,
+		}, {
+			desc:     "many failures",
+			random:   0.5,
+			failures: 1000,
+			expected: 100 * time.Second,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			k, err := NewKeepalive(
+				func(context.Context, Message) error { return nil },
+				KeepaliveInterval(100*time.Second),
+			)
+			require.NoError(t, err)
+
+			k.random = func() float64 { return tc.random }
+			assert.Equal(t, tc.expected, k.delay(tc.failures))
+		})
+	}
+}
+
+func TestSleepContext(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(sleepContext(context.Background(), time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(sleepContext(ctx, time.Hour), context.Canceled)
+}
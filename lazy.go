@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"strings"
+
+	"github.com/ugorji/go/codec"
+)
+
+// lazyMsgpackHandle is the same as msgpackHandle, except that decoded []byte
+// and string values point into the input.
+var lazyMsgpackHandle = codec.MsgpackHandle{
+	WriteExt: true,
+	// nolint:staticcheck
+	BasicHandle: codec.BasicHandle{
+		TypeInfos: codec.NewTypeInfos([]string{"json"}),
+		DecodeOptions: codec.DecodeOptions{
+			ZeroCopy: true,
+		},
+	},
+}
+
+// LazyMessage is a Message whose Payload has not been copied out of the
+// buffer the message was decoded from.  Relays that route a message without
+// inspecting its payload can use a LazyMessage to avoid copying large
+// payloads.
+//
+// Until PayloadBytes is called, the embedded Message's Payload is a view into
+// the source buffer, so the source buffer must not be modified or reused.
+// All other fields are always independent of the source buffer.
+type LazyMessage struct {
+	Message
+
+	// owned is true when the Payload no longer refers to the source buffer.
+	owned bool
+}
+
+// DecodeLazy decodes the input into msg without copying the payload.
+//
+// Only the Msgpack format supports deferring the copy.  JSON payloads are
+// base64 encoded, so they are always decoded eagerly and msg owns its payload
+// when this function returns.
+func DecodeLazy(input []byte, f Format, msg *LazyMessage) error {
+	*msg = LazyMessage{}
+
+	if f != Msgpack {
+		msg.owned = true
+		return NewDecoderBytes(input, f).Decode(&msg.Message)
+	}
+
+	err := codec.NewDecoderBytes(input, &lazyMsgpackHandle).Decode(&msg.Message)
+
+	// The zero copy decoder also returns views for strings, which must not
+	// outlive the source buffer.
+	cloneStrings(&msg.Message)
+
+	if len(msg.Payload) == 0 {
+		msg.Payload = nil
+		msg.owned = true
+	}
+
+	return err
+}
+
+// PayloadView returns the payload without copying it.  If PayloadBytes has
+// not been called, the returned slice refers to the source buffer.
+func (msg *LazyMessage) PayloadView() []byte {
+	return msg.Payload
+}
+
+// PayloadBytes returns a payload that is owned by the message, copying it out
+// of the source buffer on first access.  After this method is called, the
+// message no longer refers to the source buffer.
+func (msg *LazyMessage) PayloadBytes() []byte {
+	if !msg.owned {
+		msg.Payload = append([]byte(nil), msg.Payload...)
+		msg.owned = true
+	}
+
+	return msg.Payload
+}
+
+// IsPayloadOwned returns true if the payload no longer refers to the source
+// buffer.
+func (msg *LazyMessage) IsPayloadOwned() bool {
+	return msg.owned
+}
+
+// ToMessage returns a Message that owns its payload, copying the payload out
+// of the source buffer if needed.
+func (msg *LazyMessage) ToMessage() *Message {
+	msg.PayloadBytes()
+	m := msg.Message
+	return &m
+}
+
+// cloneStrings replaces every string in the message with a copy.
+func cloneStrings(msg *Message) {
+	msg.Source = strings.Clone(msg.Source)
+	msg.Destination = strings.Clone(msg.Destination)
+	msg.TransactionUUID = strings.Clone(msg.TransactionUUID)
+	msg.ContentType = strings.Clone(msg.ContentType)
+	msg.Accept = strings.Clone(msg.Accept)
+	msg.Path = strings.Clone(msg.Path)
+	msg.ServiceName = strings.Clone(msg.ServiceName)
+	msg.URL = strings.Clone(msg.URL)
+	msg.SessionID = strings.Clone(msg.SessionID)
+
+	for i := range msg.Headers {
+		msg.Headers[i] = strings.Clone(msg.Headers[i])
+	}
+
+	for i := range msg.PartnerIDs {
+		msg.PartnerIDs[i] = strings.Clone(msg.PartnerIDs[i])
+	}
+
+	for i := range msg.Spans { // nolint:staticcheck
+		for j := range msg.Spans[i] { // nolint:staticcheck
+			msg.Spans[i][j] = strings.Clone(msg.Spans[i][j]) // nolint:staticcheck
+		}
+	}
+
+	// the keys are views into the source too; as in Interner.Message, storing
+	// under a clone of each key swaps the key itself for the clone
+	for k, v := range msg.Metadata {
+		msg.Metadata[strings.Clone(k)] = strings.Clone(v)
+	}
+}
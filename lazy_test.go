@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeLazy(t *testing.T) {
+	want := Message{
+		Type:            SimpleRequestResponseMessageType,
+		Source:          "dns:example.com",
+		Destination:     "mac:112233445566/config",
+		TransactionUUID: "1234",
+		ContentType:     "application/octet-stream",
+		Accept:          "application/json",
+		Headers:         []string{"a", "b"},
+		Metadata:        map[string]string{"/key": "value"},
+		Spans:           [][]string{{"parent", "name", "1", "2", "3"}},
+		Path:            "/path",
+		PartnerIDs:      []string{"comcast"},
+		SessionID:       "session",
+		Payload:         bytes.Repeat([]byte("payload"), 100),
+	}
+
+	t.Run("Msgpack", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		source := MustEncode(&want, Msgpack)
+
+		var lazy LazyMessage
+		require.NoError(DecodeLazy(source, Msgpack, &lazy))
+		assert.False(lazy.IsPayloadOwned())
+		assert.Equal(want, lazy.Message)
+
+		// the payload is a view into the source
+		view := lazy.PayloadView()
+		assert.True(bytes.Contains(source, view))
+		idx := bytes.Index(source, view)
+		assert.Same(&source[idx], &view[0])
+
+		owned := lazy.PayloadBytes()
+		assert.True(lazy.IsPayloadOwned())
+		assert.Equal(want.Payload, owned)
+		assert.Same(&owned[0], &lazy.PayloadBytes()[0])
+
+		// scribbling over the source must not change the message
+		for i := range source {
+			source[i] = 'X'
+		}
+		assert.Equal(want, lazy.Message)
+		assert.Equal(want, *lazy.ToMessage())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var lazy LazyMessage
+		require.NoError(t, DecodeLazy(MustEncode(&want, JSON), JSON, &lazy))
+		assert.True(t, lazy.IsPayloadOwned())
+		assert.Equal(t, want, lazy.Message)
+	})
+
+	t.Run("no payload", func(t *testing.T) {
+		var lazy LazyMessage
+		require.NoError(t, DecodeLazy(MustEncode(&Message{Type: SimpleEventMessageType}, Msgpack), Msgpack, &lazy))
+		assert.True(t, lazy.IsPayloadOwned())
+		assert.Nil(t, lazy.PayloadView())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var lazy LazyMessage
+		assert.Error(t, DecodeLazy([]byte{0xc1}, Msgpack, &lazy))
+	})
+}
+
+func BenchmarkDecodeLazy(b *testing.B) {
+	encoded := MustEncode(&Message{
+		Type:        SimpleEventMessageType,
+		Source:      "mac:112233445566",
+		Destination: "event:big",
+		Payload:     make([]byte, 64*1024),
+	}, Msgpack)
+
+	b.Run("standard", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var m Message
+			if err := NewDecoderBytes(encoded, Msgpack).Decode(&m); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("lazy", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var m LazyMessage
+			if err := DecodeLazy(encoded, Msgpack, &m); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DefaultLintMaxMetadataValue is the default size, in bytes, above which Lint
+// reports a metadata value.
+const DefaultLintMaxMetadataValue = 256
+
+// LintRule identifies a best practice checked by Lint.  The values are stable,
+// so they may be used to suppress findings in tools and CI.
+type LintRule string
+
+const (
+	// LintContentTypeMissing reports a message with a Payload but no
+	// ContentType, which leaves consumers guessing how to interpret it.
+	LintContentTypeMissing LintRule = "content-type-missing"
+
+	// LintEventWithoutSubtopic reports an event Destination with only a
+	// classifier, e.g. event:device-status rather than
+	// event:device-status/mac:112233445566/online, which prevents consumers
+	// from filtering events.
+	LintEventWithoutSubtopic LintRule = "event-without-subtopic"
+
+	// LintMetadataValueTooLarge reports a metadata value larger than the
+	// configured limit.  Metadata is meant for small routing hints; large
+	// values belong in the Payload.
+	LintMetadataValueTooLarge LintRule = "metadata-value-too-large"
+
+	// LintTransactionUUIDMissing reports a message without a TransactionUUID
+	// whose type requires one, or whose type supports QOS acks, which rely on
+	// it to identify the message.
+	LintTransactionUUIDMissing LintRule = "transaction-uuid-missing"
+)
+
+// LintFinding is an advisory finding about a message.  A finding does not
+// make a message invalid.
+type LintFinding struct {
+	// Rule is the best practice that the message does not follow.
+	Rule LintRule
+
+	// Field is the field the finding is about.
+	Field Field
+
+	// Message describes the finding.
+	Message string
+}
+
+// String returns the finding in the form "rule: Field: message".
+func (f LintFinding) String() string {
+	return fmt.Sprintf("%s: %s: %s", f.Rule, f.Field, f.Message)
+}
+
+// LintOption is a functional option for Lint.
+type LintOption interface {
+	apply(*linter)
+}
+
+type lintOptionFunc func(*linter)
+
+func (f lintOptionFunc) apply(l *linter) {
+	f(l)
+}
+
+// LintMaxMetadataValue sets the size, in bytes, above which a metadata value
+// is reported.  The default is DefaultLintMaxMetadataValue.
+func LintMaxMetadataValue(n int) LintOption {
+	return lintOptionFunc(func(l *linter) {
+		l.maxMetadataValue = n
+	})
+}
+
+// LintSkip skips the given rules.
+func LintSkip(rules ...LintRule) LintOption {
+	return lintOptionFunc(func(l *linter) {
+		l.skip = append(l.skip, rules...)
+	})
+}
+
+type linter struct {
+	maxMetadataValue int
+	skip             []LintRule
+	findings         []LintFinding
+}
+
+func (l *linter) report(rule LintRule, field Field, format string, args ...any) {
+	if slices.Contains(l.skip, rule) {
+		return
+	}
+
+	l.findings = append(l.findings, LintFinding{
+		Rule:    rule,
+		Field:   field,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// Lint checks the message against best practices that are not required by the
+// spec and returns its findings, or nil if there are none.  The findings are
+// advisory and in a stable order, suitable for the CLIs and CI of message
+// producers.  Use a Normifier, or the wrpvalidator package, to check that a
+// message is valid.
+func Lint(msg *Message, opts ...LintOption) []LintFinding {
+	l := linter{
+		maxMetadataValue: DefaultLintMaxMetadataValue,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt.apply(&l)
+		}
+	}
+
+	if len(msg.Payload) > 0 && msg.ContentType == "" {
+		l.report(LintContentTypeMissing, ContentTypeField,
+			"a %d byte payload has no content type", len(msg.Payload))
+	}
+
+	if dest, err := ParseLocator(msg.Destination); err == nil &&
+		dest.Scheme == SchemeEvent && strings.Trim(dest.Ignored, "/") == "" {
+		l.report(LintEventWithoutSubtopic, DestinationField,
+			"event destination `%s` has no subtopic", msg.Destination)
+	}
+
+	keys := make([]string, 0, len(msg.Metadata))
+	for k := range msg.Metadata {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		if n := len(msg.Metadata[k]); n > l.maxMetadataValue {
+			l.report(LintMetadataValueTooLarge, MetadataField,
+				"metadata `%s` is %d bytes, more than %d", k, n, l.maxMetadataValue)
+		}
+	}
+
+	if msg.TransactionUUID == "" {
+		switch {
+		case msg.Type.RequiresTransaction():
+			l.report(LintTransactionUUIDMissing, TransactionUUIDField,
+				"%s messages require a transaction UUID", friendlyName(msg.Type))
+		case msg.Type.SupportsQOSAck():
+			l.report(LintTransactionUUIDMissing, TransactionUUIDField,
+				"%s messages need a transaction UUID to be acknowledged", friendlyName(msg.Type))
+		}
+	}
+
+	return l.findings
+}
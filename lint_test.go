@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	clean := Message{
+		Type:            SimpleEventMessageType,
+		Source:          "mac:112233445566",
+		Destination:     "event:device-status/mac:112233445566/online",
+		TransactionUUID: "c07ee5e1-70be-444c-a156-097c767ad8aa",
+		ContentType:     MimeTypeJson,
+		Metadata:        map[string]string{"/key": "value"},
+		Payload:         []byte(`{}`),
+	}
+
+	tests := []struct {
+		desc   string
+		modify func(*Message)
+		opts   []LintOption
+		want   []LintRule
+		fields []Field
+	}{
+		{
+			desc: "clean",
+		}, {
+			desc:   "content type missing",
+			modify: func(m *Message) { m.ContentType = "" },
+			want:   []LintRule{LintContentTypeMissing},
+			fields: []Field{ContentTypeField},
+		}, {
+			desc: "no payload, no content type",
+			modify: func(m *Message) {
+				m.ContentType = ""
+				m.Payload = nil
+			},
+		}, {
+			desc:   "event without subtopic",
+			modify: func(m *Message) { m.Destination = "event:device-status" },
+			want:   []LintRule{LintEventWithoutSubtopic},
+			fields: []Field{DestinationField},
+		}, {
+			desc:   "event with only a trailing slash",
+			modify: func(m *Message) { m.Destination = "event:device-status/" },
+			want:   []LintRule{LintEventWithoutSubtopic},
+			fields: []Field{DestinationField},
+		}, {
+			desc:   "non-event destination",
+			modify: func(m *Message) { m.Destination = "mac:112233445566" },
+		}, {
+			desc:   "invalid destination is not linted",
+			modify: func(m *Message) { m.Destination = "invalid" },
+		}, {
+			desc: "metadata value too large",
+			modify: func(m *Message) {
+				m.Metadata = map[string]string{
+					"/b": strings.Repeat("x", DefaultLintMaxMetadataValue+1),
+					"/a": strings.Repeat("x", DefaultLintMaxMetadataValue+1),
+					"/c": strings.Repeat("x", DefaultLintMaxMetadataValue),
+				}
+			},
+			want:   []LintRule{LintMetadataValueTooLarge, LintMetadataValueTooLarge},
+			fields: []Field{MetadataField, MetadataField},
+		}, {
+			desc:   "metadata limit option",
+			opts:   []LintOption{LintMaxMetadataValue(4)},
+			want:   []LintRule{LintMetadataValueTooLarge},
+			fields: []Field{MetadataField},
+		}, {
+			desc:   "event transaction uuid missing",
+			modify: func(m *Message) { m.TransactionUUID = "" },
+			want:   []LintRule{LintTransactionUUIDMissing},
+			fields: []Field{TransactionUUIDField},
+		}, {
+			desc: "request transaction uuid missing",
+			modify: func(m *Message) {
+				m.Type = SimpleRequestResponseMessageType
+				m.Destination = "mac:112233445566/config"
+				m.TransactionUUID = ""
+			},
+			want:   []LintRule{LintTransactionUUIDMissing},
+			fields: []Field{TransactionUUIDField},
+		}, {
+			desc: "registration transaction uuid not needed",
+			modify: func(m *Message) {
+				m.Type = ServiceRegistrationMessageType
+				m.Destination = ""
+				m.TransactionUUID = ""
+			},
+		}, {
+			desc: "everything",
+			modify: func(m *Message) {
+				m.ContentType = ""
+				m.Destination = "event:device-status"
+				m.TransactionUUID = ""
+			},
+			opts: []LintOption{LintMaxMetadataValue(1)},
+			want: []LintRule{
+				LintContentTypeMissing,
+				LintEventWithoutSubtopic,
+				LintMetadataValueTooLarge,
+				LintTransactionUUIDMissing,
+			},
+			fields: []Field{ContentTypeField, DestinationField, MetadataField, TransactionUUIDField},
+		}, {
+			desc: "skip",
+			modify: func(m *Message) {
+				m.ContentType = ""
+				m.TransactionUUID = ""
+			},
+			opts:   []LintOption{nil, LintSkip(LintContentTypeMissing)},
+			want:   []LintRule{LintTransactionUUIDMissing},
+			fields: []Field{TransactionUUIDField},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			msg := clean
+			msg.Metadata = map[string]string{"/key": "value"}
+			if tc.modify != nil {
+				tc.modify(&msg)
+			}
+
+			findings := Lint(&msg, tc.opts...)
+			if len(tc.want) == 0 {
+				assert.Nil(findings)
+				return
+			}
+
+			var rules []LintRule
+			var fields []Field
+			for _, f := range findings {
+				rules = append(rules, f.Rule)
+				fields = append(fields, f.Field)
+				assert.NotEmpty(f.Message)
+			}
+			assert.Equal(tc.want, rules)
+			assert.Equal(tc.fields, fields)
+		})
+	}
+}
+
+func TestLintFindingString(t *testing.T) {
+	msg := Message{
+		Type:            SimpleEventMessageType,
+		Destination:     "event:test/sub",
+		TransactionUUID: "id",
+		Metadata: map[string]string{
+			"/b": "12345",
+			"/a": "12345",
+		},
+	}
+
+	findings := Lint(&msg, LintMaxMetadataValue(4))
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.String())
+	}
+
+	assert.Equal(t, []string{
+		"metadata-value-too-large: Metadata: metadata `/a` is 5 bytes, more than 4",
+		"metadata-value-too-large: Metadata: metadata `/b` is 5 bytes, more than 4",
+	}, got)
+}
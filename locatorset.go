@@ -0,0 +1,281 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// locatorSetWildcard matches any authority of a scheme in a LocatorSet entry.
+const locatorSetWildcard = "*"
+
+var ErrInvalidLocatorSetEntry = errors.New("invalid locator set entry")
+
+// LocatorSet is a set of locators for allow and deny lists.  Each entry has
+// one of the forms:
+//
+//	mac:112233445566          any service of the device
+//	mac:112233445566/config   only the config service of the device
+//	mac:*                     any device with the mac scheme
+//	mac:*/config              the config service of any device with the mac scheme
+//
+// Device identifiers are compared in their canonical form, and schemes are
+// case insensitive.  Membership is tested with a constant number of map
+// lookups, regardless of the size of the set.
+//
+// A LocatorSet is marshaled to and from JSON and YAML as a list of entries.
+// The zero value is an empty set, ready to use.  A LocatorSet is not safe for
+// concurrent use while it is being modified.
+type LocatorSet struct {
+	// authorities holds the entries with an authority, keyed by scheme and
+	// canonical authority.
+	authorities map[locatorSetKey]*locatorSetServices
+
+	// schemes holds the wildcard entries, keyed by scheme.
+	schemes map[string]*locatorSetServices
+
+	entries []string
+}
+
+type locatorSetKey struct {
+	scheme    string
+	authority string
+}
+
+// locatorSetServices are the services matched for a scheme or authority.
+type locatorSetServices struct {
+	all      bool
+	services map[string]struct{}
+}
+
+func (s *locatorSetServices) add(service string) {
+	if service == "" {
+		s.all = true
+		return
+	}
+
+	if s.services == nil {
+		s.services = make(map[string]struct{})
+	}
+	s.services[service] = struct{}{}
+}
+
+func (s *locatorSetServices) contains(service string) bool {
+	if s == nil {
+		return false
+	}
+
+	if s.all {
+		return true
+	}
+
+	_, ok := s.services[service]
+	return ok
+}
+
+// NewLocatorSet creates a LocatorSet with the given entries.
+func NewLocatorSet(entries ...string) (*LocatorSet, error) {
+	var s LocatorSet
+	if err := s.Add(entries...); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// Add adds the entries to the set.  If any entry is invalid, an error is
+// returned and the set is unchanged.
+func (s *LocatorSet) Add(entries ...string) error {
+	parsed := make([]locatorSetEntry, 0, len(entries))
+	for _, entry := range entries {
+		e, err := parseLocatorSetEntry(entry)
+		if err != nil {
+			return err
+		}
+		parsed = append(parsed, e)
+	}
+
+	for _, e := range parsed {
+		s.add(e)
+	}
+
+	return nil
+}
+
+func (s *LocatorSet) add(e locatorSetEntry) {
+	var services *locatorSetServices
+	if e.authority == locatorSetWildcard {
+		if s.schemes == nil {
+			s.schemes = make(map[string]*locatorSetServices)
+		}
+		if services = s.schemes[e.scheme]; services == nil {
+			services = new(locatorSetServices)
+			s.schemes[e.scheme] = services
+		}
+	} else {
+		key := locatorSetKey{scheme: e.scheme, authority: e.authority}
+		if s.authorities == nil {
+			s.authorities = make(map[locatorSetKey]*locatorSetServices)
+		}
+		if services = s.authorities[key]; services == nil {
+			services = new(locatorSetServices)
+			s.authorities[key] = services
+		}
+	}
+
+	services.add(e.service)
+
+	if str := e.String(); !slices.Contains(s.entries, str) {
+		s.entries = append(s.entries, str)
+	}
+}
+
+// Contains returns true if the locator matches an entry.  A locator that
+// cannot be parsed is never contained.
+func (s *LocatorSet) Contains(locator string) bool {
+	l, err := ParseLocator(locator)
+	return err == nil && s.ContainsLocator(l)
+}
+
+// ContainsLocator returns true if the parsed locator matches an entry.
+func (s *LocatorSet) ContainsLocator(l Locator) bool {
+	if s == nil {
+		return false
+	}
+
+	authority := l.Authority
+	if l.HasDeviceID() {
+		authority = l.ID.ID()
+	}
+
+	key := locatorSetKey{scheme: l.Scheme, authority: authority}
+	return s.authorities[key].contains(l.Service) || s.schemes[l.Scheme].contains(l.Service)
+}
+
+// Len returns the number of distinct entries in the set.
+func (s *LocatorSet) Len() int {
+	if s == nil {
+		return 0
+	}
+
+	return len(s.entries)
+}
+
+// Entries returns the distinct entries of the set in canonical form, sorted.
+func (s *LocatorSet) Entries() []string {
+	if s == nil {
+		return nil
+	}
+
+	entries := slices.Clone(s.entries)
+	slices.Sort(entries)
+	return entries
+}
+
+// MarshalJSON encodes the set as a JSON array of its Entries.
+func (s LocatorSet) MarshalJSON() ([]byte, error) {
+	entries := s.Entries()
+	if entries == nil {
+		entries = []string{}
+	}
+
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON decodes a JSON array of entries, replacing the contents of the
+// set.
+func (s *LocatorSet) UnmarshalJSON(b []byte) error {
+	var entries []string
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return err
+	}
+
+	return s.replace(entries)
+}
+
+// MarshalYAML encodes the set as a YAML sequence of its Entries.
+func (s LocatorSet) MarshalYAML() (any, error) {
+	return s.Entries(), nil
+}
+
+// UnmarshalYAML decodes a YAML sequence of entries, replacing the contents of
+// the set.
+func (s *LocatorSet) UnmarshalYAML(unmarshal func(any) error) error {
+	var entries []string
+	if err := unmarshal(&entries); err != nil {
+		return err
+	}
+
+	return s.replace(entries)
+}
+
+func (s *LocatorSet) replace(entries []string) error {
+	var replacement LocatorSet
+	if err := replacement.Add(entries...); err != nil {
+		return err
+	}
+
+	*s = replacement
+	return nil
+}
+
+// locatorSetEntry is a parsed LocatorSet entry.
+type locatorSetEntry struct {
+	scheme    string
+	authority string
+	service   string
+}
+
+func (e locatorSetEntry) String() string {
+	if e.service == "" {
+		return e.scheme + ":" + e.authority
+	}
+
+	return e.scheme + ":" + e.authority + "/" + e.service
+}
+
+func parseLocatorSetEntry(entry string) (locatorSetEntry, error) {
+	scheme, rest, ok := strings.Cut(strings.TrimSpace(entry), ":")
+	authority, service, _ := strings.Cut(rest, "/")
+	if !ok || scheme == "" || authority == "" || strings.Contains(service, "/") {
+		return locatorSetEntry{}, fmt.Errorf("%w: `%s`", ErrInvalidLocatorSetEntry, entry)
+	}
+
+	if authority == locatorSetWildcard {
+		scheme = strings.ToLower(scheme)
+		switch {
+		case scheme == SchemeSelf || !IsKnownScheme(scheme):
+			return locatorSetEntry{}, fmt.Errorf("%w: `%s`: unsupported scheme", ErrInvalidLocatorSetEntry, entry)
+		case scheme == SchemeEvent && service != "":
+			return locatorSetEntry{}, fmt.Errorf("%w: `%s`: event locators have no service", ErrInvalidLocatorSetEntry, entry)
+		}
+
+		return locatorSetEntry{scheme: scheme, authority: locatorSetWildcard, service: service}, nil
+	}
+
+	l, err := ParseLocator(strings.TrimSpace(entry))
+	if err != nil {
+		return locatorSetEntry{}, fmt.Errorf("%w: `%s`: %w", ErrInvalidLocatorSetEntry, entry, err)
+	}
+	if l.Ignored != "" {
+		// Only event locators get here, since the service of any other
+		// locator cannot contain a slash.
+		return locatorSetEntry{}, fmt.Errorf("%w: `%s`: event locators have no service", ErrInvalidLocatorSetEntry, entry)
+	}
+
+	e := locatorSetEntry{
+		scheme:    l.Scheme,
+		authority: l.Authority,
+		service:   l.Service,
+	}
+	if l.HasDeviceID() {
+		e.authority = l.ID.ID()
+	}
+
+	return e, nil
+}
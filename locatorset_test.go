@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestLocatorSetContains(t *testing.T) {
+	tests := []struct {
+		desc    string
+		entries []string
+		in      []string
+		out     []string
+	}{
+		{
+			desc: "empty",
+			out:  []string{"mac:112233445566", "dns:example.com"},
+		}, {
+			desc:    "device",
+			entries: []string{"mac:11-22-33-44-55-66"},
+			in:      []string{"mac:112233445566", "MAC:11:22:33:44:55:66/config", "mac:112233445566/config/ignored"},
+			out:     []string{"mac:665544332211", "uuid:112233445566", "invalid"},
+		}, {
+			desc:    "device service",
+			entries: []string{"mac:112233445566/config"},
+			in:      []string{"mac:112233445566/config", "mac:112233445566/config/ignored"},
+			out:     []string{"mac:112233445566", "mac:112233445566/other", "mac:665544332211/config"},
+		}, {
+			desc:    "scheme",
+			entries: []string{"MAC:*"},
+			in:      []string{"mac:112233445566", "mac:665544332211/config"},
+			out:     []string{"uuid:1234", "dns:example.com"},
+		}, {
+			desc:    "scheme service",
+			entries: []string{"mac:*/config"},
+			in:      []string{"mac:112233445566/config", "mac:665544332211/config"},
+			out:     []string{"mac:112233445566", "mac:112233445566/other", "uuid:1234/config"},
+		}, {
+			desc:    "dns",
+			entries: []string{"dns:example.com/service"},
+			in:      []string{"dns:example.com/service"},
+			out:     []string{"dns:example.com", "dns:other.com/service"},
+		}, {
+			desc:    "event",
+			entries: []string{"event:device-status"},
+			in:      []string{"event:device-status", "event:device-status/mac:112233445566/online"},
+			out:     []string{"event:other"},
+		}, {
+			desc:    "mixed",
+			entries: []string{"mac:112233445566/config", "mac:112233445566/status", "uuid:*", "serial:*/config"},
+			in:      []string{"mac:112233445566/config", "mac:112233445566/status", "uuid:abcd/any", "serial:1234/config"},
+			out:     []string{"mac:112233445566/other", "serial:1234/other"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			s, err := NewLocatorSet(tc.entries...)
+			require.NoError(t, err)
+
+			for _, l := range tc.in {
+				assert.True(t, s.Contains(l), l)
+			}
+			for _, l := range tc.out {
+				assert.False(t, s.Contains(l), l)
+			}
+		})
+	}
+}
+
+func TestLocatorSetInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"mac",
+		"mac:",
+		":112233445566",
+		"mac:invalid",
+		"mac:112233445566/config/extra",
+		"unknown:*",
+		"self:*",
+		"event:*/service",
+		"event:device-status/online",
+		"*:*",
+	}
+	for _, entry := range tests {
+		t.Run(entry, func(t *testing.T) {
+			var s LocatorSet
+			require.NoError(t, s.Add("dns:example.com"))
+
+			err := s.Add("mac:112233445566", entry)
+			assert.ErrorIs(t, err, ErrInvalidLocatorSetEntry)
+
+			// The set is unchanged.
+			assert.Equal(t, []string{"dns:example.com"}, s.Entries())
+			assert.False(t, s.Contains("mac:112233445566"))
+
+			_, err = NewLocatorSet(entry)
+			assert.ErrorIs(t, err, ErrInvalidLocatorSetEntry)
+		})
+	}
+}
+
+func TestLocatorSetEntries(t *testing.T) {
+	assert := assert.New(t)
+
+	var nilSet *LocatorSet
+	assert.Zero(nilSet.Len())
+	assert.Nil(nilSet.Entries())
+	assert.False(nilSet.Contains("mac:112233445566"))
+
+	var s LocatorSet
+	assert.Zero(s.Len())
+	assert.False(s.Contains("mac:112233445566"))
+
+	require.NoError(t, s.Add(
+		"mac:11:22:33:44:55:66/config",
+		"mac:112233445566/config",
+		" Mac:* ",
+		"dns:example.com",
+	))
+
+	assert.Equal(3, s.Len())
+	assert.Equal([]string{"dns:example.com", "mac:*", "mac:112233445566/config"}, s.Entries())
+
+	locator, err := ParseLocator("mac:665544332211")
+	require.NoError(t, err)
+	assert.True(s.ContainsLocator(locator))
+}
+
+func TestLocatorSetJSON(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var config struct {
+		Allow LocatorSet  `json:"allow"`
+		Deny  *LocatorSet `json:"deny"`
+	}
+
+	err := json.Unmarshal([]byte(`{
+		"allow": ["mac:*/config", "dns:example.com"],
+		"deny": ["mac:112233445566"]
+	}`), &config)
+	require.NoError(err)
+
+	assert.True(config.Allow.Contains("mac:665544332211/config"))
+	assert.True(config.Deny.Contains("mac:112233445566/config"))
+
+	b, err := json.Marshal(config)
+	require.NoError(err)
+	assert.JSONEq(`{
+		"allow": ["dns:example.com", "mac:*/config"],
+		"deny": ["mac:112233445566"]
+	}`, string(b))
+
+	b, err = json.Marshal(LocatorSet{})
+	require.NoError(err)
+	assert.Equal(`[]`, string(b))
+
+	// Unmarshaling replaces the contents.
+	require.NoError(json.Unmarshal([]byte(`["uuid:*"]`), &config.Allow))
+	assert.Equal([]string{"uuid:*"}, config.Allow.Entries())
+
+	err = json.Unmarshal([]byte(`["invalid"]`), &config.Allow)
+	assert.ErrorIs(err, ErrInvalidLocatorSetEntry)
+	assert.Equal([]string{"uuid:*"}, config.Allow.Entries())
+
+	err = json.Unmarshal([]byte(`"mac:*"`), &config.Allow)
+	assert.Error(err)
+}
+
+func TestLocatorSetYAML(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var config struct {
+		Allow LocatorSet `yaml:"allow"`
+	}
+
+	err := yaml.Unmarshal([]byte("allow:\n  - mac:*/config\n  - dns:example.com\n"), &config)
+	require.NoError(err)
+	assert.True(config.Allow.Contains("mac:665544332211/config"))
+	assert.False(config.Allow.Contains("mac:665544332211"))
+
+	b, err := yaml.Marshal(config)
+	require.NoError(err)
+	assert.Equal("allow:\n    - dns:example.com\n    - mac:*/config\n", string(b))
+
+	err = yaml.Unmarshal([]byte("allow:\n  - invalid\n"), &config)
+	assert.ErrorIs(err, ErrInvalidLocatorSetEntry)
+
+	err = yaml.Unmarshal([]byte("allow: mac:*\n"), &config)
+	assert.Error(err)
+}
@@ -0,0 +1,309 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+
+	"github.com/xmidt-org/wrp-go/v3/internal/tracecontext"
+)
+
+const (
+	// DefaultTraceParentKey is the Metadata key of the W3C traceparent, e.g.
+	// "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", used by a
+	// LogObserver by default.
+	DefaultTraceParentKey = "traceparent"
+
+	// DefaultLogMessage is the message of the log entries of a LogObserver.
+	DefaultLogMessage = "wrp message"
+)
+
+var ErrInvalidLogObserver = errors.New("invalid log observer configuration")
+
+// DefaultLogFields returns the fields logged by a LogObserver by default.  A
+// new slice is returned each time, so callers may modify it freely.
+func DefaultLogFields() []Field {
+	return []Field{
+		TypeField,
+		SourceField,
+		DestinationField,
+		TransactionUUIDField,
+		ContentTypeField,
+		PathField,
+		StatusField,
+		PayloadField,
+	}
+}
+
+// LogObserverOption is a functional option for configuring a LogObserver.
+type LogObserverOption interface {
+	apply(*LogObserver) error
+}
+
+type logObserverOptionFunc func(*LogObserver) error
+
+func (f logObserverOptionFunc) apply(o *LogObserver) error {
+	return f(o)
+}
+
+// LogLevel sets the level of the log entries.  The default is slog.LevelInfo.
+func LogLevel(level slog.Level) LogObserverOption {
+	return logObserverOptionFunc(func(o *LogObserver) error {
+		o.level = level
+		return nil
+	})
+}
+
+// LogMessage sets the message of the log entries.  The default is
+// DefaultLogMessage.
+func LogMessage(msg string) LogObserverOption {
+	return logObserverOptionFunc(func(o *LogObserver) error {
+		o.msg = msg
+		return nil
+	})
+}
+
+// LogFields sets the fields of the message that are logged, replacing
+// DefaultLogFields.  Each field is logged under its wire name, e.g. "dest",
+// and only if it is set.  The Payload is never logged, only its length as
+// "payload_length".  An invalid field results in an error wrapping
+// ErrInvalidLogObserver.
+func LogFields(fields ...Field) LogObserverOption {
+	return logObserverOptionFunc(func(o *LogObserver) error {
+		for _, f := range fields {
+			if !f.valid() {
+				return fmt.Errorf("%w: %s", ErrInvalidLogObserver, f)
+			}
+		}
+		o.fields = append([]Field(nil), fields...)
+		return nil
+	})
+}
+
+// LogSampleRate sets the fraction, in the range [0, 1], of messages that are
+// logged.  The default is 1, which logs every message.
+func LogSampleRate(rate float64) LogObserverOption {
+	return logObserverOptionFunc(func(o *LogObserver) error {
+		if !(rate >= 0 && rate <= 1) {
+			return fmt.Errorf("%w: sample rate %v", ErrInvalidLogObserver, rate)
+		}
+		o.sampleRate = rate
+		return nil
+	})
+}
+
+// LogTraceParentKey sets the Metadata key of the W3C traceparent.  The default
+// is DefaultTraceParentKey.  The B3 keys are always those of wrptrace.  An
+// empty key disables the trace_id and span_id attributes.
+func LogTraceParentKey(key string) LogObserverOption {
+	return logObserverOptionFunc(func(o *LogObserver) error {
+		o.traceParentKey = key
+		return nil
+	})
+}
+
+// LogRedactor sets the Redactor that is applied to each message before it is
+// logged, replacing the default, which redacts the values of all Metadata
+// keys.  A nil Redactor disables redaction.  When the Redactor hashes the
+// Payload, the hash is logged as "payload_hash" in place of "payload_length".
+func LogRedactor(r *Redactor) LogObserverOption {
+	return logObserverOptionFunc(func(o *LogObserver) error {
+		o.redactor = r
+		return nil
+	})
+}
+
+// LogObserver is an Observer that logs a summary of each message through a
+// slog.Logger, to standardize message logging across services.
+//
+// When the message's Metadata holds a valid trace context, in the W3C or B3
+// formats that wrptrace propagates and parsed the same way, the entry has
+// trace_id and span_id attributes, the names the OpenTelemetry log data model
+// uses, so entries written through an OpenTelemetry slog bridge, or collected
+// from JSON logs, are correlated with the trace of the message.
+//
+// Sampling is decided by TransactionSample, so every service using the same
+// sample rate logs the same transactions.  Messages without a TransactionUUID
+// are sampled at random.
+//
+// Use ObserverAsProcessor to place a LogObserver in a Processors chain.  A
+// LogObserver is safe for concurrent use.
+type LogObserver struct {
+	logger         *slog.Logger
+	level          slog.Level
+	msg            string
+	fields         []Field
+	sampleRate     float64
+	traceParentKey string
+	redactor       *Redactor
+
+	// random is replaceable for testing
+	random func() float64
+}
+
+var _ Observer = (*LogObserver)(nil)
+
+// NewLogObserver creates a LogObserver that logs through the logger.
+func NewLogObserver(logger *slog.Logger, opts ...LogObserverOption) (*LogObserver, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("%w: nil logger", ErrInvalidLogObserver)
+	}
+
+	o := LogObserver{
+		logger:         logger,
+		level:          slog.LevelInfo,
+		msg:            DefaultLogMessage,
+		fields:         DefaultLogFields(),
+		sampleRate:     1,
+		traceParentKey: DefaultTraceParentKey,
+		redactor:       &Redactor{mask: DefaultRedactionMask, allMetadata: true},
+		random:         rand.Float64, // nolint:gosec
+	}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt.apply(&o); err != nil {
+			return nil, err
+		}
+	}
+
+	return &o, nil
+}
+
+// ObserveWRP logs the message if it is sampled and the logger is enabled for
+// the level.
+func (o *LogObserver) ObserveWRP(ctx context.Context, msg Message) {
+	if !o.logger.Enabled(ctx, o.level) || o.sample(msg.TransactionUUID) >= o.sampleRate {
+		return
+	}
+
+	o.logger.LogAttrs(ctx, o.level, o.msg, o.attrs(&msg)...)
+}
+
+// sample returns a value in [0, 1) that is compared to the sample rate.
+func (o *LogObserver) sample(tid string) float64 {
+	if o.sampleRate >= 1 {
+		return 0
+	}
+
+	return TransactionSample(tid, o.random)
+}
+
+// attrs returns the attributes of the log entry of the message.  The trace
+// context is taken from the message before it is redacted.
+func (o *LogObserver) attrs(msg *Message) []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(o.fields)+2)
+	if o.traceParentKey != "" {
+		if sc, ok := tracecontext.Extract(o.traceKey(msg)); ok {
+			attrs = append(attrs, slog.String("trace_id", sc.TraceID), slog.String("span_id", sc.SpanID))
+		}
+	}
+
+	if o.redactor != nil {
+		msg = o.redactor.Redact(msg)
+	}
+
+	schema := messageWireSchema()
+	for _, f := range o.fields {
+		if f == PayloadField && o.redactor != nil && o.redactor.Payload() == PayloadHash {
+			if len(msg.Payload) > 0 {
+				attrs = append(attrs, slog.String("payload_hash", string(msg.Payload)))
+			}
+			continue
+		}
+
+		if a, ok := fieldAttr(schema.Fields[f].Name, f, msg); ok {
+			attrs = append(attrs, a)
+		}
+	}
+
+	return attrs
+}
+
+// traceKey returns the function that looks up the keys of the trace context
+// in the Metadata of the message, with the traceparent under traceParentKey.
+func (o *LogObserver) traceKey(msg *Message) func(string) string {
+	return func(key string) string {
+		if key == tracecontext.TraceParentKey {
+			key = o.traceParentKey
+		}
+
+		return msg.Metadata[key]
+	}
+}
+
+// fieldAttr returns the attribute of a field of the message, or false if the
+// field is not set.
+func fieldAttr(name string, f Field, msg *Message) (slog.Attr, bool) {
+	str := func(v string) (slog.Attr, bool) {
+		return slog.String(name, v), v != ""
+	}
+
+	strs := func(v []string) (slog.Attr, bool) {
+		return slog.Any(name, v), len(v) > 0
+	}
+
+	integer := func(v *int64) (slog.Attr, bool) {
+		if v == nil {
+			return slog.Attr{}, false
+		}
+		return slog.Int64(name, *v), true
+	}
+
+	switch f {
+	case TypeField:
+		return slog.String(name, msg.Type.FriendlyName()), true
+	case SourceField:
+		return str(msg.Source)
+	case DestinationField:
+		return str(msg.Destination)
+	case TransactionUUIDField:
+		return str(msg.TransactionUUID)
+	case ContentTypeField:
+		return str(msg.ContentType)
+	case AcceptField:
+		return str(msg.Accept)
+	case StatusField:
+		return integer(msg.Status)
+	case RequestDeliveryResponseField:
+		return integer(msg.RequestDeliveryResponse)
+	case HeadersField:
+		return strs(msg.Headers)
+	case MetadataField:
+		attrs := make([]any, 0, len(msg.Metadata))
+		for k, v := range msg.Metadata {
+			attrs = append(attrs, slog.String(k, v))
+		}
+		return slog.Group(name, attrs...), len(attrs) > 0
+	case SpansField:
+		return slog.Any(name, msg.Spans), len(msg.Spans) > 0 // nolint:staticcheck
+	case IncludeSpansField:
+		if msg.IncludeSpans == nil { // nolint:staticcheck
+			return slog.Attr{}, false
+		}
+		return slog.Bool(name, *msg.IncludeSpans), true // nolint:staticcheck
+	case PathField:
+		return str(msg.Path)
+	case PayloadField:
+		return slog.Int("payload_length", len(msg.Payload)), true
+	case ServiceNameField:
+		return str(msg.ServiceName)
+	case URLField:
+		return str(msg.URL)
+	case PartnerIDsField:
+		return strs(msg.PartnerIDs)
+	case SessionIDField:
+		return str(msg.SessionID)
+	case QualityOfServiceField:
+		return slog.Int(name, int(msg.QualityOfService)), true
+	}
+
+	return slog.Attr{}, false
+}
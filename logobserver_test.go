@@ -0,0 +1,249 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLogObserver(t *testing.T, buf *bytes.Buffer, opts ...LogObserverOption) *LogObserver {
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	o, err := NewLogObserver(logger, opts...)
+	require.NoError(t, err)
+	return o
+}
+
+func logEntries(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var entries []map[string]any
+	for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+
+		var e map[string]any
+		require.NoError(t, json.Unmarshal(line, &e))
+		delete(e, "time")
+		entries = append(entries, e)
+	}
+
+	return entries
+}
+
+func TestLogObserver(t *testing.T) {
+	msg := Message{
+		Type:            SimpleRequestResponseMessageType,
+		Source:          "dns:example.com",
+		Destination:     "mac:112233445566/config",
+		TransactionUUID: "1234",
+		Path:            "/config",
+		Payload:         []byte(`{"secret":"value"}`),
+		PartnerIDs:      []string{"comcast"},
+		Metadata: map[string]string{
+			DefaultTraceParentKey: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			"/boot-time":          "1",
+		},
+	}
+	msg.SetStatus(200)
+
+	redactor, err := NewRedactor(
+		RedactFields(PartnerIDsField),
+		RedactMetadata("/boot-*"),
+		RedactPayload(PayloadHash),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		desc     string
+		opts     []LogObserverOption
+		msg      Message
+		expected map[string]any
+	}{
+		{
+			desc: "defaults",
+			msg:  msg,
+			expected: map[string]any{
+				"level":            "INFO",
+				"msg":              DefaultLogMessage,
+				"trace_id":         "4bf92f3577b34da6a3ce929d0e0e4736",
+				"span_id":          "00f067aa0ba902b7",
+				"msg_type":         "SimpleRequestResponse",
+				"source":           "dns:example.com",
+				"dest":             "mac:112233445566/config",
+				"transaction_uuid": "1234",
+				"path":             "/config",
+				"status":           float64(200),
+				"payload_length":   float64(18),
+			},
+		}, {
+			desc: "configured",
+			opts: []LogObserverOption{
+				LogLevel(slog.LevelDebug),
+				LogMessage("relayed"),
+				LogFields(SourceField, MetadataField, PartnerIDsField, PayloadField, URLField),
+				LogTraceParentKey(""),
+				nil,
+			},
+			msg: msg,
+			expected: map[string]any{
+				"level":          "DEBUG",
+				"msg":            "relayed",
+				"source":         "dns:example.com",
+				"metadata":       map[string]any{DefaultTraceParentKey: DefaultRedactionMask, "/boot-time": DefaultRedactionMask},
+				"partner_ids":    []any{"comcast"},
+				"payload_length": float64(18),
+			},
+		}, {
+			desc: "unredacted",
+			opts: []LogObserverOption{
+				LogFields(MetadataField),
+				LogRedactor(nil),
+			},
+			msg: msg,
+			expected: map[string]any{
+				"level":    "INFO",
+				"msg":      DefaultLogMessage,
+				"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736",
+				"span_id":  "00f067aa0ba902b7",
+				"metadata": map[string]any{DefaultTraceParentKey: msg.Metadata[DefaultTraceParentKey], "/boot-time": "1"},
+			},
+		}, {
+			desc: "redacted",
+			opts: []LogObserverOption{
+				LogFields(SourceField, MetadataField, PartnerIDsField, PayloadField),
+				LogRedactor(redactor),
+			},
+			msg: msg,
+			expected: map[string]any{
+				"level":        "INFO",
+				"msg":          DefaultLogMessage,
+				"trace_id":     "4bf92f3577b34da6a3ce929d0e0e4736",
+				"span_id":      "00f067aa0ba902b7",
+				"source":       "dns:example.com",
+				"metadata":     map[string]any{DefaultTraceParentKey: msg.Metadata[DefaultTraceParentKey], "/boot-time": DefaultRedactionMask},
+				"partner_ids":  []any{DefaultRedactionMask},
+				"payload_hash": "sha256:370ba19fc3dd5a67418776bda6cdd77c2579d32e777d5ed0122856752fad4ff1",
+			},
+		}, {
+			desc: "b3",
+			opts: []LogObserverOption{LogFields(TypeField)},
+			msg: Message{
+				Type:     SimpleEventMessageType,
+				Metadata: map[string]string{"b3": "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-1"},
+			},
+			expected: map[string]any{
+				"level":    "INFO",
+				"msg":      DefaultLogMessage,
+				"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736",
+				"span_id":  "00f067aa0ba902b7",
+				"msg_type": "SimpleEvent",
+			},
+		}, {
+			desc: "invalid traceparent",
+			opts: []LogObserverOption{LogFields(TypeField)},
+			msg: Message{
+				Type:     SimpleEventMessageType,
+				Metadata: map[string]string{DefaultTraceParentKey: "00-00000000000000000000000000000000-00f067aa0ba902b7-01"},
+			},
+			expected: map[string]any{
+				"level":    "INFO",
+				"msg":      DefaultLogMessage,
+				"msg_type": "SimpleEvent",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var buf bytes.Buffer
+			newTestLogObserver(t, &buf, tc.opts...).ObserveWRP(context.Background(), tc.msg)
+
+			entries := logEntries(t, &buf)
+			require.Len(t, entries, 1)
+			assert.Equal(t, tc.expected, entries[0])
+		})
+	}
+}
+
+func TestLogObserver_disabled(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	o, err := NewLogObserver(logger)
+	require.NoError(t, err)
+
+	o.ObserveWRP(context.Background(), Message{})
+	assert.Zero(t, buf.Len())
+}
+
+func TestLogSampleRate(t *testing.T) {
+	var buf bytes.Buffer
+	o := newTestLogObserver(t, &buf, LogSampleRate(0.5))
+
+	// sampling by transaction is consistent
+	for i := 0; i < 100; i++ {
+		tid := uuid.NewSHA1(uuid.NameSpaceOID, []byte(strconv.Itoa(i))).String()
+		o.ObserveWRP(context.Background(), Message{TransactionUUID: tid})
+		o.ObserveWRP(context.Background(), Message{TransactionUUID: tid})
+	}
+
+	entries := logEntries(t, &buf)
+	assert.Greater(t, len(entries), 40)
+	assert.Less(t, len(entries), 160)
+	for i := 0; i < len(entries); i += 2 {
+		assert.Equal(t, entries[i]["transaction_uuid"], entries[i+1]["transaction_uuid"])
+	}
+
+	// messages without a transaction are sampled at random
+	buf.Reset()
+	o.random = func() float64 { return 0.75 }
+	o.ObserveWRP(context.Background(), Message{})
+	assert.Zero(t, buf.Len())
+
+	o.random = func() float64 { return 0.25 }
+	o.ObserveWRP(context.Background(), Message{})
+	assert.NotZero(t, buf.Len())
+
+	buf.Reset()
+	o = newTestLogObserver(t, &buf, LogSampleRate(0))
+	o.ObserveWRP(context.Background(), Message{TransactionUUID: "1234"})
+	assert.Zero(t, buf.Len())
+}
+
+func TestNewLogObserver_invalid(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(&bytes.Buffer{}, nil))
+
+	tests := []struct {
+		desc   string
+		logger *slog.Logger
+		opts   []LogObserverOption
+	}{
+		{
+			desc: "nil logger",
+		}, {
+			desc:   "sample rate",
+			logger: logger,
+			opts:   []LogObserverOption{LogSampleRate(1.5)},
+		}, {
+			desc:   "field",
+			logger: logger,
+			opts:   []LogObserverOption{LogFields(Field(-1))},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			o, err := NewLogObserver(tc.logger, tc.opts...)
+			assert.Nil(t, o)
+			assert.ErrorIs(t, err, ErrInvalidLogObserver)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+const (
+	// multicastBit is set in the first octet of a multicast (group) address.
+	multicastBit = 0x01
+
+	// localBit is set in the first octet of a locally administered address.
+	localBit = 0x02
+)
+
+var ErrBogusMAC = errors.New("bogus MAC address")
+
+// mac returns the bytes of the device identifier if it is a normalized mac
+// scheme identifier, as produced by ParseDeviceID.
+func (id DeviceID) mac() ([]byte, bool) {
+	prefix, idPart := id.split()
+	if prefix != SchemeMAC {
+		return nil, false
+	}
+
+	b, err := hex.DecodeString(idPart)
+	if err != nil || len(b) < 3 {
+		return nil, false
+	}
+
+	return b, true
+}
+
+// OUI returns the organizationally unique identifier of a mac device
+// identifier, i.e. the first 6 hex digits, which identify the manufacturer.
+// The OUI is meaningless for a locally administered address.  false is
+// returned if the identifier is not a normalized mac identifier.
+func (id DeviceID) OUI() (string, bool) {
+	if _, ok := id.mac(); !ok {
+		return "", false
+	}
+
+	return id.ID()[:6], true
+}
+
+// IsLocallyAdministered returns true if the identifier is a mac address that
+// was assigned locally rather than by the manufacturer, e.g. a randomized
+// address.
+func (id DeviceID) IsLocallyAdministered() bool {
+	b, ok := id.mac()
+	return ok && b[0]&localBit != 0
+}
+
+// IsMulticast returns true if the identifier is a mac address that addresses
+// a group rather than a single device.  The broadcast address is a multicast
+// address.
+func (id DeviceID) IsMulticast() bool {
+	b, ok := id.mac()
+	return ok && b[0]&multicastBit != 0
+}
+
+// ValidateMAC returns an error wrapping ErrBogusMAC if the identifier is a mac
+// address that cannot belong to a real device: the all-zero address or the
+// broadcast address.  Identifiers with other schemes are not checked.
+func ValidateMAC(id DeviceID) error {
+	b, ok := id.mac()
+	if !ok {
+		return nil
+	}
+
+	zero, broadcast := true, true
+	for _, v := range b {
+		zero = zero && v == 0x00
+		broadcast = broadcast && v == 0xff
+	}
+
+	switch {
+	case zero:
+		return fmt.Errorf("%w: `%s` is the all-zero address", ErrBogusMAC, id)
+	case broadcast:
+		return fmt.Errorf("%w: `%s` is the broadcast address", ErrBogusMAC, id)
+	}
+
+	return nil
+}
+
+// RejectBogusMACs ensures that neither the source nor the destination is a mac
+// locator with a bogus address, see ValidateMAC.  Locators that cannot be
+// parsed are left to ValidateSource and ValidateDestination.
+func RejectBogusMACs() NormifierOption {
+	return optionFunc(func(m *Message) error {
+		if l, err := ParseLocator(m.Source); err == nil {
+			if err := ValidateMAC(l.ID); err != nil {
+				return newError(CodeInvalidLocator, "Source", errors.Join(err, ErrInvalidSource))
+			}
+		}
+
+		if l, err := ParseLocator(m.Destination); err == nil {
+			if err := ValidateMAC(l.ID); err != nil {
+				return newError(CodeInvalidLocator, "Destination", errors.Join(err, ErrInvalidDest))
+			}
+		}
+
+		return nil
+	})
+}
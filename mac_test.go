@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceIDMAC(t *testing.T) {
+	tests := []struct {
+		desc      string
+		id        DeviceID
+		oui       string
+		local     bool
+		multicast bool
+		bogus     bool
+	}{
+		{
+			desc: "universal unicast",
+			id:   "mac:00a0c9112233",
+			oui:  "00a0c9",
+		}, {
+			desc:  "locally administered",
+			id:    "mac:02a0c9112233",
+			oui:   "02a0c9",
+			local: true,
+		}, {
+			desc:      "multicast",
+			id:        "mac:01005e000001",
+			oui:       "01005e",
+			multicast: true,
+		}, {
+			desc: "eui-64",
+			id:   "mac:00a0c9fffe112233",
+			oui:  "00a0c9",
+		}, {
+			desc:  "all zero",
+			id:    "mac:000000000000",
+			oui:   "000000",
+			bogus: true,
+		}, {
+			desc:      "broadcast",
+			id:        "mac:ffffffffffff",
+			oui:       "ffffff",
+			local:     true,
+			multicast: true,
+			bogus:     true,
+		}, {
+			desc: "not a mac",
+			id:   "uuid:ffffffffffff",
+		}, {
+			desc: "not normalized",
+			id:   "mac:00:a0:c9:11:22:33",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			oui, ok := tc.id.OUI()
+			assert.Equal(tc.oui != "", ok)
+			assert.Equal(tc.oui, oui)
+			assert.Equal(tc.local, tc.id.IsLocallyAdministered())
+			assert.Equal(tc.multicast, tc.id.IsMulticast())
+
+			if tc.bogus {
+				assert.ErrorIs(ValidateMAC(tc.id), ErrBogusMAC)
+			} else {
+				assert.NoError(ValidateMAC(tc.id))
+			}
+		})
+	}
+}
+
+func TestRejectBogusMACs(t *testing.T) {
+	tests := []struct {
+		desc        string
+		msg         Message
+		expectedErr error
+	}{
+		{
+			desc: "valid",
+			msg:  Message{Source: "mac:00a0c9112233", Destination: "dns:example.com"},
+		}, {
+			desc: "unparsable locators are ignored",
+			msg:  Message{Source: "invalid"},
+		}, {
+			desc:        "bogus source",
+			msg:         Message{Source: "mac:00-00-00-00-00-00"},
+			expectedErr: ErrInvalidSource,
+		}, {
+			desc:        "bogus destination",
+			msg:         Message{Source: "dns:example.com", Destination: "MAC:FF:FF:FF:FF:FF:FF/config"},
+			expectedErr: ErrInvalidDest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := NewNormifier(RejectBogusMACs()).Normify(&tc.msg)
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.ErrorIs(t, err, ErrBogusMAC)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"slices"
+	"strings"
+)
+
+const (
+	// MimeTypeWrpMsgpack is the WRP specific media type of msgpack encoded
+	// messages.
+	MimeTypeWrpMsgpack = "application/wrp+msgpack"
+
+	// MimeTypeWrpJSON is the WRP specific media type of JSON encoded messages.
+	MimeTypeWrpJSON = "application/wrp+json"
+
+	// MimeTypeXMsgpack is the unregistered media type some clients use for
+	// msgpack.
+	MimeTypeXMsgpack = "application/x-msgpack"
+
+	// MimeTypeTextPlain is the media type of plain text payloads, e.g. the
+	// note of a delivery receipt.
+	MimeTypeTextPlain = "text/plain"
+)
+
+var ErrUnknownMediaType = errors.New("unknown WRP media type")
+
+// mediaTypes are the media types of each format.  The first is the one
+// returned by Format.ContentType.
+var mediaTypes = map[Format][]string{
+	Msgpack: {MimeTypeMsgpack, MimeTypeWrpMsgpack, MimeTypeXMsgpack, MimeTypeWrp},
+	JSON:    {MimeTypeJson, MimeTypeWrpJSON},
+}
+
+// MediaTypes returns the media types that identify the format, starting with
+// the one returned by ContentType.  Nil is returned for an invalid format.
+func MediaTypes(f Format) []string {
+	return slices.Clone(mediaTypes[f])
+}
+
+// ParseMediaType returns the format of a single media type, such as the value
+// of a Content-Type header.  Parameters, e.g. charset, are ignored and the
+// comparison is case insensitive.  Unlike FormatFromContentType, only the
+// media types listed by MediaTypes are recognized.
+func ParseMediaType(v string) (Format, error) {
+	mt, _, err := mime.ParseMediaType(v)
+	if err != nil {
+		return Format(-1), fmt.Errorf("%w: `%s`: %w", ErrUnknownMediaType, v, err)
+	}
+
+	for f, list := range mediaTypes {
+		if slices.Contains(list, mt) {
+			return f, nil
+		}
+	}
+
+	return Format(-1), fmt.Errorf("%w: `%s`", ErrUnknownMediaType, v)
+}
+
+// ParseMediaTypes returns the format of the first recognized media type in a
+// comma separated list, such as the value of an Accept header.  Quality
+// values are not considered; the list is assumed to be in order of
+// preference.
+func ParseMediaTypes(v string) (Format, error) {
+	for _, part := range strings.Split(v, ",") {
+		if f, err := ParseMediaType(strings.TrimSpace(part)); err == nil {
+			return f, nil
+		}
+	}
+
+	return Format(-1), fmt.Errorf("%w: `%s`", ErrUnknownMediaType, v)
+}
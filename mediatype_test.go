@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMediaTypes(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, f := range AllFormats() {
+		list := MediaTypes(f)
+		if assert.NotEmpty(list) {
+			assert.Equal(f.ContentType(), list[0])
+		}
+
+		for _, mt := range list {
+			actual, err := ParseMediaType(mt)
+			assert.NoError(err)
+			assert.Equal(f, actual, mt)
+		}
+	}
+
+	assert.Nil(MediaTypes(Format(99)))
+
+	// The returned slice is a copy.
+	MediaTypes(Msgpack)[0] = "changed"
+	assert.Equal(MimeTypeMsgpack, MediaTypes(Msgpack)[0])
+}
+
+func TestParseMediaType(t *testing.T) {
+	tests := []struct {
+		desc    string
+		input   string
+		want    Format
+		wantErr bool
+	}{
+		{desc: "msgpack", input: "application/msgpack", want: Msgpack},
+		{desc: "wrp msgpack", input: "application/wrp+msgpack", want: Msgpack},
+		{desc: "x-msgpack", input: "application/x-msgpack", want: Msgpack},
+		{desc: "legacy wrp", input: "application/wrp", want: Msgpack},
+		{desc: "json", input: "application/json", want: JSON},
+		{desc: "wrp json", input: "application/wrp+json", want: JSON},
+		{desc: "parameters", input: "application/json; charset=utf-8", want: JSON},
+		{desc: "case", input: "Application/WRP+MsgPack", want: Msgpack},
+		{desc: "empty", input: "", wantErr: true},
+		{desc: "malformed", input: "application/json;;", wantErr: true},
+		{desc: "octet stream", input: "application/octet-stream", wantErr: true},
+		{desc: "substring", input: "text/json", wantErr: true},
+		{desc: "list", input: "application/json, application/msgpack", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := ParseMediaType(tc.input)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrUnknownMediaType)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParseMediaTypes(t *testing.T) {
+	tests := []struct {
+		desc    string
+		input   string
+		want    Format
+		wantErr bool
+	}{
+		{desc: "single", input: "application/wrp+json", want: JSON},
+		{desc: "first wins", input: "application/msgpack, application/json", want: Msgpack},
+		{desc: "unknown skipped", input: "text/html, */*;q=0.8, application/json;q=0.5", want: JSON},
+		{desc: "none", input: "text/html, */*", wantErr: true},
+		{desc: "empty", input: "", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := ParseMediaTypes(tc.input)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrUnknownMediaType)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
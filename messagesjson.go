@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import "encoding/json"
+
+// The message type specific structs marshal to and from the same JSON as a
+// Message of their type, using the wire field names including msg_type, so
+// they can be used directly in HTTP APIs.  When marshaling, msg_type is set
+// from the struct's type.  When unmarshaling, a missing msg_type is taken to
+// be the struct's type, and any other msg_type is rejected with an *Error
+// wrapping ErrMessageTypeMismatch.  CRUD has no single message type, so its
+// msg_type must be one of the CRUD message types in both directions.
+//
+// MarshalJSON has a value receiver, so values are marshaled the same way as
+// pointers, including values held in maps, slices and struct fields.
+
+// MarshalJSON encodes the message with a msg_type of SimpleRequestResponse.
+func (msg SimpleRequestResponse) MarshalJSON() ([]byte, error) {
+	type simpleRequestResponse SimpleRequestResponse
+	v := simpleRequestResponse(msg)
+	v.Type = SimpleRequestResponseMessageType
+	return json.Marshal(&v)
+}
+
+// UnmarshalJSON decodes a message with a msg_type of SimpleRequestResponse.
+func (msg *SimpleRequestResponse) UnmarshalJSON(b []byte) error {
+	type simpleRequestResponse SimpleRequestResponse
+	v := simpleRequestResponse{Type: SimpleRequestResponseMessageType}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if err := CheckUnionType(v.Type, SimpleRequestResponseMessageType); err != nil {
+		return err
+	}
+
+	*msg = SimpleRequestResponse(v)
+	return nil
+}
+
+// MarshalJSON encodes the message with a msg_type of SimpleEvent.
+func (msg SimpleEvent) MarshalJSON() ([]byte, error) {
+	type simpleEvent SimpleEvent
+	v := simpleEvent(msg)
+	v.Type = SimpleEventMessageType
+	return json.Marshal(&v)
+}
+
+// UnmarshalJSON decodes a message with a msg_type of SimpleEvent.
+func (msg *SimpleEvent) UnmarshalJSON(b []byte) error {
+	type simpleEvent SimpleEvent
+	v := simpleEvent{Type: SimpleEventMessageType}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if err := CheckUnionType(v.Type, SimpleEventMessageType); err != nil {
+		return err
+	}
+
+	*msg = SimpleEvent(v)
+	return nil
+}
+
+// MarshalJSON encodes the message, whose Type must be one of the CRUD message
+// types.
+func (msg CRUD) MarshalJSON() ([]byte, error) {
+	if err := checkCRUDType(msg.Type); err != nil {
+		return nil, err
+	}
+
+	type crud CRUD
+	return json.Marshal(crud(msg))
+}
+
+// UnmarshalJSON decodes a message with a msg_type of one of the CRUD message
+// types.
+func (msg *CRUD) UnmarshalJSON(b []byte) error {
+	type crud CRUD
+	var v crud
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if err := checkCRUDType(v.Type); err != nil {
+		return err
+	}
+
+	*msg = CRUD(v)
+	return nil
+}
+
+// MarshalJSON encodes the message with a msg_type of ServiceRegistration.
+func (msg ServiceRegistration) MarshalJSON() ([]byte, error) {
+	type serviceRegistration ServiceRegistration
+	v := serviceRegistration(msg)
+	v.Type = ServiceRegistrationMessageType
+	return json.Marshal(&v)
+}
+
+// UnmarshalJSON decodes a message with a msg_type of ServiceRegistration.
+func (msg *ServiceRegistration) UnmarshalJSON(b []byte) error {
+	type serviceRegistration ServiceRegistration
+	v := serviceRegistration{Type: ServiceRegistrationMessageType}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if err := CheckUnionType(v.Type, ServiceRegistrationMessageType); err != nil {
+		return err
+	}
+
+	*msg = ServiceRegistration(v)
+	return nil
+}
+
+// MarshalJSON encodes the message with a msg_type of ServiceAlive.
+func (msg ServiceAlive) MarshalJSON() ([]byte, error) {
+	type serviceAlive ServiceAlive
+	v := serviceAlive(msg)
+	v.Type = ServiceAliveMessageType
+	return json.Marshal(&v)
+}
+
+// UnmarshalJSON decodes a message with a msg_type of ServiceAlive.
+func (msg *ServiceAlive) UnmarshalJSON(b []byte) error {
+	type serviceAlive ServiceAlive
+	v := serviceAlive{Type: ServiceAliveMessageType}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if err := CheckUnionType(v.Type, ServiceAliveMessageType); err != nil {
+		return err
+	}
+
+	*msg = ServiceAlive(v)
+	return nil
+}
+
+// MarshalUnionJSON encodes a message view as the JSON of the Message it is a
+// view of, using the wire field names.
+func MarshalUnionJSON(u Union) ([]byte, error) {
+	var msg Message
+	if err := u.ToMessage(&msg); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&msg)
+}
+
+// UnmarshalUnionJSON decodes the JSON of a Message into a message view, which
+// checks the message's type.
+func UnmarshalUnionJSON(b []byte, u Union) error {
+	var msg Message
+	if err := json.Unmarshal(b, &msg); err != nil {
+		return err
+	}
+
+	return u.FromMessage(&msg)
+}
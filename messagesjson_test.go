@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecificMessages_JSON(t *testing.T) {
+	status := int64(200)
+
+	tests := []struct {
+		desc     string
+		specific interface{}
+		expected Message
+	}{
+		{
+			desc: "SimpleRequestResponse",
+			specific: &SimpleRequestResponse{
+				Source:          "dns:talaria.example.com",
+				Destination:     "mac:112233445566/config",
+				TransactionUUID: "123e4567-e89b-12d3-a456-426614174000",
+				Status:          &status,
+				Payload:         []byte("payload"),
+			},
+			expected: Message{
+				Type:            SimpleRequestResponseMessageType,
+				Source:          "dns:talaria.example.com",
+				Destination:     "mac:112233445566/config",
+				TransactionUUID: "123e4567-e89b-12d3-a456-426614174000",
+				Status:          &status,
+				Payload:         []byte("payload"),
+			},
+		}, {
+			desc: "SimpleEvent",
+			specific: &SimpleEvent{
+				Source:      "mac:112233445566",
+				Destination: "event:device-status",
+				PartnerIDs:  []string{"comcast"},
+			},
+			expected: Message{
+				Type:        SimpleEventMessageType,
+				Source:      "mac:112233445566",
+				Destination: "event:device-status",
+				PartnerIDs:  []string{"comcast"},
+			},
+		}, {
+			desc: "CRUD",
+			specific: &CRUD{
+				Type:        UpdateMessageType,
+				Source:      "dns:talaria.example.com",
+				Destination: "mac:112233445566",
+				Path:        "/config",
+			},
+			expected: Message{
+				Type:        UpdateMessageType,
+				Source:      "dns:talaria.example.com",
+				Destination: "mac:112233445566",
+				Path:        "/config",
+			},
+		}, {
+			desc: "ServiceRegistration",
+			specific: &ServiceRegistration{
+				ServiceName: "config",
+				URL:         "tcp://127.0.0.1:6666",
+			},
+			expected: Message{
+				Type:        ServiceRegistrationMessageType,
+				ServiceName: "config",
+				URL:         "tcp://127.0.0.1:6666",
+			},
+		}, {
+			desc:     "ServiceAlive",
+			specific: &ServiceAlive{},
+			expected: Message{Type: ServiceAliveMessageType},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			b, err := json.Marshal(tc.specific)
+			require.NoError(err)
+
+			// the JSON is that of the equivalent Message
+			var msg Message
+			require.NoError(NewDecoderBytes(b, JSON).Decode(&msg))
+			assert.Equal(tc.expected, msg)
+
+			// values, including those held by containers, marshal the same
+			value := reflect.ValueOf(tc.specific).Elem().Interface()
+			fromValue, err := json.Marshal(value)
+			require.NoError(err)
+			assert.JSONEq(string(b), string(fromValue))
+
+			fromSlice, err := json.Marshal([]interface{}{value})
+			require.NoError(err)
+			assert.JSONEq("["+string(b)+"]", string(fromSlice))
+
+			fromMap, err := json.Marshal(map[string]interface{}{"msg": value})
+			require.NoError(err)
+			assert.JSONEq(`{"msg":`+string(b)+"}", string(fromMap))
+
+			var encoded []byte
+			require.NoError(NewEncoderBytes(&encoded, JSON).Encode(&tc.expected))
+
+			decoded := reflect.New(reflect.TypeOf(tc.specific).Elem()).Interface()
+			require.NoError(json.Unmarshal(encoded, decoded))
+
+			b, err = json.Marshal(decoded)
+			require.NoError(err)
+			msg = Message{}
+			require.NoError(NewDecoderBytes(b, JSON).Decode(&msg))
+			assert.Equal(tc.expected, msg)
+		})
+	}
+}
+
+func TestSpecificMessages_UnmarshalJSON(t *testing.T) {
+	var se SimpleEvent
+	require.NoError(t, json.Unmarshal([]byte(`{"source": "mac:112233445566", "dest": "event:device-status"}`), &se))
+	assert.Equal(t, SimpleEvent{
+		Type:        SimpleEventMessageType,
+		Source:      "mac:112233445566",
+		Destination: "event:device-status",
+	}, se)
+
+	tests := []struct {
+		desc     string
+		input    string
+		specific interface{}
+	}{
+		{
+			desc:     "SimpleRequestResponse",
+			input:    `{"msg_type": 4}`,
+			specific: &SimpleRequestResponse{},
+		}, {
+			desc:     "SimpleEvent",
+			input:    `{"msg_type": 3}`,
+			specific: &SimpleEvent{},
+		}, {
+			desc:     "CRUD",
+			input:    `{"msg_type": 4}`,
+			specific: &CRUD{},
+		}, {
+			desc:     "CRUD without a type",
+			input:    `{"path": "/config"}`,
+			specific: &CRUD{},
+		}, {
+			desc:     "ServiceRegistration",
+			input:    `{"msg_type": 10}`,
+			specific: &ServiceRegistration{},
+		}, {
+			desc:     "ServiceAlive",
+			input:    `{"msg_type": 9}`,
+			specific: &ServiceAlive{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := json.Unmarshal([]byte(tc.input), tc.specific)
+			assert.ErrorIs(t, err, ErrMessageTypeMismatch)
+		})
+	}
+
+	assert.Error(t, json.Unmarshal([]byte(`{"source": 1}`), &se))
+}
+
+func TestCRUD_MarshalJSON(t *testing.T) {
+	_, err := json.Marshal(&CRUD{Type: SimpleEventMessageType})
+	assert.ErrorIs(t, err, ErrMessageTypeMismatch)
+}
+
+func TestSimpleEvent_MarshalJSONValue(t *testing.T) {
+	type body struct {
+		Event  SimpleEvent   `json:"event"`
+		Events []SimpleEvent `json:"events"`
+	}
+
+	b, err := json.Marshal(body{
+		Event:  SimpleEvent{Source: "mac:112233445566"},
+		Events: []SimpleEvent{{Source: "mac:112233445566"}},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"event":  {"msg_type": 4, "source": "mac:112233445566", "dest": ""},
+		"events": [{"msg_type": 4, "source": "mac:112233445566", "dest": ""}]
+	}`, string(b))
+}
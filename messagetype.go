@@ -4,6 +4,7 @@
 package wrp
 
 import (
+	"slices"
 	"strconv"
 )
 
@@ -41,6 +42,20 @@ func (mt MessageType) RequiresTransaction() bool {
 	}
 }
 
+// crudMessageTypes are the message types of CRUD messages.
+var crudMessageTypes = []MessageType{
+	CreateMessageType,
+	RetrieveMessageType,
+	UpdateMessageType,
+	DeleteMessageType,
+}
+
+// IsCRUD tests if mt is one of the CRUD message types: Create, Retrieve,
+// Update or Delete.
+func IsCRUD(mt MessageType) bool {
+	return slices.Contains(crudMessageTypes, mt)
+}
+
 // SupportsQOSAck tests if messages of this type are allowed to participate in QOS Ack
 // as specified in https://xmidt.io/docs/wrp/basics/#qos-description-qos .
 // If this method returns false, QOS Ack is foregone.
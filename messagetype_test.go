@@ -95,6 +95,32 @@ func TestMessageTypeSupportsQOSAck(t *testing.T) {
 	}
 }
 
+func TestIsCRUD(t *testing.T) {
+	var (
+		assert       = assert.New(t)
+		expectedCRUD = map[MessageType]bool{
+			Invalid0MessageType:              false,
+			Invalid1MessageType:              false,
+			AuthorizationMessageType:         false,
+			SimpleRequestResponseMessageType: false,
+			SimpleEventMessageType:           false,
+			CreateMessageType:                true,
+			RetrieveMessageType:              true,
+			UpdateMessageType:                true,
+			DeleteMessageType:                true,
+			ServiceRegistrationMessageType:   false,
+			ServiceAliveMessageType:          false,
+			UnknownMessageType:               false,
+			LastMessageType:                  false,
+			LastMessageType + 1:              false,
+		}
+	)
+
+	for messageType, expected := range expectedCRUD {
+		assert.Equal(expected, IsCRUD(messageType))
+	}
+}
+
 func testStringToMessageTypeValid(t *testing.T, expected MessageType) {
 	var (
 		assert         = assert.New(t)
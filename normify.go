@@ -5,10 +5,9 @@ package wrp
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
 	"time"
-
-	"github.com/google/uuid"
 )
 
 var (
@@ -147,14 +146,25 @@ func ReplaceDestinationSelfLocator(me string) NormifierOption {
 // the message does not have a transaction UUID, a new one is generated and
 // added to the message.
 func EnsureTransactionUUID() NormifierOption {
+	return EnsureTransactionUUIDFrom(UUIDGenerator)
+}
+
+// EnsureTransactionUUIDFrom is like EnsureTransactionUUID, but the transaction
+// UUID is generated by the given IDGenerator.  If gen is nil, UUIDGenerator is
+// used.
+func EnsureTransactionUUIDFrom(gen IDGenerator) NormifierOption {
+	if gen == nil {
+		gen = UUIDGenerator
+	}
+
 	return optionFunc(func(m *Message) error {
 		if m.TransactionUUID == "" {
-			id, err := uuid.NewRandom()
+			id, err := gen.NewID()
 			if err != nil {
 				return err
 			}
 
-			m.TransactionUUID = id.String()
+			m.TransactionUUID = id
 		}
 		return nil
 	})
@@ -235,21 +245,23 @@ func EnsureMetadataInt64(key string, i int64) NormifierOption {
 
 // -- Validators ---------------------------------------------------------------
 
-// ValidateSource ensures that the source locator is valid.
+// ValidateSource ensures that the source locator is valid.  Locators of
+// registered schemes are also checked by the SchemeValidator of the scheme.
 func ValidateSource() NormifierOption {
 	return optionFunc(func(m *Message) error {
-		if _, err := ParseLocator(m.Source); err != nil {
-			return errors.Join(err, ErrInvalidSource)
+		if _, err := ValidateLocator(m.Source); err != nil {
+			return newError(CodeInvalidLocator, "Source", errors.Join(err, ErrInvalidSource))
 		}
 		return nil
 	})
 }
 
-// ValidateDestination ensures that the destination locator is valid.
+// ValidateDestination ensures that the destination locator is valid.  Locators
+// of registered schemes are also checked by the SchemeValidator of the scheme.
 func ValidateDestination() NormifierOption {
 	return optionFunc(func(m *Message) error {
-		if _, err := ParseLocator(m.Destination); err != nil {
-			return errors.Join(err, ErrInvalidDest)
+		if _, err := ValidateLocator(m.Destination); err != nil {
+			return newError(CodeInvalidLocator, "Destination", errors.Join(err, ErrInvalidDest))
 		}
 		return nil
 	})
@@ -259,7 +271,7 @@ func ValidateDestination() NormifierOption {
 func ValidateMessageType() NormifierOption {
 	return optionFunc(func(m *Message) error {
 		if m.Type <= Invalid1MessageType || m.Type >= LastMessageType {
-			return ErrInvalidMessageType
+			return newError(CodeInvalidMessageType, "Type", ErrInvalidMessageType)
 		}
 		return nil
 	})
@@ -276,12 +288,24 @@ func ValidateOnlyUTF8Strings() NormifierOption {
 	})
 }
 
+// ValidatePayloadSize ensures that the payload is no larger than max bytes.
+func ValidatePayloadSize(max int) NormifierOption {
+	return optionFunc(func(m *Message) error {
+		if len(m.Payload) > max {
+			return newError(CodePayloadTooLarge, "Payload",
+				fmt.Errorf("%w: %d bytes is more than %d", ErrPayloadTooLarge, len(m.Payload), max))
+		}
+		return nil
+	})
+}
+
 // ValidateIsPartner ensures that the message has the given partner ID.
 func ValidateIsPartner(partner string) NormifierOption {
 	return optionFunc(func(m *Message) error {
 		list := m.TrimmedPartnerIDs()
 		if len(list) != 1 || list[0] != partner {
-			return ErrInvalidPartnerID
+			return newError(CodeInvalidPartner, "PartnerIDs",
+				fmt.Errorf("%w: %q is not the only partner ID", ErrInvalidPartnerID, partner))
 		}
 
 		return nil
@@ -307,6 +331,7 @@ func ValidateHasPartner(partners ...string) NormifierOption {
 				}
 			}
 		}
-		return ErrInvalidPartnerID
+		return newError(CodeInvalidPartner, "PartnerIDs",
+			fmt.Errorf("%w: none of %q", ErrInvalidPartnerID, trimmed))
 	})
 }
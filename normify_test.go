@@ -49,6 +49,26 @@ func TestNormifier(t *testing.T) {
 			want: Message{
 				TransactionUUID: "123e4567-e89b-12d3-a456-426614174000",
 			},
+		}, {
+			description: "EnsureTransactionUUIDFrom(gen), new UUID",
+			opt: EnsureTransactionUUIDFrom(IDGeneratorFunc(func() (string, error) {
+				return "123e4567-e89b-12d3-a456-426614174000", nil
+			})),
+			want: Message{
+				TransactionUUID: "123e4567-e89b-12d3-a456-426614174000",
+			},
+		}, {
+			description: "EnsureTransactionUUIDFrom(gen), generator error",
+			opt: EnsureTransactionUUIDFrom(IDGeneratorFunc(func() (string, error) {
+				return "", errUnknown
+			})),
+			expectedErr: errUnknown,
+		}, {
+			description: "EnsureTransactionUUIDFrom(nil), new UUID",
+			opt:         EnsureTransactionUUIDFrom(nil),
+			wantFn: func(assert *assert.Assertions, m *Message) {
+				assert.NotEmpty(m.TransactionUUID)
+			},
 		}, {
 			description: "EnsurePartnerID(partner) appending it to empty list",
 			opt:         EnsurePartnerID("partner"),
@@ -143,6 +163,15 @@ func TestNormifier(t *testing.T) {
 					"key": "99",
 				},
 			},
+		}, {
+			description: "ValidatePayloadSize(3)",
+			opt:         ValidatePayloadSize(3),
+			msg: Message{
+				Payload: []byte("abc"),
+			},
+			want: Message{
+				Payload: []byte("abc"),
+			},
 		}, {
 			description: "ValidateSource()",
 			opt:         ValidateSource(),
@@ -235,6 +264,13 @@ func TestNormifier(t *testing.T) {
 				Source: "invalid:/place/ignored",
 			},
 			expectedErr: ErrorInvalidLocator,
+		}, {
+			description: "ValidatePayloadSize(3) too large",
+			opt:         ValidatePayloadSize(3),
+			msg: Message{
+				Payload: []byte("abcd"),
+			},
+			expectedErr: ErrPayloadTooLarge,
 		}, {
 			description: "ValidateSource() empty",
 			opt:         ValidateSource(),
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizePartnerID returns the canonical form of a partner ID: surrounding
+// white space is removed, the ID is case folded and it is normalized to NFKC.
+// Partner IDs that differ only by case, or by Unicode compatibility forms such
+// as full width letters, have the same canonical form.
+func NormalizePartnerID(id string) string {
+	// compatibility forms such as ℭ only fold once normalized, and folding may
+	// produce denormalized text, so normalize both before and after folding
+	id = norm.NFKC.String(strings.TrimSpace(id))
+	return norm.NFKC.String(cases.Fold().String(id))
+}
+
+// NormalizeSessionID returns the canonical form of a session ID: surrounding
+// white space is removed and it is normalized to NFKC.  Session IDs are
+// opaque, so unlike partner IDs they are not case folded.
+func NormalizeSessionID(id string) string {
+	return norm.NFKC.String(strings.TrimSpace(id))
+}
+
+// EqualPartnerID reports whether two partner IDs have the same canonical
+// form, see NormalizePartnerID.  The comparison takes the same time wherever
+// the IDs differ, and whatever their lengths, so it does not leak how much of
+// an expected ID a caller guessed.  Empty IDs are never equal.
+func EqualPartnerID(a, b string) bool {
+	return equalNormalized(NormalizePartnerID(a), NormalizePartnerID(b))
+}
+
+// EqualSessionID reports whether two session IDs have the same canonical form,
+// see NormalizeSessionID.  Like EqualPartnerID, the comparison is constant
+// time and empty IDs are never equal.
+func EqualSessionID(a, b string) bool {
+	return equalNormalized(NormalizeSessionID(a), NormalizeSessionID(b))
+}
+
+// ContainsPartnerID reports whether any of the partner IDs is equal to id as
+// determined by EqualPartnerID.  Every partner ID is compared, so the time
+// taken does not reveal the position of a match.
+func ContainsPartnerID(ids []string, id string) bool {
+	want := NormalizePartnerID(id)
+
+	var found int
+	for _, candidate := range ids {
+		if equalNormalized(NormalizePartnerID(candidate), want) {
+			found |= 1
+		}
+	}
+
+	return found == 1
+}
+
+// HasPartnerID reports whether the message's PartnerIDs contain id, see
+// ContainsPartnerID.
+func (msg *Message) HasPartnerID(id string) bool {
+	return ContainsPartnerID(msg.PartnerIDs, id)
+}
+
+// equalNormalized compares the digests of the normalized values, which have
+// a fixed length, since subtle.ConstantTimeCompare returns early when the
+// lengths differ.
+func equalNormalized(a, b string) bool {
+	ha := sha256.Sum256([]byte(a))
+	hb := sha256.Sum256([]byte(b))
+
+	equal := subtle.ConstantTimeCompare(ha[:], hb[:]) == 1
+	return equal && a != "" && b != ""
+}
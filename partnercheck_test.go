@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqualPartnerID(t *testing.T) {
+	tests := []struct {
+		desc  string
+		a, b  string
+		equal bool
+	}{
+		{desc: "identical", a: "comcast", b: "comcast", equal: true},
+		{desc: "case", a: "Comcast", b: "COMCAST", equal: true},
+		{desc: "full width", a: "ｃomcast", b: "comcast", equal: true},
+		{desc: "decomposed", a: "cafe\u0301", b: "caf\u00e9", equal: true},
+		{desc: "black letter", a: "ℭomcast", b: "comcast", equal: true},
+		{desc: "black letter upper case", a: "ℌBO", b: "hbo", equal: true},
+		{desc: "sharp s", a: "straße", b: "STRASSE", equal: true},
+		{desc: "white space", a: " comcast\t", b: "comcast", equal: true},
+		{desc: "prefix", a: "comcast", b: "comcast-x"},
+		{desc: "different", a: "comcast", b: "sky"},
+		{desc: "empty", a: "", b: ""},
+		{desc: "one empty", a: "", b: "comcast"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.equal, EqualPartnerID(tc.a, tc.b))
+			assert.Equal(t, tc.equal, EqualPartnerID(tc.b, tc.a))
+		})
+	}
+}
+
+func TestEqualSessionID(t *testing.T) {
+	tests := []struct {
+		desc  string
+		a, b  string
+		equal bool
+	}{
+		{desc: "identical", a: "abc123", b: "abc123", equal: true},
+		{desc: "full width", a: "ａbc123", b: "abc123", equal: true},
+		{desc: "white space", a: "abc123 ", b: "abc123", equal: true},
+		{desc: "case", a: "ABC123", b: "abc123"},
+		{desc: "different", a: "abc123", b: "abc124"},
+		{desc: "empty", a: "", b: " "},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.equal, EqualSessionID(tc.a, tc.b))
+		})
+	}
+}
+
+func TestContainsPartnerID(t *testing.T) {
+	msg := Message{PartnerIDs: []string{"", "sky", "Comcast"}}
+
+	assert.True(t, msg.HasPartnerID("comcast"))
+	assert.True(t, msg.HasPartnerID("SKY"))
+	assert.False(t, msg.HasPartnerID("comcast-x"))
+	assert.False(t, msg.HasPartnerID(""))
+	assert.False(t, ContainsPartnerID(nil, "comcast"))
+}
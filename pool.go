@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import "sync"
+
+// Reset zeroes every field of the message, but keeps the storage of its
+// slices and maps so that the next decode into the message can reuse it.
+// Headers, Spans, Payload and PartnerIDs are truncated to zero length, and
+// Metadata is emptied.  The pointer fields are set to nil rather than reused,
+// since the values they point to may be shared.
+//
+// After Reset, the retained slices and maps are empty but not nil.  Encoding
+// treats them as absent, as it does nil values.
+//
+// Reset must only be called once nothing else refers to the message's slices
+// or maps, e.g. a payload handed off to another goroutine, since their
+// storage will be overwritten.
+func (msg *Message) Reset() {
+	headers := msg.Headers[:0]
+	metadata := msg.Metadata
+	spans := msg.Spans
+	payload := msg.Payload[:0]
+	partnerIDs := msg.PartnerIDs[:0]
+
+	clear(metadata)
+
+	// drop the references to the inner slices, so they can be collected
+	clear(spans)
+	spans = spans[:0]
+
+	*msg = Message{
+		Headers:    headers,
+		Metadata:   metadata,
+		Spans:      spans,
+		Payload:    payload,
+		PartnerIDs: partnerIDs,
+	}
+}
+
+// MessagePoolOption is a functional option for a MessagePool.
+type MessagePoolOption interface {
+	apply(*MessagePool)
+}
+
+type messagePoolOptionFunc func(*MessagePool)
+
+func (f messagePoolOptionFunc) apply(p *MessagePool) {
+	f(p)
+}
+
+// MaxPooledMessagePayload sets the capacity of the largest payload buffer a
+// pooled message retains.  Larger buffers are left to the garbage collector so
+// that an occasional huge message doesn't pin memory.  Values less than 1
+// prevent pooled messages from retaining payload buffers.  The default is
+// DefaultMaxPooledPayload.
+func MaxPooledMessagePayload(size int) MessagePoolOption {
+	return messagePoolOptionFunc(func(p *MessagePool) {
+		p.maxPayload = size
+	})
+}
+
+// MessagePool is a pool of Messages, along with the storage of their slices
+// and maps, for reuse across decodes in relays and other hot loops.
+//
+// A MessagePool is safe for concurrent use.
+type MessagePool struct {
+	pool       sync.Pool
+	maxPayload int
+}
+
+// NewMessagePool creates a MessagePool.
+func NewMessagePool(opts ...MessagePoolOption) *MessagePool {
+	p := MessagePool{
+		maxPayload: DefaultMaxPooledPayload,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt.apply(&p)
+		}
+	}
+
+	p.pool.New = func() any {
+		return new(Message)
+	}
+
+	return &p
+}
+
+// Get returns an empty message from the pool, or a new one if the pool is
+// empty.
+func (p *MessagePool) Get() *Message {
+	return p.pool.Get().(*Message)
+}
+
+// Put resets the message and returns it to the pool.  Neither the message nor
+// any of its slices or maps may be used after calling Put.
+func (p *MessagePool) Put(msg *Message) {
+	if msg == nil {
+		return
+	}
+
+	if cap(msg.Payload) > p.maxPayload {
+		msg.Payload = nil
+	}
+
+	msg.Reset()
+	p.pool.Put(msg)
+}
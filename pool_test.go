@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPoolMessage() Message {
+	return Message{
+		Type:                    SimpleRequestResponseMessageType,
+		Source:                  "dns:example.com",
+		Destination:             "mac:112233445566/config",
+		TransactionUUID:         "1234",
+		ContentType:             "application/json",
+		Accept:                  "application/json",
+		Status:                  int64Ptr(200),
+		RequestDeliveryResponse: int64Ptr(0),
+		Headers:                 []string{"X-A: 1", "X-B: 2"},
+		Metadata:                map[string]string{"/a": "1", "/b": "2"},
+		Spans:                   [][]string{{"a", "1", "2"}},
+		IncludeSpans:            ptrBool(true),
+		Path:                    "/path",
+		Payload:                 []byte(`{"hello":"world"}`),
+		ServiceName:             "config",
+		URL:                     "http://example.com",
+		PartnerIDs:              []string{"comcast"},
+		SessionID:               "session",
+		QualityOfService:        75,
+	}
+}
+
+func ptrBool(v bool) *bool {
+	return &v
+}
+
+func TestMessage_Reset(t *testing.T) {
+	assert := assert.New(t)
+	msg := testPoolMessage()
+	msg.Reset()
+
+	// every field must be empty, whatever fields are added in the future
+	v := reflect.ValueOf(msg)
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		switch f.Kind() {
+		case reflect.Slice, reflect.Map:
+			assert.Zero(f.Len(), v.Type().Field(i).Name)
+		default:
+			assert.True(f.IsZero(), v.Type().Field(i).Name)
+		}
+	}
+
+	assert.NotNil(msg.Metadata)
+	assert.NotZero(cap(msg.Payload))
+	assert.NotZero(cap(msg.Headers))
+	assert.NotZero(cap(msg.PartnerIDs))
+	assert.NotZero(cap(msg.Spans))
+
+	// nil slices and maps stay nil
+	var empty Message
+	empty.Reset()
+	assert.Equal(Message{}, empty)
+}
+
+func TestMessage_ResetReuse(t *testing.T) {
+	for _, f := range AllFormats() {
+		t.Run(f.String(), func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			var msg Message
+			original := testPoolMessage()
+			encoded := MustEncode(&original, f)
+			require.NoError(NewDecoderBytes(encoded, f).Decode(&msg))
+
+			payload := &msg.Payload[:1][0]
+			metadata := reflect.ValueOf(msg.Metadata).Pointer()
+
+			msg.Reset()
+
+			next := testPoolMessage()
+			next.Payload = []byte(`{"hello":"there"}`)
+			next.Metadata = map[string]string{"/c": "3"}
+			encoded = MustEncode(&next, f)
+			require.NoError(NewDecoderBytes(encoded, f).Decode(&msg))
+
+			assert.Equal(next, msg)
+			assert.Same(payload, &msg.Payload[:1][0])
+			assert.Equal(metadata, reflect.ValueOf(msg.Metadata).Pointer())
+
+			// a message without the fields decodes to empty values
+			msg.Reset()
+			minimal := Message{Type: SimpleEventMessageType, Source: "dns:example.com", Destination: "event:a"}
+			require.NoError(NewDecoderBytes(MustEncode(&minimal, f), f).Decode(&msg))
+			assert.Equal(MustEncode(&minimal, f), MustEncode(&msg, f))
+			assert.Empty(msg.Payload)
+			assert.Empty(msg.Metadata)
+		})
+	}
+}
+
+func TestMessagePool(t *testing.T) {
+	assert := assert.New(t)
+	p := NewMessagePool(nil, MaxPooledMessagePayload(8))
+
+	msg := p.Get()
+	require.NotNil(t, msg)
+	assert.Equal(Message{}, *msg)
+
+	*msg = testPoolMessage()
+	p.Put(msg)
+	p.Put(nil)
+
+	// the payload was larger than the maximum, so it was dropped
+	assert.Nil(msg.Payload)
+	assert.Empty(msg.Source)
+	assert.Empty(msg.Metadata)
+
+	msg = p.Get()
+	require.NotNil(t, msg)
+	assert.Empty(msg.Source)
+}
+
+func BenchmarkMessagePool(b *testing.B) {
+	original := testPoolMessage()
+	encoded := MustEncode(&original, Msgpack)
+	p := NewMessagePool()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		msg := p.Get()
+		if err := NewDecoderBytes(encoded, Msgpack).Decode(msg); err != nil {
+			b.Fatal(err)
+		}
+		p.Put(msg)
+	}
+}
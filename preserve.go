@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"encoding/binary"
+	"sort"
+
+	"github.com/ugorji/go/codec"
+)
+
+// PreservingMessage is a Message along with the fields of its encoding that
+// this version of the package does not know, e.g. fields added to WRP by
+// newer producers.  A relay that decodes messages with DecodePreserving and
+// encodes them with an Encoder from this package passes the unknown fields
+// along instead of silently dropping them.
+//
+// The unknown fields are encoded after the known ones, sorted by name.  An
+// unknown field with the same name as a known field is not encoded.
+type PreservingMessage struct {
+	Message
+
+	// Unknown holds the values of the unknown fields by their name on the
+	// wire.  The values are the generic values decoded from the format, e.g.
+	// string, []byte, int64, uint64, float64, bool, []interface{} or
+	// map[interface{}]interface{}, so they can be encoded in either format.
+	Unknown map[string]interface{}
+}
+
+// DecodePreserving decodes the input into msg, keeping the unknown fields in
+// msg.Unknown.  Unknown is nil if there are no unknown fields.
+//
+// The input is decoded twice, so DecodePreserving is slower than decoding a
+// Message.  Passing a *PreservingMessage to a Decoder decodes only the
+// Message.
+func DecodePreserving(input []byte, f Format, msg *PreservingMessage) error {
+	*msg = PreservingMessage{}
+	if err := NewDecoderBytes(input, f).Decode(&msg.Message); err != nil {
+		return err
+	}
+
+	var fields map[string]interface{}
+	if err := NewDecoderBytes(input, f).Decode(&fields); err != nil {
+		return err
+	}
+
+	for _, wf := range messageWireSchema().Fields {
+		delete(fields, wf.Name)
+	}
+
+	if len(fields) > 0 {
+		msg.Unknown = fields
+	}
+
+	return nil
+}
+
+// preserving returns the encoding of the message, with its unknown fields,
+// as a codec.Raw.
+func (o encoderOptions) preserving(msg *PreservingMessage) (interface{}, error) {
+	prepared, err := o.prepare(&msg.Message)
+	if err != nil || len(msg.Unknown) == 0 {
+		return prepared, err
+	}
+
+	h := o.format.handle()
+
+	var known []byte
+	if err := codec.NewEncoderBytes(&known, h).Encode(prepared); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(msg.Unknown))
+	for name := range msg.Unknown {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var (
+		entries [][2][]byte
+		schema  = messageWireSchema()
+	)
+
+outer:
+	for _, name := range names {
+		for _, wf := range schema.Fields {
+			if wf.Name == name {
+				continue outer
+			}
+		}
+
+		var key, value []byte
+		if err := codec.NewEncoderBytes(&key, h).Encode(name); err != nil {
+			return nil, err
+		}
+		if err := codec.NewEncoderBytes(&value, h).Encode(msg.Unknown[name]); err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, [2][]byte{key, value})
+	}
+
+	if o.format == JSON {
+		return spliceJSON(known, entries), nil
+	}
+
+	return spliceMsgpack(known, entries), nil
+}
+
+// spliceJSON appends the encoded key/value pairs to an encoded JSON object.
+func spliceJSON(object []byte, entries [][2][]byte) codec.Raw {
+	out := make([]byte, 0, len(object)+64*len(entries))
+	out = append(out, object[:len(object)-1]...)
+	for _, e := range entries {
+		if len(out) > 1 {
+			out = append(out, ',')
+		}
+		out = append(out, e[0]...)
+		out = append(out, ':')
+		out = append(out, e[1]...)
+	}
+
+	return append(out, '}')
+}
+
+// spliceMsgpack appends the encoded key/value pairs to an encoded msgpack
+// map, rewriting its header with the new length.
+func spliceMsgpack(m []byte, entries [][2][]byte) codec.Raw {
+	var n, header int
+	switch b := m[0]; {
+	case b&0xf0 == 0x80:
+		n, header = int(b&0x0f), 1
+	case b == 0xde:
+		n, header = int(binary.BigEndian.Uint16(m[1:])), 3
+	default: // 0xdf
+		n, header = int(binary.BigEndian.Uint32(m[1:])), 5
+	}
+
+	n += len(entries)
+	out := make([]byte, 0, len(m)+64*len(entries))
+	switch {
+	case n < 16:
+		out = append(out, 0x80|byte(n))
+	case n <= 0xffff:
+		out = append(out, 0xde)
+		out = binary.BigEndian.AppendUint16(out, uint16(n))
+	default:
+		out = append(out, 0xdf)
+		out = binary.BigEndian.AppendUint32(out, uint32(n))
+	}
+
+	out = append(out, m[header:]...)
+	for _, e := range entries {
+		out = append(out, e[0]...)
+		out = append(out, e[1]...)
+	}
+
+	return out
+}
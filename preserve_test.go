@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newerMessage is the encoding of a message by a newer producer, with fields
+// this version of the package does not know.
+func newerMessage(f Format, unknown int) []byte {
+	fields := map[string]interface{}{
+		"msg_type":         int64(SimpleRequestResponseMessageType),
+		"source":           "dns:example.com",
+		"dest":             "mac:112233445566/config",
+		"transaction_uuid": "1234",
+		"payload":          []byte("payload"),
+		"qos":              int64(50),
+		"new_string":       "value",
+		"new_object":       map[string]interface{}{"a": "b"},
+	}
+
+	for i := 0; i < unknown; i++ {
+		fields["new_"+strconv.Itoa(i)] = int64(i)
+	}
+
+	return MustEncode(fields, f)
+}
+
+func genericFields(t *testing.T, input []byte, f Format) map[string]interface{} {
+	var fields map[string]interface{}
+	require.NoError(t, NewDecoderBytes(input, f).Decode(&fields))
+	return fields
+}
+
+func TestDecodePreserving(t *testing.T) {
+	for _, from := range AllFormats() {
+		for _, unknown := range []int{0, 20} {
+			t.Run(from.String()+"/"+strconv.Itoa(unknown), func(t *testing.T) {
+				assert := assert.New(t)
+				require := require.New(t)
+
+				input := newerMessage(from, unknown)
+
+				var msg PreservingMessage
+				require.NoError(DecodePreserving(input, from, &msg))
+				assert.Equal(Message{
+					Type:             SimpleRequestResponseMessageType,
+					Source:           "dns:example.com",
+					Destination:      "mac:112233445566/config",
+					TransactionUUID:  "1234",
+					Payload:          []byte("payload"),
+					QualityOfService: 50,
+				}, msg.Message)
+				assert.Len(msg.Unknown, 2+unknown)
+				assert.Equal("value", msg.Unknown["new_string"])
+
+				// the same format round trips
+				output := MustEncode(&msg, from)
+				assert.Equal(genericFields(t, input, from), genericFields(t, output, from))
+
+				// other formats keep the unknown fields
+				for _, to := range AllFormats() {
+					var transcoded PreservingMessage
+					require.NoError(DecodePreserving(MustEncode(msg, to), to, &transcoded))
+					assert.Equal(msg.Message, transcoded.Message)
+					assert.Equal("value", transcoded.Unknown["new_string"])
+					assert.Len(transcoded.Unknown, 2+unknown)
+				}
+
+				// decoding into a Message drops the unknown fields
+				var plain PreservingMessage
+				require.NoError(DecodePreserving(MustEncode(&msg.Message, from), from, &plain))
+				assert.Equal(msg.Message, plain.Message)
+				assert.Nil(plain.Unknown)
+			})
+		}
+	}
+}
+
+func TestDecodePreserving_invalid(t *testing.T) {
+	for _, f := range AllFormats() {
+		t.Run(f.String(), func(t *testing.T) {
+			var msg PreservingMessage
+			assert.Error(t, DecodePreserving([]byte{0xc1}, f, &msg))
+
+			// a valid message that is not a map
+			assert.Error(t, DecodePreserving(MustEncode("string", f), f, &msg))
+		})
+	}
+}
+
+func TestPreservingMessage_encode(t *testing.T) {
+	msg := PreservingMessage{
+		Message: Message{
+			Type:   SimpleEventMessageType,
+			Status: int64Ptr(200),
+		},
+		Unknown: map[string]interface{}{
+			"source": "ignored, since it is a known field",
+			"zz":     "last",
+			"aa":     "first",
+		},
+	}
+
+	assert.Equal(t,
+		`{"msg_type":4,"qos":0,"status":"200","aa":"first","zz":"last"}`,
+		string(encodeWithOptions(t, &msg, JSON, WithJSONIntegers(JSONIntegersAsStrings))))
+
+	var decoded map[string]interface{}
+	output := encodeWithOptions(t, msg, Msgpack, WithEmptyCollections(IncludeEmptyCollections))
+	require.NoError(t, NewDecoderBytes(output, Msgpack).Decode(&decoded))
+	assert.Equal(t, "first", decoded["aa"])
+	assert.Equal(t, "last", decoded["zz"])
+	assert.NotContains(t, decoded, "source")
+	assert.Contains(t, decoded, "partner_ids")
+
+	var unsafe []byte
+	err := NewEncoderBytesWithOptions(&unsafe, JSON, WithJSONIntegers(SafeJSONIntegers)).
+		Encode(&PreservingMessage{Message: Message{Status: int64Ptr(1 << 60)}, Unknown: msg.Unknown})
+	assert.ErrorIs(t, err, ErrUnsafeJSONInteger)
+}
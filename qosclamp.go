@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"maps"
+	"strconv"
+)
+
+// QOSClampedKey is the Metadata key ClampQOS sets to the original
+// QualityOfService of a message it clamps.
+const QOSClampedKey = "/qos-clamped"
+
+// QOSPolicy returns the highest QualityOfService allowed for a message.  The
+// bool is false if no limit applies to the message.
+type QOSPolicy func(Message) (QOSValue, bool)
+
+// QOSLimitByPartner returns a QOSPolicy that limits messages by their
+// PartnerIDs.  A message with several partners gets the lowest of their
+// limits.  Messages without a partner in limits get the fallback limit.
+func QOSLimitByPartner(limits map[string]QOSValue, fallback QOSValue) QOSPolicy {
+	limits = maps.Clone(limits)
+	return func(msg Message) (QOSValue, bool) {
+		var (
+			limit QOSValue
+			found bool
+		)
+
+		for _, id := range msg.TrimmedPartnerIDs() {
+			if l, ok := limits[id]; ok && (!found || l < limit) {
+				limit = l
+				found = true
+			}
+		}
+
+		if !found {
+			return fallback, true
+		}
+
+		return limit, true
+	}
+}
+
+// QOSLimitBySource returns a QOSPolicy that limits messages by their Source.
+// The keys of limits are locators without a service, e.g.
+// "mac:112233445566" or "dns:example.com", and are compared to the canonical
+// form of the message's Source with the service and the rest of the path
+// removed.  Messages from other sources get the fallback limit.
+func QOSLimitBySource(limits map[string]QOSValue, fallback QOSValue) QOSPolicy {
+	canonical := make(map[string]QOSValue, len(limits))
+	for k, v := range limits {
+		canonical[sourceKey(k)] = v
+	}
+
+	return func(msg Message) (QOSValue, bool) {
+		if l, ok := canonical[sourceKey(msg.Source)]; ok {
+			return l, true
+		}
+
+		return fallback, true
+	}
+}
+
+// sourceKey returns the canonical scheme and authority of the locator, or the
+// locator itself if it cannot be parsed.
+func sourceKey(s string) string {
+	l, err := ParseLocator(s)
+	if err != nil {
+		return s
+	}
+
+	l.Service = ""
+	l.Ignored = ""
+	return l.Canonical()
+}
+
+// ClampQOS returns a Modifier that lowers the QualityOfService of each message
+// to the limit returned by the policy, to prevent senders from marking every
+// message as critical.  When a message is clamped, its original value is
+// recorded in the Metadata under QOSClampedKey.  The Metadata is copied before
+// it is changed.
+//
+// Messages that are within their limit are returned unmodified with
+// ErrNotHandled.
+func ClampQOS(policy QOSPolicy) Modifier {
+	return ModifierFunc(func(_ context.Context, msg Message) (Message, error) {
+		limit, ok := policy(msg)
+		if !ok || msg.QualityOfService <= limit {
+			return msg, ErrNotHandled
+		}
+
+		metadata := make(map[string]string, len(msg.Metadata)+1)
+		maps.Copy(metadata, msg.Metadata)
+		metadata[QOSClampedKey] = strconv.Itoa(int(msg.QualityOfService))
+
+		msg.Metadata = metadata
+		msg.QualityOfService = limit
+		return msg, nil
+	})
+}
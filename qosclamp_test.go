@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQOSLimitByPartner(t *testing.T) {
+	policy := QOSLimitByPartner(map[string]QOSValue{
+		"trusted":   QOSCriticalValue,
+		"untrusted": QOSLowValue,
+	}, QOSMediumValue)
+
+	tests := []struct {
+		desc     string
+		partners []string
+		expected QOSValue
+	}{
+		{
+			desc:     "no partners",
+			expected: QOSMediumValue,
+		}, {
+			desc:     "unknown partner",
+			partners: []string{"", "other"},
+			expected: QOSMediumValue,
+		}, {
+			desc:     "known partner",
+			partners: []string{"trusted"},
+			expected: QOSCriticalValue,
+		}, {
+			desc:     "lowest limit wins",
+			partners: []string{"trusted", "other", "untrusted"},
+			expected: QOSLowValue,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			limit, ok := policy(Message{PartnerIDs: tc.partners})
+			assert.True(t, ok)
+			assert.Equal(t, tc.expected, limit)
+		})
+	}
+}
+
+func TestQOSLimitBySource(t *testing.T) {
+	policy := QOSLimitBySource(map[string]QOSValue{
+		"mac:11:22:33:44:55:66":   QOSLowValue,
+		"DNS:talaria.example.com": QOSCriticalValue,
+		"not a locator":           QOSHighValue,
+	}, QOSMediumValue)
+
+	tests := []struct {
+		source   string
+		expected QOSValue
+	}{
+		{source: "mac:112233445566", expected: QOSLowValue},
+		{source: "MAC:11-22-33-44-55-66/service/path", expected: QOSLowValue},
+		{source: "dns:talaria.example.com/api", expected: QOSCriticalValue},
+		{source: "not a locator", expected: QOSHighValue},
+		{source: "mac:665544332211", expected: QOSMediumValue},
+		{source: "", expected: QOSMediumValue},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.source, func(t *testing.T) {
+			limit, ok := policy(Message{Source: tc.source})
+			assert.True(t, ok)
+			assert.Equal(t, tc.expected, limit)
+		})
+	}
+}
+
+func TestClampQOS(t *testing.T) {
+	noLimit := func(Message) (QOSValue, bool) {
+		return 0, false
+	}
+
+	tests := []struct {
+		desc     string
+		policy   QOSPolicy
+		msg      Message
+		expected Message
+		err      error
+	}{
+		{
+			desc:     "within limit",
+			policy:   QOSLimitByPartner(nil, QOSHighValue),
+			msg:      Message{QualityOfService: QOSHighValue},
+			expected: Message{QualityOfService: QOSHighValue},
+			err:      ErrNotHandled,
+		}, {
+			desc:     "no limit",
+			policy:   noLimit,
+			msg:      Message{QualityOfService: 99},
+			expected: Message{QualityOfService: 99},
+			err:      ErrNotHandled,
+		}, {
+			desc:   "clamped",
+			policy: QOSLimitByPartner(nil, QOSMediumValue),
+			msg: Message{
+				QualityOfService: 99,
+				Metadata:         map[string]string{"/boot-time": "1"},
+			},
+			expected: Message{
+				QualityOfService: QOSMediumValue,
+				Metadata:         map[string]string{"/boot-time": "1", QOSClampedKey: "99"},
+			},
+		}, {
+			desc:   "clamped without metadata",
+			policy: QOSLimitBySource(map[string]QOSValue{"mac:112233445566": QOSLowValue}, QOSCriticalValue),
+			msg: Message{
+				Source:           "mac:112233445566/event",
+				QualityOfService: QOSHighValue,
+			},
+			expected: Message{
+				Source:           "mac:112233445566/event",
+				QualityOfService: QOSLowValue,
+				Metadata:         map[string]string{QOSClampedKey: "50"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var original map[string]string
+			if tc.msg.Metadata != nil {
+				original = map[string]string{}
+				for k, v := range tc.msg.Metadata {
+					original[k] = v
+				}
+			}
+
+			actual, err := ClampQOS(tc.policy).ModifyWRP(context.Background(), tc.msg)
+			if tc.err != nil {
+				assert.ErrorIs(err, tc.err)
+			} else {
+				assert.NoError(err)
+			}
+			assert.Equal(tc.expected, actual)
+
+			// the caller's metadata is never modified
+			assert.Equal(original, tc.msg.Metadata)
+		})
+	}
+}
@@ -0,0 +1,278 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrRateLimited is the sentinel error wrapped by every RateLimitError.  Use
+	// errors.Is(err, ErrRateLimited) to determine if a message was rejected
+	// because a rate limit was exceeded.
+	ErrRateLimited = errors.New("rate limit exceeded")
+
+	ErrInvalidRateLimit = errors.New("invalid rate limit")
+)
+
+// RateLimitError is the error returned by a RateLimiter when a message is
+// rejected.  It identifies the bucket that was exhausted and how long the caller
+// should wait before a token will be available.
+type RateLimitError struct {
+	// Key is the rate limiting key that was exhausted, e.g. "mac:112233445566"
+	// or "partner:comcast".
+	Key string
+
+	// RetryAfter is the amount of time until the bucket will have a token.
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s: %s, retry after %s", ErrRateLimited, e.Key, e.RetryAfter)
+}
+
+// Unwrap returns ErrRateLimited.
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
+
+// RateLimit describes a token bucket.  Rate is the number of tokens added to the
+// bucket per second, and Burst is the maximum number of tokens the bucket holds.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+func (rl RateLimit) validate() error {
+	if rl.Rate <= 0 || math.IsNaN(rl.Rate) || math.IsInf(rl.Rate, 0) || rl.Burst < 1 {
+		return fmt.Errorf("%w: rate=%v burst=%d", ErrInvalidRateLimit, rl.Rate, rl.Burst)
+	}
+	return nil
+}
+
+// RateLimiterOption is a functional option for configuring a RateLimiter.
+type RateLimiterOption interface {
+	apply(*RateLimiter) error
+}
+
+type rateLimiterOptionFunc func(*RateLimiter) error
+
+func (f rateLimiterOptionFunc) apply(rl *RateLimiter) error {
+	return f(rl)
+}
+
+// RateLimitDevices limits the messages for each device.  The device is the
+// first of the Source or Destination locators that contains a device ID,
+// excluding `self:` locators.  Messages without a device ID are not limited by
+// this option.
+func RateLimitDevices(limit RateLimit) RateLimiterOption {
+	return rateLimiterOptionFunc(func(rl *RateLimiter) error {
+		if err := limit.validate(); err != nil {
+			return err
+		}
+		rl.device = &limit
+		return nil
+	})
+}
+
+// RateLimitPartners limits the messages for each partner ID present in the
+// message.  A message with more than one partner ID is charged against each
+// partner, and is rejected if any of the partners are exhausted.  Messages
+// without a partner ID are not limited by this option.
+func RateLimitPartners(limit RateLimit) RateLimiterOption {
+	return rateLimiterOptionFunc(func(rl *RateLimiter) error {
+		if err := limit.validate(); err != nil {
+			return err
+		}
+		rl.partner = &limit
+		return nil
+	})
+}
+
+// RateLimitClock sets the clock used to refill the token buckets.  The default
+// is SystemClock.
+func RateLimitClock(c Clock) RateLimiterOption {
+	return rateLimiterOptionFunc(func(rl *RateLimiter) error {
+		if c != nil {
+			rl.now = c.Now
+		}
+		return nil
+	})
+}
+
+// RateLimiter is a Processor that rejects messages when a device or partner
+// sends more messages than its token bucket allows.  Messages that are allowed
+// result in ErrNotHandled so the RateLimiter can be placed at the front of a
+// Processors chain.  Messages that are rejected result in a *RateLimitError.
+//
+// A RateLimiter is safe for concurrent use.
+type RateLimiter struct {
+	device  *RateLimit
+	partner *RateLimit
+	now     func() time.Time
+
+	m         sync.Mutex
+	buckets   map[string]*tokenBucket
+	sweepSize int
+}
+
+var _ Processor = (*RateLimiter)(nil)
+
+// NewRateLimiter creates a new RateLimiter with the given options.  At least
+// one of RateLimitDevices or RateLimitPartners should be provided, otherwise
+// no messages are limited.
+func NewRateLimiter(opts ...RateLimiterOption) (*RateLimiter, error) {
+	rl := RateLimiter{
+		now:       SystemClock.Now,
+		buckets:   make(map[string]*tokenBucket),
+		sweepSize: minRateLimitSweepSize,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			if err := opt.apply(&rl); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &rl, nil
+}
+
+// ProcessWRP charges the message against the buckets for its device and
+// partners.  If every bucket has a token available, ErrNotHandled is returned.
+// Otherwise no tokens are consumed and a *RateLimitError is returned for the
+// first exhausted bucket.
+func (rl *RateLimiter) ProcessWRP(_ context.Context, msg Message) error {
+	keys := rl.keys(&msg)
+	if len(keys) == 0 {
+		return ErrNotHandled
+	}
+
+	now := rl.now()
+
+	rl.m.Lock()
+	defer rl.m.Unlock()
+
+	buckets := make([]*tokenBucket, len(keys))
+	for i, k := range keys {
+		b := rl.bucket(k, now)
+		b.refill(now)
+		if b.tokens < 1 {
+			return &RateLimitError{
+				Key:        k.name,
+				RetryAfter: b.wait(),
+			}
+		}
+		buckets[i] = b
+	}
+
+	for _, b := range buckets {
+		b.tokens--
+	}
+
+	return ErrNotHandled
+}
+
+type rateLimitKey struct {
+	name  string
+	limit *RateLimit
+}
+
+// keys returns the list of buckets the message is charged against.
+func (rl *RateLimiter) keys(msg *Message) []rateLimitKey {
+	var keys []rateLimitKey
+
+	if rl.device != nil {
+		if id, ok := messageDeviceID(msg); ok {
+			keys = append(keys, rateLimitKey{name: string(id), limit: rl.device})
+		}
+	}
+
+	if rl.partner != nil {
+		seen := make(map[string]struct{}, len(msg.PartnerIDs))
+		for _, id := range msg.TrimmedPartnerIDs() {
+			if _, dup := seen[id]; dup {
+				continue
+			}
+			seen[id] = struct{}{}
+			keys = append(keys, rateLimitKey{name: "partner:" + id, limit: rl.partner})
+		}
+	}
+
+	return keys
+}
+
+// minRateLimitSweepSize is the smallest number of buckets that triggers a sweep
+// of idle buckets.
+const minRateLimitSweepSize = 1024
+
+// bucket returns the bucket for the key, creating a full one if needed.  The
+// lock must be held by the caller.
+func (rl *RateLimiter) bucket(k rateLimitKey, now time.Time) *tokenBucket {
+	if b, ok := rl.buckets[k.name]; ok {
+		return b
+	}
+
+	if len(rl.buckets) >= rl.sweepSize {
+		rl.sweep(now)
+	}
+
+	b := &tokenBucket{
+		limit:  k.limit,
+		tokens: float64(k.limit.Burst),
+		last:   now,
+	}
+	rl.buckets[k.name] = b
+	return b
+}
+
+// sweep removes buckets that have refilled completely, since they are
+// indistinguishable from a new bucket.  The lock must be held by the caller.
+func (rl *RateLimiter) sweep(now time.Time) {
+	for name, b := range rl.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.limit.Burst) {
+			delete(rl.buckets, name)
+		}
+	}
+
+	rl.sweepSize = 2 * len(rl.buckets)
+	if rl.sweepSize < minRateLimitSweepSize {
+		rl.sweepSize = minRateLimitSweepSize
+	}
+}
+
+type tokenBucket struct {
+	limit  *RateLimit
+	tokens float64
+	last   time.Time
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last)
+	if elapsed <= 0 {
+		return
+	}
+
+	b.last = now
+	b.tokens += elapsed.Seconds() * b.limit.Rate
+	if burst := float64(b.limit.Burst); b.tokens > burst {
+		b.tokens = burst
+	}
+}
+
+// wait returns the time until the next token is available.
+func (b *tokenBucket) wait() time.Duration {
+	missing := 1 - b.tokens
+	if missing <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(missing / b.limit.Rate * float64(time.Second)))
+}
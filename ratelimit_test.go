@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRateLimiter(t *testing.T) {
+	tests := []struct {
+		desc        string
+		opts        []RateLimiterOption
+		expectedErr error
+	}{
+		{
+			desc: "no options",
+		}, {
+			desc: "nil option",
+			opts: []RateLimiterOption{nil},
+		}, {
+			desc: "valid options",
+			opts: []RateLimiterOption{
+				RateLimitDevices(RateLimit{Rate: 1, Burst: 1}),
+				RateLimitPartners(RateLimit{Rate: 10, Burst: 100}),
+			},
+		}, {
+			desc:        "zero rate",
+			opts:        []RateLimiterOption{RateLimitDevices(RateLimit{Burst: 1})},
+			expectedErr: ErrInvalidRateLimit,
+		}, {
+			desc:        "zero burst",
+			opts:        []RateLimiterOption{RateLimitPartners(RateLimit{Rate: 1})},
+			expectedErr: ErrInvalidRateLimit,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			rl, err := NewRateLimiter(tc.opts...)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, rl)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotNil(t, rl)
+		})
+	}
+}
+
+func TestRateLimiter_ProcessWRP(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type step struct {
+		offset     time.Duration
+		msg        Message
+		key        string
+		retryAfter time.Duration
+	}
+
+	tests := []struct {
+		desc  string
+		opts  []RateLimiterOption
+		steps []step
+	}{
+		{
+			desc: "no limits",
+			steps: []step{
+				{msg: Message{Source: "mac:112233445566"}},
+				{msg: Message{Source: "mac:112233445566"}},
+			},
+		}, {
+			desc: "device burst then refill",
+			opts: []RateLimiterOption{RateLimitDevices(RateLimit{Rate: 2, Burst: 2})},
+			steps: []step{
+				{msg: Message{Source: "mac:112233445566/service"}},
+				{msg: Message{Source: "MAC:11-22-33-44-55-66"}},
+				{
+					msg:        Message{Source: "mac:112233445566"},
+					key:        "mac:112233445566",
+					retryAfter: 500 * time.Millisecond,
+				},
+				{msg: Message{Source: "mac:aabbccddeeff"}},
+				{offset: 500 * time.Millisecond, msg: Message{Source: "mac:112233445566"}},
+			},
+		}, {
+			desc: "device from destination",
+			opts: []RateLimiterOption{RateLimitDevices(RateLimit{Rate: 1, Burst: 1})},
+			steps: []step{
+				{msg: Message{Source: "dns:example.com", Destination: "mac:112233445566"}},
+				{
+					msg:        Message{Source: "self:", Destination: "mac:112233445566"},
+					key:        "mac:112233445566",
+					retryAfter: time.Second,
+				},
+				{msg: Message{Source: "dns:example.com", Destination: "event:device-status"}},
+				{msg: Message{Source: "dns:example.com", Destination: "event:device-status"}},
+			},
+		}, {
+			desc: "partners",
+			opts: []RateLimiterOption{RateLimitPartners(RateLimit{Rate: 1, Burst: 1})},
+			steps: []step{
+				{msg: Message{PartnerIDs: []string{"a", "", "a"}}},
+				{
+					msg:        Message{PartnerIDs: []string{"b", "a"}},
+					key:        "partner:a",
+					retryAfter: time.Second,
+				},
+				// b must not have been charged by the rejected message
+				{msg: Message{PartnerIDs: []string{"b"}}},
+				{msg: Message{}},
+			},
+		}, {
+			desc: "device and partner",
+			opts: []RateLimiterOption{
+				RateLimitDevices(RateLimit{Rate: 1, Burst: 10}),
+				RateLimitPartners(RateLimit{Rate: 1, Burst: 1}),
+			},
+			steps: []step{
+				{msg: Message{Source: "mac:112233445566", PartnerIDs: []string{"a"}}},
+				{
+					msg:        Message{Source: "mac:112233445566", PartnerIDs: []string{"a"}},
+					key:        "partner:a",
+					retryAfter: time.Second,
+				},
+				{offset: 250 * time.Millisecond,
+					msg:        Message{Source: "mac:aabbccddeeff", PartnerIDs: []string{"a"}},
+					key:        "partner:a",
+					retryAfter: 750 * time.Millisecond,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			rl, err := NewRateLimiter(tc.opts...)
+			require.NoError(err)
+
+			now := start
+			rl.now = func() time.Time { return now }
+
+			for i, s := range tc.steps {
+				now = now.Add(s.offset)
+				err := rl.ProcessWRP(context.Background(), s.msg)
+
+				if s.key == "" {
+					assert.ErrorIs(err, ErrNotHandled, "step %d", i)
+					continue
+				}
+
+				var rle *RateLimitError
+				require.True(errors.As(err, &rle), "step %d", i)
+				assert.ErrorIs(err, ErrRateLimited)
+				assert.Equal(s.key, rle.Key, "step %d", i)
+				assert.Equal(s.retryAfter, rle.RetryAfter, "step %d", i)
+				assert.NotEmpty(err.Error())
+			}
+		})
+	}
+}
+
+func TestRateLimiter_sweep(t *testing.T) {
+	rl, err := NewRateLimiter(RateLimitDevices(RateLimit{Rate: 1, Burst: 1}))
+	require.NoError(t, err)
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	rl.now = func() time.Time { return now }
+
+	for i := 0; i < minRateLimitSweepSize; i++ {
+		msg := Message{Source: fmt.Sprintf("mac:%012x", i)}
+		assert.ErrorIs(t, rl.ProcessWRP(context.Background(), msg), ErrNotHandled)
+	}
+	assert.Len(t, rl.buckets, minRateLimitSweepSize)
+
+	// All of the existing buckets are full again, so they are removed.
+	now = now.Add(time.Second)
+	assert.ErrorIs(t, rl.ProcessWRP(context.Background(), Message{Source: "mac:ffffffffffff"}), ErrNotHandled)
+	assert.Len(t, rl.buckets, 1)
+}
+
+func TestRateLimitClock(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	rl, err := NewRateLimiter(
+		RateLimitDevices(RateLimit{Rate: 1, Burst: 1}),
+		RateLimitClock(ClockFunc(func() time.Time { return now })),
+		RateLimitClock(nil),
+	)
+	require.NoError(err)
+
+	msg := Message{Source: "mac:112233445566"}
+	assert.ErrorIs(rl.ProcessWRP(context.Background(), msg), ErrNotHandled)
+	assert.ErrorIs(rl.ProcessWRP(context.Background(), msg), ErrRateLimited)
+
+	now = now.Add(time.Second)
+	assert.ErrorIs(rl.ProcessWRP(context.Background(), msg), ErrNotHandled)
+}
@@ -0,0 +1,214 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// ReadOnlyMessage is a view of a Message that provides no way to modify the
+// message.  Transports hand a ReadOnlyMessage to code that must not change a
+// routed message, such as a ReadOnlyObserver.  Accessors that would otherwise
+// expose shared memory return copies.  Use Clone to get a Message that can be
+// modified.
+//
+// The zero value is a view of an empty message.
+type ReadOnlyMessage struct {
+	msg *Message
+}
+
+// ReadOnly returns a read only view of the message.  The view reflects any
+// later changes made to the message through other references.
+func (msg *Message) ReadOnly() ReadOnlyMessage {
+	return ReadOnlyMessage{msg: msg}
+}
+
+func (r ReadOnlyMessage) message() *Message {
+	if r.msg == nil {
+		return &Message{}
+	}
+	return r.msg
+}
+
+// Clone returns a deep copy of the message that is safe to modify.
+func (r ReadOnlyMessage) Clone() *Message {
+	return cloneMessage(r.message())
+}
+
+// MessageType returns the message's Type.
+func (r ReadOnlyMessage) MessageType() MessageType {
+	return r.message().Type
+}
+
+// Source returns the message's Source.
+func (r ReadOnlyMessage) Source() string {
+	return r.message().Source
+}
+
+// Destination returns the message's Destination.
+func (r ReadOnlyMessage) Destination() string {
+	return r.message().Destination
+}
+
+// TransactionUUID returns the message's TransactionUUID.
+func (r ReadOnlyMessage) TransactionUUID() string {
+	return r.message().TransactionUUID
+}
+
+// ContentType returns the message's ContentType.
+func (r ReadOnlyMessage) ContentType() string {
+	return r.message().ContentType
+}
+
+// Accept returns the message's Accept.
+func (r ReadOnlyMessage) Accept() string {
+	return r.message().Accept
+}
+
+// Status returns the message's Status, if it is set.
+func (r ReadOnlyMessage) Status() (int64, bool) {
+	return deref(r.message().Status)
+}
+
+// RequestDeliveryResponse returns the message's RequestDeliveryResponse, if it
+// is set.
+func (r ReadOnlyMessage) RequestDeliveryResponse() (int64, bool) {
+	return deref(r.message().RequestDeliveryResponse)
+}
+
+// Headers returns a copy of the message's Headers.
+func (r ReadOnlyMessage) Headers() []string {
+	return slices.Clone(r.message().Headers)
+}
+
+// Metadata returns the value of a single metadata entry.
+func (r ReadOnlyMessage) Metadata(key string) (string, bool) {
+	v, ok := r.message().Metadata[key]
+	return v, ok
+}
+
+// MetadataMap returns a copy of the message's Metadata.
+func (r ReadOnlyMessage) MetadataMap() map[string]string {
+	return maps.Clone(r.message().Metadata)
+}
+
+// Path returns the message's Path.
+func (r ReadOnlyMessage) Path() string {
+	return r.message().Path
+}
+
+// PayloadLen returns the length of the message's Payload without copying it.
+func (r ReadOnlyMessage) PayloadLen() int {
+	return len(r.message().Payload)
+}
+
+// Payload returns a copy of the message's Payload.
+func (r ReadOnlyMessage) Payload() []byte {
+	return slices.Clone(r.message().Payload)
+}
+
+// ServiceName returns the message's ServiceName.
+func (r ReadOnlyMessage) ServiceName() string {
+	return r.message().ServiceName
+}
+
+// URL returns the message's URL.
+func (r ReadOnlyMessage) URL() string {
+	return r.message().URL
+}
+
+// PartnerIDs returns a copy of the message's PartnerIDs.
+func (r ReadOnlyMessage) PartnerIDs() []string {
+	return slices.Clone(r.message().PartnerIDs)
+}
+
+// SessionID returns the message's SessionID.
+func (r ReadOnlyMessage) SessionID() string {
+	return r.message().SessionID
+}
+
+// QualityOfService returns the message's QualityOfService.
+func (r ReadOnlyMessage) QualityOfService() QOSValue {
+	return r.message().QualityOfService
+}
+
+// ReadOnlyObserver is an Observer that is guaranteed not to modify the
+// messages it observes.
+type ReadOnlyObserver interface {
+	// ObserveWRP is called to observe a message.
+	ObserveWRP(context.Context, ReadOnlyMessage)
+}
+
+// ReadOnlyObserverFunc is a convenience type to define a ReadOnlyObserver
+// using a function.
+type ReadOnlyObserverFunc func(context.Context, ReadOnlyMessage)
+
+func (f ReadOnlyObserverFunc) ObserveWRP(ctx context.Context, msg ReadOnlyMessage) {
+	f(ctx, msg)
+}
+
+// ReadOnlyObserverAsObserver returns an Observer that hands a read only view
+// of each message to the ReadOnlyObserver.
+func ReadOnlyObserverAsObserver(o ReadOnlyObserver) Observer {
+	return ObserverFunc(func(ctx context.Context, msg Message) {
+		o.ObserveWRP(ctx, msg.ReadOnly())
+	})
+}
+
+// DetectMutations returns an Observer that reports when the wrapped Observer
+// modifies the shared parts of a message, i.e. the contents of its slices,
+// maps or pointers.  Such modifications are visible to every other holder of
+// the message and are a common source of cross middleware bugs.
+//
+// Each message is deep copied before it is observed, so this is intended for
+// diagnosing bugs in tests and development rather than for production use.
+func DetectMutations(o Observer, report func(before, after Message)) Observer {
+	return ObserverFunc(func(ctx context.Context, msg Message) {
+		before := cloneMessage(&msg)
+		o.ObserveWRP(ctx, msg)
+		if !reflect.DeepEqual(*before, msg) {
+			report(*before, *cloneMessage(&msg))
+		}
+	})
+}
+
+// cloneMessage returns a deep copy of the message.
+func cloneMessage(msg *Message) *Message {
+	c := *msg
+	c.Status = clonePtr(msg.Status)
+	c.RequestDeliveryResponse = clonePtr(msg.RequestDeliveryResponse)
+	c.IncludeSpans = clonePtr(msg.IncludeSpans) // nolint:staticcheck
+	c.Headers = slices.Clone(msg.Headers)
+	c.Metadata = maps.Clone(msg.Metadata)
+	c.Payload = slices.Clone(msg.Payload)
+	c.PartnerIDs = slices.Clone(msg.PartnerIDs)
+
+	if msg.Spans != nil { // nolint:staticcheck
+		c.Spans = make([][]string, len(msg.Spans)) // nolint:staticcheck
+		for i, span := range msg.Spans {           // nolint:staticcheck
+			c.Spans[i] = slices.Clone(span) // nolint:staticcheck
+		}
+	}
+
+	return &c
+}
+
+func deref[T any](p *T) (T, bool) {
+	if p == nil {
+		var zero T
+		return zero, false
+	}
+	return *p, true
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadOnlyMessage(t *testing.T) {
+	assert := assert.New(t)
+
+	msg := testConvertMessage(SimpleRequestResponseMessageType)
+	msg.QualityOfService = 75
+	r := msg.ReadOnly()
+
+	assert.Equal(msg.Type, r.MessageType())
+	assert.Equal(msg.Source, r.Source())
+	assert.Equal(msg.Destination, r.Destination())
+	assert.Equal(msg.TransactionUUID, r.TransactionUUID())
+	assert.Equal(msg.ContentType, r.ContentType())
+	assert.Equal(msg.Accept, r.Accept())
+	assert.Equal(msg.Path, r.Path())
+	assert.Equal(msg.ServiceName, r.ServiceName())
+	assert.Equal(msg.URL, r.URL())
+	assert.Equal(msg.SessionID, r.SessionID())
+	assert.Equal(msg.QualityOfService, r.QualityOfService())
+	assert.Equal(len(msg.Payload), r.PayloadLen())
+
+	status, ok := r.Status()
+	assert.True(ok)
+	assert.Equal(*msg.Status, status)
+
+	rdr, ok := r.RequestDeliveryResponse()
+	assert.True(ok)
+	assert.Equal(*msg.RequestDeliveryResponse, rdr)
+
+	v, ok := r.Metadata("/key")
+	assert.True(ok)
+	assert.Equal("value", v)
+
+	// modifying anything returned by the view must not affect the message
+	r.Headers()[0] = "changed"
+	r.MetadataMap()["/key"] = "changed"
+	r.Payload()[0] = 'X'
+	r.PartnerIDs()[0] = "changed"
+	assert.Equal(testConvertMessage(SimpleRequestResponseMessageType).Headers, msg.Headers)
+	assert.Equal(testConvertMessage(SimpleRequestResponseMessageType).Metadata, msg.Metadata)
+	assert.Equal(testConvertMessage(SimpleRequestResponseMessageType).Payload, msg.Payload)
+	assert.Equal(testConvertMessage(SimpleRequestResponseMessageType).PartnerIDs, msg.PartnerIDs)
+
+	// the view reflects changes made through the message
+	msg.Source = "dns:other.example.com"
+	assert.Equal("dns:other.example.com", r.Source())
+}
+
+func TestReadOnlyMessage_zero(t *testing.T) {
+	assert := assert.New(t)
+
+	var r ReadOnlyMessage
+	assert.Equal(Invalid0MessageType, r.MessageType())
+	assert.Empty(r.Source())
+	assert.Nil(r.Payload())
+	assert.Nil(r.MetadataMap())
+
+	_, ok := r.Status()
+	assert.False(ok)
+
+	_, ok = r.Metadata("/key")
+	assert.False(ok)
+
+	assert.Equal(&Message{}, r.Clone())
+}
+
+func TestReadOnlyMessage_Clone(t *testing.T) {
+	assert := assert.New(t)
+
+	msg := testConvertMessage(SimpleRequestResponseMessageType)
+	c := msg.ReadOnly().Clone()
+	assert.Equal(msg, c)
+
+	*c.Status = 500
+	*c.RequestDeliveryResponse = 1
+	*c.IncludeSpans = false // nolint:staticcheck
+	c.Headers[0] = "changed"
+	c.Metadata["/key"] = "changed"
+	c.Spans[0][0] = "changed" // nolint:staticcheck
+	c.Payload[0] = 'X'
+	c.PartnerIDs[0] = "changed"
+
+	assert.Equal(testConvertMessage(SimpleRequestResponseMessageType), msg)
+}
+
+func TestReadOnlyObserverAsObserver(t *testing.T) {
+	assert := assert.New(t)
+
+	msg := testConvertMessage(SimpleEventMessageType)
+	called := false
+	o := ReadOnlyObserverAsObserver(ReadOnlyObserverFunc(func(_ context.Context, r ReadOnlyMessage) {
+		called = true
+		assert.Equal(msg, r.Clone())
+	}))
+
+	o.ObserveWRP(context.Background(), *msg)
+	assert.True(called)
+}
+
+func TestDetectMutations(t *testing.T) {
+	tests := []struct {
+		desc    string
+		observe func(Message)
+		mutated bool
+	}{
+		{
+			desc:    "read only",
+			observe: func(msg Message) { _ = msg.Metadata["/key"] },
+		}, {
+			desc:    "local change",
+			observe: func(msg Message) { msg.Source = "changed" },
+		}, {
+			desc:    "metadata",
+			observe: func(msg Message) { msg.Metadata["/key"] = "changed" },
+			mutated: true,
+		}, {
+			desc:    "payload",
+			observe: func(msg Message) { msg.Payload[0] = 'X' },
+			mutated: true,
+		}, {
+			desc:    "status",
+			observe: func(msg Message) { *msg.Status = 500 },
+			mutated: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var reported bool
+			o := DetectMutations(
+				ObserverFunc(func(_ context.Context, msg Message) {
+					tc.observe(msg)
+				}),
+				func(before, after Message) {
+					reported = true
+					assert.NotEqual(before, after)
+				},
+			)
+
+			o.ObserveWRP(context.Background(), *testConvertMessage(SimpleEventMessageType))
+			assert.Equal(tc.mutated, reported)
+		})
+	}
+}
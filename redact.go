@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+)
+
+// DefaultRedactionMask is the value that replaces redacted strings.
+const DefaultRedactionMask = "[REDACTED]"
+
+var ErrInvalidRedactor = errors.New("invalid redactor configuration")
+
+// PayloadRedaction is how a Redactor treats the Payload of a message.
+type PayloadRedaction int
+
+const (
+	// PayloadKeep leaves the Payload as is.  This is the default.
+	PayloadKeep PayloadRedaction = iota
+
+	// PayloadStrip removes the Payload.
+	PayloadStrip
+
+	// PayloadHash replaces the Payload with "sha256:" followed by the hex
+	// SHA-256 of the original, so that equal payloads can still be matched.
+	PayloadHash
+)
+
+// RedactorOption is a functional option for configuring a Redactor.
+type RedactorOption interface {
+	apply(*Redactor) error
+}
+
+type redactorOptionFunc func(*Redactor) error
+
+func (f redactorOptionFunc) apply(r *Redactor) error {
+	return f(r)
+}
+
+// RedactFields sets the fields of the message that are redacted.  String
+// fields, and each element of string slice fields such as PartnerIDs, are
+// replaced with the mask; any other field is set to its zero value.  Use
+// RedactPayload and RedactMetadata for the Payload and the Metadata.
+func RedactFields(fields ...Field) RedactorOption {
+	return redactorOptionFunc(func(r *Redactor) error {
+		for _, f := range fields {
+			switch {
+			case !f.valid():
+				return fmt.Errorf("%w: %s", ErrInvalidRedactor, f)
+			case f == PayloadField || f == MetadataField:
+				return fmt.Errorf("%w: use RedactPayload or RedactMetadata for %s", ErrInvalidRedactor, f)
+			}
+		}
+		r.fields = append(r.fields, fields...)
+		return nil
+	})
+}
+
+// RedactPayload sets how the Payload is redacted.  The default is PayloadKeep.
+func RedactPayload(pr PayloadRedaction) RedactorOption {
+	return redactorOptionFunc(func(r *Redactor) error {
+		if pr < PayloadKeep || pr > PayloadHash {
+			return fmt.Errorf("%w: payload redaction %d", ErrInvalidRedactor, pr)
+		}
+		r.payload = pr
+		return nil
+	})
+}
+
+// RedactMetadata adds patterns of Metadata keys whose values are replaced with
+// the mask.  The patterns have the syntax of path.Match, e.g. "/auth-*" or
+// "*token*".
+func RedactMetadata(patterns ...string) RedactorOption {
+	return redactorOptionFunc(func(r *Redactor) error {
+		for _, p := range patterns {
+			if _, err := path.Match(p, ""); err != nil {
+				return fmt.Errorf("%w: metadata pattern `%s`: %w", ErrInvalidRedactor, p, err)
+			}
+		}
+		r.metadata = append(r.metadata, patterns...)
+		return nil
+	})
+}
+
+// RedactAllMetadata replaces the values of all Metadata keys with the mask.
+func RedactAllMetadata() RedactorOption {
+	return redactorOptionFunc(func(r *Redactor) error {
+		r.allMetadata = true
+		return nil
+	})
+}
+
+// RedactionMask sets the value that replaces redacted strings.  The default is
+// DefaultRedactionMask.
+func RedactionMask(mask string) RedactorOption {
+	return redactorOptionFunc(func(r *Redactor) error {
+		r.mask = mask
+		return nil
+	})
+}
+
+// Redactor produces sanitized copies of messages, e.g. before they are
+// archived or logged, according to a policy of fields, Metadata keys and
+// payload handling.  A Redactor is safe for concurrent use.
+type Redactor struct {
+	fields      []Field
+	metadata    []string
+	allMetadata bool
+	payload     PayloadRedaction
+	mask        string
+}
+
+// NewRedactor creates a Redactor.  Without options, the copies it produces
+// are not redacted at all.
+func NewRedactor(opts ...RedactorOption) (*Redactor, error) {
+	r := Redactor{
+		mask: DefaultRedactionMask,
+	}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt.apply(&r); err != nil {
+			return nil, err
+		}
+	}
+
+	return &r, nil
+}
+
+// Payload returns how the Redactor treats the Payload.
+func (r *Redactor) Payload() PayloadRedaction {
+	return r.payload
+}
+
+// Redact returns a redacted deep copy of the message.  The message itself is
+// not changed.  A nil Redactor returns an unredacted copy.
+func (r *Redactor) Redact(msg *Message) *Message {
+	c := msg.ReadOnly().Clone()
+	if r == nil {
+		return c
+	}
+
+	v := reflect.ValueOf(c).Elem()
+	for _, f := range r.fields {
+		fv := v.FieldByName(fieldNames[f])
+		switch {
+		case fv.Kind() == reflect.String:
+			if fv.Len() > 0 {
+				fv.SetString(r.mask)
+			}
+		case fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.String:
+			for i := 0; i < fv.Len(); i++ {
+				fv.Index(i).SetString(r.mask)
+			}
+		default:
+			fv.Set(reflect.Zero(fv.Type()))
+		}
+	}
+
+	for k := range c.Metadata {
+		if r.redactsKey(k) {
+			c.Metadata[k] = r.mask
+		}
+	}
+
+	switch {
+	case len(c.Payload) == 0:
+	case r.payload == PayloadStrip:
+		c.Payload = nil
+	case r.payload == PayloadHash:
+		sum := sha256.Sum256(c.Payload)
+		c.Payload = []byte("sha256:" + hex.EncodeToString(sum[:]))
+	}
+
+	return c
+}
+
+func (r *Redactor) redactsKey(key string) bool {
+	if r.allMetadata {
+		return true
+	}
+
+	for _, p := range r.metadata {
+		if ok, _ := path.Match(p, key); ok {
+			return true
+		}
+	}
+
+	return false
+}
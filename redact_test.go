@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactor_Redact(t *testing.T) {
+	msg := Message{
+		Type:            SimpleEventMessageType,
+		Source:          "mac:112233445566",
+		Destination:     "event:device-status",
+		TransactionUUID: "1234",
+		Payload:         []byte("payload"),
+		PartnerIDs:      []string{"comcast", "sky"},
+		Metadata: map[string]string{
+			"/auth-token": "secret",
+			"/auth-scope": "all",
+			"/boot-time":  "1",
+		},
+		Status: int64Ptr(200),
+	}
+
+	tests := []struct {
+		desc     string
+		opts     []RedactorOption
+		expected Message
+	}{
+		{
+			desc:     "no policy",
+			expected: msg,
+		}, {
+			desc: "fields",
+			opts: []RedactorOption{RedactFields(SourceField, PartnerIDsField, StatusField, AcceptField), nil},
+			expected: Message{
+				Type:            SimpleEventMessageType,
+				Source:          DefaultRedactionMask,
+				Destination:     "event:device-status",
+				TransactionUUID: "1234",
+				Payload:         []byte("payload"),
+				PartnerIDs:      []string{DefaultRedactionMask, DefaultRedactionMask},
+				Metadata:        msg.Metadata,
+			},
+		}, {
+			desc: "metadata",
+			opts: []RedactorOption{RedactMetadata("/auth-*"), RedactionMask("***")},
+			expected: Message{
+				Type:            SimpleEventMessageType,
+				Source:          "mac:112233445566",
+				Destination:     "event:device-status",
+				TransactionUUID: "1234",
+				Payload:         []byte("payload"),
+				PartnerIDs:      []string{"comcast", "sky"},
+				Metadata: map[string]string{
+					"/auth-token": "***",
+					"/auth-scope": "***",
+					"/boot-time":  "1",
+				},
+				Status: int64Ptr(200),
+			},
+		}, {
+			desc: "all metadata",
+			opts: []RedactorOption{RedactAllMetadata()},
+			expected: Message{
+				Type:            SimpleEventMessageType,
+				Source:          "mac:112233445566",
+				Destination:     "event:device-status",
+				TransactionUUID: "1234",
+				Payload:         []byte("payload"),
+				PartnerIDs:      []string{"comcast", "sky"},
+				Metadata: map[string]string{
+					"/auth-token": DefaultRedactionMask,
+					"/auth-scope": DefaultRedactionMask,
+					"/boot-time":  DefaultRedactionMask,
+				},
+				Status: int64Ptr(200),
+			},
+		}, {
+			desc: "payload stripped",
+			opts: []RedactorOption{RedactPayload(PayloadStrip)},
+			expected: Message{
+				Type:            SimpleEventMessageType,
+				Source:          "mac:112233445566",
+				Destination:     "event:device-status",
+				TransactionUUID: "1234",
+				PartnerIDs:      []string{"comcast", "sky"},
+				Metadata:        msg.Metadata,
+				Status:          int64Ptr(200),
+			},
+		}, {
+			desc: "payload hashed",
+			opts: []RedactorOption{RedactPayload(PayloadHash)},
+			expected: Message{
+				Type:            SimpleEventMessageType,
+				Source:          "mac:112233445566",
+				Destination:     "event:device-status",
+				TransactionUUID: "1234",
+				Payload:         []byte("sha256:239f59ed55e737c77147cf55ad0c1b030b6d7ee748a7426952f9b852d5a935e5"),
+				PartnerIDs:      []string{"comcast", "sky"},
+				Metadata:        msg.Metadata,
+				Status:          int64Ptr(200),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			original := *msg.ReadOnly().Clone()
+			r, err := NewRedactor(tc.opts...)
+			require.NoError(t, err)
+
+			assert.Equal(&tc.expected, r.Redact(&msg))
+			assert.Equal(original, msg, "the message must not change")
+		})
+	}
+}
+
+func TestRedactor_nil(t *testing.T) {
+	var r *Redactor
+	msg := Message{Source: "dns:example.com", Payload: []byte("payload")}
+	redacted := r.Redact(&msg)
+	assert.Equal(t, &msg, redacted)
+	assert.NotSame(t, &msg, redacted)
+}
+
+func TestNewRedactor_invalid(t *testing.T) {
+	tests := []struct {
+		desc string
+		opt  RedactorOption
+	}{
+		{
+			desc: "invalid field",
+			opt:  RedactFields(Field(-1)),
+		}, {
+			desc: "payload field",
+			opt:  RedactFields(PayloadField),
+		}, {
+			desc: "metadata field",
+			opt:  RedactFields(MetadataField),
+		}, {
+			desc: "payload redaction",
+			opt:  RedactPayload(PayloadHash + 1),
+		}, {
+			desc: "metadata pattern",
+			opt:  RedactMetadata("[a-"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			r, err := NewRedactor(tc.opt)
+			assert.ErrorIs(t, err, ErrInvalidRedactor)
+			assert.Nil(t, r)
+		})
+	}
+}
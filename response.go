@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ResponseOption is a functional option for NewResponse.
+type ResponseOption interface {
+	apply(*Message)
+}
+
+type responseOptionFunc func(*Message)
+
+func (f responseOptionFunc) apply(msg *Message) {
+	f(msg)
+}
+
+// ResponseSource sets the Source of the response.  By default, the Source is
+// the request's Destination.
+func ResponseSource(source string) ResponseOption {
+	return responseOptionFunc(func(msg *Message) {
+		msg.Source = source
+	})
+}
+
+// ResponsePayload sets the Payload and ContentType of the response.
+func ResponsePayload(contentType string, payload []byte) ResponseOption {
+	return responseOptionFunc(func(msg *Message) {
+		msg.ContentType = contentType
+		msg.Payload = payload
+	})
+}
+
+// ResponseRDR sets the RequestDeliveryResponse of the response.
+func ResponseRDR(rdr int64) ResponseOption {
+	return responseOptionFunc(func(msg *Message) {
+		msg.SetRequestDeliveryResponse(rdr)
+	})
+}
+
+// NewResponse creates the response to a SimpleRequestResponse or CRUD request.
+// Unlike Message.Response, the result is a *Message and only the fields that
+// belong in a response are carried over from the request:
+//
+//   - Source and Destination are swapped.
+//   - Type, TransactionUUID, PartnerIDs and SessionID are preserved.
+//   - Path is preserved for CRUD messages.
+//   - Status is set to the given status.
+//
+// Any other message type results in an *Error with CodeInvalidMessageType.
+func NewResponse(request *Message, status int64, opts ...ResponseOption) (*Message, error) {
+	if !request.Type.RequiresTransaction() {
+		return nil, newError(CodeInvalidMessageType, "Type",
+			fmt.Errorf("%w: %s messages have no response", ErrInvalidMessageType, friendlyName(request.Type)))
+	}
+
+	response := Message{
+		Type:            request.Type,
+		Source:          request.Destination,
+		Destination:     request.Source,
+		TransactionUUID: request.TransactionUUID,
+		PartnerIDs:      slices.Clone(request.PartnerIDs),
+		SessionID:       request.SessionID,
+	}
+
+	if request.Type != SimpleRequestResponseMessageType {
+		response.Path = request.Path
+	}
+
+	response.SetStatus(status)
+	for _, opt := range opts {
+		if opt != nil {
+			opt.apply(&response)
+		}
+	}
+
+	return &response, nil
+}
+
+// NewDeliveryReceipt creates the message returned to the sender of original
+// when it could not be delivered, for example because the device was offline
+// or the message expired.  The receipt is addressed back to the original
+// Source, with the original Destination as its Source, and carries rdr as its
+// RequestDeliveryResponse.  A non-empty note is included as a text/plain
+// payload.
+//
+// The receipt has the same type as the original message, so requests receive
+// a response they can correlate and events receive an event.  Only the fields
+// that identify the delivery are carried over: TransactionUUID, PartnerIDs,
+// SessionID and QualityOfService, and Path for CRUD messages.
+//
+// Messages other than SimpleRequestResponse, CRUD and SimpleEvent messages
+// have no receipt and result in an *Error with CodeInvalidMessageType.
+func NewDeliveryReceipt(original *Message, rdr int64, note string) (*Message, error) {
+	if !original.Type.RequiresTransaction() && !original.Type.SupportsQOSAck() {
+		return nil, newError(CodeInvalidMessageType, "Type",
+			fmt.Errorf("%w: %s messages have no delivery receipt", ErrInvalidMessageType, friendlyName(original.Type)))
+	}
+
+	receipt := Message{
+		Type:             original.Type,
+		Source:           original.Destination,
+		Destination:      original.Source,
+		TransactionUUID:  original.TransactionUUID,
+		PartnerIDs:       slices.Clone(original.PartnerIDs),
+		SessionID:        original.SessionID,
+		QualityOfService: original.QualityOfService,
+	}
+
+	if IsCRUD(original.Type) {
+		receipt.Path = original.Path
+	}
+
+	if note != "" {
+		receipt.ContentType = MimeTypeTextPlain
+		receipt.Payload = []byte(note)
+	}
+
+	receipt.SetRequestDeliveryResponse(rdr)
+	return &receipt, nil
+}
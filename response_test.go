@@ -0,0 +1,225 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewResponse(t *testing.T) {
+	request := func(mt MessageType) *Message {
+		return &Message{
+			Type:            mt,
+			Source:          "dns:example.com/api",
+			Destination:     "mac:112233445566/config",
+			TransactionUUID: "1234",
+			ContentType:     "application/json",
+			Accept:          "application/json",
+			Path:            "/config/value",
+			Payload:         []byte(`{"value":1}`),
+			Metadata:        map[string]string{"/key": "value"},
+			PartnerIDs:      []string{"comcast"},
+			SessionID:       "session",
+		}
+	}
+
+	tests := []struct {
+		desc     string
+		request  *Message
+		status   int64
+		opts     []ResponseOption
+		expected func() *Message
+	}{
+		{
+			desc:    "simple request response",
+			request: request(SimpleRequestResponseMessageType),
+			status:  200,
+			expected: func() *Message {
+				return (&Message{
+					Type:            SimpleRequestResponseMessageType,
+					Source:          "mac:112233445566/config",
+					Destination:     "dns:example.com/api",
+					TransactionUUID: "1234",
+					PartnerIDs:      []string{"comcast"},
+					SessionID:       "session",
+				}).SetStatus(200)
+			},
+		}, {
+			desc:    "crud keeps path",
+			request: request(RetrieveMessageType),
+			status:  404,
+			expected: func() *Message {
+				return (&Message{
+					Type:            RetrieveMessageType,
+					Source:          "mac:112233445566/config",
+					Destination:     "dns:example.com/api",
+					TransactionUUID: "1234",
+					Path:            "/config/value",
+					PartnerIDs:      []string{"comcast"},
+					SessionID:       "session",
+				}).SetStatus(404)
+			},
+		}, {
+			desc:    "options",
+			request: request(UpdateMessageType),
+			status:  200,
+			opts: []ResponseOption{
+				ResponseSource("mac:112233445566"),
+				ResponsePayload("text/plain", []byte("ok")),
+				ResponseRDR(0),
+				nil,
+			},
+			expected: func() *Message {
+				return (&Message{
+					Type:            UpdateMessageType,
+					Source:          "mac:112233445566",
+					Destination:     "dns:example.com/api",
+					TransactionUUID: "1234",
+					ContentType:     "text/plain",
+					Path:            "/config/value",
+					Payload:         []byte("ok"),
+					PartnerIDs:      []string{"comcast"},
+					SessionID:       "session",
+				}).SetStatus(200).SetRequestDeliveryResponse(0)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			actual, err := NewResponse(tc.request, tc.status, tc.opts...)
+			require.NoError(t, err)
+			assert.Equal(tc.expected(), actual)
+
+			// the response does not share the request's partner ids
+			actual.PartnerIDs[0] = "changed"
+			assert.Equal("comcast", tc.request.PartnerIDs[0])
+		})
+	}
+}
+
+func TestNewResponse_invalid(t *testing.T) {
+	for _, mt := range []MessageType{SimpleEventMessageType, AuthorizationMessageType, ServiceAliveMessageType, Invalid0MessageType} {
+		t.Run(mt.String(), func(t *testing.T) {
+			assert := assert.New(t)
+
+			actual, err := NewResponse(&Message{Type: mt}, 200)
+			assert.Nil(actual)
+			assert.ErrorIs(err, ErrInvalidMessageType)
+			assert.Equal(CodeInvalidMessageType, ErrorCodeOf(err))
+		})
+	}
+}
+
+func TestNewDeliveryReceipt(t *testing.T) {
+	original := func(mt MessageType) *Message {
+		return &Message{
+			Type:             mt,
+			Source:           "dns:example.com/api",
+			Destination:      "mac:112233445566/config",
+			TransactionUUID:  "1234",
+			ContentType:      "application/json",
+			Path:             "/config/value",
+			Payload:          []byte(`{"value":1}`),
+			Metadata:         map[string]string{"/key": "value"},
+			PartnerIDs:       []string{"comcast"},
+			SessionID:        "session",
+			QualityOfService: 75,
+		}
+	}
+
+	tests := []struct {
+		desc     string
+		original *Message
+		rdr      int64
+		note     string
+		expected func() *Message
+	}{
+		{
+			desc:     "simple request response",
+			original: original(SimpleRequestResponseMessageType),
+			rdr:      1,
+			note:     "device offline",
+			expected: func() *Message {
+				return (&Message{
+					Type:             SimpleRequestResponseMessageType,
+					Source:           "mac:112233445566/config",
+					Destination:      "dns:example.com/api",
+					TransactionUUID:  "1234",
+					ContentType:      "text/plain",
+					Payload:          []byte("device offline"),
+					PartnerIDs:       []string{"comcast"},
+					SessionID:        "session",
+					QualityOfService: 75,
+				}).SetRequestDeliveryResponse(1)
+			},
+		}, {
+			desc:     "crud keeps the path",
+			original: original(RetrieveMessageType),
+			rdr:      2,
+			expected: func() *Message {
+				return (&Message{
+					Type:             RetrieveMessageType,
+					Source:           "mac:112233445566/config",
+					Destination:      "dns:example.com/api",
+					TransactionUUID:  "1234",
+					Path:             "/config/value",
+					PartnerIDs:       []string{"comcast"},
+					SessionID:        "session",
+					QualityOfService: 75,
+				}).SetRequestDeliveryResponse(2)
+			},
+		}, {
+			desc:     "event",
+			original: original(SimpleEventMessageType),
+			rdr:      1,
+			note:     "expired",
+			expected: func() *Message {
+				return (&Message{
+					Type:             SimpleEventMessageType,
+					Source:           "mac:112233445566/config",
+					Destination:      "dns:example.com/api",
+					TransactionUUID:  "1234",
+					ContentType:      "text/plain",
+					Payload:          []byte("expired"),
+					PartnerIDs:       []string{"comcast"},
+					SessionID:        "session",
+					QualityOfService: 75,
+				}).SetRequestDeliveryResponse(1)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			actual, err := NewDeliveryReceipt(tc.original, tc.rdr, tc.note)
+			require.NoError(t, err)
+			assert.Equal(tc.expected(), actual)
+
+			// the receipt does not share the original's partner ids
+			actual.PartnerIDs[0] = "changed"
+			assert.Equal("comcast", tc.original.PartnerIDs[0])
+		})
+	}
+}
+
+func TestNewDeliveryReceipt_invalid(t *testing.T) {
+	for _, mt := range []MessageType{AuthorizationMessageType, ServiceRegistrationMessageType, ServiceAliveMessageType, Invalid0MessageType} {
+		t.Run(mt.String(), func(t *testing.T) {
+			assert := assert.New(t)
+
+			actual, err := NewDeliveryReceipt(&Message{Type: mt}, 1, "")
+			assert.Nil(actual)
+			assert.ErrorIs(err, ErrInvalidMessageType)
+			assert.Equal(CodeInvalidMessageType, ErrorCodeOf(err))
+		})
+	}
+}
@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import "hash/fnv"
+
+// TransactionSample returns a value in the range [0, 1) that is compared with
+// a sample rate to decide if a transaction is sampled, e.g. logged.  The value
+// is derived from a hash of the transaction UUID, so every service using the
+// same sample rate samples the same transactions.  Without a transaction UUID
+// the value is taken from random, e.g. rand.Float64.
+func TransactionSample(transactionUUID string, random func() float64) float64 {
+	if transactionUUID == "" {
+		return random()
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(transactionUUID))
+	return float64(h.Sum64()>>11) / (1 << 53)
+}
@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionSample(t *testing.T) {
+	assert := assert.New(t)
+	random := func() float64 { return 0.25 }
+
+	v := TransactionSample("1234", random)
+	assert.GreaterOrEqual(v, 0.0)
+	assert.Less(v, 1.0)
+	assert.Equal(v, TransactionSample("1234", nil))
+	assert.NotEqual(v, TransactionSample("5678", nil))
+
+	assert.Equal(0.25, TransactionSample("", random))
+}
@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sync"
+)
+
+var (
+	ErrInvalidScheme     = errors.New("invalid locator scheme")
+	ErrSchemeRegistered  = errors.New("locator scheme already registered")
+	ErrLocatorNotAllowed = errors.New("locator not allowed")
+)
+
+var (
+	// builtinSchemes are the schemes defined by the WRP spec, which cannot be
+	// registered.
+	builtinSchemes = []string{SchemeMAC, SchemeUUID, SchemeDNS, SchemeSerial, SchemeSelf, SchemeEvent}
+
+	// schemeNamePattern is the syntax of a scheme registered with
+	// RegisterScheme, which is that of a URI scheme in lower case.
+	schemeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9+.\-]*$`)
+
+	// customLocatorPattern matches the locators of any scheme, with the same
+	// groups as LocatorPattern.
+	customLocatorPattern = regexp.MustCompile(
+		`^(?P<scheme>[A-Za-z][A-Za-z0-9+.\-]*):(?P<authority>[^/]+)?(?P<service>/[^/]+)?(?P<ignored>.+)?`,
+	)
+)
+
+// SchemeParser completes the parsing of a locator of a registered scheme.
+// ParseLocator calls it with the Scheme, in lower case, and the Authority,
+// Service and Ignored parts split and trimmed as for any other scheme.  The
+// parser may normalize the parts, and set the ID if the scheme identifies
+// devices.  An error makes the locator invalid.
+type SchemeParser func(*Locator) error
+
+// SchemeValidator checks a parsed locator of a registered scheme beyond what
+// is needed to parse it, e.g. that its authority is one the deployment knows.
+// It is called by ValidateLocator, which is what the validators and
+// normalizers of messages use.
+type SchemeValidator func(Locator) error
+
+type registeredScheme struct {
+	parser    SchemeParser
+	validator SchemeValidator
+}
+
+var schemes = struct {
+	lock       sync.RWMutex
+	registered map[string]registeredScheme
+}{
+	registered: make(map[string]registeredScheme),
+}
+
+// RegisterScheme adds a locator scheme, e.g. "xpc" or "room", for private
+// deployments.  ParseLocator, and everything built on it, then accepts
+// locators of the scheme.  The name must be a lower case URI scheme name.
+//
+// If parser is nil, the parts of a locator are used as is, and a locator must
+// have an authority.  If validator is nil, any locator that parses is valid.
+//
+// The schemes of the WRP spec, e.g. SchemeMAC, and schemes that are already
+// registered cannot be registered, which results in an error wrapping
+// ErrSchemeRegistered.  Schemes are usually registered during initialization.
+func RegisterScheme(name string, parser SchemeParser, validator SchemeValidator) error {
+	if !schemeNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: `%s`", ErrInvalidScheme, name)
+	}
+
+	if slices.Contains(builtinSchemes, name) {
+		return fmt.Errorf("%w: `%s` is defined by the WRP spec", ErrSchemeRegistered, name)
+	}
+
+	schemes.lock.Lock()
+	defer schemes.lock.Unlock()
+
+	if _, ok := schemes.registered[name]; ok {
+		return fmt.Errorf("%w: `%s`", ErrSchemeRegistered, name)
+	}
+
+	schemes.registered[name] = registeredScheme{
+		parser:    parser,
+		validator: validator,
+	}
+
+	return nil
+}
+
+// RegisteredSchemes returns the names of the schemes added with
+// RegisterScheme, in sorted order.
+func RegisteredSchemes() []string {
+	schemes.lock.RLock()
+	defer schemes.lock.RUnlock()
+
+	names := make([]string, 0, len(schemes.registered))
+	for name := range schemes.registered {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+	return names
+}
+
+// IsKnownScheme returns true if the scheme, in lower case, is one of the
+// schemes of the WRP spec or a registered scheme.
+func IsKnownScheme(scheme string) bool {
+	if slices.Contains(builtinSchemes, scheme) {
+		return true
+	}
+
+	_, ok := lookupScheme(scheme)
+	return ok
+}
+
+func lookupScheme(name string) (registeredScheme, bool) {
+	schemes.lock.RLock()
+	defer schemes.lock.RUnlock()
+
+	s, ok := schemes.registered[name]
+	return s, ok
+}
+
+// parse completes the parsing of a locator of a registered scheme.
+func (s registeredScheme) parse(l *Locator) error {
+	if s.parser == nil {
+		if l.Authority == "" {
+			return fmt.Errorf("%w: empty authority", ErrorInvalidLocator)
+		}
+		return nil
+	}
+
+	if err := s.parser(l); err != nil {
+		return fmt.Errorf("%w: %w", ErrorInvalidLocator, err)
+	}
+
+	return nil
+}
+
+// ValidateLocator parses the locator and, if its scheme is registered, checks
+// it with the SchemeValidator of the scheme.  A locator that the validator
+// rejects results in an *Error with the code CodeInvalidLocator that wraps
+// ErrLocatorNotAllowed.
+func ValidateLocator(locator string) (Locator, error) {
+	l, err := ParseLocator(locator)
+	if err != nil {
+		return Locator{}, err
+	}
+
+	if s, ok := lookupScheme(l.Scheme); ok && s.validator != nil {
+		if err := s.validator(l); err != nil {
+			return Locator{}, newError(CodeInvalidLocator, "", fmt.Errorf("%w: `%s`: %w", ErrLocatorNotAllowed, locator, err))
+		}
+	}
+
+	return l, nil
+}
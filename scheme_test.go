@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errUnknownRoom = errors.New("unknown room")
+
+// registerTestSchemes registers the schemes of the tests, and unregisters them
+// when the test ends.
+func registerTestSchemes(t *testing.T) {
+	t.Helper()
+
+	require.NoError(t, RegisterScheme("xpc", nil, nil))
+	require.NoError(t, RegisterScheme("room",
+		func(l *Locator) error {
+			if l.Authority == "" {
+				return errors.New("no room")
+			}
+			l.Authority = strings.ToLower(l.Authority)
+			l.ID = DeviceID("room:" + l.Authority)
+			return nil
+		},
+		func(l Locator) error {
+			if l.Authority == "attic" {
+				return errUnknownRoom
+			}
+			return nil
+		},
+	))
+
+	t.Cleanup(func() {
+		schemes.lock.Lock()
+		defer schemes.lock.Unlock()
+		delete(schemes.registered, "xpc")
+		delete(schemes.registered, "room")
+	})
+}
+
+func TestRegisterScheme(t *testing.T) {
+	registerTestSchemes(t)
+
+	tests := []struct {
+		description string
+		name        string
+		expectedErr error
+	}{
+		{
+			description: "builtin scheme",
+			name:        SchemeMAC,
+			expectedErr: ErrSchemeRegistered,
+		}, {
+			description: "event scheme",
+			name:        SchemeEvent,
+			expectedErr: ErrSchemeRegistered,
+		}, {
+			description: "already registered",
+			name:        "xpc",
+			expectedErr: ErrSchemeRegistered,
+		}, {
+			description: "upper case",
+			name:        "XPC",
+			expectedErr: ErrInvalidScheme,
+		}, {
+			description: "empty",
+			expectedErr: ErrInvalidScheme,
+		}, {
+			description: "invalid characters",
+			name:        "x/pc",
+			expectedErr: ErrInvalidScheme,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.ErrorIs(t, RegisterScheme(tc.name, nil, nil), tc.expectedErr)
+		})
+	}
+
+	assert.Equal(t, []string{"room", "xpc"}, RegisteredSchemes())
+	assert.True(t, IsKnownScheme("room"))
+	assert.True(t, IsKnownScheme(SchemeDNS))
+	assert.False(t, IsKnownScheme("unknown"))
+}
+
+func TestParseLocator_registeredSchemes(t *testing.T) {
+	registerTestSchemes(t)
+
+	tests := []struct {
+		description string
+		locator     string
+		validate    bool
+		expected    Locator
+		expectedErr error
+	}{
+		{
+			description: "default parser",
+			locator:     "XPC:account-1/config/ignored",
+			expected: Locator{
+				Scheme:    "xpc",
+				Authority: "account-1",
+				Service:   "config",
+				Ignored:   "/ignored",
+			},
+		}, {
+			description: "default parser without an authority",
+			locator:     "xpc:",
+			expectedErr: ErrorInvalidLocator,
+		}, {
+			description: "custom parser",
+			locator:     "room:Kitchen/lights",
+			validate:    true,
+			expected: Locator{
+				Scheme:    "room",
+				Authority: "kitchen",
+				Service:   "lights",
+				ID:        "room:kitchen",
+			},
+		}, {
+			description: "custom parser error",
+			locator:     "room:/lights",
+			expectedErr: ErrorInvalidLocator,
+		}, {
+			description: "validator error",
+			locator:     "room:attic",
+			validate:    true,
+			expectedErr: errUnknownRoom,
+		}, {
+			description: "unregistered scheme",
+			locator:     "hall:kitchen",
+			expectedErr: ErrorInvalidLocator,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			parse := ParseLocator
+			if tc.validate {
+				parse = ValidateLocator
+			}
+
+			l, err := parse(tc.locator)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				assert.ErrorIs(err, &Error{Code: CodeInvalidLocator})
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tc.expected, l)
+		})
+	}
+}
+
+func TestRegisteredScheme_validators(t *testing.T) {
+	registerTestSchemes(t)
+	assert := assert.New(t)
+
+	msg := Message{
+		Type:        SimpleEventMessageType,
+		Source:      "room:kitchen",
+		Destination: "room:attic",
+	}
+
+	assert.NoError(ValidateSource().normify(&msg))
+	err := ValidateDestination().normify(&msg)
+	assert.ErrorIs(err, ErrInvalidDest)
+	assert.ErrorIs(err, ErrLocatorNotAllowed)
+
+	var set LocatorSet
+	assert.NoError(set.Add("room:*/lights", "xpc:account-1"))
+	assert.True(set.Contains("room:Kitchen/lights"))
+	assert.False(set.Contains("room:kitchen/heating"))
+	assert.True(set.Contains("xpc:account-1/config"))
+}
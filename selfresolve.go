@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrMissingIdentity = errors.New("no device identity to resolve self locator")
+
+// IdentityFunc returns the identity of the device a message is associated
+// with, typically the device of the connection the message arrived on.
+type IdentityFunc func(context.Context) (DeviceID, bool)
+
+// ContextIdentity is an IdentityFunc that returns the device ID added to the
+// context with ContextWithDeviceID.  Unlike DeviceIDFromContext, it never
+// falls back to the locators of the message, since those are what is being
+// resolved.
+func ContextIdentity(ctx context.Context) (DeviceID, bool) {
+	id, ok := ctx.Value(deviceIDContextKey{}).(DeviceID)
+	return id, ok && id != ""
+}
+
+// ResolveSelfLocators returns a Modifier that replaces `self:` Source and
+// Destination locators with fully qualified locators for the device returned
+// by identity, keeping their service and ignored parts.  For example, with the
+// identity mac:112233445566 the locator self:/config becomes
+// mac:112233445566/config.  If identity is nil, ContextIdentity is used.
+//
+// Messages without `self:` locators are returned unmodified with
+// ErrNotHandled.  If a message has a `self:` locator and there is no identity,
+// or the identity is not a valid device ID, an *Error with the code
+// CodeInvalidLocator is returned.
+func ResolveSelfLocators(identity IdentityFunc) Modifier {
+	if identity == nil {
+		identity = ContextIdentity
+	}
+
+	return ModifierFunc(func(ctx context.Context, msg Message) (Message, error) {
+		src, srcSelf := selfLocator(msg.Source)
+		dst, dstSelf := selfLocator(msg.Destination)
+		if !srcSelf && !dstSelf {
+			return msg, ErrNotHandled
+		}
+
+		field := "Source"
+		if !srcSelf {
+			field = "Destination"
+		}
+
+		raw, ok := identity(ctx)
+		if !ok {
+			return msg, newError(CodeInvalidLocator, field, ErrMissingIdentity)
+		}
+
+		id, err := ParseDeviceID(string(raw))
+		if err == nil && id.Prefix() == SchemeSelf {
+			err = ErrorInvalidDeviceName
+		}
+		if err != nil {
+			return msg, newError(CodeInvalidLocator, field,
+				fmt.Errorf("%w: identity `%s`: %w", ErrMissingIdentity, raw, err))
+		}
+
+		if srcSelf {
+			msg.Source = resolveSelf(src, id)
+		}
+		if dstSelf {
+			msg.Destination = resolveSelf(dst, id)
+		}
+
+		return msg, nil
+	})
+}
+
+// selfLocator parses the locator and returns true if it is a `self:` locator.
+func selfLocator(s string) (Locator, bool) {
+	l, err := ParseLocator(s)
+	return l, err == nil && l.IsSelf()
+}
+
+func resolveSelf(l Locator, id DeviceID) string {
+	l.Scheme = id.Prefix()
+	l.Authority = id.ID()
+	l.ID = id
+	return l.String()
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveSelfLocators(t *testing.T) {
+	device := ContextWithDeviceID(context.Background(), "mac:112233445566")
+
+	tests := []struct {
+		desc     string
+		identity IdentityFunc
+		ctx      context.Context
+		msg      Message
+		want     Message
+		field    string
+		err      error
+	}{
+		{
+			desc: "self source",
+			ctx:  device,
+			msg:  Message{Source: "self:/config", Destination: "event:device-status"},
+			want: Message{Source: "mac:112233445566/config", Destination: "event:device-status"},
+		}, {
+			desc: "self destination with ignored parts",
+			ctx:  device,
+			msg:  Message{Source: "dns:talaria", Destination: "self:/service/ignored/parts"},
+			want: Message{Source: "dns:talaria", Destination: "mac:112233445566/service/ignored/parts"},
+		}, {
+			desc: "self source and destination",
+			ctx:  device,
+			msg:  Message{Source: "self:", Destination: "self:/parodus"},
+			want: Message{Source: "mac:112233445566", Destination: "mac:112233445566/parodus"},
+		}, {
+			desc: "custom identity",
+			identity: func(context.Context) (DeviceID, bool) {
+				return "UUID:1234-ABCD", true
+			},
+			ctx:  context.Background(),
+			msg:  Message{Source: "self:/config"},
+			want: Message{Source: "uuid:1234-ABCD/config"},
+		}, {
+			desc: "no self locators",
+			ctx:  context.Background(),
+			msg:  Message{Source: "mac:112233445566/config", Destination: "dns:talaria"},
+			want: Message{Source: "mac:112233445566/config", Destination: "dns:talaria"},
+			err:  ErrNotHandled,
+		}, {
+			desc: "invalid locators are left alone",
+			ctx:  context.Background(),
+			msg:  Message{Source: "not a locator"},
+			want: Message{Source: "not a locator"},
+			err:  ErrNotHandled,
+		}, {
+			desc:  "missing identity",
+			ctx:   context.Background(),
+			msg:   Message{Source: "dns:talaria", Destination: "self:/config"},
+			want:  Message{Source: "dns:talaria", Destination: "self:/config"},
+			field: "Destination",
+			err:   ErrMissingIdentity,
+		}, {
+			desc:  "message device ID is not an identity",
+			ctx:   context.Background(),
+			msg:   Message{Source: "self:/config", Destination: "mac:112233445566"},
+			want:  Message{Source: "self:/config", Destination: "mac:112233445566"},
+			field: "Source",
+			err:   ErrMissingIdentity,
+		}, {
+			desc: "invalid identity",
+			identity: func(context.Context) (DeviceID, bool) {
+				return "self:", true
+			},
+			ctx:   context.Background(),
+			msg:   Message{Source: "self:/config"},
+			want:  Message{Source: "self:/config"},
+			field: "Source",
+			err:   ErrorInvalidDeviceName,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			got, err := ResolveSelfLocators(tc.identity).ModifyWRP(tc.ctx, tc.msg)
+			assert.Equal(tc.want, got)
+			assert.ErrorIs(err, tc.err)
+
+			if tc.field != "" {
+				var e *Error
+				assert.ErrorAs(err, &e)
+				assert.Equal(CodeInvalidLocator, e.Code)
+				assert.Equal(tc.field, e.Field)
+				assert.ErrorIs(err, ErrMissingIdentity)
+			}
+		})
+	}
+}
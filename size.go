@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/ugorji/go/codec"
+)
+
+// FieldSize is the number of bytes a single field contributes to an encoded
+// message, including the field's name.
+type FieldSize struct {
+	Field Field
+	Bytes int
+}
+
+// Breakdown attributes the encoded size of a message to its fields.
+type Breakdown struct {
+	// Format is the format of the encoding.
+	Format Format
+
+	// Total is the size of the encoded message, which is the sum of the
+	// Fields and the Overhead.
+	Total int
+
+	// Fields are the sizes of the fields present in the encoding, in
+	// declaration order.  Fields that are left out of the encoding because
+	// they are empty are not included.
+	Fields []FieldSize
+
+	// Overhead is the size of the encoding that does not belong to a single
+	// field, e.g. the msgpack map header or the JSON braces and commas.
+	Overhead int
+}
+
+// Bytes returns the number of bytes the field contributes to the encoding.
+func (b Breakdown) Bytes(f Field) int {
+	for _, fs := range b.Fields {
+		if fs.Field == f {
+			return fs.Bytes
+		}
+	}
+
+	return 0
+}
+
+// Size returns the size of the message when encoded in the given format.  This
+// method panics if the format is not a valid value.
+func (msg *Message) Size(f Format) int {
+	var output []byte
+	if err := NewEncoderBytes(&output, f).Encode(msg); err != nil {
+		panic(err)
+	}
+
+	return len(output)
+}
+
+// Breakdown returns the size of the message when encoded in the given format
+// along with the size of each field, so bandwidth can be attributed to the
+// payload, metadata, headers, etc.  This method panics if the format is not a
+// valid value.
+func (msg *Message) Breakdown(f Format) Breakdown {
+	b := Breakdown{
+		Format: f,
+		Total:  msg.Size(f),
+	}
+
+	var (
+		v      = reflect.ValueOf(msg).Elem()
+		schema = messageWireSchema()
+		sum    int
+	)
+
+	for i, wf := range schema.Fields {
+		fv := v.Field(i)
+		if wf.OmitEmpty && isEmptyValue(fv) {
+			continue
+		}
+
+		n := fieldSize(f, wf.Name, fv.Interface())
+		b.Fields = append(b.Fields, FieldSize{Field: Field(i), Bytes: n})
+		sum += n
+	}
+
+	b.Overhead = b.Total - sum
+	return b
+}
+
+var messageWireSchema = sync.OnceValue(func() WireSchema {
+	s, err := WireSchemaOf(Message{})
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+})
+
+// fieldSize returns the size of a single name/value pair by encoding it as
+// the only entry of a map, less the size of an empty map.
+func fieldSize(f Format, name string, value interface{}) int {
+	var entry, empty []byte
+
+	h := f.handle()
+	if err := codec.NewEncoderBytes(&entry, h).Encode(map[string]interface{}{name: value}); err != nil {
+		panic(err)
+	}
+	if err := codec.NewEncoderBytes(&empty, h).Encode(map[string]interface{}{}); err != nil {
+		panic(err)
+	}
+
+	return len(entry) - len(empty)
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessage_Size(t *testing.T) {
+	msg := Message{
+		Type:    SimpleEventMessageType,
+		Source:  "dns:example.com",
+		Payload: []byte("hello"),
+	}
+
+	for _, f := range AllFormats() {
+		assert.Equal(t, len(MustEncode(&msg, f)), msg.Size(f), f.String())
+	}
+
+	assert.Panics(t, func() {
+		msg.Size(Format(-1))
+	})
+}
+
+func TestMessage_Breakdown(t *testing.T) {
+	status := int64(200)
+
+	tests := []struct {
+		desc string
+		msg  Message
+	}{
+		{
+			desc: "minimal",
+			msg:  Message{Type: SimpleEventMessageType},
+		}, {
+			desc: "typical",
+			msg: Message{
+				Type:             SimpleRequestResponseMessageType,
+				Source:           "dns:example.com",
+				Destination:      "mac:112233445566/config",
+				TransactionUUID:  "546514d4-9cb6-41c9-88ca-ccd4c130c525",
+				ContentType:      "application/json",
+				Status:           &status,
+				Headers:          []string{"X-Test: 1", "X-Other: 2"},
+				Metadata:         map[string]string{"/boot-time": "1700000000", "/reason": "ok"},
+				Payload:          []byte(strings.Repeat("x", 1000)),
+				PartnerIDs:       []string{"comcast"},
+				QualityOfService: 42,
+			},
+		}, {
+			desc: "binary payload",
+			msg: Message{
+				Type:    SimpleEventMessageType,
+				Payload: []byte{0x00, 0xff, 0x80, 0x7f},
+			},
+		}, {
+			desc: "empty collections are omitted",
+			msg: Message{
+				Type:     SimpleEventMessageType,
+				Headers:  []string{},
+				Metadata: map[string]string{},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			for _, f := range AllFormats() {
+				assert := assert.New(t)
+				b := tc.msg.Breakdown(f)
+
+				assert.Equal(f, b.Format)
+				assert.Equal(tc.msg.Size(f), b.Total)
+
+				// the overhead is only the container, so the fields account
+				// for every other byte
+				switch f {
+				case Msgpack:
+					expected := 1
+					if len(b.Fields) > 15 {
+						expected = 3
+					}
+					assert.Equal(expected, b.Overhead, f.String())
+				case JSON:
+					assert.Equal(2+len(b.Fields)-1, b.Overhead, f.String())
+				}
+
+				for _, fs := range b.Fields {
+					assert.Positive(fs.Bytes)
+					assert.Equal(fs.Bytes, b.Bytes(fs.Field))
+				}
+			}
+		})
+	}
+}
+
+func TestBreakdown_Bytes(t *testing.T) {
+	assert := assert.New(t)
+
+	msg := Message{
+		Type:    SimpleEventMessageType,
+		Payload: []byte(strings.Repeat("x", 100)),
+	}
+
+	b := msg.Breakdown(Msgpack)
+	assert.Zero(b.Bytes(MetadataField))
+	assert.Equal(len("payload")+1+2+100, b.Bytes(PayloadField))
+	assert.Equal(len("msg_type")+1+1, b.Bytes(TypeField))
+	assert.Equal(len("qos")+1+1, b.Bytes(QualityOfServiceField))
+}
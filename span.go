@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// spanLength is the number of elements in the wire form of a span.
+const spanLength = 5
+
+var ErrInvalidSpan = errors.New("invalid span")
+
+// Span is the structured form of a single element of Message.Spans, which
+// records the timing of an operation performed on a message.
+type Span struct {
+	// Parent is the name of the span that this span is a part of.
+	Parent string
+
+	// Name is the name of the operation.
+	Name string
+
+	// Start is when the operation started.  It is sent with microsecond
+	// precision.
+	Start time.Time
+
+	// Duration is how long the operation took.  It is sent with microsecond
+	// precision.
+	Duration time.Duration
+
+	// Status is the outcome of the operation.
+	Status int64
+}
+
+// ParseSpan converts the wire form of a span, a list of parent, name, start
+// time, duration and status, into a Span.  The start time is in microseconds
+// since the Unix epoch and the duration is in microseconds.
+func ParseSpan(s []string) (Span, error) {
+	if len(s) != spanLength {
+		return Span{}, fmt.Errorf("%w: expected %d elements, got %d", ErrInvalidSpan, spanLength, len(s))
+	}
+
+	start, err := strconv.ParseInt(s[2], 10, 64)
+	if err != nil {
+		return Span{}, fmt.Errorf("%w: invalid start time: %w", ErrInvalidSpan, err)
+	}
+
+	duration, err := strconv.ParseInt(s[3], 10, 64)
+	if err != nil {
+		return Span{}, fmt.Errorf("%w: invalid duration: %w", ErrInvalidSpan, err)
+	}
+
+	status, err := strconv.ParseInt(s[4], 10, 64)
+	if err != nil {
+		return Span{}, fmt.Errorf("%w: invalid status: %w", ErrInvalidSpan, err)
+	}
+
+	span := Span{
+		Parent:   s[0],
+		Name:     s[1],
+		Start:    time.UnixMicro(start).UTC(),
+		Duration: time.Duration(duration) * time.Microsecond,
+		Status:   status,
+	}
+
+	if err := span.Validate(); err != nil {
+		return Span{}, err
+	}
+
+	return span, nil
+}
+
+// Validate checks that the span has a parent and a name and that its duration
+// is not negative.
+func (s Span) Validate() error {
+	switch {
+	case s.Parent == "":
+		return fmt.Errorf("%w: empty parent", ErrInvalidSpan)
+	case s.Name == "":
+		return fmt.Errorf("%w: empty name", ErrInvalidSpan)
+	case s.Duration < 0:
+		return fmt.Errorf("%w: negative duration %s", ErrInvalidSpan, s.Duration)
+	}
+
+	return nil
+}
+
+// Strings returns the wire form of the span, see ParseSpan.
+func (s Span) Strings() []string {
+	return []string{
+		s.Parent,
+		s.Name,
+		strconv.FormatInt(s.Start.UnixMicro(), 10),
+		strconv.FormatInt(s.Duration.Microseconds(), 10),
+		strconv.FormatInt(s.Status, 10),
+	}
+}
+
+// ParsedSpans returns the message's Spans as Span structs.  An error wrapping
+// ErrInvalidSpan is returned if any span is malformed.
+func (msg *Message) ParsedSpans() ([]Span, error) {
+	if len(msg.Spans) == 0 { // nolint:staticcheck
+		return nil, nil
+	}
+
+	spans := make([]Span, 0, len(msg.Spans)) // nolint:staticcheck
+	for i, s := range msg.Spans {            // nolint:staticcheck
+		span, err := ParseSpan(s)
+		if err != nil {
+			return nil, fmt.Errorf("span %d: %w", i, err)
+		}
+		spans = append(spans, span)
+	}
+
+	return spans, nil
+}
+
+// SetSpans replaces the message's Spans with the wire form of the given spans.
+func (msg *Message) SetSpans(spans ...Span) *Message {
+	msg.Spans = nil // nolint:staticcheck
+	return msg.AppendSpans(spans...)
+}
+
+// AppendSpans adds the wire form of the given spans to the message's Spans.
+func (msg *Message) AppendSpans(spans ...Span) *Message {
+	for _, s := range spans {
+		msg.Spans = append(msg.Spans, s.Strings()) // nolint:staticcheck
+	}
+
+	return msg
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSpan(t *testing.T) {
+	tests := []struct {
+		desc        string
+		wire        []string
+		expected    Span
+		expectedErr bool
+	}{
+		{
+			desc: "valid",
+			wire: []string{"parent", "name", "1700000000123456", "1500", "200"},
+			expected: Span{
+				Parent:   "parent",
+				Name:     "name",
+				Start:    time.Date(2023, time.November, 14, 22, 13, 20, 123456000, time.UTC),
+				Duration: 1500 * time.Microsecond,
+				Status:   200,
+			},
+		},
+		{desc: "too short", wire: []string{"parent", "name", "1", "2"}, expectedErr: true},
+		{desc: "too long", wire: []string{"parent", "name", "1", "2", "3", "4"}, expectedErr: true},
+		{desc: "empty parent", wire: []string{"", "name", "1", "2", "3"}, expectedErr: true},
+		{desc: "empty name", wire: []string{"parent", "", "1", "2", "3"}, expectedErr: true},
+		{desc: "invalid start", wire: []string{"parent", "name", "x", "2", "3"}, expectedErr: true},
+		{desc: "invalid duration", wire: []string{"parent", "name", "1", "x", "3"}, expectedErr: true},
+		{desc: "negative duration", wire: []string{"parent", "name", "1", "-2", "3"}, expectedErr: true},
+		{desc: "invalid status", wire: []string{"parent", "name", "1", "2", "x"}, expectedErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			span, err := ParseSpan(tc.wire)
+			if tc.expectedErr {
+				assert.ErrorIs(err, ErrInvalidSpan)
+				assert.Equal(Span{}, span)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(tc.expected, span)
+			assert.Equal(tc.wire, span.Strings())
+		})
+	}
+}
+
+func TestMessageSpans(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var msg Message
+	spans, err := msg.ParsedSpans()
+	assert.NoError(err)
+	assert.Nil(spans)
+
+	first := Span{Parent: "p", Name: "a", Start: time.UnixMicro(10).UTC(), Duration: time.Millisecond, Status: 0}
+	second := Span{Parent: "p", Name: "b", Start: time.UnixMicro(20).UTC(), Duration: time.Second, Status: 1}
+
+	msg.SetSpans(first).AppendSpans(second)
+	assert.Equal([][]string{{"p", "a", "10", "1000", "0"}, {"p", "b", "20", "1000000", "1"}}, msg.Spans) // nolint:staticcheck
+
+	spans, err = msg.ParsedSpans()
+	require.NoError(err)
+	assert.Equal([]Span{first, second}, spans)
+
+	msg.SetSpans(second)
+	spans, err = msg.ParsedSpans()
+	require.NoError(err)
+	assert.Equal([]Span{second}, spans)
+
+	// the existing wire form is interoperable
+	spans, err = testConvertMessage(SimpleRequestResponseMessageType).ParsedSpans()
+	require.NoError(err)
+	assert.Len(spans, 1)
+
+	msg.Spans = append(msg.Spans, []string{"bad"}) // nolint:staticcheck
+	spans, err = msg.ParsedSpans()
+	assert.ErrorIs(err, ErrInvalidSpan)
+	assert.Nil(spans)
+}
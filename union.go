@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CheckUnionType returns an error wrapping ErrMessageTypeMismatch if mt is not
+// one of the allowed message types.  It is used by code generated by
+// cmd/wrpgen.
+func CheckUnionType(mt MessageType, allowed ...MessageType) error {
+	names := make([]string, len(allowed))
+	for i, a := range allowed {
+		if mt == a {
+			return nil
+		}
+		names[i] = friendlyName(a)
+	}
+
+	return newError(CodeInvalidMessageType, "Type",
+		fmt.Errorf("%w: %s is not %s", ErrMessageTypeMismatch, friendlyName(mt), strings.Join(names, " or ")))
+}
+
+// CheckUnionFields returns an error wrapping ErrRequiredFieldsMissing if any
+// of the named Message fields are missing from a view of type mt.  It returns
+// nil if there are no missing fields.  It is used by code generated by
+// cmd/wrpgen.
+func CheckUnionFields(mt MessageType, missing ...string) error {
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return fieldsError(CodeMissingField, ErrRequiredFieldsMissing, mt, missing...)
+}
@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckUnionType(t *testing.T) {
+	assert.NoError(t, CheckUnionType(UpdateMessageType, CreateMessageType, UpdateMessageType))
+
+	err := CheckUnionType(DeleteMessageType, CreateMessageType, UpdateMessageType)
+	assert.ErrorIs(t, err, ErrMessageTypeMismatch)
+	assert.ErrorIs(t, err, &Error{Code: CodeInvalidMessageType, Field: "Type"})
+	assert.EqualError(t, err, "message type does not match the target type: Delete is not Create or Update")
+}
+
+func TestCheckUnionFields(t *testing.T) {
+	assert.NoError(t, CheckUnionFields(SimpleEventMessageType))
+
+	err := CheckUnionFields(SimpleEventMessageType, "Source")
+	assert.ErrorIs(t, err, ErrRequiredFieldsMissing)
+	assert.Equal(t, "Source", ErrorFieldOf(err))
+
+	err = CheckUnionFields(SimpleEventMessageType, "Source", "Destination")
+	assert.Equal(t, CodeMissingField, ErrorCodeOf(err))
+	assert.Empty(t, ErrorFieldOf(err))
+}
@@ -15,7 +15,8 @@ var (
 	ErrUnexpectedKind = errors.New("a struct or non-nil pointer to struct is required")
 )
 
-// UTF8 takes any struct verifies that it contains UTF-8 strings.
+// UTF8 takes any struct verifies that it contains UTF-8 strings.  An invalid
+// string results in an *Error with the code CodeNotUTF8.
 func UTF8(v interface{}) error {
 	value := reflect.ValueOf(v)
 	if value.Kind() == reflect.Ptr && !value.IsNil() {
@@ -39,7 +40,7 @@ func UTF8(v interface{}) error {
 
 		if s, ok := f.Interface().(string); ok {
 			if !utf8.ValidString(s) {
-				return fmt.Errorf("%w: '%s:%v'", ErrNotUTF8, ft.Name, s)
+				return newError(CodeNotUTF8, ft.Name, fmt.Errorf("%w: '%s:%v'", ErrNotUTF8, ft.Name, s))
 			}
 		}
 	}
@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// UTF8Policy determines how a UTF8Sanitizer handles a string that is not
+// valid UTF-8.
+type UTF8Policy int
+
+const (
+	// UTF8Reject rejects the message with an error wrapping ErrNotUTF8.  This
+	// is the zero value.
+	UTF8Reject UTF8Policy = iota
+
+	// UTF8Repair replaces each invalid byte sequence with the Unicode
+	// replacement character, U+FFFD.
+	UTF8Repair
+
+	// UTF8Strip removes each invalid byte sequence.
+	UTF8Strip
+)
+
+// UTF8Sanitizer checks the strings of a message for invalid UTF-8, with a
+// separate UTF8Policy for each group of fields.  Unlike UTF8, which only
+// checks the top level string fields, every string in the message is checked,
+// including the elements of Headers, PartnerIDs and Metadata.
+//
+// The zero value rejects invalid UTF-8 in all fields.
+type UTF8Sanitizer struct {
+	// Routing applies to Source, Destination, TransactionUUID, Path,
+	// ServiceName, URL, SessionID and PartnerIDs.
+	Routing UTF8Policy
+
+	// Headers applies to Headers.
+	Headers UTF8Policy
+
+	// Metadata applies to the keys and values of Metadata.
+	Metadata UTF8Policy
+
+	// Payload applies to the fields describing the payload, ContentType and
+	// Accept.  The Payload itself is binary and is never checked.
+	Payload UTF8Policy
+}
+
+var _ Modifier = UTF8Sanitizer{}
+
+// Sanitize applies the policies to the message in place.  The first string
+// that is rejected stops processing and the error is returned, in which case
+// the message may have been partially repaired.
+//
+// Slices and maps are replaced rather than modified, so other references to
+// the message's original Headers, PartnerIDs and Metadata are unaffected.
+func (s UTF8Sanitizer) Sanitize(msg *Message) error {
+	routing := []struct {
+		name  string
+		value *string
+	}{
+		{"Source", &msg.Source},
+		{"Destination", &msg.Destination},
+		{"TransactionUUID", &msg.TransactionUUID},
+		{"Path", &msg.Path},
+		{"ServiceName", &msg.ServiceName},
+		{"URL", &msg.URL},
+		{"SessionID", &msg.SessionID},
+	}
+
+	for _, f := range routing {
+		if err := sanitizeUTF8(f.name, f.value, s.Routing); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range []struct {
+		name  string
+		value *string
+	}{
+		{"ContentType", &msg.ContentType},
+		{"Accept", &msg.Accept},
+	} {
+		if err := sanitizeUTF8(f.name, f.value, s.Payload); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if msg.PartnerIDs, err = sanitizeUTF8Slice("PartnerIDs", msg.PartnerIDs, s.Routing); err != nil {
+		return err
+	}
+
+	if msg.Headers, err = sanitizeUTF8Slice("Headers", msg.Headers, s.Headers); err != nil {
+		return err
+	}
+
+	msg.Metadata, err = sanitizeUTF8Map("Metadata", msg.Metadata, s.Metadata)
+	return err
+}
+
+// ModifyWRP returns a sanitized copy of the message.  The original message is
+// not modified.
+func (s UTF8Sanitizer) ModifyWRP(_ context.Context, msg Message) (Message, error) {
+	original := msg
+	if err := s.Sanitize(&msg); err != nil {
+		return original, err
+	}
+
+	return msg, nil
+}
+
+// repairUTF8 applies the policy to a single string of the named field.  The
+// name describes the string within the field, e.g. an element of a slice.  The
+// returned bool is true if the string was changed.
+func repairUTF8(field, name, s string, p UTF8Policy) (string, bool, error) {
+	if utf8.ValidString(s) {
+		return s, false, nil
+	}
+
+	switch p {
+	case UTF8Repair:
+		return strings.ToValidUTF8(s, string(utf8.RuneError)), true, nil
+	case UTF8Strip:
+		return strings.ToValidUTF8(s, ""), true, nil
+	default:
+		return s, false, newError(CodeNotUTF8, field, fmt.Errorf("%w: '%s:%v'", ErrNotUTF8, name, s))
+	}
+}
+
+func sanitizeUTF8(name string, s *string, p UTF8Policy) error {
+	v, changed, err := repairUTF8(name, name, *s, p)
+	if changed {
+		*s = v
+	}
+	return err
+}
+
+// sanitizeUTF8Slice returns the slice itself if nothing changed, or a repaired
+// copy.
+func sanitizeUTF8Slice(name string, s []string, p UTF8Policy) ([]string, error) {
+	var repaired []string
+	for i, v := range s {
+		v, changed, err := repairUTF8(name, fmt.Sprintf("%s[%d]", name, i), v, p)
+		if err != nil {
+			return s, err
+		}
+
+		if changed {
+			if repaired == nil {
+				repaired = slices.Clone(s)
+			}
+			repaired[i] = v
+		}
+	}
+
+	if repaired == nil {
+		return s, nil
+	}
+	return repaired, nil
+}
+
+// sanitizeUTF8Map returns the map itself if nothing changed, or a repaired
+// copy.  If repairing two keys results in the same key, one of the values is
+// kept.
+func sanitizeUTF8Map(name string, m map[string]string, p UTF8Policy) (map[string]string, error) {
+	var repaired map[string]string
+	for k, v := range m {
+		rk, keyChanged, err := repairUTF8(name, name+" key", k, p)
+		if err != nil {
+			return m, err
+		}
+
+		rv, valueChanged, err := repairUTF8(name, name+"["+rk+"]", v, p)
+		if err != nil {
+			return m, err
+		}
+
+		if keyChanged || valueChanged {
+			if repaired == nil {
+				repaired = maps.Clone(m)
+			}
+			delete(repaired, k)
+			repaired[rk] = rv
+		}
+	}
+
+	if repaired == nil {
+		return m, nil
+	}
+	return repaired, nil
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const invalidUTF8 = "a\xffb"
+
+func TestUTF8Sanitizer(t *testing.T) {
+	tests := []struct {
+		desc      string
+		sanitizer UTF8Sanitizer
+		msg       Message
+		expected  Message
+		expectErr error
+	}{
+		{
+			desc:     "valid message",
+			msg:      Message{Source: "dns:example.com", Metadata: map[string]string{"/k": "v"}},
+			expected: Message{Source: "dns:example.com", Metadata: map[string]string{"/k": "v"}},
+		}, {
+			desc:      "reject routing",
+			msg:       Message{Source: invalidUTF8},
+			expectErr: ErrNotUTF8,
+		}, {
+			desc:      "reject partner id",
+			sanitizer: UTF8Sanitizer{Headers: UTF8Repair, Metadata: UTF8Repair, Payload: UTF8Repair},
+			msg:       Message{PartnerIDs: []string{"ok", invalidUTF8}},
+			expectErr: ErrNotUTF8,
+		}, {
+			desc:      "repair routing",
+			sanitizer: UTF8Sanitizer{Routing: UTF8Repair},
+			msg:       Message{Destination: invalidUTF8, PartnerIDs: []string{invalidUTF8}},
+			expected:  Message{Destination: "a�b", PartnerIDs: []string{"a�b"}},
+		}, {
+			desc:      "strip headers",
+			sanitizer: UTF8Sanitizer{Headers: UTF8Strip},
+			msg:       Message{Headers: []string{"X-Test: " + invalidUTF8}},
+			expected:  Message{Headers: []string{"X-Test: ab"}},
+		}, {
+			desc:      "reject headers",
+			sanitizer: UTF8Sanitizer{Routing: UTF8Repair},
+			msg:       Message{Headers: []string{invalidUTF8}},
+			expectErr: ErrNotUTF8,
+		}, {
+			desc:      "repair metadata keys and values",
+			sanitizer: UTF8Sanitizer{Metadata: UTF8Repair},
+			msg:       Message{Metadata: map[string]string{invalidUTF8: "v", "/k": invalidUTF8}},
+			expected:  Message{Metadata: map[string]string{"a�b": "v", "/k": "a�b"}},
+		}, {
+			desc:      "reject metadata value",
+			msg:       Message{Metadata: map[string]string{"/k": invalidUTF8}},
+			expectErr: ErrNotUTF8,
+		}, {
+			desc:      "strip content type",
+			sanitizer: UTF8Sanitizer{Payload: UTF8Strip},
+			msg:       Message{ContentType: invalidUTF8, Accept: invalidUTF8, Payload: []byte{0xff}},
+			expected:  Message{ContentType: "ab", Accept: "ab", Payload: []byte{0xff}},
+		}, {
+			desc:      "reject content type",
+			sanitizer: UTF8Sanitizer{Routing: UTF8Strip},
+			msg:       Message{ContentType: invalidUTF8},
+			expectErr: ErrNotUTF8,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			msg := tc.msg
+			err := tc.sanitizer.Sanitize(&msg)
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, msg)
+		})
+	}
+}
+
+func TestUTF8Sanitizer_ModifyWRP(t *testing.T) {
+	assert := assert.New(t)
+	s := UTF8Sanitizer{Headers: UTF8Repair, Metadata: UTF8Strip}
+
+	msg := Message{
+		Headers:  []string{"ok", invalidUTF8},
+		Metadata: map[string]string{"/k": invalidUTF8},
+	}
+
+	got, err := s.ModifyWRP(context.Background(), msg)
+	assert.NoError(err)
+	assert.Equal([]string{"ok", "a�b"}, got.Headers)
+	assert.Equal(map[string]string{"/k": "ab"}, got.Metadata)
+
+	// the original message is untouched
+	assert.Equal([]string{"ok", invalidUTF8}, msg.Headers)
+	assert.Equal(map[string]string{"/k": invalidUTF8}, msg.Metadata)
+
+	msg.Source = invalidUTF8
+	got, err = s.ModifyWRP(context.Background(), msg)
+	assert.ErrorIs(err, ErrNotUTF8)
+	assert.Equal(msg, got)
+}
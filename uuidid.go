@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UUID returns the parsed UUID of a uuid scheme device identifier.  false is
+// returned if the identifier does not use the uuid scheme or its identifier
+// portion is not a valid UUID.
+func (id DeviceID) UUID() (uuid.UUID, bool) {
+	prefix, idPart := id.split()
+	if prefix != SchemeUUID {
+		return uuid.Nil, false
+	}
+
+	u, err := uuid.Parse(idPart)
+	if err != nil {
+		return uuid.Nil, false
+	}
+
+	return u, true
+}
+
+// UUIDVersion returns the version of a uuid scheme device identifier, e.g. 4
+// for a random UUID or 7 for a time ordered UUID.
+func (id DeviceID) UUIDVersion() (int, bool) {
+	u, ok := id.UUID()
+	if !ok {
+		return 0, false
+	}
+
+	return int(u.Version()), true
+}
+
+// UUIDTime returns the timestamp embedded in a uuid scheme device identifier.
+// Only version 1, 6 and 7 UUIDs have a timestamp; false is returned for other
+// versions.  Version 7 timestamps have millisecond precision.
+func (id DeviceID) UUIDTime() (time.Time, bool) {
+	u, ok := id.UUID()
+	if !ok || !hasUUIDTime(u) {
+		return time.Time{}, false
+	}
+
+	sec, nsec := uuidTime(u).UnixTime()
+	return time.Unix(sec, nsec).UTC(), true
+}
+
+// TimeOrderedKey returns a key for a uuid scheme device identifier with an
+// embedded timestamp whose lexical order is the order of the timestamps,
+// suitable for keying archives of messages by device.  The key is the
+// timestamp, in 100ns intervals since the start of the Gregorian calendar, as
+// 16 hex digits followed by the 32 hex digits of the UUID.  false is returned
+// for identifiers without a timestamp, see UUIDTime.
+func (id DeviceID) TimeOrderedKey() (string, bool) {
+	u, ok := id.UUID()
+	if !ok || !hasUUIDTime(u) {
+		return "", false
+	}
+
+	return fmt.Sprintf("%016x%s", uint64(uuidTime(u)), hex.EncodeToString(u[:])), true
+}
+
+// CompareDeviceIDs provides a total ordering of device identifiers, returning
+// -1, 0 or +1 as with strings.Compare.  UUIDs with an embedded timestamp come
+// first, ordered by timestamp, followed by the other UUIDs in byte order and
+// then all other identifiers in lexical order.  Ties are broken by comparing
+// the identifiers as strings, so 0 is only returned for equal identifiers.
+//
+// CompareDeviceIDs can be used with slices.SortFunc.
+func CompareDeviceIDs(a, b DeviceID) int {
+	ua, aok := a.UUID()
+	ub, bok := b.UUID()
+
+	if c := cmp.Compare(uuidClass(ua, aok), uuidClass(ub, bok)); c != 0 {
+		return c
+	}
+
+	if aok && bok {
+		if hasUUIDTime(ua) {
+			if c := cmp.Compare(uuidTime(ua), uuidTime(ub)); c != 0 {
+				return c
+			}
+		}
+
+		if c := bytes.Compare(ua[:], ub[:]); c != 0 {
+			return c
+		}
+	}
+
+	return strings.Compare(string(a), string(b))
+}
+
+// uuidClass groups identifiers for CompareDeviceIDs.
+func uuidClass(u uuid.UUID, ok bool) int {
+	switch {
+	case !ok:
+		return 2
+	case hasUUIDTime(u):
+		return 0
+	default:
+		return 1
+	}
+}
+
+// uuidTime returns the timestamp of a version 1, 6 or 7 UUID.  uuid.UUID.Time
+// does not remove the version bits from version 6 timestamps, so they are
+// decoded here.
+func uuidTime(u uuid.UUID) uuid.Time {
+	if u.Version() != 6 {
+		return u.Time()
+	}
+
+	high := binary.BigEndian.Uint64(u[:8])
+	return uuid.Time((high>>16)<<12 | high&0x0fff)
+}
+
+func hasUUIDTime(u uuid.UUID) bool {
+	switch u.Version() {
+	case 1, 6, 7:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The example UUIDs from RFC 9562, all of which were created at the same time.
+const (
+	exampleUUIDv1 = DeviceID("uuid:c232ab00-9414-11ec-b3c8-9f6bdeced846")
+	exampleUUIDv4 = DeviceID("uuid:919108f7-52d1-4320-9bac-f847db4148a8")
+	exampleUUIDv6 = DeviceID("uuid:1ec9414c-232a-6b00-b3c8-9f6bdeced846")
+	exampleUUIDv7 = DeviceID("uuid:017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+)
+
+var exampleUUIDTime = time.Date(2022, time.February, 22, 19, 22, 22, 0, time.UTC)
+
+func TestDeviceIDUUID(t *testing.T) {
+	tests := []struct {
+		desc    string
+		id      DeviceID
+		version int
+		time    time.Time
+	}{
+		{desc: "v1", id: exampleUUIDv1, version: 1, time: exampleUUIDTime},
+		{desc: "v4", id: exampleUUIDv4, version: 4},
+		{desc: "v6", id: exampleUUIDv6, version: 6, time: exampleUUIDTime},
+		{desc: "v7", id: exampleUUIDv7, version: 7, time: exampleUUIDTime},
+		{desc: "no dashes", id: "uuid:017f22e279b07cc398c4dc0c0c07398f", version: 7, time: exampleUUIDTime},
+		{desc: "not a uuid", id: "mac:112233445566"},
+		{desc: "invalid uuid", id: "uuid:1234"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			version, ok := tc.id.UUIDVersion()
+			assert.Equal(tc.version != 0, ok)
+			assert.Equal(tc.version, version)
+
+			ts, ok := tc.id.UUIDTime()
+			assert.Equal(!tc.time.IsZero(), ok)
+			assert.True(tc.time.Equal(ts), "expected %s, got %s", tc.time, ts)
+
+			key, ok := tc.id.TimeOrderedKey()
+			assert.Equal(!tc.time.IsZero(), ok)
+			if ok {
+				assert.Len(key, 48)
+			}
+		})
+	}
+}
+
+func TestTimeOrderedKey(t *testing.T) {
+	// v1 UUIDs do not sort by time as strings, but their keys do
+	earlier := DeviceID("uuid:ffffffff-9414-11ec-b3c8-9f6bdeced846")
+	later := DeviceID("uuid:00000000-9415-11ec-b3c8-9f6bdeced846")
+	assert.Greater(t, string(earlier), string(later))
+
+	ek, _ := earlier.TimeOrderedKey()
+	lk, _ := later.TimeOrderedKey()
+	assert.Less(t, ek, lk)
+}
+
+func TestCompareDeviceIDs(t *testing.T) {
+	expected := []DeviceID{
+		"uuid:ffffffff-9414-11ec-b3c8-9f6bdeced846",
+		"uuid:00000000-9415-11ec-b3c8-9f6bdeced846",
+		"uuid:018f22e2-79b0-7cc3-98c4-dc0c0c07398f",
+		"uuid:00000000-0000-4000-8000-000000000000",
+		exampleUUIDv4,
+		"mac:112233445566",
+		"uuid:1234",
+	}
+
+	ids := slices.Clone(expected)
+	slices.Reverse(ids)
+	slices.SortFunc(ids, CompareDeviceIDs)
+	assert.Equal(t, expected, ids)
+
+	assert.Zero(t, CompareDeviceIDs(exampleUUIDv7, exampleUUIDv7))
+
+	// equal UUIDs in different forms are still ordered
+	upper := DeviceID("uuid:017F22E2-79B0-7CC3-98C4-DC0C0C07398F")
+	assert.Equal(t, -1, CompareDeviceIDs(upper, exampleUUIDv7))
+	assert.Equal(t, 1, CompareDeviceIDs(exampleUUIDv7, upper))
+}
@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var ErrNotStruct = errors.New("not a struct")
+
+// WireField describes how a single struct field is encoded.  The values are
+// derived from the json struct tags, which both the msgpack and JSON formats
+// use.
+type WireField struct {
+	// Name is the name of the field on the wire, e.g. "dest".
+	Name string `json:"name"`
+
+	// GoName is the name of the Go struct field, e.g. "Destination".
+	GoName string `json:"go_name"`
+
+	// Type is the language neutral type of the field: "int", "string",
+	// "bool" or "bytes", or a composite such as "array<string>" or
+	// "map<string,string>".
+	Type string `json:"type"`
+
+	// OmitEmpty is true if the field is left out of the encoding when it
+	// holds its zero value.
+	OmitEmpty bool `json:"omit_empty"`
+
+	// Nullable is true if the field is a pointer, so an unset value can be
+	// told apart from the zero value.
+	Nullable bool `json:"nullable"`
+}
+
+// WireSchema describes the encoding of a message struct.
+type WireSchema struct {
+	// Name is the name of the Go struct, e.g. "Message".
+	Name string `json:"name"`
+
+	// Fields are the encoded fields, in declaration order.
+	Fields []WireField `json:"fields"`
+}
+
+// WireSchemas returns the schemas of the message structs of this package:
+// Message, followed by the deprecated SimpleRequestResponse, SimpleEvent,
+// CRUD, ServiceRegistration, ServiceAlive and Unknown structs.  The result
+// can be marshaled to JSON for use by code generators and schema registries.
+func WireSchemas() []WireSchema {
+	values := []any{
+		Message{},
+		SimpleRequestResponse{}, // nolint:staticcheck
+		SimpleEvent{},           // nolint:staticcheck
+		CRUD{},                  // nolint:staticcheck
+		ServiceRegistration{},   // nolint:staticcheck
+		ServiceAlive{},          // nolint:staticcheck
+		Unknown{},               // nolint:staticcheck
+	}
+
+	schemas := make([]WireSchema, len(values))
+	for i, v := range values {
+		s, err := WireSchemaOf(v)
+		if err != nil {
+			panic(err)
+		}
+		schemas[i] = s
+	}
+
+	return schemas
+}
+
+// WireSchemaOf returns the schema of a struct, or a pointer to a struct, as
+// it is encoded by this package.  Unexported fields and fields tagged with
+// `json:"-"` are skipped.
+func WireSchemaOf(v any) (WireSchema, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return WireSchema{}, fmt.Errorf("%w: %T", ErrNotStruct, v)
+	}
+
+	s := WireSchema{
+		Name:   t.Name(),
+		Fields: make([]WireField, 0, t.NumField()),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = sf.Name
+		}
+
+		ft := sf.Type
+		nullable := ft.Kind() == reflect.Pointer
+		if nullable {
+			ft = ft.Elem()
+		}
+
+		s.Fields = append(s.Fields, WireField{
+			Name:      name,
+			GoName:    sf.Name,
+			Type:      wireType(ft),
+			OmitEmpty: hasTagOption(options, "omitempty"),
+			Nullable:  nullable,
+		})
+	}
+
+	return s, nil
+}
+
+func hasTagOption(options, option string) bool {
+	for options != "" {
+		var o string
+		o, options, _ = strings.Cut(options, ",")
+		if o == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// wireType returns the language neutral name of the type.
+func wireType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "int"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "uint"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Pointer:
+		return wireType(t.Elem())
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "bytes"
+		}
+		return "array<" + wireType(t.Elem()) + ">"
+	case reflect.Map:
+		return "map<" + wireType(t.Key()) + "," + wireType(t.Elem()) + ">"
+	case reflect.Struct:
+		return "struct<" + t.Name() + ">"
+	}
+
+	return t.Kind().String()
+}
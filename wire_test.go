@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWireSchemaOf(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s, err := WireSchemaOf(&Message{})
+	require.NoError(err)
+	assert.Equal("Message", s.Name)
+	require.Len(s.Fields, len(AllFields()))
+
+	for i, f := range AllFields() {
+		assert.Equal(f.String(), s.Fields[i].GoName)
+	}
+
+	assert.Equal(WireField{Name: "msg_type", GoName: "Type", Type: "int"}, s.Fields[0])
+	assert.Equal(WireField{Name: "dest", GoName: "Destination", Type: "string", OmitEmpty: true}, s.Fields[2])
+	assert.Equal(WireField{Name: "status", GoName: "Status", Type: "int", OmitEmpty: true, Nullable: true}, s.Fields[StatusField])
+	assert.Equal(WireField{Name: "metadata", GoName: "Metadata", Type: "map<string,string>", OmitEmpty: true}, s.Fields[MetadataField])
+	assert.Equal(WireField{Name: "spans", GoName: "Spans", Type: "array<array<string>>", OmitEmpty: true}, s.Fields[SpansField])
+	assert.Equal(WireField{Name: "payload", GoName: "Payload", Type: "bytes", OmitEmpty: true}, s.Fields[PayloadField])
+	assert.Equal(WireField{Name: "qos", GoName: "QualityOfService", Type: "int"}, s.Fields[QualityOfServiceField])
+}
+
+func TestWireSchemaOf_matchesEncoding(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var (
+		status  int64 = 200
+		include       = true
+		msg           = Message{
+			Type:                    SimpleRequestResponseMessageType,
+			Source:                  "dns:example.com",
+			Destination:             "mac:112233445566",
+			TransactionUUID:         "1234",
+			ContentType:             "text/plain",
+			Accept:                  "text/plain",
+			Status:                  &status,
+			RequestDeliveryResponse: &status,
+			Headers:                 []string{"a"},
+			Metadata:                map[string]string{"a": "b"},
+			Spans:                   [][]string{{"a"}},
+			IncludeSpans:            &include,
+			Path:                    "/a",
+			Payload:                 []byte("a"),
+			ServiceName:             "a",
+			URL:                     "a",
+			PartnerIDs:              []string{"a"},
+			SessionID:               "a",
+			QualityOfService:        1,
+		}
+	)
+
+	var encoded []byte
+	require.NoError(NewEncoderBytes(&encoded, JSON).Encode(&msg))
+
+	var keys map[string]any
+	require.NoError(json.Unmarshal(encoded, &keys))
+
+	s, err := WireSchemaOf(msg)
+	require.NoError(err)
+
+	var names []string
+	for _, f := range s.Fields {
+		names = append(names, f.Name)
+	}
+
+	for k := range keys {
+		assert.Contains(names, k)
+	}
+	assert.Len(keys, len(names))
+}
+
+func TestWireSchemaOf_invalid(t *testing.T) {
+	for _, v := range []any{nil, 1, "message", new(int)} {
+		_, err := WireSchemaOf(v)
+		assert.ErrorIs(t, err, ErrNotStruct)
+	}
+}
+
+func TestWireSchemaOf_tags(t *testing.T) {
+	type example struct {
+		Untagged string
+		Skipped  string  `json:"-"`
+		Options  float64 `json:",string,omitempty"`
+		Nested   struct{ A int }
+		internal int
+	}
+
+	s, err := WireSchemaOf(example{internal: 1})
+	require.NoError(t, err)
+	assert.Equal(t, WireSchema{
+		Name: "example",
+		Fields: []WireField{
+			{Name: "Untagged", GoName: "Untagged", Type: "string"},
+			{Name: "Options", GoName: "Options", Type: "float", OmitEmpty: true},
+			{Name: "Nested", GoName: "Nested", Type: "struct<>"},
+		},
+	}, s)
+}
+
+func TestWireSchemas(t *testing.T) {
+	assert := assert.New(t)
+
+	schemas := WireSchemas()
+
+	var names []string
+	for _, s := range schemas {
+		names = append(names, s.Name)
+		assert.NotEmpty(s.Fields)
+		assert.Equal("msg_type", s.Fields[0].Name)
+	}
+
+	assert.Equal([]string{
+		"Message",
+		"SimpleRequestResponse",
+		"SimpleEvent",
+		"CRUD",
+		"ServiceRegistration",
+		"ServiceAlive",
+		"Unknown",
+	}, names)
+
+	b, err := json.Marshal(schemas)
+	assert.NoError(err)
+	assert.Contains(string(b), `{"name":"dest","go_name":"Destination","type":"string","omit_empty":true,"nullable":false}`)
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpaws
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// MaxBatchEntries is the largest number of entries in an SQS or SNS batch.
+const MaxBatchEntries = 10
+
+// Entry is an entry of a batch, identified by an ID that is unique within the
+// batch.
+type Entry struct {
+	ID string
+	Message
+}
+
+// BatchSender sends a batch of entries, e.g. with SendMessageBatch for SQS or
+// PublishBatch for SNS.
+type BatchSender interface {
+	SendBatch(ctx context.Context, entries []Entry) error
+}
+
+// BatchSenderFunc is a function that implements BatchSender.
+type BatchSenderFunc func(context.Context, []Entry) error
+
+func (f BatchSenderFunc) SendBatch(ctx context.Context, entries []Entry) error {
+	return f(ctx, entries)
+}
+
+// Batches splits the entries, in order, into batches of at most
+// MaxBatchEntries entries whose total size is at most MaxMessageSize.  An entry
+// larger than MaxMessageSize is put in a batch of its own.
+func Batches(entries []Entry) [][]Entry {
+	var (
+		batches [][]Entry
+		start   int
+		size    int
+	)
+
+	for i, e := range entries {
+		n := e.Size()
+		if i > start && (i-start == MaxBatchEntries || size+n > MaxMessageSize) {
+			batches = append(batches, entries[start:i:i])
+			start, size = i, 0
+		}
+		size += n
+	}
+
+	if start < len(entries) {
+		batches = append(batches, entries[start:])
+	}
+
+	return batches
+}
+
+// Send marshals the messages and sends them in batches.  The ID of each entry
+// is the index of its message.  Sending stops at the first batch that fails.
+func (c *Codec) Send(ctx context.Context, s BatchSender, msgs ...*wrp.Message) error {
+	entries := make([]Entry, len(msgs))
+	for i, msg := range msgs {
+		m, err := c.Marshal(ctx, msg)
+		if err != nil {
+			return fmt.Errorf("message %d: %w", i, err)
+		}
+
+		entries[i] = Entry{ID: strconv.Itoa(i), Message: m}
+	}
+
+	for _, batch := range Batches(entries) {
+		if err := s.SendBatch(ctx, batch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpaws
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func sizedEntry(id string, size int) Entry {
+	return Entry{ID: id, Message: Message{Body: strings.Repeat("x", size)}}
+}
+
+func batchIDs(batches [][]Entry) [][]string {
+	ids := make([][]string, len(batches))
+	for i, b := range batches {
+		for _, e := range b {
+			ids[i] = append(ids[i], e.ID)
+		}
+	}
+
+	return ids
+}
+
+func TestBatches(t *testing.T) {
+	tests := []struct {
+		desc     string
+		entries  []Entry
+		expected [][]string
+	}{
+		{
+			desc: "empty",
+		}, {
+			desc: "entry limit",
+			entries: []Entry{
+				sizedEntry("0", 1), sizedEntry("1", 1), sizedEntry("2", 1), sizedEntry("3", 1),
+				sizedEntry("4", 1), sizedEntry("5", 1), sizedEntry("6", 1), sizedEntry("7", 1),
+				sizedEntry("8", 1), sizedEntry("9", 1), sizedEntry("10", 1),
+			},
+			expected: [][]string{{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}, {"10"}},
+		}, {
+			desc: "size limit",
+			entries: []Entry{
+				sizedEntry("0", MaxMessageSize/2),
+				sizedEntry("1", MaxMessageSize/2),
+				sizedEntry("2", 1),
+				sizedEntry("3", MaxMessageSize),
+			},
+			expected: [][]string{{"0", "1"}, {"2"}, {"3"}},
+		}, {
+			desc: "oversized entry",
+			entries: []Entry{
+				sizedEntry("0", MaxMessageSize+1),
+				sizedEntry("1", 1),
+			},
+			expected: [][]string{{"0"}, {"1"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			batches := Batches(tc.entries)
+			if tc.expected == nil {
+				assert.Empty(t, batches)
+				return
+			}
+
+			assert.Equal(t, tc.expected, batchIDs(batches))
+		})
+	}
+}
+
+func TestCodec_Send(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := NewCodec(OffloadPayloads(&memStore{}))
+	require.NoError(err)
+
+	msgs := make([]*wrp.Message, 12)
+	for i := range msgs {
+		msgs[i] = testMessage([]byte("payload"))
+	}
+	msgs[11] = testMessage(bytes.Repeat([]byte("x"), MaxMessageSize))
+
+	var batches [][]Entry
+	err = c.Send(context.Background(), BatchSenderFunc(func(_ context.Context, entries []Entry) error {
+		batches = append(batches, entries)
+		return nil
+	}), msgs...)
+	require.NoError(err)
+
+	assert.Equal([][]string{
+		{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"},
+		{"10", "11"},
+	}, batchIDs(batches))
+	assert.Contains(batches[1][1].Attributes, AttributePayloadRef)
+}
+
+func TestCodec_Send_errors(t *testing.T) {
+	errSend := errors.New("expected")
+	c, err := NewCodec()
+	require.NoError(t, err)
+
+	var calls int
+	s := BatchSenderFunc(func(context.Context, []Entry) error {
+		calls++
+		return errSend
+	})
+
+	msgs := make([]*wrp.Message, 11)
+	for i := range msgs {
+		msgs[i] = testMessage(nil)
+	}
+
+	assert.ErrorIs(t, c.Send(context.Background(), s, msgs...), errSend)
+	assert.Equal(t, 1, calls)
+
+	err = c.Send(context.Background(), s, testMessage(bytes.Repeat([]byte("x"), MaxMessageSize)))
+	assert.ErrorIs(t, err, ErrMessageTooLarge)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,237 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpaws
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// MaxMessageSize is the largest size of an SQS or SNS message, and of a batch
+// of messages, in bytes.
+const MaxMessageSize = 256 * 1024
+
+// The names of the message attributes set by a Codec.
+const (
+	AttributeContentType     = "wrp.content_type"
+	AttributeMessageType     = "wrp.msg_type"
+	AttributeTransactionUUID = "wrp.transaction_uuid"
+	AttributeDeviceID        = "wrp.device_id"
+	AttributeQOS             = "wrp.qos"
+	AttributePayloadRef      = "wrp.payload_ref"
+)
+
+// The data types of message attributes.
+const (
+	StringType = "String"
+	NumberType = "Number"
+)
+
+var (
+	ErrInvalidCodec     = errors.New("invalid codec configuration")
+	ErrMessageTooLarge  = errors.New("message too large")
+	ErrNoPayloadStore   = errors.New("offloaded payload without a payload store")
+	ErrInvalidAttribute = errors.New("invalid message attribute")
+)
+
+// Attribute is a message attribute.
+type Attribute struct {
+	// DataType is the type of the attribute, e.g. StringType or NumberType.
+	DataType string
+
+	// StringValue is the value of the attribute.
+	StringValue string
+}
+
+// Message is the body and message attributes of an SQS or SNS message.
+type Message struct {
+	Body       string
+	Attributes map[string]Attribute
+}
+
+// Size returns the size of the message as counted against MaxMessageSize: the
+// size of the body and of the name, data type and value of each attribute.
+func (m Message) Size() int {
+	n := len(m.Body)
+	for name, a := range m.Attributes {
+		n += len(name) + len(a.DataType) + len(a.StringValue)
+	}
+
+	return n
+}
+
+// PayloadStore stores the payloads of messages that are too large for SQS or
+// SNS, e.g. in S3.
+type PayloadStore interface {
+	// PutPayload stores the payload of the message and returns the reference
+	// used to retrieve it.
+	PutPayload(ctx context.Context, msg *wrp.Message) (string, error)
+
+	// GetPayload retrieves a payload stored by PutPayload.
+	GetPayload(ctx context.Context, ref string) ([]byte, error)
+}
+
+// CodecOption is a functional option for NewCodec.
+type CodecOption interface {
+	apply(*Codec) error
+}
+
+type codecOptionFunc func(*Codec) error
+
+func (f codecOptionFunc) apply(c *Codec) error {
+	return f(c)
+}
+
+// BodyFormat sets the format of the message bodies.  The default is wrp.JSON.
+func BodyFormat(f wrp.Format) CodecOption {
+	return codecOptionFunc(func(c *Codec) error {
+		if f != wrp.Msgpack && f != wrp.JSON {
+			return fmt.Errorf("%w: unknown format %s", ErrInvalidCodec, f)
+		}
+		c.format = f
+		return nil
+	})
+}
+
+// OffloadPayloads sets the store of the payloads of messages that would
+// otherwise be larger than MaxMessageSize.  By default, such messages result
+// in ErrMessageTooLarge.
+func OffloadPayloads(store PayloadStore) CodecOption {
+	return codecOptionFunc(func(c *Codec) error {
+		c.store = store
+		return nil
+	})
+}
+
+// Codec converts WRP messages to and from SQS and SNS messages.  A Codec is
+// safe for concurrent use if its PayloadStore is.
+type Codec struct {
+	format wrp.Format
+	store  PayloadStore
+}
+
+// NewCodec creates a Codec with the given options.
+func NewCodec(opts ...CodecOption) (*Codec, error) {
+	c := Codec{
+		format: wrp.JSON,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			if err := opt.apply(&c); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &c, nil
+}
+
+// Marshal converts a WRP message to an SQS or SNS message.  The device ID
+// attribute is the one returned by wrp.DeviceIDFromContext for the message.
+// If the message would be larger than MaxMessageSize, its payload is
+// offloaded to the PayloadStore.
+func (c *Codec) Marshal(ctx context.Context, msg *wrp.Message) (Message, error) {
+	m, err := c.marshal(ctx, msg)
+	if err != nil || m.Size() <= MaxMessageSize {
+		return m, err
+	}
+
+	if c.store == nil || len(msg.Payload) == 0 {
+		return Message{}, fmt.Errorf("%w: %d bytes", ErrMessageTooLarge, m.Size())
+	}
+
+	ref, err := c.store.PutPayload(ctx, msg)
+	if err != nil {
+		return Message{}, err
+	}
+
+	stripped := *msg
+	stripped.Payload = nil
+	if m, err = c.marshal(ctx, &stripped); err != nil {
+		return Message{}, err
+	}
+
+	m.Attributes[AttributePayloadRef] = Attribute{DataType: StringType, StringValue: ref}
+	if m.Size() > MaxMessageSize {
+		return Message{}, fmt.Errorf("%w: %d bytes without the payload", ErrMessageTooLarge, m.Size())
+	}
+
+	return m, nil
+}
+
+func (c *Codec) marshal(ctx context.Context, msg *wrp.Message) (Message, error) {
+	var encoded []byte
+	if err := wrp.NewEncoderBytes(&encoded, c.format).Encode(msg); err != nil {
+		return Message{}, err
+	}
+
+	m := Message{
+		Body: string(encoded),
+		Attributes: map[string]Attribute{
+			AttributeContentType: {DataType: StringType, StringValue: c.format.ContentType()},
+			AttributeMessageType: {DataType: StringType, StringValue: msg.Type.FriendlyName()},
+			AttributeQOS:         {DataType: NumberType, StringValue: strconv.Itoa(int(msg.QualityOfService))},
+		},
+	}
+
+	if c.format == wrp.Msgpack {
+		m.Body = base64.StdEncoding.EncodeToString(encoded)
+	}
+
+	if msg.TransactionUUID != "" {
+		m.Attributes[AttributeTransactionUUID] = Attribute{DataType: StringType, StringValue: msg.TransactionUUID}
+	}
+
+	if id, ok := wrp.DeviceIDFromContext(wrp.ContextWithMessage(ctx, msg)); ok {
+		m.Attributes[AttributeDeviceID] = Attribute{DataType: StringType, StringValue: string(id)}
+	}
+
+	return m, nil
+}
+
+// Unmarshal converts an SQS or SNS message to a WRP message.  The format of
+// the body is taken from its content type attribute, and defaults to the
+// Codec's format.  An offloaded payload is loaded from the PayloadStore.
+func (c *Codec) Unmarshal(ctx context.Context, m Message) (*wrp.Message, error) {
+	f := c.format
+	if a, ok := m.Attributes[AttributeContentType]; ok {
+		var err error
+		if f, err = wrp.FormatFromContentType(a.StringValue); err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidAttribute, AttributeContentType, err)
+		}
+	}
+
+	encoded := []byte(m.Body)
+	if f == wrp.Msgpack {
+		var err error
+		if encoded, err = base64.StdEncoding.DecodeString(m.Body); err != nil {
+			return nil, err
+		}
+	}
+
+	var msg wrp.Message
+	if err := wrp.NewDecoderBytes(encoded, f).Decode(&msg); err != nil {
+		return nil, err
+	}
+
+	if a, ok := m.Attributes[AttributePayloadRef]; ok {
+		if c.store == nil {
+			return nil, ErrNoPayloadStore
+		}
+
+		payload, err := c.store.GetPayload(ctx, a.StringValue)
+		if err != nil {
+			return nil, err
+		}
+		msg.Payload = payload
+	}
+
+	return &msg, nil
+}
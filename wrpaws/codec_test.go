@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpaws
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// memStore is a PayloadStore that keeps the payloads in memory.
+type memStore struct {
+	payloads map[string][]byte
+	err      error
+}
+
+func (s *memStore) PutPayload(_ context.Context, msg *wrp.Message) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+
+	if s.payloads == nil {
+		s.payloads = make(map[string][]byte)
+	}
+
+	ref := "s3://payloads/" + msg.TransactionUUID
+	s.payloads[ref] = msg.Payload
+	return ref, nil
+}
+
+func (s *memStore) GetPayload(_ context.Context, ref string) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return s.payloads[ref], nil
+}
+
+func testMessage(payload []byte) *wrp.Message {
+	return &wrp.Message{
+		Type:             wrp.SimpleRequestResponseMessageType,
+		Source:           "dns:talaria.example.com",
+		Destination:      "mac:112233445566/config",
+		TransactionUUID:  "123e4567-e89b-12d3-a456-426614174000",
+		QualityOfService: wrp.QOSHighValue,
+		Payload:          payload,
+	}
+}
+
+func TestCodec(t *testing.T) {
+	for _, f := range wrp.AllFormats() {
+		t.Run(f.String(), func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			c, err := NewCodec(BodyFormat(f), nil)
+			require.NoError(err)
+
+			msg := testMessage([]byte("payload"))
+			m, err := c.Marshal(context.Background(), msg)
+			require.NoError(err)
+
+			assert.Equal(map[string]Attribute{
+				AttributeContentType:     {DataType: StringType, StringValue: f.ContentType()},
+				AttributeMessageType:     {DataType: StringType, StringValue: "SimpleRequestResponse"},
+				AttributeTransactionUUID: {DataType: StringType, StringValue: msg.TransactionUUID},
+				AttributeDeviceID:        {DataType: StringType, StringValue: "mac:112233445566"},
+				AttributeQOS:             {DataType: NumberType, StringValue: "50"},
+			}, m.Attributes)
+
+			// the body is the same for any codec
+			decoded, err := (&Codec{format: wrp.JSON}).Unmarshal(context.Background(), m)
+			require.NoError(err)
+			assert.Equal(msg, decoded)
+		})
+	}
+}
+
+func TestCodec_deviceIDFromContext(t *testing.T) {
+	c, err := NewCodec()
+	require.NoError(t, err)
+
+	ctx := wrp.ContextWithDeviceID(context.Background(), "mac:665544332211")
+	m, err := c.Marshal(ctx, &wrp.Message{Type: wrp.SimpleEventMessageType})
+	require.NoError(t, err)
+	assert.Equal(t, "mac:665544332211", m.Attributes[AttributeDeviceID].StringValue)
+	assert.NotContains(t, m.Attributes, AttributeTransactionUUID)
+}
+
+func TestCodec_offload(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var store memStore
+	c, err := NewCodec(OffloadPayloads(&store))
+	require.NoError(err)
+
+	msg := testMessage(bytes.Repeat([]byte("x"), MaxMessageSize))
+	m, err := c.Marshal(context.Background(), msg)
+	require.NoError(err)
+	assert.LessOrEqual(m.Size(), MaxMessageSize)
+	assert.Equal("s3://payloads/"+msg.TransactionUUID, m.Attributes[AttributePayloadRef].StringValue)
+
+	decoded, err := c.Unmarshal(context.Background(), m)
+	require.NoError(err)
+	assert.Equal(msg, decoded)
+
+	// a message that fits is not offloaded
+	m, err = c.Marshal(context.Background(), testMessage([]byte("payload")))
+	require.NoError(err)
+	assert.NotContains(m.Attributes, AttributePayloadRef)
+	assert.Len(store.payloads, 1)
+}
+
+func TestCodec_errors(t *testing.T) {
+	errStore := errors.New("expected")
+	large := testMessage(bytes.Repeat([]byte("x"), MaxMessageSize))
+
+	tests := []struct {
+		desc     string
+		opts     []CodecOption
+		msg      *wrp.Message
+		expected error
+	}{
+		{
+			desc:     "too large",
+			msg:      large,
+			expected: ErrMessageTooLarge,
+		}, {
+			desc: "too large without the payload",
+			opts: []CodecOption{OffloadPayloads(&memStore{})},
+			msg: &wrp.Message{
+				Type:    wrp.SimpleEventMessageType,
+				Headers: []string{string(bytes.Repeat([]byte("x"), MaxMessageSize))},
+				Payload: []byte("payload"),
+			},
+			expected: ErrMessageTooLarge,
+		}, {
+			desc:     "store error",
+			opts:     []CodecOption{OffloadPayloads(&memStore{err: errStore})},
+			msg:      large,
+			expected: errStore,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			c, err := NewCodec(tc.opts...)
+			require.NoError(t, err)
+
+			m, err := c.Marshal(context.Background(), tc.msg)
+			assert.ErrorIs(t, err, tc.expected)
+			assert.Zero(t, m)
+		})
+	}
+}
+
+func TestCodec_Unmarshal_invalid(t *testing.T) {
+	errStore := errors.New("expected")
+	offloaded := map[string]Attribute{AttributePayloadRef: {DataType: StringType, StringValue: "ref"}}
+
+	tests := []struct {
+		desc     string
+		opts     []CodecOption
+		m        Message
+		expected error
+	}{
+		{
+			desc: "content type",
+			m: Message{
+				Body:       "{}",
+				Attributes: map[string]Attribute{AttributeContentType: {DataType: StringType, StringValue: "text/plain"}},
+			},
+			expected: ErrInvalidAttribute,
+		}, {
+			desc: "no payload store",
+			m: Message{
+				Body:       "{}",
+				Attributes: offloaded,
+			},
+			expected: ErrNoPayloadStore,
+		}, {
+			desc: "store error",
+			opts: []CodecOption{OffloadPayloads(&memStore{err: errStore})},
+			m: Message{
+				Body:       "{}",
+				Attributes: offloaded,
+			},
+			expected: errStore,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			c, err := NewCodec(tc.opts...)
+			require.NoError(t, err)
+
+			msg, err := c.Unmarshal(context.Background(), tc.m)
+			assert.ErrorIs(t, err, tc.expected)
+			assert.Nil(t, msg)
+		})
+	}
+
+	c, err := NewCodec(BodyFormat(wrp.Msgpack))
+	require.NoError(t, err)
+
+	_, err = c.Unmarshal(context.Background(), Message{Body: "!"})
+	assert.Error(t, err)
+
+	_, err = c.Unmarshal(context.Background(), Message{Body: "AQ=="})
+	assert.Error(t, err)
+}
+
+func TestNewCodec_invalid(t *testing.T) {
+	c, err := NewCodec(BodyFormat(wrp.Format(-1)))
+	assert.ErrorIs(t, err, ErrInvalidCodec)
+	assert.Nil(t, c)
+}
@@ -0,0 +1,20 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package wrpaws carries WRP messages over Amazon SQS and SNS.  It does not
+depend on the AWS SDK: a Message holds the body and message attributes of an
+SQS or SNS message, which the caller copies to and from the SDK's types.
+
+A Codec encodes a WRP message as the body of a Message, and copies the fields
+that are useful for routing and filtering, such as the device ID and the QOS,
+to message attributes.  Msgpack bodies are base64 encoded, since SQS and SNS
+bodies must be text.
+
+SQS and SNS messages are limited to MaxMessageSize bytes, including the
+attributes.  A Codec configured with a PayloadStore offloads the payloads of
+larger messages, e.g. to S3, and loads them again when the message is
+unmarshaled.  Send marshals messages and sends them in batches that honor the
+limits on the number of entries and the total size of a batch.
+*/
+package wrpaws
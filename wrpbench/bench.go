@@ -0,0 +1,263 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpbench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+	"time"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+var (
+	ErrBudgetExceeded = errors.New("performance budget exceeded")
+)
+
+// Operation identifies the codec operation being measured.
+type Operation int
+
+const (
+	Encode Operation = iota
+	Decode
+)
+
+// String returns the name of the operation.
+func (o Operation) String() string {
+	switch o {
+	case Encode:
+		return "encode"
+	case Decode:
+		return "decode"
+	default:
+		return "unknown"
+	}
+}
+
+// B is the part of *testing.B used by the benchmark functions.  Taking an
+// interface keeps the testing package out of the binaries that import this
+// package.
+type B interface {
+	ReportAllocs()
+	ResetTimer()
+	SetBytes(n int64)
+	Skip(args ...any)
+	Fatal(args ...any)
+}
+
+// BenchmarkEncode encodes the messages of the corpus n times in the given
+// format, where b and n are usually a *testing.B and its N field.
+// Allocations are always reported.
+func BenchmarkEncode(b B, n int, c Corpus, f wrp.Format) {
+	benchmark(b, n, c, f, Encode)
+}
+
+// BenchmarkDecode decodes the messages of the corpus n times from the given
+// format, where b and n are usually a *testing.B and its N field.
+// Allocations are always reported.
+func BenchmarkDecode(b B, n int, c Corpus, f wrp.Format) {
+	benchmark(b, n, c, f, Decode)
+}
+
+func benchmark(b B, n int, c Corpus, f wrp.Format, op Operation) {
+	if len(c.Messages) == 0 {
+		b.Skip("empty corpus")
+		return
+	}
+
+	if err := run(b, n, c, f, op); err != nil {
+		b.Fatal(err)
+	}
+}
+
+// timer is the part of B that run uses, which Measure implements itself.
+type timer interface {
+	ReportAllocs()
+	ResetTimer()
+	SetBytes(n int64)
+}
+
+// run performs the operation n times on the messages of the non-empty corpus.
+func run(b timer, n int, c Corpus, f wrp.Format, op Operation) (err error) {
+	var (
+		encoded = c.Encoded(f)
+		size    int
+	)
+	for _, e := range encoded {
+		size += len(e)
+	}
+
+	labels := pprof.Labels(
+		"wrp_corpus", c.Name,
+		"wrp_format", f.String(),
+		"wrp_operation", op.String(),
+	)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(size / len(encoded)))
+	b.ResetTimer()
+
+	pprof.Do(context.Background(), labels, func(context.Context) {
+		switch op {
+		case Encode:
+			var output []byte
+			encoder := wrp.NewEncoderBytes(&output, f)
+			for i := 0; i < n && err == nil; i++ {
+				encoder.ResetBytes(&output)
+				err = encoder.Encode(&c.Messages[i%len(c.Messages)])
+			}
+		case Decode:
+			decoder := wrp.NewDecoderBytes(nil, f)
+			for i := 0; i < n && err == nil; i++ {
+				var msg wrp.Message
+				decoder.ResetBytes(encoded[i%len(encoded)])
+				err = decoder.Decode(&msg)
+			}
+		}
+	})
+
+	return err
+}
+
+// Result is the outcome of a single Measure call.
+type Result struct {
+	Corpus    string
+	Format    wrp.Format
+	Operation Operation
+
+	// N is the number of messages processed.
+	N int
+
+	NsPerOp     int64
+	AllocsPerOp int64
+	BytesPerOp  int64
+
+	// MessagesPerSecond is the throughput of the operation.
+	MessagesPerSecond float64
+}
+
+// String returns a summary of the result similar to the output of go test -bench.
+func (r Result) String() string {
+	return fmt.Sprintf("%s/%s/%s\t%d\t%d ns/op\t%.0f msg/s\t%d B/op\t%d allocs/op",
+		r.Corpus, r.Format, r.Operation, r.N, r.NsPerOp, r.MessagesPerSecond, r.BytesPerOp, r.AllocsPerOp)
+}
+
+// measureTime is how long Measure runs an operation for.
+const measureTime = time.Second
+
+// maxMeasureN is the most times Measure performs an operation.
+const maxMeasureN = 1_000_000_000
+
+// Measure runs the benchmark for the corpus, format and operation and
+// summarizes the outcome.  As with testing.Benchmark, the operation is
+// repeated more times on each run until a run lasts at least a second.  An
+// empty corpus, or one that cannot be encoded or decoded, results in a
+// Result with an N of zero.
+func Measure(c Corpus, f wrp.Format, op Operation) Result {
+	r := Result{
+		Corpus:    c.Name,
+		Format:    f,
+		Operation: op,
+	}
+
+	if len(c.Messages) == 0 {
+		return r
+	}
+
+	var m meter
+	for n := 1; ; {
+		if err := run(&m, n, c, f, op); err != nil {
+			return r
+		}
+
+		elapsed, allocs, bytes := m.stop()
+		if elapsed >= measureTime || n >= maxMeasureN {
+			r.N = n
+			r.NsPerOp = elapsed.Nanoseconds() / int64(n)
+			r.AllocsPerOp = int64(allocs) / int64(n)
+			r.BytesPerOp = int64(bytes) / int64(n)
+			if elapsed > 0 {
+				r.MessagesPerSecond = float64(n) / elapsed.Seconds()
+			}
+			return r
+		}
+
+		// aim 20% past the target, growing at least by one and at most 100x
+		next := 100 * n
+		if elapsed > 0 {
+			next = int(1.2 * float64(n) * float64(measureTime) / float64(elapsed))
+		}
+		n = min(max(next, n+1), 100*n, maxMeasureN)
+	}
+}
+
+// meter times the operations of a Measure run and counts their allocations.
+type meter struct {
+	start  time.Time
+	allocs uint64
+	bytes  uint64
+}
+
+func (m *meter) ReportAllocs()  {}
+func (m *meter) SetBytes(int64) {}
+
+func (m *meter) ResetTimer() {
+	runtime.GC()
+
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	m.allocs, m.bytes = ms.Mallocs, ms.TotalAlloc
+	m.start = time.Now()
+}
+
+// stop returns the time taken and the allocations made since the timer was
+// last reset.
+func (m *meter) stop() (time.Duration, uint64, uint64) {
+	elapsed := time.Since(m.start)
+
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return elapsed, ms.Mallocs - m.allocs, ms.TotalAlloc - m.bytes
+}
+
+// Budget describes the performance limits for a Result.  A zero value for
+// any field means that value is not checked.
+type Budget struct {
+	MaxNsPerOp     int64
+	MaxAllocsPerOp int64
+	MaxBytesPerOp  int64
+
+	// MinMessagesPerSecond is the minimum acceptable throughput.
+	MinMessagesPerSecond float64
+}
+
+// Check returns an error wrapping ErrBudgetExceeded describing every limit of
+// the budget the result exceeds, or nil if the result is within budget.
+func (r Result) Check(b Budget) error {
+	var exceeded []string
+
+	if b.MaxNsPerOp > 0 && r.NsPerOp > b.MaxNsPerOp {
+		exceeded = append(exceeded, fmt.Sprintf("%d ns/op > %d", r.NsPerOp, b.MaxNsPerOp))
+	}
+	if b.MaxAllocsPerOp > 0 && r.AllocsPerOp > b.MaxAllocsPerOp {
+		exceeded = append(exceeded, fmt.Sprintf("%d allocs/op > %d", r.AllocsPerOp, b.MaxAllocsPerOp))
+	}
+	if b.MaxBytesPerOp > 0 && r.BytesPerOp > b.MaxBytesPerOp {
+		exceeded = append(exceeded, fmt.Sprintf("%d B/op > %d", r.BytesPerOp, b.MaxBytesPerOp))
+	}
+	if b.MinMessagesPerSecond > 0 && r.MessagesPerSecond < b.MinMessagesPerSecond {
+		exceeded = append(exceeded, fmt.Sprintf("%.0f msg/s < %.0f", r.MessagesPerSecond, b.MinMessagesPerSecond))
+	}
+
+	if len(exceeded) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s/%s/%s: %s", ErrBudgetExceeded,
+		r.Corpus, r.Format, r.Operation, strings.Join(exceeded, ", "))
+}
@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpbench
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestOperation_String(t *testing.T) {
+	assert.Equal(t, "encode", Encode.String())
+	assert.Equal(t, "decode", Decode.String())
+	assert.Equal(t, "unknown", Operation(-1).String())
+}
+
+func TestResult_Check(t *testing.T) {
+	r := Result{
+		Corpus:            "small",
+		Format:            wrp.Msgpack,
+		Operation:         Encode,
+		N:                 1000,
+		NsPerOp:           500,
+		AllocsPerOp:       3,
+		BytesPerOp:        256,
+		MessagesPerSecond: 2000000,
+	}
+
+	tests := []struct {
+		desc     string
+		budget   Budget
+		exceeded bool
+	}{
+		{
+			desc: "empty budget",
+		}, {
+			desc: "within budget",
+			budget: Budget{
+				MaxNsPerOp:           500,
+				MaxAllocsPerOp:       3,
+				MaxBytesPerOp:        256,
+				MinMessagesPerSecond: 2000000,
+			},
+		}, {
+			desc:     "too slow",
+			budget:   Budget{MaxNsPerOp: 499},
+			exceeded: true,
+		}, {
+			desc:     "too many allocations",
+			budget:   Budget{MaxAllocsPerOp: 2},
+			exceeded: true,
+		}, {
+			desc:     "too many bytes",
+			budget:   Budget{MaxBytesPerOp: 128},
+			exceeded: true,
+		}, {
+			desc:     "too little throughput",
+			budget:   Budget{MinMessagesPerSecond: 3000000},
+			exceeded: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := r.Check(tc.budget)
+			if tc.exceeded {
+				assert.ErrorIs(t, err, ErrBudgetExceeded)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping measurement in short mode")
+	}
+
+	for _, op := range []Operation{Encode, Decode} {
+		r := Measure(Small(), wrp.Msgpack, op)
+
+		assert.Equal(t, "small", r.Corpus)
+		assert.Equal(t, wrp.Msgpack, r.Format)
+		assert.Equal(t, op, r.Operation)
+		assert.Positive(t, r.N)
+		assert.Positive(t, r.NsPerOp)
+		assert.Positive(t, r.MessagesPerSecond)
+		assert.NotEmpty(t, r.String())
+	}
+}
+
+// recorder is a B that records how the benchmark functions use it.
+type recorder struct {
+	skipped bool
+	failed  bool
+	bytes   int64
+}
+
+func (r *recorder) ReportAllocs()    {}
+func (r *recorder) ResetTimer()      {}
+func (r *recorder) SetBytes(n int64) { r.bytes = n }
+func (r *recorder) Skip(...any)      { r.skipped = true }
+func (r *recorder) Fatal(...any)     { r.failed = true }
+
+func TestBenchmark(t *testing.T) {
+	var r recorder
+	BenchmarkEncode(&r, 10, Small(), wrp.Msgpack)
+	BenchmarkDecode(&r, 10, Small(), wrp.JSON)
+	assert.False(t, r.skipped)
+	assert.False(t, r.failed)
+	assert.Positive(t, r.bytes)
+
+	var empty recorder
+	BenchmarkEncode(&empty, 10, Corpus{Name: "empty"}, wrp.Msgpack)
+	assert.True(t, empty.skipped)
+
+	assert.Zero(t, Measure(Corpus{Name: "empty"}, wrp.Msgpack, Encode).N)
+}
+
+func BenchmarkCorpora_Encode(b *testing.B) {
+	for _, c := range Corpora() {
+		for _, f := range wrp.AllFormats() {
+			b.Run(c.Name+"/"+f.String(), func(b *testing.B) {
+				BenchmarkEncode(b, b.N, c, f)
+			})
+		}
+	}
+}
+
+func BenchmarkCorpora_Decode(b *testing.B) {
+	for _, c := range Corpora() {
+		for _, f := range wrp.AllFormats() {
+			b.Run(c.Name+"/"+f.String(), func(b *testing.B) {
+				BenchmarkDecode(b, b.N, c, f)
+			})
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpbench
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+const (
+	// LargePayloadSize is the size of the payload used by the LargePayload corpus.
+	LargePayloadSize = 64 * 1024
+
+	// MetadataEntries is the number of metadata entries used by the
+	// MetadataHeavy corpus.
+	MetadataEntries = 64
+)
+
+// Corpus is a named, fixed set of messages used for benchmarking.  The
+// messages of a corpus are the same on every call, so results are comparable
+// across runs and releases.
+type Corpus struct {
+	// Name identifies the corpus in results and pprof labels.
+	Name string
+
+	// Messages are the messages that make up the corpus.  Benchmarks cycle
+	// through these messages in order.
+	Messages []wrp.Message
+}
+
+// Corpora returns all of the canonical corpora.
+func Corpora() []Corpus {
+	return []Corpus{
+		Small(),
+		LargePayload(),
+		MetadataHeavy(),
+	}
+}
+
+// Small returns a corpus of small messages of the most common types, similar
+// to the steady state traffic of a device connection.
+func Small() Corpus {
+	return Corpus{
+		Name: "small",
+		Messages: []wrp.Message{
+			{
+				Type:        wrp.SimpleEventMessageType,
+				Source:      "mac:112233445566/service",
+				Destination: "event:device-status/mac:112233445566/online",
+				ContentType: wrp.MimeTypeJson,
+				PartnerIDs:  []string{"comcast"},
+				Payload:     []byte(`{"id":"mac:112233445566","ts":"2019-02-12T11:10:02.614191735Z"}`),
+			}, {
+				Type:            wrp.SimpleRequestResponseMessageType,
+				Source:          "dns:talaria.example.com",
+				Destination:     "mac:112233445566/config",
+				TransactionUUID: "546514d4-9cb6-41c9-88ca-ccd4c130c525",
+				ContentType:     wrp.MimeTypeJson,
+				Accept:          wrp.MimeTypeJson,
+				PartnerIDs:      []string{"comcast"},
+				Payload:         []byte(`{"command":"GET","names":["Device.DeviceInfo.SerialNumber"]}`),
+			}, {
+				Type:            wrp.RetrieveMessageType,
+				Source:          "dns:scytale.example.com",
+				Destination:     "mac:112233445566/config",
+				TransactionUUID: "8ee4e2a4-2b7f-4c3d-b4fb-36ef3c6a6d11",
+				Path:            "/some/path",
+			},
+		},
+	}
+}
+
+// LargePayload returns a corpus of events carrying a LargePayloadSize byte
+// binary payload.
+func LargePayload() Corpus {
+	payload := bytes.Repeat([]byte{0x00, 0x7f, 0x80, 0xff}, LargePayloadSize/4)
+
+	return Corpus{
+		Name: "large_payload",
+		Messages: []wrp.Message{
+			{
+				Type:        wrp.SimpleEventMessageType,
+				Source:      "mac:112233445566/logger",
+				Destination: "event:log-upload/mac:112233445566",
+				ContentType: wrp.MimeTypeOctetStream,
+				PartnerIDs:  []string{"comcast"},
+				Payload:     payload,
+			},
+		},
+	}
+}
+
+// MetadataHeavy returns a corpus of events with MetadataEntries metadata
+// entries, similar to the events emitted by talaria on device connect.
+func MetadataHeavy() Corpus {
+	metadata := make(map[string]string, MetadataEntries)
+	for i := 0; i < MetadataEntries; i++ {
+		metadata[fmt.Sprintf("/metadata-key-%02d", i)] = fmt.Sprintf("metadata value number %02d", i)
+	}
+	metadata["/boot-time"] = "1542834188"
+	metadata["/last-reconnect-reason"] = "spanish inquisition"
+
+	return Corpus{
+		Name: "metadata_heavy",
+		Messages: []wrp.Message{
+			{
+				Type:        wrp.SimpleEventMessageType,
+				Source:      "dns:talaria.example.com",
+				Destination: "event:device-status/mac:112233445566/online",
+				ContentType: wrp.MimeTypeJson,
+				Metadata:    metadata,
+				PartnerIDs:  []string{"comcast", "other"},
+				SessionID:   "1234567890",
+				Payload:     []byte(`{"id":"mac:112233445566"}`),
+			},
+		},
+	}
+}
+
+// Encoded returns the messages of the corpus encoded in the given format.
+// This function panics if any message cannot be encoded.
+func (c Corpus) Encoded(f wrp.Format) [][]byte {
+	encoded := make([][]byte, len(c.Messages))
+	for i := range c.Messages {
+		encoded[i] = wrp.MustEncode(&c.Messages[i], f)
+	}
+	return encoded
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpbench
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestCorpora(t *testing.T) {
+	names := make(map[string]bool)
+	for _, c := range Corpora() {
+		t.Run(c.Name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			assert.False(names[c.Name], "duplicate corpus name")
+			names[c.Name] = true
+			require.NotEmpty(c.Messages)
+
+			for _, f := range wrp.AllFormats() {
+				encoded := c.Encoded(f)
+				require.Len(encoded, len(c.Messages))
+
+				for i, e := range encoded {
+					var got wrp.Message
+					require.NoError(wrp.NewDecoderBytes(e, f).Decode(&got))
+					assert.Equal(c.Messages[i], got)
+				}
+			}
+		})
+	}
+}
+
+func TestCorpusSizes(t *testing.T) {
+	assert.Len(t, LargePayload().Messages[0].Payload, LargePayloadSize)
+	assert.GreaterOrEqual(t, len(MetadataHeavy().Messages[0].Metadata), MetadataEntries)
+}
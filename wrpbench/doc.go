@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package wrpbench provides canonical WRP message corpora and helpers for
+measuring encode and decode throughput.
+
+The benchmark functions can be called from a downstream repository's own
+Benchmark functions, where they work with the usual -cpuprofile and
+-memprofile flags.  They take a B, which a *testing.B implements, so that this
+package does not import the testing package.  Each iteration is tagged with pprof labels identifying the
+corpus, format and operation so profiles can be filtered:
+
+	func BenchmarkEncode(b *testing.B) {
+		for _, c := range wrpbench.Corpora() {
+			b.Run(c.Name, func(b *testing.B) {
+				wrpbench.BenchmarkEncode(b, b.N, c, wrp.Msgpack)
+			})
+		}
+	}
+
+Measure runs the same benchmarks programmatically, and the returned Result can
+be checked against a Budget so CI can fail when performance regresses:
+
+	r := wrpbench.Measure(wrpbench.Small(), wrp.Msgpack, wrpbench.Encode)
+	if err := r.Check(wrpbench.Budget{MaxAllocsPerOp: 4}); err != nil {
+		t.Error(err)
+	}
+*/
+package wrpbench
@@ -0,0 +1,271 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// DefaultQueueSize is the default number of messages queued for a
+// Subscription.
+const DefaultQueueSize = 64
+
+var (
+	ErrClosed        = errors.New("closed")
+	ErrInvalidOption = errors.New("invalid option")
+)
+
+// Bus delivers published messages to the subscriptions whose pattern matches
+// the message's Destination.  The zero value is not usable; use New.
+type Bus struct {
+	lock   sync.RWMutex
+	subs   map[*Subscription]struct{}
+	closed bool
+}
+
+// New creates a Bus.
+func New() *Bus {
+	return &Bus{
+		subs: make(map[*Subscription]struct{}),
+	}
+}
+
+// SubscribeOption is a functional option for Subscribe.
+type SubscribeOption interface {
+	apply(*Subscription) error
+}
+
+type subscribeOptionFunc func(*Subscription) error
+
+func (f subscribeOptionFunc) apply(s *Subscription) error {
+	return f(s)
+}
+
+// QueueSize sets the number of messages queued for the subscription.  The
+// default is DefaultQueueSize.
+func QueueSize(n int) SubscribeOption {
+	return subscribeOptionFunc(func(s *Subscription) error {
+		if n < 1 {
+			return fmt.Errorf("%w: queue size %d", ErrInvalidOption, n)
+		}
+		s.queue = make(chan wrp.Message, n)
+		return nil
+	})
+}
+
+// Block makes Publish wait for room in the subscription's queue, instead of
+// dropping the message, until the context passed to Publish is done.
+func Block() SubscribeOption {
+	return subscribeOptionFunc(func(s *Subscription) error {
+		s.block = true
+		return nil
+	})
+}
+
+// Subscribe receives the published messages whose Destination matches the
+// pattern.
+func (b *Bus) Subscribe(pattern string, opts ...SubscribeOption) (*Subscription, error) {
+	p, err := ParsePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	s := Subscription{
+		bus:     b,
+		pattern: p,
+		done:    make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt.apply(&s); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.queue == nil {
+		s.queue = make(chan wrp.Message, DefaultQueueSize)
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.closed {
+		return nil, ErrClosed
+	}
+
+	b.subs[&s] = struct{}{}
+	return &s, nil
+}
+
+// Publish delivers the message to every matching subscription and returns the
+// number of subscriptions it was queued for.  Messages dropped because a
+// queue is full are not counted.  An error is returned if the Destination is
+// not a valid locator, if the Bus is closed, or if the context ends while
+// waiting for a subscription created with Block.
+//
+// The message is shared by the subscribers, so they must not modify its
+// slices or maps in place.
+func (b *Bus) Publish(ctx context.Context, msg wrp.Message) (int, error) {
+	parts, err := destinationParts(msg.Destination)
+	if err != nil {
+		return 0, err
+	}
+
+	b.lock.RLock()
+	if b.closed {
+		b.lock.RUnlock()
+		return 0, ErrClosed
+	}
+
+	var matched []*Subscription
+	for s := range b.subs {
+		if s.pattern.match(parts) {
+			matched = append(matched, s)
+		}
+	}
+	b.lock.RUnlock()
+
+	var delivered int
+	for _, s := range matched {
+		ok, err := s.deliver(ctx, msg)
+		if err != nil {
+			return delivered, err
+		}
+		if ok {
+			delivered++
+		}
+	}
+
+	return delivered, nil
+}
+
+// Processor returns a Processor that publishes each message to the Bus.  A
+// message that matches no subscription results in ErrNotHandled.
+func (b *Bus) Processor() wrp.Processor {
+	return wrp.ProcessorFunc(func(ctx context.Context, msg wrp.Message) error {
+		n, err := b.Publish(ctx, msg)
+		if err == nil && n == 0 {
+			return wrp.ErrNotHandled
+		}
+
+		return err
+	})
+}
+
+// Close closes the Bus and all of its subscriptions.
+func (b *Bus) Close() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.closed = true
+	for s := range b.subs {
+		s.close()
+		delete(b.subs, s)
+	}
+}
+
+func (b *Bus) unsubscribe(s *Subscription) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	delete(b.subs, s)
+}
+
+// Subscription is the queue of messages for a pattern.
+type Subscription struct {
+	bus     *Bus
+	pattern Pattern
+	queue   chan wrp.Message
+	block   bool
+	dropped atomic.Uint64
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Pattern returns the pattern of the subscription.
+func (s *Subscription) Pattern() Pattern {
+	return s.pattern
+}
+
+// Recv returns the next message.  It blocks until a message is available,
+// the context is done or the subscription is closed.  Messages queued before
+// the subscription was closed are still returned.
+func (s *Subscription) Recv(ctx context.Context) (wrp.Message, error) {
+	select {
+	case msg := <-s.queue:
+		return msg, nil
+	default:
+	}
+
+	select {
+	case msg := <-s.queue:
+		return msg, nil
+	case <-ctx.Done():
+		return wrp.Message{}, ctx.Err()
+	case <-s.done:
+		select {
+		case msg := <-s.queue:
+			return msg, nil
+		default:
+			return wrp.Message{}, ErrClosed
+		}
+	}
+}
+
+// Dropped returns the number of messages that were dropped because the queue
+// was full.
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Unsubscribe stops the delivery of messages to the subscription.
+func (s *Subscription) Unsubscribe() {
+	s.close()
+	s.bus.unsubscribe(s)
+}
+
+func (s *Subscription) close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+}
+
+// deliver queues the message, returning false if it was dropped or the
+// subscription is closed.
+func (s *Subscription) deliver(ctx context.Context, msg wrp.Message) (bool, error) {
+	select {
+	case <-s.done:
+		return false, nil
+	default:
+	}
+
+	if !s.block {
+		select {
+		case s.queue <- msg:
+			return true, nil
+		default:
+			s.dropped.Add(1)
+			return false, nil
+		}
+	}
+
+	select {
+	case s.queue <- msg:
+		return true, nil
+	case <-s.done:
+		return false, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpbus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func event(dest string) wrp.Message {
+	return wrp.Message{
+		Type:        wrp.SimpleEventMessageType,
+		Source:      "dns:example.com",
+		Destination: dest,
+	}
+}
+
+func TestBus(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	b := New()
+	defer b.Close()
+
+	device, err := b.Subscribe("mac:112233445566/**")
+	require.NoError(err)
+	config, err := b.Subscribe("mac:*/config")
+	require.NoError(err)
+	assert.Equal("mac:*/config", config.Pattern().String())
+
+	n, err := b.Publish(ctx, event("mac:11-22-33-44-55-66/config"))
+	require.NoError(err)
+	assert.Equal(2, n)
+
+	n, err = b.Publish(ctx, event("mac:665544332211/config"))
+	require.NoError(err)
+	assert.Equal(1, n)
+
+	n, err = b.Publish(ctx, event("dns:example.com"))
+	require.NoError(err)
+	assert.Zero(n)
+
+	msg, err := device.Recv(ctx)
+	require.NoError(err)
+	assert.Equal("mac:11-22-33-44-55-66/config", msg.Destination)
+
+	msg, err = config.Recv(ctx)
+	require.NoError(err)
+	assert.Equal("mac:11-22-33-44-55-66/config", msg.Destination)
+	msg, err = config.Recv(ctx)
+	require.NoError(err)
+	assert.Equal("mac:665544332211/config", msg.Destination)
+
+	short, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = device.Recv(short)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+
+	_, err = b.Publish(ctx, event("invalid"))
+	assert.Equal(wrp.CodeInvalidLocator, wrp.ErrorCodeOf(err))
+}
+
+func TestDrop(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	b := New()
+	defer b.Close()
+
+	s, err := b.Subscribe("event:*/**", QueueSize(2))
+	require.NoError(err)
+
+	for i := 0; i < 5; i++ {
+		_, err := b.Publish(ctx, event("event:test"))
+		require.NoError(err)
+	}
+
+	assert.Equal(uint64(3), s.Dropped())
+}
+
+func TestBlock(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	b := New()
+	defer b.Close()
+
+	s, err := b.Subscribe("event:*/**", QueueSize(1), Block())
+	require.NoError(err)
+
+	n, err := b.Publish(ctx, event("event:first"))
+	require.NoError(err)
+	assert.Equal(1, n)
+
+	short, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	n, err = b.Publish(short, event("event:second"))
+	assert.ErrorIs(err, context.DeadlineExceeded)
+	assert.Zero(n)
+
+	published := make(chan error, 1)
+	go func() {
+		_, err := b.Publish(ctx, event("event:third"))
+		published <- err
+	}()
+
+	msg, err := s.Recv(ctx)
+	require.NoError(err)
+	assert.Equal("event:first", msg.Destination)
+	require.NoError(<-published)
+
+	msg, err = s.Recv(ctx)
+	require.NoError(err)
+	assert.Equal("event:third", msg.Destination)
+	assert.Zero(s.Dropped())
+}
+
+func TestUnsubscribe(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	b := New()
+	defer b.Close()
+
+	s, err := b.Subscribe("event:*/**", QueueSize(1), Block())
+	require.NoError(err)
+
+	_, err = b.Publish(ctx, event("event:queued"))
+	require.NoError(err)
+
+	// A publisher blocked on the subscription is released.
+	published := make(chan int, 1)
+	go func() {
+		n, _ := b.Publish(ctx, event("event:blocked"))
+		published <- n
+	}()
+	time.Sleep(10 * time.Millisecond)
+	s.Unsubscribe()
+	s.Unsubscribe()
+
+	select {
+	case n := <-published:
+		assert.Zero(n)
+	case <-time.After(5 * time.Second):
+		assert.Fail("publisher was not released")
+	}
+
+	// Queued messages are still returned.
+	msg, err := s.Recv(ctx)
+	require.NoError(err)
+	assert.Equal("event:queued", msg.Destination)
+
+	_, err = s.Recv(ctx)
+	assert.ErrorIs(err, ErrClosed)
+
+	n, err := b.Publish(ctx, event("event:after"))
+	require.NoError(err)
+	assert.Zero(n)
+}
+
+func TestClose(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	b := New()
+	s, err := b.Subscribe("event:*/**")
+	require.NoError(err)
+
+	b.Close()
+
+	_, err = s.Recv(ctx)
+	assert.ErrorIs(err, ErrClosed)
+
+	_, err = b.Publish(ctx, event("event:test"))
+	assert.ErrorIs(err, ErrClosed)
+
+	_, err = b.Subscribe("event:*/**")
+	assert.ErrorIs(err, ErrClosed)
+}
+
+func TestProcessor(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	b := New()
+	defer b.Close()
+
+	_, err := b.Subscribe("event:*/**")
+	require.NoError(err)
+
+	p := b.Processor()
+	assert.NoError(p.ProcessWRP(ctx, event("event:test")))
+	assert.ErrorIs(p.ProcessWRP(ctx, event("mac:112233445566")), wrp.ErrNotHandled)
+	assert.Equal(wrp.CodeInvalidLocator, wrp.ErrorCodeOf(p.ProcessWRP(ctx, event("invalid"))))
+}
+
+func TestInvalidSubscribe(t *testing.T) {
+	b := New()
+	defer b.Close()
+
+	_, err := b.Subscribe("invalid")
+	assert.ErrorIs(t, err, ErrInvalidPattern)
+
+	_, err = b.Subscribe("event:*", QueueSize(0))
+	assert.ErrorIs(t, err, ErrInvalidOption)
+}
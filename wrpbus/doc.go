@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+// Package wrpbus routes WRP messages between components of a single process.
+// Components Subscribe to destination locator patterns and receive every
+// Published message whose Destination matches, so monolithic deployments and
+// tests can wire components together without a network transport.
+//
+// A pattern is a locator in which any part may be the wildcard *, matching
+// exactly one part, and whose last part may be **, matching any number of
+// remaining parts:
+//
+//	mac:112233445566/config     only the config service of one device
+//	mac:*/config                the config service of any device
+//	mac:112233445566/**         any service of one device
+//	event:device-status/**      any device-status event
+//	*:*/**                      everything
+//
+// Device identifiers are compared in their canonical form, so
+// mac:11-22-33-44-55-66 and mac:112233445566 are the same.
+//
+// Each Subscription has a bounded queue.  When it is full, messages for that
+// subscriber are dropped and counted, unless the subscription was created with
+// Block, in which case Publish waits for room.
+package wrpbus
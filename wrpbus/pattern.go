@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpbus
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+const (
+	// wildcard matches exactly one part of a locator.
+	wildcard = "*"
+
+	// rest matches any number of remaining parts, and may only be last.
+	rest = "**"
+)
+
+var ErrInvalidPattern = errors.New("invalid pattern")
+
+// Pattern matches destination locators.  See the package documentation for
+// the syntax.
+type Pattern struct {
+	raw   string
+	parts []string
+}
+
+// ParsePattern parses a destination locator pattern.
+func ParsePattern(s string) (Pattern, error) {
+	scheme, path, ok := strings.Cut(s, ":")
+	if !ok {
+		return Pattern{}, fmt.Errorf("%w: `%s` has no scheme", ErrInvalidPattern, s)
+	}
+
+	parts := append([]string{strings.ToLower(scheme)}, strings.Split(path, "/")...)
+	for i, part := range parts {
+		switch {
+		case part == "":
+			return Pattern{}, fmt.Errorf("%w: `%s` has an empty part", ErrInvalidPattern, s)
+		case part == rest && i != len(parts)-1:
+			return Pattern{}, fmt.Errorf("%w: `%s` has %s before the end", ErrInvalidPattern, s, rest)
+		case part == rest && i < 2:
+			return Pattern{}, fmt.Errorf("%w: `%s` uses %s for the scheme or authority", ErrInvalidPattern, s, rest)
+		case part != wildcard && part != rest && strings.Contains(part, wildcard):
+			return Pattern{}, fmt.Errorf("%w: `%s` has a partial wildcard", ErrInvalidPattern, s)
+		}
+	}
+
+	// Device identifiers are matched in their canonical form.
+	if parts[0] != wildcard && parts[1] != wildcard {
+		l, err := wrp.ParseLocator(parts[0] + ":" + parts[1])
+		if err != nil {
+			return Pattern{}, fmt.Errorf("%w: %w", ErrInvalidPattern, err)
+		}
+		if l.HasDeviceID() {
+			parts[1] = l.ID.ID()
+		}
+	}
+
+	return Pattern{
+		raw:   s,
+		parts: parts,
+	}, nil
+}
+
+// MustParsePattern is like ParsePattern, but panics if the pattern is
+// invalid.
+func MustParsePattern(s string) Pattern {
+	p, err := ParsePattern(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+// String returns the pattern as it was parsed.
+func (p Pattern) String() string {
+	return p.raw
+}
+
+// Match returns true if the destination locator matches the pattern.  A
+// destination that is not a valid locator never matches.
+func (p Pattern) Match(dest string) bool {
+	parts, err := destinationParts(dest)
+	return err == nil && p.match(parts)
+}
+
+func (p Pattern) match(parts []string) bool {
+	for i, want := range p.parts {
+		if want == rest {
+			return true
+		}
+		if i >= len(parts) || (want != wildcard && want != parts[i]) {
+			return false
+		}
+	}
+
+	return len(parts) == len(p.parts)
+}
+
+// destinationParts splits the canonical form of a destination locator into
+// its scheme, authority and path segments.
+func destinationParts(dest string) ([]string, error) {
+	l, err := wrp.ParseLocator(dest)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme, path, _ := strings.Cut(l.Canonical(), ":")
+	return append([]string{scheme}, strings.Split(path, "/")...), nil
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestPatternMatch(t *testing.T) {
+	tests := []struct {
+		desc    string
+		pattern string
+		match   []string
+		noMatch []string
+	}{
+		{
+			desc:    "exact",
+			pattern: "mac:112233445566/config",
+			match:   []string{"mac:112233445566/config", "MAC:11-22-33-44-55-66/config"},
+			noMatch: []string{"mac:112233445566", "mac:112233445566/config/a", "mac:665544332211/config"},
+		}, {
+			desc:    "non-canonical pattern",
+			pattern: "MAC:11:22:33:44:55:66/config",
+			match:   []string{"mac:112233445566/config"},
+		}, {
+			desc:    "any device",
+			pattern: "mac:*/config",
+			match:   []string{"mac:112233445566/config", "mac:665544332211/config"},
+			noMatch: []string{"uuid:1234/config", "mac:112233445566/other", "mac:112233445566"},
+		}, {
+			desc:    "any service",
+			pattern: "mac:112233445566/**",
+			match:   []string{"mac:112233445566", "mac:112233445566/config", "mac:112233445566/config/a/b"},
+			noMatch: []string{"mac:665544332211/config"},
+		}, {
+			desc:    "events",
+			pattern: "event:device-status/**",
+			match:   []string{"event:device-status", "event:device-status/mac:112233445566/online"},
+			noMatch: []string{"event:other/mac:112233445566/online"},
+		}, {
+			desc:    "middle wildcard",
+			pattern: "event:device-status/*/online",
+			match:   []string{"event:device-status/mac:112233445566/online"},
+			noMatch: []string{"event:device-status/mac:112233445566/offline"},
+		}, {
+			desc:    "everything",
+			pattern: "*:*/**",
+			match:   []string{"dns:example.com", "mac:112233445566/config", "event:foo/bar"},
+			noMatch: []string{"", "invalid", "mac:invalid"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			p, err := ParsePattern(tc.pattern)
+			require.NoError(t, err)
+			assert.Equal(t, tc.pattern, p.String())
+
+			for _, dest := range tc.match {
+				assert.True(t, p.Match(dest), dest)
+			}
+			for _, dest := range tc.noMatch {
+				assert.False(t, p.Match(dest), dest)
+			}
+		})
+	}
+}
+
+func TestParsePatternInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"config",
+		":authority",
+		"mac:",
+		"mac:112233445566/",
+		"mac:112233445566//config",
+		"mac:**",
+		"**:112233445566",
+		"mac:*/**/config",
+		"mac:*/conf*",
+		"mac:invalid",
+		"dns:",
+	}
+	for _, pattern := range tests {
+		t.Run(pattern, func(t *testing.T) {
+			_, err := ParsePattern(pattern)
+			assert.ErrorIs(t, err, ErrInvalidPattern)
+			assert.Panics(t, func() { MustParsePattern(pattern) })
+		})
+	}
+}
+
+func TestPatternLocatorError(t *testing.T) {
+	_, err := ParsePattern("mac:invalid")
+	assert.Equal(t, wrp.CodeInvalidLocator, wrp.ErrorCodeOf(err))
+}
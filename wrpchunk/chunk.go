@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpchunk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// The Metadata keys of a chunk.
+const (
+	// IDKey is the key of the ID of the chunked message, which is the same
+	// for all of its chunks.
+	IDKey = "/wrp-chunk-id"
+
+	// IndexKey is the key of the index of the chunk, from 0.
+	IndexKey = "/wrp-chunk-index"
+
+	// TotalKey is the key of the number of chunks.
+	TotalKey = "/wrp-chunk-total"
+
+	// ChecksumKey is the key of the hex encoded SHA-256 checksum of the whole
+	// payload.
+	ChecksumKey = "/wrp-chunk-checksum"
+)
+
+var (
+	ErrInvalidConfig = errors.New("invalid chunk configuration")
+	ErrInvalidChunk  = errors.New("invalid chunk")
+)
+
+// Chunk describes a chunk message.
+type Chunk struct {
+	// ID is the ID of the chunked message.
+	ID string
+
+	// Index is the index of the chunk, from 0.
+	Index int
+
+	// Total is the number of chunks.
+	Total int
+
+	// Checksum is the hex encoded SHA-256 checksum of the whole payload.
+	Checksum string
+}
+
+// IsChunk returns true if the message is a chunk, i.e. has an IDKey.
+func IsChunk(msg *wrp.Message) bool {
+	_, ok := msg.Metadata[IDKey]
+	return ok
+}
+
+// Parse returns the description of a chunk message.  A message that is not a
+// chunk, or whose chunk metadata is invalid, results in an error wrapping
+// ErrInvalidChunk.
+func Parse(msg *wrp.Message) (Chunk, error) {
+	var (
+		c   = Chunk{ID: msg.Metadata[IDKey], Checksum: msg.Metadata[ChecksumKey]}
+		err error
+	)
+
+	if c.ID == "" {
+		return Chunk{}, fmt.Errorf("%w: no %s", ErrInvalidChunk, IDKey)
+	}
+
+	if c.Index, err = strconv.Atoi(msg.Metadata[IndexKey]); err != nil {
+		return Chunk{}, fmt.Errorf("%w: %s: %w", ErrInvalidChunk, IndexKey, err)
+	}
+
+	if c.Total, err = strconv.Atoi(msg.Metadata[TotalKey]); err != nil {
+		return Chunk{}, fmt.Errorf("%w: %s: %w", ErrInvalidChunk, TotalKey, err)
+	}
+
+	switch {
+	case c.Total < 1:
+		return Chunk{}, fmt.Errorf("%w: total %d", ErrInvalidChunk, c.Total)
+	case c.Index < 0 || c.Index >= c.Total:
+		return Chunk{}, fmt.Errorf("%w: index %d of %d", ErrInvalidChunk, c.Index, c.Total)
+	case len(c.Checksum) != 2*sha256.Size:
+		return Chunk{}, fmt.Errorf("%w: checksum `%s`", ErrInvalidChunk, c.Checksum)
+	}
+
+	return c, nil
+}
+
+// checksum returns the hex encoded SHA-256 checksum of the payload.
+func checksum(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// SplitterOption is a functional option for NewSplitter.
+type SplitterOption interface {
+	apply(*Splitter) error
+}
+
+type splitterOptionFunc func(*Splitter) error
+
+func (f splitterOptionFunc) apply(s *Splitter) error {
+	return f(s)
+}
+
+// IDs sets the source of the IDs of messages without a TransactionUUID.  The
+// default is wrp.UUIDGenerator.
+func IDs(gen wrp.IDGenerator) SplitterOption {
+	return splitterOptionFunc(func(s *Splitter) error {
+		if gen != nil {
+			s.ids = gen
+		}
+		return nil
+	})
+}
+
+// Splitter splits messages into chunks.  A Splitter is safe for concurrent
+// use.
+type Splitter struct {
+	size int
+	ids  wrp.IDGenerator
+}
+
+// NewSplitter creates a Splitter whose chunks have payloads of at most size
+// bytes.
+func NewSplitter(size int, opts ...SplitterOption) (*Splitter, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("%w: chunk size %d", ErrInvalidConfig, size)
+	}
+
+	s := Splitter{
+		size: size,
+		ids:  wrp.UUIDGenerator,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			if err := opt.apply(&s); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &s, nil
+}
+
+// Split returns the chunks of the message, in order.  The ID of the chunks is
+// the TransactionUUID of the message, or a new ID if it has none.  A message
+// whose payload is within the chunk size is returned as is, as the only
+// element.  The chunks share the Headers, PartnerIDs and payload of the
+// message, which must not be modified while the chunks are in use.
+func (s *Splitter) Split(msg *wrp.Message) ([]*wrp.Message, error) {
+	if len(msg.Payload) <= s.size {
+		return []*wrp.Message{msg}, nil
+	}
+
+	id := msg.TransactionUUID
+	if id == "" {
+		var err error
+		if id, err = s.ids.NewID(); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		total  = (len(msg.Payload) + s.size - 1) / s.size
+		sum    = checksum(msg.Payload)
+		chunks = make([]*wrp.Message, total)
+	)
+
+	for i := range chunks {
+		chunk := *msg
+		chunk.Payload = msg.Payload[i*s.size : min((i+1)*s.size, len(msg.Payload))]
+
+		chunk.Metadata = make(map[string]string, len(msg.Metadata)+4)
+		maps.Copy(chunk.Metadata, msg.Metadata)
+		chunk.Metadata[IDKey] = id
+		chunk.Metadata[IndexKey] = strconv.Itoa(i)
+		chunk.Metadata[TotalKey] = strconv.Itoa(total)
+		chunk.Metadata[ChecksumKey] = sum
+
+		chunks[i] = &chunk
+	}
+
+	return chunks, nil
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpchunk
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+	"github.com/xmidt-org/wrp-go/v3/wrptest"
+)
+
+func TestSplitter_Split(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s, err := NewSplitter(4, nil)
+	require.NoError(err)
+
+	msg := wrp.Message{
+		Type:            wrp.SimpleEventMessageType,
+		Source:          "mac:112233445566",
+		Destination:     "event:device-status/mac:112233445566/online",
+		TransactionUUID: "1234",
+		Metadata:        map[string]string{"/boot-time": "1"},
+		Payload:         []byte("0123456789"),
+	}
+
+	chunks, err := s.Split(&msg)
+	require.NoError(err)
+	require.Len(chunks, 3)
+
+	for i, chunk := range chunks {
+		assert.Equal(msg.Destination, chunk.Destination)
+		assert.Equal("1", chunk.Metadata["/boot-time"])
+
+		c, err := Parse(chunk)
+		require.NoError(err)
+		assert.Equal(Chunk{
+			ID:       "1234",
+			Index:    i,
+			Total:    3,
+			Checksum: checksum(msg.Payload),
+		}, c)
+	}
+
+	assert.Equal([]byte("0123"), chunks[0].Payload)
+	assert.Equal([]byte("4567"), chunks[1].Payload)
+	assert.Equal([]byte("89"), chunks[2].Payload)
+	assert.Equal(map[string]string{"/boot-time": "1"}, msg.Metadata)
+	assert.False(IsChunk(&msg))
+
+	msg.Payload = []byte("0123")
+	chunks, err = s.Split(&msg)
+	require.NoError(err)
+	assert.Equal([]*wrp.Message{&msg}, chunks)
+}
+
+func TestSplitter_Split_ids(t *testing.T) {
+	s, err := NewSplitter(1, IDs(new(wrptest.SequentialIDs)))
+	require.NoError(t, err)
+
+	chunks, err := s.Split(&wrp.Message{Payload: []byte("ab")})
+	require.NoError(t, err)
+	assert.Equal(t, wrptest.SequentialID(1), chunks[0].Metadata[IDKey])
+
+	errExpected := errors.New("expected")
+	s, err = NewSplitter(1, IDs(wrp.IDGeneratorFunc(func() (string, error) {
+		return "", errExpected
+	})))
+	require.NoError(t, err)
+
+	_, err = s.Split(&wrp.Message{Payload: []byte("ab")})
+	assert.ErrorIs(t, err, errExpected)
+
+	s, err = NewSplitter(0)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+	assert.Nil(t, s)
+}
+
+func TestParse(t *testing.T) {
+	valid := func() map[string]string {
+		return map[string]string{
+			IDKey:       "1234",
+			IndexKey:    "1",
+			TotalKey:    "2",
+			ChecksumKey: strings.Repeat("0", 64),
+		}
+	}
+
+	tests := []struct {
+		desc   string
+		change func(map[string]string)
+	}{
+		{desc: "no id", change: func(m map[string]string) { delete(m, IDKey) }},
+		{desc: "invalid index", change: func(m map[string]string) { m[IndexKey] = "one" }},
+		{desc: "invalid total", change: func(m map[string]string) { delete(m, TotalKey) }},
+		{desc: "zero total", change: func(m map[string]string) { m[TotalKey] = "0" }},
+		{desc: "negative index", change: func(m map[string]string) { m[IndexKey] = "-1" }},
+		{desc: "index past total", change: func(m map[string]string) { m[IndexKey] = "2" }},
+		{desc: "invalid checksum", change: func(m map[string]string) { m[ChecksumKey] = "abc" }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			metadata := valid()
+			tc.change(metadata)
+
+			_, err := Parse(&wrp.Message{Metadata: metadata})
+			assert.ErrorIs(t, err, ErrInvalidChunk)
+		})
+	}
+
+	c, err := Parse(&wrp.Message{Metadata: valid()})
+	require.NoError(t, err)
+	assert.Equal(t, 1, c.Index)
+}
@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package wrpchunk splits messages whose payload exceeds the frame limit of a
+transport into a sequence of smaller chunk messages, and reassembles them on
+the other side.
+
+A Splitter copies every field of the message onto each chunk, and gives each
+chunk a part of the payload.  The chunks carry the ID of the chunked message,
+their index, the total number of chunks and the SHA-256 checksum of the whole
+payload in their Metadata, under the keys IDKey, IndexKey, TotalKey and
+ChecksumKey.  Messages whose payload fits in one chunk are not split.
+
+A Reassembler collects the chunks in any order, and returns the original
+message once every chunk has arrived and the checksum of the payload
+matches.  Messages that are not chunks pass through it unchanged.  Chunked
+messages that are not complete within the timeout are dropped, so a lost
+chunk does not hold on to the others forever.
+*/
+package wrpchunk
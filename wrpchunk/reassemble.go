@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpchunk
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+const (
+	// DefaultTimeout is how long a Reassembler waits for the chunks of a
+	// message by default.
+	DefaultTimeout = time.Minute
+
+	// DefaultMaxPending is the number of incomplete messages a Reassembler
+	// holds by default.
+	DefaultMaxPending = 1024
+)
+
+var (
+	ErrChecksumMismatch = errors.New("chunk checksum mismatch")
+	ErrTooManyPending   = errors.New("too many incomplete chunked messages")
+)
+
+// Incomplete describes a chunked message that was dropped because not all of
+// its chunks arrived in time.
+type Incomplete struct {
+	// ID is the ID of the chunked message.
+	ID string
+
+	// Received is the number of chunks that arrived.
+	Received int
+
+	// Total is the number of chunks.
+	Total int
+}
+
+// ReassemblerOption is a functional option for NewReassembler.
+type ReassemblerOption interface {
+	apply(*Reassembler) error
+}
+
+type reassemblerOptionFunc func(*Reassembler) error
+
+func (f reassemblerOptionFunc) apply(r *Reassembler) error {
+	return f(r)
+}
+
+// Timeout sets how long after its first chunk arrives a chunked message must
+// be complete.  The default is DefaultTimeout.
+func Timeout(d time.Duration) ReassemblerOption {
+	return reassemblerOptionFunc(func(r *Reassembler) error {
+		if d <= 0 {
+			return fmt.Errorf("%w: timeout %s", ErrInvalidConfig, d)
+		}
+		r.timeout = d
+		return nil
+	})
+}
+
+// MaxPending sets the largest number of incomplete chunked messages that are
+// held at once.  The default is DefaultMaxPending.
+func MaxPending(n int) ReassemblerOption {
+	return reassemblerOptionFunc(func(r *Reassembler) error {
+		if n < 1 {
+			return fmt.Errorf("%w: max pending %d", ErrInvalidConfig, n)
+		}
+		r.maxPending = n
+		return nil
+	})
+}
+
+// Clock sets the clock of the timeouts.  The default is wrp.SystemClock.
+func Clock(c wrp.Clock) ReassemblerOption {
+	return reassemblerOptionFunc(func(r *Reassembler) error {
+		if c != nil {
+			r.clock = c
+		}
+		return nil
+	})
+}
+
+// OnExpired sets a callback that is called with each chunked message dropped
+// because it timed out.  The callback is called while the Reassembler is
+// locked, so it must not call the Reassembler.
+func OnExpired(f func(Incomplete)) ReassemblerOption {
+	return reassemblerOptionFunc(func(r *Reassembler) error {
+		r.onExpired = f
+		return nil
+	})
+}
+
+// pending is a chunked message whose chunks have not all arrived.
+type pending struct {
+	first    Chunk
+	deadline time.Time
+	chunks   map[int]*wrp.Message
+	size     int
+}
+
+// Reassembler reassembles chunked messages.  A Reassembler is safe for
+// concurrent use.
+type Reassembler struct {
+	timeout    time.Duration
+	maxPending int
+	clock      wrp.Clock
+	onExpired  func(Incomplete)
+
+	lock    sync.Mutex
+	pending map[string]*pending
+}
+
+// NewReassembler creates a Reassembler.
+func NewReassembler(opts ...ReassemblerOption) (*Reassembler, error) {
+	r := Reassembler{
+		timeout:    DefaultTimeout,
+		maxPending: DefaultMaxPending,
+		clock:      wrp.SystemClock,
+		pending:    make(map[string]*pending),
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			if err := opt.apply(&r); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &r, nil
+}
+
+// Add adds a received message.  When the message completes a chunked
+// message, the reassembled message is returned along with true.  A message
+// that is not a chunk is returned as is, along with true.  Otherwise, no
+// message and false are returned until the other chunks arrive.
+//
+// A chunk that does not agree with the earlier chunks of its message, or a
+// reassembled payload that does not match the checksum, results in an error
+// and drops the chunked message.  Chunks received again are ignored.
+func (r *Reassembler) Add(msg *wrp.Message) (*wrp.Message, bool, error) {
+	if !IsChunk(msg) {
+		return msg, true, nil
+	}
+
+	c, err := Parse(msg)
+	if err != nil {
+		return nil, false, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.clock.Now()
+	r.expire(now)
+
+	p, ok := r.pending[c.ID]
+	if !ok {
+		if len(r.pending) >= r.maxPending {
+			return nil, false, fmt.Errorf("%w: %d", ErrTooManyPending, len(r.pending))
+		}
+
+		p = &pending{
+			first:    c,
+			deadline: now.Add(r.timeout),
+			chunks:   make(map[int]*wrp.Message),
+		}
+		r.pending[c.ID] = p
+	}
+
+	if c.Total != p.first.Total || c.Checksum != p.first.Checksum {
+		delete(r.pending, c.ID)
+		return nil, false, fmt.Errorf("%w: chunk %d of `%s` does not match the earlier chunks", ErrInvalidChunk, c.Index, c.ID)
+	}
+
+	if _, ok := p.chunks[c.Index]; ok {
+		return nil, false, nil
+	}
+
+	p.chunks[c.Index] = msg
+	p.size += len(msg.Payload)
+	if len(p.chunks) < c.Total {
+		return nil, false, nil
+	}
+
+	delete(r.pending, c.ID)
+	return p.reassemble()
+}
+
+// reassemble returns the message of the complete chunks.  The fields other
+// than the payload are those of the first chunk.
+func (p *pending) reassemble() (*wrp.Message, bool, error) {
+	payload := make([]byte, 0, p.size)
+	for i := 0; i < p.first.Total; i++ {
+		payload = append(payload, p.chunks[i].Payload...)
+	}
+
+	if checksum(payload) != p.first.Checksum {
+		return nil, false, fmt.Errorf("%w: `%s`", ErrChecksumMismatch, p.first.ID)
+	}
+
+	msg := *p.chunks[0]
+	msg.Payload = payload
+	msg.Metadata = make(map[string]string, len(msg.Metadata))
+	for k, v := range p.chunks[0].Metadata {
+		switch k {
+		case IDKey, IndexKey, TotalKey, ChecksumKey:
+		default:
+			msg.Metadata[k] = v
+		}
+	}
+
+	if len(msg.Metadata) == 0 {
+		msg.Metadata = nil
+	}
+
+	return &msg, true, nil
+}
+
+// Pending returns the number of incomplete chunked messages.
+func (r *Reassembler) Pending() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return len(r.pending)
+}
+
+// Expire drops the chunked messages that have timed out, and returns how many
+// were dropped.  Add also drops them, so Expire only needs to be called to
+// free the memory of messages whose chunks stopped arriving altogether.
+func (r *Reassembler) Expire() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.expire(r.clock.Now())
+}
+
+func (r *Reassembler) expire(now time.Time) int {
+	var n int
+	for id, p := range r.pending {
+		if now.Before(p.deadline) {
+			continue
+		}
+
+		delete(r.pending, id)
+		n++
+		if r.onExpired != nil {
+			r.onExpired(Incomplete{
+				ID:       id,
+				Received: len(p.chunks),
+				Total:    p.first.Total,
+			})
+		}
+	}
+
+	return n
+}
@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpchunk
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+	"github.com/xmidt-org/wrp-go/v3/wrptest"
+)
+
+func split(t *testing.T, msg *wrp.Message, size int) []*wrp.Message {
+	s, err := NewSplitter(size)
+	require.NoError(t, err)
+
+	chunks, err := s.Split(msg)
+	require.NoError(t, err)
+	return chunks
+}
+
+func TestReassembler(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	msg := wrp.Message{
+		Type:            wrp.SimpleRequestResponseMessageType,
+		Source:          "dns:talaria.example.com",
+		Destination:     "mac:112233445566/config",
+		TransactionUUID: "1234",
+		Payload:         bytes.Repeat([]byte("0123456789"), 100),
+	}
+
+	chunks := split(t, &msg, 64)
+	rand.New(rand.NewSource(1)).Shuffle(len(chunks), func(i, j int) { // nolint:gosec
+		chunks[i], chunks[j] = chunks[j], chunks[i]
+	})
+
+	r, err := NewReassembler(nil)
+	require.NoError(err)
+
+	for i, chunk := range chunks[:len(chunks)-1] {
+		actual, ok, err := r.Add(chunk)
+		require.NoError(err)
+		assert.False(ok)
+		assert.Nil(actual)
+
+		// duplicates are ignored
+		if i == 0 {
+			_, ok, err = r.Add(chunk)
+			require.NoError(err)
+			assert.False(ok)
+		}
+	}
+	assert.Equal(1, r.Pending())
+
+	actual, ok, err := r.Add(chunks[len(chunks)-1])
+	require.NoError(err)
+	assert.True(ok)
+	assert.Equal(&msg, actual)
+	assert.Zero(r.Pending())
+
+	other := wrp.Message{Type: wrp.SimpleEventMessageType}
+	actual, ok, err = r.Add(&other)
+	require.NoError(err)
+	assert.True(ok)
+	assert.Same(&other, actual)
+}
+
+func TestReassembler_errors(t *testing.T) {
+	msg := wrp.Message{
+		TransactionUUID: "1234",
+		Metadata:        map[string]string{"/boot-time": "1"},
+		Payload:         []byte("0123456789"),
+	}
+
+	tests := []struct {
+		desc     string
+		chunks   func(t *testing.T) []*wrp.Message
+		expected error
+	}{
+		{
+			desc: "invalid chunk",
+			chunks: func(*testing.T) []*wrp.Message {
+				return []*wrp.Message{{Metadata: map[string]string{IDKey: "1234"}}}
+			},
+			expected: ErrInvalidChunk,
+		}, {
+			desc: "mismatched total",
+			chunks: func(t *testing.T) []*wrp.Message {
+				return append(split(t, &msg, 4)[:1], split(t, &msg, 5)[1])
+			},
+			expected: ErrInvalidChunk,
+		}, {
+			desc: "checksum mismatch",
+			chunks: func(t *testing.T) []*wrp.Message {
+				chunks := split(t, &msg, 5)
+				chunks[1].Payload = []byte("abcde")
+				return chunks
+			},
+			expected: ErrChecksumMismatch,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			r, err := NewReassembler()
+			require.NoError(t, err)
+
+			var last error
+			for _, chunk := range tc.chunks(t) {
+				_, _, last = r.Add(chunk)
+			}
+
+			assert.ErrorIs(t, last, tc.expected)
+			assert.Zero(t, r.Pending())
+		})
+	}
+}
+
+func TestReassembler_expire(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var (
+		clock   = wrptest.NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+		expired []Incomplete
+	)
+
+	r, err := NewReassembler(
+		Clock(clock),
+		Timeout(time.Second),
+		MaxPending(1),
+		OnExpired(func(i Incomplete) { expired = append(expired, i) }),
+	)
+	require.NoError(err)
+
+	first := split(t, &wrp.Message{TransactionUUID: "first", Payload: []byte("0123")}, 1)
+	second := split(t, &wrp.Message{TransactionUUID: "second", Payload: []byte("01")}, 1)
+
+	_, _, err = r.Add(first[0])
+	require.NoError(err)
+	_, _, err = r.Add(second[0])
+	assert.ErrorIs(err, ErrTooManyPending)
+
+	clock.Advance(999 * time.Millisecond)
+	assert.Zero(r.Expire())
+
+	clock.Advance(time.Millisecond)
+	_, ok, err := r.Add(second[0])
+	require.NoError(err)
+	assert.False(ok)
+	assert.Equal([]Incomplete{{ID: "first", Received: 1, Total: 4}}, expired)
+
+	clock.Advance(time.Second)
+	assert.Equal(1, r.Expire())
+	assert.Zero(r.Pending())
+	assert.Len(expired, 2)
+}
+
+func TestNewReassembler_invalid(t *testing.T) {
+	for _, opt := range []ReassemblerOption{Timeout(0), MaxPending(0)} {
+		r, err := NewReassembler(opt)
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+		assert.Nil(t, r)
+	}
+}
@@ -0,0 +1,222 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DefaultPollInterval is the time waited between polls of a status resource
+// when the server does not send a Retry-After header.
+const DefaultPollInterval = time.Second
+
+var (
+	errAwait           = errors.New("asynchronous response error")
+	errMissingLocation = errors.New("accepted response has no Location")
+)
+
+// Accepted describes a request the server accepted for asynchronous
+// processing with a 202 Accepted response, e.g. because the device has to be
+// woken up first.
+type Accepted struct {
+	// Location is the status resource of the operation, from the Location
+	// header resolved against the request URL.  It is nil if the response had
+	// no Location header.
+	Location *url.URL
+
+	// RetryAfter is how long the server asked the client to wait before
+	// checking on the operation, from the Retry-After header.  It is zero if
+	// the response had no valid Retry-After header.
+	RetryAfter time.Duration
+
+	// Header is the header of the 202 Accepted response.
+	Header http.Header
+}
+
+// Awaiter waits for the outcome of an asynchronous operation, e.g. by polling
+// its status resource or by waiting for a callback.  The returned response is
+// handled as the response to the original request, so a successful response
+// must carry the WRP response in its body.  Awaiters must stop waiting when
+// the context is canceled.
+type Awaiter interface {
+	Await(context.Context, *Accepted) (*http.Response, error)
+}
+
+// AwaiterFunc is a function that implements Awaiter.
+type AwaiterFunc func(context.Context, *Accepted) (*http.Response, error)
+
+func (f AwaiterFunc) Await(ctx context.Context, a *Accepted) (*http.Response, error) {
+	return f(ctx, a)
+}
+
+// WithAwaiter configures the client to wait for the outcome of requests the
+// server accepts with a 202 Accepted response using the Awaiter.  By default,
+// a 202 Accepted response is handled as any other successful response.
+func WithAwaiter(a Awaiter) Option {
+	return func(c *Client) {
+		c.awaiter = a
+	}
+}
+
+// WithPolling configures the client to poll the status resource named by the
+// Location header of a 202 Accepted response until the WRP response is
+// available.  Each poll is a GET request made after waiting for the duration
+// of the Retry-After header of the previous response, or interval if it has
+// none.  If interval is not positive, DefaultPollInterval is used.
+//
+// A poll that results in a 202 Accepted response, or a 429 Too Many Requests or
+// 503 Service Unavailable response with a Retry-After header, is polled again.
+// Any other response is handled as the response to the original request.
+// Polling stops when the context of the request is canceled.
+func WithPolling(interval time.Duration) Option {
+	return func(c *Client) {
+		if interval <= 0 {
+			interval = DefaultPollInterval
+		}
+
+		c.awaiter = &poller{
+			client:   c,
+			interval: interval,
+		}
+	}
+}
+
+// newAccepted describes the 202 Accepted response to the request.
+func newAccepted(req *http.Request, resp *http.Response, now time.Time) *Accepted {
+	a := Accepted{
+		Header: resp.Header,
+	}
+
+	if l := resp.Header.Get("Location"); l != "" {
+		if u, err := req.URL.Parse(l); err == nil {
+			a.Location = u
+		}
+	}
+
+	a.RetryAfter, _ = retryAfter(resp.Header, now)
+	return &a
+}
+
+// retryAfter returns the duration of the Retry-After header, which is either a
+// number of seconds or an HTTP date, and whether it was present and valid.
+func retryAfter(h http.Header, now time.Time) (time.Duration, bool) {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if s, err := strconv.ParseInt(v, 10, 64); err == nil {
+		if s < 0 {
+			return 0, false
+		}
+
+		return time.Duration(s) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// poller is the Awaiter created by WithPolling.
+type poller struct {
+	client   *Client
+	interval time.Duration
+}
+
+func (p *poller) Await(ctx context.Context, a *Accepted) (*http.Response, error) {
+	if a.Location == nil {
+		return nil, errMissingLocation
+	}
+
+	location := a.Location
+	wait := a.RetryAfter
+	if _, ok := retryAfter(a.Header, p.client.now()); !ok {
+		wait = p.interval
+	}
+
+	for {
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, location.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", p.client.requestFormat.ContentType())
+
+		resp, err := p.client.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		d, hasRetryAfter := retryAfter(resp.Header, p.client.now())
+		switch {
+		case resp.StatusCode == http.StatusAccepted:
+			next := newAccepted(req, resp, p.client.now())
+			if next.Location != nil {
+				location = next.Location
+			}
+		case hasRetryAfter && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable):
+		default:
+			return resp, nil
+		}
+
+		discard(resp)
+		wait = p.interval
+		if hasRetryAfter {
+			wait = d
+		}
+	}
+}
+
+// sleep waits for the duration, or until the context is canceled.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// discard reads and closes the body of a response that is not used, so the
+// connection can be reused.
+func discard(resp *http.Response) {
+	if resp.Body != nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+}
+
+// await waits for the outcome of an accepted request with the client's
+// Awaiter.
+func (c *Client) await(ctx context.Context, req *http.Request, resp *http.Response) (*http.Response, error) {
+	a := newAccepted(req, resp, c.now())
+	discard(resp)
+
+	next, err := c.awaiter.Await(ctx, a)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errAwait, err)
+	}
+
+	return next, nil
+}
@@ -0,0 +1,256 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		desc     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{desc: "missing"},
+		{desc: "seconds", value: "3", expected: 3 * time.Second, ok: true},
+		{desc: "zero", value: "0", ok: true},
+		{desc: "negative", value: "-1"},
+		{desc: "date", value: now.Add(time.Minute).Format(http.TimeFormat), expected: time.Minute, ok: true},
+		{desc: "past date", value: now.Add(-time.Minute).Format(http.TimeFormat), ok: true},
+		{desc: "invalid", value: "soon"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			h := http.Header{}
+			if tc.value != "" {
+				h.Set("Retry-After", tc.value)
+			}
+
+			d, ok := retryAfter(h, now)
+			assert.Equal(t, tc.expected, d)
+			assert.Equal(t, tc.ok, ok)
+		})
+	}
+}
+
+func TestWithPolling(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	expected := wrp.Message{
+		Type:            wrp.SimpleRequestResponseMessageType,
+		Source:          "mac:112233445566/config",
+		Destination:     "dns:example.com",
+		TransactionUUID: "1",
+		Payload:         []byte("done"),
+	}
+
+	var polls atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/status/1")
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("/status/1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(wrp.MimeTypeJson, r.Header.Get("Accept"))
+		switch polls.Add(1) {
+		case 1:
+			// still in progress, at a new location
+			w.Header().Set("Location", "/status/2")
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			t.Error("the status resource moved")
+		}
+	})
+	mux.HandleFunc("/status/2", func(w http.ResponseWriter, r *http.Request) {
+		switch polls.Add(1) {
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Header().Set("Content-Type", wrp.MimeTypeJson)
+			_, _ = w.Write(wrp.MustEncode(&expected, wrp.JSON))
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := New(server.URL+"/api", wrp.JSON, nil, WithPolling(time.Millisecond))
+	require.NoError(err)
+
+	var response wrp.Message
+	require.NoError(client.SendWRP(context.Background(), &response, &wrp.Message{Type: wrp.SimpleRequestResponseMessageType}))
+	assert.Equal(expected, response)
+	assert.Equal(int32(3), polls.Load())
+}
+
+func TestWithPolling_failures(t *testing.T) {
+	tests := []struct {
+		desc        string
+		post        http.HandlerFunc
+		status      http.HandlerFunc
+		timeout     time.Duration
+		expectedErr error
+	}{
+		{
+			desc: "missing location",
+			post: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+			},
+			expectedErr: errAwait,
+		}, {
+			desc: "failed operation",
+			post: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Location", "/status")
+				w.WriteHeader(http.StatusAccepted)
+			},
+			status: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusGatewayTimeout)
+			},
+			expectedErr: errNonSuccessfulResponse,
+		}, {
+			desc: "unavailable without retry-after",
+			post: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Location", "/status")
+				w.WriteHeader(http.StatusAccepted)
+			},
+			status: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			expectedErr: errNonSuccessfulResponse,
+		}, {
+			desc: "canceled",
+			post: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Location", "/status")
+				w.WriteHeader(http.StatusAccepted)
+			},
+			status: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+			},
+			timeout:     50 * time.Millisecond,
+			expectedErr: errAwait,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/", tc.post)
+			if tc.status != nil {
+				mux.HandleFunc("/status", tc.status)
+			}
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			client, err := New(server.URL, wrp.Msgpack, nil, WithPolling(0))
+			require.NoError(t, err)
+			client.awaiter.(*poller).interval = time.Millisecond
+
+			ctx := context.Background()
+			if tc.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+
+			err = client.SendWRP(ctx, &wrp.Message{}, &wrp.Message{Type: wrp.SimpleRequestResponseMessageType})
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestWithAwaiter(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Location", "/operations/1")
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	expected := wrp.Message{Type: wrp.SimpleRequestResponseMessageType, Payload: []byte("callback")}
+
+	// an awaiter that receives the response through a callback
+	callback := make(chan []byte, 1)
+	callback <- wrp.MustEncode(&expected, wrp.JSON)
+
+	var accepted *Accepted
+	client, err := New(server.URL, wrp.JSON, nil, WithAwaiter(AwaiterFunc(func(ctx context.Context, a *Accepted) (*http.Response, error) {
+		accepted = a
+		select {
+		case body := <-callback:
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(bytes.NewReader(body))}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})))
+	require.NoError(err)
+
+	var response wrp.Message
+	require.NoError(client.SendWRP(context.Background(), &response, &wrp.Message{Type: wrp.SimpleRequestResponseMessageType}))
+	assert.Equal(expected, response)
+	require.NotNil(accepted)
+	assert.Equal(server.URL+"/operations/1", accepted.Location.String())
+	assert.Equal(30*time.Second, accepted.RetryAfter)
+}
+
+func TestSendWRP_acceptedWithoutAwaiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL, wrp.Msgpack, nil)
+	require.NoError(t, err)
+
+	err = client.SendWRP(context.Background(), &wrp.Message{}, &wrp.Message{Type: wrp.SimpleRequestResponseMessageType})
+	assert.ErrorIs(t, err, errDecoding)
+}
+
+func TestWithClock(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Location", "/operations/1")
+		w.Header().Set("Retry-After", now.Add(time.Minute).Format(http.TimeFormat))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	var accepted *Accepted
+	client, err := New(server.URL, wrp.JSON, nil,
+		WithClock(wrp.ClockFunc(func() time.Time { return now })),
+		WithAwaiter(AwaiterFunc(func(_ context.Context, a *Accepted) (*http.Response, error) {
+			accepted = a
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(bytes.NewReader(wrp.MustEncode(&wrp.Message{}, wrp.JSON)))}, nil
+		})),
+	)
+	require.NoError(err)
+
+	require.NoError(client.SendWRP(context.Background(), &wrp.Message{}, &wrp.Message{Type: wrp.SimpleRequestResponseMessageType}))
+	require.NotNil(accepted)
+	assert.Equal(time.Minute, accepted.RetryAfter)
+}
@@ -8,11 +8,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/xmidt-org/httpaux/erraux"
 	"github.com/xmidt-org/wrp-go/v3"
+	"github.com/xmidt-org/wrp-go/v3/wrphttp"
 )
 
 var (
@@ -41,14 +44,62 @@ type Client struct {
 
 	// If unset, defaults to net/http.DefaultClient
 	httpClient HTTPClient
+
+	// headerAllow lists the WRP Headers projected to and from HTTP headers.
+	headerAllow []string
+
+	// awaiter waits for the outcome of requests accepted for asynchronous
+	// processing.  If nil, 202 Accepted responses are not treated specially.
+	awaiter Awaiter
+
+	// clock is the source of the current time, against which Retry-After
+	// dates are evaluated.  If nil, wrp.SystemClock is used.
+	clock wrp.Clock
+}
+
+// Option is a configurable option for a Client.
+type Option func(*Client)
+
+// WithHeaderProjection configures the client to project the WRP Headers of a
+// *wrp.Message request named in allow onto the HTTP request, and to add the
+// allowed HTTP headers and trailers of the response to the Headers of a
+// *wrp.Message response.  See wrphttp.CollectWRPHeaders for how trailers are
+// handled and wrphttp.ProjectWRPHeaders for how names are handled.
+func WithHeaderProjection(allow ...string) Option {
+	return func(c *Client) {
+		c.headerAllow = allow
+	}
+}
+
+// WithClock configures the client to evaluate the HTTP dates of Retry-After
+// headers against the clock.  The default is wrp.SystemClock.  The clock does
+// not affect how long the client waits.
+func WithClock(clock wrp.Clock) Option {
+	return func(c *Client) {
+		c.clock = clock
+	}
 }
 
-func New(reqURL string, reqFormat wrp.Format, httpClient HTTPClient) (*Client, error) {
+// now returns the current time of the client's clock.
+func (c *Client) now() time.Time {
+	if c.clock == nil {
+		return wrp.SystemClock.Now()
+	}
+
+	return c.clock.Now()
+}
+
+func New(reqURL string, reqFormat wrp.Format, httpClient HTTPClient, opts ...Option) (*Client, error) {
 	c := Client{
 		url:           reqURL,
 		requestFormat: reqFormat,
 		httpClient:    httpClient,
 	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
 	if c.url == "" {
 		c.url = "http://localhost:6200"
 	}
@@ -78,12 +129,25 @@ func (c *Client) SendWRP(ctx context.Context, response, request interface{}) err
 	if err != nil {
 		return fmt.Errorf("%w: %v", errCreateRequest, err)
 	}
+	if msg, ok := request.(*wrp.Message); ok {
+		wrphttp.ProjectWRPHeaders(r.Header, msg, c.headerAllow...)
+	}
 
 	// Use c.HTTPClient or http.DefaultClient to execute the HTTP transaction
 	resp, err := c.httpClient.Do(r.WithContext(ctx))
 	if err != nil {
 		return fmt.Errorf("%w: %v", errHTTPTransaction, err)
-	} else if resp.StatusCode >= 300 || resp.StatusCode < 200 {
+	}
+
+	// Wait for the outcome of a request accepted for asynchronous processing
+	if resp.StatusCode == http.StatusAccepted && c.awaiter != nil {
+		resp, err = c.await(ctx, r, resp)
+		if err != nil {
+			return err
+		}
+	}
+
+	if resp.StatusCode >= 300 || resp.StatusCode < 200 {
 		err := &erraux.Error{
 			Err:     err,
 			Code:    resp.StatusCode,
@@ -99,6 +163,12 @@ func (c *Client) SendWRP(ctx context.Context, response, request interface{}) err
 	if err != nil {
 		return fmt.Errorf("%w: %v", errDecoding, err)
 	}
+	if msg, ok := response.(*wrp.Message); ok && len(c.headerAllow) > 0 {
+		// trailers are only available once the body has been read to the end
+		_, _ = io.Copy(io.Discard, resp.Body)
+		wrphttp.CollectWRPHeaders(msg, resp.Header, c.headerAllow...)
+		wrphttp.CollectWRPHeaders(msg, resp.Trailer, c.headerAllow...)
+	}
 
 	return nil
 }
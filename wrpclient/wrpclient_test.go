@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -173,3 +174,52 @@ func TestSendWRP(t *testing.T) {
 		})
 	}
 }
+
+func TestSendWRP_headerProjection(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("abc", r.Header.Get("X-Trace"))
+		assert.Empty(r.Header.Get("X-Private"))
+
+		w.Header().Set("X-Custom", "from-server")
+		w.Header().Set("X-Private", "not allowed")
+		_ = wrp.NewEncoder(w, wrp.JSON).Encode(&wrp.Message{Type: wrp.SimpleRequestResponseMessageType})
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL, wrp.JSON, nil, WithHeaderProjection("X-Trace", "X-Custom"), nil)
+	require.NoError(err)
+
+	request := &wrp.Message{
+		Type:    wrp.SimpleRequestResponseMessageType,
+		Headers: []string{"X-Trace: abc", "X-Private: secret"},
+	}
+
+	var response wrp.Message
+	require.NoError(client.SendWRP(context.Background(), &response, request))
+	assert.Equal([]string{"X-Custom: from-server"}, response.Headers)
+}
+
+func TestSendWRP_trailerProjection(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Trace", "from-header")
+		w.Header().Set("Trailer", "X-Custom, X-Trace, X-Private")
+		_ = wrp.NewEncoder(w, wrp.JSON).Encode(&wrp.Message{Type: wrp.SimpleRequestResponseMessageType})
+		w.Header().Set("X-Custom", "from-trailer")
+		w.Header().Set("X-Trace", "ignored")
+		w.Header().Set("X-Private", "not allowed")
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL, wrp.JSON, nil, WithHeaderProjection("X-Trace", "X-Custom"), nil)
+	require.NoError(err)
+
+	var response wrp.Message
+	require.NoError(client.SendWRP(context.Background(), &response, &wrp.Message{Type: wrp.SimpleRequestResponseMessageType}))
+	assert.Equal([]string{"X-Trace: from-header", "X-Custom: from-trailer"}, response.Headers)
+}
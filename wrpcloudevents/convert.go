@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpcloudevents
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xmidt-org/wrp-go/v3"
+	"github.com/xmidt-org/wrp-go/v3/wrpevent"
+)
+
+const (
+	// DefaultTypePrefix is the prefix of the type of the events created by a
+	// Converter, which is followed by the classifier of the event.
+	DefaultTypePrefix = "com.xmidt.wrp."
+
+	// PartnerIDsExtension is the extension that holds the partner IDs of a
+	// message, separated by commas.
+	PartnerIDsExtension = "partnerids"
+
+	// QOSExtension is the extension that holds the QualityOfService of a
+	// message.
+	QOSExtension = "qos"
+)
+
+var (
+	ErrInvalidConverter = errors.New("invalid CloudEvents converter configuration")
+	ErrUnknownType      = errors.New("unknown CloudEvent type")
+)
+
+// ConverterOption is a functional option for NewConverter.
+type ConverterOption interface {
+	apply(*Converter) error
+}
+
+type converterOptionFunc func(*Converter) error
+
+func (f converterOptionFunc) apply(c *Converter) error {
+	return f(c)
+}
+
+// TypePrefix sets the prefix of the event types, which must not be empty.  The
+// default is DefaultTypePrefix.
+func TypePrefix(prefix string) ConverterOption {
+	return converterOptionFunc(func(c *Converter) error {
+		if prefix == "" {
+			return fmt.Errorf("%w: empty type prefix", ErrInvalidConverter)
+		}
+		c.prefix = prefix
+		return nil
+	})
+}
+
+// IDs sets the generator of the IDs of events created from messages without a
+// TransactionUUID.  The default is wrp.UUIDGenerator.
+func IDs(gen wrp.IDGenerator) ConverterOption {
+	return converterOptionFunc(func(c *Converter) error {
+		if gen != nil {
+			c.ids = gen
+		}
+		return nil
+	})
+}
+
+// Clock sets the clock used for the time of the events.  The default is
+// wrp.SystemClock.
+func Clock(clock wrp.Clock) ConverterOption {
+	return converterOptionFunc(func(c *Converter) error {
+		if clock != nil {
+			c.clock = clock
+		}
+		return nil
+	})
+}
+
+// Converter converts SimpleEvent messages to and from CloudEvents.  A
+// Converter is safe for concurrent use.
+type Converter struct {
+	prefix string
+	ids    wrp.IDGenerator
+	clock  wrp.Clock
+}
+
+// NewConverter creates a Converter with the given options.
+func NewConverter(opts ...ConverterOption) (*Converter, error) {
+	c := Converter{
+		prefix: DefaultTypePrefix,
+		ids:    wrp.UUIDGenerator,
+		clock:  wrp.SystemClock,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			if err := opt.apply(&c); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &c, nil
+}
+
+// FromMessage converts a SimpleEvent message to an Event.  The message's
+// Destination must be an event locator.
+func (c *Converter) FromMessage(msg *wrp.Message) (*Event, error) {
+	if err := wrp.CheckUnionType(msg.Type, wrp.SimpleEventMessageType); err != nil {
+		return nil, err
+	}
+
+	if msg.Source == "" {
+		return nil, fmt.Errorf("%w: message has no source", ErrInvalidEvent)
+	}
+
+	ev, err := wrpevent.Parse(msg.Destination)
+	if err != nil {
+		return nil, err
+	}
+
+	id := msg.TransactionUUID
+	if id == "" {
+		if id, err = c.ids.NewID(); err != nil {
+			return nil, err
+		}
+	}
+
+	e := Event{
+		ID:              id,
+		Source:          msg.Source,
+		SpecVersion:     SpecVersion,
+		Type:            c.prefix + string(ev.Classifier),
+		DataContentType: msg.ContentType,
+		Subject:         ev.Path,
+		Time:            c.clock.Now().UTC(),
+		Data:            msg.Payload,
+	}
+
+	if len(msg.PartnerIDs) > 0 || msg.QualityOfService != 0 {
+		e.Extensions = make(map[string]interface{}, 2)
+	}
+
+	if len(msg.PartnerIDs) > 0 {
+		e.Extensions[PartnerIDsExtension] = strings.Join(msg.PartnerIDs, ",")
+	}
+
+	if msg.QualityOfService != 0 {
+		e.Extensions[QOSExtension] = int64(msg.QualityOfService)
+	}
+
+	return &e, nil
+}
+
+// ToMessage converts an Event to a SimpleEvent message.  The event's type must
+// have the prefix of the Converter.  Extensions other than partnerids and qos
+// are dropped.
+func (c *Converter) ToMessage(e *Event) (*wrp.Message, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+
+	classifier, ok := strings.CutPrefix(e.Type, c.prefix)
+	if !ok || classifier == "" {
+		return nil, fmt.Errorf("%w: `%s`", ErrUnknownType, e.Type)
+	}
+
+	msg := wrp.Message{
+		Type:            wrp.SimpleEventMessageType,
+		Source:          e.Source,
+		Destination:     wrpevent.Event{Classifier: wrpevent.Classifier(classifier), Path: e.Subject}.Destination(),
+		TransactionUUID: e.ID,
+		ContentType:     e.DataContentType,
+		Payload:         e.Data,
+	}
+
+	if v, ok := e.Extensions[PartnerIDsExtension]; ok {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s is not a string", ErrInvalidEvent, PartnerIDsExtension)
+		}
+
+		for _, id := range strings.Split(s, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				msg.PartnerIDs = append(msg.PartnerIDs, id)
+			}
+		}
+	}
+
+	if v, ok := e.Extensions[QOSExtension]; ok {
+		qos, err := integer(v)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidEvent, QOSExtension, err)
+		}
+		msg.QualityOfService = wrp.QOSValue(qos)
+	}
+
+	return &msg, nil
+}
+
+// integer converts an integer extension value, which some transports encode
+// as a string.
+func integer(v interface{}) (int64, error) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case string:
+		return strconv.ParseInt(v, 10, 32)
+	default:
+		return 0, fmt.Errorf("%v is not an integer", v)
+	}
+}
@@ -0,0 +1,284 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpcloudevents
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+	"github.com/xmidt-org/wrp-go/v3/wrpevent"
+	"github.com/xmidt-org/wrp-go/v3/wrptest"
+)
+
+var testTime = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestConverter(t *testing.T, opts ...ConverterOption) *Converter {
+	opts = append([]ConverterOption{
+		Clock(wrptest.NewFakeClock(testTime)),
+		IDs(&wrptest.SequentialIDs{}),
+	}, opts...)
+
+	c, err := NewConverter(opts...)
+	require.NoError(t, err)
+	return c
+}
+
+func TestConverter(t *testing.T) {
+	tests := []struct {
+		desc     string
+		msg      wrp.Message
+		expected Event
+		json     string
+	}{
+		{
+			desc: "all fields",
+			msg: wrp.Message{
+				Type:             wrp.SimpleEventMessageType,
+				Source:           "mac:112233445566",
+				Destination:      "event:device-status/mac:112233445566/online",
+				TransactionUUID:  "123e4567-e89b-12d3-a456-426614174000",
+				ContentType:      "application/json",
+				PartnerIDs:       []string{"comcast", "sky"},
+				QualityOfService: wrp.QOSHighValue,
+				Payload:          []byte(`{"id":"mac:112233445566"}`),
+			},
+			expected: Event{
+				ID:              "123e4567-e89b-12d3-a456-426614174000",
+				Source:          "mac:112233445566",
+				SpecVersion:     SpecVersion,
+				Type:            "com.xmidt.wrp.device-status",
+				DataContentType: "application/json",
+				Subject:         "mac:112233445566/online",
+				Time:            testTime,
+				Extensions: map[string]interface{}{
+					PartnerIDsExtension: "comcast,sky",
+					QOSExtension:        int64(wrp.QOSHighValue),
+				},
+				Data: []byte(`{"id":"mac:112233445566"}`),
+			},
+			json: `{
+				"id": "123e4567-e89b-12d3-a456-426614174000",
+				"source": "mac:112233445566",
+				"specversion": "1.0",
+				"type": "com.xmidt.wrp.device-status",
+				"datacontenttype": "application/json",
+				"subject": "mac:112233445566/online",
+				"time": "2025-01-01T12:00:00Z",
+				"partnerids": "comcast,sky",
+				"qos": 50,
+				"data": {"id": "mac:112233445566"}
+			}`,
+		}, {
+			desc: "generated ID",
+			msg: wrp.Message{
+				Type:        wrp.SimpleEventMessageType,
+				Source:      "mac:112233445566",
+				Destination: "event:reboot",
+				ContentType: "application/octet-stream",
+				Payload:     []byte{0xde, 0xad},
+			},
+			expected: Event{
+				ID:              wrptest.SequentialID(1),
+				Source:          "mac:112233445566",
+				SpecVersion:     SpecVersion,
+				Type:            "com.xmidt.wrp.reboot",
+				DataContentType: "application/octet-stream",
+				Time:            testTime,
+				Data:            []byte{0xde, 0xad},
+			},
+			json: `{
+				"id": "00000000-0000-4000-8000-000000000001",
+				"source": "mac:112233445566",
+				"specversion": "1.0",
+				"type": "com.xmidt.wrp.reboot",
+				"datacontenttype": "application/octet-stream",
+				"time": "2025-01-01T12:00:00Z",
+				"data_base64": "3q0="
+			}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+			c := newTestConverter(t)
+
+			e, err := c.FromMessage(&tc.msg)
+			require.NoError(err)
+			assert.Equal(tc.expected, *e)
+
+			b, err := json.Marshal(e)
+			require.NoError(err)
+			assert.JSONEq(tc.json, string(b))
+
+			var decoded Event
+			require.NoError(json.Unmarshal(b, &decoded))
+			assert.Equal(tc.expected, decoded)
+
+			msg, err := c.ToMessage(&decoded)
+			require.NoError(err)
+
+			expected := tc.msg
+			if expected.TransactionUUID == "" {
+				expected.TransactionUUID = tc.expected.ID
+			}
+			assert.Equal(expected, *msg)
+		})
+	}
+}
+
+func TestConverter_FromMessage_invalid(t *testing.T) {
+	errGen := errors.New("expected")
+
+	tests := []struct {
+		desc     string
+		opts     []ConverterOption
+		msg      wrp.Message
+		expected error
+	}{
+		{
+			desc:     "not an event",
+			msg:      wrp.Message{Type: wrp.SimpleRequestResponseMessageType},
+			expected: wrp.ErrMessageTypeMismatch,
+		}, {
+			desc: "no source",
+			msg: wrp.Message{
+				Type:        wrp.SimpleEventMessageType,
+				Destination: "event:reboot",
+			},
+			expected: ErrInvalidEvent,
+		}, {
+			desc: "not an event locator",
+			msg: wrp.Message{
+				Type:        wrp.SimpleEventMessageType,
+				Source:      "mac:112233445566",
+				Destination: "dns:example.com",
+			},
+			expected: wrpevent.ErrNotEvent,
+		}, {
+			desc: "ID generator error",
+			opts: []ConverterOption{IDs(wrp.IDGeneratorFunc(func() (string, error) {
+				return "", errGen
+			}))},
+			msg: wrp.Message{
+				Type:        wrp.SimpleEventMessageType,
+				Source:      "mac:112233445566",
+				Destination: "event:reboot",
+			},
+			expected: errGen,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			e, err := newTestConverter(t, tc.opts...).FromMessage(&tc.msg)
+			assert.ErrorIs(t, err, tc.expected)
+			assert.Nil(t, e)
+		})
+	}
+}
+
+func TestConverter_ToMessage(t *testing.T) {
+	tests := []struct {
+		desc       string
+		extensions map[string]interface{}
+		typ        string
+		expected   error
+		partnerIDs []string
+		qos        wrp.QOSValue
+	}{
+		{
+			desc: "string qos",
+			extensions: map[string]interface{}{
+				PartnerIDsExtension: " comcast, ,sky",
+				QOSExtension:        "75",
+				"other":             true,
+			},
+			partnerIDs: []string{"comcast", "sky"},
+			qos:        wrp.QOSCriticalValue,
+		}, {
+			desc:       "int qos",
+			extensions: map[string]interface{}{QOSExtension: 25},
+			qos:        wrp.QOSMediumValue,
+		}, {
+			desc:     "unknown type",
+			typ:      "com.example.reboot",
+			expected: ErrUnknownType,
+		}, {
+			desc:     "no classifier",
+			typ:      DefaultTypePrefix,
+			expected: ErrUnknownType,
+		}, {
+			desc:       "invalid partner IDs",
+			extensions: map[string]interface{}{PartnerIDsExtension: int64(1)},
+			expected:   ErrInvalidEvent,
+		}, {
+			desc:       "invalid qos",
+			extensions: map[string]interface{}{QOSExtension: "high"},
+			expected:   ErrInvalidEvent,
+		}, {
+			desc:       "boolean qos",
+			extensions: map[string]interface{}{QOSExtension: true},
+			expected:   ErrInvalidEvent,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			e := Event{
+				ID:          "1",
+				Source:      "mac:112233445566",
+				SpecVersion: SpecVersion,
+				Type:        "com.xmidt.wrp.reboot",
+				Extensions:  tc.extensions,
+			}
+			if tc.typ != "" {
+				e.Type = tc.typ
+			}
+
+			msg, err := newTestConverter(t).ToMessage(&e)
+			assert.ErrorIs(err, tc.expected)
+			if tc.expected != nil {
+				assert.Nil(msg)
+				return
+			}
+
+			assert.Equal(&wrp.Message{
+				Type:             wrp.SimpleEventMessageType,
+				Source:           "mac:112233445566",
+				Destination:      "event:reboot",
+				TransactionUUID:  "1",
+				PartnerIDs:       tc.partnerIDs,
+				QualityOfService: tc.qos,
+			}, msg)
+		})
+	}
+}
+
+func TestNewConverter(t *testing.T) {
+	c, err := NewConverter(TypePrefix("com.example."), IDs(nil), Clock(nil), nil)
+	require.NoError(t, err)
+
+	e, err := c.FromMessage(&wrp.Message{
+		Type:        wrp.SimpleEventMessageType,
+		Source:      "mac:112233445566",
+		Destination: "event:reboot",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "com.example.reboot", e.Type)
+	assert.NotEmpty(t, e.ID)
+	assert.False(t, e.Time.IsZero())
+
+	c, err = NewConverter(TypePrefix(""))
+	assert.ErrorIs(t, err, ErrInvalidConverter)
+	assert.Nil(t, c)
+}
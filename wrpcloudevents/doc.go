@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package wrpcloudevents converts WRP SimpleEvent messages to and from
+CloudEvents v1.0, so that WRP events can be exchanged with CloudEvents native
+event buses.
+
+A Converter maps the fields of a message to the attributes of an Event:
+
+	source           Source
+	type             TypePrefix + the classifier of the Destination,
+	                 e.g. "com.xmidt.wrp.device-status"
+	subject          the path of the Destination after the classifier,
+	                 e.g. "mac:112233445566/online"
+	id               TransactionUUID, or a generated ID if it is empty
+	datacontenttype  ContentType
+	data             Payload
+	partnerids       PartnerIDs, as a comma separated extension
+	qos              QualityOfService, as an integer extension
+
+The other fields of the message are not carried by the Event.  Events are
+encoded in the structured JSON format of the CloudEvents specification, whose
+media type is ContentType.
+*/
+package wrpcloudevents
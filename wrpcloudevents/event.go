@@ -0,0 +1,285 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpcloudevents
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+)
+
+const (
+	// SpecVersion is the version of the CloudEvents specification of an Event.
+	SpecVersion = "1.0"
+
+	// ContentType is the media type of an Event in the structured JSON format.
+	ContentType = "application/cloudevents+json"
+)
+
+var ErrInvalidEvent = errors.New("invalid CloudEvent")
+
+// attributes are the names of the context attributes defined by the
+// specification, which cannot be used as extensions.
+var attributes = map[string]bool{
+	"id":              true,
+	"source":          true,
+	"specversion":     true,
+	"type":            true,
+	"datacontenttype": true,
+	"dataschema":      true,
+	"subject":         true,
+	"time":            true,
+	"data":            true,
+	"data_base64":     true,
+}
+
+// Event is a CloudEvent.  Its JSON encoding is the structured JSON format of
+// the specification.
+type Event struct {
+	// ID identifies the event.  It is required.
+	ID string
+
+	// Source identifies the context in which the event happened.  It is
+	// required.
+	Source string
+
+	// SpecVersion is the version of the specification the event uses.  It is
+	// required and must be SpecVersion.
+	SpecVersion string
+
+	// Type is the type of the event.  It is required.
+	Type string
+
+	// DataContentType is the media type of Data.
+	DataContentType string
+
+	// DataSchema is the URI of the schema Data adheres to.
+	DataSchema string
+
+	// Subject is the subject of the event in the context of Source.
+	Subject string
+
+	// Time is when the event happened.  The zero time is omitted.
+	Time time.Time
+
+	// Extensions are the extension attributes of the event.  Values must be
+	// strings, booleans or integers.
+	Extensions map[string]interface{}
+
+	// Data is the payload of the event.
+	Data []byte
+}
+
+// Validate checks that the required attributes are set and that the names of
+// the extensions are valid.
+func (e *Event) Validate() error {
+	var missing []string
+	for _, a := range []struct {
+		name, value string
+	}{
+		{"id", e.ID},
+		{"source", e.Source},
+		{"specversion", e.SpecVersion},
+		{"type", e.Type},
+	} {
+		if a.value == "" {
+			missing = append(missing, a.name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: missing %s", ErrInvalidEvent, strings.Join(missing, ", "))
+	}
+
+	if e.SpecVersion != SpecVersion {
+		return fmt.Errorf("%w: unsupported specversion `%s`", ErrInvalidEvent, e.SpecVersion)
+	}
+
+	for name := range e.Extensions {
+		if !validExtensionName(name) {
+			return fmt.Errorf("%w: invalid extension name `%s`", ErrInvalidEvent, name)
+		}
+	}
+
+	return nil
+}
+
+// validExtensionName tests if the name consists of lowercase letters and
+// digits, and is not the name of a context attribute.
+func validExtensionName(name string) bool {
+	if name == "" || attributes[name] {
+		return false
+	}
+
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isJSON tests if the media type is JSON.  A missing media type is JSON in the
+// structured JSON format.
+func isJSON(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+
+	mt, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mt == "application/json" || strings.HasSuffix(mt, "+json"))
+}
+
+// MarshalJSON encodes the event in the structured JSON format.  Data is
+// encoded as JSON if DataContentType is JSON and Data is valid JSON, and as
+// data_base64 otherwise.
+func (e *Event) MarshalJSON() ([]byte, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]interface{}, len(e.Extensions)+9)
+	for name, value := range e.Extensions {
+		m[name] = value
+	}
+
+	m["id"] = e.ID
+	m["source"] = e.Source
+	m["specversion"] = e.SpecVersion
+	m["type"] = e.Type
+	for name, value := range map[string]string{
+		"datacontenttype": e.DataContentType,
+		"dataschema":      e.DataSchema,
+		"subject":         e.Subject,
+	} {
+		if value != "" {
+			m[name] = value
+		}
+	}
+
+	if !e.Time.IsZero() {
+		m["time"] = e.Time.Format(time.RFC3339Nano)
+	}
+
+	switch {
+	case e.Data == nil:
+	case isJSON(e.DataContentType) && json.Valid(e.Data):
+		m["data"] = json.RawMessage(e.Data)
+	default:
+		m["data_base64"] = e.Data
+	}
+
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON decodes an event in the structured JSON format.  Members that
+// are not context attributes are decoded as extensions, with integers as
+// int64.
+func (e *Event) UnmarshalJSON(b []byte) error {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+
+	var (
+		v    Event
+		t    string
+		errs []error
+	)
+
+	for name, dst := range map[string]*string{
+		"id":              &v.ID,
+		"source":          &v.Source,
+		"specversion":     &v.SpecVersion,
+		"type":            &v.Type,
+		"datacontenttype": &v.DataContentType,
+		"dataschema":      &v.DataSchema,
+		"subject":         &v.Subject,
+		"time":            &t,
+	} {
+		if raw, ok := m[name]; ok {
+			if err := json.Unmarshal(raw, dst); err != nil {
+				errs = append(errs, fmt.Errorf("%w: %s: %w", ErrInvalidEvent, name, err))
+			}
+		}
+	}
+
+	if t != "" {
+		var err error
+		if v.Time, err = time.Parse(time.RFC3339Nano, t); err != nil {
+			errs = append(errs, fmt.Errorf("%w: time: %w", ErrInvalidEvent, err))
+		}
+	}
+
+	if raw, ok := m["data_base64"]; ok {
+		if err := json.Unmarshal(raw, &v.Data); err != nil {
+			errs = append(errs, fmt.Errorf("%w: data_base64: %w", ErrInvalidEvent, err))
+		}
+	} else if raw, ok := m["data"]; ok {
+		v.Data = data(raw, v.DataContentType)
+	}
+
+	for name, raw := range m {
+		if attributes[name] {
+			continue
+		}
+
+		if v.Extensions == nil {
+			v.Extensions = make(map[string]interface{})
+		}
+
+		value, err := extension(raw)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: %s: %w", ErrInvalidEvent, name, err))
+		}
+		v.Extensions[name] = value
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	if err := v.Validate(); err != nil {
+		return err
+	}
+
+	*e = v
+	return nil
+}
+
+// data returns the payload held by the data member.  A JSON string is the
+// payload itself unless the data is JSON.
+func data(raw json.RawMessage, contentType string) []byte {
+	var s string
+	if !isJSON(contentType) && json.Unmarshal(raw, &s) == nil {
+		return []byte(s)
+	}
+
+	return bytes.Clone(raw)
+}
+
+// extension decodes the value of an extension attribute.
+func extension(raw json.RawMessage) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+
+	var value interface{}
+	if err := d.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	switch v := value.(type) {
+	case string, bool:
+		return v, nil
+	case json.Number:
+		return v.Int64()
+	default:
+		return nil, fmt.Errorf("unsupported extension value %s", raw)
+	}
+}
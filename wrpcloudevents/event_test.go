@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpcloudevents
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvent_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    string
+		expected Event
+	}{
+		{
+			desc: "text data",
+			input: `{
+				"id": "1", "source": "/s", "specversion": "1.0", "type": "t",
+				"datacontenttype": "text/plain", "data": "hello"
+			}`,
+			expected: Event{
+				ID:              "1",
+				Source:          "/s",
+				SpecVersion:     "1.0",
+				Type:            "t",
+				DataContentType: "text/plain",
+				Data:            []byte("hello"),
+			},
+		}, {
+			desc: "JSON data without a content type",
+			input: `{
+				"id": "1", "source": "/s", "specversion": "1.0", "type": "t",
+				"dataschema": "https://example.com/schema", "data": "hello"
+			}`,
+			expected: Event{
+				ID:          "1",
+				Source:      "/s",
+				SpecVersion: "1.0",
+				Type:        "t",
+				DataSchema:  "https://example.com/schema",
+				Data:        []byte(`"hello"`),
+			},
+		}, {
+			desc: "extensions",
+			input: `{
+				"id": "1", "source": "/s", "specversion": "1.0", "type": "t",
+				"flag": true, "count": 3, "name": "x"
+			}`,
+			expected: Event{
+				ID:          "1",
+				Source:      "/s",
+				SpecVersion: "1.0",
+				Type:        "t",
+				Extensions: map[string]interface{}{
+					"flag":  true,
+					"count": int64(3),
+					"name":  "x",
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var e Event
+			require.NoError(t, json.Unmarshal([]byte(tc.input), &e))
+			assert.Equal(t, tc.expected, e)
+		})
+	}
+}
+
+func TestEvent_invalid(t *testing.T) {
+	tests := []struct {
+		desc  string
+		input string
+	}{
+		{
+			desc:  "missing attributes",
+			input: `{"id": "1", "specversion": "1.0"}`,
+		}, {
+			desc:  "specversion",
+			input: `{"id": "1", "source": "/s", "specversion": "0.3", "type": "t"}`,
+		}, {
+			desc:  "attribute type",
+			input: `{"id": 1, "source": "/s", "specversion": "1.0", "type": "t"}`,
+		}, {
+			desc:  "time",
+			input: `{"id": "1", "source": "/s", "specversion": "1.0", "type": "t", "time": "yesterday"}`,
+		}, {
+			desc:  "data_base64",
+			input: `{"id": "1", "source": "/s", "specversion": "1.0", "type": "t", "data_base64": "!"}`,
+		}, {
+			desc:  "extension value",
+			input: `{"id": "1", "source": "/s", "specversion": "1.0", "type": "t", "ext": [1]}`,
+		}, {
+			desc:  "extension name",
+			input: `{"id": "1", "source": "/s", "specversion": "1.0", "type": "t", "Ext": 1}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var e Event
+			assert.ErrorIs(t, json.Unmarshal([]byte(tc.input), &e), ErrInvalidEvent)
+			assert.Zero(t, e)
+		})
+	}
+
+	_, err := json.Marshal(&Event{ID: "1"})
+	assert.ErrorIs(t, err, ErrInvalidEvent)
+
+	assert.Error(t, json.Unmarshal([]byte(`[]`), &Event{}))
+}
@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpcompat
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// The Metadata keys used by PreserveInMetadata.
+const (
+	SessionIDKey        = "/wrp-session-id"
+	QualityOfServiceKey = "/wrp-qos"
+)
+
+var ErrLossyConversion = errors.New("conversion would lose fields")
+
+// Policy determines how ToLegacy handles fields that Legacy cannot hold.
+type Policy int
+
+const (
+	// Drop discards the fields.  They are still named in the Report.  This
+	// is the zero value.
+	Drop Policy = iota
+
+	// Reject fails the conversion with an error wrapping ErrLossyConversion.
+	Reject
+
+	// PreserveInMetadata stores the fields in the Metadata under SessionIDKey
+	// and QualityOfServiceKey.  FromLegacy with the same policy restores them.
+	PreserveInMetadata
+)
+
+// Legacy is the generic message struct of older releases, which have no
+// SessionID or QualityOfService fields.  Its wire form is the same as a
+// Message without those fields, so it can be encoded and decoded with the
+// wrp package's Encoders and Decoders.
+type Legacy struct {
+	Type                    wrp.MessageType   `json:"msg_type"`
+	Source                  string            `json:"source,omitempty"`
+	Destination             string            `json:"dest,omitempty"`
+	TransactionUUID         string            `json:"transaction_uuid,omitempty"`
+	ContentType             string            `json:"content_type,omitempty"`
+	Accept                  string            `json:"accept,omitempty"`
+	Status                  *int64            `json:"status,omitempty"`
+	RequestDeliveryResponse *int64            `json:"rdr,omitempty"`
+	Headers                 []string          `json:"headers,omitempty"`
+	Metadata                map[string]string `json:"metadata,omitempty"`
+	Spans                   [][]string        `json:"spans,omitempty"`
+	IncludeSpans            *bool             `json:"include_spans,omitempty"`
+	Path                    string            `json:"path,omitempty"`
+	Payload                 []byte            `json:"payload,omitempty"`
+	ServiceName             string            `json:"service_name,omitempty"`
+	URL                     string            `json:"url,omitempty"`
+	PartnerIDs              []string          `json:"partner_ids,omitempty"`
+}
+
+// Report describes the outcome of a conversion.
+type Report struct {
+	// Lost are the fields whose values were not carried over.
+	Lost []wrp.Field
+
+	// Preserved are the fields whose values were carried in the Metadata.
+	Preserved []wrp.Field
+}
+
+// Lossy returns true if any field was lost.
+func (r Report) Lossy() bool {
+	return len(r.Lost) > 0
+}
+
+// ToLegacy converts a message to a Legacy message according to the policy.
+// Slices, maps and pointers are shared with the message, except that the
+// Metadata is copied before values are added to it.
+func ToLegacy(msg *wrp.Message, p Policy) (*Legacy, Report, error) {
+	var (
+		report Report
+		extra  = make(map[string]string)
+	)
+
+	if msg.SessionID != "" {
+		extra[SessionIDKey] = msg.SessionID
+		report.Lost = append(report.Lost, wrp.SessionIDField)
+	}
+
+	if msg.QualityOfService != 0 {
+		extra[QualityOfServiceKey] = strconv.Itoa(int(msg.QualityOfService))
+		report.Lost = append(report.Lost, wrp.QualityOfServiceField)
+	}
+
+	l := Legacy{
+		Type:                    msg.Type,
+		Source:                  msg.Source,
+		Destination:             msg.Destination,
+		TransactionUUID:         msg.TransactionUUID,
+		ContentType:             msg.ContentType,
+		Accept:                  msg.Accept,
+		Status:                  msg.Status,
+		RequestDeliveryResponse: msg.RequestDeliveryResponse,
+		Headers:                 msg.Headers,
+		Metadata:                msg.Metadata,
+		Spans:                   msg.Spans,        // nolint:staticcheck
+		IncludeSpans:            msg.IncludeSpans, // nolint:staticcheck
+		Path:                    msg.Path,
+		Payload:                 msg.Payload,
+		ServiceName:             msg.ServiceName,
+		URL:                     msg.URL,
+		PartnerIDs:              msg.PartnerIDs,
+	}
+
+	if !report.Lossy() {
+		return &l, report, nil
+	}
+
+	switch p {
+	case Reject:
+		return nil, report, fmt.Errorf("%w: %v", ErrLossyConversion, report.Lost)
+
+	case PreserveInMetadata:
+		l.Metadata = maps.Clone(msg.Metadata)
+		if l.Metadata == nil {
+			l.Metadata = make(map[string]string, len(extra))
+		}
+		maps.Copy(l.Metadata, extra)
+
+		report.Preserved, report.Lost = report.Lost, nil
+	}
+
+	return &l, report, nil
+}
+
+// FromLegacy converts a Legacy message to a message.  A Message can hold
+// every Legacy field, so nothing is lost.  With the PreserveInMetadata policy,
+// the SessionID and QualityOfService are restored from the Metadata and their
+// keys are removed; a QualityOfServiceKey value that is not an integer is left
+// in the Metadata.  Slices, maps and pointers are shared with the Legacy
+// message, except that the Metadata is copied before keys are removed.
+func FromLegacy(l *Legacy, p Policy) (*wrp.Message, Report) {
+	var report Report
+
+	msg := wrp.Message{
+		Type:                    l.Type,
+		Source:                  l.Source,
+		Destination:             l.Destination,
+		TransactionUUID:         l.TransactionUUID,
+		ContentType:             l.ContentType,
+		Accept:                  l.Accept,
+		Status:                  l.Status,
+		RequestDeliveryResponse: l.RequestDeliveryResponse,
+		Headers:                 l.Headers,
+		Metadata:                l.Metadata,
+		Spans:                   l.Spans,        // nolint:staticcheck
+		IncludeSpans:            l.IncludeSpans, // nolint:staticcheck
+		Path:                    l.Path,
+		Payload:                 l.Payload,
+		ServiceName:             l.ServiceName,
+		URL:                     l.URL,
+		PartnerIDs:              l.PartnerIDs,
+	}
+
+	if p != PreserveInMetadata {
+		return &msg, report
+	}
+
+	sessionID, hasSessionID := l.Metadata[SessionIDKey]
+	qos, hasQOS := l.Metadata[QualityOfServiceKey]
+	if !hasSessionID && !hasQOS {
+		return &msg, report
+	}
+
+	msg.Metadata = maps.Clone(l.Metadata)
+	if hasSessionID {
+		msg.SessionID = sessionID
+		delete(msg.Metadata, SessionIDKey)
+		report.Preserved = append(report.Preserved, wrp.SessionIDField)
+	}
+
+	if hasQOS {
+		if v, err := strconv.Atoi(qos); err == nil {
+			msg.QualityOfService = wrp.QOSValue(v)
+			delete(msg.Metadata, QualityOfServiceKey)
+			report.Preserved = append(report.Preserved, wrp.QualityOfServiceField)
+		}
+	}
+
+	if len(msg.Metadata) == 0 {
+		msg.Metadata = nil
+	}
+
+	return &msg, report
+}
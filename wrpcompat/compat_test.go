@@ -0,0 +1,206 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpcompat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func testMessage() wrp.Message {
+	return wrp.Message{
+		Type:            wrp.SimpleEventMessageType,
+		Source:          "mac:112233445566",
+		Destination:     "event:device-status/mac:112233445566/online",
+		TransactionUUID: "1234",
+		ContentType:     "application/json",
+		Headers:         []string{"X-Test: 1"},
+		Metadata:        map[string]string{"/boot-time": "1700000000"},
+		Payload:         []byte(`{}`),
+		PartnerIDs:      []string{"comcast"},
+	}
+}
+
+func TestToLegacy(t *testing.T) {
+	tests := []struct {
+		desc      string
+		modify    func(*wrp.Message)
+		policy    Policy
+		expectErr bool
+		metadata  map[string]string
+		lost      []wrp.Field
+		preserved []wrp.Field
+	}{
+		{
+			desc:     "nothing to lose",
+			policy:   Reject,
+			metadata: map[string]string{"/boot-time": "1700000000"},
+		}, {
+			desc: "drop",
+			modify: func(msg *wrp.Message) {
+				msg.SessionID = "session"
+				msg.QualityOfService = wrp.QOSHighValue
+			},
+			metadata: map[string]string{"/boot-time": "1700000000"},
+			lost:     []wrp.Field{wrp.SessionIDField, wrp.QualityOfServiceField},
+		}, {
+			desc: "reject",
+			modify: func(msg *wrp.Message) {
+				msg.QualityOfService = wrp.QOSHighValue
+			},
+			policy:    Reject,
+			expectErr: true,
+			lost:      []wrp.Field{wrp.QualityOfServiceField},
+		}, {
+			desc: "preserve",
+			modify: func(msg *wrp.Message) {
+				msg.SessionID = "session"
+				msg.QualityOfService = wrp.QOSHighValue
+			},
+			policy: PreserveInMetadata,
+			metadata: map[string]string{
+				"/boot-time":        "1700000000",
+				SessionIDKey:        "session",
+				QualityOfServiceKey: "50",
+			},
+			preserved: []wrp.Field{wrp.SessionIDField, wrp.QualityOfServiceField},
+		}, {
+			desc: "preserve without metadata",
+			modify: func(msg *wrp.Message) {
+				msg.Metadata = nil
+				msg.SessionID = "session"
+			},
+			policy:    PreserveInMetadata,
+			metadata:  map[string]string{SessionIDKey: "session"},
+			preserved: []wrp.Field{wrp.SessionIDField},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			msg := testMessage()
+			if tc.modify != nil {
+				tc.modify(&msg)
+			}
+
+			l, report, err := ToLegacy(&msg, tc.policy)
+			assert.Equal(tc.lost, report.Lost)
+			assert.Equal(tc.preserved, report.Preserved)
+			assert.Equal(len(tc.lost) > 0, report.Lossy())
+
+			if tc.expectErr {
+				assert.ErrorIs(err, ErrLossyConversion)
+				assert.Nil(l)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(msg.Source, l.Source)
+			assert.Equal(msg.Payload, l.Payload)
+			assert.Equal(tc.metadata, l.Metadata)
+
+			// the original message is never modified
+			expected := testMessage()
+			if tc.modify != nil {
+				tc.modify(&expected)
+			}
+			assert.Equal(expected, msg)
+		})
+	}
+}
+
+func TestFromLegacy(t *testing.T) {
+	tests := []struct {
+		desc      string
+		metadata  map[string]string
+		policy    Policy
+		expected  func(*wrp.Message)
+		preserved []wrp.Field
+	}{
+		{
+			desc:     "drop ignores metadata",
+			metadata: map[string]string{SessionIDKey: "session"},
+			expected: func(msg *wrp.Message) {
+				msg.Metadata = map[string]string{SessionIDKey: "session"}
+			},
+		}, {
+			desc:     "preserve restores",
+			metadata: map[string]string{"/boot-time": "1", SessionIDKey: "session", QualityOfServiceKey: "75"},
+			policy:   PreserveInMetadata,
+			expected: func(msg *wrp.Message) {
+				msg.Metadata = map[string]string{"/boot-time": "1"}
+				msg.SessionID = "session"
+				msg.QualityOfService = wrp.QOSCriticalValue
+			},
+			preserved: []wrp.Field{wrp.SessionIDField, wrp.QualityOfServiceField},
+		}, {
+			desc:     "preserve removes empty metadata",
+			metadata: map[string]string{SessionIDKey: "session"},
+			policy:   PreserveInMetadata,
+			expected: func(msg *wrp.Message) {
+				msg.Metadata = nil
+				msg.SessionID = "session"
+			},
+			preserved: []wrp.Field{wrp.SessionIDField},
+		}, {
+			desc:     "invalid qos is left in the metadata",
+			metadata: map[string]string{QualityOfServiceKey: "high"},
+			policy:   PreserveInMetadata,
+			expected: func(msg *wrp.Message) {
+				msg.Metadata = map[string]string{QualityOfServiceKey: "high"}
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			msg := testMessage()
+			l, _, err := ToLegacy(&msg, Drop)
+			require.NoError(t, err)
+			l.Metadata = tc.metadata
+
+			actual, report := FromLegacy(l, tc.policy)
+
+			expected := testMessage()
+			tc.expected(&expected)
+			assert.Equal(expected, *actual)
+			assert.Equal(tc.preserved, report.Preserved)
+			assert.False(report.Lossy())
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, f := range wrp.AllFormats() {
+		t.Run(f.String(), func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			msg := testMessage()
+			msg.SessionID = "session"
+			msg.QualityOfService = wrp.QOSMediumValue
+
+			l, _, err := ToLegacy(&msg, PreserveInMetadata)
+			require.NoError(err)
+
+			// an older service decodes the legacy encoding as its own message
+			var encoded []byte
+			require.NoError(wrp.NewEncoderBytes(&encoded, f).Encode(l))
+
+			var decoded Legacy
+			require.NoError(wrp.NewDecoderBytes(encoded, f).Decode(&decoded))
+			assert.Equal(*l, decoded)
+
+			actual, _ := FromLegacy(&decoded, PreserveInMetadata)
+			assert.Equal(msg, *actual)
+		})
+	}
+}
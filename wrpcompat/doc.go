@@ -0,0 +1,15 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package wrpcompat converts between the wrp.Message and the Legacy message
+struct used by older releases of this module, which predate the SessionID and
+QualityOfService fields.  It eases an incremental migration, where services
+built against different releases exchange messages.
+
+Converting a Message to a Legacy message loses the fields Legacy does not
+have.  Each conversion returns a Report naming the fields that were lost, and a
+Policy decides whether the loss is accepted, rejected, or avoided by carrying
+the values in Metadata so that FromLegacy can restore them.
+*/
+package wrpcompat
@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package wrpconn writes WRP messages directly to a net.Conn, for building
+device-facing socket servers.
+
+A Writer encodes each message and writes it with its own write deadline, so a
+peer that stops reading cannot block the server indefinitely.  A write that
+times out before any byte is written only loses that message, while a write
+that times out part way through a message leaves the peer unable to decode
+the stream, so the Writer reports ErrBroken from then on and the connection
+should be closed.
+
+With a queue, messages are written in the background and Write never blocks.
+When the queue is full, the message with the lowest QOS level, the oldest
+among equals, is dropped to make room for a message with a higher level, so
+critical messages get through a slow connection at the expense of low
+priority ones.
+*/
+package wrpconn
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpconn
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// DefaultWriteTimeout is the default time allowed to write each message.
+const DefaultWriteTimeout = 10 * time.Second
+
+var ErrInvalidOption = errors.New("invalid option")
+
+// Option is a functional option for a Writer.
+type Option interface {
+	apply(*Writer) error
+}
+
+type optionFunc func(*Writer) error
+
+func (f optionFunc) apply(w *Writer) error {
+	return f(w)
+}
+
+// WithFormat sets the format used to encode messages.  The default is
+// wrp.Msgpack.
+func WithFormat(f wrp.Format) Option {
+	return optionFunc(func(w *Writer) error {
+		if f != wrp.Msgpack && f != wrp.JSON {
+			return fmt.Errorf("%w: format %d", ErrInvalidOption, f)
+		}
+		w.format = f
+		return nil
+	})
+}
+
+// WriteTimeout sets the time allowed to write each message.  The default is
+// DefaultWriteTimeout.  A timeout of 0 disables the write deadline, leaving
+// only the deadline of the context passed to Write.
+func WriteTimeout(d time.Duration) Option {
+	return optionFunc(func(w *Writer) error {
+		if d < 0 {
+			return fmt.Errorf("%w: write timeout %s", ErrInvalidOption, d)
+		}
+		w.timeout = d
+		return nil
+	})
+}
+
+// QueueSize sets the number of messages queued for writing in the
+// background.  By default there is no queue, and Write writes the message
+// before it returns.
+func QueueSize(n int) Option {
+	return optionFunc(func(w *Writer) error {
+		if n < 1 {
+			return fmt.Errorf("%w: queue size %d", ErrInvalidOption, n)
+		}
+		w.queueSize = n
+		return nil
+	})
+}
+
+// OnError sets a function that is called with errors that are not returned to
+// a caller, i.e. failed background writes and queued messages that are
+// dropped.
+func OnError(f func(error)) Option {
+	return optionFunc(func(w *Writer) error {
+		w.onError = f
+		return nil
+	})
+}
@@ -0,0 +1,331 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpconn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+var (
+	ErrBroken  = errors.New("connection broken by a partial write")
+	ErrDropped = errors.New("message dropped")
+	ErrClosed  = errors.New("writer closed")
+)
+
+// queued is an encoded message waiting to be written.
+type queued struct {
+	level wrp.QOSLevel
+	b     []byte
+}
+
+// Writer writes WRP messages to a net.Conn.  It is safe for concurrent use.
+type Writer struct {
+	conn      net.Conn
+	format    wrp.Format
+	timeout   time.Duration
+	queueSize int
+	onError   func(error)
+
+	// wlock serializes writes to the connection and guards broken.
+	wlock  sync.Mutex
+	broken error
+
+	// lock guards the queue and closed, which is the error returned by
+	// Write once the Writer is closed.
+	lock    sync.Mutex
+	queue   []queued
+	writing bool
+	closed  error
+	ready   chan struct{}
+	idle    chan struct{}
+	stopped chan struct{}
+
+	dropped atomic.Uint64
+}
+
+// NewWriter creates a Writer for the connection.  If the Writer has a queue,
+// Close must be called to stop writing in the background.  Neither the Writer
+// nor Close closes the connection.
+func NewWriter(conn net.Conn, opts ...Option) (*Writer, error) {
+	w := Writer{
+		conn:    conn,
+		format:  wrp.Msgpack,
+		timeout: DefaultWriteTimeout,
+		ready:   make(chan struct{}, 1),
+		idle:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt.apply(&w); err != nil {
+			return nil, err
+		}
+	}
+
+	close(w.idle)
+	if w.queueSize > 0 {
+		go w.run()
+	} else {
+		close(w.stopped)
+	}
+
+	return &w, nil
+}
+
+// Write encodes the message and writes it to the connection, or queues it if
+// the Writer has a queue.
+//
+// Without a queue, the write must finish before the write timeout and the
+// context end.  An error wrapping ErrBroken is returned if only part of the
+// message was written, after which the connection should be closed.
+//
+// With a queue, the context is not used.  An error wrapping ErrDropped is
+// returned if the queue is full of messages with at least the QOS level of
+// this one.
+func (w *Writer) Write(ctx context.Context, msg *wrp.Message) error {
+	var b []byte
+	if err := wrp.NewEncoderBytes(&b, w.format).Encode(msg); err != nil {
+		return err
+	}
+
+	if w.queueSize == 0 {
+		if err := w.closedErr(); err != nil {
+			return err
+		}
+		return w.write(ctx, b)
+	}
+
+	return w.enqueue(queued{level: msg.QualityOfService.Level(), b: b})
+}
+
+// Dropped returns the number of messages dropped because the queue was full.
+func (w *Writer) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// Flush waits until the queued messages have been written or the context
+// ends.
+func (w *Writer) Flush(ctx context.Context) error {
+	w.lock.Lock()
+	idle := w.idle
+	w.lock.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close discards the queued messages and waits for a write in progress to
+// finish.  Call Flush first to write the queued messages.  Subsequent Writes
+// return ErrClosed, or the error that broke the connection if a background
+// write broke it.
+func (w *Writer) Close() error {
+	w.lock.Lock()
+	if w.closed == nil {
+		w.closed = ErrClosed
+		w.queue = nil
+		w.notify()
+	}
+	w.lock.Unlock()
+
+	<-w.stopped
+	return nil
+}
+
+// write writes the whole encoded message with a fresh write deadline.
+func (w *Writer) write(ctx context.Context, b []byte) error {
+	w.wlock.Lock()
+	defer w.wlock.Unlock()
+
+	if w.broken != nil {
+		return w.broken
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var deadline time.Time
+	if w.timeout > 0 {
+		deadline = time.Now().Add(w.timeout)
+	}
+	if d, ok := ctx.Deadline(); ok && (deadline.IsZero() || d.Before(deadline)) {
+		deadline = d
+	}
+	_ = w.conn.SetWriteDeadline(deadline)
+
+	// interrupt the write when the context ends
+	interrupted := make(chan struct{})
+	stop := context.AfterFunc(ctx, func() {
+		defer close(interrupted)
+		_ = w.conn.SetWriteDeadline(time.Unix(1, 0))
+	})
+
+	var (
+		written int
+		err     error
+	)
+
+	for written < len(b) && err == nil {
+		var n int
+		n, err = w.conn.Write(b[written:])
+		written += n
+		if n == 0 && err == nil {
+			err = io.ErrShortWrite
+		}
+	}
+
+	if !stop() {
+		<-interrupted
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		} else if d, ok := ctx.Deadline(); ok && !time.Now().Before(d) {
+			// the connection's deadline may pass just before the context's
+			err = context.DeadlineExceeded
+		}
+	}
+
+	if written > 0 {
+		w.broken = fmt.Errorf("%w: %d of %d bytes written: %w", ErrBroken, written, len(b), err)
+		return w.broken
+	}
+
+	return err
+}
+
+func (w *Writer) enqueue(q queued) error {
+	// report a replaced message once the queue is unlocked
+	var replaced error
+	defer func() {
+		if replaced != nil {
+			w.error(replaced)
+		}
+	}()
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.closed != nil {
+		return w.closed
+	}
+
+	if len(w.queue) >= w.queueSize {
+		// drop the oldest message with the lowest level, if it is lower
+		lowest := 0
+		for i, other := range w.queue {
+			if other.level < w.queue[lowest].level {
+				lowest = i
+			}
+		}
+
+		if w.queue[lowest].level >= q.level {
+			w.dropped.Add(1)
+			return fmt.Errorf("%w: queue full with %d messages of QOS level %s or higher", ErrDropped, len(w.queue), q.level)
+		}
+
+		dropped := w.queue[lowest]
+		w.queue = append(w.queue[:lowest], w.queue[lowest+1:]...)
+		w.dropped.Add(1)
+		replaced = fmt.Errorf("%w: replaced a message of QOS level %s with one of %s", ErrDropped, dropped.level, q.level)
+	}
+
+	w.queue = append(w.queue, q)
+	if !w.writing && len(w.queue) == 1 {
+		w.idle = make(chan struct{})
+	}
+	w.notify()
+
+	return nil
+}
+
+func (w *Writer) closedErr() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.closed
+}
+
+// notify wakes up the background writer.
+func (w *Writer) notify() {
+	select {
+	case w.ready <- struct{}{}:
+	default:
+	}
+}
+
+// run writes the queued messages until the Writer is closed or broken.
+func (w *Writer) run() {
+	defer close(w.stopped)
+
+	for range w.ready {
+		for {
+			w.lock.Lock()
+			if w.closed != nil || len(w.queue) == 0 {
+				w.writing = false
+				closeIdle(w.idle)
+				closed := w.closed != nil
+				w.lock.Unlock()
+
+				if closed {
+					return
+				}
+				break
+			}
+
+			q := w.queue[0]
+			w.queue = w.queue[1:]
+			w.writing = true
+			w.lock.Unlock()
+
+			if err := w.write(context.Background(), q.b); err != nil {
+				w.error(err)
+				if errors.Is(err, ErrBroken) {
+					w.lock.Lock()
+					w.closed = err
+					w.queue = nil
+					closeIdle(w.idle)
+					w.lock.Unlock()
+					return
+				}
+			}
+		}
+	}
+}
+
+func closeIdle(idle chan struct{}) {
+	select {
+	case <-idle:
+	default:
+		close(idle)
+	}
+}
+
+func (w *Writer) error(err error) {
+	if w.onError != nil {
+		w.onError(err)
+	}
+}
@@ -0,0 +1,212 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpconn
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// writeStarted is a net.Conn that signals when the first write starts.
+type writeStarted struct {
+	net.Conn
+	once    sync.Once
+	started chan struct{}
+}
+
+func (c *writeStarted) Write(b []byte) (int, error) {
+	c.once.Do(func() { close(c.started) })
+	return c.Conn.Write(b)
+}
+
+func newPipe(t *testing.T) (client net.Conn, server net.Conn) {
+	client, server = net.Pipe()
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+
+	return client, server
+}
+
+func message(source string, qos wrp.QOSValue) *wrp.Message {
+	return &wrp.Message{
+		Type:             wrp.SimpleEventMessageType,
+		Source:           source,
+		Destination:      "event:device-status",
+		QualityOfService: qos,
+	}
+}
+
+func readMessages(t *testing.T, conn net.Conn, f wrp.Format, n int) []string {
+	d := wrp.NewDecoder(conn, f)
+
+	var sources []string
+	for i := 0; i < n; i++ {
+		var msg wrp.Message
+		require.NoError(t, d.Decode(&msg))
+		sources = append(sources, msg.Source)
+	}
+
+	return sources
+}
+
+func TestWriter(t *testing.T) {
+	for _, f := range wrp.AllFormats() {
+		t.Run(f.String(), func(t *testing.T) {
+			client, server := newPipe(t)
+			w, err := NewWriter(server, WithFormat(f), nil)
+			require.NoError(t, err)
+
+			go func() {
+				assert.NoError(t, w.Write(context.Background(), message("dns:a", wrp.QOSLowValue)))
+				assert.NoError(t, w.Write(context.Background(), message("dns:b", wrp.QOSLowValue)))
+			}()
+
+			assert.Equal(t, []string{"dns:a", "dns:b"}, readMessages(t, client, f, 2))
+			assert.NoError(t, w.Close())
+			assert.ErrorIs(t, w.Write(context.Background(), message("dns:c", 0)), ErrClosed)
+		})
+	}
+}
+
+func TestWriter_timeout(t *testing.T) {
+	client, server := newPipe(t)
+	w, err := NewWriter(server, WriteTimeout(10*time.Millisecond))
+	require.NoError(t, err)
+
+	// nothing was written, so the connection can still be used
+	assert.ErrorIs(t, w.Write(context.Background(), message("dns:a", 0)), os.ErrDeadlineExceeded)
+
+	go func() {
+		assert.NoError(t, w.Write(context.Background(), message("dns:b", 0)))
+	}()
+	assert.Equal(t, []string{"dns:b"}, readMessages(t, client, wrp.Msgpack, 1))
+}
+
+func TestWriter_partial(t *testing.T) {
+	client, server := newPipe(t)
+	w, err := NewWriter(server, WriteTimeout(50*time.Millisecond))
+	require.NoError(t, err)
+
+	go func() {
+		_, _ = io.ReadFull(client, make([]byte, 3))
+	}()
+
+	err = w.Write(context.Background(), message("dns:a", 0))
+	assert.ErrorIs(t, err, ErrBroken)
+	assert.ErrorIs(t, err, os.ErrDeadlineExceeded)
+	assert.ErrorIs(t, w.Write(context.Background(), message("dns:b", 0)), ErrBroken)
+}
+
+func TestWriter_context(t *testing.T) {
+	_, server := newPipe(t)
+	w, err := NewWriter(server, WriteTimeout(0))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	assert.ErrorIs(t, w.Write(ctx, message("dns:a", 0)), context.Canceled)
+	assert.ErrorIs(t, w.Write(ctx, message("dns:a", 0)), context.Canceled)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, w.Write(ctx, message("dns:a", 0)), context.DeadlineExceeded)
+}
+
+func TestWriter_queue(t *testing.T) {
+	client, server := newPipe(t)
+	conn := &writeStarted{Conn: server, started: make(chan struct{})}
+
+	var errs []error
+	w, err := NewWriter(conn, QueueSize(2), WriteTimeout(0), OnError(func(err error) {
+		errs = append(errs, err)
+	}))
+	require.NoError(t, err)
+	defer w.Close()
+
+	ctx := context.Background()
+
+	// the first message is being written until the client reads
+	require.NoError(t, w.Write(ctx, message("dns:a", wrp.QOSLowValue)))
+	<-conn.started
+
+	require.NoError(t, w.Write(ctx, message("dns:b", wrp.QOSLowValue)))
+	require.NoError(t, w.Write(ctx, message("dns:c", wrp.QOSMediumValue)))
+	assert.ErrorIs(t, w.Write(ctx, message("dns:d", wrp.QOSLowValue)), ErrDropped)
+	require.NoError(t, w.Write(ctx, message("dns:e", wrp.QOSCriticalValue)))
+	assert.Equal(t, uint64(2), w.Dropped())
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrDropped)
+
+	assert.Equal(t, []string{"dns:a", "dns:c", "dns:e"}, readMessages(t, client, wrp.Msgpack, 3))
+	assert.NoError(t, w.Flush(ctx))
+
+	// a full queue of critical messages drops more critical messages
+	flushCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	require.NoError(t, w.Write(ctx, message("dns:f", wrp.QOSCriticalValue)))
+	require.Eventually(t, func() bool {
+		w.lock.Lock()
+		defer w.lock.Unlock()
+		return w.writing && len(w.queue) == 0
+	}, time.Second, time.Millisecond)
+
+	for _, source := range []string{"dns:g", "dns:h"} {
+		require.NoError(t, w.Write(ctx, message(source, wrp.QOSCriticalValue)))
+	}
+	assert.ErrorIs(t, w.Write(ctx, message("dns:i", wrp.QOSCriticalValue)), ErrDropped)
+	assert.ErrorIs(t, w.Flush(flushCtx), context.DeadlineExceeded)
+
+	assert.Equal(t, []string{"dns:f", "dns:g", "dns:h"}, readMessages(t, client, wrp.Msgpack, 3))
+	assert.NoError(t, w.Flush(ctx))
+}
+
+func TestWriter_queueBroken(t *testing.T) {
+	client, server := newPipe(t)
+
+	errs := make(chan error, 1)
+	w, err := NewWriter(server, QueueSize(1), WriteTimeout(50*time.Millisecond), OnError(func(err error) {
+		errs <- err
+	}))
+	require.NoError(t, err)
+
+	go func() {
+		_, _ = io.ReadFull(client, make([]byte, 3))
+	}()
+
+	require.NoError(t, w.Write(context.Background(), message("dns:a", 0)))
+	assert.ErrorIs(t, <-errs, ErrBroken)
+	assert.NoError(t, w.Flush(context.Background()))
+	assert.ErrorIs(t, w.Write(context.Background(), message("dns:b", 0)), ErrBroken)
+	assert.NoError(t, w.Close())
+}
+
+func TestNewWriter_invalid(t *testing.T) {
+	tests := []struct {
+		desc string
+		opt  Option
+	}{
+		{desc: "format", opt: WithFormat(wrp.Format(-1))},
+		{desc: "write timeout", opt: WriteTimeout(-time.Second)},
+		{desc: "queue size", opt: QueueSize(0)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			w, err := NewWriter(nil, tc.opt)
+			assert.Nil(t, w)
+			assert.ErrorIs(t, err, ErrInvalidOption)
+		})
+	}
+}
@@ -17,11 +17,12 @@ func SetMessage(ctx context.Context, msg any) context.Context {
 	return context.WithValue(ctx, contextWRPMessageKey{}, msg)
 }
 
-// Get a message from a context and return it as type T
+// Get a message from a context and return it as type T.  If no message was added with SetMessage,
+// the message added by a transport with wrp.ContextWithMessage is returned.
 func GetMessage(ctx context.Context) (*wrp.Message, bool) {
 	src := ctx.Value(contextWRPMessageKey{})
 	if src == nil {
-		return nil, false
+		return wrp.MessageFromContext(ctx)
 	}
 
 	return get[*wrp.Message](ctx, src)
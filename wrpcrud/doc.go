@@ -0,0 +1,20 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package wrpcrud standardizes the payload of responses to CRUD messages.  A
+Result carries a status code, an optional human readable message and optional
+data, and is encoded as JSON:
+
+	{"code": 404, "message": "no such tag", "data": {...}}
+
+The code of a Result is the Status of the response message, so clients that
+only look at the Status and clients that decode the payload agree on the
+outcome.  NewResponse builds the response to a CRUD request from a Result, and
+FromMessage decodes the Result of a response.
+
+Failures are expressed as an *Error, and Result.Err converts a failed Result
+back into one, so services and clients can use errors.Is with the predefined
+errors such as ErrNotFound.
+*/
+package wrpcrud
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpcrud
+
+import (
+	"maps"
+	"strings"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// The Metadata keys of conditional CRUD requests, which follow the HTTP
+// ETag and If-None-Match headers.  Entity tags are stored as they appear in
+// HTTP, including their quotes, e.g. "v1" or W/"v1".
+const (
+	// ETagKey is the key of the entity tag of the object in a response.
+	ETagKey = "/etag"
+
+	// IfNoneMatchKey is the key of the comma separated entity tags of a
+	// request, of which the client already has a copy.  It can also be *.
+	IfNoneMatchKey = "/if-none-match"
+)
+
+// ETag returns the entity tag of the message, if any.
+func ETag(msg *wrp.Message) (string, bool) {
+	etag, ok := msg.Metadata[ETagKey]
+	return etag, ok && etag != ""
+}
+
+// SetETag sets the entity tag of the message.  The Metadata is copied before
+// it is changed, so other references to the original Metadata are unaffected.
+func SetETag(msg *wrp.Message, etag string) {
+	setMetadata(msg, ETagKey, etag)
+}
+
+// SetIfNoneMatch sets the entity tags the client already has a copy of on a
+// request.  The Metadata is copied before it is changed.
+func SetIfNoneMatch(msg *wrp.Message, etags ...string) {
+	setMetadata(msg, IfNoneMatchKey, strings.Join(etags, ", "))
+}
+
+// NotModified returns true if the request is conditional and the client
+// already has the object with the entity tag, in which case a service may
+// answer with a 304 (Not Modified) Result and no data.
+func NotModified(request *wrp.Message, etag string) bool {
+	return ETagMatches(request.Metadata[IfNoneMatchKey], etag)
+}
+
+// ETagMatches returns true if the entity tag matches one of the comma
+// separated list of entity tags, using the weak comparison of If-None-Match:
+// the W/ prefixes are ignored.  A list of * matches any entity tag, and an
+// empty entity tag never matches.
+func ETagMatches(list, etag string) bool {
+	if etag == "" {
+		return false
+	}
+
+	if strings.TrimSpace(list) == "*" {
+		return true
+	}
+
+	opaque := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(list, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == opaque {
+			return true
+		}
+	}
+
+	return false
+}
+
+func setMetadata(msg *wrp.Message, key, value string) {
+	metadata := make(map[string]string, len(msg.Metadata)+1)
+	maps.Copy(metadata, msg.Metadata)
+	metadata[key] = value
+
+	msg.Metadata = metadata
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpcrud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestETag(t *testing.T) {
+	assert := assert.New(t)
+
+	original := map[string]string{"/boot-time": "1"}
+	msg := wrp.Message{Metadata: original}
+
+	_, ok := ETag(&msg)
+	assert.False(ok)
+
+	SetETag(&msg, `"v1"`)
+	etag, ok := ETag(&msg)
+	assert.True(ok)
+	assert.Equal(`"v1"`, etag)
+	assert.Len(original, 1)
+
+	assert.False(NotModified(&msg, `"v1"`))
+	SetIfNoneMatch(&msg, `"v0"`, `W/"v1"`)
+	assert.Equal(`"v0", W/"v1"`, msg.Metadata[IfNoneMatchKey])
+	assert.True(NotModified(&msg, `"v1"`))
+	assert.False(NotModified(&msg, `"v2"`))
+}
+
+func TestETagMatches(t *testing.T) {
+	tests := []struct {
+		desc     string
+		list     string
+		etag     string
+		expected bool
+	}{
+		{desc: "empty list", etag: `"v1"`},
+		{desc: "empty etag", list: "*"},
+		{desc: "any", list: " * ", etag: `"v1"`, expected: true},
+		{desc: "match", list: `"v1"`, etag: `"v1"`, expected: true},
+		{desc: "weak match", list: `"v0",W/"v1"`, etag: `"v1"`, expected: true},
+		{desc: "weak etag", list: `"v1"`, etag: `W/"v1"`, expected: true},
+		{desc: "no match", list: `"v0", "v2"`, etag: `"v1"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ETagMatches(tc.list, tc.etag))
+		})
+	}
+}
@@ -0,0 +1,232 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpcrud
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+var (
+	ErrInvalidResult = errors.New("invalid CRUD result")
+	ErrNotCRUD       = errors.New("not a CRUD message")
+)
+
+// The predefined errors for the common failures of CRUD requests.  An *Error
+// matches these with errors.Is by its code, whatever its message.
+var (
+	ErrBadRequest = &Error{Code: http.StatusBadRequest}
+	ErrForbidden  = &Error{Code: http.StatusForbidden}
+	ErrNotFound   = &Error{Code: http.StatusNotFound}
+	ErrConflict   = &Error{Code: http.StatusConflict}
+	ErrInternal   = &Error{Code: http.StatusInternalServerError}
+)
+
+// Error is a failed CRUD request.
+type Error struct {
+	// Code is the status code of the failure, one of the HTTP status codes
+	// from 400 to 599.
+	Code int
+
+	// Message describes the failure.  If empty, the HTTP status text of the
+	// code is used.
+	Message string
+}
+
+// Errorf creates an *Error with the code and a formatted message.
+func Errorf(code int, format string, a ...any) *Error {
+	return &Error{
+		Code:    code,
+		Message: fmt.Sprintf(format, a...),
+	}
+}
+
+func (e *Error) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.Code)
+	}
+
+	return fmt.Sprintf("CRUD error %d: %s", e.Code, msg)
+}
+
+// Is returns true if the target is an *Error with the same code.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Result is the payload of a response to a CRUD message.
+type Result struct {
+	// Code is the status code of the response, following the HTTP status
+	// codes.  It is also the Status of the response message.
+	Code int `json:"code"`
+
+	// Message is an optional human readable description of the result.
+	Message string `json:"message,omitempty"`
+
+	// Data is the optional JSON encoded data of the result, e.g. the
+	// retrieved object.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// OK creates a successful Result with the data encoded as JSON.  A nil data
+// results in no Data.
+func OK(data any) (Result, error) {
+	r := Result{Code: http.StatusOK}
+	if data == nil {
+		return r, nil
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return Result{}, fmt.Errorf("%w: %w", ErrInvalidResult, err)
+	}
+
+	r.Data = b
+	return r, nil
+}
+
+// FromError creates a failed Result from the error.  The code and message of
+// an *Error are used as is.  Any other error results in an internal server
+// error with the error's text as the message.
+func FromError(err error) Result {
+	var e *Error
+	if !errors.As(err, &e) {
+		return Result{
+			Code:    http.StatusInternalServerError,
+			Message: err.Error(),
+		}
+	}
+
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.Code)
+	}
+
+	return Result{
+		Code:    e.Code,
+		Message: msg,
+	}
+}
+
+// Success returns true if the code is a 2xx status code.
+func (r Result) Success() bool {
+	return r.Code >= 200 && r.Code < 300
+}
+
+// Err returns nil if the result is a success, and otherwise the *Error with
+// the result's code and message.
+func (r Result) Err() error {
+	if r.Success() {
+		return nil
+	}
+
+	return &Error{
+		Code:    r.Code,
+		Message: r.Message,
+	}
+}
+
+// DecodeData decodes the JSON Data of the result into v.
+func (r Result) DecodeData(v any) error {
+	if len(r.Data) == 0 {
+		return fmt.Errorf("%w: no data", ErrInvalidResult)
+	}
+
+	if err := json.Unmarshal(r.Data, v); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidResult, err)
+	}
+
+	return nil
+}
+
+// validate checks the code is a valid status code.
+func (r Result) validate() error {
+	if r.Code < 100 || r.Code > 599 {
+		return fmt.Errorf("%w: code %d is not a status code", ErrInvalidResult, r.Code)
+	}
+
+	return nil
+}
+
+// Encode returns the JSON encoding of the result.
+func (r Result) Encode() ([]byte, error) {
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(r)
+}
+
+// Decode decodes a JSON encoded result.
+func Decode(b []byte) (Result, error) {
+	var r Result
+	if err := json.Unmarshal(b, &r); err != nil {
+		return Result{}, fmt.Errorf("%w: %w", ErrInvalidResult, err)
+	}
+
+	if err := r.validate(); err != nil {
+		return Result{}, err
+	}
+
+	return r, nil
+}
+
+// NewResponse creates the response to a CRUD request, as wrp.NewResponse
+// does, with the result as its payload and the result's code as its Status.
+// Additional options, e.g. wrp.ResponseSource, are applied after the payload
+// is set.
+func NewResponse(request *wrp.Message, r Result, opts ...wrp.ResponseOption) (*wrp.Message, error) {
+	if !wrp.IsCRUD(request.Type) {
+		return nil, fmt.Errorf("%w: %s", ErrNotCRUD, request.Type)
+	}
+
+	payload, err := r.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append([]wrp.ResponseOption{wrp.ResponsePayload(wrp.MimeTypeJson, payload)}, opts...)
+	return wrp.NewResponse(request, int64(r.Code), opts...)
+}
+
+// FromMessage returns the result of a response to a CRUD request.  If the
+// response has no payload, the result has its Status as the code, so
+// responses from services that do not follow the payload convention are still
+// understood.  A response with neither a payload nor a Status, or whose payload
+// code differs from its Status, is invalid.
+func FromMessage(msg *wrp.Message) (Result, error) {
+	if !wrp.IsCRUD(msg.Type) {
+		return Result{}, fmt.Errorf("%w: %s", ErrNotCRUD, msg.Type)
+	}
+
+	if len(msg.Payload) == 0 {
+		if msg.Status == nil {
+			return Result{}, fmt.Errorf("%w: no payload or status", ErrInvalidResult)
+		}
+
+		r := Result{Code: int(*msg.Status)}
+		if err := r.validate(); err != nil {
+			return Result{}, err
+		}
+
+		return r, nil
+	}
+
+	r, err := Decode(msg.Payload)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if msg.Status != nil && *msg.Status != int64(r.Code) {
+		return Result{}, fmt.Errorf("%w: code %d does not match status %d", ErrInvalidResult, r.Code, *msg.Status)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,234 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpcrud
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+type tag struct {
+	Name string `json:"name"`
+}
+
+func status(s int64) *int64 {
+	return &s
+}
+
+func TestError(t *testing.T) {
+	assert := assert.New(t)
+
+	err := Errorf(http.StatusNotFound, "no tag %s", "a")
+	assert.Equal("CRUD error 404: no tag a", err.Error())
+	assert.ErrorIs(err, ErrNotFound)
+	assert.NotErrorIs(err, ErrConflict)
+	assert.ErrorIs(errors.Join(errors.New("other"), err), ErrNotFound)
+	assert.Equal("CRUD error 409: Conflict", ErrConflict.Error())
+}
+
+func TestOK(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	r, err := OK(tag{Name: "a"})
+	require.NoError(err)
+	assert.True(r.Success())
+	assert.NoError(r.Err())
+
+	var got tag
+	require.NoError(r.DecodeData(&got))
+	assert.Equal(tag{Name: "a"}, got)
+
+	r, err = OK(nil)
+	require.NoError(err)
+	assert.Equal(Result{Code: http.StatusOK}, r)
+	assert.ErrorIs(r.DecodeData(&got), ErrInvalidResult)
+
+	_, err = OK(make(chan int))
+	assert.ErrorIs(err, ErrInvalidResult)
+}
+
+func TestFromError(t *testing.T) {
+	tests := []struct {
+		desc string
+		err  error
+		want Result
+	}{
+		{
+			desc: "crud error",
+			err:  Errorf(http.StatusConflict, "tag exists"),
+			want: Result{Code: http.StatusConflict, Message: "tag exists"},
+		}, {
+			desc: "predefined error",
+			err:  ErrForbidden,
+			want: Result{Code: http.StatusForbidden, Message: "Forbidden"},
+		}, {
+			desc: "other error",
+			err:  errors.New("disk full"),
+			want: Result{Code: http.StatusInternalServerError, Message: "disk full"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+			r := FromError(tc.err)
+			assert.Equal(tc.want, r)
+			assert.False(r.Success())
+
+			var e *Error
+			require.ErrorAs(t, r.Err(), &e)
+			assert.Equal(tc.want.Code, e.Code)
+		})
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	tests := []struct {
+		desc    string
+		r       Result
+		encoded string
+		err     error
+	}{
+		{
+			desc:    "success with data",
+			r:       Result{Code: 200, Data: json.RawMessage(`{"name":"a"}`)},
+			encoded: `{"code":200,"data":{"name":"a"}}`,
+		}, {
+			desc:    "failure",
+			r:       Result{Code: 404, Message: "no tag a"},
+			encoded: `{"code":404,"message":"no tag a"}`,
+		}, {
+			desc: "invalid code",
+			r:    Result{Code: 42},
+			err:  ErrInvalidResult,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+			b, err := tc.r.Encode()
+			if tc.err != nil {
+				assert.ErrorIs(err, tc.err)
+				assert.Nil(b)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.JSONEq(tc.encoded, string(b))
+
+			r, err := Decode(b)
+			require.NoError(t, err)
+			assert.Equal(tc.r, r)
+		})
+	}
+
+	_, err := Decode([]byte(`{"code":`))
+	assert.ErrorIs(t, err, ErrInvalidResult)
+	_, err = Decode([]byte(`{"message":"no code"}`))
+	assert.ErrorIs(t, err, ErrInvalidResult)
+}
+
+func TestNewResponse(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	request := wrp.Message{
+		Type:            wrp.RetrieveMessageType,
+		Source:          "dns:scytale.example.com",
+		Destination:     "mac:112233445566/config",
+		TransactionUUID: "1",
+		Path:            "/tags/a",
+	}
+
+	response, err := NewResponse(&request, FromError(Errorf(http.StatusNotFound, "no tag a")))
+	require.NoError(err)
+	assert.Equal(wrp.RetrieveMessageType, response.Type)
+	assert.Equal(request.Source, response.Destination)
+	assert.Equal(request.Destination, response.Source)
+	assert.Equal("/tags/a", response.Path)
+	assert.Equal(wrp.MimeTypeJson, response.ContentType)
+	assert.Equal(status(404), response.Status)
+	assert.JSONEq(`{"code":404,"message":"no tag a"}`, string(response.Payload))
+
+	r, err := FromMessage(response)
+	require.NoError(err)
+	assert.ErrorIs(r.Err(), ErrNotFound)
+
+	// options are applied after the payload
+	response, err = NewResponse(&request, Result{Code: 200}, wrp.ResponseSource("mac:112233445566"))
+	require.NoError(err)
+	assert.Equal("mac:112233445566", response.Source)
+
+	_, err = NewResponse(&request, Result{})
+	assert.ErrorIs(err, ErrInvalidResult)
+
+	request.Type = wrp.SimpleRequestResponseMessageType
+	_, err = NewResponse(&request, Result{Code: 200})
+	assert.ErrorIs(err, ErrNotCRUD)
+}
+
+func TestFromMessage(t *testing.T) {
+	tests := []struct {
+		desc string
+		msg  wrp.Message
+		want Result
+		err  error
+	}{
+		{
+			desc: "payload and status",
+			msg:  wrp.Message{Type: wrp.CreateMessageType, Status: status(201), Payload: []byte(`{"code":201}`)},
+			want: Result{Code: 201},
+		}, {
+			desc: "payload without status",
+			msg:  wrp.Message{Type: wrp.UpdateMessageType, Payload: []byte(`{"code":409,"message":"conflict"}`)},
+			want: Result{Code: 409, Message: "conflict"},
+		}, {
+			desc: "status without payload",
+			msg:  wrp.Message{Type: wrp.DeleteMessageType, Status: status(204)},
+			want: Result{Code: 204},
+		}, {
+			desc: "mismatched code",
+			msg:  wrp.Message{Type: wrp.CreateMessageType, Status: status(200), Payload: []byte(`{"code":500}`)},
+			err:  ErrInvalidResult,
+		}, {
+			desc: "no payload or status",
+			msg:  wrp.Message{Type: wrp.CreateMessageType},
+			err:  ErrInvalidResult,
+		}, {
+			desc: "invalid status",
+			msg:  wrp.Message{Type: wrp.CreateMessageType, Status: status(-1)},
+			err:  ErrInvalidResult,
+		}, {
+			desc: "invalid payload",
+			msg:  wrp.Message{Type: wrp.CreateMessageType, Payload: []byte("not json")},
+			err:  ErrInvalidResult,
+		}, {
+			desc: "not crud",
+			msg:  wrp.Message{Type: wrp.SimpleEventMessageType, Status: status(200)},
+			err:  ErrNotCRUD,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			r, err := FromMessage(&tc.msg)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Equal(t, Result{}, r)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, r)
+		})
+	}
+}
@@ -0,0 +1,275 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpcrypto
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+const (
+	// KeyIDKey is the metadata key that holds the ID of the key used to
+	// encrypt the payload.
+	KeyIDKey = "/wrp-crypto-key-id"
+
+	// AlgorithmKey is the metadata key that holds the encryption algorithm.
+	AlgorithmKey = "/wrp-crypto-alg"
+
+	// AESGCM is the only supported algorithm.  The envelope is the nonce
+	// followed by the sealed payload.  The key ID, Source, Destination and
+	// TransactionUUID are authenticated as additional data, so a payload
+	// cannot be moved to another message.
+	AESGCM = "AES-GCM"
+)
+
+var (
+	ErrInvalidKey           = errors.New("invalid encryption key")
+	ErrUnknownKey           = errors.New("unknown encryption key")
+	ErrUnsupportedAlgorithm = errors.New("unsupported encryption algorithm")
+	ErrNotEncrypted         = errors.New("payload is not encrypted")
+	ErrDecrypt              = errors.New("unable to decrypt payload")
+)
+
+// Keys looks up the key for a key ID.
+type Keys interface {
+	// Key returns the AES key with the given ID.  Keys must be 16, 24 or 32
+	// bytes long.
+	Key(id string) ([]byte, error)
+}
+
+// KeyMap is a simple, static implementation of Keys.
+type KeyMap map[string][]byte
+
+// Key returns the key with the given ID, or ErrUnknownKey.
+func (km KeyMap) Key(id string) ([]byte, error) {
+	if key, ok := km[id]; ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("%w: `%s`", ErrUnknownKey, id)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// payloadAAD is the additional data authenticated with a payload.
+func payloadAAD(keyID string, msg *wrp.Message) []byte {
+	return []byte(keyID + "\x00" + msg.Source + "\x00" + msg.Destination + "\x00" + msg.TransactionUUID)
+}
+
+// IsEncrypted returns true if the message carries an encrypted payload.
+func IsEncrypted(msg *wrp.Message) bool {
+	_, ok := msg.Metadata[KeyIDKey]
+	return ok
+}
+
+// Encrypter seals message payloads with a single key.
+type Encrypter struct {
+	keyID string
+	aead  cipher.AEAD
+	rand  io.Reader
+}
+
+var _ wrp.Modifier = (*Encrypter)(nil)
+
+// NewEncrypter creates an Encrypter that uses the given key, which must be
+// 16, 24 or 32 bytes long.  The key ID is sent along with each message.
+func NewEncrypter(keyID string, key []byte) (*Encrypter, error) {
+	if keyID == "" {
+		return nil, fmt.Errorf("%w: empty key ID", ErrInvalidKey)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Encrypter{
+		keyID: keyID,
+		aead:  aead,
+		rand:  rand.Reader,
+	}, nil
+}
+
+// Encrypt seals the message's payload in place and adds the key ID and
+// algorithm to its metadata.  The message's metadata map is replaced, not
+// modified.  Messages without a payload are left unchanged.
+//
+// The payload is bound to the message's Source, Destination and
+// TransactionUUID, so the message must be encrypted after they are final and
+// they must not be rewritten before it is decrypted.
+func (e *Encrypter) Encrypt(msg *wrp.Message) error {
+	if len(msg.Payload) == 0 {
+		return nil
+	}
+
+	nonce := make([]byte, e.aead.NonceSize(), e.aead.NonceSize()+len(msg.Payload)+e.aead.Overhead())
+	if _, err := io.ReadFull(e.rand, nonce); err != nil {
+		return err
+	}
+
+	msg.Payload = e.aead.Seal(nonce, nonce, msg.Payload, payloadAAD(e.keyID, msg))
+	msg.Metadata = withMetadata(msg.Metadata, map[string]string{
+		KeyIDKey:     e.keyID,
+		AlgorithmKey: AESGCM,
+	})
+
+	return nil
+}
+
+// ModifyWRP returns a copy of the message with an encrypted payload.
+func (e *Encrypter) ModifyWRP(_ context.Context, msg wrp.Message) (wrp.Message, error) {
+	err := e.Encrypt(&msg)
+	return msg, err
+}
+
+// DecrypterOption is a functional option for a Decrypter.
+type DecrypterOption interface {
+	apply(*Decrypter)
+}
+
+type decrypterOptionFunc func(*Decrypter)
+
+func (f decrypterOptionFunc) apply(d *Decrypter) {
+	f(d)
+}
+
+// RequireEncryption causes the Decrypter to reject messages with a payload
+// that is not encrypted.  By default, such messages are passed through.
+func RequireEncryption() DecrypterOption {
+	return decrypterOptionFunc(func(d *Decrypter) {
+		d.required = true
+	})
+}
+
+// Decrypter opens message payloads sealed by an Encrypter.
+type Decrypter struct {
+	keys     Keys
+	required bool
+}
+
+var _ wrp.Modifier = (*Decrypter)(nil)
+
+// NewDecrypter creates a Decrypter that uses the given Keys.
+func NewDecrypter(keys Keys, opts ...DecrypterOption) (*Decrypter, error) {
+	if keys == nil {
+		return nil, fmt.Errorf("%w: nil keys", ErrInvalidKey)
+	}
+
+	d := Decrypter{
+		keys: keys,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt.apply(&d)
+		}
+	}
+
+	return &d, nil
+}
+
+// Decrypt opens the message's payload in place and removes the encryption
+// metadata.  The message's metadata map is replaced, not modified.
+func (d *Decrypter) Decrypt(msg *wrp.Message) error {
+	keyID, ok := msg.Metadata[KeyIDKey]
+	if !ok {
+		if d.required && len(msg.Payload) > 0 {
+			return ErrNotEncrypted
+		}
+		return nil
+	}
+
+	if alg := msg.Metadata[AlgorithmKey]; alg != AESGCM {
+		return fmt.Errorf("%w: `%s`", ErrUnsupportedAlgorithm, alg)
+	}
+
+	key, err := d.keys.Key(keyID)
+	if err != nil {
+		return err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+
+	if len(msg.Payload) < aead.NonceSize() {
+		return fmt.Errorf("%w: envelope too short", ErrDecrypt)
+	}
+
+	nonce, sealed := msg.Payload[:aead.NonceSize()], msg.Payload[aead.NonceSize():]
+	payload, err := aead.Open(nil, nonce, sealed, payloadAAD(keyID, msg))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrDecrypt, err)
+	}
+
+	msg.Payload = payload
+	msg.Metadata = withMetadata(msg.Metadata, nil, KeyIDKey, AlgorithmKey)
+	return nil
+}
+
+// ModifyWRP returns a copy of the message with a decrypted payload.
+func (d *Decrypter) ModifyWRP(_ context.Context, msg wrp.Message) (wrp.Message, error) {
+	err := d.Decrypt(&msg)
+	return msg, err
+}
+
+// DecryptProcessor returns a wrp.Processor that decrypts each message before
+// passing it to next.  Messages that cannot be decrypted are not passed on.
+func DecryptProcessor(d *Decrypter, next wrp.Processor) wrp.Processor {
+	return wrp.ProcessorFunc(func(ctx context.Context, msg wrp.Message) error {
+		if err := d.Decrypt(&msg); err != nil {
+			return err
+		}
+		return next.ProcessWRP(ctx, msg)
+	})
+}
+
+// EncryptProcessor returns a wrp.Processor that encrypts each message before
+// passing it to next, which is normally the function that sends the message.
+func EncryptProcessor(e *Encrypter, next wrp.Processor) wrp.Processor {
+	return wrp.ProcessorFunc(func(ctx context.Context, msg wrp.Message) error {
+		if err := e.Encrypt(&msg); err != nil {
+			return err
+		}
+		return next.ProcessWRP(ctx, msg)
+	})
+}
+
+// withMetadata returns a copy of the metadata with the given entries added
+// and keys removed.  A nil map is returned instead of an empty one.
+func withMetadata(metadata, add map[string]string, remove ...string) map[string]string {
+	result := make(map[string]string, len(metadata)+len(add))
+	for k, v := range metadata {
+		result[k] = v
+	}
+
+	for k, v := range add {
+		result[k] = v
+	}
+
+	for _, k := range remove {
+		delete(result, k)
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
@@ -0,0 +1,259 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpcrypto
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+var (
+	key1 = bytes.Repeat([]byte{1}, 32)
+	key2 = bytes.Repeat([]byte{2}, 16)
+)
+
+func testMessage() wrp.Message {
+	return wrp.Message{
+		Type:        wrp.SimpleEventMessageType,
+		Source:      "mac:112233445566",
+		Destination: "event:secret",
+		Metadata:    map[string]string{"/boot-time": "1"},
+		Payload:     []byte(`{"secret":true}`),
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, keyID := range []string{"k1", "k2"} {
+		t.Run(keyID, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			keys := KeyMap{"k1": key1, "k2": key2}
+			e, err := NewEncrypter(keyID, keys[keyID])
+			require.NoError(err)
+
+			d, err := NewDecrypter(keys)
+			require.NoError(err)
+
+			original := testMessage()
+			encrypted, err := e.ModifyWRP(context.Background(), original)
+			require.NoError(err)
+
+			assert.Equal(testMessage(), original, "the original message must not be modified")
+			assert.True(IsEncrypted(&encrypted))
+			assert.False(IsEncrypted(&original))
+			assert.Equal(keyID, encrypted.Metadata[KeyIDKey])
+			assert.Equal(AESGCM, encrypted.Metadata[AlgorithmKey])
+			assert.NotContains(string(encrypted.Payload), "secret")
+
+			decrypted, err := d.ModifyWRP(context.Background(), encrypted)
+			require.NoError(err)
+			assert.Equal(testMessage(), decrypted)
+			assert.True(IsEncrypted(&encrypted), "the encrypted message must not be modified")
+		})
+	}
+}
+
+func TestEncrypter(t *testing.T) {
+	t.Run("InvalidKey", func(t *testing.T) {
+		_, err := NewEncrypter("k", []byte("short"))
+		assert.ErrorIs(t, err, ErrInvalidKey)
+
+		_, err = NewEncrypter("", key1)
+		assert.ErrorIs(t, err, ErrInvalidKey)
+	})
+
+	t.Run("NoPayload", func(t *testing.T) {
+		e, err := NewEncrypter("k1", key1)
+		require.NoError(t, err)
+
+		msg := wrp.Message{Type: wrp.SimpleEventMessageType}
+		assert.NoError(t, e.Encrypt(&msg))
+		assert.Equal(t, wrp.Message{Type: wrp.SimpleEventMessageType}, msg)
+	})
+
+	t.Run("RandomFailure", func(t *testing.T) {
+		e, err := NewEncrypter("k1", key1)
+		require.NoError(t, err)
+
+		errRandom := errors.New("no entropy")
+		e.rand = iotest.ErrReader(errRandom)
+
+		msg := testMessage()
+		assert.ErrorIs(t, e.Encrypt(&msg), errRandom)
+	})
+
+	t.Run("UniqueNonces", func(t *testing.T) {
+		e, err := NewEncrypter("k1", key1)
+		require.NoError(t, err)
+
+		first, second := testMessage(), testMessage()
+		require.NoError(t, e.Encrypt(&first))
+		require.NoError(t, e.Encrypt(&second))
+		assert.NotEqual(t, first.Payload, second.Payload)
+	})
+}
+
+func TestDecrypter(t *testing.T) {
+	e, err := NewEncrypter("k1", key1)
+	require.NoError(t, err)
+
+	encrypted := testMessage()
+	require.NoError(t, e.Encrypt(&encrypted))
+
+	with := func(f func(*wrp.Message)) wrp.Message {
+		msg := encrypted
+		msg.Payload = append([]byte(nil), encrypted.Payload...)
+		msg.Metadata = withMetadata(encrypted.Metadata, nil)
+		f(&msg)
+		return msg
+	}
+
+	tests := []struct {
+		desc     string
+		keys     Keys
+		opts     []DecrypterOption
+		msg      wrp.Message
+		expected error
+	}{
+		{
+			desc: "not encrypted",
+			keys: KeyMap{},
+			msg:  testMessage(),
+		}, {
+			desc:     "not encrypted but required",
+			keys:     KeyMap{},
+			opts:     []DecrypterOption{nil, RequireEncryption()},
+			msg:      testMessage(),
+			expected: ErrNotEncrypted,
+		}, {
+			desc: "no payload and required",
+			keys: KeyMap{},
+			opts: []DecrypterOption{RequireEncryption()},
+			msg:  wrp.Message{Type: wrp.SimpleEventMessageType},
+		}, {
+			desc:     "unknown key",
+			keys:     KeyMap{"k2": key2},
+			msg:      encrypted,
+			expected: ErrUnknownKey,
+		}, {
+			desc:     "invalid key",
+			keys:     KeyMap{"k1": []byte("short")},
+			msg:      encrypted,
+			expected: ErrInvalidKey,
+		}, {
+			desc:     "wrong key",
+			keys:     KeyMap{"k1": key2},
+			msg:      encrypted,
+			expected: ErrDecrypt,
+		}, {
+			desc: "unsupported algorithm",
+			keys: KeyMap{"k1": key1},
+			msg: with(func(msg *wrp.Message) {
+				msg.Metadata[AlgorithmKey] = "ROT13"
+			}),
+			expected: ErrUnsupportedAlgorithm,
+		}, {
+			desc: "tampered payload",
+			keys: KeyMap{"k1": key1},
+			msg: with(func(msg *wrp.Message) {
+				msg.Payload[len(msg.Payload)-1] ^= 0xff
+			}),
+			expected: ErrDecrypt,
+		}, {
+			desc: "tampered key ID",
+			keys: KeyMap{"k1": key1, "k2": key1},
+			msg: with(func(msg *wrp.Message) {
+				msg.Metadata[KeyIDKey] = "k2"
+			}),
+			expected: ErrDecrypt,
+		}, {
+			desc: "tampered source",
+			keys: KeyMap{"k1": key1},
+			msg: with(func(msg *wrp.Message) {
+				msg.Source = "mac:665544332211"
+			}),
+			expected: ErrDecrypt,
+		}, {
+			desc: "tampered destination",
+			keys: KeyMap{"k1": key1},
+			msg: with(func(msg *wrp.Message) {
+				msg.Destination = "event:public"
+			}),
+			expected: ErrDecrypt,
+		}, {
+			desc: "tampered transaction UUID",
+			keys: KeyMap{"k1": key1},
+			msg: with(func(msg *wrp.Message) {
+				msg.TransactionUUID = "replayed"
+			}),
+			expected: ErrDecrypt,
+		}, {
+			desc: "truncated envelope",
+			keys: KeyMap{"k1": key1},
+			msg: with(func(msg *wrp.Message) {
+				msg.Payload = msg.Payload[:4]
+			}),
+			expected: ErrDecrypt,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			d, err := NewDecrypter(tc.keys, tc.opts...)
+			require.NoError(t, err)
+
+			msg := tc.msg
+			err = d.Decrypt(&msg)
+			if tc.expected == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.msg, msg)
+				return
+			}
+
+			assert.ErrorIs(t, err, tc.expected)
+		})
+	}
+
+	_, err = NewDecrypter(nil)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestProcessors(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	e, err := NewEncrypter("k1", key1)
+	require.NoError(err)
+
+	d, err := NewDecrypter(KeyMap{"k1": key1}, RequireEncryption())
+	require.NoError(err)
+
+	var received []wrp.Message
+	receiver := DecryptProcessor(d, wrp.ProcessorFunc(func(_ context.Context, msg wrp.Message) error {
+		received = append(received, msg)
+		return nil
+	}))
+
+	sender := EncryptProcessor(e, receiver)
+
+	assert.NoError(sender.ProcessWRP(context.Background(), testMessage()))
+	require.Len(received, 1)
+	assert.Equal(testMessage(), received[0])
+
+	// plaintext is rejected by the receiver and never reaches next
+	assert.ErrorIs(receiver.ProcessWRP(context.Background(), testMessage()), ErrNotEncrypted)
+	assert.Len(received, 1)
+
+	e.rand = iotest.ErrReader(errors.New("no entropy"))
+	assert.Error(sender.ProcessWRP(context.Background(), testMessage()))
+	assert.Len(received, 1)
+}
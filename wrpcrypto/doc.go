@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package wrpcrypto provides end-to-end confidentiality for WRP payloads.  A
+payload is sealed in an AES-GCM envelope, and the ID of the key used is
+carried in the message's Metadata so that the receiver can find the key
+needed to open it.  Only the Payload is encrypted; every other field remains
+visible to the services that route the message.  The Source, Destination and
+TransactionUUID are authenticated along with the payload, so an encrypted
+payload cannot be replayed in a message to or from another device.
+
+Selected metadata values, such as account identifiers, can also be protected
+at rest with a MetadataEncrypter and MetadataDecrypter.  Each encrypted value
+names the key that encrypted it, so keys can be rotated by encrypting with a
+new key while older keys remain available for decryption.
+*/
+package wrpcrypto
@@ -0,0 +1,280 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpcrypto
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// EncryptedValuePrefix starts every encrypted metadata value.  The rest of the
+// value is the key ID, a colon, and the unpadded base64url AES-GCM envelope.
+// The key ID and the metadata key are authenticated as additional data, so an
+// encrypted value cannot be moved to another metadata key.
+const EncryptedValuePrefix = "wrp-enc:"
+
+// IsEncryptedValue returns true if the metadata value was encrypted by a
+// MetadataEncrypter.
+func IsEncryptedValue(v string) bool {
+	return strings.HasPrefix(v, EncryptedValuePrefix)
+}
+
+// valueAAD is the additional data authenticated with a metadata value.
+func valueAAD(keyID, name string) []byte {
+	return []byte(keyID + "\x00" + name)
+}
+
+// parseValue splits an encrypted metadata value into its key ID and envelope.
+func parseValue(v string) (string, []byte, error) {
+	rest, ok := strings.CutPrefix(v, EncryptedValuePrefix)
+	if !ok {
+		return "", nil, ErrNotEncrypted
+	}
+
+	i := strings.LastIndexByte(rest, ':')
+	if i < 1 {
+		return "", nil, fmt.Errorf("%w: malformed metadata value", ErrDecrypt)
+	}
+
+	envelope, err := base64.RawURLEncoding.DecodeString(rest[i+1:])
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %w", ErrDecrypt, err)
+	}
+
+	return rest[:i], envelope, nil
+}
+
+// MetadataEncrypter encrypts the values of selected metadata keys, such as
+// account identifiers, so that they are protected at rest in queues and
+// archives while the rest of the message remains readable.
+type MetadataEncrypter struct {
+	keyID string
+	aead  cipher.AEAD
+	rand  io.Reader
+	names []string
+}
+
+var _ wrp.Modifier = (*MetadataEncrypter)(nil)
+
+// NewMetadataEncrypter creates a MetadataEncrypter that encrypts the values of
+// the named metadata keys with the given key, which must be 16, 24 or 32 bytes
+// long.  To rotate keys, create a new MetadataEncrypter with the new key ID
+// while keeping the old key available to the MetadataDecrypter.
+func NewMetadataEncrypter(keyID string, key []byte, names ...string) (*MetadataEncrypter, error) {
+	if keyID == "" || strings.ContainsRune(keyID, ':') {
+		return nil, fmt.Errorf("%w: key ID `%s`", ErrInvalidKey, keyID)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetadataEncrypter{
+		keyID: keyID,
+		aead:  aead,
+		rand:  rand.Reader,
+		names: names,
+	}, nil
+}
+
+// Encrypt encrypts the protected metadata values of the message in place.
+// Values that are already encrypted are left unchanged.  The message's
+// metadata map is replaced, not modified.
+func (e *MetadataEncrypter) Encrypt(msg *wrp.Message) error {
+	var add map[string]string
+	for _, name := range e.names {
+		v, ok := msg.Metadata[name]
+		if !ok || IsEncryptedValue(v) {
+			continue
+		}
+
+		encrypted, err := e.encryptValue(name, v)
+		if err != nil {
+			return err
+		}
+
+		if add == nil {
+			add = make(map[string]string, len(e.names))
+		}
+		add[name] = encrypted
+	}
+
+	if add != nil {
+		msg.Metadata = withMetadata(msg.Metadata, add)
+	}
+
+	return nil
+}
+
+func (e *MetadataEncrypter) encryptValue(name, v string) (string, error) {
+	nonce := make([]byte, e.aead.NonceSize(), e.aead.NonceSize()+len(v)+e.aead.Overhead())
+	if _, err := io.ReadFull(e.rand, nonce); err != nil {
+		return "", err
+	}
+
+	envelope := e.aead.Seal(nonce, nonce, []byte(v), valueAAD(e.keyID, name))
+	return EncryptedValuePrefix + e.keyID + ":" + base64.RawURLEncoding.EncodeToString(envelope), nil
+}
+
+// ModifyWRP returns a copy of the message with encrypted metadata values.
+// Messages without any of the protected metadata keys result in
+// wrp.ErrNotHandled.
+func (e *MetadataEncrypter) ModifyWRP(_ context.Context, msg wrp.Message) (wrp.Message, error) {
+	if !e.matches(msg) {
+		return msg, wrp.ErrNotHandled
+	}
+
+	err := e.Encrypt(&msg)
+	return msg, err
+}
+
+// matches returns true if the message has any of the protected metadata keys.
+func (e *MetadataEncrypter) matches(msg wrp.Message) bool {
+	for _, name := range e.names {
+		if _, ok := msg.Metadata[name]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MetadataDecrypter decrypts metadata values encrypted by a MetadataEncrypter.
+type MetadataDecrypter struct {
+	keys  Keys
+	names []string
+}
+
+var _ wrp.Modifier = (*MetadataDecrypter)(nil)
+
+// NewMetadataDecrypter creates a MetadataDecrypter that decrypts the values of
+// the named metadata keys using the given Keys.  Every key ID that may still
+// be found in stored messages must remain available from keys.
+func NewMetadataDecrypter(keys Keys, names ...string) (*MetadataDecrypter, error) {
+	if keys == nil {
+		return nil, fmt.Errorf("%w: nil keys", ErrInvalidKey)
+	}
+
+	return &MetadataDecrypter{
+		keys:  keys,
+		names: names,
+	}, nil
+}
+
+// Decrypt decrypts the protected metadata values of the message in place.
+// Values that are not encrypted are left unchanged.  The message's metadata
+// map is replaced, not modified.
+func (d *MetadataDecrypter) Decrypt(msg *wrp.Message) error {
+	var add map[string]string
+	for _, name := range d.names {
+		v, ok := msg.Metadata[name]
+		if !ok || !IsEncryptedValue(v) {
+			continue
+		}
+
+		decrypted, _, err := d.decryptValue(name, v)
+		if err != nil {
+			return fmt.Errorf("metadata `%s`: %w", name, err)
+		}
+
+		if add == nil {
+			add = make(map[string]string, len(d.names))
+		}
+		add[name] = decrypted
+	}
+
+	if add != nil {
+		msg.Metadata = withMetadata(msg.Metadata, add)
+	}
+
+	return nil
+}
+
+// decryptValue returns the plaintext value and the ID of the key that
+// encrypted it.
+func (d *MetadataDecrypter) decryptValue(name, v string) (string, string, error) {
+	keyID, envelope, err := parseValue(v)
+	if err != nil {
+		return "", "", err
+	}
+
+	key, err := d.keys.Key(keyID)
+	if err != nil {
+		return "", "", err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", "", err
+	}
+
+	if len(envelope) < aead.NonceSize() {
+		return "", "", fmt.Errorf("%w: envelope too short", ErrDecrypt)
+	}
+
+	nonce, sealed := envelope[:aead.NonceSize()], envelope[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, valueAAD(keyID, name))
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %w", ErrDecrypt, err)
+	}
+
+	return string(plaintext), keyID, nil
+}
+
+// ModifyWRP returns a copy of the message with decrypted metadata values.
+func (d *MetadataDecrypter) ModifyWRP(_ context.Context, msg wrp.Message) (wrp.Message, error) {
+	err := d.Decrypt(&msg)
+	return msg, err
+}
+
+// RotateMetadata returns a wrp.Modifier that re-encrypts the metadata values
+// that e protects with e's key, e.g. when rewriting an archive after a key
+// rotation.  Values that are not encrypted are encrypted, and values already
+// encrypted with e's key are left unchanged.
+func RotateMetadata(d *MetadataDecrypter, e *MetadataEncrypter) wrp.Modifier {
+	return wrp.ModifierFunc(func(_ context.Context, msg wrp.Message) (wrp.Message, error) {
+		var add map[string]string
+		for _, name := range e.names {
+			v, ok := msg.Metadata[name]
+			if !ok {
+				continue
+			}
+
+			if IsEncryptedValue(v) {
+				plaintext, keyID, err := d.decryptValue(name, v)
+				if err != nil {
+					return msg, fmt.Errorf("metadata `%s`: %w", name, err)
+				}
+				if keyID == e.keyID {
+					continue
+				}
+				v = plaintext
+			}
+
+			encrypted, err := e.encryptValue(name, v)
+			if err != nil {
+				return msg, err
+			}
+
+			if add == nil {
+				add = make(map[string]string, len(e.names))
+			}
+			add[name] = encrypted
+		}
+
+		if add != nil {
+			msg.Metadata = withMetadata(msg.Metadata, add)
+		}
+
+		return msg, nil
+	})
+}
@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpcrypto
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func piiMessage() wrp.Message {
+	return wrp.Message{
+		Type:        wrp.SimpleEventMessageType,
+		Source:      "mac:112233445566",
+		Destination: "event:account",
+		Metadata: map[string]string{
+			"/account-id": "8675309",
+			"/email":      "someone@example.com",
+			"/boot-time":  "1",
+		},
+	}
+}
+
+func TestMetadataRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	names := []string{"/account-id", "/email", "/missing"}
+	e, err := NewMetadataEncrypter("k1", key1, names...)
+	require.NoError(err)
+
+	d, err := NewMetadataDecrypter(KeyMap{"k1": key1}, names...)
+	require.NoError(err)
+
+	original := piiMessage()
+	encrypted, err := e.ModifyWRP(context.Background(), original)
+	require.NoError(err)
+
+	assert.Equal(piiMessage(), original, "the original message must not be modified")
+	assert.True(IsEncryptedValue(encrypted.Metadata["/account-id"]))
+	assert.True(strings.HasPrefix(encrypted.Metadata["/email"], EncryptedValuePrefix+"k1:"))
+	assert.NotContains(encrypted.Metadata["/email"], "example.com")
+	assert.Equal("1", encrypted.Metadata["/boot-time"])
+	assert.NotContains(encrypted.Metadata, "/missing")
+
+	// encrypting twice leaves the values alone
+	again, err := e.ModifyWRP(context.Background(), encrypted)
+	require.NoError(err)
+	assert.Equal(encrypted, again)
+
+	decrypted, err := d.ModifyWRP(context.Background(), encrypted)
+	require.NoError(err)
+	assert.Equal(piiMessage(), decrypted)
+	assert.True(IsEncryptedValue(encrypted.Metadata["/email"]), "the encrypted message must not be modified")
+
+	// plaintext values pass through the decrypter
+	plain, err := d.ModifyWRP(context.Background(), piiMessage())
+	require.NoError(err)
+	assert.Equal(piiMessage(), plain)
+}
+
+func TestMetadataEncrypter(t *testing.T) {
+	for _, keyID := range []string{"", "k:1"} {
+		_, err := NewMetadataEncrypter(keyID, key1, "/account-id")
+		assert.ErrorIs(t, err, ErrInvalidKey)
+	}
+
+	_, err := NewMetadataEncrypter("k1", []byte("short"), "/account-id")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	e, err := NewMetadataEncrypter("k1", key1, "/account-id")
+	require.NoError(t, err)
+
+	errRandom := errors.New("no entropy")
+	e.rand = iotest.ErrReader(errRandom)
+	msg := piiMessage()
+	assert.ErrorIs(t, e.Encrypt(&msg), errRandom)
+	assert.Equal(t, piiMessage(), msg)
+
+	unprotected := piiMessage()
+	delete(unprotected.Metadata, "/account-id")
+	modified, err := e.ModifyWRP(context.Background(), unprotected)
+	assert.ErrorIs(t, err, wrp.ErrNotHandled)
+	assert.Equal(t, unprotected, modified)
+}
+
+func TestMetadataDecrypter(t *testing.T) {
+	e, err := NewMetadataEncrypter("k1", key1, "/account-id", "/email")
+	require.NoError(t, err)
+
+	encrypted := piiMessage()
+	require.NoError(t, e.Encrypt(&encrypted))
+
+	with := func(f func(map[string]string)) wrp.Message {
+		msg := encrypted
+		msg.Metadata = withMetadata(encrypted.Metadata, nil)
+		f(msg.Metadata)
+		return msg
+	}
+
+	tests := []struct {
+		desc     string
+		keys     Keys
+		msg      wrp.Message
+		expected error
+	}{
+		{
+			desc:     "unknown key",
+			keys:     KeyMap{"k2": key2},
+			msg:      encrypted,
+			expected: ErrUnknownKey,
+		}, {
+			desc:     "wrong key",
+			keys:     KeyMap{"k1": key2},
+			msg:      encrypted,
+			expected: ErrDecrypt,
+		}, {
+			desc: "value moved to another key",
+			keys: KeyMap{"k1": key1},
+			msg: with(func(m map[string]string) {
+				m["/email"] = m["/account-id"]
+			}),
+			expected: ErrDecrypt,
+		}, {
+			desc: "malformed value",
+			keys: KeyMap{"k1": key1},
+			msg: with(func(m map[string]string) {
+				m["/email"] = EncryptedValuePrefix + "k1"
+			}),
+			expected: ErrDecrypt,
+		}, {
+			desc: "invalid base64",
+			keys: KeyMap{"k1": key1},
+			msg: with(func(m map[string]string) {
+				m["/email"] = EncryptedValuePrefix + "k1:!!!"
+			}),
+			expected: ErrDecrypt,
+		}, {
+			desc: "truncated envelope",
+			keys: KeyMap{"k1": key1},
+			msg: with(func(m map[string]string) {
+				m["/email"] = EncryptedValuePrefix + "k1:AAAA"
+			}),
+			expected: ErrDecrypt,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			d, err := NewMetadataDecrypter(tc.keys, "/account-id", "/email")
+			require.NoError(t, err)
+
+			msg := tc.msg
+			assert.ErrorIs(t, d.Decrypt(&msg), tc.expected)
+		})
+	}
+
+	_, err = NewMetadataDecrypter(nil)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestRotateMetadata(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	names := []string{"/account-id", "/email"}
+	old, err := NewMetadataEncrypter("k1", key1, names...)
+	require.NoError(err)
+	current, err := NewMetadataEncrypter("k2", key2, names...)
+	require.NoError(err)
+	d, err := NewMetadataDecrypter(KeyMap{"k1": key1, "k2": key2}, names...)
+	require.NoError(err)
+
+	// one value from before the rotation and one that was never encrypted
+	msg := piiMessage()
+	old.names = []string{"/account-id"}
+	require.NoError(old.Encrypt(&msg))
+
+	rotated, err := RotateMetadata(d, current).ModifyWRP(context.Background(), msg)
+	require.NoError(err)
+	assert.True(strings.HasPrefix(rotated.Metadata["/account-id"], EncryptedValuePrefix+"k2:"))
+	assert.True(strings.HasPrefix(rotated.Metadata["/email"], EncryptedValuePrefix+"k2:"))
+
+	// values already using the current key are not re-encrypted
+	again, err := RotateMetadata(d, current).ModifyWRP(context.Background(), rotated)
+	require.NoError(err)
+	assert.Equal(rotated, again)
+
+	decrypted, err := d.ModifyWRP(context.Background(), rotated)
+	require.NoError(err)
+	assert.Equal(piiMessage(), decrypted)
+
+	// values that cannot be decrypted are not rotated
+	withoutOld, err := NewMetadataDecrypter(KeyMap{"k2": key2}, names...)
+	require.NoError(err)
+	_, err = RotateMetadata(withoutOld, current).ModifyWRP(context.Background(), msg)
+	assert.ErrorIs(err, ErrUnknownKey)
+}
@@ -7,21 +7,30 @@ import (
 	"context"
 
 	"github.com/go-kit/kit/endpoint"
+	"github.com/xmidt-org/wrp-go/v3"
 )
 
 // New constructs a go-kit endpoint for the given WRP service.  This endpoint enforces
-// the constraint that ctx must be the context associated with the Request.
+// the constraint that ctx must be the context associated with the Request.  If the request
+// has a message, it is added to the context passed to the service, see wrp.MessageFromContext.
 func New(s Service) endpoint.Endpoint {
 	return func(ctx context.Context, value interface{}) (interface{}, error) {
-		return s.ServeWRP(ctx, value.(Request))
+		request := value.(Request)
+		if msg := request.Message(); msg != nil {
+			ctx = wrp.ContextWithMessage(ctx, msg)
+		}
+
+		return s.ServeWRP(ctx, request)
 	}
 }
 
 // Wrap does the opposite of New: it takes a go-kit endpoint and returns a Service
-// that invokes it.
+// that invokes it.  An endpoint with no response, e.g. one created with
+// ProcessorEndpoint, results in a nil Response.
 func Wrap(e endpoint.Endpoint) Service {
 	return ServiceFunc(func(ctx context.Context, request Request) (Response, error) {
-		response, err := e(ctx, request)
-		return response.(Response), err
+		value, err := e(ctx, request)
+		response, _ := value.(Response)
+		return response, err
 	})
 }
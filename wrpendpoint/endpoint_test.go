@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/xmidt-org/wrp-go/v3"
 )
 
 type testContextKey string
@@ -42,6 +43,34 @@ func TestNew(t *testing.T) {
 	service.AssertExpectations(t)
 }
 
+func TestNewWithMessage(t *testing.T) {
+	var (
+		assert = assert.New(t)
+
+		expectedMessage         = &wrp.Message{Type: wrp.SimpleEventMessageType}
+		expectedRequest Request = WrapAsRequest(nil, expectedMessage)
+
+		expectedResponse Response = &response{
+			note: note{
+				contents: []byte("response"),
+			},
+		}
+
+		service = ServiceFunc(func(ctx context.Context, r Request) (Response, error) {
+			msg, ok := wrp.MessageFromContext(ctx)
+			assert.True(ok)
+			assert.Same(expectedMessage, msg)
+			assert.Equal("bar", ctx.Value(foo))
+			assert.Equal(expectedRequest, r)
+			return expectedResponse, nil
+		})
+	)
+
+	actualResponse, err := New(service)(context.WithValue(context.Background(), foo, "bar"), expectedRequest)
+	assert.Equal(expectedResponse, actualResponse)
+	assert.NoError(err)
+}
+
 func TestWrap(t *testing.T) {
 	var (
 		assert = assert.New(t)
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpendpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// IdempotentKey is the metadata key that marks a SimpleRequestResponse message
+// as safe to send more than once.  Its value is parsed with strconv.ParseBool.
+const IdempotentKey = "/wrp-idempotent"
+
+// DefaultHedgeDelay is the time to wait for the first attempt before sending
+// the second.
+const DefaultHedgeDelay = 500 * time.Millisecond
+
+var ErrInvalidHedge = errors.New("invalid hedge configuration")
+
+// HedgeOption is a functional option for configuring the hedge middleware.
+type HedgeOption interface {
+	apply(*hedge) error
+}
+
+type hedgeOptionFunc func(*hedge) error
+
+func (f hedgeOptionFunc) apply(h *hedge) error {
+	return f(h)
+}
+
+// HedgeDelay sets how long the first attempt may take before the second is
+// sent.  The default is DefaultHedgeDelay.
+func HedgeDelay(d time.Duration) HedgeOption {
+	return hedgeOptionFunc(func(h *hedge) error {
+		if d <= 0 {
+			return fmt.Errorf("%w: delay %s", ErrInvalidHedge, d)
+		}
+		h.delay = d
+		return nil
+	})
+}
+
+type hedge struct {
+	delay time.Duration
+}
+
+// NewHedge creates a Middleware that hedges idempotent requests: if the first
+// attempt has not completed after the hedge delay, a second attempt is sent and
+// the response of whichever attempt succeeds first is used.  This reduces the
+// tail latency of requests over flaky device links at the cost of some
+// duplicate traffic.
+//
+// Only Retrieve messages and SimpleRequestResponse messages whose IdempotentKey
+// metadata is true are hedged; all other requests are passed through.  The
+// attempt that is not used has its context canceled.  Hedging is not retrying:
+// a first attempt that fails before the delay is returned as is, and if both
+// attempts fail, the error of the last one is returned.
+func NewHedge(opts ...HedgeOption) (Middleware, error) {
+	h := hedge{
+		delay: DefaultHedgeDelay,
+	}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt.apply(&h); err != nil {
+			return nil, err
+		}
+	}
+
+	return h.middleware, nil
+}
+
+// IsIdempotent tests if the message may be sent more than once without
+// changing its outcome.
+func IsIdempotent(msg *wrp.Message) bool {
+	if msg == nil {
+		return false
+	}
+
+	switch msg.Type {
+	case wrp.RetrieveMessageType:
+		return true
+	case wrp.SimpleRequestResponseMessageType:
+		idempotent, _ := strconv.ParseBool(msg.Metadata[IdempotentKey])
+		return idempotent
+	default:
+		return false
+	}
+}
+
+type hedgeResult struct {
+	response Response
+	err      error
+}
+
+func (h hedge) middleware(next Service) Service {
+	return ServiceFunc(func(ctx context.Context, r Request) (Response, error) {
+		if !IsIdempotent(r.Message()) {
+			return next.ServeWRP(ctx, r)
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// buffered so that the attempt that is not used does not block
+		results := make(chan hedgeResult, 2)
+		attempt := func() {
+			response, err := next.ServeWRP(ctx, r)
+			results <- hedgeResult{response: response, err: err}
+		}
+
+		go attempt()
+		pending := 1
+
+		timer := time.NewTimer(h.delay)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-timer.C:
+				go attempt()
+				pending++
+
+			case result := <-results:
+				pending--
+				if result.err == nil || pending == 0 {
+					return result.response, result.err
+				}
+			}
+		}
+	})
+}
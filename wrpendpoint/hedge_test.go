@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpendpoint
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestIsIdempotent(t *testing.T) {
+	tests := []struct {
+		desc     string
+		msg      *wrp.Message
+		expected bool
+	}{
+		{
+			desc: "nil",
+		}, {
+			desc:     "retrieve",
+			msg:      &wrp.Message{Type: wrp.RetrieveMessageType},
+			expected: true,
+		}, {
+			desc:     "marked request",
+			msg:      &wrp.Message{Type: wrp.SimpleRequestResponseMessageType, Metadata: map[string]string{IdempotentKey: "true"}},
+			expected: true,
+		}, {
+			desc: "unmarked request",
+			msg:  &wrp.Message{Type: wrp.SimpleRequestResponseMessageType},
+		}, {
+			desc: "request marked false",
+			msg:  &wrp.Message{Type: wrp.SimpleRequestResponseMessageType, Metadata: map[string]string{IdempotentKey: "false"}},
+		}, {
+			desc: "marked update",
+			msg:  &wrp.Message{Type: wrp.UpdateMessageType, Metadata: map[string]string{IdempotentKey: "true"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsIdempotent(tc.msg))
+		})
+	}
+}
+
+func TestNewHedge_invalid(t *testing.T) {
+	for _, d := range []time.Duration{0, -time.Second} {
+		m, err := NewHedge(HedgeDelay(d))
+		assert.Nil(t, m)
+		assert.ErrorIs(t, err, ErrInvalidHedge)
+	}
+}
+
+func TestNewHedge(t *testing.T) {
+	var (
+		errAttempt = errors.New("expected")
+		response   = WrapAsResponse(&wrp.Message{Type: wrp.RetrieveMessageType, Payload: []byte("ok")})
+	)
+
+	// each attempt takes the duration and returns the error at its index
+	type attempt struct {
+		d   time.Duration
+		err error
+	}
+
+	tests := []struct {
+		desc     string
+		msg      *wrp.Message
+		attempts []attempt
+		calls    int32
+		err      error
+	}{
+		{
+			desc:     "fast first attempt",
+			msg:      &wrp.Message{Type: wrp.RetrieveMessageType},
+			attempts: []attempt{{d: 0}},
+			calls:    1,
+		}, {
+			desc:     "slow first attempt is hedged",
+			msg:      &wrp.Message{Type: wrp.RetrieveMessageType},
+			attempts: []attempt{{d: time.Hour}, {d: 0}},
+			calls:    2,
+		}, {
+			desc:     "failed hedge waits for the first attempt",
+			msg:      &wrp.Message{Type: wrp.SimpleRequestResponseMessageType, Metadata: map[string]string{IdempotentKey: "true"}},
+			attempts: []attempt{{d: 100 * time.Millisecond}, {err: errAttempt}},
+			calls:    2,
+		}, {
+			desc:     "both attempts fail",
+			msg:      &wrp.Message{Type: wrp.RetrieveMessageType},
+			attempts: []attempt{{d: 100 * time.Millisecond, err: errAttempt}, {err: errAttempt}},
+			calls:    2,
+			err:      errAttempt,
+		}, {
+			desc:     "fast failure is not retried",
+			msg:      &wrp.Message{Type: wrp.RetrieveMessageType},
+			attempts: []attempt{{err: errAttempt}},
+			calls:    1,
+			err:      errAttempt,
+		}, {
+			desc:     "not idempotent",
+			msg:      &wrp.Message{Type: wrp.SimpleRequestResponseMessageType},
+			attempts: []attempt{{d: 100 * time.Millisecond}},
+			calls:    1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m, err := NewHedge(HedgeDelay(10*time.Millisecond), nil)
+			require.NoError(t, err)
+
+			var calls atomic.Int32
+			service := m(ServiceFunc(func(ctx context.Context, _ Request) (Response, error) {
+				a := tc.attempts[calls.Add(1)-1]
+				select {
+				case <-time.After(a.d):
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+				if a.err != nil {
+					return nil, a.err
+				}
+				return response, nil
+			}))
+
+			actual, err := service.ServeWRP(context.Background(), WrapAsRequest(log.NewNopLogger(), tc.msg))
+			assert.Equal(tc.calls, calls.Load())
+			if tc.err != nil {
+				assert.ErrorIs(err, tc.err)
+				assert.Nil(actual)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(response, actual)
+		})
+	}
+}
@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpendpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// DefaultKitRetryAfter is how long clients of requests rejected by a go-kit
+// rate limiter or circuit breaker are asked to wait before trying again.
+const DefaultKitRetryAfter = time.Second
+
+var ErrInvalidKit = errors.New("invalid go-kit middleware configuration")
+
+// KitOption is a functional option for configuring the middleware created by
+// NewRateLimit and NewCircuitBreaker.
+type KitOption interface {
+	apply(*kit) error
+}
+
+type kitOptionFunc func(*kit) error
+
+func (f kitOptionFunc) apply(k *kit) error {
+	return f(k)
+}
+
+// KitBypassQOS sets the lowest QOS level of the messages that bypass the go-kit
+// middleware, e.g. wrp.QOSCritical so that critical messages are never rate
+// limited.  By default, no messages bypass it.
+func KitBypassQOS(level wrp.QOSLevel) KitOption {
+	return kitOptionFunc(func(k *kit) error {
+		if level < wrp.QOSLow || level > wrp.QOSCritical {
+			return fmt.Errorf("%w: unknown QOS level %d", ErrInvalidKit, level)
+		}
+		k.bypass = level
+		return nil
+	})
+}
+
+// KitRetryAfter sets the RetryAfter of the errors returned for rejected
+// requests.  The default is DefaultKitRetryAfter.
+func KitRetryAfter(d time.Duration) KitOption {
+	return kitOptionFunc(func(k *kit) error {
+		if d <= 0 {
+			return fmt.Errorf("%w: retry after %s", ErrInvalidKit, d)
+		}
+		k.retryAfter = d
+		return nil
+	})
+}
+
+type kit struct {
+	middleware endpoint.Middleware
+	bypass     wrp.QOSLevel
+	retryAfter time.Duration
+
+	// reject translates the error of a request the go-kit middleware rejected
+	reject func(level wrp.QOSLevel, err error) error
+
+	// failed reports whether a response counts as a failure to the go-kit
+	// middleware
+	failed func(Response) bool
+}
+
+// NewRateLimit applies a go-kit rate limiting middleware, such as
+// ratelimit.NewErroringLimiter with a *rate.Limiter from golang.org/x/time/rate,
+// to WRP requests.  A request the limiter rejects results in a
+// *wrp.RateLimitError whose Key is the QOS level of the request, e.g.
+// "qos:Low", which wrphttp.ErrorEncoder writes as a 429 Too Many Requests.
+func NewRateLimit(m endpoint.Middleware, opts ...KitOption) (Middleware, error) {
+	return newKit(m, opts, func(k *kit) {
+		k.reject = func(level wrp.QOSLevel, _ error) error {
+			return &wrp.RateLimitError{
+				Key:        "qos:" + level.String(),
+				RetryAfter: k.retryAfter,
+			}
+		}
+	})
+}
+
+// NewCircuitBreaker applies a go-kit circuit breaker middleware, such as
+// circuitbreaker.Gobreaker with a *gobreaker.CircuitBreaker from
+// github.com/sony/gobreaker, to WRP requests.
+//
+// Besides the errors of the Service, responses with a non-zero
+// RequestDeliveryResponse count as failures to the breaker, since they report
+// that the message could not be delivered; they are still returned as
+// responses.  A request the breaker rejects results in a *BusyError that wraps
+// the breaker's error, which wrphttp.ErrorEncoder writes as a 503 Service
+// Unavailable.
+func NewCircuitBreaker(m endpoint.Middleware, opts ...KitOption) (Middleware, error) {
+	return newKit(m, opts, func(k *kit) {
+		k.reject = func(level wrp.QOSLevel, err error) error {
+			return &BusyError{
+				Level:      level,
+				RetryAfter: k.retryAfter,
+				Err:        err,
+			}
+		}
+		k.failed = undelivered
+	})
+}
+
+func newKit(m endpoint.Middleware, opts []KitOption, init func(*kit)) (Middleware, error) {
+	if m == nil {
+		return nil, fmt.Errorf("%w: no middleware", ErrInvalidKit)
+	}
+
+	k := kit{
+		middleware: m,
+		bypass:     wrp.QOSCritical + 1,
+		retryAfter: DefaultKitRetryAfter,
+	}
+	init(&k)
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt.apply(&k); err != nil {
+			return nil, err
+		}
+	}
+
+	return k.decorate, nil
+}
+
+// undelivered tests if the response's message has a delivery failure RDR.
+func undelivered(response Response) bool {
+	if response == nil || response.Message() == nil {
+		return false
+	}
+
+	rdr := response.Message().RequestDeliveryResponse
+	return rdr != nil && *rdr != 0
+}
+
+// kitRequest is the request passed through the go-kit middleware, which
+// records whether the Service was called.
+type kitRequest struct {
+	request Request
+	called  bool
+}
+
+// failedResponse is the error reported to the go-kit middleware for a
+// response that counts as a failure.
+type failedResponse struct {
+	response Response
+}
+
+func (failedResponse) Error() string {
+	return "undelivered response"
+}
+
+func (k kit) decorate(next Service) Service {
+	e := k.middleware(func(ctx context.Context, value interface{}) (interface{}, error) {
+		kr := value.(*kitRequest)
+		kr.called = true
+
+		response, err := next.ServeWRP(ctx, kr.request)
+		if err == nil && k.failed != nil && k.failed(response) {
+			return nil, failedResponse{response: response}
+		}
+
+		return response, err
+	})
+
+	return ServiceFunc(func(ctx context.Context, r Request) (Response, error) {
+		level := wrp.QOSLow
+		if msg := r.Message(); msg != nil {
+			level = msg.QualityOfService.Level()
+		}
+
+		if level >= k.bypass {
+			return next.ServeWRP(ctx, r)
+		}
+
+		kr := kitRequest{request: r}
+		response, err := e(ctx, &kr)
+
+		var fr failedResponse
+		switch {
+		case errors.As(err, &fr):
+			return fr.response, nil
+		case err != nil && !kr.called:
+			return nil, k.reject(level, err)
+		case err != nil:
+			return nil, err
+		}
+
+		if response == nil {
+			return nil, nil
+		}
+
+		return response.(Response), nil
+	})
+}
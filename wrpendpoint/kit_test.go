@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpendpoint
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/ratelimit"
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+var errOpen = errors.New("circuit breaker is open")
+
+// testBreaker is a circuit breaker middleware that opens after a number of
+// consecutive failures, like the go-kit circuitbreaker middleware.
+type testBreaker struct {
+	threshold int
+	failures  int
+}
+
+func (b *testBreaker) middleware(next endpoint.Endpoint) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		if b.failures >= b.threshold {
+			return nil, errOpen
+		}
+
+		response, err := next(ctx, request)
+		if err != nil {
+			b.failures++
+		} else {
+			b.failures = 0
+		}
+
+		return response, err
+	}
+}
+
+func kitRequestOf(qos wrp.QOSValue) Request {
+	return WrapAsRequest(log.NewNopLogger(), &wrp.Message{
+		Type:             wrp.SimpleRequestResponseMessageType,
+		QualityOfService: qos,
+	})
+}
+
+func TestNewRateLimit(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	allow := true
+	m, err := NewRateLimit(
+		ratelimit.NewErroringLimiter(ratelimit.AllowerFunc(func() bool { return allow })),
+		KitBypassQOS(wrp.QOSCritical),
+		KitRetryAfter(2*time.Second),
+		nil,
+	)
+	require.NoError(err)
+
+	var calls int
+	svc := m(ServiceFunc(func(_ context.Context, r Request) (Response, error) {
+		calls++
+		return WrapAsResponse(r.Message()), nil
+	}))
+
+	response, err := svc.ServeWRP(context.Background(), kitRequestOf(wrp.QOSLowValue))
+	require.NoError(err)
+	assert.NotNil(response)
+	assert.Equal(1, calls)
+
+	allow = false
+	response, err = svc.ServeWRP(context.Background(), kitRequestOf(wrp.QOSMediumValue))
+	assert.Nil(response)
+
+	var rle *wrp.RateLimitError
+	require.True(errors.As(err, &rle))
+	assert.Equal("qos:Medium", rle.Key)
+	assert.Equal(2*time.Second, rle.RetryAfter)
+	assert.Equal(1, calls)
+
+	// critical messages bypass the limiter
+	_, err = svc.ServeWRP(context.Background(), kitRequestOf(wrp.QOSCriticalValue))
+	assert.NoError(err)
+	assert.Equal(2, calls)
+}
+
+func TestNewCircuitBreaker(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var (
+		b       = testBreaker{threshold: 2}
+		errFail = errors.New("expected")
+		results = []func(Request) (Response, error){
+			func(r Request) (Response, error) {
+				msg := *r.Message()
+				msg.SetRequestDeliveryResponse(1)
+				return WrapAsResponse(&msg), nil
+			},
+			func(Request) (Response, error) {
+				return nil, errFail
+			},
+		}
+	)
+
+	m, err := NewCircuitBreaker(b.middleware)
+	require.NoError(err)
+
+	svc := m(ServiceFunc(func(_ context.Context, r Request) (Response, error) {
+		result := results[0]
+		results = results[1:]
+		return result(r)
+	}))
+
+	// an undelivered response counts as a failure, but is still returned
+	response, err := svc.ServeWRP(context.Background(), kitRequestOf(wrp.QOSHighValue))
+	require.NoError(err)
+	require.NotNil(response)
+	assert.Equal(int64(1), *response.Message().RequestDeliveryResponse)
+	assert.Equal(1, b.failures)
+
+	// errors of the service are returned as is
+	_, err = svc.ServeWRP(context.Background(), kitRequestOf(wrp.QOSHighValue))
+	assert.Same(errFail, err)
+	assert.Equal(2, b.failures)
+
+	// the breaker is open
+	response, err = svc.ServeWRP(context.Background(), kitRequestOf(wrp.QOSHighValue))
+	assert.Nil(response)
+	assert.ErrorIs(err, ErrBusy)
+	assert.ErrorIs(err, errOpen)
+
+	var be *BusyError
+	require.True(errors.As(err, &be))
+	assert.Equal(wrp.QOSHigh, be.Level)
+	assert.Equal(DefaultKitRetryAfter, be.RetryAfter)
+	assert.Contains(be.Error(), errOpen.Error())
+}
+
+func TestNewCircuitBreaker_success(t *testing.T) {
+	b := testBreaker{threshold: 1}
+	m, err := NewCircuitBreaker(b.middleware)
+	require.NoError(t, err)
+
+	response, err := m(ServiceFunc(func(context.Context, Request) (Response, error) {
+		return nil, nil
+	})).ServeWRP(context.Background(), &request{})
+	assert.NoError(t, err)
+	assert.Nil(t, response)
+	assert.Zero(t, b.failures)
+}
+
+func TestNewKit_invalid(t *testing.T) {
+	nop := func(next endpoint.Endpoint) endpoint.Endpoint { return next }
+
+	tests := []struct {
+		desc string
+		m    endpoint.Middleware
+		opts []KitOption
+	}{
+		{
+			desc: "no middleware",
+		}, {
+			desc: "unknown QOS level",
+			m:    nop,
+			opts: []KitOption{KitBypassQOS(wrp.QOSCritical + 1)},
+		}, {
+			desc: "retry after",
+			m:    nop,
+			opts: []KitOption{KitRetryAfter(0)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			m, err := NewRateLimit(tc.m, tc.opts...)
+			assert.ErrorIs(t, err, ErrInvalidKit)
+			assert.Nil(t, m)
+
+			m, err = NewCircuitBreaker(tc.m, tc.opts...)
+			assert.ErrorIs(t, err, ErrInvalidKit)
+			assert.Nil(t, m)
+		})
+	}
+}
@@ -0,0 +1,292 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpendpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+
+	"github.com/xmidt-org/wrp-go/v3"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var ErrInvalidLogging = errors.New("invalid logging configuration")
+
+// Logger is the minimal logging interface used by the logging middleware.
+// Use NewSlogLogger or NewZapLogger to adapt a concrete logger.
+type Logger interface {
+	// LogWRP writes a single log entry.
+	LogWRP(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr)
+}
+
+// LoggerFunc is a convenience type to define a Logger using a function.
+type LoggerFunc func(context.Context, slog.Level, string, ...slog.Attr)
+
+func (f LoggerFunc) LogWRP(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	f(ctx, level, msg, attrs...)
+}
+
+// NewSlogLogger adapts a slog.Logger.
+func NewSlogLogger(l *slog.Logger) Logger {
+	return LoggerFunc(func(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+		l.LogAttrs(ctx, level, msg, attrs...)
+	})
+}
+
+// NewZapLogger adapts a zap.Logger.  Levels between the standard slog levels
+// are rounded down, e.g. slog.LevelInfo+2 is logged at zap's info level.
+func NewZapLogger(l *zap.Logger) Logger {
+	return LoggerFunc(func(_ context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+		ce := l.Check(zapLevel(level), msg)
+		if ce == nil {
+			return
+		}
+
+		fields := make([]zap.Field, len(attrs))
+		for i, a := range attrs {
+			fields[i] = zap.Any(a.Key, a.Value.Any())
+		}
+		ce.Write(fields...)
+	})
+}
+
+func zapLevel(level slog.Level) zapcore.Level {
+	switch {
+	case level >= slog.LevelError:
+		return zapcore.ErrorLevel
+	case level >= slog.LevelWarn:
+		return zapcore.WarnLevel
+	case level >= slog.LevelInfo:
+		return zapcore.InfoLevel
+	default:
+		return zapcore.DebugLevel
+	}
+}
+
+// LoggingOption is a functional option for configuring the logging middleware.
+type LoggingOption interface {
+	apply(*logging) error
+}
+
+type loggingOptionFunc func(*logging) error
+
+func (f loggingOptionFunc) apply(l *logging) error {
+	return f(l)
+}
+
+// LogLevel sets the level of the entries for successful transactions.  The
+// default is slog.LevelInfo.
+func LogLevel(level slog.Level) LoggingOption {
+	return loggingOptionFunc(func(l *logging) error {
+		l.level = level
+		return nil
+	})
+}
+
+// LogErrorLevel sets the level of the entries for failed transactions.  The
+// default is slog.LevelError.
+func LogErrorLevel(level slog.Level) LoggingOption {
+	return loggingOptionFunc(func(l *logging) error {
+		l.errorLevel = level
+		return nil
+	})
+}
+
+// LogSampleRate sets the fraction, in the range [0, 1], of successful
+// transactions that are logged.  The default is 1, which logs every
+// transaction.
+func LogSampleRate(rate float64) LoggingOption {
+	return loggingOptionFunc(func(l *logging) error {
+		if !(rate >= 0 && rate <= 1) {
+			return fmt.Errorf("%w: sample rate %v", ErrInvalidLogging, rate)
+		}
+		l.sampleRate = rate
+		return nil
+	})
+}
+
+// LogErrorSampleRate sets the fraction, in the range [0, 1], of failed
+// transactions that are logged.  The default is 1, which logs every failure.
+func LogErrorSampleRate(rate float64) LoggingOption {
+	return loggingOptionFunc(func(l *logging) error {
+		if !(rate >= 0 && rate <= 1) {
+			return fmt.Errorf("%w: error sample rate %v", ErrInvalidLogging, rate)
+		}
+		l.errorSampleRate = rate
+		return nil
+	})
+}
+
+// LogRedactor sets the Redactor that is applied to messages before they are
+// logged, so that log entries are redacted by the same policy as other
+// sanitized copies of messages.  Only the summary fields are ever logged:
+// Type, Source, Destination, TransactionUUID, Path, ContentType and Status.
+// The Payload is never logged, only its length.  By default, nothing is
+// redacted.
+func LogRedactor(r *wrp.Redactor) LoggingOption {
+	return loggingOptionFunc(func(l *logging) error {
+		l.redactor = r
+		return nil
+	})
+}
+
+// LogClock sets the clock used to measure the duration of transactions.  The
+// default is wrp.SystemClock.
+func LogClock(clock wrp.Clock) LoggingOption {
+	return loggingOptionFunc(func(l *logging) error {
+		if clock != nil {
+			l.clock = clock
+		}
+		return nil
+	})
+}
+
+type logging struct {
+	logger          Logger
+	level           slog.Level
+	errorLevel      slog.Level
+	sampleRate      float64
+	errorSampleRate float64
+	redactor        *wrp.Redactor
+	clock           wrp.Clock
+
+	// random is replaceable for testing
+	random func() float64
+}
+
+// NewLogging creates a Middleware that logs a redacted summary of each WRP
+// request and its response or error.
+//
+// Both entries carry the request's transaction_uuid so that they can be
+// correlated.  The response entry also includes response_transaction_uuid if
+// the response has a different TransactionUUID.
+//
+// Sampling is decided once per transaction, so the request and response
+// entries of a successful transaction are either both logged or both dropped.
+// Transactions are sampled by wrp.TransactionSample, so every service using
+// the same sample rate logs the same transactions.  The failure of a
+// transaction is logged if either sample rate selects it, but its request
+// entry only if the success sample rate does, since the outcome is not known
+// when the request is logged.
+func NewLogging(logger Logger, opts ...LoggingOption) (Middleware, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("%w: nil logger", ErrInvalidLogging)
+	}
+
+	l := logging{
+		logger:          logger,
+		level:           slog.LevelInfo,
+		errorLevel:      slog.LevelError,
+		sampleRate:      1,
+		errorSampleRate: 1,
+		clock:           wrp.SystemClock,
+		random:          rand.Float64, // nolint:gosec
+	}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt.apply(&l); err != nil {
+			return nil, err
+		}
+	}
+
+	return l.middleware, nil
+}
+
+func (l *logging) middleware(next Service) Service {
+	return ServiceFunc(func(ctx context.Context, r Request) (Response, error) {
+		var (
+			tid    = r.TransactionID()
+			sample = l.sample(tid)
+			start  = l.clock.Now()
+		)
+
+		if sample < l.sampleRate {
+			l.logger.LogWRP(ctx, l.level, "wrp request", l.summary(tid, l.redact(r.Message()))...)
+		}
+
+		response, err := next.ServeWRP(ctx, r)
+
+		attrs := []slog.Attr{
+			slog.String("transaction_uuid", l.redactTransactionID(tid)),
+			slog.Duration("duration", l.clock.Now().Sub(start)),
+		}
+
+		switch {
+		case err != nil:
+			if sample < l.errorSampleRate || sample < l.sampleRate {
+				attrs = append(attrs, slog.String("error", err.Error()))
+				l.logger.LogWRP(ctx, l.errorLevel, "wrp request failed", attrs...)
+			}
+
+		case sample < l.sampleRate:
+			if response != nil {
+				if rtid := response.TransactionID(); rtid != tid {
+					attrs = append(attrs, slog.String("response_transaction_uuid", l.redactTransactionID(rtid)))
+				}
+				attrs = append(attrs, l.summary("", l.redact(response.Message()))...)
+			}
+			l.logger.LogWRP(ctx, l.level, "wrp response", attrs...)
+		}
+
+		return response, err
+	})
+}
+
+// sample returns a value in [0, 1) that is compared to the sample rates.
+func (l *logging) sample(tid string) float64 {
+	return wrp.TransactionSample(tid, l.random)
+}
+
+// redact returns the message as it is logged.
+func (l *logging) redact(msg *wrp.Message) *wrp.Message {
+	if l.redactor == nil || msg == nil {
+		return msg
+	}
+
+	return l.redactor.Redact(msg)
+}
+
+// redactTransactionID returns the transaction UUID as it is logged.
+func (l *logging) redactTransactionID(tid string) string {
+	if l.redactor == nil || tid == "" {
+		return tid
+	}
+
+	return l.redactor.Redact(&wrp.Message{TransactionUUID: tid}).TransactionUUID
+}
+
+// summary returns the attributes describing a message, which has already been
+// redacted.  The transaction_uuid attribute is only included if tid is set.
+func (l *logging) summary(tid string, msg *wrp.Message) []slog.Attr {
+	var attrs []slog.Attr
+	if tid != "" {
+		attrs = append(attrs, slog.String("transaction_uuid", l.redactTransactionID(tid)))
+	}
+
+	if msg == nil {
+		return attrs
+	}
+
+	attrs = append(attrs,
+		slog.String("type", msg.Type.FriendlyName()),
+		slog.String("source", msg.Source),
+		slog.String("dest", msg.Destination),
+		slog.String("path", msg.Path),
+		slog.String("content_type", msg.ContentType),
+		slog.Int("payload_length", len(msg.Payload)),
+	)
+
+	if msg.Status != nil {
+		attrs = append(attrs, slog.Int64("status", *msg.Status))
+	}
+
+	return attrs
+}
@@ -0,0 +1,336 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpendpoint
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type logEntry struct {
+	level slog.Level
+	msg   string
+	attrs map[string]any
+}
+
+type logRecorder struct {
+	entries []logEntry
+}
+
+func (r *logRecorder) LogWRP(_ context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	e := logEntry{
+		level: level,
+		msg:   msg,
+		attrs: make(map[string]any),
+	}
+	for _, a := range attrs {
+		e.attrs[a.Key] = a.Value.Any()
+	}
+	r.entries = append(r.entries, e)
+}
+
+func testLoggingRequest() Request {
+	status := int64(200)
+	return WrapAsRequest(log.NewNopLogger(), &wrp.Message{
+		Type:            wrp.SimpleRequestResponseMessageType,
+		Source:          "dns:example.com",
+		Destination:     "mac:112233445566/config",
+		TransactionUUID: "1234",
+		Path:            "/config",
+		ContentType:     "application/json",
+		Payload:         []byte(`{"secret":"value"}`),
+		Status:          &status,
+	})
+}
+
+func newTestLogging(t *testing.T, r *logRecorder, opts ...LoggingOption) Middleware {
+	m, err := NewLogging(r, opts...)
+	require.NoError(t, err)
+	return m
+}
+
+func TestNewLogging(t *testing.T) {
+	assert := assert.New(t)
+	recorder := new(logRecorder)
+
+	response := WrapAsResponse(&wrp.Message{
+		Type:            wrp.SimpleRequestResponseMessageType,
+		Source:          "mac:112233445566/config",
+		Destination:     "dns:example.com",
+		TransactionUUID: "1234",
+		Payload:         []byte("ok"),
+	})
+
+	service := newTestLogging(t, recorder, LogLevel(slog.LevelDebug), nil)(
+		ServiceFunc(func(context.Context, Request) (Response, error) {
+			return response, nil
+		}),
+	)
+
+	actual, err := service.ServeWRP(context.Background(), testLoggingRequest())
+	assert.NoError(err)
+	assert.Equal(response, actual)
+
+	require.Len(t, recorder.entries, 2)
+
+	request := recorder.entries[0]
+	assert.Equal(slog.LevelDebug, request.level)
+	assert.Equal("wrp request", request.msg)
+	assert.Equal(map[string]any{
+		"transaction_uuid": "1234",
+		"type":             "SimpleRequestResponse",
+		"source":           "dns:example.com",
+		"dest":             "mac:112233445566/config",
+		"path":             "/config",
+		"content_type":     "application/json",
+		"payload_length":   int64(18),
+		"status":           int64(200),
+	}, request.attrs)
+
+	resp := recorder.entries[1]
+	assert.Equal(slog.LevelDebug, resp.level)
+	assert.Equal("wrp response", resp.msg)
+	assert.Equal("1234", resp.attrs["transaction_uuid"])
+	assert.Equal("mac:112233445566/config", resp.attrs["source"])
+	assert.Equal(int64(2), resp.attrs["payload_length"])
+	assert.NotContains(resp.attrs, "response_transaction_uuid")
+	assert.Contains(resp.attrs, "duration")
+}
+
+func TestNewLogging_clock(t *testing.T) {
+	assert := assert.New(t)
+	recorder := new(logRecorder)
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := wrp.ClockFunc(func() time.Time { now = now.Add(5 * time.Millisecond); return now })
+
+	service := newTestLogging(t, recorder, LogClock(clock))(
+		ServiceFunc(func(context.Context, Request) (Response, error) {
+			return nil, nil
+		}),
+	)
+
+	_, err := service.ServeWRP(context.Background(), testLoggingRequest())
+	assert.NoError(err)
+	require.Len(t, recorder.entries, 2)
+	assert.Equal(5*time.Millisecond, recorder.entries[1].attrs["duration"])
+}
+
+func TestNewLogging_correlation(t *testing.T) {
+	assert := assert.New(t)
+	recorder := new(logRecorder)
+
+	service := newTestLogging(t, recorder)(
+		ServiceFunc(func(context.Context, Request) (Response, error) {
+			return WrapAsResponse(&wrp.Message{TransactionUUID: "5678"}), nil
+		}),
+	)
+
+	_, err := service.ServeWRP(context.Background(), testLoggingRequest())
+	assert.NoError(err)
+	require.Len(t, recorder.entries, 2)
+	assert.Equal("1234", recorder.entries[1].attrs["transaction_uuid"])
+	assert.Equal("5678", recorder.entries[1].attrs["response_transaction_uuid"])
+}
+
+func TestNewLogging_error(t *testing.T) {
+	errFail := errors.New("fail")
+
+	tests := []struct {
+		desc     string
+		opts     []LoggingOption
+		expected []string
+	}{
+		{
+			desc:     "default",
+			expected: []string{"wrp request", "wrp request failed"},
+		}, {
+			desc:     "only errors sampled",
+			opts:     []LoggingOption{LogSampleRate(0)},
+			expected: []string{"wrp request failed"},
+		}, {
+			desc:     "nothing sampled",
+			opts:     []LoggingOption{LogSampleRate(0), LogErrorSampleRate(0)},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+			recorder := new(logRecorder)
+
+			service := newTestLogging(t, recorder, append(tc.opts, LogErrorLevel(slog.LevelWarn))...)(
+				ServiceFunc(func(context.Context, Request) (Response, error) {
+					return nil, errFail
+				}),
+			)
+
+			_, err := service.ServeWRP(context.Background(), testLoggingRequest())
+			assert.ErrorIs(err, errFail)
+
+			var messages []string
+			for _, e := range recorder.entries {
+				messages = append(messages, e.msg)
+				if e.msg == "wrp request failed" {
+					assert.Equal(slog.LevelWarn, e.level)
+					assert.Equal("fail", e.attrs["error"])
+					assert.Equal("1234", e.attrs["transaction_uuid"])
+				}
+			}
+			assert.Equal(tc.expected, messages)
+		})
+	}
+}
+
+func TestNewLogging_sampling(t *testing.T) {
+	assert := assert.New(t)
+	recorder := new(logRecorder)
+
+	service := newTestLogging(t, recorder, LogSampleRate(0.5))(
+		ServiceFunc(func(_ context.Context, r Request) (Response, error) {
+			return WrapAsResponse(r.Message()), nil
+		}),
+	)
+
+	const count = 1000
+	sampled := make(map[string]int)
+	for i := 0; i < count; i++ {
+		request := WrapAsRequest(log.NewNopLogger(), &wrp.Message{
+			Type:            wrp.SimpleRequestResponseMessageType,
+			TransactionUUID: time.Duration(i).String(),
+		})
+
+		// the same transaction is always sampled the same way
+		for j := 0; j < 2; j++ {
+			_, err := service.ServeWRP(context.Background(), request)
+			assert.NoError(err)
+		}
+	}
+
+	for _, e := range recorder.entries {
+		sampled[e.attrs["transaction_uuid"].(string)]++
+	}
+
+	for tid, n := range sampled {
+		assert.Equal(4, n, tid)
+	}
+	assert.InDelta(count/2, len(sampled), count/10)
+}
+
+func TestNewLogging_redact(t *testing.T) {
+	assert := assert.New(t)
+	recorder := new(logRecorder)
+
+	redactor, err := wrp.NewRedactor(wrp.RedactFields(wrp.SourceField, wrp.TransactionUUIDField, wrp.StatusField))
+	require.NoError(t, err)
+
+	service := newTestLogging(t, recorder, LogRedactor(redactor))(
+		ServiceFunc(func(context.Context, Request) (Response, error) {
+			return WrapAsResponse(&wrp.Message{TransactionUUID: "5678"}), nil
+		}),
+	)
+
+	request := testLoggingRequest()
+	_, err = service.ServeWRP(context.Background(), request)
+	assert.NoError(err)
+	require.Len(t, recorder.entries, 2)
+
+	// the request itself is not redacted
+	assert.Equal("dns:example.com", request.Message().Source)
+
+	entry := recorder.entries[0]
+	assert.Equal(wrp.DefaultRedactionMask, entry.attrs["source"])
+	assert.Equal(wrp.DefaultRedactionMask, entry.attrs["transaction_uuid"])
+	assert.NotContains(entry.attrs, "status")
+	assert.Equal("mac:112233445566/config", entry.attrs["dest"])
+	assert.NotContains(entry.attrs, "payload")
+
+	assert.Equal(wrp.DefaultRedactionMask, recorder.entries[1].attrs["transaction_uuid"])
+	assert.Equal(wrp.DefaultRedactionMask, recorder.entries[1].attrs["response_transaction_uuid"])
+	assert.Equal("", recorder.entries[1].attrs["source"], "empty values are not redacted")
+}
+
+func TestNewLogging_invalid(t *testing.T) {
+	tests := []struct {
+		desc   string
+		logger Logger
+		opts   []LoggingOption
+	}{
+		{
+			desc: "nil logger",
+		}, {
+			desc:   "negative sample rate",
+			logger: new(logRecorder),
+			opts:   []LoggingOption{LogSampleRate(-0.1)},
+		}, {
+			desc:   "large error sample rate",
+			logger: new(logRecorder),
+			opts:   []LoggingOption{LogErrorSampleRate(1.1)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			m, err := NewLogging(tc.logger, tc.opts...)
+			assert.ErrorIs(t, err, ErrInvalidLogging)
+			assert.Nil(t, m)
+		})
+	}
+}
+
+func TestNewSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewSlogLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	l.LogWRP(context.Background(), slog.LevelInfo, "wrp request", slog.String("source", "dns:example.com"))
+	l.LogWRP(context.Background(), slog.LevelDebug, "dropped")
+
+	assert.Contains(t, buf.String(), `msg="wrp request" source=dns:example.com`)
+	assert.NotContains(t, buf.String(), "dropped")
+}
+
+func TestNewZapLogger(t *testing.T) {
+	assert := assert.New(t)
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	l := NewZapLogger(zap.New(core))
+
+	levels := []struct {
+		level    slog.Level
+		expected zapcore.Level
+	}{
+		{slog.LevelDebug - 4, zapcore.DebugLevel},
+		{slog.LevelDebug, zapcore.DebugLevel},
+		{slog.LevelInfo, zapcore.InfoLevel},
+		{slog.LevelInfo + 2, zapcore.InfoLevel},
+		{slog.LevelWarn, zapcore.WarnLevel},
+		{slog.LevelError, zapcore.ErrorLevel},
+		{slog.LevelError + 4, zapcore.ErrorLevel},
+	}
+
+	for _, tc := range levels {
+		l.LogWRP(context.Background(), tc.level, "wrp request", slog.String("source", "dns:example.com"), slog.Int("payload_length", 3))
+	}
+
+	entries := logs.All()
+	require.Len(t, entries, len(levels))
+	for i, e := range entries {
+		assert.Equal(levels[i].expected, e.Level)
+		assert.Equal("wrp request", e.Message)
+		assert.Equal(map[string]any{"source": "dns:example.com", "payload_length": int64(3)}, e.ContextMap())
+	}
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpendpoint
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/log"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// ErrNoMessage is returned by the Services of Processors and Modifiers for
+// requests that have no decoded message.
+var ErrNoMessage = errors.New("request has no message")
+
+// ModifierService returns a Service that serves each request with the
+// Modifier, e.g. a wrp.Modifiers chain, and responds with the modified
+// message.  The errors of the Modifier are returned as is, including
+// wrp.ErrNotHandled.
+func ModifierService(m wrp.Modifier) Service {
+	return ServiceFunc(func(ctx context.Context, r Request) (Response, error) {
+		msg := r.Message()
+		if msg == nil {
+			return nil, ErrNoMessage
+		}
+
+		modified, err := m.ModifyWRP(ctx, *msg)
+		if err != nil {
+			return nil, err
+		}
+
+		return WrapAsResponse(&modified), nil
+	})
+}
+
+// ProcessorService returns a Service that serves each request with the
+// Processor, e.g. a wrp.Processors chain.  A Processor produces no message, so
+// a request it handles has no response.  The errors of the Processor are
+// returned as is, including wrp.ErrNotHandled.
+func ProcessorService(p wrp.Processor) Service {
+	return ServiceFunc(func(ctx context.Context, r Request) (Response, error) {
+		msg := r.Message()
+		if msg == nil {
+			return nil, ErrNoMessage
+		}
+
+		return nil, p.ProcessWRP(ctx, *msg)
+	})
+}
+
+// ModifierEndpoint returns a go-kit endpoint of ModifierService, which can be
+// deployed behind go-kit transports.
+func ModifierEndpoint(m wrp.Modifier) endpoint.Endpoint {
+	return New(ModifierService(m))
+}
+
+// ProcessorEndpoint returns a go-kit endpoint of ProcessorService, which can
+// be deployed behind go-kit transports.
+func ProcessorEndpoint(p wrp.Processor) endpoint.Endpoint {
+	return New(ProcessorService(p))
+}
+
+// ModifierFromEndpoint does the opposite of ModifierEndpoint: it returns a
+// Modifier that invokes a go-kit endpoint of WRP requests and responses, such
+// as one created with New.  The message of the response is the modified
+// message; an endpoint with no response leaves the message as is.  The logger,
+// which may be nil, is the Logger of the requests.
+func ModifierFromEndpoint(logger log.Logger, e endpoint.Endpoint) wrp.Modifier {
+	return wrp.ModifierFunc(func(ctx context.Context, msg wrp.Message) (wrp.Message, error) {
+		response, err := invoke(ctx, logger, e, msg)
+		if err != nil {
+			return msg, err
+		}
+
+		if response != nil && response.Message() != nil {
+			return *response.Message(), nil
+		}
+
+		return msg, nil
+	})
+}
+
+// ProcessorFromEndpoint does the opposite of ProcessorEndpoint: it returns a
+// Processor that invokes a go-kit endpoint of WRP requests and responses, such
+// as one created with New.  Any response is discarded.  The logger, which may
+// be nil, is the Logger of the requests.
+func ProcessorFromEndpoint(logger log.Logger, e endpoint.Endpoint) wrp.Processor {
+	return wrp.ProcessorFunc(func(ctx context.Context, msg wrp.Message) error {
+		_, err := invoke(ctx, logger, e, msg)
+		return err
+	})
+}
+
+// invoke calls the endpoint with a request of the message.
+func invoke(ctx context.Context, logger log.Logger, e endpoint.Endpoint, msg wrp.Message) (Response, error) {
+	if logger == nil {
+		logger = log.NewNopLogger()
+	}
+
+	value, err := e(ctx, WrapAsRequest(logger, &msg))
+	if err != nil {
+		return nil, err
+	}
+
+	response, _ := value.(Response)
+	return response, nil
+}
@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpendpoint
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestModifierEndpoint(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	chain := wrp.Modifiers{
+		wrp.ModifierFunc(func(ctx context.Context, msg wrp.Message) (wrp.Message, error) {
+			if _, ok := wrp.MessageFromContext(ctx); !ok {
+				return msg, errors.New("no message in the context")
+			}
+			if msg.Path == "fail" {
+				return msg, errFailed
+			}
+			if msg.Path == "skip" {
+				return msg, wrp.ErrNotHandled
+			}
+			msg.Payload = []byte("modified")
+			return msg, nil
+		}),
+	}
+
+	tests := []struct {
+		desc            string
+		request         Request
+		expectedPayload string
+		expectedErr     error
+	}{
+		{
+			desc:            "modified",
+			request:         WrapAsRequest(log.NewNopLogger(), &wrp.Message{Type: wrp.SimpleEventMessageType}),
+			expectedPayload: "modified",
+		}, {
+			desc:        "error",
+			request:     WrapAsRequest(log.NewNopLogger(), &wrp.Message{Type: wrp.SimpleEventMessageType, Path: "fail"}),
+			expectedErr: errFailed,
+		}, {
+			desc:        "not handled",
+			request:     WrapAsRequest(log.NewNopLogger(), &wrp.Message{Type: wrp.SimpleEventMessageType, Path: "skip"}),
+			expectedErr: wrp.ErrNotHandled,
+		}, {
+			desc:        "no message",
+			request:     &request{},
+			expectedErr: ErrNoMessage,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			original := tc.request.Message()
+			value, err := ModifierEndpoint(chain)(context.Background(), tc.request)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(err)
+			response, ok := value.(Response)
+			require.True(ok)
+			assert.Equal(tc.expectedPayload, string(response.Message().Payload))
+			assert.Empty(original.Payload)
+		})
+	}
+}
+
+func TestProcessorEndpoint(t *testing.T) {
+	assert := assert.New(t)
+
+	var processed []string
+	chain := wrp.Processors{
+		wrp.ProcessorFunc(func(_ context.Context, msg wrp.Message) error {
+			processed = append(processed, msg.Path)
+			return nil
+		}),
+	}
+
+	e := ProcessorEndpoint(chain)
+	value, err := e(context.Background(), WrapAsRequest(nil, &wrp.Message{Path: "a"}))
+	assert.NoError(err)
+	assert.Nil(value)
+	assert.Equal([]string{"a"}, processed)
+
+	_, err = e(context.Background(), &request{})
+	assert.ErrorIs(err, ErrNoMessage)
+
+	_, err = ProcessorEndpoint(wrp.Processors{})(context.Background(), WrapAsRequest(nil, &wrp.Message{}))
+	assert.ErrorIs(err, wrp.ErrNotHandled)
+}
+
+func TestModifierFromEndpoint(t *testing.T) {
+	assert := assert.New(t)
+	errFailed := errors.New("failed")
+
+	m := ModifierFromEndpoint(nil, New(ServiceFunc(func(_ context.Context, r Request) (Response, error) {
+		r.Logger().Log("msg", "serving")
+
+		switch r.Message().Path {
+		case "fail":
+			return nil, errFailed
+		case "none":
+			return nil, nil
+		}
+
+		reply := *r.Message()
+		reply.Payload = []byte("reply")
+		return WrapAsResponse(&reply), nil
+	})))
+
+	msg, err := m.ModifyWRP(context.Background(), wrp.Message{Path: "echo"})
+	assert.NoError(err)
+	assert.Equal(wrp.Message{Path: "echo", Payload: []byte("reply")}, msg)
+
+	msg, err = m.ModifyWRP(context.Background(), wrp.Message{Path: "none"})
+	assert.NoError(err)
+	assert.Equal(wrp.Message{Path: "none"}, msg)
+
+	msg, err = m.ModifyWRP(context.Background(), wrp.Message{Path: "fail"})
+	assert.ErrorIs(err, errFailed)
+	assert.Equal(wrp.Message{Path: "fail"}, msg)
+}
+
+func TestProcessorFromEndpoint_roundTrip(t *testing.T) {
+	assert := assert.New(t)
+
+	var processed []string
+	p := ProcessorFromEndpoint(log.NewNopLogger(), ProcessorEndpoint(wrp.ProcessorFunc(
+		func(_ context.Context, msg wrp.Message) error {
+			if msg.Path == "skip" {
+				return wrp.ErrNotHandled
+			}
+			processed = append(processed, msg.Path)
+			return nil
+		},
+	)))
+
+	assert.NoError(p.ProcessWRP(context.Background(), wrp.Message{Path: "a"}))
+	assert.ErrorIs(p.ProcessWRP(context.Background(), wrp.Message{Path: "skip"}), wrp.ErrNotHandled)
+	assert.Equal([]string{"a"}, processed)
+}
+
+func TestProcessorEndpoint_wrap(t *testing.T) {
+	assert := assert.New(t)
+	errFailed := errors.New("failed")
+
+	var processed []string
+	s := Wrap(ProcessorEndpoint(wrp.ProcessorFunc(func(_ context.Context, msg wrp.Message) error {
+		if msg.Path == "fail" {
+			return errFailed
+		}
+		processed = append(processed, msg.Path)
+		return nil
+	})))
+
+	response, err := s.ServeWRP(context.Background(), WrapAsRequest(nil, &wrp.Message{Path: "a"}))
+	assert.NoError(err)
+	assert.Nil(response)
+	assert.Equal([]string{"a"}, processed)
+
+	response, err = s.ServeWRP(context.Background(), WrapAsRequest(nil, &wrp.Message{Path: "fail"}))
+	assert.ErrorIs(err, errFailed)
+	assert.Nil(response)
+
+	response, err = Wrap(ModifierEndpoint(wrp.Modifiers{})).ServeWRP(context.Background(), WrapAsRequest(nil, &wrp.Message{}))
+	assert.ErrorIs(err, wrp.ErrNotHandled)
+	assert.Nil(response)
+}
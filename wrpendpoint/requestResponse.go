@@ -4,6 +4,8 @@
 package wrpendpoint
 
 import (
+	"bytes"
+	"context"
 	"io"
 
 	"github.com/go-kit/log"
@@ -143,8 +145,25 @@ func DecodeRequest(logger log.Logger, source io.Reader, format wrp.Format) (Requ
 // This function also enhances the given logger with contextual information about the returned WRP request.  The
 // logger that is passed to this function should never be nil and should never have a Caller or DefaultCaller set.
 func DecodeRequestBytes(logger log.Logger, contents []byte, format wrp.Format) (Request, error) {
+	return DecodeRequestBytesContext(context.Background(), logger, contents, format)
+}
+
+// DecodeRequestContext is like DecodeRequest, except that reading and decoding stop when the context is
+// cancelled, in which case the context's error is returned.
+func DecodeRequestContext(ctx context.Context, logger log.Logger, source io.Reader, format wrp.Format) (Request, error) {
+	contents, err := readAllContext(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeRequestBytesContext(ctx, logger, contents, format)
+}
+
+// DecodeRequestBytesContext is like DecodeRequestBytes, except that decoding stops when the context is
+// cancelled, in which case the context's error is returned.
+func DecodeRequestBytesContext(ctx context.Context, logger log.Logger, contents []byte, format wrp.Format) (Request, error) {
 	m := new(wrp.Message)
-	if err := wrp.NewDecoderBytes(contents, format).Decode(m); err != nil {
+	if err := wrp.DecodeBytesContext(ctx, contents, format, m); err != nil {
 		return nil, err
 	}
 
@@ -213,8 +232,25 @@ func DecodeResponse(source io.Reader, format wrp.Format) (Response, error) {
 
 // DecodeResponseBytes returns a Response taken from the contents.  The given pool is used to decode the WRP message.
 func DecodeResponseBytes(contents []byte, format wrp.Format) (Response, error) {
+	return DecodeResponseBytesContext(context.Background(), contents, format)
+}
+
+// DecodeResponseContext is like DecodeResponse, except that reading and decoding stop when the context is
+// cancelled, in which case the context's error is returned.
+func DecodeResponseContext(ctx context.Context, source io.Reader, format wrp.Format) (Response, error) {
+	contents, err := readAllContext(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeResponseBytesContext(ctx, contents, format)
+}
+
+// DecodeResponseBytesContext is like DecodeResponseBytes, except that decoding stops when the context is
+// cancelled, in which case the context's error is returned.
+func DecodeResponseBytesContext(ctx context.Context, contents []byte, format wrp.Format) (Response, error) {
 	m := new(wrp.Message)
-	if err := wrp.NewDecoderBytes(contents, format).Decode(m); err != nil {
+	if err := wrp.DecodeBytesContext(ctx, contents, format, m); err != nil {
 		return nil, err
 	}
 
@@ -239,3 +275,27 @@ func WrapAsResponse(m *wrp.Message) Response {
 		},
 	}
 }
+
+// readAllContext reads the source until EOF, checking the context between reads.
+func readAllContext(ctx context.Context, source io.Reader) ([]byte, error) {
+	var (
+		output bytes.Buffer
+		buffer = make([]byte, 32*1024)
+	)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		n, err := source.Read(buffer)
+		output.Write(buffer[:n])
+
+		switch {
+		case err == io.EOF:
+			return output.Bytes(), nil
+		case err != nil:
+			return nil, err
+		}
+	}
+}
@@ -5,9 +5,11 @@ package wrpendpoint
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"testing"
+	"testing/iotest"
 
 	"github.com/go-kit/log"
 	"github.com/stretchr/testify/assert"
@@ -369,3 +371,60 @@ func TestResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeContext(t *testing.T) {
+	var (
+		msg = wrp.Message{
+			Type:            wrp.SimpleRequestResponseMessageType,
+			Source:          "dns:example.com",
+			Destination:     "mac:112233445566",
+			TransactionUUID: "1234",
+			Payload:         []byte("payload"),
+		}
+		contents = wrp.MustEncode(&msg, wrp.Msgpack)
+	)
+
+	t.Run("Request", func(t *testing.T) {
+		require := require.New(t)
+
+		r, err := DecodeRequestContext(context.Background(), log.NewNopLogger(), bytes.NewReader(contents), wrp.Msgpack)
+		require.NoError(err)
+		assertNote(t, msg, r)
+	})
+
+	t.Run("Response", func(t *testing.T) {
+		require := require.New(t)
+
+		r, err := DecodeResponseContext(context.Background(), bytes.NewReader(contents), wrp.Msgpack)
+		require.NoError(err)
+		assertNote(t, msg, r)
+	})
+
+	t.Run("ReadError", func(t *testing.T) {
+		assert := assert.New(t)
+		expectedErr := errors.New("expected")
+
+		r, err := DecodeRequestContext(context.Background(), log.NewNopLogger(), iotest.ErrReader(expectedErr), wrp.Msgpack)
+		assert.ErrorIs(err, expectedErr)
+		assert.Nil(r)
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		assert := assert.New(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		r, err := DecodeRequestContext(ctx, log.NewNopLogger(), bytes.NewReader(contents), wrp.Msgpack)
+		assert.ErrorIs(err, context.Canceled)
+		assert.Nil(r)
+
+		resp, err := DecodeResponseContext(ctx, bytes.NewReader(contents), wrp.Msgpack)
+		assert.ErrorIs(err, context.Canceled)
+		assert.Nil(resp)
+
+		r, err = DecodeRequestBytesContext(ctx, log.NewNopLogger(), contents, wrp.Msgpack)
+		assert.ErrorIs(err, context.Canceled)
+		assert.Nil(r)
+	})
+}
@@ -20,3 +20,6 @@ type ServiceFunc func(context.Context, Request) (Response, error)
 func (sf ServiceFunc) ServeWRP(ctx context.Context, r Request) (Response, error) {
 	return sf(ctx, r)
 }
+
+// Middleware decorates a Service with additional behavior.
+type Middleware func(Service) Service
@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpendpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// DefaultShedRetryAfter is how long clients of shed requests are asked to
+// wait before trying again.
+const DefaultShedRetryAfter = time.Second
+
+var (
+	// ErrBusy is the sentinel error wrapped by every BusyError.  Use
+	// errors.Is(err, ErrBusy) to determine if a request was shed.
+	ErrBusy = errors.New("service busy")
+
+	ErrInvalidShedding = errors.New("invalid load shedding configuration")
+)
+
+// BusyError is the error returned when a request is rejected because the
+// service is overloaded, by the load shedding middleware or by a circuit
+// breaker applied with NewCircuitBreaker.
+type BusyError struct {
+	// Level is the QOS level of the request that was rejected.
+	Level wrp.QOSLevel
+
+	// Load is the load signal that caused the request to be shed.  It is zero
+	// if the request was rejected by a circuit breaker.
+	Load float64
+
+	// RetryAfter is how long the client should wait before trying again.
+	RetryAfter time.Duration
+
+	// Err is the error of the circuit breaker that rejected the request, if
+	// any.
+	Err error
+}
+
+func (e *BusyError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s QOS rejected: %s, retry after %s", ErrBusy, e.Level, e.Err, e.RetryAfter)
+	}
+
+	return fmt.Sprintf("%s: shedding %s QOS at load %g, retry after %s", ErrBusy, e.Level, e.Load, e.RetryAfter)
+}
+
+// Unwrap returns ErrBusy and Err.
+func (e *BusyError) Unwrap() []error {
+	return []error{ErrBusy, e.Err}
+}
+
+// LoadSignal reports the current load of a service, e.g. the depth of its
+// work queue or an EWMA of its latency.  The unit is up to the caller, as long
+// as it matches the thresholds given to NewLoadShedding.  Load is called once
+// per request, so it must be cheap and safe for concurrent use.
+type LoadSignal interface {
+	Load() float64
+}
+
+// LoadSignalFunc is a function type that implements LoadSignal.
+type LoadSignalFunc func() float64
+
+func (f LoadSignalFunc) Load() float64 {
+	return f()
+}
+
+// ShedOption is a functional option for configuring the load shedding
+// middleware.
+type ShedOption interface {
+	apply(*shedder) error
+}
+
+type shedOptionFunc func(*shedder) error
+
+func (f shedOptionFunc) apply(s *shedder) error {
+	return f(s)
+}
+
+// ShedThreshold sets the load at or above which requests with messages of the
+// given QOS level are shed.  Levels without a threshold are never shed.
+func ShedThreshold(level wrp.QOSLevel, load float64) ShedOption {
+	return shedOptionFunc(func(s *shedder) error {
+		if level < wrp.QOSLow || level > wrp.QOSCritical {
+			return fmt.Errorf("%w: unknown QOS level %d", ErrInvalidShedding, level)
+		}
+		if math.IsNaN(load) {
+			return fmt.Errorf("%w: %s threshold is NaN", ErrInvalidShedding, level)
+		}
+		s.thresholds[level] = load
+		return nil
+	})
+}
+
+// ShedThresholds sets the thresholds for several QOS levels at once, e.g. from
+// configuration.  Levels that are not in the map keep their thresholds.
+func ShedThresholds(m map[wrp.QOSLevel]float64) ShedOption {
+	return shedOptionFunc(func(s *shedder) error {
+		for level, load := range m {
+			if err := ShedThreshold(level, load).apply(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ShedRetryAfter sets the RetryAfter of the BusyError returned for shed
+// requests.  The default is DefaultShedRetryAfter.
+func ShedRetryAfter(d time.Duration) ShedOption {
+	return shedOptionFunc(func(s *shedder) error {
+		if d <= 0 {
+			return fmt.Errorf("%w: retry after %s", ErrInvalidShedding, d)
+		}
+		s.retryAfter = d
+		return nil
+	})
+}
+
+type shedder struct {
+	signal     LoadSignal
+	thresholds [wrp.QOSCritical + 1]float64
+	retryAfter time.Duration
+}
+
+// NewLoadShedding creates a Middleware that sheds requests when the service is
+// overloaded, lowest QOS first.  Before each request the signal is read, and
+// if the load is at or above the threshold for the QOS level of the request's
+// message, a *BusyError is returned without calling the Service.  Requests
+// without a decoded message are treated as wrp.QOSLow.
+//
+// At least one threshold must be set, and the thresholds must not decrease
+// with the QOS level, so that a higher level is never shed before a lower one.
+// wrphttp.ErrorEncoder writes a BusyError as a 503 Service Unavailable with a
+// Retry-After header.
+func NewLoadShedding(signal LoadSignal, opts ...ShedOption) (Middleware, error) {
+	if signal == nil {
+		return nil, fmt.Errorf("%w: no load signal", ErrInvalidShedding)
+	}
+
+	s := shedder{
+		signal:     signal,
+		retryAfter: DefaultShedRetryAfter,
+	}
+
+	for i := range s.thresholds {
+		s.thresholds[i] = math.Inf(1)
+	}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt.apply(&s); err != nil {
+			return nil, err
+		}
+	}
+
+	for level := wrp.QOSMedium; level <= wrp.QOSCritical; level++ {
+		if s.thresholds[level] < s.thresholds[level-1] {
+			return nil, fmt.Errorf("%w: %s threshold %g is below the %s threshold %g",
+				ErrInvalidShedding, level, s.thresholds[level], level-1, s.thresholds[level-1])
+		}
+	}
+
+	// the thresholds do not decrease, so the low threshold is set if any is
+	if math.IsInf(s.thresholds[wrp.QOSLow], 1) {
+		return nil, fmt.Errorf("%w: no thresholds", ErrInvalidShedding)
+	}
+
+	return s.middleware, nil
+}
+
+func (s shedder) middleware(next Service) Service {
+	return ServiceFunc(func(ctx context.Context, r Request) (Response, error) {
+		level := wrp.QOSLow
+		if msg := r.Message(); msg != nil {
+			level = msg.QualityOfService.Level()
+		}
+
+		if load := s.signal.Load(); load >= s.thresholds[level] {
+			return nil, &BusyError{
+				Level:      level,
+				Load:       load,
+				RetryAfter: s.retryAfter,
+			}
+		}
+
+		return next.ServeWRP(ctx, r)
+	})
+}
@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpendpoint
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestNewLoadShedding(t *testing.T) {
+	thresholds := ShedThresholds(map[wrp.QOSLevel]float64{
+		wrp.QOSLow:    10,
+		wrp.QOSMedium: 20,
+		wrp.QOSHigh:   30,
+	})
+
+	tests := []struct {
+		desc       string
+		opts       []ShedOption
+		load       float64
+		msg        *wrp.Message
+		shed       bool
+		retryAfter time.Duration
+	}{
+		{
+			desc: "below the low threshold",
+			opts: []ShedOption{thresholds},
+			load: 9,
+			msg:  &wrp.Message{QualityOfService: wrp.QOSLowValue},
+		}, {
+			desc:       "low is shed first",
+			opts:       []ShedOption{thresholds},
+			load:       10,
+			msg:        &wrp.Message{QualityOfService: wrp.QOSLowValue},
+			shed:       true,
+			retryAfter: DefaultShedRetryAfter,
+		}, {
+			desc: "medium is kept while low is shed",
+			opts: []ShedOption{thresholds},
+			load: 15,
+			msg:  &wrp.Message{QualityOfService: wrp.QOSMediumValue},
+		}, {
+			desc:       "high is shed",
+			opts:       []ShedOption{thresholds, ShedRetryAfter(5 * time.Second), nil},
+			load:       30,
+			msg:        &wrp.Message{QualityOfService: wrp.QOSHighValue},
+			shed:       true,
+			retryAfter: 5 * time.Second,
+		}, {
+			desc: "critical is never shed without a threshold",
+			opts: []ShedOption{thresholds},
+			load: math.MaxFloat64,
+			msg:  &wrp.Message{QualityOfService: wrp.QOSCriticalValue},
+		}, {
+			desc:       "no message is low",
+			opts:       []ShedOption{ShedThreshold(wrp.QOSLow, 1)},
+			load:       1,
+			shed:       true,
+			retryAfter: DefaultShedRetryAfter,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m, err := NewLoadShedding(LoadSignalFunc(func() float64 { return tc.load }), tc.opts...)
+			require.NoError(t, err)
+
+			var request Request = &request{}
+			if tc.msg != nil {
+				request = WrapAsRequest(log.NewNopLogger(), tc.msg)
+			}
+
+			var called bool
+			_, err = m(ServiceFunc(func(context.Context, Request) (Response, error) {
+				called = true
+				return nil, nil
+			})).ServeWRP(context.Background(), request)
+
+			if !tc.shed {
+				assert.NoError(err)
+				assert.True(called)
+				return
+			}
+
+			assert.False(called)
+			assert.ErrorIs(err, ErrBusy)
+
+			var be *BusyError
+			require.True(t, errors.As(err, &be))
+			assert.Equal(tc.load, be.Load)
+			assert.Equal(tc.retryAfter, be.RetryAfter)
+			assert.NotEmpty(be.Error())
+		})
+	}
+}
+
+func TestNewLoadShedding_invalid(t *testing.T) {
+	signal := LoadSignalFunc(func() float64 { return 0 })
+
+	tests := []struct {
+		desc   string
+		signal LoadSignal
+		opts   []ShedOption
+	}{
+		{
+			desc: "no signal",
+			opts: []ShedOption{ShedThreshold(wrp.QOSLow, 1)},
+		}, {
+			desc:   "no thresholds",
+			signal: signal,
+		}, {
+			desc:   "unknown level",
+			signal: signal,
+			opts:   []ShedOption{ShedThreshold(wrp.QOSCritical+1, 1)},
+		}, {
+			desc:   "NaN threshold",
+			signal: signal,
+			opts:   []ShedOption{ShedThresholds(map[wrp.QOSLevel]float64{wrp.QOSLow: math.NaN()})},
+		}, {
+			desc:   "decreasing thresholds",
+			signal: signal,
+			opts:   []ShedOption{ShedThreshold(wrp.QOSLow, 10), ShedThreshold(wrp.QOSHigh, 5)},
+		}, {
+			desc:   "low not set",
+			signal: signal,
+			opts:   []ShedOption{ShedThreshold(wrp.QOSMedium, 5)},
+		}, {
+			desc:   "retry after",
+			signal: signal,
+			opts:   []ShedOption{ShedThreshold(wrp.QOSLow, 1), ShedRetryAfter(0)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			m, err := NewLoadShedding(tc.signal, tc.opts...)
+			assert.ErrorIs(t, err, ErrInvalidShedding)
+			assert.Nil(t, m)
+		})
+	}
+}
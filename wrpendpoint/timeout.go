@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpendpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// The default timeouts for each QOS level.
+const (
+	DefaultLowQOSTimeout      = 5 * time.Second
+	DefaultMediumQOSTimeout   = 15 * time.Second
+	DefaultHighQOSTimeout     = 30 * time.Second
+	DefaultCriticalQOSTimeout = 60 * time.Second
+)
+
+var ErrInvalidTimeout = errors.New("invalid timeout configuration")
+
+// TimeoutOption is a functional option for configuring the timeout middleware.
+type TimeoutOption interface {
+	apply(*qosTimeout) error
+}
+
+type timeoutOptionFunc func(*qosTimeout) error
+
+func (f timeoutOptionFunc) apply(t *qosTimeout) error {
+	return f(t)
+}
+
+// QOSTimeout sets the timeout for messages with the given QOS level.  A zero
+// timeout means requests of that level have no timeout of their own.
+func QOSTimeout(level wrp.QOSLevel, d time.Duration) TimeoutOption {
+	return timeoutOptionFunc(func(t *qosTimeout) error {
+		if level < wrp.QOSLow || level > wrp.QOSCritical {
+			return fmt.Errorf("%w: unknown QOS level %d", ErrInvalidTimeout, level)
+		}
+		if d < 0 {
+			return fmt.Errorf("%w: %s timeout %s", ErrInvalidTimeout, level, d)
+		}
+		t.timeouts[level] = d
+		return nil
+	})
+}
+
+// QOSTimeouts sets the timeouts for several QOS levels at once, e.g. from
+// configuration.  Levels that are not in the map keep their timeouts.
+func QOSTimeouts(m map[wrp.QOSLevel]time.Duration) TimeoutOption {
+	return timeoutOptionFunc(func(t *qosTimeout) error {
+		for level, d := range m {
+			if err := QOSTimeout(level, d).apply(t); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DefaultQOSLevel sets the QOS level used for requests that have no decoded
+// message.  The default is wrp.QOSLow.
+func DefaultQOSLevel(level wrp.QOSLevel) TimeoutOption {
+	return timeoutOptionFunc(func(t *qosTimeout) error {
+		if level < wrp.QOSLow || level > wrp.QOSCritical {
+			return fmt.Errorf("%w: unknown QOS level %d", ErrInvalidTimeout, level)
+		}
+		t.defaultLevel = level
+		return nil
+	})
+}
+
+type qosTimeout struct {
+	timeouts     [wrp.QOSCritical + 1]time.Duration
+	defaultLevel wrp.QOSLevel
+}
+
+// NewTimeout creates a Middleware that bounds each request with a timeout
+// derived from the QOS level of its message, so that critical messages get
+// longer to complete while low QOS messages fail fast.  Without options, the
+// Default*QOSTimeout values are used.
+//
+// The timeout is applied with context.WithTimeout, so a deadline already set
+// on the context is kept if it is earlier.  The Service is responsible for
+// honoring the context.
+func NewTimeout(opts ...TimeoutOption) (Middleware, error) {
+	t := qosTimeout{
+		timeouts: [...]time.Duration{
+			wrp.QOSLow:      DefaultLowQOSTimeout,
+			wrp.QOSMedium:   DefaultMediumQOSTimeout,
+			wrp.QOSHigh:     DefaultHighQOSTimeout,
+			wrp.QOSCritical: DefaultCriticalQOSTimeout,
+		},
+		defaultLevel: wrp.QOSLow,
+	}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt.apply(&t); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.middleware, nil
+}
+
+// timeout returns the timeout for the request.
+func (t qosTimeout) timeout(r Request) time.Duration {
+	level := t.defaultLevel
+	if msg := r.Message(); msg != nil {
+		level = msg.QualityOfService.Level()
+	}
+
+	return t.timeouts[level]
+}
+
+func (t qosTimeout) middleware(next Service) Service {
+	return ServiceFunc(func(ctx context.Context, r Request) (Response, error) {
+		if d := t.timeout(r); d > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, d)
+			defer cancel()
+		}
+
+		return next.ServeWRP(ctx, r)
+	})
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpendpoint
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestNewTimeout(t *testing.T) {
+	tests := []struct {
+		desc     string
+		opts     []TimeoutOption
+		msg      *wrp.Message
+		parent   time.Duration
+		expected time.Duration
+	}{
+		{
+			desc:     "low default",
+			msg:      &wrp.Message{QualityOfService: wrp.QOSLowValue},
+			expected: DefaultLowQOSTimeout,
+		}, {
+			desc:     "critical default",
+			msg:      &wrp.Message{QualityOfService: 99},
+			expected: DefaultCriticalQOSTimeout,
+		}, {
+			desc:     "configured",
+			opts:     []TimeoutOption{QOSTimeouts(map[wrp.QOSLevel]time.Duration{wrp.QOSMedium: time.Minute})},
+			msg:      &wrp.Message{QualityOfService: wrp.QOSMediumValue},
+			expected: time.Minute,
+		}, {
+			desc:     "no message",
+			opts:     []TimeoutOption{DefaultQOSLevel(wrp.QOSHigh), nil},
+			expected: DefaultHighQOSTimeout,
+		}, {
+			desc: "disabled",
+			opts: []TimeoutOption{QOSTimeout(wrp.QOSLow, 0)},
+			msg:  &wrp.Message{},
+		}, {
+			desc:     "earlier parent deadline is kept",
+			msg:      &wrp.Message{QualityOfService: wrp.QOSCriticalValue},
+			parent:   time.Second,
+			expected: time.Second,
+		}, {
+			desc:     "later parent deadline is shortened",
+			msg:      &wrp.Message{QualityOfService: wrp.QOSLowValue},
+			parent:   time.Hour,
+			expected: DefaultLowQOSTimeout,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m, err := NewTimeout(tc.opts...)
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			if tc.parent > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.parent)
+				defer cancel()
+			}
+
+			var request Request = &request{}
+			if tc.msg != nil {
+				request = WrapAsRequest(log.NewNopLogger(), tc.msg)
+			}
+
+			start := time.Now()
+			_, err = m(ServiceFunc(func(ctx context.Context, _ Request) (Response, error) {
+				deadline, ok := ctx.Deadline()
+				if tc.expected == 0 {
+					assert.False(ok)
+				} else if assert.True(ok) {
+					assert.WithinDuration(start.Add(tc.expected), deadline, time.Second)
+				}
+				return nil, nil
+			})).ServeWRP(ctx, request)
+			assert.NoError(err)
+		})
+	}
+}
+
+func TestNewTimeout_expires(t *testing.T) {
+	m, err := NewTimeout(QOSTimeout(wrp.QOSLow, time.Millisecond))
+	require.NoError(t, err)
+
+	_, err = m(ServiceFunc(func(ctx context.Context, _ Request) (Response, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})).ServeWRP(context.Background(), WrapAsRequest(log.NewNopLogger(), &wrp.Message{}))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestNewTimeout_invalid(t *testing.T) {
+	tests := []struct {
+		desc string
+		opt  TimeoutOption
+	}{
+		{desc: "negative", opt: QOSTimeout(wrp.QOSHigh, -time.Second)},
+		{desc: "unknown level", opt: QOSTimeout(wrp.QOSCritical+1, time.Second)},
+		{desc: "unknown level in map", opt: QOSTimeouts(map[wrp.QOSLevel]time.Duration{-1: time.Second})},
+		{desc: "unknown default level", opt: DefaultQOSLevel(-1)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			m, err := NewTimeout(tc.opt)
+			assert.ErrorIs(t, err, ErrInvalidTimeout)
+			assert.Nil(t, m)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpendpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/xmidt-org/wrp-go/v3/wrptrace"
+)
+
+// DefaultSpanName is the name of the spans of requests that have no decoded
+// message.
+const DefaultSpanName = "wrp"
+
+var ErrInvalidTracing = errors.New("invalid tracing configuration")
+
+// TracingOption is a functional option for configuring the tracing
+// middleware.
+type TracingOption interface {
+	apply(*spanTracing) error
+}
+
+type tracingOptionFunc func(*spanTracing) error
+
+func (f tracingOptionFunc) apply(t *spanTracing) error {
+	return f(t)
+}
+
+// TracePropagator sets the Propagator used to extract the parent span
+// context from the Metadata of the messages.  The default is the zero
+// wrptrace.Propagator.
+func TracePropagator(p wrptrace.Propagator) TracingOption {
+	return tracingOptionFunc(func(t *spanTracing) error {
+		t.propagator = p
+		return nil
+	})
+}
+
+type spanTracing struct {
+	tracer     wrptrace.Tracer
+	propagator wrptrace.Propagator
+}
+
+// NewTracing creates a Middleware that starts a span for each WRP request,
+// named by wrptrace.SpanName.  The parent of the span is the span context in
+// the Metadata of the request message, or else the one carried by the
+// context.  The span context of the new span is added to the context with
+// wrptrace.ContextWithSpanContext, so the messages the Service sends can be
+// injected with it.  An error returned by the Service is recorded on the span.
+func NewTracing(tracer wrptrace.Tracer, opts ...TracingOption) (Middleware, error) {
+	if tracer == nil {
+		return nil, fmt.Errorf("%w: nil tracer", ErrInvalidTracing)
+	}
+
+	t := spanTracing{
+		tracer: tracer,
+	}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt.apply(&t); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.middleware, nil
+}
+
+func (t *spanTracing) middleware(next Service) Service {
+	return ServiceFunc(func(ctx context.Context, r Request) (Response, error) {
+		name := DefaultSpanName
+		parent, _ := wrptrace.SpanContextFromContext(ctx)
+		if msg := r.Message(); msg != nil {
+			name = wrptrace.SpanName(msg)
+			if sc, ok := t.propagator.ExtractMessage(msg); ok {
+				parent = sc
+			}
+		}
+
+		ctx, span := t.tracer.Start(ctx, name, parent)
+		defer span.End()
+
+		ctx = wrptrace.ContextWithSpanContext(ctx, span.SpanContext())
+		response, err := next.ServeWRP(ctx, r)
+		if err != nil {
+			span.RecordError(err)
+		}
+
+		return response, err
+	})
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpendpoint
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+	"github.com/xmidt-org/wrp-go/v3/wrptrace"
+)
+
+// testSpan records what is done to it.
+type testSpan struct {
+	name   string
+	parent wrptrace.SpanContext
+	sc     wrptrace.SpanContext
+	err    error
+	ended  bool
+}
+
+func (s *testSpan) SpanContext() wrptrace.SpanContext { return s.sc }
+func (s *testSpan) RecordError(err error)             { s.err = err }
+func (s *testSpan) End()                              { s.ended = true }
+
+func TestNewTracing(t *testing.T) {
+	var (
+		traceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+		fromMessage = wrptrace.SpanContext{TraceID: traceID, SpanID: "00f067aa0ba902b7", Sampled: true}
+		fromContext = wrptrace.SpanContext{TraceID: traceID, SpanID: "05e3ac9a4f6e3b90"}
+		child       = wrptrace.SpanContext{TraceID: traceID, SpanID: "1111111111111111"}
+		errTest     = errors.New("expected")
+	)
+
+	withTrace := &wrp.Message{
+		Type:        wrp.SimpleRequestResponseMessageType,
+		Destination: "mac:112233445566/config",
+	}
+	wrptrace.Propagator{}.InjectMessage(withTrace, fromMessage)
+
+	tests := []struct {
+		desc           string
+		opts           []TracingOption
+		msg            *wrp.Message
+		contextParent  bool
+		err            error
+		expectedName   string
+		expectedParent wrptrace.SpanContext
+	}{
+		{
+			desc:           "parent from message",
+			msg:            withTrace,
+			contextParent:  true,
+			expectedName:   "SimpleRequestResponse 112233445566",
+			expectedParent: fromMessage,
+		}, {
+			desc:           "parent from context",
+			opts:           []TracingOption{TracePropagator(wrptrace.Propagator{Formats: []wrptrace.Format{wrptrace.B3Multi}}), nil},
+			msg:            &wrp.Message{Type: wrp.SimpleEventMessageType, Destination: "event:device-status"},
+			contextParent:  true,
+			err:            errTest,
+			expectedName:   "SimpleEvent device-status",
+			expectedParent: fromContext,
+		}, {
+			desc:         "no parent or message",
+			expectedName: DefaultSpanName,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			var span *testSpan
+			tracer := wrptrace.TracerFunc(func(ctx context.Context, name string, parent wrptrace.SpanContext) (context.Context, wrptrace.Span) {
+				span = &testSpan{name: name, parent: parent, sc: child}
+				return ctx, span
+			})
+
+			m, err := NewTracing(tracer, tc.opts...)
+			require.NoError(err)
+
+			ctx := context.Background()
+			if tc.contextParent {
+				ctx = wrptrace.ContextWithSpanContext(ctx, fromContext)
+			}
+
+			var r Request = &request{}
+			if tc.msg != nil {
+				r = WrapAsRequest(log.NewNopLogger(), tc.msg)
+			}
+
+			_, err = m(ServiceFunc(func(ctx context.Context, _ Request) (Response, error) {
+				sc, ok := wrptrace.SpanContextFromContext(ctx)
+				assert.True(ok)
+				assert.Equal(child, sc)
+				assert.False(span.ended)
+				return nil, tc.err
+			})).ServeWRP(ctx, r)
+
+			assert.Equal(tc.err, err)
+			assert.Equal(tc.expectedName, span.name)
+			assert.Equal(tc.expectedParent, span.parent)
+			assert.Equal(tc.err, span.err)
+			assert.True(span.ended)
+		})
+	}
+
+	m, err := NewTracing(nil)
+	assert.ErrorIs(t, err, ErrInvalidTracing)
+	assert.Nil(t, m)
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpevent
+
+import (
+	"slices"
+	"sync"
+)
+
+// Classifier is the first part of an event locator, which identifies the
+// family of the event.
+type Classifier string
+
+// The classifiers that are registered by default.
+const (
+	// DeviceStatus events are published by the cloud when a device's
+	// connection status changes, see DeviceStatusEvent.
+	DeviceStatus Classifier = "device-status"
+
+	// IoT events are published by devices on behalf of attached IoT devices.
+	IoT Classifier = "iot"
+)
+
+var (
+	registryLock sync.RWMutex
+	registry     = map[Classifier]struct{}{
+		DeviceStatus: {},
+		IoT:          {},
+	}
+)
+
+// Register adds classifiers to the set of known classifiers.  Registering a
+// classifier more than once has no effect.
+func Register(cs ...Classifier) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	for _, c := range cs {
+		registry[c] = struct{}{}
+	}
+}
+
+// Known returns the registered classifiers, sorted.
+func Known() []Classifier {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	known := make([]Classifier, 0, len(registry))
+	for c := range registry {
+		known = append(known, c)
+	}
+
+	slices.Sort(known)
+	return known
+}
+
+// IsKnown returns true if the classifier has been registered.
+func (c Classifier) IsKnown() bool {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	_, ok := registry[c]
+	return ok
+}
+
+// String returns the classifier as a string.
+func (c Classifier) String() string {
+	return string(c)
+}
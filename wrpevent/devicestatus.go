@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpevent
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// Status is the status segment of a device-status event.
+type Status string
+
+// The standard device-status event statuses.
+const (
+	// Online is published when a device connects.
+	Online Status = "online"
+
+	// Offline is published when a device disconnects.
+	Offline Status = "offline"
+
+	// FullyManageable is published when a device has finished booting and can
+	// be managed.
+	FullyManageable Status = "fully-manageable"
+
+	// Operational is published when a device is providing service.
+	Operational Status = "operational"
+
+	// RebootPending is published when a device is about to reboot.
+	RebootPending Status = "reboot-pending"
+)
+
+// DeviceStatusEvent is a parsed device-status event locator, which has the
+// form:
+//
+//	event:device-status/{device id}/{status}/{reason}
+//
+// where the reason is optional, e.g.
+//
+//	event:device-status/mac:112233445566/offline
+type DeviceStatusEvent struct {
+	// ID is the device the event is about.
+	ID wrp.DeviceID
+
+	// Status is the new status of the device.
+	Status Status
+
+	// Reason is the optional remainder of the path after the status, which
+	// some producers use to qualify the status.  It may contain '/'.
+	Reason string
+}
+
+// ParseDeviceStatus parses a device-status event locator.  An error wrapping
+// ErrNotEvent or ErrInvalidDeviceStatus is returned if the locator is not a
+// well formed device-status event.
+func ParseDeviceStatus(locator string) (DeviceStatusEvent, error) {
+	e, err := Parse(locator)
+	if err != nil {
+		return DeviceStatusEvent{}, err
+	}
+
+	return e.DeviceStatus()
+}
+
+// DeviceStatus interprets the event as a device-status event.
+func (e Event) DeviceStatus() (DeviceStatusEvent, error) {
+	if e.Classifier != DeviceStatus {
+		return DeviceStatusEvent{}, fmt.Errorf("%w: classifier is `%s`", ErrInvalidDeviceStatus, e.Classifier)
+	}
+
+	parts := strings.SplitN(e.Path, "/", 3)
+	if len(parts) < 2 || parts[1] == "" {
+		return DeviceStatusEvent{}, fmt.Errorf("%w: `%s` does not have a device id and status", ErrInvalidDeviceStatus, e.Path)
+	}
+
+	id, err := wrp.ParseDeviceID(parts[0])
+	if err != nil {
+		return DeviceStatusEvent{}, fmt.Errorf("%w: %w", ErrInvalidDeviceStatus, err)
+	}
+
+	dse := DeviceStatusEvent{
+		ID:     id,
+		Status: Status(parts[1]),
+	}
+	if len(parts) == 3 {
+		dse.Reason = parts[2]
+	}
+
+	return dse, nil
+}
+
+// Event returns the generic form of the event.
+func (dse DeviceStatusEvent) Event() Event {
+	segments := []string{string(dse.ID), string(dse.Status)}
+	if dse.Reason != "" {
+		segments = append(segments, dse.Reason)
+	}
+
+	return New(DeviceStatus, segments...)
+}
+
+// Destination returns the event locator, suitable for use as the Destination
+// of a message.
+func (dse DeviceStatusEvent) Destination() string {
+	return dse.Event().Destination()
+}
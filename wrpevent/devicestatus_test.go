@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpevent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestParseDeviceStatus(t *testing.T) {
+	tests := []struct {
+		desc        string
+		locator     string
+		expected    DeviceStatusEvent
+		dest        string
+		expectedErr error
+	}{
+		{
+			desc:     "online",
+			locator:  "event:device-status/mac:112233445566/online",
+			expected: DeviceStatusEvent{ID: "mac:112233445566", Status: Online},
+		}, {
+			desc:     "offline with reason",
+			locator:  "event:device-status/mac:112233445566/offline/ping-miss",
+			expected: DeviceStatusEvent{ID: "mac:112233445566", Status: Offline, Reason: "ping-miss"},
+		}, {
+			desc:     "device id is normalized",
+			locator:  "event:device-status/MAC:11-22-33-44-55-66/fully-manageable",
+			expected: DeviceStatusEvent{ID: "mac:112233445566", Status: FullyManageable},
+			dest:     "event:device-status/mac:112233445566/fully-manageable",
+		}, {
+			desc:        "other classifier",
+			locator:     "event:iot/mac:112233445566/online",
+			expectedErr: ErrInvalidDeviceStatus,
+		}, {
+			desc:        "missing status",
+			locator:     "event:device-status/mac:112233445566",
+			expectedErr: ErrInvalidDeviceStatus,
+		}, {
+			desc:        "empty status",
+			locator:     "event:device-status/mac:112233445566/",
+			expectedErr: ErrInvalidDeviceStatus,
+		}, {
+			desc:        "invalid device id",
+			locator:     "event:device-status/mac:invalid/online",
+			expectedErr: wrp.ErrorInvalidDeviceName,
+		}, {
+			desc:        "not an event",
+			locator:     "dns:example.com",
+			expectedErr: ErrNotEvent,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			dse, err := ParseDeviceStatus(tc.locator)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(tc.expected, dse)
+
+			dest := tc.dest
+			if dest == "" {
+				dest = tc.locator
+			}
+			assert.Equal(dest, dse.Destination())
+		})
+	}
+}
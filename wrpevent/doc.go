@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package wrpevent provides typed access to WRP event locators.  An event
+locator has the form
+
+	event:{classifier}/{path}
+
+where the classifier, such as device-status, identifies the family of the
+event.  Parse splits a locator into an Event, and ParseDeviceStatus further
+parses the path of a device-status event.  Both types build their Destination
+strings, so producers and consumers agree on the format.
+
+The payloads of the standard device-status events are provided as structs,
+which NewSimpleEvent and FromSimpleEvent convert to and from messages.
+*/
+package wrpevent
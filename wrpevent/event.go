@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpevent
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+var (
+	ErrNotEvent            = errors.New("not an event locator")
+	ErrUnknownClassifier   = errors.New("unknown event classifier")
+	ErrInvalidDeviceStatus = errors.New("invalid device-status event")
+)
+
+// Event is a parsed event locator.
+type Event struct {
+	// Classifier is the family of the event.
+	Classifier Classifier
+
+	// Path is the remainder of the locator after the classifier, without
+	// the leading '/'.  The format of the path depends on the classifier.
+	Path string
+}
+
+// New creates an Event with the path made from the given segments.
+func New(c Classifier, segments ...string) Event {
+	return Event{
+		Classifier: c,
+		Path:       strings.Join(segments, "/"),
+	}
+}
+
+// Parse parses an event locator, such as the Destination of a SimpleEvent.
+// An error wrapping ErrNotEvent is returned if the locator does not use the
+// event scheme.  Unregistered classifiers are accepted; use ParseKnown to
+// reject them.
+func Parse(locator string) (Event, error) {
+	l, err := wrp.ParseLocator(locator)
+	if err != nil {
+		return Event{}, errors.Join(err, ErrNotEvent)
+	}
+
+	if l.Scheme != wrp.SchemeEvent {
+		return Event{}, fmt.Errorf("%w: `%s`", ErrNotEvent, locator)
+	}
+
+	return Event{
+		Classifier: Classifier(l.Authority),
+		Path:       strings.TrimPrefix(l.Ignored, "/"),
+	}, nil
+}
+
+// ParseKnown is like Parse, but also returns an error wrapping
+// ErrUnknownClassifier if the classifier has not been registered.
+func ParseKnown(locator string) (Event, error) {
+	e, err := Parse(locator)
+	if err != nil {
+		return Event{}, err
+	}
+
+	if !e.Classifier.IsKnown() {
+		return Event{}, fmt.Errorf("%w: `%s`", ErrUnknownClassifier, e.Classifier)
+	}
+
+	return e, nil
+}
+
+// Segments returns the '/' separated segments of the path.
+func (e Event) Segments() []string {
+	if e.Path == "" {
+		return nil
+	}
+
+	return strings.Split(e.Path, "/")
+}
+
+// Destination returns the event locator, suitable for use as the Destination
+// of a message.
+func (e Event) Destination() string {
+	var buf strings.Builder
+	buf.WriteString(wrp.SchemeEvent)
+	buf.WriteString(":")
+	buf.WriteString(string(e.Classifier))
+	if e.Path != "" {
+		buf.WriteString("/")
+		buf.WriteString(e.Path)
+	}
+
+	return buf.String()
+}
+
+// String returns the event locator.
+func (e Event) String() string {
+	return e.Destination()
+}
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpevent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		desc        string
+		locator     string
+		expected    Event
+		segments    []string
+		dest        string
+		expectedErr error
+	}{
+		{
+			desc:     "classifier only",
+			locator:  "event:iot",
+			expected: Event{Classifier: IoT},
+			dest:     "event:iot",
+		}, {
+			desc:     "device status",
+			locator:  "event:device-status/mac:112233445566/online",
+			expected: Event{Classifier: DeviceStatus, Path: "mac:112233445566/online"},
+			segments: []string{"mac:112233445566", "online"},
+			dest:     "event:device-status/mac:112233445566/online",
+		}, {
+			desc:     "unknown classifier",
+			locator:  "EVENT:custom/a",
+			expected: Event{Classifier: "custom", Path: "a"},
+			segments: []string{"a"},
+			dest:     "event:custom/a",
+		}, {
+			desc:        "not an event",
+			locator:     "mac:112233445566",
+			expectedErr: ErrNotEvent,
+		}, {
+			desc:        "invalid locator",
+			locator:     "event:",
+			expectedErr: ErrNotEvent,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			e, err := Parse(tc.locator)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(tc.expected, e)
+			assert.Equal(tc.segments, e.Segments())
+			assert.Equal(tc.dest, e.Destination())
+			assert.Equal(tc.dest, e.String())
+		})
+	}
+}
+
+func TestParseKnown(t *testing.T) {
+	_, err := ParseKnown("event:device-status/mac:112233445566/online")
+	assert.NoError(t, err)
+
+	_, err = ParseKnown("event:test-only-classifier")
+	assert.ErrorIs(t, err, ErrUnknownClassifier)
+
+	_, err = ParseKnown("dns:example.com")
+	assert.ErrorIs(t, err, ErrNotEvent)
+
+	Register("test-only-classifier", "test-only-classifier")
+	_, err = ParseKnown("event:test-only-classifier")
+	assert.NoError(t, err)
+	assert.Contains(t, Known(), Classifier("test-only-classifier"))
+	assert.Contains(t, Known(), DeviceStatus)
+}
+
+func TestNew(t *testing.T) {
+	assert.Equal(t, "event:iot/a/b", New(IoT, "a", "b").Destination())
+	assert.Equal(t, "event:iot", New(IoT).Destination())
+}
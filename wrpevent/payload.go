@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpevent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+var (
+	ErrInvalidPayload    = errors.New("invalid device-status payload")
+	ErrUnsupportedStatus = errors.New("unsupported device-status status")
+)
+
+// DeviceStatusPayload is implemented by the payload structs of device-status
+// events.
+type DeviceStatusPayload interface {
+	// DeviceStatusEvent returns the event the payload belongs to, which
+	// determines the Destination of the message.
+	DeviceStatusEvent() DeviceStatusEvent
+}
+
+// Duration is a time.Duration that is encoded in JSON as a string, such as
+// "1h2m3s".
+type Duration time.Duration
+
+// MarshalJSON encodes the duration as a string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON decodes a duration string.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(v)
+	return nil
+}
+
+// OnlinePayload is the payload of an online event, published when a device
+// connects.
+type OnlinePayload struct {
+	ID         wrp.DeviceID      `json:"id"`
+	Timestamp  time.Time         `json:"ts"`
+	PartnerIDs []string          `json:"partner-ids,omitempty"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+}
+
+// DeviceStatusEvent returns the online event for the device.
+func (p *OnlinePayload) DeviceStatusEvent() DeviceStatusEvent {
+	return DeviceStatusEvent{ID: p.ID, Status: Online}
+}
+
+// OfflinePayload is the payload of an offline event, published when a device
+// disconnects.  It summarizes the connection that was closed.
+type OfflinePayload struct {
+	ID               wrp.DeviceID `json:"id"`
+	Timestamp        time.Time    `json:"ts"`
+	BytesSent        int64        `json:"bytes-sent"`
+	MessagesSent     int64        `json:"messages-sent"`
+	BytesReceived    int64        `json:"bytes-received"`
+	MessagesReceived int64        `json:"messages-received"`
+	ConnectedAt      time.Time    `json:"connected-at"`
+	UpTime           Duration     `json:"up-time"`
+	ReasonForClosure string       `json:"reason-for-closure,omitempty"`
+}
+
+// DeviceStatusEvent returns the offline event for the device.
+func (p *OfflinePayload) DeviceStatusEvent() DeviceStatusEvent {
+	return DeviceStatusEvent{ID: p.ID, Status: Offline}
+}
+
+// FullyManageablePayload is the payload of a fully-manageable event,
+// published by a device once it has booted and can be managed.
+type FullyManageablePayload struct {
+	ID           wrp.DeviceID `json:"id"`
+	Timestamp    time.Time    `json:"ts"`
+	RebootReason string       `json:"reboot-reason,omitempty"`
+	BootTime     int64        `json:"boot-time,omitempty"`
+}
+
+// DeviceStatusEvent returns the fully-manageable event for the device.
+func (p *FullyManageablePayload) DeviceStatusEvent() DeviceStatusEvent {
+	return DeviceStatusEvent{ID: p.ID, Status: FullyManageable}
+}
+
+var (
+	_ DeviceStatusPayload = (*OnlinePayload)(nil)
+	_ DeviceStatusPayload = (*OfflinePayload)(nil)
+	_ DeviceStatusPayload = (*FullyManageablePayload)(nil)
+)
+
+// NewSimpleEvent creates the SimpleEvent carrying the payload, with the
+// Destination set from the payload's event and the payload encoded as JSON.
+func NewSimpleEvent(source string, p DeviceStatusPayload) (*wrp.SimpleEvent, error) {
+	payload, err := json.Marshal(p)
+	if err != nil {
+		return nil, errors.Join(err, ErrInvalidPayload)
+	}
+
+	return &wrp.SimpleEvent{
+		Type:        wrp.SimpleEventMessageType,
+		Source:      source,
+		Destination: p.DeviceStatusEvent().Destination(),
+		ContentType: wrp.MimeTypeJson,
+		Payload:     payload,
+	}, nil
+}
+
+// FromSimpleEvent decodes the payload of a device-status event.  The type of
+// the returned payload is determined by the status in the Destination, e.g.
+// an online event produces an *OnlinePayload.  If the payload does not include
+// the device ID, it is taken from the Destination.
+//
+// An error wrapping ErrUnsupportedStatus is returned for statuses that do not
+// have a payload struct, and one wrapping ErrInvalidPayload if the payload
+// cannot be decoded.
+func FromSimpleEvent(se *wrp.SimpleEvent) (DeviceStatusPayload, error) {
+	dse, err := ParseDeviceStatus(se.Destination)
+	if err != nil {
+		return nil, err
+	}
+
+	var p DeviceStatusPayload
+	var id *wrp.DeviceID
+	switch dse.Status {
+	case Online:
+		op := new(OnlinePayload)
+		p, id = op, &op.ID
+	case Offline:
+		op := new(OfflinePayload)
+		p, id = op, &op.ID
+	case FullyManageable:
+		fp := new(FullyManageablePayload)
+		p, id = fp, &fp.ID
+	default:
+		return nil, fmt.Errorf("%w: `%s`", ErrUnsupportedStatus, dse.Status)
+	}
+
+	if err := json.Unmarshal(se.Payload, p); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+
+	if *id == "" {
+		*id = dse.ID
+	}
+
+	return p, nil
+}
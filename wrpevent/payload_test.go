@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpevent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestDeviceStatusPayloads(t *testing.T) {
+	ts := time.Date(2025, time.January, 2, 3, 4, 5, 6, time.UTC)
+
+	tests := []struct {
+		desc    string
+		payload DeviceStatusPayload
+		dest    string
+	}{
+		{
+			desc: "online",
+			payload: &OnlinePayload{
+				ID:         "mac:112233445566",
+				Timestamp:  ts,
+				PartnerIDs: []string{"comcast"},
+				Metadata:   map[string]string{"/hw-model": "model"},
+			},
+			dest: "event:device-status/mac:112233445566/online",
+		}, {
+			desc: "offline",
+			payload: &OfflinePayload{
+				ID:               "mac:112233445566",
+				Timestamp:        ts,
+				BytesSent:        1,
+				MessagesSent:     2,
+				BytesReceived:    3,
+				MessagesReceived: 4,
+				ConnectedAt:      ts.Add(-time.Hour),
+				UpTime:           Duration(time.Hour),
+				ReasonForClosure: "ping miss",
+			},
+			dest: "event:device-status/mac:112233445566/offline",
+		}, {
+			desc: "fully-manageable",
+			payload: &FullyManageablePayload{
+				ID:           "mac:112233445566",
+				Timestamp:    ts,
+				RebootReason: "power-on",
+				BootTime:     1735787045,
+			},
+			dest: "event:device-status/mac:112233445566/fully-manageable",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			se, err := NewSimpleEvent("dns:talaria.example.com", tc.payload)
+			require.NoError(err)
+			assert.Equal(wrp.SimpleEventMessageType, se.Type)
+			assert.Equal("dns:talaria.example.com", se.Source)
+			assert.Equal(tc.dest, se.Destination)
+			assert.Equal(wrp.MimeTypeJson, se.ContentType)
+
+			p, err := FromSimpleEvent(se)
+			require.NoError(err)
+			assert.Equal(tc.payload, p)
+		})
+	}
+}
+
+func TestFromSimpleEvent(t *testing.T) {
+	t.Run("talaria offline payload", func(t *testing.T) {
+		assert := assert.New(t)
+
+		p, err := FromSimpleEvent(&wrp.SimpleEvent{
+			Destination: "event:device-status/mac:112233445566/offline",
+			Payload: []byte(`{
+				"id": "mac:112233445566",
+				"ts": "2025-01-02T03:04:05Z",
+				"bytes-sent": 10,
+				"messages-sent": 1,
+				"bytes-received": 20,
+				"messages-received": 2,
+				"connected-at": "2025-01-02T02:04:05Z",
+				"up-time": "1h0m0s",
+				"reason-for-closure": "ping miss"
+			}`),
+		})
+		require.NoError(t, err)
+		require.IsType(t, &OfflinePayload{}, p)
+
+		offline := p.(*OfflinePayload)
+		assert.Equal(Duration(time.Hour), offline.UpTime)
+		assert.Equal("ping miss", offline.ReasonForClosure)
+		assert.Equal(int64(20), offline.BytesReceived)
+	})
+
+	t.Run("id from destination", func(t *testing.T) {
+		p, err := FromSimpleEvent(&wrp.SimpleEvent{
+			Destination: "event:device-status/mac:112233445566/online",
+			Payload:     []byte(`{"ts": "2025-01-02T03:04:05Z"}`),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, wrp.DeviceID("mac:112233445566"), p.DeviceStatusEvent().ID)
+	})
+
+	tests := []struct {
+		desc        string
+		se          wrp.SimpleEvent
+		expectedErr error
+	}{
+		{
+			desc:        "not device-status",
+			se:          wrp.SimpleEvent{Destination: "event:iot/x"},
+			expectedErr: ErrInvalidDeviceStatus,
+		}, {
+			desc:        "unsupported status",
+			se:          wrp.SimpleEvent{Destination: "event:device-status/mac:112233445566/reboot-pending"},
+			expectedErr: ErrUnsupportedStatus,
+		}, {
+			desc:        "invalid json",
+			se:          wrp.SimpleEvent{Destination: "event:device-status/mac:112233445566/online", Payload: []byte("{")},
+			expectedErr: ErrInvalidPayload,
+		}, {
+			desc: "invalid up-time",
+			se: wrp.SimpleEvent{
+				Destination: "event:device-status/mac:112233445566/offline",
+				Payload:     []byte(`{"up-time": "forever"}`),
+			},
+			expectedErr: ErrInvalidPayload,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			p, err := FromSimpleEvent(&tc.se)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Nil(t, p)
+		})
+	}
+}
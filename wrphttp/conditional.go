@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrphttp
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/xmidt-org/wrp-go/v3"
+	"github.com/xmidt-org/wrp-go/v3/wrpcrud"
+)
+
+// WithConditionalRetrieve configures the handler to translate HTTP conditional
+// requests to and from the CRUD metadata of wrpcrud, so clients can cache the
+// objects they retrieve, e.g. device configuration.
+//
+// The If-None-Match headers of a request with a Retrieve message are set in
+// the Metadata of the message under wrpcrud.IfNoneMatchKey, in which case the
+// entity's Bytes are cleared since they no longer match.  A Retrieve response
+// with an entity tag under wrpcrud.ETagKey is written with an ETag header.  A
+// response with a 304 Status, or with a 2xx Status and an entity tag that
+// matches the If-None-Match of the request, is written as a 304 (Not Modified)
+// with no body.  Other responses are written as usual.
+//
+// By default, the handler does not translate conditional requests.
+func WithConditionalRetrieve() Option {
+	return func(wh *wrpHandler) {
+		wh.conditional = true
+	}
+}
+
+// setIfNoneMatch copies the If-None-Match headers onto a Retrieve message
+// and reports whether it did.  An If-None-Match the message already has is
+// kept.
+func setIfNoneMatch(m *wrp.Message, h http.Header) bool {
+	values := h.Values("If-None-Match")
+	if m.Type != wrp.RetrieveMessageType || len(values) == 0 {
+		return false
+	}
+
+	if _, ok := m.Metadata[wrpcrud.IfNoneMatchKey]; ok {
+		return false
+	}
+
+	wrpcrud.SetIfNoneMatch(m, strings.Join(values, ", "))
+	return true
+}
+
+// conditionalResponseWriter is a decorator that writes the entity tags of
+// Retrieve responses as ETag headers, and unmodified objects as a 304.
+type conditionalResponseWriter struct {
+	ResponseWriter
+	ifNoneMatch string
+}
+
+func (rw *conditionalResponseWriter) WriteWRP(e *Entity) (int, error) {
+	if rw.notModified(&e.Message) {
+		return 0, nil
+	}
+
+	return rw.ResponseWriter.WriteWRP(e)
+}
+
+func (rw *conditionalResponseWriter) WriteWRPBytes(f wrp.Format, encodedWRP []byte) (int, error) {
+	var msg wrp.Message
+	if len(encodedWRP) > 0 && wrp.NewDecoderBytes(encodedWRP, f).Decode(&msg) == nil {
+		if rw.notModified(&msg) {
+			return 0, nil
+		}
+	}
+
+	return rw.ResponseWriter.WriteWRPBytes(f, encodedWRP)
+}
+
+// ReadFrom writes the contents of r as is, in the same way as Write.
+func (rw *conditionalResponseWriter) ReadFrom(r io.Reader) (int64, error) {
+	return io.Copy(rw.ResponseWriter, r)
+}
+
+// notModified sets the ETag header of a Retrieve response, and writes a 304
+// and reports true if the client's copy is current.
+func (rw *conditionalResponseWriter) notModified(msg *wrp.Message) bool {
+	if msg.Type != wrp.RetrieveMessageType {
+		return false
+	}
+
+	etag, ok := wrpcrud.ETag(msg)
+	if ok {
+		rw.Header().Set("ETag", etag)
+	}
+
+	switch {
+	case msg.Status == nil:
+		return false
+	case *msg.Status == http.StatusNotModified:
+	case *msg.Status >= 200 && *msg.Status < 300 && wrpcrud.ETagMatches(rw.ifNoneMatch, etag):
+	default:
+		return false
+	}
+
+	rw.WriteHeader(http.StatusNotModified)
+	return true
+}
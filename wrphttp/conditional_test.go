@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrphttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xmidt-org/wrp-go/v3"
+	"github.com/xmidt-org/wrp-go/v3/wrpcrud"
+)
+
+func TestConditionalRetrieve(t *testing.T) {
+	status := func(s int64) *int64 { return &s }
+
+	tests := []struct {
+		desc          string
+		opts          []Option
+		requestType   wrp.MessageType
+		ifNoneMatch   string
+		response      wrp.Message
+		useBytes      bool
+		expectedMatch string
+		expectedCode  int
+		expectedETag  string
+	}{
+		{
+			desc:         "disabled",
+			requestType:  wrp.RetrieveMessageType,
+			ifNoneMatch:  `"v1"`,
+			response:     wrp.Message{Type: wrp.RetrieveMessageType, Status: status(200), Metadata: map[string]string{wrpcrud.ETagKey: `"v1"`}},
+			expectedCode: http.StatusOK,
+		}, {
+			desc:          "modified",
+			opts:          []Option{WithConditionalRetrieve()},
+			requestType:   wrp.RetrieveMessageType,
+			ifNoneMatch:   `"v1"`,
+			response:      wrp.Message{Type: wrp.RetrieveMessageType, Status: status(200), Metadata: map[string]string{wrpcrud.ETagKey: `"v2"`}},
+			expectedMatch: `"v1"`,
+			expectedCode:  http.StatusOK,
+			expectedETag:  `"v2"`,
+		}, {
+			desc:          "not modified",
+			opts:          []Option{WithConditionalRetrieve()},
+			requestType:   wrp.RetrieveMessageType,
+			ifNoneMatch:   `"v1"`,
+			response:      wrp.Message{Type: wrp.RetrieveMessageType, Status: status(200), Metadata: map[string]string{wrpcrud.ETagKey: `"v1"`}},
+			expectedMatch: `"v1"`,
+			expectedCode:  http.StatusNotModified,
+			expectedETag:  `"v1"`,
+		}, {
+			desc:          "not modified bytes",
+			opts:          []Option{WithConditionalRetrieve()},
+			requestType:   wrp.RetrieveMessageType,
+			ifNoneMatch:   `"v1"`,
+			response:      wrp.Message{Type: wrp.RetrieveMessageType, Status: status(200), Metadata: map[string]string{wrpcrud.ETagKey: `"v1"`}},
+			useBytes:      true,
+			expectedMatch: `"v1"`,
+			expectedCode:  http.StatusNotModified,
+			expectedETag:  `"v1"`,
+		}, {
+			desc:          "304 from the service",
+			opts:          []Option{WithConditionalRetrieve()},
+			requestType:   wrp.RetrieveMessageType,
+			ifNoneMatch:   `"v1"`,
+			response:      wrp.Message{Type: wrp.RetrieveMessageType, Status: status(304)},
+			expectedMatch: `"v1"`,
+			expectedCode:  http.StatusNotModified,
+		}, {
+			desc:         "unconditional",
+			opts:         []Option{WithConditionalRetrieve()},
+			requestType:  wrp.RetrieveMessageType,
+			response:     wrp.Message{Type: wrp.RetrieveMessageType, Status: status(200), Metadata: map[string]string{wrpcrud.ETagKey: `"v1"`}},
+			expectedCode: http.StatusOK,
+			expectedETag: `"v1"`,
+		}, {
+			desc:          "failure",
+			opts:          []Option{WithConditionalRetrieve()},
+			requestType:   wrp.RetrieveMessageType,
+			ifNoneMatch:   "*",
+			response:      wrp.Message{Type: wrp.RetrieveMessageType, Status: status(404), Metadata: map[string]string{wrpcrud.ETagKey: `"v1"`}},
+			expectedMatch: "*",
+			expectedCode:  http.StatusOK,
+			expectedETag:  `"v1"`,
+		}, {
+			desc:         "not a retrieve",
+			opts:         []Option{WithConditionalRetrieve()},
+			requestType:  wrp.UpdateMessageType,
+			ifNoneMatch:  `"v1"`,
+			response:     wrp.Message{Type: wrp.UpdateMessageType, Status: status(200), Metadata: map[string]string{wrpcrud.ETagKey: `"v1"`}},
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			handler := HandlerFunc(func(w ResponseWriter, r *Request) {
+				assert.Equal(tc.expectedMatch, r.Entity.Message.Metadata[wrpcrud.IfNoneMatchKey])
+				if tc.expectedMatch != "" {
+					assert.Nil(r.Entity.Bytes)
+				}
+
+				var err error
+				if tc.useBytes {
+					_, err = w.WriteWRPBytes(wrp.Msgpack, wrp.MustEncode(&tc.response, wrp.Msgpack))
+				} else {
+					_, err = w.WriteWRP(&Entity{Message: tc.response})
+				}
+				assert.NoError(err)
+			})
+
+			decoder := func(context.Context, *http.Request) (*Entity, error) {
+				msg := wrp.Message{Type: tc.requestType, TransactionUUID: "1234"}
+				return &Entity{Message: msg, Bytes: wrp.MustEncode(&msg, wrp.Msgpack)}, nil
+			}
+
+			opts := append([]Option{
+				WithDecoder(decoder),
+				WithNewResponseWriter(NewEntityResponseWriter(wrp.Msgpack)),
+			}, tc.opts...)
+
+			httpRequest := httptest.NewRequest("POST", "/", nil)
+			if tc.ifNoneMatch != "" {
+				httpRequest.Header.Set("If-None-Match", tc.ifNoneMatch)
+			}
+
+			httpResponse := httptest.NewRecorder()
+			NewHTTPHandler(handler, opts...).ServeHTTP(httpResponse, httpRequest)
+
+			assert.Equal(tc.expectedCode, httpResponse.Code)
+			assert.Equal(tc.expectedETag, httpResponse.Header().Get("ETag"))
+			if tc.expectedCode == http.StatusNotModified {
+				assert.Empty(httpResponse.Body.Bytes())
+			} else {
+				assert.NotEmpty(httpResponse.Body.Bytes())
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrphttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// ErrAbandoned is returned by Drain when exchanges were still in flight at its
+// deadline, and by the ResponseWriter of an abandoned exchange.
+var ErrAbandoned = errors.New("exchange abandoned while draining")
+
+// Drainer is a Handler decorator that tracks the WRP exchanges in flight so
+// that a service can shut down gracefully with Drain.  Use it as the Handler
+// given to NewHTTPHandler:
+//
+//	d := wrphttp.NewDrainer(handler)
+//	server := http.Server{Handler: wrphttp.NewHTTPHandler(d)}
+//	...
+//	_ = d.Drain(ctx)
+//	_ = server.Shutdown(ctx)
+//
+// A Drainer is safe for concurrent use.
+type Drainer struct {
+	next Handler
+
+	lock     sync.Mutex
+	draining bool
+	inflight map[*drainingExchange]struct{}
+	idle     chan struct{}
+}
+
+var _ Handler = (*Drainer)(nil)
+
+// NewDrainer creates a Drainer that passes requests to next until it is
+// drained.
+func NewDrainer(next Handler) *Drainer {
+	if next == nil {
+		panic("A WRP Handler is required")
+	}
+
+	return &Drainer{
+		next:     next,
+		inflight: make(map[*drainingExchange]struct{}),
+		idle:     make(chan struct{}),
+	}
+}
+
+// ServeWRP passes the request to the decorated Handler.  Once Drain has been
+// called, the request is rejected with a response whose Status is
+// http.StatusServiceUnavailable and whose RequestDeliveryResponse is
+// RDRShuttingDown, written with an HTTP status of
+// http.StatusServiceUnavailable.
+func (d *Drainer) ServeWRP(w ResponseWriter, r *Request) {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	e := &drainingExchange{
+		ResponseWriter: w,
+		request:        r,
+		cancel:         cancel,
+	}
+
+	if !d.add(e) {
+		writeFailureResponse(w, r, http.StatusServiceUnavailable, RDRShuttingDown)
+		return
+	}
+
+	defer d.remove(e)
+	d.next.ServeWRP(e, r.WithContext(ctx))
+}
+
+// InFlight returns the number of exchanges in flight.
+func (d *Drainer) InFlight() int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return len(d.inflight)
+}
+
+// Drain stops accepting new requests and waits for the exchanges in flight to
+// complete.  If the context ends first, the remaining exchanges are abandoned:
+// the context of each is canceled and, unless its Handler has already started
+// a response, the same response as that of a rejected request is written
+// with a RequestDeliveryResponse of RDRShuttingDown.  Further writes by the
+// Handler of an abandoned exchange fail with ErrAbandoned.  In that case an
+// error wrapping both ErrAbandoned and the context's error is returned.
+//
+// Drain does not wait for the Handlers of abandoned exchanges to return, so
+// they should honor the cancellation of their request's context.  Drain may
+// be called more than once.
+func (d *Drainer) Drain(ctx context.Context) error {
+	d.lock.Lock()
+	if !d.draining {
+		d.draining = true
+		if len(d.inflight) == 0 {
+			close(d.idle)
+		}
+	}
+	d.lock.Unlock()
+
+	select {
+	case <-d.idle:
+		return nil
+	case <-ctx.Done():
+	}
+
+	d.lock.Lock()
+	abandoned := make([]*drainingExchange, 0, len(d.inflight))
+	for e := range d.inflight {
+		abandoned = append(abandoned, e)
+	}
+	d.lock.Unlock()
+
+	for _, e := range abandoned {
+		e.abandon()
+	}
+
+	return fmt.Errorf("%w: %d exchanges: %w", ErrAbandoned, len(abandoned), ctx.Err())
+}
+
+// add tracks the exchange, or returns false if the Drainer is draining.
+func (d *Drainer) add(e *drainingExchange) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.draining {
+		return false
+	}
+
+	d.inflight[e] = struct{}{}
+	return true
+}
+
+func (d *Drainer) remove(e *drainingExchange) {
+	e.finish()
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	delete(d.inflight, e)
+	if d.draining && len(d.inflight) == 0 {
+		close(d.idle)
+	}
+}
+
+// drainingExchange is the ResponseWriter of an exchange in flight.  Its writes
+// are serialized with abandon, which may be called from another goroutine.
+type drainingExchange struct {
+	ResponseWriter
+	request *Request
+	cancel  context.CancelFunc
+
+	lock      sync.Mutex
+	written   bool
+	abandoned bool
+	finished  bool
+}
+
+// abandon cancels the exchange and writes the final response, unless the
+// exchange has already finished or started a response.
+func (e *drainingExchange) abandon() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.cancel()
+	if e.finished || e.abandoned {
+		return
+	}
+
+	e.abandoned = true
+	if !e.written {
+		writeFailureResponse(e.ResponseWriter, e.request, http.StatusServiceUnavailable, RDRShuttingDown)
+	}
+}
+
+// finish marks the exchange as finished, after which it is not abandoned.
+func (e *drainingExchange) finish() {
+	e.lock.Lock()
+	e.finished = true
+	e.lock.Unlock()
+}
+
+// Header returns a throwaway header once the exchange is abandoned, so that
+// the Handler does not modify the header of the final response.
+func (e *drainingExchange) Header() http.Header {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.abandoned {
+		return make(http.Header)
+	}
+
+	return e.ResponseWriter.Header()
+}
+
+func (e *drainingExchange) WriteHeader(code int) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if !e.abandoned {
+		e.written = true
+		e.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (e *drainingExchange) Write(b []byte) (int, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.abandoned {
+		return 0, ErrAbandoned
+	}
+
+	e.written = true
+	return e.ResponseWriter.Write(b)
+}
+
+func (e *drainingExchange) WriteWRP(entity *Entity) (int, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.abandoned {
+		return 0, ErrAbandoned
+	}
+
+	e.written = true
+	return e.ResponseWriter.WriteWRP(entity)
+}
+
+func (e *drainingExchange) WriteWRPBytes(f wrp.Format, encoded []byte) (int, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.abandoned {
+		return 0, ErrAbandoned
+	}
+
+	e.written = true
+	return e.ResponseWriter.WriteWRPBytes(f, encoded)
+}
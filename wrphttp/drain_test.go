@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrphttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+var drainRequest = wrp.Message{
+	Type:            wrp.SimpleRequestResponseMessageType,
+	Source:          "dns:example.com",
+	Destination:     "mac:112233445566/config",
+	TransactionUUID: "1234",
+}
+
+func newDrainHandler(d *Drainer) http.Handler {
+	decoder := func(context.Context, *http.Request) (*Entity, error) {
+		return &Entity{Message: drainRequest}, nil
+	}
+
+	return NewHTTPHandler(d, WithDecoder(decoder))
+}
+
+// serveDrain serves a request in the background, returning a channel that
+// receives the response once the exchange is over.
+func serveDrain(h http.Handler) <-chan *httptest.ResponseRecorder {
+	done := make(chan *httptest.ResponseRecorder, 1)
+	go func() {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("POST", "/", nil))
+		done <- w
+	}()
+
+	return done
+}
+
+func assertShuttingDown(t *testing.T, w *httptest.ResponseRecorder) {
+	assert := assert.New(t)
+	assert.Equal(http.StatusServiceUnavailable, w.Code)
+
+	var actual wrp.Message
+	require.NoError(t, wrp.NewDecoderBytes(w.Body.Bytes(), wrp.Msgpack).Decode(&actual))
+
+	expected := wrp.Message{
+		Type:            wrp.SimpleRequestResponseMessageType,
+		Source:          "mac:112233445566/config",
+		Destination:     "dns:example.com",
+		TransactionUUID: "1234",
+	}
+	expected.SetStatus(http.StatusServiceUnavailable).SetRequestDeliveryResponse(RDRShuttingDown)
+	assert.Equal(expected, actual)
+}
+
+func TestDrainer(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var (
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+
+	d := NewDrainer(HandlerFunc(func(w ResponseWriter, _ *Request) {
+		started <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	h := newDrainHandler(d)
+
+	done := serveDrain(h)
+	<-started
+	assert.Equal(1, d.InFlight())
+
+	drained := make(chan error, 1)
+	go func() {
+		drained <- d.Drain(context.Background())
+	}()
+
+	require.Eventually(func() bool {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("POST", "/", nil))
+		return w.Code == http.StatusServiceUnavailable
+	}, time.Second, time.Millisecond, "new requests must be rejected")
+
+	select {
+	case <-drained:
+		require.Fail("Drain returned with an exchange in flight")
+	default:
+	}
+
+	close(release)
+	assert.Equal(http.StatusAccepted, (<-done).Code)
+	assert.NoError(<-drained)
+	assert.Zero(d.InFlight())
+
+	// rejected requests get a WRP response
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/", nil))
+	assertShuttingDown(t, w)
+
+	// draining again returns immediately
+	assert.NoError(d.Drain(context.Background()))
+}
+
+func TestDrainer_idle(t *testing.T) {
+	d := NewDrainer(HandlerFunc(func(ResponseWriter, *Request) {}))
+	assert.NoError(t, d.Drain(context.Background()))
+}
+
+func TestDrainer_abandon(t *testing.T) {
+	tests := []struct {
+		desc string
+
+		// write is the response written before the exchange is abandoned
+		write        bool
+		expectedCode int
+	}{
+		{
+			desc: "no response",
+		}, {
+			desc:         "response started",
+			write:        true,
+			expectedCode: http.StatusAccepted,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			var (
+				started  = make(chan struct{})
+				writeErr = make(chan error, 1)
+			)
+
+			d := NewDrainer(HandlerFunc(func(w ResponseWriter, r *Request) {
+				if tc.write {
+					w.WriteHeader(http.StatusAccepted)
+				}
+
+				close(started)
+				<-r.Context().Done()
+
+				w.Header().Set("X-Ignored", "true")
+				_, err := w.WriteWRP(&Entity{Message: wrp.Message{Type: wrp.SimpleRequestResponseMessageType}})
+				writeErr <- err
+			}))
+
+			done := serveDrain(newDrainHandler(d))
+			<-started
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+
+			err := d.Drain(ctx)
+			assert.ErrorIs(err, ErrAbandoned)
+			assert.ErrorIs(err, context.DeadlineExceeded)
+
+			assert.ErrorIs(<-writeErr, ErrAbandoned)
+
+			w := <-done
+			assert.Empty(w.Header().Get("X-Ignored"))
+			if !tc.write {
+				assertShuttingDown(t, w)
+				return
+			}
+
+			assert.Equal(tc.expectedCode, w.Code)
+			require.Zero(w.Body.Len())
+		})
+	}
+}
@@ -3,11 +3,23 @@
 
 package wrphttp
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	gokithttp "github.com/go-kit/kit/transport/http"
+	"github.com/xmidt-org/wrp-go/v3"
+	"github.com/xmidt-org/wrp-go/v3/wrpendpoint"
+)
 
 type httpError struct {
-	err  error
-	code int
+	err    error
+	code   int
+	header http.Header
 }
 
 func (e httpError) Error() string {
@@ -18,7 +30,55 @@ func (e httpError) StatusCode() int {
 	return e.code
 }
 
+// Headers returns any additional headers to write with the error response.
+func (e httpError) Headers() http.Header {
+	return e.header
+}
+
 // Is reports whether any error in e.err's chain matches target.
 func (e httpError) Is(target error) bool {
 	return errors.Is(e.err, target)
 }
+
+// ErrorEncoder is a go-kit ErrorEncoder that maps errors from the wrp package
+// onto the appropriate HTTP status codes before delegating to
+// gokithttp.DefaultErrorEncoder.  It can be supplied to WithErrorEncoder.
+//
+// A wrp.RateLimitError results in a 429 Too Many Requests response and a
+// wrpendpoint.BusyError in a 503 Service Unavailable response, both with a
+// Retry-After header.  All other errors are passed through unchanged.
+func ErrorEncoder(ctx context.Context, err error, w http.ResponseWriter) {
+	gokithttp.DefaultErrorEncoder(ctx, translateError(err), w)
+}
+
+// translateError converts known wrp errors into httpError instances.
+func translateError(err error) error {
+	var rle *wrp.RateLimitError
+	if errors.As(err, &rle) {
+		return retryError(err, http.StatusTooManyRequests, rle.RetryAfter)
+	}
+
+	var be *wrpendpoint.BusyError
+	if errors.As(err, &be) {
+		return retryError(err, http.StatusServiceUnavailable, be.RetryAfter)
+	}
+
+	return err
+}
+
+// retryError returns an httpError with a Retry-After header, in whole seconds
+// rounded up to at least one.
+func retryError(err error, code int, retryAfter time.Duration) httpError {
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	return httpError{
+		err:  err,
+		code: code,
+		header: http.Header{
+			"Retry-After": []string{strconv.FormatInt(seconds, 10)},
+		},
+	}
+}
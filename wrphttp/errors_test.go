@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrphttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xmidt-org/wrp-go/v3"
+	"github.com/xmidt-org/wrp-go/v3/wrpendpoint"
+)
+
+func TestErrorEncoder(t *testing.T) {
+	tests := []struct {
+		desc       string
+		err        error
+		code       int
+		retryAfter string
+	}{
+		{
+			desc: "unknown error",
+			err:  errors.New("unknown"),
+			code: http.StatusInternalServerError,
+		}, {
+			desc: "httpError",
+			err:  httpError{err: errors.New("bad"), code: http.StatusBadRequest},
+			code: http.StatusBadRequest,
+		}, {
+			desc: "rate limited",
+			err: fmt.Errorf("wrapped: %w", &wrp.RateLimitError{
+				Key:        "mac:112233445566",
+				RetryAfter: 1500 * time.Millisecond,
+			}),
+			code:       http.StatusTooManyRequests,
+			retryAfter: "2",
+		}, {
+			desc:       "rate limited, sub-second",
+			err:        &wrp.RateLimitError{Key: "partner:a", RetryAfter: time.Millisecond},
+			code:       http.StatusTooManyRequests,
+			retryAfter: "1",
+		}, {
+			desc: "busy",
+			err: fmt.Errorf("wrapped: %w", &wrpendpoint.BusyError{
+				Level:      wrp.QOSLow,
+				Load:       100,
+				RetryAfter: 3 * time.Second,
+			}),
+			code:       http.StatusServiceUnavailable,
+			retryAfter: "3",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+			w := httptest.NewRecorder()
+
+			ErrorEncoder(context.Background(), tc.err, w)
+
+			assert.Equal(tc.code, w.Code)
+			assert.Equal(tc.retryAfter, w.Header().Get("Retry-After"))
+			assert.Equal(tc.err.Error(), w.Body.String())
+		})
+	}
+}
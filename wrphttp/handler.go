@@ -8,6 +8,9 @@ import (
 	"net/http"
 
 	gokithttp "github.com/go-kit/kit/transport/http"
+	"github.com/xmidt-org/wrp-go/v3"
+	"github.com/xmidt-org/wrp-go/v3/wrpcrud"
+	"github.com/xmidt-org/wrp-go/v3/wrptrace"
 )
 
 type wrpHandler struct {
@@ -17,6 +20,12 @@ type wrpHandler struct {
 	before            []MessageFunc
 	decoder           Decoder
 	newResponseWriter ResponseWriterFunc
+	rdrStatusCodes    map[int64]int
+	headerAllow       headerAllowlist
+	conditional       bool
+	tracePropagator   *wrptrace.Propagator
+	middleware        []Middleware
+	clock             wrp.Clock
 }
 
 // Handler is a WRP handler for messages over HTTP.  This is the analog of http.Handler.
@@ -83,6 +92,20 @@ func WithAfter(funcs ...MessageFunc) Option {
 	}
 }
 
+// WithClock sets the clock of the time each request is received, which the
+// handler adds to the request's context with wrp.ContextWithReceived.  By
+// default, wrp.SystemClock is used.  If the supplied clock is nil, it reverts
+// to the default.
+func WithClock(c wrp.Clock) Option {
+	return func(wh *wrpHandler) {
+		if c != nil {
+			wh.clock = c
+		} else {
+			wh.clock = wrp.SystemClock
+		}
+	}
+}
+
 // NewHTTPHandler creates an http.Handler that forwards WRP requests to the supplied WRP handler.
 func NewHTTPHandler(h Handler, options ...Option) http.Handler {
 	if h == nil {
@@ -94,17 +117,21 @@ func NewHTTPHandler(h Handler, options ...Option) http.Handler {
 		errorEncoder:      gokithttp.DefaultErrorEncoder,
 		decoder:           DefaultDecoder(),
 		newResponseWriter: DefaultResponseWriterFunc(),
+		clock:             wrp.SystemClock,
 	}
 
 	for _, o := range options {
 		o(wh)
 	}
 
-	return wh
+	return wh.then(wh)
 }
 
 func (wh *wrpHandler) ServeHTTP(httpResponse http.ResponseWriter, httpRequest *http.Request) {
+	received := wh.clock.Now()
 	ctx := httpRequest.Context()
+	defer suspendErrorConversion(ctx)()
+
 	entity, err := wh.decoder(ctx, httpRequest)
 	if err != nil {
 		wrappedErr := httpError{
@@ -124,6 +151,24 @@ func (wh *wrpHandler) ServeHTTP(httpResponse http.ResponseWriter, httpRequest *h
 		return
 	}
 
+	// the body has been read, so any trailers are available; headers take
+	// precedence over trailers of the same name
+	fromHeader := wh.headerAllow.fromHTTP(&entity.Message, httpRequest.Header)
+	fromTrailer := wh.headerAllow.fromHTTP(&entity.Message, httpRequest.Trailer)
+	if fromHeader || fromTrailer {
+		entity.Bytes = nil
+	}
+
+	if wh.tracePropagator != nil && CollectTraceContext(&entity.Message, httpRequest.Header, *wh.tracePropagator) {
+		entity.Bytes = nil
+	}
+
+	if wh.conditional && setIfNoneMatch(&entity.Message, httpRequest.Header) {
+		entity.Bytes = nil
+	}
+
+	ctx = wrp.ContextWithReceived(ctx, received)
+	ctx = wrp.ContextWithMessage(ctx, &entity.Message)
 	for _, mf := range wh.before {
 		ctx = mf(ctx, &entity.Message)
 	}
@@ -140,5 +185,33 @@ func (wh *wrpHandler) ServeHTTP(httpResponse http.ResponseWriter, httpRequest *h
 		return
 	}
 
+	if wh.conditional {
+		wrpResponse = &conditionalResponseWriter{
+			ResponseWriter: wrpResponse,
+			ifNoneMatch:    entity.Message.Metadata[wrpcrud.IfNoneMatchKey],
+		}
+	}
+
+	if wh.rdrStatusCodes != nil {
+		wrpResponse = &rdrResponseWriter{
+			ResponseWriter: wrpResponse,
+			codes:          wh.rdrStatusCodes,
+		}
+	}
+
+	if wh.tracePropagator != nil {
+		wrpResponse = &traceResponseWriter{
+			ResponseWriter: wrpResponse,
+			propagator:     *wh.tracePropagator,
+		}
+	}
+
+	if len(wh.headerAllow) > 0 {
+		wrpResponse = &headerResponseWriter{
+			ResponseWriter: wrpResponse,
+			allow:          wh.headerAllow,
+		}
+	}
+
 	wh.handler.ServeWRP(wrpResponse, wrpRequest)
 }
@@ -12,6 +12,7 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	gokithttp "github.com/go-kit/kit/transport/http"
 	"github.com/stretchr/testify/assert"
@@ -206,6 +207,34 @@ func TestWithAfter(t *testing.T) {
 	}
 }
 
+func TestWithClock(t *testing.T) {
+	var (
+		assert   = assert.New(t)
+		received = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		called   bool
+	)
+
+	handler := HandlerFunc(func(_ ResponseWriter, r *Request) {
+		called = true
+		actual, ok := wrp.ReceivedFromContext(r.Context())
+		assert.True(ok)
+		assert.Equal(received, actual)
+	})
+
+	decoder := func(context.Context, *http.Request) (*Entity, error) {
+		return &Entity{Message: wrp.Message{Type: wrp.SimpleEventMessageType}}, nil
+	}
+
+	clock := wrp.ClockFunc(func() time.Time { return received })
+	NewHTTPHandler(handler, WithDecoder(decoder), WithClock(clock)).
+		ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/", nil))
+	assert.True(called)
+
+	wh := new(wrpHandler)
+	WithClock(nil)(wh)
+	assert.NotNil(wh.clock)
+}
+
 func TestNewHTTPHandler(t *testing.T) {
 	assert := assert.New(t)
 	assert.Panics(func() {
@@ -349,8 +378,12 @@ func testWRPHandlerResponseWriterError(t *testing.T) {
 		errorEncoderCalled = false
 		errorEncoder       = func(actualCtx context.Context, actualErr error, _ http.ResponseWriter) {
 			errorEncoderCalled = true
-			assert.Equal(expectedCtx, actualCtx)
+			assert.Equal("bar", actualCtx.Value(foo))
 			assert.Equal(expectedErr, actualErr)
+
+			msg, ok := wrp.MessageFromContext(actualCtx)
+			assert.True(ok)
+			assert.Equal(&expectedEntity.Message, msg)
 		}
 
 		wrpHandler  = new(MockHandler)
@@ -412,7 +445,10 @@ func testWRPHandlerSuccess(t *testing.T) {
 			return r != nil
 		}),
 		mock.MatchedBy(func(r *Request) bool {
-			return assert.Equal(wrp.Message{Type: wrp.SimpleEventMessageType, ContentType: "something"}, r.Entity.Message)
+			msg, ok := wrp.MessageFromContext(r.Context())
+			return assert.Equal(wrp.Message{Type: wrp.SimpleEventMessageType, ContentType: "something"}, r.Entity.Message) &&
+				assert.True(ok) &&
+				assert.Same(&r.Entity.Message, msg)
 		}),
 	).Once()
 
@@ -0,0 +1,239 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrphttp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/xmidt-org/httpaux"
+	"github.com/xmidt-org/httpaux/client"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// Middleware decorates an http.Handler.  It has the signature of the server
+// middleware of github.com/xmidt-org/httpaux, such as recovery.Middleware()
+// and busy.Server.Then, so those can be passed as is.
+type Middleware func(http.Handler) http.Handler
+
+// WithMiddleware decorates the http.Handler created by NewHTTPHandler with the
+// middleware.  The first middleware is the outermost, so it sees the HTTP
+// request first.  Use ConvertErrors as the first middleware to send the error
+// responses of the others as WRP messages.
+func WithMiddleware(m ...Middleware) Option {
+	return func(wh *wrpHandler) {
+		wh.middleware = append(wh.middleware, m...)
+	}
+}
+
+// then decorates next with the middleware.
+func (wh *wrpHandler) then(next http.Handler) http.Handler {
+	for i := len(wh.middleware) - 1; i >= 0; i-- {
+		if wh.middleware[i] != nil {
+			next = wh.middleware[i](next)
+		}
+	}
+
+	return next
+}
+
+type errorConverterKey struct{}
+
+// ConvertErrors returns Middleware that turns the error responses written by
+// the middleware it decorates, e.g. the 503 of busy.Server or the 500 of
+// recovery.Middleware, into WRP responses.  An error response is one with a
+// status of 400 or more whose Content-Type is not a WRP format.
+//
+// The WRP response is sent back to the Source of the request, with the
+// request's TransactionUUID, as given by the request's WRP headers.  Its
+// Status is the HTTP status, and a status of 500 or more also sets a
+// RequestDeliveryResponse of RDRHandlerFailure.  The body the middleware wrote
+// is dropped, as it may hold details such as a stack trace.  The response is
+// encoded in the format of the request's Accept header, or defaultFormat.
+//
+// Responses written by the handler created with NewHTTPHandler, including its
+// own error responses, are not converted.
+func ConvertErrors(defaultFormat wrp.Format) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ec := &errorConverter{
+				ResponseWriter: w,
+				request:        r,
+				defaultFormat:  defaultFormat,
+			}
+
+			ctx := context.WithValue(r.Context(), errorConverterKey{}, ec)
+			next.ServeHTTP(ec, r.WithContext(ctx))
+		})
+	}
+}
+
+// suspendErrorConversion stops the errorConverter in the context, if any,
+// from converting responses until the returned function is called.
+func suspendErrorConversion(ctx context.Context) func() {
+	ec, ok := ctx.Value(errorConverterKey{}).(*errorConverter)
+	if !ok {
+		return func() {}
+	}
+
+	ec.suspended.Add(1)
+	return func() {
+		ec.suspended.Add(-1)
+	}
+}
+
+// errorConverter is the http.ResponseWriter of ConvertErrors.
+type errorConverter struct {
+	http.ResponseWriter
+	request       *http.Request
+	defaultFormat wrp.Format
+	suspended     atomic.Int32
+	wroteHeader   bool
+	converted     bool
+}
+
+func (ec *errorConverter) WriteHeader(code int) {
+	if ec.wroteHeader {
+		return
+	}
+	ec.wroteHeader = true
+
+	if code < http.StatusBadRequest || ec.suspended.Load() > 0 || isWRPContentType(ec.Header().Get("Content-Type")) {
+		ec.ResponseWriter.WriteHeader(code)
+		return
+	}
+
+	ec.converted = true
+	ec.writeError(code)
+}
+
+func (ec *errorConverter) Write(b []byte) (int, error) {
+	if !ec.wroteHeader {
+		ec.WriteHeader(http.StatusOK)
+	}
+
+	if ec.converted {
+		return len(b), nil
+	}
+
+	return ec.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the decorated http.ResponseWriter, for http.ResponseController.
+func (ec *errorConverter) Unwrap() http.ResponseWriter {
+	return ec.ResponseWriter
+}
+
+func (ec *errorConverter) writeError(code int) {
+	f, err := DetermineFormat(ec.defaultFormat, ec.request.Header, "Accept")
+	if err != nil {
+		f = ec.defaultFormat
+	}
+
+	h := ec.request.Header
+	msg := wrp.Message{
+		Type:            wrp.SimpleRequestResponseMessageType,
+		Source:          h.Get(DestinationHeader),
+		Destination:     h.Get(SourceHeader),
+		TransactionUUID: h.Get(TransactionUuidHeader),
+	}
+	msg.SetStatus(int64(code))
+	if code >= http.StatusInternalServerError {
+		msg.SetRequestDeliveryResponse(RDRHandlerFailure)
+	}
+
+	header := ec.Header()
+	header.Del("Content-Length")
+	header.Set("Content-Type", f.ContentType())
+	ec.ResponseWriter.WriteHeader(code)
+	_, _ = encodeTo(ec.ResponseWriter, f, &msg)
+}
+
+func isWRPContentType(v string) bool {
+	_, err := wrp.ParseMediaTypes(v)
+	return err == nil
+}
+
+// ResponseError is the error returned by the client middleware of
+// ConvertResponseErrors for an error response that carries a WRP message.  It
+// implements the StatusCode and Headers methods used by httpaux and go-kit.
+type ResponseError struct {
+	// Code is the HTTP status of the response.
+	Code int
+
+	// Header is the header of the response.
+	Header http.Header
+
+	// Message is the WRP message of the response, or nil if it could not be
+	// decoded.
+	Message *wrp.Message
+
+	// Err is the error decoding the message, if any.
+	Err error
+}
+
+func (e *ResponseError) Error() string {
+	switch {
+	case e.Err != nil:
+		return fmt.Sprintf("WRP error response with status %d: %v", e.Code, e.Err)
+	case e.Message.Status != nil:
+		return fmt.Sprintf("WRP error response with status %d, message status %d", e.Code, *e.Message.Status)
+	}
+
+	return fmt.Sprintf("WRP error response with status %d", e.Code)
+}
+
+func (e *ResponseError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode returns the HTTP status of the response.
+func (e *ResponseError) StatusCode() int {
+	return e.Code
+}
+
+// Headers returns the header of the response.
+func (e *ResponseError) Headers() http.Header {
+	return e.Header
+}
+
+// ConvertResponseErrors returns an httpaux client.Constructor that turns error
+// responses, i.e. those with a status of 400 or more, that carry a WRP message
+// into a *ResponseError, e.g. those written by a server using ConvertErrors.
+// The body of such a response is read and closed.  Other responses, and the
+// errors of the decorated client, are returned unchanged.
+//
+// Use it with client.NewChain, along with the other httpaux client
+// middleware, to decorate the HTTP client of a WRP client.
+func ConvertResponseErrors() client.Constructor {
+	return func(next httpaux.Client) httpaux.Client {
+		return client.Func(func(request *http.Request) (*http.Response, error) {
+			response, err := next.Do(request)
+			if err != nil || response.StatusCode < http.StatusBadRequest {
+				return response, err
+			}
+
+			f, ferr := wrp.ParseMediaTypes(response.Header.Get("Content-Type"))
+			if ferr != nil {
+				return response, nil
+			}
+
+			defer httpaux.Cleanup(response)
+
+			re := ResponseError{
+				Code:   response.StatusCode,
+				Header: response.Header,
+			}
+
+			var msg wrp.Message
+			if re.Err = wrp.NewDecoder(response.Body, f).Decode(&msg); re.Err == nil {
+				re.Message = &msg
+			}
+
+			return nil, &re
+		})
+	}
+}
@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrphttp
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/httpaux/busy"
+	"github.com/xmidt-org/httpaux/client"
+	"github.com/xmidt-org/httpaux/recovery"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// busyLimiter is a busy.Limiter that rejects every request.
+type busyLimiter struct{}
+
+func (busyLimiter) Check(*http.Request) (busy.RequestDone, bool) {
+	return busy.NopRequestDone, false
+}
+
+func TestWithMiddleware(t *testing.T) {
+	request := wrp.Message{
+		Type:            wrp.SimpleRequestResponseMessageType,
+		Source:          "dns:example.com",
+		Destination:     "mac:112233445566/config",
+		TransactionUUID: "1234",
+	}
+
+	tests := []struct {
+		desc         string
+		middleware   []Middleware
+		handler      HandlerFunc
+		body         []byte
+		accept       string
+		expectedCode int
+		expected     *wrp.Message
+	}{
+		{
+			desc:       "success",
+			middleware: []Middleware{ConvertErrors(wrp.Msgpack), recovery.Middleware()},
+			handler: func(w ResponseWriter, _ *Request) {
+				w.WriteHeader(http.StatusAccepted)
+			},
+			expectedCode: http.StatusAccepted,
+		}, {
+			desc:         "busy",
+			middleware:   []Middleware{ConvertErrors(wrp.Msgpack), busy.Server{Limiter: busyLimiter{}}.Then},
+			expectedCode: http.StatusServiceUnavailable,
+			expected: (&wrp.Message{
+				Type:            wrp.SimpleRequestResponseMessageType,
+				Source:          "mac:112233445566/config",
+				Destination:     "dns:example.com",
+				TransactionUUID: "1234",
+			}).SetStatus(http.StatusServiceUnavailable).SetRequestDeliveryResponse(RDRHandlerFailure),
+		}, {
+			desc:       "recovered panic",
+			middleware: []Middleware{ConvertErrors(wrp.Msgpack), recovery.Middleware()},
+			handler: func(ResponseWriter, *Request) {
+				panic("boom")
+			},
+			accept:       wrp.JSON.ContentType(),
+			expectedCode: http.StatusInternalServerError,
+			expected: (&wrp.Message{
+				Type:            wrp.SimpleRequestResponseMessageType,
+				Source:          "mac:112233445566/config",
+				Destination:     "dns:example.com",
+				TransactionUUID: "1234",
+			}).SetStatus(http.StatusInternalServerError).SetRequestDeliveryResponse(RDRHandlerFailure),
+		}, {
+			desc: "client error",
+			middleware: []Middleware{ConvertErrors(wrp.Msgpack), func(http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					http.Error(w, "forbidden", http.StatusForbidden)
+				})
+			}},
+			expectedCode: http.StatusForbidden,
+			expected: (&wrp.Message{
+				Type:            wrp.SimpleRequestResponseMessageType,
+				Source:          "mac:112233445566/config",
+				Destination:     "dns:example.com",
+				TransactionUUID: "1234",
+			}).SetStatus(http.StatusForbidden),
+		}, {
+			desc:         "handler errors are not converted",
+			middleware:   []Middleware{ConvertErrors(wrp.Msgpack)},
+			body:         []byte("not a WRP message"),
+			expectedCode: http.StatusBadRequest,
+		}, {
+			desc:         "without ConvertErrors",
+			middleware:   []Middleware{busy.Server{Limiter: busyLimiter{}}.Then, nil},
+			expectedCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			handler := tc.handler
+			if handler == nil {
+				handler = func(w ResponseWriter, _ *Request) {
+					t.Error("the handler should not be called")
+				}
+			}
+
+			body := tc.body
+			if body == nil {
+				body = wrp.MustEncode(&request, wrp.Msgpack)
+			}
+
+			httpRequest := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+			httpRequest.Header.Set("Content-Type", wrp.Msgpack.ContentType())
+			AddMessageHeaders(httpRequest.Header, &request)
+			if tc.accept != "" {
+				httpRequest.Header.Set("Accept", tc.accept)
+			}
+
+			httpResponse := httptest.NewRecorder()
+			NewHTTPHandler(handler, WithMiddleware(tc.middleware...)).
+				ServeHTTP(httpResponse, httpRequest)
+
+			assert.Equal(tc.expectedCode, httpResponse.Code)
+			if tc.expected == nil {
+				assert.False(isWRPContentType(httpResponse.Header().Get("Content-Type")))
+				return
+			}
+
+			f, err := wrp.ParseMediaTypes(httpResponse.Header().Get("Content-Type"))
+			require.NoError(err)
+			if tc.accept != "" {
+				assert.Equal(wrp.JSON, f)
+			}
+
+			var actual wrp.Message
+			require.NoError(wrp.NewDecoderBytes(httpResponse.Body.Bytes(), f).Decode(&actual))
+			assert.Equal(*tc.expected, actual)
+		})
+	}
+}
+
+func TestConvertResponseErrors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/busy", NewHTTPHandler(
+		HandlerFunc(func(ResponseWriter, *Request) {}),
+		WithMiddleware(ConvertErrors(wrp.JSON), busy.Server{Limiter: busyLimiter{}}.Then),
+	))
+	mux.HandleFunc("/text", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	})
+	mux.HandleFunc("/invalid", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", wrp.Msgpack.ContentType())
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte{0xc1})
+	})
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c := client.NewChain(ConvertResponseErrors()).Then(server.Client())
+	do := func(path string) (*http.Response, error) {
+		request, err := http.NewRequestWithContext(context.Background(), "GET", server.URL+path, nil)
+		require.NoError(t, err)
+
+		return c.Do(request)
+	}
+
+	t.Run("WRP error", func(t *testing.T) {
+		response, err := do("/busy")
+		assert.Nil(t, response)
+
+		var re *ResponseError
+		require.ErrorAs(t, err, &re)
+		assert.Equal(t, http.StatusServiceUnavailable, re.StatusCode())
+		assert.Equal(t, wrp.JSON.ContentType(), re.Headers().Get("Content-Type"))
+		require.NotNil(t, re.Message)
+		require.NotNil(t, re.Message.Status)
+		assert.Equal(t, int64(http.StatusServiceUnavailable), *re.Message.Status)
+		assert.NoError(t, re.Unwrap())
+		assert.Contains(t, err.Error(), "503")
+	})
+
+	t.Run("invalid WRP error", func(t *testing.T) {
+		response, err := do("/invalid")
+		assert.Nil(t, response)
+
+		var re *ResponseError
+		require.ErrorAs(t, err, &re)
+		assert.Equal(t, http.StatusBadGateway, re.Code)
+		assert.Nil(t, re.Message)
+		assert.Error(t, re.Unwrap())
+	})
+
+	for _, path := range []string{"/text", "/ok"} {
+		t.Run(path, func(t *testing.T) {
+			response, err := do(path)
+			require.NoError(t, err)
+			response.Body.Close()
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrphttp
+
+import (
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// reservedHeaders are never projected, since they describe the HTTP message
+// itself rather than the WRP payload.
+var reservedHeaders = map[string]bool{
+	"Connection":        true,
+	"Content-Length":    true,
+	"Content-Type":      true,
+	"Host":              true,
+	"Keep-Alive":        true,
+	"Te":                true,
+	"Trailer":           true,
+	"Transfer-Encoding": true,
+	"Upgrade":           true,
+	DestinationHeader:   true,
+}
+
+// headerAllowlist is the set of canonical header names that are projected
+// between the WRP Headers field and HTTP headers.
+type headerAllowlist map[string]bool
+
+func newHeaderAllowlist(names []string) headerAllowlist {
+	allow := make(headerAllowlist, len(names))
+	for _, name := range names {
+		name = http.CanonicalHeaderKey(strings.TrimSpace(name))
+		if name == "" || reservedHeaders[name] ||
+			strings.HasPrefix(name, "X-Xmidt-") || strings.HasPrefix(name, "X-Midt-") {
+			continue
+		}
+		allow[name] = true
+	}
+
+	return allow
+}
+
+// toHTTP sets the allowed WRP headers of the message on dst.  Headers that are
+// already set on dst are kept.
+func (a headerAllowlist) toHTTP(dst http.Header, m *wrp.Message) {
+	if len(a) == 0 {
+		return
+	}
+
+	for k, values := range m.ToHTTPHeader() {
+		if a[k] && len(dst.Values(k)) == 0 {
+			dst[k] = values
+		}
+	}
+}
+
+// fromHTTP adds the allowed headers of src to the WRP headers of the message
+// and reports whether any were added.  Headers that the message already has
+// are kept.  Headers is replaced, not modified.
+func (a headerAllowlist) fromHTTP(m *wrp.Message, src http.Header) bool {
+	if len(a) == 0 {
+		return false
+	}
+
+	var add []string
+	for k := range a {
+		if _, ok := m.GetHeader(k); ok {
+			continue
+		}
+		for _, v := range src.Values(k) {
+			add = append(add, k+": "+v)
+		}
+	}
+
+	if len(add) == 0 {
+		return false
+	}
+
+	slices.Sort(add)
+	m.Headers = append(slices.Clip(m.Headers), add...)
+	return true
+}
+
+// ProjectWRPHeaders sets the WRP Headers of the message named in allow as
+// HTTP headers on dst, e.g. on an outgoing request.  Headers already present in
+// dst are not overwritten.  Names are canonicalized, and the names of the
+// X-Xmidt-* headers and of headers that describe the HTTP message itself, such
+// as Content-Type or Transfer-Encoding, are ignored.
+func ProjectWRPHeaders(dst http.Header, m *wrp.Message, allow ...string) {
+	newHeaderAllowlist(allow).toHTTP(dst, m)
+}
+
+// CollectWRPHeaders is the reverse of ProjectWRPHeaders.  The HTTP headers of
+// src named in allow are added to the WRP Headers of the message, e.g. from an
+// incoming response.  Headers that the message already has are kept, and
+// Headers is replaced rather than modified.
+//
+// Trailers are collected the same way, by passing them as src once the body
+// has been read to the end, e.g. resp.Trailer after resp.Header.  Since the
+// headers are collected first, they take precedence over trailers of the same
+// name.
+func CollectWRPHeaders(m *wrp.Message, src http.Header, allow ...string) {
+	newHeaderAllowlist(allow).fromHTTP(m, src)
+}
+
+// WithHeaderProjection configures the handler to project the WRP Headers field
+// to and from HTTP headers, so custom device headers propagate end to end
+// through the HTTP edge.  The allowed HTTP headers of a request are added to
+// the Headers of its WRP message before the wrp.Handler is called, in which
+// case the entity's Bytes are cleared since they no longer match, and the
+// allowed Headers of a WRP response written with WriteWRP are set as HTTP
+// response headers.  See ProjectWRPHeaders for how names are handled.
+//
+// The allowed trailers of a request are added too, once the request body has
+// been decoded, although a header takes precedence over a trailer of the same
+// name.  Response Headers are always set as HTTP headers rather than trailers,
+// since the whole WRP response is known before its body is written.
+//
+// Responses written with WriteWRPBytes are not decoded, so their Headers are
+// not projected.  By default, the handler does not project headers.
+func WithHeaderProjection(allow ...string) Option {
+	return func(wh *wrpHandler) {
+		wh.headerAllow = newHeaderAllowlist(allow)
+	}
+}
+
+// headerResponseWriter is a decorator that projects the Headers of a WRP
+// response onto the HTTP response.
+type headerResponseWriter struct {
+	ResponseWriter
+	allow headerAllowlist
+}
+
+func (rw *headerResponseWriter) WriteWRP(e *Entity) (int, error) {
+	rw.allow.toHTTP(rw.Header(), &e.Message)
+	return rw.ResponseWriter.WriteWRP(e)
+}
+
+// ReadFrom writes the contents of r as is, in the same way as Write.
+func (rw *headerResponseWriter) ReadFrom(r io.Reader) (int64, error) {
+	return io.Copy(rw.ResponseWriter, r)
+}
@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrphttp
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestProjectWRPHeaders(t *testing.T) {
+	tests := []struct {
+		desc     string
+		headers  []string
+		allow    []string
+		existing http.Header
+		expected http.Header
+	}{
+		{
+			desc:     "nothing allowed",
+			headers:  []string{"X-Custom: 1"},
+			expected: http.Header{},
+		}, {
+			desc:     "allowed headers",
+			headers:  []string{"x-custom: 1", "X-Other: 2", "X-Custom: 3", "raw"},
+			allow:    []string{" x-custom ", "X-Missing"},
+			expected: http.Header{"X-Custom": {"1", "3"}},
+		}, {
+			desc:     "existing headers are kept",
+			headers:  []string{"X-Custom: 1", "X-Other: 2"},
+			allow:    []string{"X-Custom", "X-Other"},
+			existing: http.Header{"X-Custom": {"http"}},
+			expected: http.Header{"X-Custom": {"http"}, "X-Other": {"2"}},
+		}, {
+			desc:     "reserved headers are never projected",
+			headers:  []string{"Content-Length: 1", "X-Xmidt-Source: dns:evil", "X-Webpa-Device-Name: mac:112233445566", "X-Custom: 1"},
+			allow:    []string{"Content-Length", "X-Xmidt-Source", "X-Webpa-Device-Name", "X-Custom", ""},
+			expected: http.Header{"X-Custom": {"1"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			h := tc.existing
+			if h == nil {
+				h = http.Header{}
+			}
+
+			ProjectWRPHeaders(h, &wrp.Message{Headers: tc.headers}, tc.allow...)
+			assert.Equal(t, tc.expected, h)
+		})
+	}
+}
+
+func TestCollectWRPHeaders(t *testing.T) {
+	tests := []struct {
+		desc     string
+		headers  []string
+		allow    []string
+		src      http.Header
+		expected []string
+	}{
+		{
+			desc:     "nothing allowed",
+			headers:  []string{"A: 1"},
+			src:      http.Header{"X-Custom": {"1"}},
+			expected: []string{"A: 1"},
+		}, {
+			desc:     "allowed headers",
+			allow:    []string{"x-custom", "X-Second"},
+			src:      http.Header{"X-Custom": {"1", "2"}, "X-Second": {"3"}, "X-Other": {"4"}},
+			expected: []string{"X-Custom: 1", "X-Custom: 2", "X-Second: 3"},
+		}, {
+			desc:     "message headers are kept",
+			headers:  []string{"x-custom: wrp"},
+			allow:    []string{"X-Custom", "X-Second"},
+			src:      http.Header{"X-Custom": {"http"}, "X-Second": {"3"}},
+			expected: []string{"x-custom: wrp", "X-Second: 3"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			// spare capacity must not be used, since copies share it
+			original := make([]string, len(tc.headers), len(tc.headers)+4)
+			copy(original, tc.headers)
+
+			msg := wrp.Message{Headers: original}
+			CollectWRPHeaders(&msg, tc.src, tc.allow...)
+			assert.Equal(t, tc.expected, msg.Headers)
+			assert.Empty(t, original[:cap(original)][len(tc.headers)], "copies must not be affected")
+		})
+	}
+}
+
+func TestWithHeaderProjection(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var received *Request
+	h := NewHTTPHandler(
+		HandlerFunc(func(w ResponseWriter, r *Request) {
+			received = r
+			response := r.Entity.Message
+			response.Headers = []string{"X-Custom: from-device", "X-Private: secret"}
+			_, err := w.WriteWRP(&Entity{Message: response})
+			assert.NoError(err)
+		}),
+		WithHeaderProjection("X-Custom", "X-Trace"),
+	)
+
+	var body []byte
+	require.NoError(wrp.NewEncoderBytes(&body, wrp.Msgpack).Encode(&wrp.Message{
+		Type:        wrp.SimpleEventMessageType,
+		Source:      "dns:example.com",
+		Destination: "event:test",
+		Headers:     []string{"X-Existing: 1"},
+	}))
+
+	request := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	request.Header.Set("Content-Type", wrp.MimeTypeMsgpack)
+	request.Header.Set("X-Trace", "abc")
+	request.Header.Set("X-Private", "not allowed")
+
+	response := httptest.NewRecorder()
+	h.ServeHTTP(response, request)
+	require.Equal(http.StatusOK, response.Code)
+
+	require.NotNil(received)
+	assert.Equal([]string{"X-Existing: 1", "X-Trace: abc"}, received.Entity.Message.Headers)
+	assert.Nil(received.Entity.Bytes, "the original bytes no longer match the message")
+
+	assert.Equal("from-device", response.Header().Get("X-Custom"))
+	assert.Empty(response.Header().Get("X-Private"))
+}
+
+func TestWithHeaderProjection_trailers(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var received *Request
+	h := NewHTTPHandler(
+		HandlerFunc(func(w ResponseWriter, r *Request) {
+			received = r
+			w.WriteHeader(http.StatusOK)
+		}),
+		WithHeaderProjection("X-Custom", "X-Trace"),
+	)
+
+	var body []byte
+	require.NoError(wrp.NewEncoderBytes(&body, wrp.Msgpack).Encode(&wrp.Message{
+		Type:        wrp.SimpleEventMessageType,
+		Source:      "dns:example.com",
+		Destination: "event:test",
+	}))
+
+	request := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	request.Header.Set("Content-Type", wrp.MimeTypeMsgpack)
+	request.Header.Set("X-Trace", "from-header")
+	request.Trailer = http.Header{
+		"X-Custom":  {"from-trailer"},
+		"X-Trace":   {"ignored"},
+		"X-Private": {"not allowed"},
+	}
+
+	response := httptest.NewRecorder()
+	h.ServeHTTP(response, request)
+	require.Equal(http.StatusOK, response.Code)
+
+	require.NotNil(received)
+	assert.Equal([]string{"X-Trace: from-header", "X-Custom: from-trailer"}, received.Entity.Message.Headers)
+	assert.Nil(received.Entity.Bytes, "the original bytes no longer match the message")
+}
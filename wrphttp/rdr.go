@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrphttp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// Request delivery response (RDR) values reported by the XMiDT cluster when a
+// message could not be delivered to a device.
+const (
+	// RDRDelivered indicates the message was delivered.
+	RDRDelivered int64 = 0
+
+	// RDRDeviceOffline indicates the device was not connected.
+	RDRDeviceOffline int64 = 1
+
+	// RDRTimeout indicates the device did not respond in time.
+	RDRTimeout int64 = 2
+)
+
+// Request delivery response values private to this package.  They are not
+// defined by the WRP specification, and describe failures of the wrphttp
+// handler itself rather than of the delivery to a device.  They start at
+// RDRPrivateBase so that they never collide with specification values, and
+// the responses that carry them also have an HTTP Status, so peers that do
+// not know them can still tell what happened.
+const (
+	// RDRPrivateBase is the first private value.
+	RDRPrivateBase int64 = 1000
+
+	// RDRHandlerFailure indicates the message could not be handled because of
+	// an internal failure, e.g. a panic recovered by WithRecovery.
+	RDRHandlerFailure = RDRPrivateBase
+
+	// RDRShuttingDown indicates the message was rejected or abandoned because
+	// the handler was shutting down, e.g. by a Drainer.
+	RDRShuttingDown = RDRPrivateBase + 1
+)
+
+// DefaultRDRStatusCodes returns the mapping used by WithRDRErrors when no
+// mapping is supplied.  A new map is returned each time, so callers may modify
+// it freely.
+func DefaultRDRStatusCodes() map[int64]int {
+	return map[int64]int{
+		RDRDeviceOffline: http.StatusNotFound,
+		RDRTimeout:       http.StatusGatewayTimeout,
+	}
+}
+
+// WithRDRErrors configures the handler to translate responses that carry a
+// delivery failure RDR into an HTTP error.  When the wrp.Handler writes a
+// response whose RequestDeliveryResponse is a key in codes, the corresponding
+// HTTP status and a JSON error body are written instead of the WRP message.
+// Responses with any other RDR, or none at all, are written as usual.
+//
+// If codes is nil, DefaultRDRStatusCodes() is used.  By default, the handler
+// does not translate RDRs.
+func WithRDRErrors(codes map[int64]int) Option {
+	return func(wh *wrpHandler) {
+		if codes == nil {
+			codes = DefaultRDRStatusCodes()
+		}
+		wh.rdrStatusCodes = codes
+	}
+}
+
+// RDRError is the JSON body written for a delivery failure RDR.
+type RDRError struct {
+	// Code is the HTTP status code of the response.
+	Code int `json:"code"`
+
+	// Message is a human readable description of the failure.
+	Message string `json:"message"`
+
+	// RDR is the request delivery response that caused the failure.
+	RDR int64 `json:"rdr"`
+
+	// TransactionUUID is the transaction of the failed message, if any.
+	TransactionUUID string `json:"transaction_uuid,omitempty"`
+}
+
+// rdrResponseWriter is a decorator that writes an RDRError in place of any
+// WRP response with a delivery failure RDR.
+type rdrResponseWriter struct {
+	ResponseWriter
+	codes map[int64]int
+}
+
+func (rw *rdrResponseWriter) WriteWRP(e *Entity) (int, error) {
+	if code, ok := rw.statusCode(&e.Message); ok {
+		return rw.writeRDRError(code, &e.Message)
+	}
+
+	return rw.ResponseWriter.WriteWRP(e)
+}
+
+func (rw *rdrResponseWriter) WriteWRPBytes(f wrp.Format, encodedWRP []byte) (int, error) {
+	var msg wrp.Message
+	if len(encodedWRP) > 0 && wrp.NewDecoderBytes(encodedWRP, f).Decode(&msg) == nil {
+		if code, ok := rw.statusCode(&msg); ok {
+			return rw.writeRDRError(code, &msg)
+		}
+	}
+
+	return rw.ResponseWriter.WriteWRPBytes(f, encodedWRP)
+}
+
+// ReadFrom writes the contents of r as is, in the same way as Write.
+func (rw *rdrResponseWriter) ReadFrom(r io.Reader) (int64, error) {
+	return io.Copy(rw.ResponseWriter, r)
+}
+
+// statusCode returns the HTTP status for the message's RDR, if it is a
+// delivery failure.
+func (rw *rdrResponseWriter) statusCode(msg *wrp.Message) (int, bool) {
+	if msg.RequestDeliveryResponse == nil {
+		return 0, false
+	}
+
+	code, ok := rw.codes[*msg.RequestDeliveryResponse]
+	return code, ok
+}
+
+func (rw *rdrResponseWriter) writeRDRError(code int, msg *wrp.Message) (int, error) {
+	body, err := json.Marshal(RDRError{
+		Code:            code,
+		Message:         http.StatusText(code),
+		RDR:             *msg.RequestDeliveryResponse,
+		TransactionUUID: msg.TransactionUUID,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rw.Header().Set("Content-Type", wrp.MimeTypeJson)
+	rw.WriteHeader(code)
+	return rw.Write(body)
+}
@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrphttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestWithRDRErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	wh := new(wrpHandler)
+	WithRDRErrors(nil)(wh)
+	assert.Equal(DefaultRDRStatusCodes(), wh.rdrStatusCodes)
+
+	custom := map[int64]int{7: http.StatusServiceUnavailable}
+	WithRDRErrors(custom)(wh)
+	assert.Equal(custom, wh.rdrStatusCodes)
+}
+
+func TestPrivateRDRs(t *testing.T) {
+	for _, rdr := range []int64{RDRHandlerFailure, RDRShuttingDown} {
+		assert.GreaterOrEqual(t, rdr, RDRPrivateBase)
+	}
+}
+
+func TestRDRErrors(t *testing.T) {
+	rdr := func(v int64) *int64 { return &v }
+
+	tests := []struct {
+		desc         string
+		opts         []Option
+		rdr          *int64
+		useBytes     bool
+		expectedCode int
+		expectedBody *RDRError
+	}{
+		{
+			desc:         "disabled",
+			rdr:          rdr(RDRDeviceOffline),
+			expectedCode: http.StatusOK,
+		}, {
+			desc:         "no rdr",
+			opts:         []Option{WithRDRErrors(nil)},
+			expectedCode: http.StatusOK,
+		}, {
+			desc:         "delivered",
+			opts:         []Option{WithRDRErrors(nil)},
+			rdr:          rdr(RDRDelivered),
+			expectedCode: http.StatusOK,
+		}, {
+			desc:         "device offline",
+			opts:         []Option{WithRDRErrors(nil)},
+			rdr:          rdr(RDRDeviceOffline),
+			expectedCode: http.StatusNotFound,
+			expectedBody: &RDRError{
+				Code:            http.StatusNotFound,
+				Message:         "Not Found",
+				RDR:             RDRDeviceOffline,
+				TransactionUUID: "1234",
+			},
+		}, {
+			desc:         "timeout",
+			opts:         []Option{WithRDRErrors(nil)},
+			rdr:          rdr(RDRTimeout),
+			useBytes:     true,
+			expectedCode: http.StatusGatewayTimeout,
+			expectedBody: &RDRError{
+				Code:            http.StatusGatewayTimeout,
+				Message:         "Gateway Timeout",
+				RDR:             RDRTimeout,
+				TransactionUUID: "1234",
+			},
+		}, {
+			desc:         "custom mapping",
+			opts:         []Option{WithRDRErrors(map[int64]int{RDRTimeout: http.StatusServiceUnavailable})},
+			rdr:          rdr(RDRDeviceOffline),
+			useBytes:     true,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			response := wrp.Message{
+				Type:                    wrp.SimpleRequestResponseMessageType,
+				TransactionUUID:         "1234",
+				RequestDeliveryResponse: tc.rdr,
+			}
+
+			handler := HandlerFunc(func(w ResponseWriter, _ *Request) {
+				var err error
+				if tc.useBytes {
+					_, err = w.WriteWRPBytes(wrp.Msgpack, wrp.MustEncode(&response, wrp.Msgpack))
+				} else {
+					_, err = w.WriteWRP(&Entity{Message: response})
+				}
+				assert.NoError(err)
+			})
+
+			decoder := func(context.Context, *http.Request) (*Entity, error) {
+				return &Entity{Message: wrp.Message{Type: wrp.SimpleEventMessageType}}, nil
+			}
+
+			opts := append([]Option{
+				WithDecoder(decoder),
+				WithNewResponseWriter(NewEntityResponseWriter(wrp.Msgpack)),
+			}, tc.opts...)
+
+			httpResponse := httptest.NewRecorder()
+			NewHTTPHandler(handler, opts...).ServeHTTP(httpResponse, httptest.NewRequest("POST", "/", nil))
+
+			assert.Equal(tc.expectedCode, httpResponse.Code)
+			if tc.expectedBody == nil {
+				assert.Equal(wrp.Msgpack.ContentType(), httpResponse.Header().Get("Content-Type"))
+				return
+			}
+
+			assert.Equal("application/json", httpResponse.Header().Get("Content-Type"))
+
+			var actual RDRError
+			require.NoError(json.Unmarshal(httpResponse.Body.Bytes(), &actual))
+			assert.Equal(*tc.expectedBody, actual)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrphttp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// PanicFunc is called with the value recovered from a panic in a Handler and
+// the stack of the panicking goroutine, e.g. to report the crash.
+type PanicFunc func(ctx context.Context, r *Request, recovered interface{}, stack []byte)
+
+// WithRecovery configures the handler to recover panics in the WRP Handler,
+// see NewRecoveryHandler.  The onPanic function may be nil.
+func WithRecovery(onPanic PanicFunc) Option {
+	return func(wh *wrpHandler) {
+		wh.handler = NewRecoveryHandler(wh.handler, onPanic)
+	}
+}
+
+// NewRecoveryHandler decorates a Handler so that a panic results in a well
+// formed WRP response instead of an empty HTTP 500.  The response is sent back
+// to the request's Source with the request's type and TransactionUUID, a
+// Status of http.StatusInternalServerError and a RequestDeliveryResponse of
+// RDRHandlerFailure.  It is written with an HTTP status of
+// http.StatusInternalServerError.
+//
+// If onPanic is not nil, it is called before the response is written.  If the
+// Handler had already started writing a response, nothing more is written.  A
+// panic with http.ErrAbortHandler is not recovered, so that the HTTP server
+// can abort the response.
+func NewRecoveryHandler(next Handler, onPanic PanicFunc) Handler {
+	return HandlerFunc(func(w ResponseWriter, r *Request) {
+		tw := &trackingResponseWriter{ResponseWriter: w}
+
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			if recovered == http.ErrAbortHandler { // nolint:errorlint
+				panic(recovered)
+			}
+
+			if onPanic != nil {
+				onPanic(r.Context(), r, recovered, debug.Stack())
+			}
+
+			if !tw.written {
+				writeFailureResponse(w, r, http.StatusInternalServerError, RDRHandlerFailure)
+			}
+		}()
+
+		next.ServeWRP(tw, r)
+	})
+}
+
+// failureResponse returns the response message for a request that failed
+// with the given status and RDR.
+func failureResponse(r *Request, status, rdr int64) *wrp.Message {
+	msg := wrp.Message{
+		Type: wrp.SimpleRequestResponseMessageType,
+	}
+
+	if r.Entity != nil {
+		msg.Type = r.Entity.Message.Type
+		msg.Source = r.Entity.Message.Destination
+		msg.Destination = r.Entity.Message.Source
+		msg.TransactionUUID = r.Entity.Message.TransactionUUID
+	}
+
+	return msg.
+		SetStatus(status).
+		SetRequestDeliveryResponse(rdr)
+}
+
+// writeFailureResponse writes the failure response with the status as the
+// HTTP status.
+func writeFailureResponse(w ResponseWriter, r *Request, status int, rdr int64) {
+	w.Header().Set("Content-Type", w.WRPFormat().ContentType())
+	w.WriteHeader(status)
+	_, _ = w.WriteWRP(&Entity{Message: *failureResponse(r, int64(status), rdr)})
+}
+
+// trackingResponseWriter records whether anything has been written.
+type trackingResponseWriter struct {
+	ResponseWriter
+	written bool
+}
+
+func (tw *trackingResponseWriter) WriteHeader(code int) {
+	tw.written = true
+	tw.ResponseWriter.WriteHeader(code)
+}
+
+func (tw *trackingResponseWriter) Write(b []byte) (int, error) {
+	tw.written = true
+	return tw.ResponseWriter.Write(b)
+}
+
+func (tw *trackingResponseWriter) WriteWRP(e *Entity) (int, error) {
+	tw.written = true
+	return tw.ResponseWriter.WriteWRP(e)
+}
+
+func (tw *trackingResponseWriter) WriteWRPBytes(f wrp.Format, encodedWRP []byte) (int, error) {
+	tw.written = true
+	return tw.ResponseWriter.WriteWRPBytes(f, encodedWRP)
+}
+
+func (tw *trackingResponseWriter) ReadFrom(r io.Reader) (int64, error) {
+	tw.written = true
+	return io.Copy(tw.ResponseWriter, r)
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrphttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestWithRecovery(t *testing.T) {
+	tests := []struct {
+		desc         string
+		handler      HandlerFunc
+		expectedCode int
+		expectPanic  bool
+	}{
+		{
+			desc: "no panic",
+			handler: func(w ResponseWriter, _ *Request) {
+				w.WriteHeader(http.StatusAccepted)
+			},
+			expectedCode: http.StatusAccepted,
+		}, {
+			desc: "panic",
+			handler: func(ResponseWriter, *Request) {
+				panic("boom")
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectPanic:  true,
+		}, {
+			desc: "panic after writing",
+			handler: func(w ResponseWriter, _ *Request) {
+				w.WriteHeader(http.StatusAccepted)
+				panic("boom")
+			},
+			expectedCode: http.StatusAccepted,
+			expectPanic:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			request := wrp.Message{
+				Type:            wrp.SimpleRequestResponseMessageType,
+				Source:          "dns:example.com",
+				Destination:     "mac:112233445566/config",
+				TransactionUUID: "1234",
+			}
+
+			decoder := func(context.Context, *http.Request) (*Entity, error) {
+				return &Entity{Message: request}, nil
+			}
+
+			var (
+				reported  interface{}
+				stack     []byte
+				onRequest *Request
+			)
+			onPanic := func(_ context.Context, r *Request, recovered interface{}, s []byte) {
+				reported, stack, onRequest = recovered, s, r
+			}
+
+			httpResponse := httptest.NewRecorder()
+			NewHTTPHandler(tc.handler, WithDecoder(decoder), WithRecovery(onPanic)).
+				ServeHTTP(httpResponse, httptest.NewRequest("POST", "/", nil))
+
+			assert.Equal(tc.expectedCode, httpResponse.Code)
+			if !tc.expectPanic {
+				assert.Nil(reported)
+				return
+			}
+
+			assert.Equal("boom", reported)
+			assert.Contains(string(stack), "recovery_test.go")
+			require.NotNil(onRequest)
+			assert.Equal(request, onRequest.Entity.Message)
+
+			if tc.expectedCode != http.StatusInternalServerError {
+				assert.Zero(httpResponse.Body.Len())
+				return
+			}
+
+			assert.Equal(wrp.Msgpack.ContentType(), httpResponse.Header().Get("Content-Type"))
+
+			var actual wrp.Message
+			require.NoError(wrp.NewDecoderBytes(httpResponse.Body.Bytes(), wrp.Msgpack).Decode(&actual))
+
+			expected := wrp.Message{
+				Type:            wrp.SimpleRequestResponseMessageType,
+				Source:          "mac:112233445566/config",
+				Destination:     "dns:example.com",
+				TransactionUUID: "1234",
+			}
+			expected.SetStatus(http.StatusInternalServerError).SetRequestDeliveryResponse(RDRHandlerFailure)
+			assert.Equal(expected, actual)
+		})
+	}
+}
+
+func TestNewRecoveryHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	// the report function is optional, and requests without an entity still
+	// get a response
+	handler := NewRecoveryHandler(HandlerFunc(func(ResponseWriter, *Request) {
+		panic("boom")
+	}), nil)
+
+	httpResponse := httptest.NewRecorder()
+	handler.ServeWRP(
+		&entityResponseWriter{ResponseWriter: httpResponse, f: wrp.JSON},
+		&Request{Original: httptest.NewRequest("POST", "/", nil)},
+	)
+
+	assert.Equal(http.StatusInternalServerError, httpResponse.Code)
+	assert.Equal(wrp.JSON.ContentType(), httpResponse.Header().Get("Content-Type"))
+	assert.Contains(httpResponse.Body.String(), `"status":500`)
+	assert.Contains(httpResponse.Body.String(), `"rdr":1000`)
+}
+
+func TestNewRecoveryHandler_abort(t *testing.T) {
+	handler := NewRecoveryHandler(HandlerFunc(func(ResponseWriter, *Request) {
+		panic(http.ErrAbortHandler)
+	}), func(context.Context, *Request, interface{}, []byte) {
+		t.Error("abort should not be reported")
+	})
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeWRP(
+			&entityResponseWriter{ResponseWriter: httptest.NewRecorder(), f: wrp.Msgpack},
+			&Request{Original: httptest.NewRequest("POST", "/", nil)},
+		)
+	})
+}
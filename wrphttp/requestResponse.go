@@ -6,7 +6,9 @@ package wrphttp
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
+	"sync"
 
 	"github.com/xmidt-org/wrp-go/v3"
 )
@@ -32,12 +34,82 @@ type Entity struct {
 var (
 	ErrEmptyWRPBytes              = errors.New("Encoded WRP bytes were empty.")
 	ErrContentNegotiationMismatch = errors.New("Given format violates content negotiation")
+	ErrResponseWritten            = errors.New("WRP response already written")
 )
 
+// WriteTo writes the entity to w in its Format.  Bytes are written as is if
+// present, otherwise the Message is encoded directly to w, so the encoded
+// message is never held in memory in its entirety.
+func (e *Entity) WriteTo(w io.Writer) (int64, error) {
+	if len(e.Bytes) > 0 {
+		n, err := w.Write(e.Bytes)
+		return int64(n), err
+	}
+
+	return encodeTo(w, e.Format, &e.Message)
+}
+
+// encodeTo encodes the message directly to w and returns the number of bytes
+// written.
+func encodeTo(w io.Writer, f wrp.Format, msg *wrp.Message) (int64, error) {
+	cw := countingWriter{w: w}
+	err := wrp.NewEncoder(&cw, f).Encode(msg)
+	return cw.n, err
+}
+
+// maxBufferedResponse is the largest EncodedSizeEstimate of a message that is
+// encoded into a pooled buffer before being written.  Larger messages are
+// streamed, so that they are never held in memory in their entirety.  It is
+// also the capacity of the largest buffer kept for reuse.
+const maxBufferedResponse = 64 * 1024
+
+var responseBuffers = sync.Pool{
+	New: func() any {
+		return new([]byte)
+	},
+}
+
+// encodeBuffered encodes the message into a pooled buffer of at least size
+// bytes.  The buffer must be returned with releaseBuffer.
+func encodeBuffered(f wrp.Format, msg *wrp.Message, size int) (*[]byte, error) {
+	buf := responseBuffers.Get().(*[]byte)
+	if size > cap(*buf) {
+		*buf = make([]byte, 0, size)
+	}
+
+	*buf = (*buf)[:0]
+	if err := wrp.NewEncoderBytes(buf, f).Encode(msg); err != nil {
+		releaseBuffer(buf)
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+func releaseBuffer(buf *[]byte) {
+	if cap(*buf) <= maxBufferedResponse {
+		*buf = (*buf)[:0]
+		responseBuffers.Put(buf)
+	}
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(b []byte) (int, error) {
+	n, err := cw.w.Write(b)
+	cw.n += int64(n)
+	return n, err
+}
+
 // DetermineFormat examines zero or more headers to determine which WRP format is to be used, either
 // for decoding or encoding.  The headers are tried in order, and the first non-empty value that maps
 // to a WRP format is returned.  Any non-empty header that is invalid results in an error.  If none of
-// the headers are present, this function returns the defaultFormat.
+// the headers are present, this function returns the defaultFormat.  A header may list several media
+// types, as an Accept header does, in which case the first one that maps to a WRP format is used.
 //
 // This function can be used with a single header, e.g. DetermineFormat(wrp.Msgpack, header, "Content-Type").
 // It can also be used for simple content negotiation, e.g. DetermineFormat(wrp.Msgpack, header, "Accept", "Content-Type").
@@ -96,8 +168,8 @@ type ResponseWriter interface {
 	http.ResponseWriter
 
 	// WriteWRP writes a WRP message to the underlying response.  The format used is determined
-	// by the configuration of the underlying implementation.  This method is idempotent, and returns
-	// an error if called multiple times for the same instance.
+	// by the configuration of the underlying implementation.  A response holds a single message,
+	// so this method returns an error if a message has already been written to the same instance.
 	WriteWRP(e *Entity) (int, error)
 
 	// WriteWRPBytes writes a WRP message to the underlying response. The byte array input is assumed
@@ -135,27 +207,62 @@ func NewEntityResponseWriter(defaultFormat wrp.Format) ResponseWriterFunc {
 	}
 }
 
-// entityResponseWriter provides ResponseWriter behavior that marshals WRP messages into the HTTP entity (body)
+// entityResponseWriter provides ResponseWriter behavior that marshals WRP messages into the HTTP entity (body).
+// Messages are encoded into a pooled buffer before anything is written, so that an encoding failure leaves
+// the response untouched, unless they are too large to buffer, in which case they are streamed and the
+// response is only committed by the first byte written.  It implements io.ReaderFrom so that io.Copy can
+// use the underlying response's io.ReaderFrom, e.g. for sendfile, when streaming an encoded message.
 type entityResponseWriter struct {
 	http.ResponseWriter
-	f wrp.Format
+	f       wrp.Format
+	written bool
 }
 
 func (erw *entityResponseWriter) WriteWRP(e *Entity) (int, error) {
-	var output []byte
+	if erw.written {
+		return 0, ErrResponseWritten
+	}
 
 	if len(e.Bytes) > 0 && e.Format == erw.f {
-		output = e.Bytes
-	} else {
-		encoder := wrp.NewEncoderBytes(&output, erw.f)
+		erw.commit()
+		return erw.ResponseWriter.Write(e.Bytes)
+	}
 
-		if err := encoder.Encode(e.Message); err != nil {
-			return 0, err
-		}
+	// messages are buffered so that nothing is written, and the response can
+	// still be used, if the message can't be encoded, unless they are too large
+	size := e.Message.EncodedSizeEstimate(erw.f)
+	if size > maxBufferedResponse {
+		n, err := encodeTo(&commitWriter{erw: erw}, erw.f, &e.Message)
+		return int(n), err
 	}
 
+	buf, err := encodeBuffered(erw.f, &e.Message, size)
+	if err != nil {
+		return 0, err
+	}
+	defer releaseBuffer(buf)
+
+	erw.commit()
+	return erw.ResponseWriter.Write(*buf)
+}
+
+// commit marks the response as written and sets its Content-Type.
+func (erw *entityResponseWriter) commit() {
+	erw.written = true
 	erw.ResponseWriter.Header().Set("Content-Type", erw.f.ContentType())
-	return erw.ResponseWriter.Write(output)
+}
+
+// commitWriter commits the response on the first write to it.
+type commitWriter struct {
+	erw *entityResponseWriter
+}
+
+func (cw *commitWriter) Write(b []byte) (int, error) {
+	if !cw.erw.written {
+		cw.erw.commit()
+	}
+
+	return cw.erw.ResponseWriter.Write(b)
 }
 
 func (erw *entityResponseWriter) WriteWRPBytes(f wrp.Format, encodedWRP []byte) (int, error) {
@@ -165,10 +272,30 @@ func (erw *entityResponseWriter) WriteWRPBytes(f wrp.Format, encodedWRP []byte)
 	if f != erw.f {
 		return 0, ErrContentNegotiationMismatch
 	}
+	if erw.written {
+		return 0, ErrResponseWritten
+	}
+
+	erw.written = true
 	erw.ResponseWriter.Header().Set("Content-Type", f.ContentType())
 	return erw.ResponseWriter.Write(encodedWRP)
 }
 
+// ReadFrom writes the contents of r to the response body.  The contents must be a WRP message
+// encoded in WRPFormat().  The Content-Type is set accordingly if it has not already been set.
+func (erw *entityResponseWriter) ReadFrom(r io.Reader) (int64, error) {
+	if erw.written {
+		return 0, ErrResponseWritten
+	}
+
+	erw.written = true
+	if erw.ResponseWriter.Header().Get("Content-Type") == "" {
+		erw.ResponseWriter.Header().Set("Content-Type", erw.f.ContentType())
+	}
+
+	return io.Copy(erw.ResponseWriter, r)
+}
+
 func (erw *entityResponseWriter) WRPFormat() wrp.Format {
 	return erw.f
 }
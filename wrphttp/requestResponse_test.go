@@ -4,9 +4,14 @@
 package wrphttp
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"net/http"
 	"net/http/httptest"
+	"runtime"
 	"testing"
+	"testing/iotest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -303,3 +308,289 @@ func TestEntityResponseWriter(t *testing.T) {
 		}
 	})
 }
+
+func TestDetermineFormat(t *testing.T) {
+	tests := []struct {
+		desc    string
+		headers map[string]string
+		names   []string
+		want    wrp.Format
+		wantErr bool
+	}{
+		{
+			desc:  "default",
+			names: []string{"Content-Type"},
+			want:  wrp.JSON,
+		}, {
+			desc:    "wrp media type",
+			headers: map[string]string{"Content-Type": wrp.MimeTypeWrpMsgpack},
+			names:   []string{"Content-Type"},
+			want:    wrp.Msgpack,
+		}, {
+			desc:    "accept list",
+			headers: map[string]string{"Accept": "text/html, application/wrp+json;q=0.9, application/msgpack"},
+			names:   []string{"Accept", "Content-Type"},
+			want:    wrp.JSON,
+		}, {
+			desc:    "first header wins",
+			headers: map[string]string{"Accept": wrp.MimeTypeMsgpack, "Content-Type": wrp.MimeTypeJson},
+			names:   []string{"Accept", "Content-Type"},
+			want:    wrp.Msgpack,
+		}, {
+			desc:    "invalid",
+			headers: map[string]string{"Content-Type": "text/html, */*"},
+			names:   []string{"Content-Type"},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			h := make(http.Header)
+			for k, v := range tc.headers {
+				h.Set(k, v)
+			}
+
+			got, err := DetermineFormat(wrp.JSON, h, tc.names...)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func newTestResponseWriter(t *testing.T, w http.ResponseWriter, accept string) ResponseWriter {
+	r := &Request{
+		Original: httptest.NewRequest("POST", "/", nil),
+	}
+	r.Original.Header.Set("Accept", accept)
+
+	rw, err := NewEntityResponseWriter(wrp.Msgpack)(w, r)
+	require.NoError(t, err)
+	return rw
+}
+
+func TestEntityWriteTo(t *testing.T) {
+	msg := wrp.Message{
+		Type:    wrp.SimpleEventMessageType,
+		Source:  "dns:example.com",
+		Payload: []byte("payload"),
+	}
+
+	for _, f := range wrp.AllFormats() {
+		t.Run(f.String(), func(t *testing.T) {
+			assert := assert.New(t)
+			expected := wrp.MustEncode(msg, f)
+
+			var encoded bytes.Buffer
+			n, err := (&Entity{Message: msg, Format: f}).WriteTo(&encoded)
+			assert.NoError(err)
+			assert.Equal(int64(len(expected)), n)
+			assert.Equal(expected, encoded.Bytes())
+
+			var reused bytes.Buffer
+			n, err = (&Entity{Format: f, Bytes: expected}).WriteTo(&reused)
+			assert.NoError(err)
+			assert.Equal(int64(len(expected)), n)
+			assert.Equal(expected, reused.Bytes())
+		})
+	}
+}
+
+func TestEntityResponseWriterWriteOnce(t *testing.T) {
+	msg := wrp.Message{Type: wrp.SimpleEventMessageType}
+	encoded := wrp.MustEncode(msg, wrp.Msgpack)
+
+	writes := []struct {
+		desc  string
+		write func(ResponseWriter) error
+	}{
+		{
+			desc: "WriteWRP",
+			write: func(rw ResponseWriter) error {
+				_, err := rw.WriteWRP(&Entity{Message: msg})
+				return err
+			},
+		}, {
+			desc: "WriteWRPBytes",
+			write: func(rw ResponseWriter) error {
+				_, err := rw.WriteWRPBytes(wrp.Msgpack, encoded)
+				return err
+			},
+		}, {
+			desc: "ReadFrom",
+			write: func(rw ResponseWriter) error {
+				_, err := rw.(io.ReaderFrom).ReadFrom(bytes.NewReader(encoded))
+				return err
+			},
+		},
+	}
+	for _, first := range writes {
+		for _, second := range writes {
+			t.Run(first.desc+" then "+second.desc, func(t *testing.T) {
+				recorder := httptest.NewRecorder()
+				rw := newTestResponseWriter(t, recorder, wrp.MimeTypeMsgpack)
+
+				require.NoError(t, first.write(rw))
+				assert.ErrorIs(t, second.write(rw), ErrResponseWritten)
+				assert.Equal(t, encoded, recorder.Body.Bytes())
+				assert.Equal(t, wrp.MimeTypeMsgpack, recorder.Header().Get("Content-Type"))
+			})
+		}
+	}
+}
+
+func TestEntityResponseWriterReadFrom(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	msg := wrp.Message{
+		Type:    wrp.SimpleEventMessageType,
+		Payload: []byte("payload"),
+	}
+	encoded := wrp.MustEncode(msg, wrp.JSON)
+
+	recorder := httptest.NewRecorder()
+	rw := newTestResponseWriter(t, recorder, wrp.MimeTypeJson)
+
+	// io.Copy works through the decorators of the handler.
+	var w io.Writer = &trackingResponseWriter{
+		ResponseWriter: &rdrResponseWriter{
+			ResponseWriter: rw,
+			codes:          DefaultRDRStatusCodes(),
+		},
+	}
+
+	_, ok := w.(io.ReaderFrom)
+	require.True(ok)
+
+	n, err := io.Copy(w, iotest.OneByteReader(bytes.NewReader(encoded)))
+	require.NoError(err)
+	assert.Equal(int64(len(encoded)), n)
+	assert.Equal(encoded, recorder.Body.Bytes())
+	assert.Equal(wrp.MimeTypeJson, recorder.Header().Get("Content-Type"))
+}
+
+// discardResponseWriter is an http.ResponseWriter that discards the body.
+type discardResponseWriter struct {
+	header http.Header
+	n      int64
+	writes int
+}
+
+func (d *discardResponseWriter) Header() http.Header {
+	if d.header == nil {
+		d.header = make(http.Header)
+	}
+	return d.header
+}
+
+func (d *discardResponseWriter) Write(b []byte) (int, error) {
+	d.n += int64(len(b))
+	d.writes++
+	return len(b), nil
+}
+
+func (d *discardResponseWriter) WriteHeader(int) {}
+
+// allocated returns the number of bytes allocated by f.
+func allocated(f func()) uint64 {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	f()
+	runtime.ReadMemStats(&after)
+	return after.TotalAlloc - before.TotalAlloc
+}
+
+func TestEntityResponseWriterBuffered(t *testing.T) {
+	msg := wrp.Message{
+		Type:        wrp.SimpleRequestResponseMessageType,
+		Source:      "mac:112233445566/service",
+		Destination: "dns:example.com/service",
+		Payload:     make([]byte, 1024),
+	}
+
+	for _, f := range []wrp.Format{wrp.Msgpack, wrp.JSON} {
+		t.Run(f.String(), func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			// twice, so that the second write reuses the pooled buffer
+			for i := 0; i < 2; i++ {
+				w := new(discardResponseWriter)
+				rw := newTestResponseWriter(t, w, f.ContentType())
+
+				n, err := rw.WriteWRP(&Entity{Message: msg})
+				require.NoError(err)
+				assert.Equal(len(wrp.MustEncode(msg, f)), n)
+				assert.Equal(int64(n), w.n)
+				assert.Equal(1, w.writes, "the encoded message must be written at once")
+				assert.Equal(f.ContentType(), w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestEntityResponseWriterLargePayload(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping 100MB payload test in short mode")
+	}
+
+	const (
+		payloadSize = 100 * 1024 * 1024
+
+		// maxAllocated is far less than the payload, so the encoded message
+		// must not have been buffered.
+		maxAllocated = 4 * 1024 * 1024
+	)
+
+	msg := wrp.Message{
+		Type:        wrp.SimpleRequestResponseMessageType,
+		Source:      "mac:112233445566/service",
+		Destination: "dns:example.com/service",
+		Payload:     make([]byte, payloadSize),
+	}
+
+	t.Run("WriteWRP", func(t *testing.T) {
+		w := new(discardResponseWriter)
+		rw := newTestResponseWriter(t, w, wrp.MimeTypeMsgpack)
+
+		var (
+			n   int
+			err error
+		)
+		alloc := allocated(func() {
+			n, err = rw.WriteWRP(&Entity{Message: msg})
+		})
+
+		require.NoError(t, err)
+		assert.Greater(t, n, payloadSize)
+		assert.Equal(t, int64(n), w.n)
+		assert.Less(t, alloc, uint64(maxAllocated))
+		t.Logf("allocated %d bytes writing a %d byte payload", alloc, payloadSize)
+	})
+
+	t.Run("ReadFrom", func(t *testing.T) {
+		encoded := wrp.MustEncode(msg, wrp.Msgpack)
+		w := new(discardResponseWriter)
+		rw := newTestResponseWriter(t, w, wrp.MimeTypeMsgpack)
+
+		var (
+			n   int64
+			err error
+		)
+		alloc := allocated(func() {
+			n, err = io.Copy(rw, iotest.HalfReader(bytes.NewReader(encoded)))
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(encoded)), n)
+		assert.Equal(t, n, w.n)
+		assert.Less(t, alloc, uint64(maxAllocated))
+		t.Logf("allocated %d bytes copying a %d byte message", alloc, len(encoded))
+	})
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrphttp
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/xmidt-org/wrp-go/v3"
+	"github.com/xmidt-org/wrp-go/v3/wrptrace"
+)
+
+// ProjectTraceContext sets the trace context in the Metadata of the message
+// as HTTP headers on dst, e.g. on an outgoing request, so that HTTP tracing
+// such as candlelight's continues the trace of the message.  Nothing is set if
+// the message has no trace context.
+func ProjectTraceContext(dst http.Header, m *wrp.Message, p wrptrace.Propagator) {
+	if sc, ok := p.ExtractMessage(m); ok {
+		p.InjectHeader(dst, sc)
+	}
+}
+
+// CollectTraceContext is the reverse of ProjectTraceContext.  The trace
+// context of the HTTP headers of src is set in the Metadata of the message,
+// unless the message already has a trace context, and reports whether it was.
+// The Metadata is replaced rather than modified.
+func CollectTraceContext(m *wrp.Message, src http.Header, p wrptrace.Propagator) bool {
+	if _, ok := p.ExtractMessage(m); ok {
+		return false
+	}
+
+	sc, ok := p.ExtractHeader(src)
+	if ok {
+		p.InjectMessage(m, sc)
+	}
+
+	return ok
+}
+
+// WithTraceContext configures the handler to propagate the trace context of
+// HTTP requests to their WRP messages, so a trace started by an HTTP client
+// continues through the services the message is sent to.  The trace context
+// of a request is collected with CollectTraceContext before the wrp.Handler
+// is called, in which case the entity's Bytes are cleared since they no
+// longer match, and the trace context of a WRP response written with WriteWRP
+// is projected onto the HTTP response headers.
+//
+// By default, the handler does not propagate trace context.
+func WithTraceContext(p wrptrace.Propagator) Option {
+	return func(wh *wrpHandler) {
+		wh.tracePropagator = &p
+	}
+}
+
+// traceResponseWriter is a decorator that projects the trace context of a WRP
+// response onto the HTTP response.
+type traceResponseWriter struct {
+	ResponseWriter
+	propagator wrptrace.Propagator
+}
+
+func (rw *traceResponseWriter) WriteWRP(e *Entity) (int, error) {
+	ProjectTraceContext(rw.Header(), &e.Message, rw.propagator)
+	return rw.ResponseWriter.WriteWRP(e)
+}
+
+// ReadFrom writes the contents of r as is, in the same way as Write.
+func (rw *traceResponseWriter) ReadFrom(r io.Reader) (int64, error) {
+	return io.Copy(rw.ResponseWriter, r)
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrphttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xmidt-org/wrp-go/v3"
+	"github.com/xmidt-org/wrp-go/v3/wrptrace"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestProjectTraceContext(t *testing.T) {
+	assert := assert.New(t)
+
+	p := wrptrace.Propagator{Formats: []wrptrace.Format{wrptrace.W3C}}
+
+	h := make(http.Header)
+	ProjectTraceContext(h, &wrp.Message{}, p)
+	assert.Empty(h)
+
+	msg := wrp.Message{Metadata: map[string]string{wrptrace.TraceParentKey: testTraceParent}}
+	ProjectTraceContext(h, &msg, p)
+	assert.Equal(testTraceParent, h.Get("Traceparent"))
+
+	var collected wrp.Message
+	assert.True(CollectTraceContext(&collected, h, p))
+	assert.Equal(msg.Metadata, collected.Metadata)
+
+	h.Set("Traceparent", "00-11111111111111111111111111111111-1111111111111111-01")
+	assert.False(CollectTraceContext(&collected, h, p))
+	assert.Equal(msg.Metadata, collected.Metadata)
+
+	assert.False(CollectTraceContext(&wrp.Message{}, make(http.Header), p))
+}
+
+func TestWithTraceContext(t *testing.T) {
+	tests := []struct {
+		desc         string
+		opts         []Option
+		header       string
+		expectedMeta string
+		expectedHTTP string
+	}{
+		{
+			desc:   "disabled",
+			header: testTraceParent,
+		}, {
+			desc:         "enabled",
+			opts:         []Option{WithTraceContext(wrptrace.Propagator{})},
+			header:       testTraceParent,
+			expectedMeta: testTraceParent,
+			expectedHTTP: testTraceParent,
+		}, {
+			desc: "no trace context",
+			opts: []Option{WithTraceContext(wrptrace.Propagator{})},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			handler := HandlerFunc(func(w ResponseWriter, r *Request) {
+				assert.Equal(tc.expectedMeta, r.Entity.Message.Metadata[wrptrace.TraceParentKey])
+				if tc.expectedMeta != "" {
+					assert.Nil(r.Entity.Bytes)
+				}
+
+				_, err := w.WriteWRP(&Entity{Message: r.Entity.Message})
+				assert.NoError(err)
+			})
+
+			decoder := func(context.Context, *http.Request) (*Entity, error) {
+				msg := wrp.Message{Type: wrp.SimpleEventMessageType}
+				return &Entity{Message: msg, Bytes: wrp.MustEncode(&msg, wrp.Msgpack)}, nil
+			}
+
+			opts := append([]Option{
+				WithDecoder(decoder),
+				WithNewResponseWriter(NewEntityResponseWriter(wrp.Msgpack)),
+			}, tc.opts...)
+
+			httpRequest := httptest.NewRequest("POST", "/", nil)
+			if tc.header != "" {
+				httpRequest.Header.Set("Traceparent", tc.header)
+			}
+
+			httpResponse := httptest.NewRecorder()
+			NewHTTPHandler(handler, opts...).ServeHTTP(httpResponse, httpRequest)
+
+			assert.Equal(http.StatusOK, httpResponse.Code)
+			assert.Equal(tc.expectedHTTP, httpResponse.Header().Get("Traceparent"))
+		})
+	}
+}
@@ -1,5 +1,7 @@
 // SPDX-FileCopyrightText: 2022 Comcast Cable Communications Management, LLC
 // SPDX-License-Identifier: Apache-2.0
 
-// Package wrpmeta provides a simple API for building WRP message metadata
+// Package wrpmeta provides a simple API for building WRP message metadata,
+// and a Schema for declaring, validating and converting the metadata a
+// service expects.
 package wrpmeta
@@ -0,0 +1,362 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpmeta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+var (
+	ErrMissingKey   = errors.New("missing metadata key")
+	ErrInvalidValue = errors.New("invalid metadata value")
+	ErrUnknownKey   = errors.New("unknown metadata key")
+	ErrNoSuchKey    = errors.New("no such metadata key in schema")
+)
+
+// Kind is the type of a metadata value.
+type Kind int
+
+const (
+	// String values are accepted as is.
+	String Kind = iota
+
+	// Int values are base 10 signed 64 bit integers.
+	Int
+
+	// Bool values are anything accepted by strconv.ParseBool.
+	Bool
+
+	// UnixSeconds values are base 10 integer seconds since the unix epoch.
+	UnixSeconds
+
+	// RFC3339 values are timestamps formatted using time.RFC3339.
+	RFC3339
+
+	// Duration values are anything accepted by time.ParseDuration.
+	Duration
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case String:
+		return "string"
+	case Int:
+		return "int"
+	case Bool:
+		return "bool"
+	case UnixSeconds:
+		return "unix seconds"
+	case RFC3339:
+		return "RFC3339"
+	case Duration:
+		return "duration"
+	default:
+		return "unknown"
+	}
+}
+
+// Key declares a single expected metadata key.
+type Key struct {
+	// Name is the metadata key, e.g. "/boot-time".  This field is required.
+	Name string
+
+	// Kind is the type of the value.  The default is String.
+	Kind Kind
+
+	// Required indicates the key must be present.
+	Required bool
+
+	// Pattern is an optional regular expression String values must match.
+	Pattern *regexp.Regexp
+
+	// Min and Max optionally bound Int and UnixSeconds values, inclusive.
+	Min, Max *int64
+
+	// Description is used when documenting the schema.
+	Description string
+}
+
+// parse converts the raw value into the typed value for the key.  Surrounding
+// white space is ignored, except in String values.
+func (k Key) parse(raw string) (any, error) {
+	var (
+		v   any
+		n   int64
+		err error
+	)
+
+	if k.Kind != String {
+		raw = strings.TrimSpace(raw)
+	}
+
+	switch k.Kind {
+	case String:
+		if k.Pattern != nil && !k.Pattern.MatchString(raw) {
+			err = fmt.Errorf("does not match `%s`", k.Pattern)
+		}
+		v = raw
+	case Int:
+		n, err = strconv.ParseInt(raw, 10, 64)
+		v = n
+	case UnixSeconds:
+		n, err = strconv.ParseInt(raw, 10, 64)
+		v = time.Unix(n, 0)
+	case Bool:
+		v, err = strconv.ParseBool(raw)
+	case RFC3339:
+		v, err = time.Parse(time.RFC3339, raw)
+	case Duration:
+		v, err = time.ParseDuration(raw)
+	default:
+		err = fmt.Errorf("unsupported kind %d", k.Kind)
+	}
+
+	if err == nil && (k.Kind == Int || k.Kind == UnixSeconds) {
+		if k.Min != nil && n < *k.Min {
+			err = fmt.Errorf("%d is less than %d", n, *k.Min)
+		} else if k.Max != nil && n > *k.Max {
+			err = fmt.Errorf("%d is greater than %d", n, *k.Max)
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: `%s`=`%s` (%s): %s", ErrInvalidValue, k.Name, raw, k.Kind, err)
+	}
+
+	return v, nil
+}
+
+// canonical returns the canonical string form of a typed value.
+func (k Key) canonical(v any) string {
+	switch tv := v.(type) {
+	case int64:
+		return strconv.FormatInt(tv, 10)
+	case bool:
+		return strconv.FormatBool(tv)
+	case time.Time:
+		if k.Kind == UnixSeconds {
+			return strconv.FormatInt(tv.Unix(), 10)
+		}
+		return tv.Format(time.RFC3339)
+	case time.Duration:
+		return tv.String()
+	case string:
+		return tv
+	}
+	return fmt.Sprint(v)
+}
+
+// Schema describes the metadata a service expects.
+type Schema struct {
+	// Keys are the declared metadata keys.
+	Keys []Key
+
+	// AllowUnknown permits metadata keys that are not declared.
+	AllowUnknown bool
+}
+
+func (s Schema) lookup(name string) (Key, bool) {
+	for _, k := range s.Keys {
+		if k.Name == name {
+			return k, true
+		}
+	}
+	return Key{}, false
+}
+
+// Parse validates the metadata against the schema and returns the typed
+// values of the declared keys that are present.  All problems are reported
+// in the returned error.
+func (s Schema) Parse(metadata map[string]string) (Values, error) {
+	var errs []error
+	values := Values{
+		schema: s,
+		values: make(map[string]any, len(s.Keys)),
+	}
+
+	for _, k := range s.Keys {
+		raw, ok := metadata[k.Name]
+		if !ok {
+			if k.Required {
+				errs = append(errs, fmt.Errorf("%w: `%s`", ErrMissingKey, k.Name))
+			}
+			continue
+		}
+
+		v, err := k.parse(raw)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		values.values[k.Name] = v
+	}
+
+	if !s.AllowUnknown {
+		unknown := make([]string, 0)
+		for name := range metadata {
+			if _, ok := s.lookup(name); !ok {
+				unknown = append(unknown, name)
+			}
+		}
+		sort.Strings(unknown)
+		for _, name := range unknown {
+			errs = append(errs, fmt.Errorf("%w: `%s`", ErrUnknownKey, name))
+		}
+	}
+
+	return values, errors.Join(errs...)
+}
+
+// Validate returns an error describing every way the metadata does not
+// conform to the schema, or nil if it conforms.
+func (s Schema) Validate(metadata map[string]string) error {
+	_, err := s.Parse(metadata)
+	return err
+}
+
+// Processor returns a wrp.Processor that validates the metadata of each
+// message.  Valid messages result in wrp.ErrNotHandled so that processing
+// continues, while invalid messages result in the validation error.
+func (s Schema) Processor() wrp.Processor {
+	return wrp.ProcessorFunc(func(_ context.Context, msg wrp.Message) error {
+		if err := s.Validate(msg.Metadata); err != nil {
+			return err
+		}
+		return wrp.ErrNotHandled
+	})
+}
+
+// Modifier returns a wrp.Modifier that validates the metadata of each message
+// and rewrites the declared values in their canonical form, e.g. " 42" becomes
+// "42" and "TRUE" becomes "true".  Invalid messages result in the validation
+// error and are returned unmodified.
+func (s Schema) Modifier() wrp.Modifier {
+	return wrp.ModifierFunc(func(_ context.Context, msg wrp.Message) (wrp.Message, error) {
+		if len(msg.Metadata) == 0 {
+			return msg, s.Validate(msg.Metadata)
+		}
+
+		values, err := s.Parse(msg.Metadata)
+		if err != nil {
+			return msg, err
+		}
+
+		// never modify the caller's map
+		metadata := maps.Clone(msg.Metadata)
+
+		for name, v := range values.values {
+			k, _ := s.lookup(name)
+			metadata[name] = k.canonical(v)
+		}
+
+		msg.Metadata = metadata
+		return msg, nil
+	})
+}
+
+// WriteDoc writes a plain text table documenting the schema.
+func (s Schema) WriteDoc(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tTYPE\tREQUIRED\tCONSTRAINTS\tDESCRIPTION")
+
+	for _, k := range s.Keys {
+		var constraints []string
+		if k.Pattern != nil {
+			constraints = append(constraints, "matches "+k.Pattern.String())
+		}
+		if k.Min != nil {
+			constraints = append(constraints, fmt.Sprintf(">= %d", *k.Min))
+		}
+		if k.Max != nil {
+			constraints = append(constraints, fmt.Sprintf("<= %d", *k.Max))
+		}
+
+		c := strings.Join(constraints, ", ")
+		if c == "" {
+			c = "-"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%t\t%s\t%s\n", k.Name, k.Kind, k.Required, c, k.Description)
+	}
+
+	if s.AllowUnknown {
+		fmt.Fprintln(tw, "*\tstring\tfalse\t-\tundeclared keys are allowed")
+	}
+
+	return tw.Flush()
+}
+
+// Values holds the typed metadata values produced by Schema.Parse.
+type Values struct {
+	schema Schema
+	values map[string]any
+}
+
+// Has returns true if the key was present and valid.
+func (v Values) Has(name string) bool {
+	_, ok := v.values[name]
+	return ok
+}
+
+func get[T any](v Values, name string, kinds ...Kind) (T, bool, error) {
+	var zero T
+
+	k, ok := v.schema.lookup(name)
+	if !ok {
+		return zero, false, fmt.Errorf("%w: `%s`", ErrNoSuchKey, name)
+	}
+
+	valid := false
+	for _, kind := range kinds {
+		valid = valid || k.Kind == kind
+	}
+	if !valid {
+		return zero, false, fmt.Errorf("%w: `%s` is %s", ErrInvalidValue, name, k.Kind)
+	}
+
+	raw, ok := v.values[name]
+	if !ok {
+		return zero, false, nil
+	}
+
+	return raw.(T), true, nil
+}
+
+// String returns the value of a String key.
+func (v Values) String(name string) (string, bool, error) {
+	return get[string](v, name, String)
+}
+
+// Int returns the value of an Int key.
+func (v Values) Int(name string) (int64, bool, error) {
+	return get[int64](v, name, Int)
+}
+
+// Bool returns the value of a Bool key.
+func (v Values) Bool(name string) (bool, bool, error) {
+	return get[bool](v, name, Bool)
+}
+
+// Time returns the value of an UnixSeconds or RFC3339 key.
+func (v Values) Time(name string) (time.Time, bool, error) {
+	return get[time.Time](v, name, UnixSeconds, RFC3339)
+}
+
+// Duration returns the value of a Duration key.
+func (v Values) Duration(name string) (time.Duration, bool, error) {
+	return get[time.Duration](v, name, Duration)
+}
@@ -0,0 +1,285 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpmeta
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func int64p(v int64) *int64 {
+	return &v
+}
+
+func testSchema() Schema {
+	return Schema{
+		Keys: []Key{
+			{
+				Name:        "/boot-time",
+				Kind:        UnixSeconds,
+				Required:    true,
+				Min:         int64p(0),
+				Description: "when the device last booted",
+			}, {
+				Name:    "/fw-name",
+				Pattern: regexp.MustCompile(`^[A-Z0-9_.]+$`),
+			}, {
+				Name: "/reboots",
+				Kind: Int,
+				Max:  int64p(1000),
+			}, {
+				Name: "/online",
+				Kind: Bool,
+			}, {
+				Name: "/last-seen",
+				Kind: RFC3339,
+			}, {
+				Name: "/interval",
+				Kind: Duration,
+			},
+		},
+	}
+}
+
+func TestSchema_Validate(t *testing.T) {
+	tests := []struct {
+		desc         string
+		allowUnknown bool
+		metadata     map[string]string
+		expected     []error
+	}{
+		{
+			desc: "all valid",
+			metadata: map[string]string{
+				"/boot-time": "1700000000",
+				"/fw-name":   "TG1682_3.14",
+				"/reboots":   "12",
+				"/online":    "true",
+				"/last-seen": "2023-11-14T22:13:20Z",
+				"/interval":  "5m",
+			},
+		}, {
+			desc:     "only required",
+			metadata: map[string]string{"/boot-time": "0"},
+		}, {
+			desc:     "missing required",
+			metadata: map[string]string{"/reboots": "1"},
+			expected: []error{ErrMissingKey},
+		}, {
+			desc: "invalid values",
+			metadata: map[string]string{
+				"/boot-time": "yesterday",
+				"/fw-name":   "lower case",
+				"/reboots":   "1001",
+				"/online":    "maybe",
+				"/last-seen": "today",
+				"/interval":  "forever",
+			},
+			expected: []error{ErrInvalidValue},
+		}, {
+			desc:     "below minimum",
+			metadata: map[string]string{"/boot-time": "-1"},
+			expected: []error{ErrInvalidValue},
+		}, {
+			desc:     "unknown key",
+			metadata: map[string]string{"/boot-time": "1", "/other": "x"},
+			expected: []error{ErrUnknownKey},
+		}, {
+			desc:         "unknown key allowed",
+			allowUnknown: true,
+			metadata:     map[string]string{"/boot-time": "1", "/other": "x"},
+		}, {
+			desc:     "everything wrong",
+			metadata: map[string]string{"/reboots": "x", "/other": "x"},
+			expected: []error{ErrMissingKey, ErrInvalidValue, ErrUnknownKey},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := testSchema()
+			s.AllowUnknown = tc.allowUnknown
+
+			err := s.Validate(tc.metadata)
+			if len(tc.expected) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			for _, e := range tc.expected {
+				assert.ErrorIs(t, err, e)
+			}
+		})
+	}
+}
+
+func TestSchema_Parse(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	values, err := testSchema().Parse(map[string]string{
+		"/boot-time": "1700000000",
+		"/fw-name":   "FW",
+		"/reboots":   "12",
+		"/online":    "1",
+		"/last-seen": "2023-11-14T22:13:20Z",
+		"/interval":  "5m",
+	})
+	require.NoError(err)
+
+	bt, ok, err := values.Time("/boot-time")
+	assert.NoError(err)
+	assert.True(ok)
+	assert.True(time.Unix(1700000000, 0).Equal(bt))
+
+	ls, ok, err := values.Time("/last-seen")
+	assert.NoError(err)
+	assert.True(ok)
+	assert.True(bt.Equal(ls))
+
+	fw, ok, err := values.String("/fw-name")
+	assert.NoError(err)
+	assert.True(ok)
+	assert.Equal("FW", fw)
+
+	n, ok, err := values.Int("/reboots")
+	assert.NoError(err)
+	assert.True(ok)
+	assert.Equal(int64(12), n)
+
+	b, ok, err := values.Bool("/online")
+	assert.NoError(err)
+	assert.True(ok)
+	assert.True(b)
+
+	d, ok, err := values.Duration("/interval")
+	assert.NoError(err)
+	assert.True(ok)
+	assert.Equal(5*time.Minute, d)
+
+	assert.True(values.Has("/reboots"))
+	assert.False(values.Has("/nope"))
+
+	_, _, err = values.Int("/nope")
+	assert.ErrorIs(err, ErrNoSuchKey)
+
+	_, _, err = values.Int("/online")
+	assert.ErrorIs(err, ErrInvalidValue)
+
+	values, err = testSchema().Parse(map[string]string{"/boot-time": "1"})
+	require.NoError(err)
+	_, ok, err = values.Int("/reboots")
+	assert.NoError(err)
+	assert.False(ok)
+}
+
+func TestSchema_Processor(t *testing.T) {
+	assert := assert.New(t)
+	p := testSchema().Processor()
+
+	err := p.ProcessWRP(context.Background(), wrp.Message{
+		Metadata: map[string]string{"/boot-time": "1"},
+	})
+	assert.ErrorIs(err, wrp.ErrNotHandled)
+
+	// accepted as the Modifier accepts it
+	err = p.ProcessWRP(context.Background(), wrp.Message{
+		Metadata: map[string]string{"/boot-time": " 1 ", "/online": "true\n"},
+	})
+	assert.ErrorIs(err, wrp.ErrNotHandled)
+
+	err = p.ProcessWRP(context.Background(), wrp.Message{})
+	assert.ErrorIs(err, ErrMissingKey)
+	assert.NotErrorIs(err, wrp.ErrNotHandled)
+}
+
+func TestSchema_Modifier(t *testing.T) {
+	tests := []struct {
+		desc     string
+		in       map[string]string
+		expected map[string]string
+		err      error
+	}{
+		{
+			desc: "canonical form",
+			in: map[string]string{
+				"/boot-time": " 0001700000000 ",
+				"/fw-name":   "FW",
+				"/online":    "TRUE",
+				"/interval":  "300s",
+				"/last-seen": "2023-11-14T17:13:20-05:00",
+			},
+			expected: map[string]string{
+				"/boot-time": "1700000000",
+				"/fw-name":   "FW",
+				"/online":    "true",
+				"/interval":  "5m0s",
+				"/last-seen": "2023-11-14T17:13:20-05:00",
+			},
+		}, {
+			desc: "invalid",
+			in:   map[string]string{"/boot-time": "x"},
+			err:  ErrInvalidValue,
+		}, {
+			desc: "empty",
+			err:  ErrMissingKey,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			in := wrp.Message{Metadata: tc.in}
+			out, err := testSchema().Modifier().ModifyWRP(context.Background(), in)
+			if tc.err != nil {
+				assert.ErrorIs(err, tc.err)
+				assert.Equal(in, out)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tc.expected, out.Metadata)
+		})
+	}
+}
+
+func TestSchema_WriteDoc(t *testing.T) {
+	assert := assert.New(t)
+
+	s := testSchema()
+	s.AllowUnknown = true
+
+	var b strings.Builder
+	assert.NoError(s.WriteDoc(&b))
+
+	doc := b.String()
+	lines := strings.Split(strings.TrimSpace(doc), "\n")
+	assert.Len(lines, len(s.Keys)+2)
+	assert.Contains(lines[0], "KEY")
+	assert.Contains(lines[1], "/boot-time")
+	assert.Contains(lines[1], "unix seconds")
+	assert.Contains(lines[1], ">= 0")
+	assert.Contains(lines[1], "when the device last booted")
+	assert.Contains(lines[2], "matches ^[A-Z0-9_.]+$")
+	assert.Contains(lines[3], "<= 1000")
+	assert.Contains(doc, "undeclared keys are allowed")
+}
+
+func TestKind_String(t *testing.T) {
+	assert.Equal(t, "string", String.String())
+	assert.Equal(t, "int", Int.String())
+	assert.Equal(t, "bool", Bool.String())
+	assert.Equal(t, "unix seconds", UnixSeconds.String())
+	assert.Equal(t, "RFC3339", RFC3339.String())
+	assert.Equal(t, "duration", Duration.String())
+	assert.Equal(t, "unknown", Kind(-1).String())
+}
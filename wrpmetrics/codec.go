@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpmetrics
+
+import (
+	"io"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/xmidt-org/touchstone"
+	"github.com/xmidt-org/wrp-go/v3"
+	"go.uber.org/multierr"
+)
+
+// Codec produces instrumented wrp Encoders and Decoders.  Every Encode and
+// Decode call increments a counter labeled by operation, format, message type
+// and outcome, and successful calls record the encoded size and the duration
+// of the call in histograms.
+//
+// A single Codec should be created per touchstone.Factory and shared, since
+// the underlying metrics can only be registered once.
+type Codec struct {
+	operations *prometheus.CounterVec
+	sizes      prometheus.ObserverVec
+	durations  prometheus.ObserverVec
+	now        func() time.Time
+}
+
+// NewCodec creates a Codec whose metrics are created with the given factory.
+func NewCodec(tf *touchstone.Factory) (*Codec, error) {
+	var errs error
+
+	operations, err := newOperationsTotal(tf)
+	errs = multierr.Append(errs, err)
+
+	sizes, err := newMessageSize(tf)
+	errs = multierr.Append(errs, err)
+
+	durations, err := newDuration(tf)
+	errs = multierr.Append(errs, err)
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	return &Codec{
+		operations: operations,
+		sizes:      sizes,
+		durations:  durations,
+		now:        time.Now,
+	}, nil
+}
+
+// NewEncoder is the instrumented analog of wrp.NewEncoder.
+func (c *Codec) NewEncoder(output io.Writer, f wrp.Format) wrp.Encoder {
+	e := &encoder{
+		c: c,
+		f: f,
+	}
+	e.w.w = output
+	e.Encoder = wrp.NewEncoder(&e.w, f)
+	return e
+}
+
+// NewEncoderBytes is the instrumented analog of wrp.NewEncoderBytes.
+func (c *Codec) NewEncoderBytes(output *[]byte, f wrp.Format) wrp.Encoder {
+	return &encoder{
+		Encoder: wrp.NewEncoderBytes(output, f),
+		c:       c,
+		f:       f,
+		output:  output,
+	}
+}
+
+// NewDecoder is the instrumented analog of wrp.NewDecoder.
+func (c *Codec) NewDecoder(input io.Reader, f wrp.Format) wrp.Decoder {
+	return &decoder{
+		Decoder: wrp.NewDecoder(input, f),
+		c:       c,
+		f:       f,
+	}
+}
+
+// NewDecoderBytes is the instrumented analog of wrp.NewDecoderBytes.
+func (c *Codec) NewDecoderBytes(input []byte, f wrp.Format) wrp.Decoder {
+	return &decoder{
+		Decoder: wrp.NewDecoderBytes(input, f),
+		c:       c,
+		f:       f,
+	}
+}
+
+// observe records a single encode or decode operation.  A negative size
+// indicates that the size is not known.
+func (c *Codec) observe(op string, f wrp.Format, v interface{}, start time.Time, size int, err error) {
+	outcome := SuccessOutcome
+	if err != nil {
+		outcome = FailureOutcome
+	}
+
+	c.operations.With(prometheus.Labels{
+		OperationLabel:   op,
+		FormatLabel:      f.String(),
+		MessageTypeLabel: messageTypeLabel(v),
+		OutcomeLabel:     outcome,
+	}).Inc()
+
+	if err != nil {
+		return
+	}
+
+	labels := prometheus.Labels{
+		OperationLabel: op,
+		FormatLabel:    f.String(),
+	}
+
+	c.durations.With(labels).Observe(c.now().Sub(start).Seconds())
+	if size >= 0 {
+		c.sizes.With(labels).Observe(float64(size))
+	}
+}
+
+// messageTypeLabel determines the message_type label value for an encoded or
+// decoded value.
+func messageTypeLabel(v interface{}) string {
+	var t wrp.Typed
+	switch m := v.(type) {
+	case wrp.Typed:
+		t = m
+	case wrp.Message:
+		t = &m
+	default:
+		return UntypedMessageType
+	}
+
+	if name := t.MessageType().FriendlyName(); name != "" {
+		return name
+	}
+
+	return InvalidMessageType
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += n
+	return n, err
+}
+
+// encoder is the instrumented wrp.Encoder.  Exactly one of w.w or output is
+// in use, depending on how the encoder was created or last reset.
+type encoder struct {
+	wrp.Encoder
+	c      *Codec
+	f      wrp.Format
+	w      countingWriter
+	output *[]byte
+}
+
+func (e *encoder) Encode(v interface{}) error {
+	start := e.c.now()
+	e.w.n = 0
+
+	err := e.Encoder.Encode(v)
+
+	size := e.w.n
+	if e.output != nil {
+		size = len(*e.output)
+	}
+
+	e.c.observe(EncodeOperation, e.f, v, start, size, err)
+	return err
+}
+
+func (e *encoder) Reset(output io.Writer) {
+	e.output = nil
+	e.w = countingWriter{w: output}
+	e.Encoder.Reset(&e.w)
+}
+
+func (e *encoder) ResetBytes(output *[]byte) {
+	e.output = output
+	e.w = countingWriter{}
+	e.Encoder.ResetBytes(output)
+}
+
+// bytesReader is implemented by decoders that track how much input has been
+// consumed, which includes the ugorji decoders returned by the wrp package.
+type bytesReader interface {
+	NumBytesRead() int
+}
+
+// decoder is the instrumented wrp.Decoder.
+type decoder struct {
+	wrp.Decoder
+	c *Codec
+	f wrp.Format
+}
+
+func (d *decoder) Decode(v interface{}) error {
+	start := d.c.now()
+
+	before := -1
+	br, ok := d.Decoder.(bytesReader)
+	if ok {
+		before = br.NumBytesRead()
+	}
+
+	err := d.Decoder.Decode(v)
+
+	size := -1
+	if ok {
+		size = br.NumBytesRead() - before
+	}
+
+	d.c.observe(DecodeOperation, d.f, v, start, size, err)
+	return err
+}
@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpmetrics
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/sallust"
+	"github.com/xmidt-org/touchstone"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func newTestCodec(t *testing.T) *Codec {
+	cfg := touchstone.Config{
+		DefaultNamespace: "n",
+		DefaultSubsystem: "s",
+	}
+	_, pr, err := touchstone.New(cfg)
+	require.NoError(t, err)
+
+	tf := touchstone.NewFactory(cfg, sallust.Default(), pr)
+	c, err := NewCodec(tf)
+	require.NoError(t, err)
+
+	return c
+}
+
+func TestNewCodec(t *testing.T) {
+	cfg := touchstone.Config{
+		DefaultNamespace: "n",
+		DefaultSubsystem: "s",
+	}
+	_, pr, err := touchstone.New(cfg)
+	require.NoError(t, err)
+
+	tf := touchstone.NewFactory(cfg, sallust.Default(), pr)
+	c, err := NewCodec(tf)
+	require.NoError(t, err)
+	require.NotNil(t, c)
+
+	// the metrics can only be registered once
+	c, err = NewCodec(tf)
+	assert.Error(t, err)
+	assert.Nil(t, c)
+}
+
+func TestCodec_Encode(t *testing.T) {
+	msg := wrp.Message{
+		Type:        wrp.SimpleEventMessageType,
+		Source:      "mac:112233445566",
+		Destination: "event:device-status/foo",
+		Payload:     []byte("payload"),
+	}
+
+	for _, f := range wrp.AllFormats() {
+		t.Run(f.String(), func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+			c := newTestCodec(t)
+
+			expected := wrp.MustEncode(&msg, f)
+
+			var buf bytes.Buffer
+			require.NoError(c.NewEncoder(&buf, f).Encode(&msg))
+			assert.Equal(expected, buf.Bytes())
+
+			var output []byte
+			require.NoError(c.NewEncoderBytes(&output, f).Encode(msg))
+			assert.Equal(expected, output)
+
+			// Reset should switch between the writer and byte modes.
+			enc := c.NewEncoderBytes(&output, f)
+			buf.Reset()
+			enc.Reset(&buf)
+			require.NoError(enc.Encode(&msg))
+			assert.Equal(expected, buf.Bytes())
+			var other []byte
+			enc.ResetBytes(&other)
+			require.NoError(enc.Encode(&msg))
+			assert.Equal(expected, other)
+
+			assert.Equal(4.0, testutil.ToFloat64(c.operations.With(prometheus.Labels{
+				OperationLabel:   EncodeOperation,
+				FormatLabel:      f.String(),
+				MessageTypeLabel: "SimpleEvent",
+				OutcomeLabel:     SuccessOutcome,
+			})))
+
+			// Every encoding used the same labels.
+			assert.Equal(1, testutil.CollectAndCount(c.sizes.(prometheus.Collector)))
+		})
+	}
+}
+
+func TestCodec_Decode(t *testing.T) {
+	msg := wrp.Message{
+		Type:            wrp.SimpleRequestResponseMessageType,
+		Source:          "dns:example.com",
+		Destination:     "mac:112233445566",
+		TransactionUUID: "1234",
+	}
+
+	for _, f := range wrp.AllFormats() {
+		t.Run(f.String(), func(t *testing.T) {
+			assert := assert.New(t)
+			c := newTestCodec(t)
+			encoded := wrp.MustEncode(&msg, f)
+
+			var got wrp.Message
+			assert.NoError(c.NewDecoderBytes(encoded, f).Decode(&got))
+			assert.Equal(msg, got)
+
+			got = wrp.Message{}
+			assert.NoError(c.NewDecoder(bytes.NewReader(encoded), f).Decode(&got))
+			assert.Equal(msg, got)
+
+			assert.Error(c.NewDecoderBytes([]byte{0xc1}, f).Decode(&wrp.Message{}))
+
+			assert.Equal(2.0, testutil.ToFloat64(c.operations.With(prometheus.Labels{
+				OperationLabel:   DecodeOperation,
+				FormatLabel:      f.String(),
+				MessageTypeLabel: "SimpleRequestResponse",
+				OutcomeLabel:     SuccessOutcome,
+			})))
+			assert.Equal(1.0, testutil.ToFloat64(c.operations.With(prometheus.Labels{
+				OperationLabel:   DecodeOperation,
+				FormatLabel:      f.String(),
+				MessageTypeLabel: InvalidMessageType,
+				OutcomeLabel:     FailureOutcome,
+			})))
+		})
+	}
+}
+
+func TestMessageTypeLabel(t *testing.T) {
+	tests := []struct {
+		desc     string
+		v        interface{}
+		expected string
+	}{
+		{
+			desc:     "message pointer",
+			v:        &wrp.Message{Type: wrp.CreateMessageType},
+			expected: "Create",
+		}, {
+			desc:     "message value",
+			v:        wrp.Message{Type: wrp.RetrieveMessageType},
+			expected: "Retrieve",
+		}, {
+			desc:     "invalid type",
+			v:        &wrp.Message{},
+			expected: InvalidMessageType,
+		}, {
+			desc:     "untyped",
+			v:        &wrp.ServiceRegistration{},
+			expected: UntypedMessageType,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expected, messageTypeLabel(tc.v))
+		})
+	}
+}
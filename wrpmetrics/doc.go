@@ -0,0 +1,14 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package wrpmetrics provides prometheus instrumentation for the encoding and
+decoding of WRP messages.  The types in this package wrap the Encoders and
+Decoders produced by the wrp package, so services can observe codec hot spots
+without writing their own wrappers.
+
+NewLocatorNormalizer counts the locators rewritten into canonical form on
+ingest, and a FieldObserver counts which fields producers set in their
+messages.
+*/
+package wrpmetrics
@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpmetrics
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/xmidt-org/touchstone"
+	"github.com/xmidt-org/wrp-go/v3"
+	"go.uber.org/multierr"
+)
+
+// observedField is a field of a Message whose population is counted.
+type observedField struct {
+	index []int
+	label string
+}
+
+// FieldObserver is a wrp.Observer that records which fields are set in each
+// message, giving operators visibility into how well producers follow the
+// spec.  Every message increments a counter labeled by message type, and each
+// field that is set increments a counter labeled by message type and the wire
+// name of the field, e.g. "transaction_uuid".  The fraction of events without
+// a TransactionUUID is then
+//
+//	1 - wrp_message_fields_populated_total{message_type="SimpleEvent",field="transaction_uuid"}
+//	  / wrp_messages_observed_total{message_type="SimpleEvent"}
+//
+// A field is set if it is not its zero value; empty slices and maps are not
+// set.  Every field but Type is counted.  A FieldObserver is safe for
+// concurrent use.
+type FieldObserver struct {
+	observed  *prometheus.CounterVec
+	populated *prometheus.CounterVec
+	fields    []observedField
+}
+
+var _ wrp.Observer = (*FieldObserver)(nil)
+
+// NewFieldObserver creates a FieldObserver whose metrics are created with the
+// given factory.
+//
+// The underlying metrics can only be registered once per touchstone.Factory.
+func NewFieldObserver(tf *touchstone.Factory) (*FieldObserver, error) {
+	var errs error
+
+	observed, err := newMessagesObservedTotal(tf)
+	errs = multierr.Append(errs, err)
+
+	populated, err := newFieldsPopulatedTotal(tf)
+	errs = multierr.Append(errs, err)
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	schema, err := wrp.WireSchemaOf(wrp.Message{})
+	if err != nil {
+		return nil, err
+	}
+
+	t := reflect.TypeOf(wrp.Message{})
+	fo := FieldObserver{
+		observed:  observed,
+		populated: populated,
+	}
+
+	for _, wf := range schema.Fields {
+		if wf.GoName == wrp.TypeField.String() {
+			continue
+		}
+
+		sf, _ := t.FieldByName(wf.GoName)
+		fo.fields = append(fo.fields, observedField{
+			index: sf.Index,
+			label: wf.Name,
+		})
+	}
+
+	return &fo, nil
+}
+
+// ObserveWRP records the fields that are set in the message.
+func (fo *FieldObserver) ObserveWRP(_ context.Context, msg wrp.Message) {
+	mt := messageTypeLabel(msg)
+	fo.observed.With(prometheus.Labels{MessageTypeLabel: mt}).Inc()
+
+	v := reflect.ValueOf(&msg).Elem()
+	for _, f := range fo.fields {
+		if isSet(v.FieldByIndex(f.index)) {
+			fo.populated.With(prometheus.Labels{
+				MessageTypeLabel: mt,
+				FieldLabel:       f.label,
+			}).Inc()
+		}
+	}
+}
+
+func isSet(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() > 0
+	default:
+		return !v.IsZero()
+	}
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpmetrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/sallust"
+	"github.com/xmidt-org/touchstone"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestFieldObserver(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	cfg := touchstone.Config{
+		DefaultNamespace: "n",
+		DefaultSubsystem: "s",
+	}
+	_, pr, err := touchstone.New(cfg)
+	require.NoError(err)
+
+	tf := touchstone.NewFactory(cfg, sallust.Default(), pr)
+	fo, err := NewFieldObserver(tf)
+	require.NoError(err)
+
+	ctx := context.Background()
+	fo.ObserveWRP(ctx, wrp.Message{
+		Type:            wrp.SimpleEventMessageType,
+		Source:          "mac:112233445566",
+		Destination:     "event:device-status",
+		TransactionUUID: "1234",
+		Headers:         []string{},
+	})
+	fo.ObserveWRP(ctx, wrp.Message{
+		Type:        wrp.SimpleEventMessageType,
+		Source:      "mac:112233445566",
+		Destination: "event:device-status",
+		ContentType: "application/json",
+		Payload:     []byte("{}"),
+	})
+	fo.ObserveWRP(ctx, *(&wrp.Message{
+		Type:   wrp.SimpleRequestResponseMessageType,
+		Source: "dns:example.com",
+	}).SetStatus(0))
+
+	populated := func(mt, field string) float64 {
+		return testutil.ToFloat64(fo.populated.WithLabelValues(mt, field))
+	}
+
+	assert.Equal(2.0, testutil.ToFloat64(fo.observed.WithLabelValues("SimpleEvent")))
+	assert.Equal(1.0, testutil.ToFloat64(fo.observed.WithLabelValues("SimpleRequestResponse")))
+
+	assert.Equal(2.0, populated("SimpleEvent", "source"))
+	assert.Equal(1.0, populated("SimpleEvent", "transaction_uuid"))
+	assert.Equal(1.0, populated("SimpleEvent", "content_type"))
+	assert.Equal(1.0, populated("SimpleEvent", "payload"))
+	assert.Zero(populated("SimpleEvent", "headers"), "empty slices are not set")
+	assert.Zero(populated("SimpleEvent", "msg_type"), "the type is not counted")
+	assert.Equal(1.0, populated("SimpleRequestResponse", "status"), "a zero status is set")
+
+	// the metrics can only be registered once
+	fo, err = NewFieldObserver(tf)
+	assert.Error(err)
+	assert.Nil(fo)
+}
@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpmetrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/xmidt-org/touchstone"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// NewLocatorNormalizer returns a wrp.Modifier that rewrites the Source and
+// Destination of each message into canonical form, see
+// wrp.CanonicalizeLocators.  Each rewrite increments a counter labeled by the
+// field that was rewritten, either "source" or "dest".
+//
+// The underlying metric can only be registered once per touchstone.Factory.
+func NewLocatorNormalizer(tf *touchstone.Factory) (wrp.Modifier, error) {
+	normalizations, err := newLocatorNormalizationsTotal(tf)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrp.CanonicalizeLocators(func(field, _, _ string) {
+		normalizations.With(prometheus.Labels{FieldLabel: field}).Inc()
+	}), nil
+}
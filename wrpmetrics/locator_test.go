@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpmetrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/sallust"
+	"github.com/xmidt-org/touchstone"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestNewLocatorNormalizer(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	cfg := touchstone.Config{
+		DefaultNamespace: "n",
+		DefaultSubsystem: "s",
+	}
+	g, pr, err := touchstone.New(cfg)
+	require.NoError(err)
+
+	tf := touchstone.NewFactory(cfg, sallust.Default(), pr)
+	m, err := NewLocatorNormalizer(tf)
+	require.NoError(err)
+
+	got, err := m.ModifyWRP(context.Background(), wrp.Message{
+		Source:      "MAC:11:22:33:44:55:66",
+		Destination: "event:device-status",
+	})
+	require.NoError(err)
+	assert.Equal("mac:112233445566", got.Source)
+	assert.Equal("event:device-status", got.Destination)
+
+	// only the source was rewritten
+	count, err := testutil.GatherAndCount(g, "n_s_"+locatorNormalizationsTotalName)
+	require.NoError(err)
+	assert.Equal(1, count)
+
+	// the metric can only be registered once
+	m, err = NewLocatorNormalizer(tf)
+	assert.Error(err)
+	assert.Nil(m)
+}
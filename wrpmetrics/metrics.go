@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpmetrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/xmidt-org/touchstone"
+)
+
+const (
+	// metricPrefix is prepended to all metrics exposed by this package.
+	metricPrefix = "wrp_codec_"
+
+	// operationsTotalName is the name of the counter for all encode and decode operations.
+	operationsTotalName = metricPrefix + "operations_total"
+
+	// operationsTotalHelp is the help text for the operations counter.
+	operationsTotalHelp = "the total number of WRP encode and decode operations"
+
+	// messageSizeName is the name of the histogram of encoded message sizes.
+	messageSizeName = metricPrefix + "message_size_bytes"
+
+	// messageSizeHelp is the help text for the message size histogram.
+	messageSizeHelp = "the size in bytes of encoded WRP messages"
+
+	// durationName is the name of the histogram of encode and decode durations.
+	durationName = metricPrefix + "duration_seconds"
+
+	// durationHelp is the help text for the duration histogram.
+	durationHelp = "the time taken to encode or decode a WRP message"
+
+	// locatorNormalizationsTotalName is the name of the counter for locators
+	// rewritten into canonical form.
+	locatorNormalizationsTotalName = "wrp_locator_normalizations_total"
+
+	// locatorNormalizationsTotalHelp is the help text for the locator
+	// normalizations counter.
+	locatorNormalizationsTotalHelp = "the total number of WRP locators rewritten into canonical form"
+
+	// messagesObservedTotalName is the name of the counter for messages whose
+	// fields are observed.
+	messagesObservedTotalName = "wrp_messages_observed_total"
+
+	// messagesObservedTotalHelp is the help text for the observed messages
+	// counter.
+	messagesObservedTotalHelp = "the total number of WRP messages whose fields were observed"
+
+	// fieldsPopulatedTotalName is the name of the counter for the fields that
+	// are set in observed messages.
+	fieldsPopulatedTotalName = "wrp_message_fields_populated_total"
+
+	// fieldsPopulatedTotalHelp is the help text for the populated fields
+	// counter.
+	fieldsPopulatedTotalHelp = "the total number of observed WRP messages with each field set"
+)
+
+// Metric label names
+const (
+	OperationLabel   = "operation"
+	FormatLabel      = "format"
+	MessageTypeLabel = "message_type"
+	OutcomeLabel     = "outcome"
+	FieldLabel       = "field"
+)
+
+// Metric label values
+const (
+	EncodeOperation = "encode"
+	DecodeOperation = "decode"
+
+	SuccessOutcome = "success"
+	FailureOutcome = "failure"
+
+	// UntypedMessageType is the message_type label value used when the
+	// encoded or decoded value does not implement wrp.Typed.
+	UntypedMessageType = "untyped"
+
+	// InvalidMessageType is the message_type label value used when the
+	// message type has no friendly name, e.g. when decoding failed.
+	InvalidMessageType = "invalid"
+)
+
+var (
+	// sizeBuckets covers messages from a few bytes up to the 256KiB upper
+	// bound imposed by most XMiDT transports.
+	sizeBuckets = prometheus.ExponentialBuckets(64, 4, 8)
+
+	// durationBuckets covers encode and decode durations from 1µs to ~1s.
+	durationBuckets = prometheus.ExponentialBuckets(0.000001, 4, 11)
+)
+
+func newOperationsTotal(tf *touchstone.Factory) (*prometheus.CounterVec, error) {
+	return tf.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: operationsTotalName,
+			Help: operationsTotalHelp,
+		},
+		OperationLabel, FormatLabel, MessageTypeLabel, OutcomeLabel,
+	)
+}
+
+func newMessageSize(tf *touchstone.Factory) (prometheus.ObserverVec, error) {
+	return tf.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    messageSizeName,
+			Help:    messageSizeHelp,
+			Buckets: sizeBuckets,
+		},
+		OperationLabel, FormatLabel,
+	)
+}
+
+func newDuration(tf *touchstone.Factory) (prometheus.ObserverVec, error) {
+	return tf.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    durationName,
+			Help:    durationHelp,
+			Buckets: durationBuckets,
+		},
+		OperationLabel, FormatLabel,
+	)
+}
+
+func newLocatorNormalizationsTotal(tf *touchstone.Factory) (*prometheus.CounterVec, error) {
+	return tf.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: locatorNormalizationsTotalName,
+			Help: locatorNormalizationsTotalHelp,
+		},
+		FieldLabel,
+	)
+}
+
+func newMessagesObservedTotal(tf *touchstone.Factory) (*prometheus.CounterVec, error) {
+	return tf.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: messagesObservedTotalName,
+			Help: messagesObservedTotalHelp,
+		},
+		MessageTypeLabel,
+	)
+}
+
+func newFieldsPopulatedTotal(tf *touchstone.Factory) (*prometheus.CounterVec, error) {
+	return tf.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: fieldsPopulatedTotalName,
+			Help: fieldsPopulatedTotalHelp,
+		},
+		MessageTypeLabel, FieldLabel,
+	)
+}
@@ -0,0 +1,20 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package wrpnano sends and receives WRP messages over nanomsg and nng
+(nanomsg-next-generation) sockets, for parity with wrp-c clients that use the
+nanomsg pipeline named by a message's URL.
+
+The scalability protocols are implemented natively over the tcp:// and ipc://
+transports, so no C library is required.  Pair sockets connect two peers, and
+Publisher and Subscriber sockets distribute messages from one peer to many.
+Messages are encoded with msgpack unless another format is configured.
+
+A socket may Listen, Dial, or both.  Dialed connections are re-established in
+the background with an exponential backoff whenever they are lost.  Each
+socket has bounded queues: a Pair blocks senders when its send queue is full,
+while a Publisher drops messages for subscribers that cannot keep up, since a
+slow subscriber must not hold back the others.
+*/
+package wrpnano
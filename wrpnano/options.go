@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpnano
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+const (
+	// DefaultQueueSize is the default number of messages a socket queues in
+	// each direction.
+	DefaultQueueSize = 128
+
+	// DefaultMaxMessageSize is the default size of the largest message a
+	// socket accepts.
+	DefaultMaxMessageSize = 1024 * 1024
+
+	// DefaultMinReconnect is the default delay before the first attempt to
+	// re-establish a dialed connection.
+	DefaultMinReconnect = 100 * time.Millisecond
+
+	// DefaultMaxReconnect is the default longest delay between attempts to
+	// re-establish a dialed connection.
+	DefaultMaxReconnect = 30 * time.Second
+)
+
+var ErrInvalidOption = errors.New("invalid option")
+
+// Option is a functional option for a socket.
+type Option interface {
+	apply(*socket) error
+}
+
+type optionFunc func(*socket) error
+
+func (f optionFunc) apply(s *socket) error {
+	return f(s)
+}
+
+// QueueSize sets the number of messages queued for sending and receiving.  A
+// Publisher queues this many messages for each subscriber.
+func QueueSize(n int) Option {
+	return optionFunc(func(s *socket) error {
+		if n < 1 {
+			return fmt.Errorf("%w: queue size %d", ErrInvalidOption, n)
+		}
+		s.queueSize = n
+		return nil
+	})
+}
+
+// MaxMessageSize sets the size of the largest message that is accepted from a
+// peer.  A peer that sends a larger message is disconnected.
+func MaxMessageSize(n int) Option {
+	return optionFunc(func(s *socket) error {
+		if n < 1 {
+			return fmt.Errorf("%w: max message size %d", ErrInvalidOption, n)
+		}
+		s.maxMessageSize = n
+		return nil
+	})
+}
+
+// Reconnect sets the delays between attempts to re-establish a dialed
+// connection.  The first attempt waits min, and each failed attempt doubles
+// the delay up to max.
+func Reconnect(min, max time.Duration) Option {
+	return optionFunc(func(s *socket) error {
+		if min <= 0 || max < min {
+			return fmt.Errorf("%w: reconnect min=%s max=%s", ErrInvalidOption, min, max)
+		}
+		s.minReconnect = min
+		s.maxReconnect = max
+		return nil
+	})
+}
+
+// WithFormat sets the format used to encode and decode messages.  The default
+// is wrp.Msgpack.
+func WithFormat(f wrp.Format) Option {
+	return optionFunc(func(s *socket) error {
+		if f != wrp.Msgpack && f != wrp.JSON {
+			return fmt.Errorf("%w: format %d", ErrInvalidOption, f)
+		}
+		s.format = f
+		return nil
+	})
+}
+
+// OnError sets a function that is called with errors that are not returned to
+// a caller, e.g. failed connection attempts, lost connections and messages
+// that cannot be decoded.
+func OnError(f func(error)) Option {
+	return optionFunc(func(s *socket) error {
+		s.onError = f
+		return nil
+	})
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpnano
+
+import (
+	"context"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// Pair is a socket that exchanges messages with a single peer.  Connections
+// from further peers are closed while one is connected.
+type Pair struct {
+	s socket
+}
+
+// NewPair creates a Pair socket.
+func NewPair(opts ...Option) (*Pair, error) {
+	p := Pair{
+		s: socket{
+			proto:    protoPair,
+			peer:     protoPair,
+			maxPipes: 1,
+			receive:  true,
+		},
+	}
+
+	if err := newSocket(&p.s, opts); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// Listen accepts connections on the address, e.g. tcp://127.0.0.1:6666 or
+// ipc:///tmp/wrp.ipc.  The bound address is returned, which is useful when
+// listening on port 0.
+func (p *Pair) Listen(addr string) (string, error) {
+	return p.s.listen(addr)
+}
+
+// Dial connects to the address in the background.  The connection is
+// re-established whenever it is lost, until the socket is closed.
+func (p *Pair) Dial(addr string) error {
+	return p.s.dial(addr)
+}
+
+// Send queues the message for the peer.  If the queue is full, Send blocks
+// until there is room, the context is done or the socket is closed.
+func (p *Pair) Send(ctx context.Context, msg *wrp.Message) error {
+	return p.s.send(ctx, msg)
+}
+
+// Recv returns the next message from the peer.  It blocks until a message is
+// available, the context is done or the socket is closed.
+func (p *Pair) Recv(ctx context.Context) (*wrp.Message, error) {
+	return p.s.recv(ctx)
+}
+
+// Connected returns true if the socket has a peer.
+func (p *Pair) Connected() bool {
+	return p.s.peers() > 0
+}
+
+// Close closes the socket and all of its connections.  Queued messages that
+// have not been sent are discarded.
+func (p *Pair) Close() error {
+	return p.s.close()
+}
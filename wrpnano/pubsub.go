@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpnano
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// Publisher is a socket that sends each message to every connected
+// Subscriber.  Messages are dropped for subscribers whose queue is full.
+type Publisher struct {
+	s socket
+}
+
+// NewPublisher creates a Publisher socket.
+func NewPublisher(opts ...Option) (*Publisher, error) {
+	p := Publisher{
+		s: socket{
+			proto:     protoPub,
+			peer:      protoSub,
+			broadcast: true,
+		},
+	}
+
+	if err := newSocket(&p.s, opts); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// Listen accepts connections on the address.  The bound address is returned.
+func (p *Publisher) Listen(addr string) (string, error) {
+	return p.s.listen(addr)
+}
+
+// Dial connects to the address in the background, reconnecting whenever the
+// connection is lost.
+func (p *Publisher) Dial(addr string) error {
+	return p.s.dial(addr)
+}
+
+// Send queues the message for every connected subscriber.  It does not block,
+// and the context is only present for symmetry with Pair.Send.  A message
+// sent while no subscribers are connected is discarded.
+func (p *Publisher) Send(ctx context.Context, msg *wrp.Message) error {
+	return p.s.send(ctx, msg)
+}
+
+// Subscribers returns the number of connected subscribers.
+func (p *Publisher) Subscribers() int {
+	return p.s.peers()
+}
+
+// Dropped returns the number of messages that were dropped because a
+// subscriber's queue was full.
+func (p *Publisher) Dropped() uint64 {
+	return p.s.dropped.Load()
+}
+
+// Close closes the socket and all of its connections.
+func (p *Publisher) Close() error {
+	return p.s.close()
+}
+
+// Subscriber is a socket that receives messages from Publishers.  Only
+// messages whose Destination starts with a subscribed prefix are received, so
+// a Subscriber without subscriptions receives nothing.
+type Subscriber struct {
+	s socket
+
+	lock   sync.RWMutex
+	topics map[string]struct{}
+}
+
+// NewSubscriber creates a Subscriber socket.
+func NewSubscriber(opts ...Option) (*Subscriber, error) {
+	sub := Subscriber{
+		s: socket{
+			proto:   protoSub,
+			peer:    protoPub,
+			receive: true,
+		},
+		topics: make(map[string]struct{}),
+	}
+	sub.s.accept = sub.subscribed
+
+	if err := newSocket(&sub.s, opts); err != nil {
+		return nil, err
+	}
+
+	return &sub, nil
+}
+
+// Listen accepts connections on the address.  The bound address is returned.
+func (sub *Subscriber) Listen(addr string) (string, error) {
+	return sub.s.listen(addr)
+}
+
+// Dial connects to the address in the background, reconnecting whenever the
+// connection is lost.
+func (sub *Subscriber) Dial(addr string) error {
+	return sub.s.dial(addr)
+}
+
+// Subscribe receives messages whose Destination starts with the prefix, e.g.
+// "event:device-status/".  The empty prefix receives all messages.
+func (sub *Subscriber) Subscribe(prefix string) {
+	sub.lock.Lock()
+	defer sub.lock.Unlock()
+
+	sub.topics[prefix] = struct{}{}
+}
+
+// Unsubscribe removes a prefix added by Subscribe.
+func (sub *Subscriber) Unsubscribe(prefix string) {
+	sub.lock.Lock()
+	defer sub.lock.Unlock()
+
+	delete(sub.topics, prefix)
+}
+
+// Recv returns the next subscribed message.  It blocks until a message is
+// available, the context is done or the socket is closed.
+func (sub *Subscriber) Recv(ctx context.Context) (*wrp.Message, error) {
+	return sub.s.recv(ctx)
+}
+
+// Close closes the socket and all of its connections.
+func (sub *Subscriber) Close() error {
+	return sub.s.close()
+}
+
+func (sub *Subscriber) subscribed(msg *wrp.Message) bool {
+	sub.lock.RLock()
+	defer sub.lock.RUnlock()
+
+	for prefix := range sub.topics {
+		if strings.HasPrefix(msg.Destination, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
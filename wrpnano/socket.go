@@ -0,0 +1,403 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpnano
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// handshakeTimeout bounds the exchange of connection headers.
+const handshakeTimeout = 10 * time.Second
+
+var ErrClosed = errors.New("socket closed")
+
+// socket is the protocol independent implementation shared by the socket
+// types.
+type socket struct {
+	proto uint16
+	peer  uint16
+
+	queueSize      int
+	maxMessageSize int
+	minReconnect   time.Duration
+	maxReconnect   time.Duration
+	format         wrp.Format
+	onError        func(error)
+
+	// maxPipes limits the number of connected peers.  Zero is unlimited.
+	maxPipes int
+
+	// broadcast sends each message to every peer, dropping it for peers
+	// whose queue is full.  Otherwise, messages are taken from sendq by
+	// whichever peer is connected.
+	broadcast bool
+
+	// receive is false for sockets that discard inbound messages.
+	receive bool
+
+	// accept filters received messages.  If nil, all messages are accepted.
+	accept func(*wrp.Message) bool
+
+	sendq   chan []byte
+	recvq   chan *wrp.Message
+	dropped atomic.Uint64
+
+	ctx       context.Context
+	cancel    context.CancelFunc
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+
+	lock      sync.Mutex
+	pipes     map[*pipe]struct{}
+	listeners []net.Listener
+}
+
+func newSocket(s *socket, opts []Option) error {
+	s.queueSize = DefaultQueueSize
+	s.maxMessageSize = DefaultMaxMessageSize
+	s.minReconnect = DefaultMinReconnect
+	s.maxReconnect = DefaultMaxReconnect
+	s.format = wrp.Msgpack
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt.apply(s); err != nil {
+			return err
+		}
+	}
+
+	s.sendq = make(chan []byte, s.queueSize)
+	s.recvq = make(chan *wrp.Message, s.queueSize)
+	s.pipes = make(map[*pipe]struct{})
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	return nil
+}
+
+func (s *socket) error(err error) {
+	if s.onError != nil && s.ctx.Err() == nil {
+		s.onError(err)
+	}
+}
+
+// listen accepts connections on the address and returns the bound address in
+// URL form.
+func (s *socket) listen(addr string) (string, error) {
+	t, address, err := parseAddress(addr)
+	if err != nil {
+		return "", err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.ctx.Err() != nil {
+		return "", ErrClosed
+	}
+
+	l, err := net.Listen(t.network, address)
+	if err != nil {
+		return "", err
+	}
+
+	s.listeners = append(s.listeners, l)
+	s.wg.Add(1)
+	go s.acceptLoop(t, l)
+
+	return t.url(l.Addr()), nil
+}
+
+func (s *socket) acceptLoop(t transport, l net.Listener) {
+	defer s.wg.Done()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			s.error(err)
+			return
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.serve(t, conn)
+		}()
+	}
+}
+
+// dial connects to the address in the background, reconnecting whenever the
+// connection is lost.
+func (s *socket) dial(addr string) error {
+	t, address, err := parseAddress(addr)
+	if err != nil {
+		return err
+	}
+
+	// close cancels the context under the lock before waiting, so the
+	// goroutine is either started before the wait or not at all
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.ctx.Err() != nil {
+		return ErrClosed
+	}
+
+	s.wg.Add(1)
+	go s.dialLoop(t, address)
+	return nil
+}
+
+func (s *socket) dialLoop(t transport, address string) {
+	defer s.wg.Done()
+
+	var (
+		dialer net.Dialer
+		delay  = s.minReconnect
+	)
+
+	for {
+		conn, err := dialer.DialContext(s.ctx, t.network, address)
+		if err == nil {
+			if s.serve(t, conn) {
+				delay = s.minReconnect
+			}
+		} else {
+			s.error(err)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		delay = min(2*delay, s.maxReconnect)
+	}
+}
+
+// serve runs a connection until it fails or the socket is closed.  The
+// returned bool is true if the connection was established.
+func (s *socket) serve(t transport, conn net.Conn) bool {
+	_ = conn.SetDeadline(time.Now().Add(handshakeTimeout))
+	if err := handshake(conn, s.proto, s.peer); err != nil {
+		s.error(err)
+		conn.Close()
+		return false
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	p := &pipe{
+		socket: s,
+		t:      t,
+		conn:   conn,
+		done:   make(chan struct{}),
+	}
+	if s.broadcast {
+		p.out = make(chan []byte, s.queueSize)
+	}
+
+	if !s.addPipe(p) {
+		conn.Close()
+		return false
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		p.writeLoop()
+	}()
+	go func() {
+		defer wg.Done()
+		p.readLoop()
+	}()
+	wg.Wait()
+
+	s.removePipe(p)
+	return true
+}
+
+func (s *socket) addPipe(p *pipe) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.ctx.Err() != nil || (s.maxPipes > 0 && len(s.pipes) >= s.maxPipes) {
+		return false
+	}
+
+	s.pipes[p] = struct{}{}
+	return true
+}
+
+func (s *socket) removePipe(p *pipe) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.pipes, p)
+}
+
+func (s *socket) encode(msg *wrp.Message) ([]byte, error) {
+	var b []byte
+	err := wrp.NewEncoderBytes(&b, s.format).Encode(msg)
+	return b, err
+}
+
+func (s *socket) send(ctx context.Context, msg *wrp.Message) error {
+	if s.ctx.Err() != nil {
+		return ErrClosed
+	}
+
+	b, err := s.encode(msg)
+	if err != nil {
+		return err
+	}
+
+	if s.broadcast {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		for p := range s.pipes {
+			select {
+			case p.out <- b:
+			default:
+				s.dropped.Add(1)
+			}
+		}
+		return nil
+	}
+
+	select {
+	case s.sendq <- b:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.ctx.Done():
+		return ErrClosed
+	}
+}
+
+func (s *socket) recv(ctx context.Context) (*wrp.Message, error) {
+	select {
+	case msg := <-s.recvq:
+		return msg, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-s.ctx.Done():
+		return nil, ErrClosed
+	}
+}
+
+// peers returns the number of connected peers.
+func (s *socket) peers() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return len(s.pipes)
+}
+
+func (s *socket) close() error {
+	s.closeOnce.Do(func() {
+		s.lock.Lock()
+		s.cancel()
+		for _, l := range s.listeners {
+			l.Close()
+		}
+		for p := range s.pipes {
+			p.close()
+		}
+		s.lock.Unlock()
+
+		s.wg.Wait()
+	})
+
+	return nil
+}
+
+// pipe is a single connection to a peer.
+type pipe struct {
+	socket *socket
+	t      transport
+	conn   net.Conn
+
+	// out is the queue of a broadcast socket's messages for this peer.
+	out chan []byte
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (p *pipe) close() {
+	p.closeOnce.Do(func() {
+		close(p.done)
+		p.conn.Close()
+	})
+}
+
+func (p *pipe) writeLoop() {
+	defer p.close()
+
+	src := p.socket.sendq
+	if p.out != nil {
+		src = p.out
+	}
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case b := <-src:
+			if err := p.t.writeMessage(p.conn, b); err != nil {
+				p.socket.error(err)
+				return
+			}
+		}
+	}
+}
+
+func (p *pipe) readLoop() {
+	defer p.close()
+
+	s := p.socket
+	for {
+		b, err := p.t.readMessage(p.conn, s.maxMessageSize)
+		if err != nil {
+			select {
+			case <-p.done:
+			default:
+				s.error(err)
+			}
+			return
+		}
+
+		if !s.receive {
+			continue
+		}
+
+		msg := new(wrp.Message)
+		if err := wrp.NewDecoderBytes(b, s.format).Decode(msg); err != nil {
+			s.error(err)
+			continue
+		}
+
+		if s.accept != nil && !s.accept(msg) {
+			continue
+		}
+
+		select {
+		case s.recvq <- msg:
+		case <-p.done:
+			return
+		}
+	}
+}
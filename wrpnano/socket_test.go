@@ -0,0 +1,444 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpnano
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func testMessage(dest string) *wrp.Message {
+	return &wrp.Message{
+		Type:        wrp.SimpleEventMessageType,
+		Source:      "mac:112233445566",
+		Destination: dest,
+		Payload:     []byte("payload"),
+	}
+}
+
+func newTestPair(t *testing.T, opts ...Option) *Pair {
+	p, err := NewPair(opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { p.Close() })
+	return p
+}
+
+func TestPair(t *testing.T) {
+	tests := []struct {
+		desc string
+		addr func(*testing.T) string
+		opts []Option
+	}{
+		{
+			desc: "tcp",
+			addr: func(*testing.T) string { return "tcp://127.0.0.1:0" },
+		}, {
+			desc: "ipc",
+			addr: func(t *testing.T) string { return "ipc://" + filepath.Join(t.TempDir(), "wrp.ipc") },
+		}, {
+			desc: "tcp with json",
+			addr: func(*testing.T) string { return "tcp://127.0.0.1:0" },
+			opts: []Option{WithFormat(wrp.JSON)},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+			ctx := testContext(t)
+
+			server := newTestPair(t, tc.opts...)
+			client := newTestPair(t, tc.opts...)
+
+			addr, err := server.Listen(tc.addr(t))
+			require.NoError(err)
+			require.NoError(client.Dial(addr))
+
+			want := testMessage("event:device-status/foo")
+			require.NoError(client.Send(ctx, want))
+
+			got, err := server.Recv(ctx)
+			require.NoError(err)
+			assert.Equal(want, got)
+
+			require.NoError(server.Send(ctx, got))
+			got, err = client.Recv(ctx)
+			require.NoError(err)
+			assert.Equal(want, got)
+			assert.True(client.Connected())
+		})
+	}
+}
+
+func TestPairReconnect(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := testContext(t)
+
+	server := newTestPair(t)
+	addr, err := server.Listen("tcp://127.0.0.1:0")
+	require.NoError(err)
+
+	client := newTestPair(t, Reconnect(10*time.Millisecond, 50*time.Millisecond))
+	require.NoError(client.Dial(addr))
+
+	require.NoError(client.Send(ctx, testMessage("event:first")))
+	got, err := server.Recv(ctx)
+	require.NoError(err)
+	assert.Equal("event:first", got.Destination)
+
+	// Restart the server on the same address.
+	require.NoError(server.Close())
+	assert.Eventually(func() bool { return !client.Connected() }, 5*time.Second, 5*time.Millisecond)
+
+	server = newTestPair(t)
+	_, err = server.Listen(addr)
+	require.NoError(err)
+
+	require.NoError(client.Send(ctx, testMessage("event:second")))
+	got, err = server.Recv(ctx)
+	require.NoError(err)
+	assert.Equal("event:second", got.Destination)
+}
+
+func TestPairSendBlocks(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	p := newTestPair(t, QueueSize(1))
+	require.NoError(p.Send(testContext(t), testMessage("event:queued")))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(p.Send(ctx, testMessage("event:blocked")), context.DeadlineExceeded)
+}
+
+func TestPairSinglePeer(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := testContext(t)
+
+	server := newTestPair(t)
+	addr, err := server.Listen("tcp://127.0.0.1:0")
+	require.NoError(err)
+
+	first := newTestPair(t)
+	require.NoError(first.Dial(addr))
+	assert.Eventually(first.Connected, 5*time.Second, 5*time.Millisecond)
+
+	// The second peer completes the handshake but is disconnected.
+	conn, err := net.Dial("tcp", strings.TrimPrefix(addr, "tcp://"))
+	require.NoError(err)
+	defer conn.Close()
+	require.NoError(handshake(conn, protoPair, protoPair))
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = conn.Read(make([]byte, 1))
+	assert.ErrorIs(err, io.EOF)
+
+	require.NoError(first.Send(ctx, testMessage("event:first")))
+	got, err := server.Recv(ctx)
+	require.NoError(err)
+	assert.Equal("event:first", got.Destination)
+}
+
+func TestPubSub(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := testContext(t)
+
+	pub, err := NewPublisher()
+	require.NoError(err)
+	defer pub.Close()
+
+	addr, err := pub.Listen("tcp://127.0.0.1:0")
+	require.NoError(err)
+
+	status, err := NewSubscriber()
+	require.NoError(err)
+	defer status.Close()
+	status.Subscribe("event:device-status/")
+	require.NoError(status.Dial(addr))
+
+	all, err := NewSubscriber()
+	require.NoError(err)
+	defer all.Close()
+	all.Subscribe("")
+	require.NoError(all.Dial(addr))
+
+	assert.Eventually(func() bool { return pub.Subscribers() == 2 }, 5*time.Second, 5*time.Millisecond)
+
+	require.NoError(pub.Send(ctx, testMessage("event:other")))
+	require.NoError(pub.Send(ctx, testMessage("event:device-status/online")))
+
+	got, err := status.Recv(ctx)
+	require.NoError(err)
+	assert.Equal("event:device-status/online", got.Destination)
+
+	got, err = all.Recv(ctx)
+	require.NoError(err)
+	assert.Equal("event:other", got.Destination)
+	got, err = all.Recv(ctx)
+	require.NoError(err)
+	assert.Equal("event:device-status/online", got.Destination)
+
+	status.Unsubscribe("event:device-status/")
+	require.NoError(pub.Send(ctx, testMessage("event:device-status/offline")))
+	got, err = all.Recv(ctx)
+	require.NoError(err)
+	assert.Equal("event:device-status/offline", got.Destination)
+
+	short, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = status.Recv(short)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+	assert.Zero(pub.Dropped())
+}
+
+func TestPublisherDrops(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := testContext(t)
+
+	pub, err := NewPublisher(QueueSize(1))
+	require.NoError(err)
+	defer pub.Close()
+
+	addr, err := pub.Listen("tcp://127.0.0.1:0")
+	require.NoError(err)
+
+	// A subscriber that never reads.
+	conn, err := net.Dial("tcp", strings.TrimPrefix(addr, "tcp://"))
+	require.NoError(err)
+	defer conn.Close()
+	require.NoError(handshake(conn, protoSub, protoPub))
+	assert.Eventually(func() bool { return pub.Subscribers() == 1 }, 5*time.Second, 5*time.Millisecond)
+
+	big := testMessage("event:big")
+	big.Payload = make([]byte, 1024*1024)
+	for i := 0; i < 16; i++ {
+		require.NoError(pub.Send(ctx, big))
+	}
+
+	assert.NotZero(pub.Dropped())
+}
+
+func TestHandshakeMismatch(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	errs := make(chan error, 10)
+	pub, err := NewPublisher(OnError(func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	}))
+	require.NoError(err)
+	defer pub.Close()
+
+	addr, err := pub.Listen("tcp://127.0.0.1:0")
+	require.NoError(err)
+
+	p := newTestPair(t)
+	require.NoError(p.Dial(addr))
+
+	select {
+	case err := <-errs:
+		assert.ErrorIs(err, ErrHandshake)
+	case <-time.After(5 * time.Second):
+		assert.Fail("no handshake error")
+	}
+	assert.Zero(pub.Subscribers())
+	assert.False(p.Connected())
+}
+
+func TestMessageTooLarge(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := testContext(t)
+
+	errs := make(chan error, 10)
+	server := newTestPair(t, MaxMessageSize(64), OnError(func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	}))
+	addr, err := server.Listen("tcp://127.0.0.1:0")
+	require.NoError(err)
+
+	client := newTestPair(t)
+	require.NoError(client.Dial(addr))
+
+	big := testMessage("event:big")
+	big.Payload = make([]byte, 128)
+	require.NoError(client.Send(ctx, big))
+
+	select {
+	case err := <-errs:
+		assert.ErrorIs(err, ErrMessageTooLarge)
+	case <-time.After(5 * time.Second):
+		assert.Fail("no error for a large message")
+	}
+}
+
+func TestWireFormat(t *testing.T) {
+	tests := []struct {
+		desc   string
+		scheme string
+		addr   func(*testing.T) string
+		header []byte
+	}{
+		{
+			desc:   "tcp",
+			scheme: "tcp",
+			addr:   func(*testing.T) string { return "tcp://127.0.0.1:0" },
+		}, {
+			desc:   "ipc",
+			scheme: "ipc",
+			addr:   func(t *testing.T) string { return "ipc://" + filepath.Join(t.TempDir(), "wrp.ipc") },
+			header: []byte{ipcMessageType},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+			ctx := testContext(t)
+
+			server := newTestPair(t)
+			addr, err := server.Listen(tc.addr(t))
+			require.NoError(err)
+
+			tr, address, err := parseAddress(addr)
+			require.NoError(err)
+			conn, err := net.Dial(tr.network, address)
+			require.NoError(err)
+			defer conn.Close()
+
+			// The connection header of a pair socket.
+			_, err = conn.Write([]byte{0x00, 'S', 'P', 0x00, 0x00, 0x10, 0x00, 0x00})
+			require.NoError(err)
+			header := make([]byte, 8)
+			_, err = io.ReadFull(conn, header)
+			require.NoError(err)
+			assert.Equal([]byte{0x00, 'S', 'P', 0x00, 0x00, 0x10, 0x00, 0x00}, header)
+
+			want := testMessage("event:raw")
+			var body []byte
+			require.NoError(wrp.NewEncoderBytes(&body, wrp.Msgpack).Encode(want))
+
+			frame := append([]byte{}, tc.header...)
+			frame = binary.BigEndian.AppendUint64(frame, uint64(len(body)))
+			frame = append(frame, body...)
+			_, err = conn.Write(frame)
+			require.NoError(err)
+
+			got, err := server.Recv(ctx)
+			require.NoError(err)
+			assert.Equal(want, got)
+
+			require.NoError(server.Send(ctx, want))
+			read := make([]byte, len(frame))
+			_, err = io.ReadFull(conn, read)
+			require.NoError(err)
+			assert.Equal(frame, read)
+		})
+	}
+}
+
+func TestClosed(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := testContext(t)
+
+	p, err := NewPair()
+	require.NoError(err)
+	require.NoError(p.Close())
+	require.NoError(p.Close())
+
+	_, err = p.Listen("tcp://127.0.0.1:0")
+	assert.ErrorIs(err, ErrClosed)
+	assert.ErrorIs(p.Dial("tcp://127.0.0.1:1"), ErrClosed)
+	assert.ErrorIs(p.Send(ctx, testMessage("event:closed")), ErrClosed)
+	_, err = p.Recv(ctx)
+	assert.ErrorIs(err, ErrClosed)
+}
+
+func TestDialWhileClosing(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		p, err := NewPair(Reconnect(time.Millisecond, time.Millisecond))
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			err := p.Dial("tcp://127.0.0.1:1")
+			if err != nil {
+				assert.ErrorIs(t, err, ErrClosed)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, p.Close())
+		}()
+		wg.Wait()
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	optTests := []struct {
+		desc string
+		opt  Option
+	}{
+		{desc: "queue size", opt: QueueSize(0)},
+		{desc: "max message size", opt: MaxMessageSize(-1)},
+		{desc: "reconnect min", opt: Reconnect(0, time.Second)},
+		{desc: "reconnect max", opt: Reconnect(time.Second, time.Millisecond)},
+		{desc: "format", opt: WithFormat(wrp.Format(99))},
+	}
+	for _, tc := range optTests {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := NewPair(tc.opt)
+			assert.ErrorIs(t, err, ErrInvalidOption)
+			_, err = NewPublisher(tc.opt)
+			assert.ErrorIs(t, err, ErrInvalidOption)
+			_, err = NewSubscriber(tc.opt)
+			assert.ErrorIs(t, err, ErrInvalidOption)
+		})
+	}
+
+	addrTests := []string{
+		"",
+		"127.0.0.1:6666",
+		"tcp://",
+		"ws://127.0.0.1:6666",
+	}
+	for _, addr := range addrTests {
+		t.Run(addr, func(t *testing.T) {
+			p := newTestPair(t)
+			_, err := p.Listen(addr)
+			assert.ErrorIs(t, err, ErrInvalidAddress)
+			assert.ErrorIs(t, p.Dial(addr), ErrInvalidAddress)
+		})
+	}
+}
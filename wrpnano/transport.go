@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpnano
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+// The scalability protocol identifiers exchanged in the connection header.
+const (
+	protoPair uint16 = 0x10
+	protoPub  uint16 = 0x20
+	protoSub  uint16 = 0x21
+)
+
+// ipcMessageType prefixes each message on the ipc transport.
+const ipcMessageType = 0x01
+
+var (
+	ErrInvalidAddress  = errors.New("invalid address")
+	ErrHandshake       = errors.New("handshake failed")
+	ErrMessageTooLarge = errors.New("message too large")
+)
+
+// transport is the framing of a single URL scheme.
+type transport struct {
+	scheme  string
+	network string
+	ipc     bool
+}
+
+var transports = map[string]transport{
+	"tcp": {scheme: "tcp", network: "tcp"},
+	"ipc": {scheme: "ipc", network: "unix", ipc: true},
+}
+
+// parseAddress splits a URL such as tcp://127.0.0.1:6666 or ipc:///tmp/wrp.ipc
+// into its transport and the network address.
+func parseAddress(addr string) (transport, string, error) {
+	scheme, address, ok := strings.Cut(addr, "://")
+	if !ok || address == "" {
+		return transport{}, "", fmt.Errorf("%w: `%s`", ErrInvalidAddress, addr)
+	}
+
+	t, ok := transports[scheme]
+	if !ok {
+		return transport{}, "", fmt.Errorf("%w: unsupported scheme `%s`", ErrInvalidAddress, scheme)
+	}
+
+	return t, address, nil
+}
+
+// url returns the address in URL form.
+func (t transport) url(a net.Addr) string {
+	return t.scheme + "://" + a.String()
+}
+
+// handshake exchanges the SP connection headers and checks that the peer
+// speaks the expected protocol.
+func handshake(conn io.ReadWriter, proto, peer uint16) error {
+	header := [8]byte{0x00, 'S', 'P', 0x00}
+	binary.BigEndian.PutUint16(header[4:], proto)
+	if _, err := conn.Write(header[:]); err != nil {
+		return fmt.Errorf("%w: %w", ErrHandshake, err)
+	}
+
+	var actual [8]byte
+	if _, err := io.ReadFull(conn, actual[:]); err != nil {
+		return fmt.Errorf("%w: %w", ErrHandshake, err)
+	}
+
+	if actual[0] != 0x00 || actual[1] != 'S' || actual[2] != 'P' || actual[3] != 0x00 {
+		return fmt.Errorf("%w: not a scalability protocol peer", ErrHandshake)
+	}
+
+	if p := binary.BigEndian.Uint16(actual[4:]); p != peer {
+		return fmt.Errorf("%w: peer protocol 0x%x, expected 0x%x", ErrHandshake, p, peer)
+	}
+
+	return nil
+}
+
+// writeMessage writes a single framed message.
+func (t transport) writeMessage(w io.Writer, msg []byte) error {
+	var header [9]byte
+	h := header[:8]
+	if t.ipc {
+		header[0] = ipcMessageType
+		h = header[:]
+		binary.BigEndian.PutUint64(h[1:], uint64(len(msg)))
+	} else {
+		binary.BigEndian.PutUint64(h, uint64(len(msg)))
+	}
+
+	if _, err := w.Write(h); err != nil {
+		return err
+	}
+
+	_, err := w.Write(msg)
+	return err
+}
+
+// readMessage reads a single framed message of at most max bytes.
+func (t transport) readMessage(r io.Reader, max int) ([]byte, error) {
+	var header [9]byte
+	h := header[:8]
+	if t.ipc {
+		h = header[:]
+	}
+
+	if _, err := io.ReadFull(r, h); err != nil {
+		return nil, err
+	}
+
+	if t.ipc {
+		if h[0] != ipcMessageType {
+			return nil, fmt.Errorf("%w: unexpected ipc message type 0x%x", ErrHandshake, h[0])
+		}
+		h = h[1:]
+	}
+
+	size := binary.BigEndian.Uint64(h)
+	if size > uint64(max) {
+		return nil, fmt.Errorf("%w: %d bytes is more than %d", ErrMessageTooLarge, size, max)
+	}
+
+	msg := make([]byte, size)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,11 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package wrppayload validates WRP payloads against schemas registered for their
+ContentType.  A Processor rejects malformed payloads, e.g. JSON that does not
+match a JSON Schema or bytes that are not a valid protobuf message, before they
+reach business logic.  JSON Schema references to local files are resolved, but
+those to remote documents are not; see JSONSchema.
+*/
+package wrppayload
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrppayload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+var ErrUnknownContentType = errors.New("no payload validator for content type")
+
+// Option is a functional option for a Processor.
+type Option interface {
+	apply(*Processor) error
+}
+
+type optionFunc func(*Processor) error
+
+func (f optionFunc) apply(p *Processor) error {
+	return f(p)
+}
+
+// WithValidator registers the Validator for payloads of the given content type,
+// e.g. "application/json".  Parameters such as charset are ignored when
+// matching content types.
+func WithValidator(contentType string, v Validator) Option {
+	return optionFunc(func(p *Processor) error {
+		mt := mediaType(contentType)
+		if mt == "" || v == nil {
+			return fmt.Errorf("%w: content type `%s`", ErrInvalidSchema, contentType)
+		}
+
+		p.validators[mt] = v
+		return nil
+	})
+}
+
+// RejectUnknownContentTypes causes the Processor to reject payloads whose
+// content type has no registered Validator.  By default, such payloads are
+// accepted.
+func RejectUnknownContentTypes() Option {
+	return optionFunc(func(p *Processor) error {
+		p.rejectUnknown = true
+		return nil
+	})
+}
+
+// Processor is a wrp.Processor that validates each message's payload with the
+// Validator registered for its ContentType.  Messages without a payload are
+// not validated.
+type Processor struct {
+	validators    map[string]Validator
+	rejectUnknown bool
+}
+
+var _ wrp.Processor = (*Processor)(nil)
+
+// NewProcessor creates a Processor with the given options.
+func NewProcessor(opts ...Option) (*Processor, error) {
+	p := Processor{
+		validators: make(map[string]Validator),
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			if err := opt.apply(&p); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &p, nil
+}
+
+// Validate returns an error if the message's payload is not valid for its
+// ContentType.
+func (p *Processor) Validate(msg *wrp.Message) error {
+	if len(msg.Payload) == 0 {
+		return nil
+	}
+
+	v, ok := p.validators[mediaType(msg.ContentType)]
+	if !ok {
+		if p.rejectUnknown {
+			return fmt.Errorf("%w: `%s`", ErrUnknownContentType, msg.ContentType)
+		}
+		return nil
+	}
+
+	return v.ValidatePayload(msg.Payload)
+}
+
+// ProcessWRP returns wrp.ErrNotHandled if the payload is valid, so that
+// processing continues, or the validation error otherwise.
+func (p *Processor) ProcessWRP(_ context.Context, msg wrp.Message) error {
+	if err := p.Validate(&msg); err != nil {
+		return err
+	}
+
+	return wrp.ErrNotHandled
+}
+
+// mediaType returns the normalized media type of a content type, without any
+// parameters.
+func mediaType(contentType string) string {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mt, _, _ = strings.Cut(contentType, ";")
+	}
+
+	return strings.ToLower(strings.TrimSpace(mt))
+}
@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrppayload
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const testSchema = `{
+	"type": "object",
+	"properties": {
+		"id": {"type": "string"},
+		"count": {"type": "integer", "minimum": 0}
+	},
+	"required": ["id"]
+}`
+
+func newTestProcessor(t *testing.T, opts ...Option) *Processor {
+	schema, err := JSONSchema([]byte(testSchema))
+	require.NoError(t, err)
+
+	duration, err := Protobuf((&durationpb.Duration{}).ProtoReflect().Descriptor())
+	require.NoError(t, err)
+
+	namePart, err := Protobuf((&descriptorpb.UninterpretedOption_NamePart{}).ProtoReflect().Descriptor())
+	require.NoError(t, err)
+
+	p, err := NewProcessor(append([]Option{
+		WithValidator("application/json", schema),
+		WithValidator("text/json", JSON()),
+		WithValidator("application/x-protobuf; messageType=google.protobuf.Duration", duration),
+		WithValidator("application/x-name-part", namePart),
+	}, opts...)...)
+	require.NoError(t, err)
+
+	return p
+}
+
+func TestProcessor(t *testing.T) {
+	duration, err := proto.Marshal(durationpb.New(5))
+	require.NoError(t, err)
+
+	complete, err := proto.Marshal(&descriptorpb.UninterpretedOption_NamePart{
+		NamePart:    proto.String("name"),
+		IsExtension: proto.Bool(false),
+	})
+	require.NoError(t, err)
+
+	partial, err := proto.MarshalOptions{AllowPartial: true}.Marshal(&descriptorpb.UninterpretedOption_NamePart{
+		NamePart: proto.String("name"),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		desc          string
+		contentType   string
+		payload       []byte
+		rejectUnknown bool
+		expected      error
+	}{
+		{
+			desc:        "valid json",
+			contentType: "application/json",
+			payload:     []byte(`{"id": "a", "count": 1}`),
+		}, {
+			desc:        "content type parameters are ignored",
+			contentType: "Application/JSON; charset=utf-8",
+			payload:     []byte(`{"id": "a"}`),
+		}, {
+			desc:        "missing required property",
+			contentType: "application/json",
+			payload:     []byte(`{"count": 1}`),
+			expected:    ErrInvalidPayload,
+		}, {
+			desc:        "wrong property type",
+			contentType: "application/json",
+			payload:     []byte(`{"id": "a", "count": -1}`),
+			expected:    ErrInvalidPayload,
+		}, {
+			desc:        "malformed json",
+			contentType: "application/json",
+			payload:     []byte(`{"id":`),
+			expected:    ErrInvalidPayload,
+		}, {
+			desc:        "well formed json",
+			contentType: "text/json",
+			payload:     []byte(`[1, 2, 3]`),
+		}, {
+			desc:        "not well formed json",
+			contentType: "text/json",
+			payload:     []byte(`[1, 2,`),
+			expected:    ErrInvalidPayload,
+		}, {
+			desc:        "valid protobuf",
+			contentType: "application/x-protobuf",
+			payload:     duration,
+		}, {
+			desc:        "malformed protobuf",
+			contentType: "application/x-protobuf",
+			payload:     []byte{0xff, 0xff, 0xff},
+			expected:    ErrInvalidPayload,
+		}, {
+			desc:        "protobuf with required fields",
+			contentType: "application/x-name-part",
+			payload:     complete,
+		}, {
+			desc:        "protobuf missing required fields",
+			contentType: "application/x-name-part",
+			payload:     partial,
+			expected:    ErrInvalidPayload,
+		}, {
+			desc:        "no payload",
+			contentType: "application/json",
+		}, {
+			desc:        "unknown content type",
+			contentType: "text/plain",
+			payload:     []byte("anything"),
+		}, {
+			desc:          "unknown content type rejected",
+			contentType:   "text/plain",
+			payload:       []byte("anything"),
+			rejectUnknown: true,
+			expected:      ErrUnknownContentType,
+		}, {
+			desc:          "no content type rejected",
+			payload:       []byte("anything"),
+			rejectUnknown: true,
+			expected:      ErrUnknownContentType,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var opts []Option
+			if tc.rejectUnknown {
+				opts = append(opts, nil, RejectUnknownContentTypes())
+			}
+
+			p := newTestProcessor(t, opts...)
+			msg := wrp.Message{
+				Type:        wrp.SimpleEventMessageType,
+				ContentType: tc.contentType,
+				Payload:     tc.payload,
+			}
+
+			err := p.ProcessWRP(context.Background(), msg)
+			if tc.expected == nil {
+				assert.ErrorIs(t, err, wrp.ErrNotHandled)
+				assert.NoError(t, p.Validate(&msg))
+				return
+			}
+
+			assert.ErrorIs(t, err, tc.expected)
+			assert.NotErrorIs(t, err, wrp.ErrNotHandled)
+		})
+	}
+}
+
+func TestNewProcessor_invalid(t *testing.T) {
+	tests := []struct {
+		desc string
+		opt  Option
+	}{
+		{
+			desc: "empty content type",
+			opt:  WithValidator("", JSON()),
+		}, {
+			desc: "nil validator",
+			opt:  WithValidator("application/json", nil),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			p, err := NewProcessor(tc.opt)
+			assert.ErrorIs(t, err, ErrInvalidSchema)
+			assert.Nil(t, p)
+		})
+	}
+}
+
+func TestSchemas_invalid(t *testing.T) {
+	_, err := JSONSchema([]byte(`{"type":`))
+	assert.ErrorIs(t, err, ErrInvalidSchema)
+
+	_, err = JSONSchema([]byte(`{"type": "nonsense"}`))
+	assert.ErrorIs(t, err, ErrInvalidSchema)
+
+	_, err = JSONSchema([]byte(`{"$ref": "http://example.com/schema.json"}`))
+	assert.ErrorIs(t, err, ErrInvalidSchema)
+
+	_, err = Protobuf(nil)
+	assert.ErrorIs(t, err, ErrInvalidSchema)
+}
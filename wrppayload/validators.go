@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrppayload
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+var (
+	ErrInvalidPayload = errors.New("invalid payload")
+	ErrInvalidSchema  = errors.New("invalid payload schema")
+)
+
+// Validator validates a payload.
+type Validator interface {
+	// ValidatePayload returns an error if the payload is malformed.
+	ValidatePayload(payload []byte) error
+}
+
+// ValidatorFunc is a convenience type to define a Validator using a function.
+type ValidatorFunc func([]byte) error
+
+func (f ValidatorFunc) ValidatePayload(payload []byte) error {
+	return f(payload)
+}
+
+// JSON returns a Validator that only checks that the payload is well formed
+// JSON.
+func JSON() Validator {
+	return ValidatorFunc(func(payload []byte) error {
+		if !json.Valid(payload) {
+			return fmt.Errorf("%w: malformed JSON", ErrInvalidPayload)
+		}
+		return nil
+	})
+}
+
+// JSONSchema returns a Validator that checks the payload against the given
+// JSON Schema document.  References within the document are resolved, as are
+// references to JSON files, which are loaded from the local file system when
+// the schema is compiled.  Relative references are resolved against the
+// working directory.  References with any other URL scheme, e.g. http, are an
+// ErrInvalidSchema.
+func JSONSchema(schema []byte) (Validator, error) {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schema))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+	}
+
+	const location = "payload.json"
+
+	c := jsonschema.NewCompiler()
+	if err = c.AddResource(location, doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+	}
+
+	compiled, err := c.Compile(location)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+	}
+
+	return ValidatorFunc(func(payload []byte) error {
+		instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(payload))
+		if err != nil {
+			return fmt.Errorf("%w: malformed JSON: %w", ErrInvalidPayload, err)
+		}
+
+		if err := compiled.Validate(instance); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+		}
+		return nil
+	}), nil
+}
+
+// Protobuf returns a Validator that checks that the payload is a valid
+// binary encoding of the described message, including all required fields.
+func Protobuf(desc protoreflect.MessageDescriptor) (Validator, error) {
+	if desc == nil {
+		return nil, fmt.Errorf("%w: nil message descriptor", ErrInvalidSchema)
+	}
+
+	return ValidatorFunc(func(payload []byte) error {
+		msg := dynamicpb.NewMessage(desc)
+		if err := proto.Unmarshal(payload, msg); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidPayload, desc.FullName(), err)
+		}
+		return nil
+	}), nil
+}
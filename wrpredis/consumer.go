@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpredis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+const (
+	// DefaultCount is the default number of entries read from each stream at
+	// a time.
+	DefaultCount = 10
+
+	// DefaultBlock is the default time a Read waits for new entries.
+	DefaultBlock = 5 * time.Second
+)
+
+// ConsumerOption is a functional option for NewConsumer.
+type ConsumerOption interface {
+	apply(*Consumer) error
+}
+
+type consumerOptionFunc func(*Consumer) error
+
+func (f consumerOptionFunc) apply(c *Consumer) error {
+	return f(c)
+}
+
+// Count sets the largest number of entries read or claimed from each stream
+// at a time.  The default is DefaultCount.
+func Count(n int64) ConsumerOption {
+	return consumerOptionFunc(func(c *Consumer) error {
+		if n <= 0 {
+			return fmt.Errorf("%w: count %d", ErrInvalidConfig, n)
+		}
+		c.count = n
+		return nil
+	})
+}
+
+// Block sets how long a Read waits for new entries.  The default is
+// DefaultBlock.
+func Block(d time.Duration) ConsumerOption {
+	return consumerOptionFunc(func(c *Consumer) error {
+		if d <= 0 {
+			return fmt.Errorf("%w: block %s", ErrInvalidConfig, d)
+		}
+		c.block = d
+		return nil
+	})
+}
+
+// Delivery is a message read from a stream.
+type Delivery struct {
+	// Stream is the stream of the entry.
+	Stream string
+
+	// ID is the ID of the entry, which is used to acknowledge it.
+	ID string
+
+	// Message is the message of the entry.  It is nil if Err is set.
+	Message *wrp.Message
+
+	// Err is set if the entry does not hold a valid message.  Such deliveries
+	// should still be acknowledged, or they will be claimed again.
+	Err error
+}
+
+// Consumer reads messages from streams as a consumer of a consumer group.  A
+// Consumer is safe for concurrent use if its Client is.
+type Consumer struct {
+	client   Client
+	streams  []string
+	group    string
+	consumer string
+	count    int64
+	block    time.Duration
+}
+
+// NewConsumer creates a Consumer named consumer in the group.
+func NewConsumer(c Client, s Streams, group, consumer string, opts ...ConsumerOption) (*Consumer, error) {
+	switch {
+	case c == nil:
+		return nil, fmt.Errorf("%w: no client", ErrInvalidConfig)
+	case group == "":
+		return nil, fmt.Errorf("%w: no group", ErrInvalidConfig)
+	case consumer == "":
+		return nil, fmt.Errorf("%w: no consumer", ErrInvalidConfig)
+	}
+
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
+	con := Consumer{
+		client:   c,
+		streams:  s.names(),
+		group:    group,
+		consumer: consumer,
+		count:    DefaultCount,
+		block:    DefaultBlock,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			if err := opt.apply(&con); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &con, nil
+}
+
+// CreateGroup creates the consumer group for each stream, and the streams if
+// they do not exist.  The group starts with the first entry of the stream.
+func (c *Consumer) CreateGroup(ctx context.Context) error {
+	for _, stream := range c.streams {
+		if err := c.client.XGroupCreate(ctx, stream, c.group, "0"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Read returns the next messages of the streams, those of higher QOS streams
+// first.  It waits for new messages for the Block time, and returns no
+// deliveries if none arrive.
+func (c *Consumer) Read(ctx context.Context) ([]Delivery, error) {
+	entries, err := c.client.XReadGroup(ctx, c.group, c.consumer, c.streams, c.count, c.block)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries(entries), nil
+}
+
+// Claim takes over the messages of the streams that were delivered to other
+// consumers, but have not been acknowledged for at least minIdle, e.g.
+// because the consumer failed.  The whole pending list of each stream is
+// scanned, claiming up to the count of the Consumer at a time.
+func (c *Consumer) Claim(ctx context.Context, minIdle time.Duration) ([]Delivery, error) {
+	var claimed []Delivery
+	for _, stream := range c.streams {
+		for start := "0-0"; ; {
+			entries, next, err := c.client.XAutoClaim(ctx, stream, c.group, c.consumer, minIdle, start, c.count)
+			if err != nil {
+				return claimed, err
+			}
+
+			claimed = append(claimed, deliveries(entries)...)
+			if next == "0-0" || next == "" {
+				break
+			}
+			start = next
+		}
+	}
+
+	return claimed, nil
+}
+
+// Ack acknowledges the deliveries, so they are not claimed again.
+func (c *Consumer) Ack(ctx context.Context, ds ...Delivery) error {
+	var (
+		streams []string
+		ids     = make(map[string][]string)
+	)
+
+	for _, d := range ds {
+		if _, ok := ids[d.Stream]; !ok {
+			streams = append(streams, d.Stream)
+		}
+		ids[d.Stream] = append(ids[d.Stream], d.ID)
+	}
+
+	var errs []error
+	for _, stream := range streams {
+		if err := c.client.XAck(ctx, stream, c.group, ids[stream]...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func deliveries(entries []Entry) []Delivery {
+	ds := make([]Delivery, len(entries))
+	for i, e := range entries {
+		ds[i] = Delivery{
+			Stream: e.Stream,
+			ID:     e.ID,
+		}
+		ds[i].Message, ds[i].Err = decode(e)
+	}
+
+	return ds
+}
+
+// decode decodes the message of the entry.
+func decode(e Entry) (*wrp.Message, error) {
+	var encoded []byte
+	switch v := e.Values[FieldMessage].(type) {
+	case []byte:
+		encoded = v
+	case string:
+		encoded = []byte(v)
+	default:
+		return nil, fmt.Errorf("%w: %s/%s", ErrNoMessage, e.Stream, e.ID)
+	}
+
+	var msg wrp.Message
+	if err := wrp.NewDecoderBytes(encoded, wrp.Msgpack).Decode(&msg); err != nil {
+		return nil, fmt.Errorf("%s/%s: %w", e.Stream, e.ID, err)
+	}
+
+	return &msg, nil
+}
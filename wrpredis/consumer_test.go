@@ -0,0 +1,266 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpredis
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+var testStreams = Streams{"wrp:low", "wrp:low", "wrp:high", "wrp:critical"}
+
+func testMessage(tid string, qos wrp.QOSValue) *wrp.Message {
+	return &wrp.Message{
+		Type:             wrp.SimpleEventMessageType,
+		Source:           "mac:112233445566",
+		Destination:      "event:device-status/mac:112233445566/online",
+		TransactionUUID:  tid,
+		QualityOfService: qos,
+		Payload:          []byte("payload " + tid),
+	}
+}
+
+func messages(ds []Delivery) []*wrp.Message {
+	msgs := make([]*wrp.Message, len(ds))
+	for i, d := range ds {
+		msgs[i] = d.Message
+	}
+
+	return msgs
+}
+
+func TestProducer(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := newMemClient()
+	p, err := NewProducer(c, testStreams, MaxLen(1000), nil)
+	require.NoError(err)
+
+	stream, id, err := p.Send(context.Background(), testMessage("1", wrp.QOSHighValue))
+	require.NoError(err)
+	assert.Equal("wrp:high", stream)
+	assert.Equal("1-0", id)
+	assert.Equal([]int64{1000}, c.maxLens)
+
+	values := c.streams["wrp:high"].entries[0].Values
+	assert.IsType([]byte{}, values[FieldMessage])
+	delete(values, FieldMessage)
+	assert.Equal(map[string]interface{}{
+		FieldMessageType:     "SimpleEvent",
+		FieldSource:          "mac:112233445566",
+		FieldDestination:     "event:device-status/mac:112233445566/online",
+		FieldTransactionUUID: "1",
+		FieldQOS:             "50",
+	}, values)
+
+	c.err = errors.New("expected")
+	_, _, err = p.Send(context.Background(), testMessage("2", wrp.QOSLowValue))
+	assert.ErrorIs(err, c.err)
+}
+
+func TestConsumer(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := newMemClient()
+	p, err := NewProducer(c, testStreams)
+	require.NoError(err)
+
+	first, err := NewConsumer(c, testStreams, "group", "first", Count(2), Block(time.Second))
+	require.NoError(err)
+	require.NoError(first.CreateGroup(context.Background()))
+
+	second, err := NewConsumer(c, testStreams, "group", "second")
+	require.NoError(err)
+	require.NoError(second.CreateGroup(context.Background()))
+
+	for _, msg := range []*wrp.Message{
+		testMessage("low", wrp.QOSLowValue),
+		testMessage("medium", wrp.QOSMediumValue),
+		testMessage("critical", wrp.QOSCriticalValue),
+		testMessage("high", wrp.QOSHighValue),
+	} {
+		_, _, err := p.Send(context.Background(), msg)
+		require.NoError(err)
+	}
+
+	// higher QOS streams first
+	ds, err := first.Read(context.Background())
+	require.NoError(err)
+	assert.Equal([]*wrp.Message{
+		testMessage("critical", wrp.QOSCriticalValue),
+		testMessage("high", wrp.QOSHighValue),
+		testMessage("low", wrp.QOSLowValue),
+		testMessage("medium", wrp.QOSMediumValue),
+	}, messages(ds))
+
+	// only the high QOS messages are processed before the first consumer fails
+	require.NoError(first.Ack(context.Background(), ds[:2]...))
+
+	ds, err = second.Read(context.Background())
+	require.NoError(err)
+	assert.Empty(ds)
+
+	ds, err = second.Claim(context.Background(), time.Minute)
+	require.NoError(err)
+	assert.Empty(ds, "the deliveries are not idle long enough")
+
+	c.now = c.now.Add(time.Minute)
+	ds, err = second.Claim(context.Background(), time.Minute)
+	require.NoError(err)
+	assert.Equal([]*wrp.Message{
+		testMessage("low", wrp.QOSLowValue),
+		testMessage("medium", wrp.QOSMediumValue),
+	}, messages(ds))
+
+	require.NoError(second.Ack(context.Background(), ds...))
+	for _, s := range c.streams {
+		assert.Empty(s.groups["group"].pending)
+	}
+}
+
+func TestConsumer_ClaimAll(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := newMemClient()
+	p, err := NewProducer(c, SingleStream("wrp"))
+	require.NoError(err)
+
+	failed, err := NewConsumer(c, SingleStream("wrp"), "group", "failed", Count(10))
+	require.NoError(err)
+	require.NoError(failed.CreateGroup(context.Background()))
+
+	claimer, err := NewConsumer(c, SingleStream("wrp"), "group", "claimer", Count(2))
+	require.NoError(err)
+
+	var sent []*wrp.Message
+	for i := 0; i < 5; i++ {
+		msg := testMessage(strconv.Itoa(i), wrp.QOSLowValue)
+		sent = append(sent, msg)
+		_, _, err := p.Send(context.Background(), msg)
+		require.NoError(err)
+	}
+
+	ds, err := failed.Read(context.Background())
+	require.NoError(err)
+	require.Len(ds, 5)
+
+	// every pending entry is claimed, not just the first count
+	c.now = c.now.Add(time.Minute)
+	ds, err = claimer.Claim(context.Background(), time.Minute)
+	require.NoError(err)
+	assert.Equal(sent, messages(ds))
+	assert.Equal(3, c.claims)
+}
+
+func TestConsumer_invalidEntries(t *testing.T) {
+	c := newMemClient()
+	con, err := NewConsumer(c, SingleStream("wrp"), "group", "consumer")
+	require.NoError(t, err)
+	require.NoError(t, con.CreateGroup(context.Background()))
+
+	for _, values := range []map[string]interface{}{
+		{FieldSource: "mac:112233445566"},
+		{FieldMessage: "not msgpack"},
+	} {
+		_, err := c.XAdd(context.Background(), "wrp", 0, values)
+		require.NoError(t, err)
+	}
+
+	ds, err := con.Read(context.Background())
+	require.NoError(t, err)
+	require.Len(t, ds, 2)
+
+	assert.ErrorIs(t, ds[0].Err, ErrNoMessage)
+	assert.Error(t, ds[1].Err)
+	for _, d := range ds {
+		assert.Nil(t, d.Message)
+	}
+
+	assert.NoError(t, con.Ack(context.Background(), ds...))
+}
+
+func TestConsumer_errors(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := newMemClient()
+	con, err := NewConsumer(c, testStreams, "group", "consumer")
+	require.NoError(err)
+
+	c.err = errors.New("expected")
+	assert.ErrorIs(con.CreateGroup(context.Background()), c.err)
+
+	ds, err := con.Read(context.Background())
+	assert.ErrorIs(err, c.err)
+	assert.Nil(ds)
+
+	ds, err = con.Claim(context.Background(), time.Minute)
+	assert.ErrorIs(err, c.err)
+	assert.Empty(ds)
+
+	assert.ErrorIs(con.Ack(context.Background(), Delivery{Stream: "wrp:low", ID: "1-0"}), c.err)
+}
+
+func TestNew_invalid(t *testing.T) {
+	c := newMemClient()
+
+	tests := []struct {
+		desc     string
+		client   Client
+		streams  Streams
+		group    string
+		consumer string
+		popts    []ProducerOption
+		copts    []ConsumerOption
+	}{
+		{
+			desc:    "no client",
+			streams: testStreams,
+		}, {
+			desc:    "no streams",
+			client:  c,
+			streams: Streams{},
+		}, {
+			desc:    "max len",
+			client:  c,
+			streams: testStreams,
+			popts:   []ProducerOption{MaxLen(-1)},
+			copts:   []ConsumerOption{Count(0)},
+		}, {
+			desc:    "block",
+			client:  c,
+			streams: testStreams,
+			popts:   []ProducerOption{MaxLen(-1)},
+			copts:   []ConsumerOption{Block(0)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			p, err := NewProducer(tc.client, tc.streams, tc.popts...)
+			assert.ErrorIs(t, err, ErrInvalidConfig)
+			assert.Nil(t, p)
+
+			con, err := NewConsumer(tc.client, tc.streams, "group", "consumer", tc.copts...)
+			assert.ErrorIs(t, err, ErrInvalidConfig)
+			assert.Nil(t, con)
+		})
+	}
+
+	for _, names := range [][2]string{{"", "consumer"}, {"group", ""}} {
+		con, err := NewConsumer(c, testStreams, names[0], names[1])
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+		assert.Nil(t, con)
+	}
+}
@@ -0,0 +1,20 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package wrpredis queues WRP messages in Redis Streams, a lightweight
+alternative to Kafka for smaller deployments.  It does not depend on a Redis
+client: Client is the handful of stream commands the package uses, which are
+implemented by wrapping a client such as github.com/redis/go-redis.
+
+A Producer appends each message to the stream for its QOS level with XADD.
+The entry holds the msgpack encoded message in the FieldMessage field, and
+copies fields that are useful when inspecting a stream, such as the source and
+destination, to fields of their own.
+
+A Consumer reads the streams as a member of a consumer group with XREADGROUP,
+returning the messages of higher QOS streams first.  Deliveries are
+acknowledged with Ack once processed; deliveries that a failed consumer never
+acknowledged are taken over with Claim, which uses XAUTOCLAIM.
+*/
+package wrpredis
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpredis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// ProducerOption is a functional option for NewProducer.
+type ProducerOption interface {
+	apply(*Producer) error
+}
+
+type producerOptionFunc func(*Producer) error
+
+func (f producerOptionFunc) apply(p *Producer) error {
+	return f(p)
+}
+
+// MaxLen sets the approximate number of entries the streams are trimmed to as
+// messages are added.  By default, or if n is 0, the streams are not trimmed.
+func MaxLen(n int64) ProducerOption {
+	return producerOptionFunc(func(p *Producer) error {
+		if n < 0 {
+			return fmt.Errorf("%w: max length %d", ErrInvalidConfig, n)
+		}
+		p.maxLen = n
+		return nil
+	})
+}
+
+// Producer appends messages to the stream of their QOS level.  A Producer is
+// safe for concurrent use if its Client is.
+type Producer struct {
+	client  Client
+	streams Streams
+	maxLen  int64
+}
+
+// NewProducer creates a Producer that appends messages to the given streams.
+func NewProducer(c Client, s Streams, opts ...ProducerOption) (*Producer, error) {
+	if c == nil {
+		return nil, fmt.Errorf("%w: no client", ErrInvalidConfig)
+	}
+
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
+	p := Producer{
+		client:  c,
+		streams: s,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			if err := opt.apply(&p); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &p, nil
+}
+
+// Send appends the message to the stream of its QOS level, returning the
+// stream and the ID of the entry.
+func (p *Producer) Send(ctx context.Context, msg *wrp.Message) (stream string, id string, err error) {
+	var encoded []byte
+	if err := wrp.NewEncoderBytes(&encoded, wrp.Msgpack).Encode(msg); err != nil {
+		return "", "", err
+	}
+
+	values := map[string]interface{}{
+		FieldMessage:     encoded,
+		FieldMessageType: msg.Type.FriendlyName(),
+		FieldQOS:         strconv.Itoa(int(msg.QualityOfService)),
+	}
+
+	for field, value := range map[string]string{
+		FieldSource:          msg.Source,
+		FieldDestination:     msg.Destination,
+		FieldTransactionUUID: msg.TransactionUUID,
+	} {
+		if value != "" {
+			values[field] = value
+		}
+	}
+
+	stream = p.streams.For(msg.QualityOfService.Level())
+	id, err = p.client.XAdd(ctx, stream, p.maxLen, values)
+	if err != nil {
+		return "", "", err
+	}
+
+	return stream, id, nil
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpredis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// The fields of a stream entry.  FieldMessage holds the encoded message; the
+// other fields are copies of message fields for inspecting the stream.
+const (
+	FieldMessage         = "wrp"
+	FieldMessageType     = "msg_type"
+	FieldSource          = "source"
+	FieldDestination     = "dest"
+	FieldTransactionUUID = "transaction_uuid"
+	FieldQOS             = "qos"
+)
+
+var (
+	ErrInvalidConfig = errors.New("invalid redis streams configuration")
+	ErrNoMessage     = errors.New("stream entry has no message")
+)
+
+// Entry is an entry of a stream.
+type Entry struct {
+	// Stream is the name of the stream the entry was read from.
+	Stream string
+
+	// ID is the ID of the entry within its stream.
+	ID string
+
+	// Values are the fields of the entry.  Values are strings, or []byte when
+	// written by a Producer.
+	Values map[string]interface{}
+}
+
+// Client is the Redis stream commands used by this package.
+type Client interface {
+	// XAdd appends an entry to the stream with XADD, trimming the stream to
+	// approximately maxLen entries if maxLen is positive, and returns its ID.
+	XAdd(ctx context.Context, stream string, maxLen int64, values map[string]interface{}) (string, error)
+
+	// XGroupCreate creates the consumer group of the stream, creating the
+	// stream if it does not exist, with XGROUP CREATE ... MKSTREAM.  It is not
+	// an error if the group already exists.
+	XGroupCreate(ctx context.Context, stream, group, start string) error
+
+	// XReadGroup reads up to count new entries of each stream for the consumer
+	// of the group with XREADGROUP, waiting up to block for entries.  The
+	// entries are returned in the order of the streams.  No entries and no
+	// error are returned if none arrive before block ends.
+	XReadGroup(ctx context.Context, group, consumer string, streams []string, count int64, block time.Duration) ([]Entry, error)
+
+	// XAck acknowledges entries of the stream with XACK.
+	XAck(ctx context.Context, stream, group string, ids ...string) error
+
+	// XAutoClaim transfers up to count pending entries of the stream that have
+	// been idle for at least minIdle to the consumer with XAUTOCLAIM, starting
+	// at start, and returns them along with the start of the next call.
+	XAutoClaim(ctx context.Context, stream, group, consumer string, minIdle time.Duration, start string, count int64) ([]Entry, string, error)
+}
+
+// Streams are the names of the streams of each QOS level.
+type Streams [wrp.QOSCritical + 1]string
+
+// SingleStream returns the Streams that use one stream for every QOS level.
+func SingleStream(name string) Streams {
+	var s Streams
+	for i := range s {
+		s[i] = name
+	}
+
+	return s
+}
+
+// For returns the stream of the QOS level.
+func (s Streams) For(level wrp.QOSLevel) string {
+	return s[level]
+}
+
+// names returns the distinct streams, from the highest QOS level to the
+// lowest.
+func (s Streams) names() []string {
+	names := make([]string, 0, len(s))
+	for level := wrp.QOSCritical; level >= wrp.QOSLow; level-- {
+		if s[level] != "" && (len(names) == 0 || names[len(names)-1] != s[level]) {
+			names = append(names, s[level])
+		}
+	}
+
+	return names
+}
+
+func (s Streams) validate() error {
+	for level, name := range s {
+		if name == "" {
+			return fmt.Errorf("%w: no stream for %s QOS", ErrInvalidConfig, wrp.QOSLevel(level))
+		}
+	}
+
+	seen := make(map[string]bool, len(s))
+	names := s.names()
+	for _, name := range names {
+		if seen[name] {
+			return fmt.Errorf("%w: stream `%s` is used for QOS levels that are not adjacent", ErrInvalidConfig, name)
+		}
+		seen[name] = true
+	}
+
+	return nil
+}
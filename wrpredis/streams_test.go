@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpredis
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// pending is an entry delivered to a consumer that has not been acknowledged.
+type pending struct {
+	consumer  string
+	delivered time.Time
+}
+
+// memGroup is a consumer group of a memStream.
+type memGroup struct {
+	next    int
+	pending map[string]pending
+}
+
+type memStream struct {
+	entries []Entry
+	groups  map[string]*memGroup
+}
+
+// memClient is a Client that keeps its streams in memory.  Reads never block.
+type memClient struct {
+	streams map[string]*memStream
+	now     time.Time
+	maxLens []int64
+	claims  int
+	err     error
+}
+
+func newMemClient() *memClient {
+	return &memClient{
+		streams: make(map[string]*memStream),
+		now:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (c *memClient) stream(name string) *memStream {
+	s, ok := c.streams[name]
+	if !ok {
+		s = &memStream{groups: make(map[string]*memGroup)}
+		c.streams[name] = s
+	}
+
+	return s
+}
+
+func (c *memClient) XAdd(_ context.Context, stream string, maxLen int64, values map[string]interface{}) (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+
+	c.maxLens = append(c.maxLens, maxLen)
+	s := c.stream(stream)
+	id := strconv.Itoa(len(s.entries)+1) + "-0"
+	s.entries = append(s.entries, Entry{Stream: stream, ID: id, Values: values})
+	return id, nil
+}
+
+func (c *memClient) XGroupCreate(_ context.Context, stream, group, start string) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	s := c.stream(stream)
+	if _, ok := s.groups[group]; !ok {
+		s.groups[group] = &memGroup{pending: make(map[string]pending)}
+	}
+
+	return nil
+}
+
+func (c *memClient) XReadGroup(_ context.Context, group, consumer string, streams []string, count int64, _ time.Duration) ([]Entry, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	var entries []Entry
+	for _, name := range streams {
+		s := c.stream(name)
+		g := s.groups[group]
+		for n := int64(0); n < count && g.next < len(s.entries); n++ {
+			e := s.entries[g.next]
+			g.pending[e.ID] = pending{consumer: consumer, delivered: c.now}
+			entries = append(entries, e)
+			g.next++
+		}
+	}
+
+	return entries, nil
+}
+
+func (c *memClient) XAck(_ context.Context, stream, group string, ids ...string) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	for _, id := range ids {
+		delete(c.stream(stream).groups[group].pending, id)
+	}
+
+	return nil
+}
+
+// XAutoClaim scans up to count pending entries from start, and returns the
+// ID of the next entry to scan, or "0-0" once the scan is complete.  IDs are
+// of the form {sequence}-0, so entries are found by their sequence.
+func (c *memClient) XAutoClaim(_ context.Context, stream, group, consumer string, minIdle time.Duration, start string, count int64) ([]Entry, string, error) {
+	if c.err != nil {
+		return nil, "", c.err
+	}
+
+	c.claims++
+	s := c.stream(stream)
+	g := s.groups[group]
+
+	first, _ := strconv.Atoi(strings.TrimSuffix(start, "-0"))
+	first = max(first-1, 0)
+
+	var (
+		entries []Entry
+		scanned int64
+	)
+	for i := first; i < len(s.entries); i++ {
+		e := s.entries[i]
+		p, ok := g.pending[e.ID]
+		if !ok {
+			continue
+		}
+		if scanned == count {
+			return entries, e.ID, nil
+		}
+
+		scanned++
+		if c.now.Sub(p.delivered) >= minIdle {
+			g.pending[e.ID] = pending{consumer: consumer, delivered: c.now}
+			entries = append(entries, e)
+		}
+	}
+
+	return entries, "0-0", nil
+}
+
+func TestStreams(t *testing.T) {
+	assert := assert.New(t)
+
+	s := SingleStream("wrp")
+	assert.Equal("wrp", s.For(wrp.QOSCritical))
+	assert.Equal([]string{"wrp"}, s.names())
+	assert.NoError(s.validate())
+
+	s = Streams{"bulk", "bulk", "urgent", "urgent"}
+	assert.Equal("bulk", s.For(wrp.QOSMedium))
+	assert.Equal([]string{"urgent", "bulk"}, s.names())
+	assert.NoError(s.validate())
+
+	assert.ErrorIs(Streams{"a", "b", "a", "c"}.validate(), ErrInvalidConfig)
+	assert.ErrorIs(Streams{"a", "b", "", "c"}.validate(), ErrInvalidConfig)
+}
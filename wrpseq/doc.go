@@ -0,0 +1,20 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package wrpseq numbers the messages sent over a connection so that the
+receiver can detect lost, duplicated and reordered messages, to diagnose
+message loss between devices and the cloud.
+
+The sender creates a Stamper for each session, which stamps each outgoing
+message with the next sequence number, starting at 1, in the Metadata under
+DefaultKey.  The receiver creates a Tracker for each connection, which
+checks the sequence numbers of the received messages and reports each
+anomaly as an Event to a callback and to prometheus metrics created with
+NewMetrics.
+
+WRP has no field for a sequence number, so intermediaries that rewrite the
+Metadata may strip it; messages without a sequence number are ignored by a
+Tracker.
+*/
+package wrpseq
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpseq
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/xmidt-org/touchstone"
+)
+
+const (
+	// metricPrefix is prepended to all metrics exposed by this package.
+	metricPrefix = "wrp_sequence_"
+
+	// eventsTotalName is the name of the counter of sequence anomalies.
+	eventsTotalName = metricPrefix + "events_total"
+
+	// eventsTotalHelp is the help text for the events counter.
+	eventsTotalHelp = "the total number of gaps, late, duplicate, reset and invalid WRP sequence numbers"
+
+	// missingTotalName is the name of the counter of skipped sequence numbers.
+	missingTotalName = metricPrefix + "missing_total"
+
+	// missingTotalHelp is the help text for the missing counter.
+	missingTotalHelp = "the total number of WRP sequence numbers skipped by gaps"
+
+	// EventLabel is the label of the events counter, with the values of
+	// Kind.String.
+	EventLabel = "event"
+)
+
+// Metrics counts the events of Trackers.
+type Metrics struct {
+	events  *prometheus.CounterVec
+	missing prometheus.Counter
+}
+
+// NewMetrics creates the metrics of Trackers.  The underlying metrics can
+// only be registered once per touchstone.Factory.
+func NewMetrics(tf *touchstone.Factory) (*Metrics, error) {
+	events, err := tf.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: eventsTotalName,
+			Help: eventsTotalHelp,
+		},
+		EventLabel,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	missing, err := tf.NewCounter(
+		prometheus.CounterOpts{
+			Name: missingTotalName,
+			Help: missingTotalHelp,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Metrics{
+		events:  events,
+		missing: missing,
+	}, nil
+}
+
+func (m *Metrics) observe(e Event) {
+	m.events.With(prometheus.Labels{EventLabel: e.Kind.String()}).Inc()
+	if e.Missing > 0 {
+		m.missing.Add(float64(e.Missing))
+	}
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpseq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// DefaultKey is the Metadata key of the sequence number.
+const DefaultKey = "/wrp-seq"
+
+var ErrInvalidSequence = errors.New("invalid sequence number")
+
+// Sequence returns the sequence number of the message stored under the key.
+// The bool is false if the message has no sequence number.  A value that is
+// not a positive base 10 integer results in an error wrapping
+// ErrInvalidSequence.
+func Sequence(msg *wrp.Message, key string) (uint64, bool, error) {
+	v, ok := msg.Metadata[key]
+	if !ok {
+		return 0, false, nil
+	}
+
+	n, err := strconv.ParseUint(v, 10, 64)
+	if err != nil || n == 0 {
+		return 0, false, fmt.Errorf("%w: %s=`%s`", ErrInvalidSequence, key, v)
+	}
+
+	return n, true, nil
+}
+
+// SetSequence sets the sequence number of the message under the key.  The
+// Metadata is copied before it is changed, so other references to the
+// original Metadata are unaffected.
+func SetSequence(msg *wrp.Message, key string, n uint64) {
+	metadata := make(map[string]string, len(msg.Metadata)+1)
+	maps.Copy(metadata, msg.Metadata)
+	metadata[key] = strconv.FormatUint(n, 10)
+
+	msg.Metadata = metadata
+}
+
+// Stamper is a wrp.Modifier that stamps each message with the next sequence
+// number of a session, starting at 1.  Use a new Stamper for each session,
+// so that a Tracker sees the numbers restart along with the session.  A
+// Stamper is safe for concurrent use, though the numbers then follow the
+// order in which ModifyWRP is called rather than the order in which the
+// messages are written.
+type Stamper struct {
+	key  string
+	last atomic.Uint64
+}
+
+var _ wrp.Modifier = (*Stamper)(nil)
+
+// NewStamper creates a Stamper that stores the sequence number under the
+// key.  An empty key is DefaultKey.
+func NewStamper(key string) *Stamper {
+	if key == "" {
+		key = DefaultKey
+	}
+
+	return &Stamper{key: key}
+}
+
+// Stamp stamps the message with the next sequence number, which is returned.
+func (s *Stamper) Stamp(msg *wrp.Message) uint64 {
+	n := s.last.Add(1)
+	SetSequence(msg, s.key, n)
+	return n
+}
+
+// ModifyWRP returns the message stamped with the next sequence number.
+func (s *Stamper) ModifyWRP(_ context.Context, msg wrp.Message) (wrp.Message, error) {
+	s.Stamp(&msg)
+	return msg, nil
+}
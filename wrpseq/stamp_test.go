@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpseq
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestStamper(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	metadata := map[string]string{"/boot-time": "1"}
+	s := NewStamper("")
+
+	for i := uint64(1); i <= 3; i++ {
+		msg, err := s.ModifyWRP(context.Background(), wrp.Message{Metadata: metadata})
+		require.NoError(err)
+
+		n, ok, err := Sequence(&msg, DefaultKey)
+		require.NoError(err)
+		assert.True(ok)
+		assert.Equal(i, n)
+		assert.Equal("1", msg.Metadata["/boot-time"])
+	}
+
+	// the original metadata is unchanged
+	assert.Equal(map[string]string{"/boot-time": "1"}, metadata)
+
+	var msg wrp.Message
+	assert.Equal(uint64(1), NewStamper("/seq").Stamp(&msg))
+	assert.Equal("1", msg.Metadata["/seq"])
+}
+
+func TestSequence(t *testing.T) {
+	tests := []struct {
+		desc     string
+		metadata map[string]string
+		expected uint64
+		ok       bool
+		invalid  bool
+	}{
+		{desc: "missing"},
+		{desc: "valid", metadata: map[string]string{DefaultKey: "42"}, expected: 42, ok: true},
+		{desc: "zero", metadata: map[string]string{DefaultKey: "0"}, invalid: true},
+		{desc: "negative", metadata: map[string]string{DefaultKey: "-1"}, invalid: true},
+		{desc: "not a number", metadata: map[string]string{DefaultKey: "one"}, invalid: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			n, ok, err := Sequence(&wrp.Message{Metadata: tc.metadata}, DefaultKey)
+			assert.Equal(t, tc.expected, n)
+			assert.Equal(t, tc.ok, ok)
+			if tc.invalid {
+				assert.ErrorIs(t, err, ErrInvalidSequence)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
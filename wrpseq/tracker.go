@@ -0,0 +1,257 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpseq
+
+import (
+	"context"
+	"sync"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// DefaultWindow is the number of missing sequence numbers a Tracker
+// remembers by default, so that messages arriving late are told apart from
+// duplicates.
+const DefaultWindow = 1024
+
+// Kind is the kind of an anomaly in the sequence numbers.
+type Kind int
+
+const (
+	// Gap means that one or more sequence numbers were skipped, i.e. the
+	// messages were lost or are late.
+	Gap Kind = iota
+
+	// Late means that a skipped sequence number arrived, i.e. the messages
+	// were reordered.
+	Late
+
+	// Duplicate means that a sequence number was received again.
+	Duplicate
+
+	// Reset means that the sequence restarted, either because the sequence
+	// number went back to 1 or because the SessionID of the messages changed.
+	Reset
+
+	// Invalid means that the sequence number could not be parsed.
+	Invalid
+)
+
+// String returns the name of the kind, which is also the value of the event
+// label of the metrics.
+func (k Kind) String() string {
+	switch k {
+	case Gap:
+		return "gap"
+	case Late:
+		return "late"
+	case Duplicate:
+		return "duplicate"
+	case Reset:
+		return "reset"
+	case Invalid:
+		return "invalid"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes an anomaly in the sequence numbers of received messages.
+type Event struct {
+	// Kind is the kind of anomaly.
+	Kind Kind
+
+	// Message is the message that revealed the anomaly.
+	Message *wrp.Message
+
+	// Expected is the next sequence number that was expected, or 0 if no
+	// message has been tracked yet.
+	Expected uint64
+
+	// Sequence is the sequence number of the message, or 0 for an Invalid
+	// event.
+	Sequence uint64
+
+	// Missing is the number of skipped sequence numbers of a Gap event.
+	Missing uint64
+}
+
+// TrackerOption is a functional option for NewTracker.
+type TrackerOption interface {
+	apply(*Tracker)
+}
+
+type trackerOptionFunc func(*Tracker)
+
+func (f trackerOptionFunc) apply(t *Tracker) {
+	f(t)
+}
+
+// Key sets the Metadata key of the sequence number.  The default is
+// DefaultKey.
+func Key(key string) TrackerOption {
+	return trackerOptionFunc(func(t *Tracker) {
+		if key != "" {
+			t.key = key
+		}
+	})
+}
+
+// OnEvent sets a callback that is called with each Event.  The callback is
+// called while the Tracker is locked, so it must not call the Tracker.
+func OnEvent(f func(Event)) TrackerOption {
+	return trackerOptionFunc(func(t *Tracker) {
+		t.onEvent = f
+	})
+}
+
+// WithMetrics counts the events and the missing messages of the Tracker.
+// The same Metrics can be shared by the Trackers of every connection.
+func WithMetrics(m *Metrics) TrackerOption {
+	return trackerOptionFunc(func(t *Tracker) {
+		t.metrics = m
+	})
+}
+
+// Window sets the number of missing sequence numbers that are remembered.
+// Skipped messages that arrive more than window numbers late are reported
+// as duplicates.  The default is DefaultWindow.
+func Window(window int) TrackerOption {
+	return trackerOptionFunc(func(t *Tracker) {
+		if window > 0 {
+			t.window = uint64(window)
+		}
+	})
+}
+
+// Tracker is a wrp.Observer that checks the sequence numbers of the messages
+// received over a connection.  Use a new Tracker for each connection.  A
+// Tracker is safe for concurrent use.
+type Tracker struct {
+	key     string
+	onEvent func(Event)
+	metrics *Metrics
+	window  uint64
+
+	lock      sync.Mutex
+	started   bool
+	sessionID string
+	highest   uint64
+	missing   map[uint64]struct{}
+}
+
+var _ wrp.Observer = (*Tracker)(nil)
+
+// NewTracker creates a Tracker.
+func NewTracker(opts ...TrackerOption) *Tracker {
+	t := Tracker{
+		key:     DefaultKey,
+		window:  DefaultWindow,
+		missing: make(map[uint64]struct{}),
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt.apply(&t)
+		}
+	}
+
+	return &t
+}
+
+// ObserveWRP checks the sequence number of the message.  Messages without a
+// sequence number are ignored.
+func (t *Tracker) ObserveWRP(_ context.Context, msg wrp.Message) {
+	n, ok, err := Sequence(&msg, t.key)
+	if !ok && err == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if err != nil {
+		t.report(Event{Kind: Invalid, Message: &msg, Expected: t.expected()})
+		return
+	}
+
+	if t.started && msg.SessionID != t.sessionID {
+		t.report(Event{Kind: Reset, Message: &msg, Expected: t.expected(), Sequence: n})
+		t.restart()
+	}
+
+	t.started = true
+	t.sessionID = msg.SessionID
+
+	expected := t.highest + 1
+	switch {
+	case n == expected:
+		t.highest = n
+
+	case n > expected:
+		t.report(Event{Kind: Gap, Message: &msg, Expected: expected, Sequence: n, Missing: n - expected})
+		for i := max(expected, n-min(n, t.window)); i < n; i++ {
+			t.missing[i] = struct{}{}
+		}
+		t.highest = n
+		t.prune()
+
+	default:
+		if _, late := t.missing[n]; late {
+			delete(t.missing, n)
+			t.report(Event{Kind: Late, Message: &msg, Expected: expected, Sequence: n})
+		} else if n == 1 {
+			t.report(Event{Kind: Reset, Message: &msg, Expected: expected, Sequence: n})
+			t.restart()
+			t.highest = n
+		} else {
+			t.report(Event{Kind: Duplicate, Message: &msg, Expected: expected, Sequence: n})
+		}
+	}
+}
+
+// Missing returns the number of skipped sequence numbers that have not
+// arrived yet, up to the window.
+func (t *Tracker) Missing() int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return len(t.missing)
+}
+
+func (t *Tracker) expected() uint64 {
+	if !t.started {
+		return 0
+	}
+
+	return t.highest + 1
+}
+
+func (t *Tracker) restart() {
+	t.highest = 0
+	clear(t.missing)
+}
+
+// prune forgets the missing sequence numbers that are outside the window.
+func (t *Tracker) prune() {
+	if uint64(len(t.missing)) <= t.window {
+		return
+	}
+
+	for n := range t.missing {
+		if n+t.window < t.highest {
+			delete(t.missing, n)
+		}
+	}
+}
+
+func (t *Tracker) report(e Event) {
+	if t.metrics != nil {
+		t.metrics.observe(e)
+	}
+
+	if t.onEvent != nil {
+		t.onEvent(e)
+	}
+}
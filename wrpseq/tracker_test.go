@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpseq
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/sallust"
+	"github.com/xmidt-org/touchstone"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+type received struct {
+	seq     string
+	session string
+}
+
+func (r received) message() wrp.Message {
+	msg := wrp.Message{SessionID: r.session}
+	if r.seq != "" {
+		msg.Metadata = map[string]string{DefaultKey: r.seq}
+	}
+
+	return msg
+}
+
+// summary is an Event without its Message.
+type summary struct {
+	kind                        Kind
+	expected, sequence, missing uint64
+}
+
+func TestTracker(t *testing.T) {
+	tests := []struct {
+		desc     string
+		opts     []TrackerOption
+		received []received
+		expected []summary
+		missing  int
+	}{
+		{
+			desc:     "in order",
+			received: []received{{seq: "1"}, {seq: "2"}, {}, {seq: "3"}},
+		}, {
+			desc:     "first message lost",
+			received: []received{{seq: "2"}, {seq: "3"}},
+			expected: []summary{{kind: Gap, expected: 1, sequence: 2, missing: 1}},
+			missing:  1,
+		}, {
+			desc:     "gap then late",
+			received: []received{{seq: "1"}, {seq: "4"}, {seq: "2"}, {seq: "5"}},
+			expected: []summary{
+				{kind: Gap, expected: 2, sequence: 4, missing: 2},
+				{kind: Late, expected: 5, sequence: 2},
+			},
+			missing: 1,
+		}, {
+			desc:     "duplicate",
+			received: []received{{seq: "1"}, {seq: "2"}, {seq: "2"}, {seq: "3"}},
+			expected: []summary{{kind: Duplicate, expected: 3, sequence: 2}},
+		}, {
+			desc:     "restart",
+			received: []received{{seq: "1"}, {seq: "5"}, {seq: "1"}, {seq: "2"}},
+			expected: []summary{
+				{kind: Gap, expected: 2, sequence: 5, missing: 3},
+				{kind: Reset, expected: 6, sequence: 1},
+			},
+		}, {
+			desc:     "new session",
+			received: []received{{seq: "1", session: "a"}, {seq: "2", session: "a"}, {seq: "1", session: "b"}},
+			expected: []summary{{kind: Reset, expected: 3, sequence: 1}},
+		}, {
+			desc:     "invalid",
+			received: []received{{seq: "1"}, {seq: "x"}, {seq: "2"}},
+			expected: []summary{{kind: Invalid, expected: 2}},
+		}, {
+			desc:     "late beyond the window",
+			opts:     []TrackerOption{Window(2)},
+			received: []received{{seq: "1"}, {seq: "6"}, {seq: "2"}, {seq: "5"}},
+			expected: []summary{
+				{kind: Gap, expected: 2, sequence: 6, missing: 4},
+				{kind: Duplicate, expected: 7, sequence: 2},
+				{kind: Late, expected: 7, sequence: 5},
+			},
+			missing: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var events []summary
+			tracker := NewTracker(append(tc.opts, nil, OnEvent(func(e Event) {
+				require.NotNil(t, e.Message)
+				events = append(events, summary{
+					kind:     e.Kind,
+					expected: e.Expected,
+					sequence: e.Sequence,
+					missing:  e.Missing,
+				})
+			}))...)
+
+			for _, r := range tc.received {
+				tracker.ObserveWRP(context.Background(), r.message())
+			}
+
+			assert.Equal(t, tc.expected, events)
+			assert.Equal(t, tc.missing, tracker.Missing())
+		})
+	}
+}
+
+func TestTracker_stamper(t *testing.T) {
+	var events []Event
+	stamper := NewStamper("/seq")
+	tracker := NewTracker(Key("/seq"), OnEvent(func(e Event) {
+		events = append(events, e)
+	}))
+
+	for i := 0; i < 10; i++ {
+		var msg wrp.Message
+		stamper.Stamp(&msg)
+		if i != 4 {
+			tracker.ObserveWRP(context.Background(), msg)
+		}
+	}
+
+	require.Len(t, events, 1)
+	assert.Equal(t, Gap, events[0].Kind)
+	assert.Equal(t, uint64(5), events[0].Expected)
+	assert.Equal(t, 1, tracker.Missing())
+}
+
+func TestMetrics(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	cfg := touchstone.Config{
+		DefaultNamespace: "n",
+		DefaultSubsystem: "s",
+	}
+	_, pr, err := touchstone.New(cfg)
+	require.NoError(err)
+
+	tf := touchstone.NewFactory(cfg, sallust.Default(), pr)
+	m, err := NewMetrics(tf)
+	require.NoError(err)
+
+	tracker := NewTracker(WithMetrics(m))
+	for _, seq := range []int{1, 4, 4, 2} {
+		tracker.ObserveWRP(context.Background(), received{seq: strconv.Itoa(seq)}.message())
+	}
+
+	assert.Equal(1.0, testutil.ToFloat64(m.events.WithLabelValues(Gap.String())))
+	assert.Equal(1.0, testutil.ToFloat64(m.events.WithLabelValues(Duplicate.String())))
+	assert.Equal(1.0, testutil.ToFloat64(m.events.WithLabelValues(Late.String())))
+	assert.Equal(2.0, testutil.ToFloat64(m.missing))
+
+	// the metrics can only be registered once
+	m, err = NewMetrics(tf)
+	assert.Error(err)
+	assert.Nil(m)
+}
+
+func TestKind_String(t *testing.T) {
+	for _, k := range []Kind{Gap, Late, Duplicate, Reset, Invalid} {
+		assert.NotEqual(t, "unknown", k.String())
+	}
+
+	assert.Equal(t, "unknown", Kind(-1).String())
+}
@@ -0,0 +1,15 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+// Package wrpsession records WRP request/response exchanges into a JSON session
+// file and replays them, for debugging and regression tests.  The format is
+// modeled on HAR (HTTP Archive): a session is a list of entries, each with the
+// time the request started, how long it took in milliseconds, the request
+// message, and either the response message or the error.
+//
+// A Recorder captures exchanges as a wrpendpoint.Middleware or as a wrphttp
+// Handler decorator.  A recorded Session can be written with WriteTo, read back
+// with Read, and replayed against a wrpendpoint.Service with ReplayService or a
+// wrphttp.Handler with ReplayHandler.  Each replayed entry produces a Result
+// that can be compared with what was recorded.
+package wrpsession
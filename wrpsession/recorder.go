@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpsession
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/xmidt-org/wrp-go/v3"
+	"github.com/xmidt-org/wrp-go/v3/wrpendpoint"
+	"github.com/xmidt-org/wrp-go/v3/wrphttp"
+)
+
+// RecorderOption is a functional option for NewRecorder.
+type RecorderOption interface {
+	apply(*Recorder)
+}
+
+type recorderOptionFunc func(*Recorder)
+
+func (f recorderOptionFunc) apply(r *Recorder) {
+	f(r)
+}
+
+// Creator sets the Creator of recorded sessions.
+func Creator(creator string) RecorderOption {
+	return recorderOptionFunc(func(r *Recorder) {
+		r.creator = creator
+	})
+}
+
+// Clock sets the clock used to timestamp exchanges.  The default is
+// wrp.SystemClock.
+func Clock(clock wrp.Clock) RecorderOption {
+	return recorderOptionFunc(func(r *Recorder) {
+		if clock != nil {
+			r.clock = clock
+		}
+	})
+}
+
+// Now sets the function used to timestamp exchanges.  It is the same as
+// Clock(wrp.ClockFunc(now)).
+func Now(now func() time.Time) RecorderOption {
+	if now == nil {
+		return Clock(nil)
+	}
+
+	return Clock(wrp.ClockFunc(now))
+}
+
+// Redact sets a Redactor that is applied to the recorded messages, so that
+// session files can be archived or shared without sensitive data.  Replayed
+// responses are compared with the redacted ones, so a Result of a redacted
+// exchange may not match.
+func Redact(redactor *wrp.Redactor) RecorderOption {
+	return recorderOptionFunc(func(r *Recorder) {
+		r.redactor = redactor
+	})
+}
+
+// Recorder records WRP exchanges.  It is safe for concurrent use.
+type Recorder struct {
+	creator  string
+	clock    wrp.Clock
+	redactor *wrp.Redactor
+
+	lock    sync.Mutex
+	entries []Entry
+}
+
+// NewRecorder creates a Recorder.
+func NewRecorder(opts ...RecorderOption) *Recorder {
+	r := Recorder{
+		clock: wrp.SystemClock,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt.apply(&r)
+		}
+	}
+
+	return &r
+}
+
+// Record adds an exchange that started at the given time.  The messages are
+// copied, and redacted if a Redactor is set, so they may be reused by the
+// caller.
+func (r *Recorder) Record(started time.Time, request, response *wrp.Message, err error) {
+	e := Entry{
+		Started: started,
+		Time:    milliseconds(r.clock.Now().Sub(started)),
+	}
+
+	if request != nil {
+		e.Request = *r.redactor.Redact(request)
+	}
+	if response != nil {
+		e.Response = r.redactor.Redact(response)
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+
+	r.lock.Lock()
+	r.entries = append(r.entries, e)
+	r.lock.Unlock()
+}
+
+// Middleware records the exchanges of a wrpendpoint.Service.  It can be used
+// as a wrpendpoint.Middleware.
+func (r *Recorder) Middleware(next wrpendpoint.Service) wrpendpoint.Service {
+	return wrpendpoint.ServiceFunc(func(ctx context.Context, request wrpendpoint.Request) (wrpendpoint.Response, error) {
+		started := r.clock.Now()
+		response, err := next.ServeWRP(ctx, request)
+
+		var msg *wrp.Message
+		if response != nil {
+			msg = response.Message()
+		}
+
+		r.Record(started, request.Message(), msg, err)
+		return response, err
+	})
+}
+
+// Handler records the exchanges of a wrphttp.Handler.  The response is the
+// message written with WriteWRP or WriteWRPBytes, if any, and a status of 400
+// or more is recorded as an ErrStatus error.
+func (r *Recorder) Handler(next wrphttp.Handler) wrphttp.Handler {
+	return wrphttp.HandlerFunc(func(w wrphttp.ResponseWriter, request *wrphttp.Request) {
+		started := r.clock.Now()
+		cw := capturingResponseWriter{ResponseWriter: w}
+		next.ServeWRP(&cw, request)
+		r.Record(started, &request.Entity.Message, cw.response, cw.err)
+	})
+}
+
+// Session returns a copy of the recorded exchanges.
+func (r *Recorder) Session() *Session {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return &Session{
+		Version: Version,
+		Creator: r.creator,
+		Entries: slices.Clone(r.entries),
+	}
+}
+
+// Reset discards the recorded exchanges.
+func (r *Recorder) Reset() {
+	r.lock.Lock()
+	r.entries = nil
+	r.lock.Unlock()
+}
+
+// capturingResponseWriter keeps the message written to a wrphttp.ResponseWriter.
+type capturingResponseWriter struct {
+	wrphttp.ResponseWriter
+	response *wrp.Message
+	err      error
+}
+
+func (cw *capturingResponseWriter) WriteHeader(code int) {
+	if code >= http.StatusBadRequest && cw.err == nil {
+		cw.err = fmt.Errorf("%w: %d", ErrStatus, code)
+	}
+
+	cw.ResponseWriter.WriteHeader(code)
+}
+
+func (cw *capturingResponseWriter) WriteWRP(e *wrphttp.Entity) (int, error) {
+	n, err := cw.ResponseWriter.WriteWRP(e)
+	if err == nil {
+		msg := e.Message
+		cw.response = &msg
+	}
+
+	return n, err
+}
+
+func (cw *capturingResponseWriter) WriteWRPBytes(f wrp.Format, encoded []byte) (int, error) {
+	n, err := cw.ResponseWriter.WriteWRPBytes(f, encoded)
+	if err == nil {
+		var msg wrp.Message
+		if wrp.NewDecoderBytes(encoded, f).Decode(&msg) == nil {
+			cw.response = &msg
+		}
+	}
+
+	return n, err
+}
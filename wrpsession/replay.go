@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpsession
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/xmidt-org/wrp-go/v3"
+	"github.com/xmidt-org/wrp-go/v3/wrpendpoint"
+	"github.com/xmidt-org/wrp-go/v3/wrphttp"
+)
+
+var ErrStatus = errors.New("unsuccessful status")
+
+// Result is the outcome of replaying a single Entry.
+type Result struct {
+	// Entry is the recorded exchange.
+	Entry Entry
+
+	// Response is the response to the replayed request, if there was one.
+	Response *wrp.Message
+
+	// Err is the error returned instead of a response, if any.
+	Err error
+
+	// Duration is how long the replayed exchange took.
+	Duration time.Duration
+}
+
+// Matches tests if the replayed exchange had the same outcome as the recorded
+// one: either both failed, or both returned the same response.  Responses are
+// compared in their msgpack encoding, so that empty and missing fields are the
+// same.  Error texts are not compared, since they often contain details such as
+// addresses.
+func (r Result) Matches() bool {
+	if (r.Err != nil) != (r.Entry.Error != "") {
+		return false
+	}
+
+	if r.Response == nil || r.Entry.Response == nil {
+		return r.Response == r.Entry.Response
+	}
+
+	return bytes.Equal(encode(r.Response), encode(r.Entry.Response))
+}
+
+func encode(msg *wrp.Message) []byte {
+	var b []byte
+	_ = wrp.NewEncoderBytes(&b, wrp.Msgpack).Encode(msg)
+	return b
+}
+
+// ReplayOption is a functional option for ReplayService and ReplayHandler.
+type ReplayOption interface {
+	apply(*replayer)
+}
+
+type replayOptionFunc func(*replayer)
+
+func (f replayOptionFunc) apply(r *replayer) {
+	f(r)
+}
+
+// ReplayClock sets the clock used to measure the Duration of each Result.  The
+// default is wrp.SystemClock.
+func ReplayClock(clock wrp.Clock) ReplayOption {
+	return replayOptionFunc(func(r *replayer) {
+		if clock != nil {
+			r.clock = clock
+		}
+	})
+}
+
+type replayer struct {
+	clock wrp.Clock
+}
+
+func newReplayer(opts []ReplayOption) replayer {
+	r := replayer{
+		clock: wrp.SystemClock,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt.apply(&r)
+		}
+	}
+
+	return r
+}
+
+// ReplayService sends the request of each entry in the session to the service,
+// in order, and returns the result of each.  Replay stops early if the context
+// is canceled, in which case the results so far are returned with the
+// context's error.
+func ReplayService(ctx context.Context, s *Session, svc wrpendpoint.Service, opts ...ReplayOption) ([]Result, error) {
+	r := newReplayer(opts)
+	return r.replay(ctx, s, func(ctx context.Context, request *wrp.Message) (*wrp.Message, error) {
+		response, err := svc.ServeWRP(ctx, wrpendpoint.WrapAsRequest(log.NewNopLogger(), request))
+		if response == nil {
+			return nil, err
+		}
+
+		return response.Message(), err
+	})
+}
+
+// ReplayHandler is like ReplayService, except that each request is served by
+// a wrphttp.Handler.  Requests and responses are msgpack encoded, and a
+// response with a status of 400 or more results in an ErrStatus error.
+func ReplayHandler(ctx context.Context, s *Session, h wrphttp.Handler, opts ...ReplayOption) ([]Result, error) {
+	r := newReplayer(opts)
+	newResponseWriter := wrphttp.NewEntityResponseWriter(wrp.Msgpack)
+	return r.replay(ctx, s, func(ctx context.Context, request *wrp.Message) (*wrp.Message, error) {
+		var encoded []byte
+		if err := wrp.NewEncoderBytes(&encoded, wrp.Msgpack).Encode(request); err != nil {
+			return nil, err
+		}
+
+		original := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(encoded)).WithContext(ctx)
+		original.Header.Set("Content-Type", wrp.Msgpack.ContentType())
+		original.Header.Set("Accept", wrp.Msgpack.ContentType())
+
+		wrpRequest := (&wrphttp.Request{
+			Original: original,
+			Entity: &wrphttp.Entity{
+				Message: *request,
+				Format:  wrp.Msgpack,
+				Bytes:   encoded,
+			},
+		}).WithContext(wrp.ContextWithMessage(ctx, request))
+
+		recorder := httptest.NewRecorder()
+		w, err := newResponseWriter(recorder, wrpRequest)
+		if err != nil {
+			return nil, err
+		}
+
+		h.ServeWRP(w, wrpRequest)
+
+		var response *wrp.Message
+		if recorder.Body.Len() > 0 && recorder.Header().Get("Content-Type") == wrp.Msgpack.ContentType() {
+			response = new(wrp.Message)
+			if err := wrp.NewDecoderBytes(recorder.Body.Bytes(), wrp.Msgpack).Decode(response); err != nil {
+				return nil, err
+			}
+		}
+
+		if recorder.Code >= http.StatusBadRequest {
+			return response, fmt.Errorf("%w: %d", ErrStatus, recorder.Code)
+		}
+
+		return response, nil
+	})
+}
+
+func (r replayer) replay(ctx context.Context, s *Session, serve func(context.Context, *wrp.Message) (*wrp.Message, error)) ([]Result, error) {
+	results := make([]Result, 0, len(s.Entries))
+	for _, e := range s.Entries {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		request := e.Request.ReadOnly().Clone()
+		start := r.clock.Now()
+		response, err := serve(ctx, request)
+		results = append(results, Result{
+			Entry:    e,
+			Response: response,
+			Err:      err,
+			Duration: r.clock.Now().Sub(start),
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,236 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpsession
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+	"github.com/xmidt-org/wrp-go/v3/wrpendpoint"
+	"github.com/xmidt-org/wrp-go/v3/wrphttp"
+)
+
+var errOffline = errors.New("device offline")
+
+func retrieve(id string) *wrp.Message {
+	return &wrp.Message{
+		Type:            wrp.RetrieveMessageType,
+		Source:          "dns:example.com",
+		Destination:     "mac:112233445566/config",
+		TransactionUUID: id,
+		Path:            "/value",
+	}
+}
+
+// echo responds to every request, except for the transaction "offline".
+func echo(version string) func(*wrp.Message) (*wrp.Message, error) {
+	return func(request *wrp.Message) (*wrp.Message, error) {
+		if request.TransactionUUID == "offline" {
+			return nil, errOffline
+		}
+
+		return &wrp.Message{
+			Type:            request.Type,
+			Source:          request.Destination,
+			Destination:     request.Source,
+			TransactionUUID: request.TransactionUUID,
+			Payload:         []byte(version),
+		}, nil
+	}
+}
+
+func service(f func(*wrp.Message) (*wrp.Message, error)) wrpendpoint.Service {
+	return wrpendpoint.ServiceFunc(func(_ context.Context, r wrpendpoint.Request) (wrpendpoint.Response, error) {
+		response, err := f(r.Message())
+		if err != nil {
+			return nil, err
+		}
+		return wrpendpoint.WrapAsResponse(response), nil
+	})
+}
+
+func handler(f func(*wrp.Message) (*wrp.Message, error)) wrphttp.Handler {
+	return wrphttp.HandlerFunc(func(w wrphttp.ResponseWriter, r *wrphttp.Request) {
+		response, err := f(&r.Entity.Message)
+		if err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.WriteWRP(&wrphttp.Entity{Message: *response})
+	})
+}
+
+func TestRecorder_Middleware(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var (
+		now     = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		clock   = func() time.Time { now = now.Add(5 * time.Millisecond); return now }
+		r       = NewRecorder(Creator("test"), Now(clock), nil)
+		svc     = r.Middleware(service(echo("v1")))
+		request = retrieve("1")
+	)
+
+	_, err := svc.ServeWRP(context.Background(), wrpendpoint.WrapAsRequest(nil, request))
+	require.NoError(err)
+	_, err = svc.ServeWRP(context.Background(), wrpendpoint.WrapAsRequest(nil, retrieve("offline")))
+	require.ErrorIs(err, errOffline)
+
+	// the recording is not affected by later changes to the request
+	request.Path = "/changed"
+
+	s := r.Session()
+	assert.Equal(Version, s.Version)
+	assert.Equal("test", s.Creator)
+	require.Len(s.Entries, 2)
+
+	assert.Equal(time.Date(2025, 6, 1, 12, 0, 0, 5000000, time.UTC), s.Entries[0].Started)
+	assert.Equal(5.0, s.Entries[0].Time)
+	assert.Equal(*retrieve("1"), s.Entries[0].Request)
+	require.NotNil(s.Entries[0].Response)
+	assert.Equal([]byte("v1"), s.Entries[0].Response.Payload)
+	assert.Empty(s.Entries[0].Error)
+
+	assert.Equal("offline", s.Entries[1].Request.TransactionUUID)
+	assert.Nil(s.Entries[1].Response)
+	assert.Equal("device offline", s.Entries[1].Error)
+
+	r.Reset()
+	assert.Empty(r.Session().Entries)
+}
+
+func TestRecorder_Redact(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	redactor, err := wrp.NewRedactor(wrp.RedactFields(wrp.SourceField), wrp.RedactPayload(wrp.PayloadStrip))
+	require.NoError(err)
+
+	r := NewRecorder(Redact(redactor))
+	request := retrieve("1")
+	_, err = r.Middleware(service(echo("v1"))).ServeWRP(context.Background(), wrpendpoint.WrapAsRequest(nil, request))
+	require.NoError(err)
+
+	s := r.Session()
+	require.Len(s.Entries, 1)
+	assert.Equal(wrp.DefaultRedactionMask, s.Entries[0].Request.Source)
+	require.NotNil(s.Entries[0].Response)
+	assert.Equal(wrp.DefaultRedactionMask, s.Entries[0].Response.Source)
+	assert.Nil(s.Entries[0].Response.Payload)
+
+	// the exchange itself is not redacted
+	assert.Equal("dns:example.com", request.Source)
+}
+
+func TestReplayService(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	r := NewRecorder()
+	svc := r.Middleware(service(echo("v1")))
+	for _, id := range []string{"1", "offline", "2"} {
+		_, _ = svc.ServeWRP(context.Background(), wrpendpoint.WrapAsRequest(nil, retrieve(id)))
+	}
+
+	// replay a session that went through the file format
+	var b bytes.Buffer
+	_, err := r.Session().WriteTo(&b)
+	require.NoError(err)
+	s, err := Read(&b)
+	require.NoError(err)
+
+	results, err := ReplayService(context.Background(), s, service(echo("v1")))
+	require.NoError(err)
+	require.Len(results, 3)
+	for _, result := range results {
+		assert.True(result.Matches(), result.Entry.Request.TransactionUUID)
+	}
+	assert.ErrorIs(results[1].Err, errOffline)
+
+	// durations are measured with the clock
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := wrp.ClockFunc(func() time.Time { now = now.Add(5 * time.Millisecond); return now })
+	results, err = ReplayService(context.Background(), s, service(echo("v1")), ReplayClock(clock), nil)
+	require.NoError(err)
+	require.Len(results, 3)
+	for _, result := range results {
+		assert.Equal(5*time.Millisecond, result.Duration)
+	}
+
+	// a regression is reported as a mismatch
+	results, err = ReplayService(context.Background(), s, service(echo("v2")))
+	require.NoError(err)
+	require.Len(results, 3)
+	assert.False(results[0].Matches())
+	assert.True(results[1].Matches())
+	assert.Equal([]byte("v2"), results[2].Response.Payload)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err = ReplayService(ctx, s, service(echo("v1")))
+	assert.ErrorIs(err, context.Canceled)
+	assert.Empty(results)
+}
+
+func TestReplayHandler(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	r := NewRecorder()
+	h := wrphttp.NewHTTPHandler(r.Handler(handler(echo("v1"))))
+
+	// record through a real HTTP handler, then replay against the wrphttp.Handler
+	for _, id := range []string{"1", "offline"} {
+		var encoded []byte
+		require.NoError(wrp.NewEncoderBytes(&encoded, wrp.Msgpack).Encode(retrieve(id)))
+		request, err := http.NewRequest(http.MethodPost, "/", bytes.NewReader(encoded))
+		require.NoError(err)
+		request.Header.Set("Content-Type", wrp.Msgpack.ContentType())
+		h.ServeHTTP(discard{header: http.Header{}}, request)
+	}
+
+	s := r.Session()
+	require.Len(s.Entries, 2)
+	require.NotNil(s.Entries[0].Response)
+	assert.Nil(s.Entries[1].Response)
+	assert.Equal("unsuccessful status: 503", s.Entries[1].Error)
+
+	results, err := ReplayHandler(context.Background(), s, handler(echo("v1")))
+	require.NoError(err)
+	require.Len(results, 2)
+
+	assert.NoError(results[0].Err)
+	assert.Equal([]byte("v1"), results[0].Response.Payload)
+	assert.True(results[0].Matches())
+
+	assert.ErrorIs(results[1].Err, ErrStatus)
+	assert.Nil(results[1].Response)
+	assert.True(results[1].Matches())
+
+	// a handler that now fails is reported as a mismatch
+	results, err = ReplayHandler(context.Background(), s, handler(func(*wrp.Message) (*wrp.Message, error) {
+		return nil, errOffline
+	}))
+	require.NoError(err)
+	require.Len(results, 2)
+	assert.False(results[0].Matches())
+	assert.True(results[1].Matches())
+}
+
+// discard is an http.ResponseWriter that discards the response.
+type discard struct {
+	header http.Header
+}
+
+func (d discard) Header() http.Header         { return d.header }
+func (d discard) Write(b []byte) (int, error) { return len(b), nil }
+func (d discard) WriteHeader(int)             {}
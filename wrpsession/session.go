@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpsession
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// Version is the version of the session format written by this package.
+const Version = "1.0"
+
+var ErrInvalidSession = errors.New("invalid session")
+
+// Session is a recorded sequence of WRP exchanges.
+type Session struct {
+	// Version is the version of the session format.
+	Version string `json:"version"`
+
+	// Creator optionally names the program that recorded the session.
+	Creator string `json:"creator,omitempty"`
+
+	// Entries are the exchanges in the order they started.
+	Entries []Entry `json:"entries"`
+}
+
+// Entry is a single recorded exchange.
+type Entry struct {
+	// Started is when the request started.
+	Started time.Time `json:"startedDateTime"`
+
+	// Time is how long the exchange took, in milliseconds.
+	Time float64 `json:"time"`
+
+	// Request is the request message.
+	Request wrp.Message `json:"request"`
+
+	// Response is the response message, if there was one.
+	Response *wrp.Message `json:"response,omitempty"`
+
+	// Error is the text of the error returned instead of a response, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// Duration returns Time as a time.Duration.
+func (e Entry) Duration() time.Duration {
+	return time.Duration(e.Time * float64(time.Millisecond))
+}
+
+// milliseconds converts a duration to the Time of an Entry.
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// Read decodes a session from r.  Sessions of an unsupported version result in
+// an ErrInvalidSession error.
+func Read(r io.Reader) (*Session, error) {
+	var s Session
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSession, err)
+	}
+
+	if s.Version != Version {
+		return nil, fmt.Errorf("%w: unsupported version `%s`", ErrInvalidSession, s.Version)
+	}
+
+	return &s, nil
+}
+
+// WriteTo writes the session to w as indented JSON.  A session without a
+// Version is written with the current Version.
+func (s *Session) WriteTo(w io.Writer) (int64, error) {
+	out := *s
+	if out.Version == "" {
+		out.Version = Version
+	}
+	if out.Entries == nil {
+		out.Entries = []Entry{}
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(append(data, '\n'))
+	return int64(n), err
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpsession
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestSession_roundTrip(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s := Session{
+		Creator: "test",
+		Entries: []Entry{
+			{
+				Started: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+				Time:    12.5,
+				Request: wrp.Message{
+					Type:            wrp.RetrieveMessageType,
+					Source:          "dns:example.com",
+					Destination:     "mac:112233445566/config",
+					TransactionUUID: "1234",
+				},
+				Response: &wrp.Message{
+					Type:            wrp.RetrieveMessageType,
+					Source:          "mac:112233445566/config",
+					Destination:     "dns:example.com",
+					TransactionUUID: "1234",
+					Payload:         []byte{0x00, 0xff},
+				},
+			}, {
+				Started: time.Date(2025, 6, 1, 12, 0, 1, 0, time.UTC),
+				Request: wrp.Message{Type: wrp.SimpleEventMessageType, Source: "dns:example.com", Destination: "event:test"},
+				Error:   "device offline",
+			},
+		},
+	}
+
+	var b bytes.Buffer
+	n, err := s.WriteTo(&b)
+	require.NoError(err)
+	assert.Equal(int64(b.Len()), n)
+	assert.Contains(b.String(), `"version": "1.0"`)
+	assert.Contains(b.String(), `"startedDateTime": "2025-06-01T12:00:00Z"`)
+
+	actual, err := Read(&b)
+	require.NoError(err)
+
+	s.Version = Version
+	assert.Equal(&s, actual)
+	assert.Equal(12500*time.Microsecond, actual.Entries[0].Duration())
+}
+
+func TestSession_WriteTo_empty(t *testing.T) {
+	var b bytes.Buffer
+	_, err := new(Session).WriteTo(&b)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version":"1.0","entries":[]}`, b.String())
+}
+
+func TestRead_invalid(t *testing.T) {
+	tests := []struct {
+		desc  string
+		input string
+	}{
+		{
+			desc:  "not json",
+			input: "not json",
+		}, {
+			desc:  "missing version",
+			input: `{"entries":[]}`,
+		}, {
+			desc:  "unsupported version",
+			input: `{"version":"2.0","entries":[]}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			s, err := Read(strings.NewReader(tc.input))
+			assert.Nil(t, s)
+			assert.ErrorIs(t, err, ErrInvalidSession)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package wrpsim simulates devices producing realistic WRP traffic for load
+testing XMiDT components.
+
+A Simulator sends the events of its devices to a wrpendpoint.Service, e.g. a
+client of the component under test: Boot sends the online and
+fully-manageable device-status events of every device, and Run then sends a
+metadata event for every device each metadata interval, with each device
+sending at its own random offset into the interval.  The Clock of a Simulator
+only sets the timestamps of the events.  Run schedules them in real time,
+since it has to wait for them and a Clock cannot wake a timer.  A Simulator is
+also a Service itself, which answers requests addressed to its devices after
+a random latency, failing a configurable fraction of them.
+*/
+package wrpsim
@@ -0,0 +1,431 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpsim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/xmidt-org/wrp-go/v3"
+	"github.com/xmidt-org/wrp-go/v3/wrpendpoint"
+	"github.com/xmidt-org/wrp-go/v3/wrpevent"
+)
+
+const (
+	// DefaultMetadataInterval is how often each device sends a metadata event
+	// by default.
+	DefaultMetadataInterval = 5 * time.Minute
+
+	// MetadataClassifier is the classifier of the metadata events, whose
+	// Destination is event:metadata/{device id}.
+	MetadataClassifier wrpevent.Classifier = "metadata"
+)
+
+var (
+	ErrInvalidSimulator = errors.New("invalid simulator configuration")
+	ErrUnknownDevice    = errors.New("unknown simulated device")
+)
+
+// Option is a functional option for New.
+type Option interface {
+	apply(*Simulator) error
+}
+
+type optionFunc func(*Simulator) error
+
+func (f optionFunc) apply(s *Simulator) error {
+	return f(s)
+}
+
+// Devices sets the number of simulated devices, whose IDs are mac:000000000001,
+// mac:000000000002 and so on.  The default is 1.
+func Devices(n int) Option {
+	return optionFunc(func(s *Simulator) error {
+		if n <= 0 {
+			return fmt.Errorf("%w: %d devices", ErrInvalidSimulator, n)
+		}
+
+		s.devices = make([]wrp.DeviceID, n)
+		for i := range s.devices {
+			s.devices[i] = wrp.DeviceID(fmt.Sprintf("mac:%012x", i+1))
+		}
+		return nil
+	})
+}
+
+// DeviceIDs sets the IDs of the simulated devices.
+func DeviceIDs(ids ...wrp.DeviceID) Option {
+	return optionFunc(func(s *Simulator) error {
+		if len(ids) == 0 {
+			return fmt.Errorf("%w: no devices", ErrInvalidSimulator)
+		}
+		s.devices = append([]wrp.DeviceID(nil), ids...)
+		return nil
+	})
+}
+
+// PartnerIDs sets the partner IDs of the messages sent by the devices.
+func PartnerIDs(ids ...string) Option {
+	return optionFunc(func(s *Simulator) error {
+		s.partnerIDs = append([]string(nil), ids...)
+		return nil
+	})
+}
+
+// MetadataInterval sets how often each device sends a metadata event during
+// Run.  The default is DefaultMetadataInterval.
+func MetadataInterval(d time.Duration) Option {
+	return optionFunc(func(s *Simulator) error {
+		if d <= 0 {
+			return fmt.Errorf("%w: metadata interval %s", ErrInvalidSimulator, d)
+		}
+		s.interval = d
+		return nil
+	})
+}
+
+// Latency sets the range of the random latency of the responses to requests.
+// By default, requests are answered immediately.
+func Latency(min, max time.Duration) Option {
+	return optionFunc(func(s *Simulator) error {
+		if min < 0 || max < min {
+			return fmt.Errorf("%w: latency from %s to %s", ErrInvalidSimulator, min, max)
+		}
+		s.minLatency, s.maxLatency = min, max
+		return nil
+	})
+}
+
+// ErrorRate sets the fraction of requests, from 0 to 1, that are answered with
+// a 500 status.  The default is 0.
+func ErrorRate(rate float64) Option {
+	return optionFunc(func(s *Simulator) error {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("%w: error rate %g", ErrInvalidSimulator, rate)
+		}
+		s.errorRate = rate
+		return nil
+	})
+}
+
+// Seed sets the seed of the random latencies and failures, so that a
+// simulation is repeatable.  By default, the seed is random.
+func Seed(seed int64) Option {
+	return optionFunc(func(s *Simulator) error {
+		s.rand = rand.New(rand.NewSource(seed)) // nolint:gosec
+		return nil
+	})
+}
+
+// Clock sets the clock of the timestamps of the events.  The default is
+// wrp.SystemClock.  The Clock only affects the timestamps: Run schedules the
+// events in real time, since a Clock cannot wake the timers it waits on.
+func Clock(c wrp.Clock) Option {
+	return optionFunc(func(s *Simulator) error {
+		if c != nil {
+			s.clock = c
+		}
+		return nil
+	})
+}
+
+// Stats are the counts of the traffic of a Simulator.
+type Stats struct {
+	// Events is the number of events sent.
+	Events int64
+
+	// EventErrors is the number of events the Service returned an error for.
+	EventErrors int64
+
+	// Requests is the number of requests answered.
+	Requests int64
+
+	// Failures is the number of requests answered with a simulated failure.
+	Failures int64
+}
+
+// Simulator simulates a set of devices.  A Simulator is safe for concurrent
+// use.
+type Simulator struct {
+	out        wrpendpoint.Service
+	devices    []wrp.DeviceID
+	partnerIDs []string
+	interval   time.Duration
+	minLatency time.Duration
+	maxLatency time.Duration
+	errorRate  float64
+	clock      wrp.Clock
+
+	m    sync.Mutex
+	rand *rand.Rand
+
+	known   map[wrp.DeviceID]bool
+	booted  time.Time
+	events  atomic.Int64
+	errs    atomic.Int64
+	served  atomic.Int64
+	failed  atomic.Int64
+	counter atomic.Int64
+}
+
+var _ wrpendpoint.Service = (*Simulator)(nil)
+
+// New creates a Simulator whose devices send their events to out.
+func New(out wrpendpoint.Service, opts ...Option) (*Simulator, error) {
+	if out == nil {
+		return nil, fmt.Errorf("%w: no service", ErrInvalidSimulator)
+	}
+
+	s := Simulator{
+		out:      out,
+		devices:  []wrp.DeviceID{"mac:000000000001"},
+		interval: DefaultMetadataInterval,
+		clock:    wrp.SystemClock,
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())), // nolint:gosec
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			if err := opt.apply(&s); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	s.known = make(map[wrp.DeviceID]bool, len(s.devices))
+	for _, id := range s.devices {
+		s.known[id] = true
+	}
+
+	return &s, nil
+}
+
+// DeviceIDs returns the IDs of the simulated devices.
+func (s *Simulator) DeviceIDs() []wrp.DeviceID {
+	return append([]wrp.DeviceID(nil), s.devices...)
+}
+
+// Stats returns the counts of the traffic so far.
+func (s *Simulator) Stats() Stats {
+	return Stats{
+		Events:      s.events.Load(),
+		EventErrors: s.errs.Load(),
+		Requests:    s.served.Load(),
+		Failures:    s.failed.Load(),
+	}
+}
+
+// Boot sends the online and fully-manageable events of every device.  The
+// errors returned by the Service are joined.
+func (s *Simulator) Boot(ctx context.Context) error {
+	now := s.clock.Now()
+	s.m.Lock()
+	s.booted = now
+	s.m.Unlock()
+
+	var errs []error
+	for _, id := range s.devices {
+		for _, p := range []wrpevent.DeviceStatusPayload{
+			&wrpevent.OnlinePayload{
+				ID:         id,
+				Timestamp:  now,
+				PartnerIDs: s.partnerIDs,
+			},
+			&wrpevent.FullyManageablePayload{
+				ID:           id,
+				Timestamp:    now,
+				RebootReason: "power-on",
+				BootTime:     now.Unix(),
+			},
+		} {
+			se, err := wrpevent.NewSimpleEvent(string(id), p)
+			if err == nil {
+				err = s.send(ctx, &wrp.Message{
+					Type:        se.Type,
+					Source:      se.Source,
+					Destination: se.Destination,
+					ContentType: se.ContentType,
+					Payload:     se.Payload,
+				})
+			}
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Metadata sends a metadata event for every device.  The errors returned by
+// the Service are joined.
+func (s *Simulator) Metadata(ctx context.Context) error {
+	var errs []error
+	for _, id := range s.devices {
+		errs = append(errs, s.metadata(ctx, id))
+	}
+
+	return errors.Join(errs...)
+}
+
+// metadata sends the metadata event of a device.
+func (s *Simulator) metadata(ctx context.Context, id wrp.DeviceID) error {
+	now := s.clock.Now()
+	s.m.Lock()
+	booted := s.booted
+	s.m.Unlock()
+
+	metadata := map[string]string{
+		"/boot-time": strconv.FormatInt(booted.Unix(), 10),
+		"/uptime":    strconv.FormatInt(int64(now.Sub(booted)/time.Second), 10),
+		"/fw-name":   "wrpsim",
+	}
+
+	payload, _ := json.Marshal(metadata)
+	return s.send(ctx, &wrp.Message{
+		Type:        wrp.SimpleEventMessageType,
+		Source:      string(id),
+		Destination: wrpevent.New(MetadataClassifier, string(id)).Destination(),
+		ContentType: wrp.MimeTypeJson,
+		Metadata:    metadata,
+		Payload:     payload,
+	})
+}
+
+// scheduled is when a device sends its metadata events during Run.
+type scheduled struct {
+	id wrp.DeviceID
+
+	// offset is the time into each metadata interval of the device's event.
+	offset time.Duration
+}
+
+// schedule picks a random offset into the metadata interval for each device,
+// so that the devices do not all send their events at once, and returns the
+// devices in the order of their offsets.
+func (s *Simulator) schedule() []scheduled {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	devices := make([]scheduled, len(s.devices))
+	for i, id := range s.devices {
+		devices[i] = scheduled{
+			id:     id,
+			offset: time.Duration(s.rand.Int63n(int64(s.interval))),
+		}
+	}
+
+	sort.SliceStable(devices, func(i, j int) bool {
+		return devices[i].offset < devices[j].offset
+	})
+
+	return devices
+}
+
+// Run boots the devices and then sends the metadata event of each device
+// every metadata interval until the context ends.  Each device sends at its
+// own random offset into the interval, so the events are spread over the
+// interval rather than sent by every device at once.  Errors returned by the
+// Service are counted in the Stats rather than stopping the simulation.
+//
+// The events are scheduled in real time, whatever the Clock.  Run waits for
+// each event on a real timer, which a fake Clock cannot wake, and measuring
+// the schedule on one clock while waiting on another would skew it.
+func (s *Simulator) Run(ctx context.Context) error {
+	_ = s.Boot(ctx)
+
+	devices := s.schedule()
+
+	// the schedule is in real time, not the Clock's, as the timer is real
+	start := time.Now()
+
+	t := time.NewTimer(0)
+	defer t.Stop()
+	<-t.C
+
+	for interval := start; ; interval = interval.Add(s.interval) {
+		for _, d := range devices {
+			t.Reset(time.Until(interval.Add(d.offset)))
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-t.C:
+				_ = s.metadata(ctx, d.id)
+			}
+		}
+	}
+}
+
+func (s *Simulator) send(ctx context.Context, msg *wrp.Message) error {
+	msg.PartnerIDs = s.partnerIDs
+	msg.TransactionUUID = fmt.Sprintf("wrpsim-%d", s.counter.Add(1))
+
+	s.events.Add(1)
+	if _, err := s.out.ServeWRP(ctx, wrpendpoint.WrapAsRequest(log.NewNopLogger(), msg)); err != nil {
+		s.errs.Add(1)
+		return err
+	}
+
+	return nil
+}
+
+// ServeWRP answers a request addressed to one of the devices after a random
+// latency.  The response has a 200 status, or a 500 status for the requests
+// picked to fail by the error rate.  A request to another device results in
+// an error wrapping ErrUnknownDevice.
+func (s *Simulator) ServeWRP(ctx context.Context, r wrpendpoint.Request) (wrpendpoint.Response, error) {
+	request := r.Message()
+	l, err := wrp.ParseLocator(request.Destination)
+	if err != nil || !s.known[l.ID] {
+		return nil, fmt.Errorf("%w: `%s`", ErrUnknownDevice, request.Destination)
+	}
+
+	s.m.Lock()
+	latency := s.minLatency
+	if s.maxLatency > s.minLatency {
+		latency += time.Duration(s.rand.Int63n(int64(s.maxLatency - s.minLatency)))
+	}
+	fail := s.errorRate > 0 && s.rand.Float64() < s.errorRate
+	s.m.Unlock()
+
+	if latency > 0 {
+		t := time.NewTimer(latency)
+		defer t.Stop()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+
+	response := wrp.Message{
+		Type:            request.Type,
+		Source:          request.Destination,
+		Destination:     request.Source,
+		TransactionUUID: request.TransactionUUID,
+		ContentType:     request.ContentType,
+		PartnerIDs:      s.partnerIDs,
+		Payload:         request.Payload,
+	}
+	response.SetStatus(http.StatusOK)
+
+	s.served.Add(1)
+	if fail {
+		s.failed.Add(1)
+		response.SetStatus(http.StatusInternalServerError)
+		response.ContentType = wrp.MimeTypeTextPlain
+		response.Payload = []byte("simulated failure")
+	}
+
+	return wrpendpoint.WrapAsResponse(&response), nil
+}
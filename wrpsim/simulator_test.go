@@ -0,0 +1,227 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpsim
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+	"github.com/xmidt-org/wrp-go/v3/wrpendpoint"
+	"github.com/xmidt-org/wrp-go/v3/wrpevent"
+	"github.com/xmidt-org/wrp-go/v3/wrptest"
+)
+
+// recorder is a Service that records the messages it serves.
+type recorder struct {
+	m        sync.Mutex
+	messages []*wrp.Message
+	err      error
+}
+
+func (r *recorder) ServeWRP(_ context.Context, request wrpendpoint.Request) (wrpendpoint.Response, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.messages = append(r.messages, request.Message())
+	return nil, r.err
+}
+
+func (r *recorder) destinations() []string {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	dests := make([]string, len(r.messages))
+	for i, msg := range r.messages {
+		dests[i] = msg.Destination
+	}
+
+	return dests
+}
+
+func TestSimulator_events(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	clock := wrptest.NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	var out recorder
+	s, err := New(&out, Devices(2), PartnerIDs("comcast"), Clock(clock), nil)
+	require.NoError(err)
+	assert.Equal([]wrp.DeviceID{"mac:000000000001", "mac:000000000002"}, s.DeviceIDs())
+
+	require.NoError(s.Boot(context.Background()))
+	clock.Advance(time.Hour)
+	require.NoError(s.Metadata(context.Background()))
+
+	assert.Equal([]string{
+		"event:device-status/mac:000000000001/online",
+		"event:device-status/mac:000000000001/fully-manageable",
+		"event:device-status/mac:000000000002/online",
+		"event:device-status/mac:000000000002/fully-manageable",
+		"event:metadata/mac:000000000001",
+		"event:metadata/mac:000000000002",
+	}, out.destinations())
+
+	for i, msg := range out.messages {
+		assert.Equal(wrp.SimpleEventMessageType, msg.Type)
+		assert.Equal([]string{"comcast"}, msg.PartnerIDs)
+		assert.NotEmpty(msg.TransactionUUID)
+		assert.NotEmpty(msg.Payload, "message %d", i)
+	}
+
+	p, err := wrpevent.FromSimpleEvent(&wrp.SimpleEvent{
+		Destination: out.messages[0].Destination,
+		Payload:     out.messages[0].Payload,
+	})
+	require.NoError(err)
+	assert.Equal(&wrpevent.OnlinePayload{
+		ID:         "mac:000000000001",
+		Timestamp:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		PartnerIDs: []string{"comcast"},
+	}, p)
+
+	assert.Equal(map[string]string{
+		"/boot-time": "1735689600",
+		"/uptime":    "3600",
+		"/fw-name":   "wrpsim",
+	}, out.messages[4].Metadata)
+
+	assert.Equal(Stats{Events: 6}, s.Stats())
+}
+
+func TestSimulator_eventErrors(t *testing.T) {
+	out := recorder{err: errors.New("expected")}
+	s, err := New(&out, DeviceIDs("mac:112233445566"))
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, s.Boot(context.Background()), out.err)
+	assert.ErrorIs(t, s.Metadata(context.Background()), out.err)
+	assert.Equal(t, Stats{Events: 3, EventErrors: 3}, s.Stats())
+}
+
+func TestSimulator_Run(t *testing.T) {
+	var out recorder
+	s, err := New(&out, MetadataInterval(time.Millisecond))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+
+	require.Eventually(t, func() bool {
+		return s.Stats().Events >= 4
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	assert.NoError(t, <-done)
+	assert.Equal(t, "event:metadata/mac:000000000001", out.destinations()[2])
+}
+
+func TestSimulator_schedule(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s, err := New(&recorder{}, Devices(50), MetadataInterval(time.Minute), Seed(1))
+	require.NoError(err)
+
+	devices := s.schedule()
+	require.Len(devices, 50)
+
+	offsets := make(map[time.Duration]bool)
+	for i, d := range devices {
+		assert.GreaterOrEqual(d.offset, time.Duration(0))
+		assert.Less(d.offset, time.Minute)
+		if i > 0 {
+			assert.GreaterOrEqual(d.offset, devices[i-1].offset)
+		}
+		offsets[d.offset] = true
+	}
+
+	assert.Greater(len(offsets), 1, "the devices must not send in lockstep")
+
+	// the schedule is repeatable
+	s, err = New(&recorder{}, Devices(50), MetadataInterval(time.Minute), Seed(1))
+	require.NoError(err)
+	assert.Equal(devices, s.schedule())
+}
+
+func request(dest string) wrpendpoint.Request {
+	return wrpendpoint.WrapAsRequest(log.NewNopLogger(), &wrp.Message{
+		Type:            wrp.SimpleRequestResponseMessageType,
+		Source:          "dns:talaria.example.com",
+		Destination:     dest,
+		TransactionUUID: "1234",
+		ContentType:     "application/json",
+		Payload:         []byte(`{}`),
+	})
+}
+
+func TestSimulator_ServeWRP(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s, err := New(&recorder{}, Devices(1), Seed(1), ErrorRate(0.5), Latency(time.Millisecond, 2*time.Millisecond))
+	require.NoError(err)
+
+	statuses := make(map[int64]int)
+	for i := 0; i < 100; i++ {
+		start := time.Now()
+		response, err := s.ServeWRP(context.Background(), request("mac:000000000001/config"))
+		require.NoError(err)
+		assert.GreaterOrEqual(time.Since(start), time.Millisecond)
+
+		msg := response.Message()
+		assert.Equal("mac:000000000001/config", msg.Source)
+		assert.Equal("dns:talaria.example.com", msg.Destination)
+		assert.Equal("1234", msg.TransactionUUID)
+		statuses[*msg.Status]++
+	}
+
+	assert.Len(statuses, 2)
+	assert.InDelta(50, statuses[http.StatusOK], 20)
+
+	stats := s.Stats()
+	assert.Equal(int64(100), stats.Requests)
+	assert.Equal(int64(statuses[http.StatusInternalServerError]), stats.Failures)
+
+	_, err = s.ServeWRP(context.Background(), request("mac:000000000002/config"))
+	assert.ErrorIs(err, ErrUnknownDevice)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = s.ServeWRP(ctx, request("mac:000000000001/config"))
+	assert.ErrorIs(err, context.Canceled)
+}
+
+func TestNew_invalid(t *testing.T) {
+	tests := []struct {
+		desc string
+		out  wrpendpoint.Service
+		opts []Option
+	}{
+		{desc: "no service"},
+		{desc: "devices", out: &recorder{}, opts: []Option{Devices(0)}},
+		{desc: "device IDs", out: &recorder{}, opts: []Option{DeviceIDs()}},
+		{desc: "metadata interval", out: &recorder{}, opts: []Option{MetadataInterval(0)}},
+		{desc: "latency", out: &recorder{}, opts: []Option{Latency(time.Second, time.Millisecond)}},
+		{desc: "error rate", out: &recorder{}, opts: []Option{ErrorRate(1.5)}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			s, err := New(tc.out, tc.opts...)
+			assert.ErrorIs(t, err, ErrInvalidSimulator)
+			assert.Nil(t, s)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpsock
+
+import (
+	"context"
+	"net"
+)
+
+// Client is a connection to a Server.
+type Client struct {
+	*Conn
+}
+
+// Dial connects to the Server listening on the Unix domain socket at the path.
+func Dial(ctx context.Context, path string, opts ...Option) (*Client, error) {
+	c, err := newConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		Conn: newConn(conn, c),
+	}, nil
+}
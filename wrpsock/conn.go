@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpsock
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// Conn is a connection that carries framed WRP messages.  Send and Recv may be
+// called concurrently with each other, and each may be called from multiple
+// goroutines.
+type Conn struct {
+	conn         net.Conn
+	maxFrameSize int
+
+	wlock sync.Mutex
+	rlock sync.Mutex
+}
+
+func newConn(conn net.Conn, c config) *Conn {
+	return &Conn{
+		conn:         conn,
+		maxFrameSize: c.maxFrameSize,
+	}
+}
+
+// Send writes the message.  If the context ends before the message is
+// written, the context's error is returned and the connection should be
+// closed, since the peer may have received part of the frame.
+func (c *Conn) Send(ctx context.Context, msg *wrp.Message) error {
+	frame, err := encodeFrame(msg)
+	if err != nil {
+		return err
+	}
+
+	c.wlock.Lock()
+	defer c.wlock.Unlock()
+
+	return withContext(ctx, c.conn.SetWriteDeadline, func() error {
+		_, err := c.conn.Write(frame)
+		return err
+	})
+}
+
+// Recv reads the next message.  If the context ends before a message is read,
+// the context's error is returned.  As with Send, the connection should be
+// closed if this happens part way through a frame.
+func (c *Conn) Recv(ctx context.Context) (*wrp.Message, error) {
+	c.rlock.Lock()
+	defer c.rlock.Unlock()
+
+	var msg *wrp.Message
+	err := withContext(ctx, c.conn.SetReadDeadline, func() (err error) {
+		msg, err = ReadFrame(c.conn, c.maxFrameSize)
+		return err
+	})
+
+	return msg, err
+}
+
+// Close closes the connection.
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+// LocalAddr returns the local address of the connection.
+func (c *Conn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+// RemoteAddr returns the remote address of the connection.
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+// withContext runs an I/O operation that is interrupted when the context
+// ends, by moving the deadline into the past.
+func withContext(ctx context.Context, setDeadline func(time.Time) error, f func() error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	deadline, hasDeadline := ctx.Deadline()
+	_ = setDeadline(deadline)
+
+	interrupted := make(chan struct{})
+	stop := context.AfterFunc(ctx, func() {
+		defer close(interrupted)
+		_ = setDeadline(time.Unix(1, 0))
+	})
+
+	err := f()
+	if !stop() {
+		// Wait so the interruption does not affect the next operation.
+		<-interrupted
+	}
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// The connection's deadline may pass just before the context's.
+		if hasDeadline && errors.Is(err, os.ErrDeadlineExceeded) && !time.Now().Before(deadline) {
+			return context.DeadlineExceeded
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,15 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package wrpsock exchanges WRP messages between services on the same host over
+Unix domain sockets, without the overhead of HTTP.
+
+Each message is sent as a frame: a 4 byte big endian length followed by the
+msgpack encoded message.  A Server accepts connections and passes every
+message it reads to a Handler, which may reply on the same connection.  A
+Client dials a Server and sends and receives messages on its connection.
+Either side may send at any time; there is no request/response pairing at
+this layer.
+*/
+package wrpsock
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpsock
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// DefaultMaxFrameSize is the default size of the largest encoded message that
+// is read.
+const DefaultMaxFrameSize = 1024 * 1024
+
+// frameHeaderSize is the size of the length that precedes each message.
+const frameHeaderSize = 4
+
+var ErrFrameTooLarge = errors.New("frame too large")
+
+// WriteFrame encodes the message with msgpack and writes it as a single
+// frame.
+func WriteFrame(w io.Writer, msg *wrp.Message) error {
+	frame, err := encodeFrame(msg)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(frame)
+	return err
+}
+
+// encodeFrame encodes the message as a complete frame, so it can be written
+// with a single call.
+func encodeFrame(msg *wrp.Message) ([]byte, error) {
+	var b []byte
+	if err := wrp.NewEncoderBytes(&b, wrp.Msgpack).Encode(msg); err != nil {
+		return nil, err
+	}
+
+	if uint64(len(b)) > math.MaxUint32 {
+		return nil, fmt.Errorf("%w: %d bytes", ErrFrameTooLarge, len(b))
+	}
+
+	frame := make([]byte, frameHeaderSize, frameHeaderSize+len(b))
+	binary.BigEndian.PutUint32(frame, uint32(len(b)))
+	return append(frame, b...), nil
+}
+
+// ReadFrame reads a single frame and decodes its message.  A frame larger
+// than max bytes results in ErrFrameTooLarge, after which the reader is no
+// longer positioned at the start of a frame.
+func ReadFrame(r io.Reader, max int) (*wrp.Message, error) {
+	var header [frameHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(header[:])
+	if uint64(size) > uint64(max) {
+		return nil, fmt.Errorf("%w: %d bytes is more than %d", ErrFrameTooLarge, size, max)
+	}
+
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r, b); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	var msg wrp.Message
+	if err := wrp.NewDecoderBytes(b, wrp.Msgpack).Decode(&msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpsock
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func testMessage() *wrp.Message {
+	return &wrp.Message{
+		Type:            wrp.SimpleRequestResponseMessageType,
+		Source:          "dns:example.com/service",
+		Destination:     "mac:112233445566/config",
+		TransactionUUID: "c07ee5e1-70be-444c-a156-097c767ad8aa",
+		Payload:         []byte("payload"),
+	}
+}
+
+func TestFrame(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	msg := testMessage()
+	var body []byte
+	require.NoError(wrp.NewEncoderBytes(&body, wrp.Msgpack).Encode(msg))
+
+	var buf bytes.Buffer
+	require.NoError(WriteFrame(&buf, msg))
+	require.NoError(WriteFrame(&buf, msg))
+
+	want := binary.BigEndian.AppendUint32(nil, uint32(len(body)))
+	want = append(want, body...)
+	assert.Equal(append(want, want...), buf.Bytes())
+
+	for i := 0; i < 2; i++ {
+		got, err := ReadFrame(&buf, DefaultMaxFrameSize)
+		require.NoError(err)
+		assert.Equal(msg, got)
+	}
+
+	_, err := ReadFrame(&buf, DefaultMaxFrameSize)
+	assert.ErrorIs(err, io.EOF)
+}
+
+func TestReadFrameErrors(t *testing.T) {
+	var frame bytes.Buffer
+	require.NoError(t, WriteFrame(&frame, testMessage()))
+	b := frame.Bytes()
+
+	tests := []struct {
+		desc   string
+		input  []byte
+		max    int
+		expect error
+	}{
+		{
+			desc:   "too large",
+			input:  b,
+			max:    len(b) - frameHeaderSize - 1,
+			expect: ErrFrameTooLarge,
+		}, {
+			desc:   "truncated header",
+			input:  b[:2],
+			max:    DefaultMaxFrameSize,
+			expect: io.ErrUnexpectedEOF,
+		}, {
+			desc:   "truncated message",
+			input:  b[:len(b)-1],
+			max:    DefaultMaxFrameSize,
+			expect: io.ErrUnexpectedEOF,
+		}, {
+			desc:   "header only",
+			input:  b[:frameHeaderSize],
+			max:    DefaultMaxFrameSize,
+			expect: io.ErrUnexpectedEOF,
+		}, {
+			desc:  "not msgpack",
+			input: []byte{0, 0, 0, 1, 0xc1},
+			max:   DefaultMaxFrameSize,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := ReadFrame(bytes.NewReader(tc.input), tc.max)
+			assert.Nil(t, got)
+			require.Error(t, err)
+			if tc.expect != nil {
+				assert.ErrorIs(t, err, tc.expect)
+			}
+		})
+	}
+}
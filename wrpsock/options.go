@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpsock
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+var ErrInvalidOption = errors.New("invalid option")
+
+// config is the configuration shared by Servers and Clients.
+type config struct {
+	maxFrameSize int
+	onError      func(error)
+	clock        wrp.Clock
+}
+
+func newConfig(opts []Option) (config, error) {
+	c := config{
+		maxFrameSize: DefaultMaxFrameSize,
+		clock:        wrp.SystemClock,
+	}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt.apply(&c); err != nil {
+			return config{}, err
+		}
+	}
+
+	return c, nil
+}
+
+func (c *config) error(err error) {
+	if c.onError != nil {
+		c.onError(err)
+	}
+}
+
+// Option is a functional option for a Server or Client.
+type Option interface {
+	apply(*config) error
+}
+
+type optionFunc func(*config) error
+
+func (f optionFunc) apply(c *config) error {
+	return f(c)
+}
+
+// MaxFrameSize sets the size of the largest encoded message that is read.  A
+// connection that receives a larger message is closed.
+func MaxFrameSize(n int) Option {
+	return optionFunc(func(c *config) error {
+		if n < 1 {
+			return fmt.Errorf("%w: max frame size %d", ErrInvalidOption, n)
+		}
+		c.maxFrameSize = n
+		return nil
+	})
+}
+
+// OnError sets a function that is called with errors that are not returned to
+// a caller, e.g. connections that fail while a Server reads from them.
+func OnError(f func(error)) Option {
+	return optionFunc(func(c *config) error {
+		c.onError = f
+		return nil
+	})
+}
+
+// Clock sets the clock of the time each message is received, which a Server
+// adds to the context passed to its Handler with wrp.ContextWithReceived.  The
+// default is wrp.SystemClock.
+func Clock(clock wrp.Clock) Option {
+	return optionFunc(func(c *config) error {
+		if clock != nil {
+			c.clock = clock
+		}
+		return nil
+	})
+}
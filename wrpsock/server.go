@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpsock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+var ErrServerClosed = errors.New("server closed")
+
+// Handler handles the messages a Server reads.  Messages from a connection are
+// handled one at a time, in the order they were sent.  The handler may reply
+// by sending on the connection.
+type Handler interface {
+	HandleWRP(context.Context, *Conn, *wrp.Message)
+}
+
+// HandlerFunc is a function type that implements Handler.
+type HandlerFunc func(context.Context, *Conn, *wrp.Message)
+
+func (f HandlerFunc) HandleWRP(ctx context.Context, c *Conn, msg *wrp.Message) {
+	f(ctx, c, msg)
+}
+
+// Server accepts connections on Unix domain sockets and passes the messages it
+// reads to a Handler.
+type Server struct {
+	handler Handler
+	config  config
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	lock      sync.Mutex
+	listeners map[net.Listener]struct{}
+	conns     map[*Conn]struct{}
+}
+
+// NewServer creates a Server that passes messages to the handler.
+func NewServer(h Handler, opts ...Option) (*Server, error) {
+	if h == nil {
+		return nil, fmt.Errorf("%w: nil handler", ErrInvalidOption)
+	}
+
+	c, err := newConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	s := Server{
+		handler:   h,
+		config:    c,
+		listeners: make(map[net.Listener]struct{}),
+		conns:     make(map[*Conn]struct{}),
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	return &s, nil
+}
+
+// ListenAndServe listens on the Unix domain socket at the path and serves
+// connections until the Server is closed.  The path must not exist.
+func (s *Server) ListenAndServe(path string) error {
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+
+	return s.Serve(l)
+}
+
+// Serve accepts connections from the listener until the Server is closed, in
+// which case ErrServerClosed is returned.  The listener is closed when Serve
+// returns.
+func (s *Server) Serve(l net.Listener) error {
+	if !s.track(l) {
+		l.Close()
+		return ErrServerClosed
+	}
+	defer s.untrack(l)
+	defer l.Close()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if s.ctx.Err() != nil {
+				return ErrServerClosed
+			}
+			return err
+		}
+
+		c := newConn(conn, s.config)
+		if !s.trackConn(c) {
+			c.Close()
+			return ErrServerClosed
+		}
+
+		s.wg.Add(1)
+		go s.serve(c)
+	}
+}
+
+func (s *Server) serve(c *Conn) {
+	defer s.wg.Done()
+	defer s.untrackConn(c)
+	defer c.Close()
+
+	for {
+		msg, err := c.Recv(s.ctx)
+		if err != nil {
+			if s.ctx.Err() == nil && !errors.Is(err, io.EOF) {
+				s.config.error(err)
+			}
+			return
+		}
+
+		ctx := wrp.ContextWithReceived(s.ctx, s.config.clock.Now())
+		s.handler.HandleWRP(wrp.ContextWithMessage(ctx, msg), c, msg)
+	}
+}
+
+// Close stops the Server, closing its listeners and connections, and waits
+// for the handlers to return.
+func (s *Server) Close() error {
+	s.lock.Lock()
+	s.cancel()
+	for l := range s.listeners {
+		l.Close()
+	}
+	for c := range s.conns {
+		c.Close()
+	}
+	s.lock.Unlock()
+
+	s.wg.Wait()
+	return nil
+}
+
+func (s *Server) track(l net.Listener) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.ctx.Err() != nil {
+		return false
+	}
+
+	s.listeners[l] = struct{}{}
+	return true
+}
+
+func (s *Server) untrack(l net.Listener) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.listeners, l)
+}
+
+func (s *Server) trackConn(c *Conn) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.ctx.Err() != nil {
+		return false
+	}
+
+	s.conns[c] = struct{}{}
+	return true
+}
+
+func (s *Server) untrackConn(c *Conn) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.conns, c)
+}
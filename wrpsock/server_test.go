@@ -0,0 +1,265 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpsock
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+// echo replies to each message with a copy that has its source and
+// destination swapped.
+var echo = HandlerFunc(func(ctx context.Context, c *Conn, msg *wrp.Message) {
+	msg.Source, msg.Destination = msg.Destination, msg.Source
+	_ = c.Send(ctx, msg)
+})
+
+func startServer(t *testing.T, h Handler, opts ...Option) (*Server, string) {
+	s, err := NewServer(h, opts...)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "wrp.sock")
+	l, err := net.Listen("unix", path)
+	require.NoError(t, err)
+
+	served := make(chan error, 1)
+	go func() {
+		served <- s.Serve(l)
+	}()
+
+	t.Cleanup(func() {
+		assert.NoError(t, s.Close())
+		assert.ErrorIs(t, <-served, ErrServerClosed)
+	})
+
+	return s, path
+}
+
+func TestServer(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := testContext(t)
+
+	_, path := startServer(t, echo)
+
+	clients := make([]*Client, 3)
+	for i := range clients {
+		c, err := Dial(ctx, path)
+		require.NoError(err)
+		defer c.Close()
+		clients[i] = c
+	}
+
+	for _, c := range clients {
+		for i := 0; i < 10; i++ {
+			msg := testMessage()
+			msg.Payload = []byte{byte(i)}
+			require.NoError(c.Send(ctx, msg))
+		}
+	}
+
+	for _, c := range clients {
+		for i := 0; i < 10; i++ {
+			got, err := c.Recv(ctx)
+			require.NoError(err)
+			assert.Equal("mac:112233445566/config", got.Source)
+			assert.Equal("dns:example.com/service", got.Destination)
+			assert.Equal([]byte{byte(i)}, got.Payload)
+		}
+	}
+}
+
+func TestServerReceived(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := testContext(t)
+
+	received := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	stamp := wrp.StampHop("server", wrp.ClockFunc(func() time.Time { return received.Add(time.Millisecond) }))
+
+	h := HandlerFunc(func(ctx context.Context, c *Conn, msg *wrp.Message) {
+		stamped, err := stamp.ModifyWRP(ctx, *msg)
+		assert.NoError(err)
+		_ = c.Send(ctx, &stamped)
+	})
+
+	_, path := startServer(t, h, Clock(wrp.ClockFunc(func() time.Time { return received })))
+
+	c, err := Dial(ctx, path)
+	require.NoError(err)
+	defer c.Close()
+
+	require.NoError(c.Send(ctx, testMessage()))
+	got, err := c.Recv(ctx)
+	require.NoError(err)
+
+	hops, err := got.Hops()
+	require.NoError(err)
+	require.Len(hops, 1)
+	assert.Equal(time.Millisecond, hops[0].Duration())
+}
+
+func TestServerContext(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := testContext(t)
+
+	handled := make(chan *wrp.Message, 1)
+	h := HandlerFunc(func(ctx context.Context, c *Conn, msg *wrp.Message) {
+		fromContext, ok := wrp.MessageFromContext(ctx)
+		assert.True(ok)
+		assert.Same(msg, fromContext)
+
+		id, ok := wrp.DeviceIDFromContext(ctx)
+		assert.True(ok)
+		assert.Equal(wrp.DeviceID("mac:112233445566"), id)
+
+		handled <- fromContext
+	})
+
+	_, path := startServer(t, h)
+
+	c, err := Dial(ctx, path)
+	require.NoError(err)
+	defer c.Close()
+
+	require.NoError(c.Send(ctx, testMessage()))
+	select {
+	case msg := <-handled:
+		assert.Equal(testMessage(), msg)
+	case <-ctx.Done():
+		t.Fatal("the message was not handled")
+	}
+}
+
+func TestServerFrameTooLarge(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := testContext(t)
+
+	errs := make(chan error, 1)
+	_, path := startServer(t, echo, MaxFrameSize(16), OnError(func(err error) {
+		errs <- err
+	}))
+
+	c, err := Dial(ctx, path)
+	require.NoError(err)
+	defer c.Close()
+
+	require.NoError(c.Send(ctx, testMessage()))
+
+	select {
+	case err := <-errs:
+		assert.ErrorIs(err, ErrFrameTooLarge)
+	case <-ctx.Done():
+		assert.Fail("no error for a large frame")
+	}
+
+	// The server closes the connection.
+	_, err = c.Recv(ctx)
+	assert.Error(err)
+}
+
+func TestClientContext(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	silent := HandlerFunc(func(context.Context, *Conn, *wrp.Message) {})
+	_, path := startServer(t, silent)
+
+	c, err := Dial(testContext(t), path)
+	require.NoError(err)
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = c.Recv(ctx)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	_, err = c.Recv(ctx)
+	assert.ErrorIs(err, context.Canceled)
+
+	// A done context fails without any I/O.
+	assert.ErrorIs(c.Send(ctx, testMessage()), context.Canceled)
+
+	// The connection is still usable afterwards.
+	assert.NoError(c.Send(testContext(t), testMessage()))
+}
+
+func TestServerClosed(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s, err := NewServer(echo)
+	require.NoError(err)
+	require.NoError(s.Close())
+
+	path := filepath.Join(t.TempDir(), "wrp.sock")
+	assert.ErrorIs(s.ListenAndServe(path), ErrServerClosed)
+}
+
+func TestListenAndServe(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := testContext(t)
+
+	s, err := NewServer(echo)
+	require.NoError(err)
+
+	path := filepath.Join(t.TempDir(), "wrp.sock")
+	served := make(chan error, 1)
+	go func() {
+		served <- s.ListenAndServe(path)
+	}()
+
+	var c *Client
+	require.Eventually(func() bool {
+		c, err = Dial(ctx, path)
+		return err == nil
+	}, 5*time.Second, 5*time.Millisecond)
+	defer c.Close()
+
+	require.NoError(c.Send(ctx, testMessage()))
+	_, err = c.Recv(ctx)
+	assert.NoError(err)
+
+	require.NoError(s.Close())
+	assert.ErrorIs(<-served, ErrServerClosed)
+
+	_, err = c.Recv(ctx)
+	assert.True(errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed))
+}
+
+func TestInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := NewServer(nil)
+	assert.ErrorIs(err, ErrInvalidOption)
+
+	_, err = NewServer(echo, MaxFrameSize(0))
+	assert.ErrorIs(err, ErrInvalidOption)
+
+	_, err = Dial(testContext(t), filepath.Join(t.TempDir(), "wrp.sock"), MaxFrameSize(-1))
+	assert.ErrorIs(err, ErrInvalidOption)
+
+	_, err = Dial(testContext(t), filepath.Join(t.TempDir(), "missing.sock"))
+	assert.Error(err)
+}
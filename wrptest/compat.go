@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrptest
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// CompatDir is the directory, relative to this package, that holds the golden
+// fixtures of previous releases.  Each release has a subdirectory, e.g.
+// compat/v3.1.4, with a {name}.msgpack and a {name}.json file per vector, as
+// encoded by that release.  The fixtures are written by internal/compatgen,
+// which is built against the release itself.
+const CompatDir = "compat"
+
+//go:embed compat
+var compatFiles embed.FS
+
+// ErrWireIncompatible indicates that a message decodes differently than it
+// did in a previous release.
+var ErrWireIncompatible = errors.New("wire format is incompatible")
+
+// CompatFixtures returns the golden fixtures pinned in this package, rooted so
+// that each release is a top level directory.
+func CompatFixtures() fs.FS {
+	sub, err := fs.Sub(compatFiles, CompatDir)
+	if err != nil {
+		panic(err)
+	}
+
+	return sub
+}
+
+// CompatSuite checks that the wire format has not changed between releases.
+// Each message of the corpus is encoded with the current library, and both
+// that encoding and the golden fixture of every pinned release must decode
+// into the same message.  Since encodings may legitimately differ in the order
+// of map keys, messages are compared after decoding rather than byte for byte.
+//
+// A vector that has no fixture in a release, e.g. because it was added later,
+// is skipped for that release.
+type CompatSuite struct {
+	// Fixtures holds the golden fixtures, one directory per release.  If
+	// unset, CompatFixtures() is used.
+	Fixtures fs.FS
+
+	// Releases are the releases to check.  If unset, every directory in
+	// Fixtures is checked.
+	Releases []string
+
+	// Corpus are the messages to check.  If unset, Vectors() is used.
+	Corpus []Vector
+
+	// NewEncoder creates the encoder of the current library.  If unset,
+	// wrp.NewEncoderBytes is used.
+	NewEncoder func(*[]byte, wrp.Format) wrp.Encoder
+
+	// NewDecoder creates the decoder of the current library.  If unset,
+	// wrp.NewDecoderBytes is used.
+	NewDecoder func([]byte, wrp.Format) wrp.Decoder
+}
+
+// Run runs the suite.  Each release, vector and format is run as a subtest.
+func (s CompatSuite) Run(t *testing.T) {
+	s = s.withDefaults()
+
+	releases := s.Releases
+	if len(releases) == 0 {
+		var err error
+		releases, err = compatReleases(s.Fixtures)
+		require.NoError(t, err)
+		require.NotEmpty(t, releases, "no releases have been pinned")
+	}
+
+	for _, release := range releases {
+		for _, v := range s.Corpus {
+			for _, f := range wrp.AllFormats() {
+				release, v, f := release, v, f
+				t.Run(release+"/"+v.Name+"/"+f.String(), func(t *testing.T) {
+					err := s.check(release, v, f)
+					if errors.Is(err, fs.ErrNotExist) {
+						t.Skipf("%s has no fixture for %s", release, v.Name)
+					}
+
+					assert.NoError(t, err)
+				})
+			}
+		}
+	}
+}
+
+func (s CompatSuite) withDefaults() CompatSuite {
+	if s.Fixtures == nil {
+		s.Fixtures = CompatFixtures()
+	}
+
+	if s.Corpus == nil {
+		s.Corpus = Vectors()
+	}
+
+	if s.NewEncoder == nil {
+		s.NewEncoder = wrp.NewEncoderBytes
+	}
+
+	if s.NewDecoder == nil {
+		s.NewDecoder = wrp.NewDecoderBytes
+	}
+
+	return s
+}
+
+// check compares the release's fixture of the vector with the current
+// encoding.  The returned error wraps fs.ErrNotExist if there is no fixture.
+func (s CompatSuite) check(release string, v Vector, f wrp.Format) error {
+	golden, err := fs.ReadFile(s.Fixtures, path.Join(release, v.Filename(f)))
+	if err != nil {
+		return err
+	}
+
+	var current []byte
+	if err := s.NewEncoder(&current, f).Encode(&v.Message); err != nil {
+		return fmt.Errorf("encoding the message: %w", err)
+	}
+
+	var fromGolden, fromCurrent wrp.Message
+	if err := s.NewDecoder(golden, f).Decode(&fromGolden); err != nil {
+		return fmt.Errorf("decoding the %s fixture: %w", release, err)
+	}
+
+	if err := s.NewDecoder(current, f).Decode(&fromCurrent); err != nil {
+		return fmt.Errorf("decoding the current encoding: %w", err)
+	}
+
+	if !reflect.DeepEqual(v.Message, fromGolden) {
+		return fmt.Errorf("%w: the %s fixture decodes into %+v, expected %+v", ErrWireIncompatible, release, fromGolden, v.Message)
+	}
+
+	if !reflect.DeepEqual(fromGolden, fromCurrent) {
+		return fmt.Errorf("%w: the current encoding decodes into %+v, %s decodes into %+v", ErrWireIncompatible, fromCurrent, release, fromGolden)
+	}
+
+	return nil
+}
+
+// compatReleases returns the release directories in fixtures, sorted.
+func compatReleases(fixtures fs.FS) ([]string, error) {
+	entries, err := fs.ReadDir(fixtures, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []string
+	for _, e := range entries {
+		if e.IsDir() {
+			releases = append(releases, e.Name())
+		}
+	}
+
+	sort.Strings(releases)
+	return releases, nil
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrptest
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestCompatSuite(t *testing.T) {
+	CompatSuite{}.Run(t)
+}
+
+func TestCompatReleases(t *testing.T) {
+	releases, err := compatReleases(CompatFixtures())
+	require.NoError(t, err)
+	assert.Contains(t, releases, "v3.1.4")
+
+	releases, err = compatReleases(fstest.MapFS{
+		"v3.2.0/a.json":  {},
+		"v3.1.0/a.json":  {},
+		"README":         {},
+		"v3.10.0/a.json": {},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v3.1.0", "v3.10.0", "v3.2.0"}, releases)
+}
+
+func TestCompatSuite_MissingFixture(t *testing.T) {
+	v := Vector{
+		Name:    "new",
+		Message: wrp.Message{Type: wrp.SimpleEventMessageType, Source: "dns:example.com", Destination: "event:new"},
+	}
+
+	fixtures := fstest.MapFS{
+		"old/new.msgpack": {Data: wrp.MustEncode(&v.Message, wrp.Msgpack)},
+	}
+
+	s := CompatSuite{
+		Fixtures: fixtures,
+		Corpus:   []Vector{v},
+	}
+
+	assert.NoError(t, s.withDefaults().check("old", v, wrp.Msgpack))
+	assert.ErrorIs(t, s.withDefaults().check("old", v, wrp.JSON), fs.ErrNotExist)
+
+	// the format without a fixture is skipped rather than failed
+	assert.True(t, t.Run("suite", s.Run))
+}
+
+func TestCompatSuite_Break(t *testing.T) {
+	v := Vector{
+		Name:    "broken",
+		Message: wrp.Message{Type: wrp.SimpleEventMessageType, Source: "dns:example.com", Destination: "event:broken"},
+	}
+
+	// a fixture that no longer decodes into the corpus message
+	changed := v.Message
+	changed.Destination = "event:changed"
+
+	fixtures := fstest.MapFS{
+		"old/broken.msgpack": {Data: wrp.MustEncode(&changed, wrp.Msgpack)},
+		"old/broken.json":    {Data: wrp.MustEncode(&changed, wrp.JSON)},
+	}
+
+	s := CompatSuite{Fixtures: fixtures}.withDefaults()
+	for _, f := range wrp.AllFormats() {
+		assert.ErrorIs(t, s.check("old", v, f), ErrWireIncompatible)
+		assert.ErrorIs(t, s.check("missing", v, f), fs.ErrNotExist)
+	}
+}
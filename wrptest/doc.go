@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package wrptest provides utilities for testing code that produces or consumes
+WRP messages.
+
+Vectors returns the canonical test vectors: messages of each type along with
+their msgpack and JSON encodings.  The encodings are stored as plain files in
+the vectors directory.  RunDecoderVectors and RunEncoderVectors check any
+Decoder or Encoder implementation against the vectors.
+
+GenerateMessage produces arbitrary messages from a source of randomness for
+property based and fuzz testing.  GeneratorOptions control the message types,
+which optional fields are present and whether the message is deliberately
+invalid.
+
+CompatSuite guards against accidental changes to the wire format.  It encodes
+the vectors with the current library and checks that the encodings decode
+identically to the golden fixtures pinned from previous releases in the
+compat directory.  The fixtures are encoded by the releases themselves: the
+internal/compatgen command is a separate module that pins a release of wrp-go
+in its go.mod and writes that release's encodings of the vectors.
+
+FakeClock and SequentialIDs are fakes of wrp.Clock and wrp.IDGenerator that
+make time and identifier dependent behavior, such as rate limiting, expiry
+checks and generated transaction UUIDs, deterministic in tests.  Advancing a
+FakeClock does not fire timers, since a wrp.Clock only supplies timestamps.
+*/
+package wrptest
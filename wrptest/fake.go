@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrptest
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// FakeClock is a wrp.Clock whose time only changes when it is told to.  The
+// zero value is a clock stopped at the zero time.  A FakeClock is safe for
+// concurrent use.
+type FakeClock struct {
+	m   sync.Mutex
+	now time.Time
+}
+
+var _ wrp.Clock = (*FakeClock)(nil)
+
+// NewFakeClock creates a FakeClock stopped at the given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the current time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.now
+}
+
+// Set sets the current time of the clock.
+func (c *FakeClock) Set(now time.Time) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.now = now
+}
+
+// Advance moves the clock forward by d and returns the new time.
+func (c *FakeClock) Advance(d time.Duration) time.Time {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.now = c.now.Add(d)
+	return c.now
+}
+
+// SequentialIDs is a wrp.IDGenerator whose identifiers are version 4 UUIDs
+// numbered from 1, i.e. "00000000-0000-4000-8000-000000000001",
+// "00000000-0000-4000-8000-000000000002" and so on.  The zero value is ready
+// to use, and is safe for concurrent use.
+type SequentialIDs struct {
+	n atomic.Uint64
+}
+
+var _ wrp.IDGenerator = (*SequentialIDs)(nil)
+
+// NewID returns the next identifier.
+func (s *SequentialIDs) NewID() (string, error) {
+	return SequentialID(s.n.Add(1)), nil
+}
+
+// SequentialID returns the nth identifier returned by a SequentialIDs.
+func SequentialID(n uint64) string {
+	return fmt.Sprintf("00000000-0000-4000-8000-%012x", n&0xffffffffffff)
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrptest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestFakeClock(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+	assert.Equal(start, c.Now())
+	assert.Equal(start, c.Now())
+
+	assert.Equal(start.Add(time.Minute), c.Advance(time.Minute))
+	assert.Equal(start.Add(time.Minute), c.Now())
+
+	c.Set(start)
+	assert.Equal(start, c.Now())
+
+	var zero FakeClock
+	assert.True(zero.Now().IsZero())
+}
+
+func TestFakeClock_expiry(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+	msg := wrp.Message{Type: wrp.SimpleEventMessageType}
+	msg.SetExpiry(start.Add(time.Minute))
+
+	p := wrp.RejectExpired(c.Now)
+	assert.ErrorIs(p.ProcessWRP(context.Background(), msg), wrp.ErrNotHandled)
+
+	c.Advance(time.Minute)
+	assert.ErrorIs(p.ProcessWRP(context.Background(), msg), wrp.ErrExpired)
+}
+
+func TestSequentialIDs(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var ids SequentialIDs
+	for i := uint64(1); i <= 3; i++ {
+		id, err := ids.NewID()
+		require.NoError(err)
+		assert.Equal(SequentialID(i), id)
+
+		u, err := uuid.Parse(id)
+		require.NoError(err)
+		assert.Equal(uuid.Version(4), u.Version())
+	}
+
+	assert.Equal("00000000-0000-4000-8000-000000000001", SequentialID(1))
+
+	n := wrp.NewNormifier(wrp.EnsureTransactionUUIDFrom(&ids))
+	msg := wrp.Message{Type: wrp.SimpleEventMessageType}
+	require.NoError(n.Normify(&msg))
+	assert.Equal(SequentialID(4), msg.TransactionUUID)
+}
@@ -0,0 +1,332 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrptest
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// Presence determines whether GenerateMessage sets an optional field.
+type Presence int
+
+const (
+	// Sometimes sets the field at random.  This is the zero value.
+	Sometimes Presence = iota
+
+	// Always sets the field.
+	Always
+
+	// Never leaves the field unset.
+	Never
+)
+
+// DefaultMaxPayload is the largest payload generated when
+// GeneratorOptions.MaxPayload is not set.
+const DefaultMaxPayload = 256
+
+// GeneratorOptions controls the messages produced by GenerateMessage.  The
+// zero value generates valid messages of every valid type.
+type GeneratorOptions struct {
+	// Types are the message types to choose from.  If empty, every valid
+	// message type is used.
+	Types []wrp.MessageType
+
+	// Fields overrides the Presence of optional fields.  Fields that are
+	// required for the message type, such as the TransactionUUID of a
+	// request, are always set, and fields that are not allowed for the
+	// message type, such as the URL of an event, are never set, unless the
+	// message is deliberately invalid.  Type, Source and Destination are
+	// always set.  The deprecated Spans and IncludeSpans fields are never set.
+	Fields map[wrp.Field]Presence
+
+	// MaxPayload is the largest payload generated, in bytes.  If zero,
+	// DefaultMaxPayload is used.
+	MaxPayload int
+
+	// Invalid causes the generated message to break exactly one rule.  The
+	// message fails ValidateMessageType, ValidateSource, ValidateDestination,
+	// ValidateOnlyUTF8Strings or wrp.CrossFieldRules, or it is a request
+	// that is missing its TransactionUUID.
+	Invalid bool
+}
+
+// ValidMessageTypes returns the message types that pass
+// wrp.ValidateMessageType.
+func ValidMessageTypes() []wrp.MessageType {
+	types := make([]wrp.MessageType, 0, wrp.LastMessageType)
+	for t := wrp.Invalid1MessageType + 1; t < wrp.LastMessageType; t++ {
+		types = append(types, t)
+	}
+
+	return types
+}
+
+// GenerateMessage returns an arbitrary message for property based and fuzz
+// testing.  The same source of randomness and options always produce the
+// same message.
+//
+// Unless opts.Invalid is set, the message passes all of the package's
+// validators, the wrp.CrossFieldRules and, for requests, has a
+// TransactionUUID.
+func GenerateMessage(r *rand.Rand, opts GeneratorOptions) wrp.Message {
+	types := opts.Types
+	if len(types) == 0 {
+		types = ValidMessageTypes()
+	}
+
+	g := generator{
+		r:    r,
+		opts: opts,
+	}
+
+	msg := g.message(types[r.Intn(len(types))])
+	if opts.Invalid {
+		g.invalidate(&msg)
+	}
+
+	return msg
+}
+
+type generator struct {
+	r    *rand.Rand
+	opts GeneratorOptions
+}
+
+// field reports whether the field should be set, given whether the message
+// type requires or allows it.
+func (g generator) field(f wrp.Field, required, allowed bool) bool {
+	switch {
+	case required:
+		return true
+	case !allowed:
+		return false
+	}
+
+	switch g.opts.Fields[f] {
+	case Always:
+		return true
+	case Never:
+		return false
+	}
+
+	return g.r.Intn(2) == 0
+}
+
+func (g generator) message(mt wrp.MessageType) wrp.Message {
+	var (
+		request      = mt.RequiresTransaction()
+		registration = mt == wrp.ServiceRegistrationMessageType
+		msg          = wrp.Message{
+			Type:        mt,
+			Source:      g.locator(),
+			Destination: g.locator(),
+		}
+	)
+
+	if g.field(wrp.TransactionUUIDField, request, true) {
+		msg.TransactionUUID = g.uuid()
+	}
+	if g.field(wrp.ContentTypeField, false, true) {
+		msg.ContentType = pick(g.r, "application/json", "application/msgpack", "text/plain", "application/octet-stream")
+	}
+	if g.field(wrp.AcceptField, false, request) {
+		msg.Accept = pick(g.r, "application/json", "application/msgpack", "*/*")
+	}
+	if g.field(wrp.StatusField, mt == wrp.AuthorizationMessageType, request) {
+		status := g.r.Int63n(600)
+		msg.Status = &status
+	}
+	if g.field(wrp.RequestDeliveryResponseField, false, request) {
+		rdr := g.r.Int63n(2)
+		msg.RequestDeliveryResponse = &rdr
+	}
+	if g.field(wrp.HeadersField, false, true) {
+		msg.Headers = g.strings(func() string {
+			return g.token() + ": " + g.text()
+		})
+	}
+	if g.field(wrp.MetadataField, false, true) {
+		msg.Metadata = make(map[string]string)
+		for n := 1 + g.r.Intn(3); n > 0; n-- {
+			msg.Metadata["/"+g.token()] = g.text()
+		}
+	}
+	if g.field(wrp.PathField, wrp.IsCRUD(mt), true) {
+		msg.Path = "/" + g.token() + "/" + g.token()
+	}
+	if g.field(wrp.PayloadField, false, true) {
+		msg.Payload = g.payload()
+	}
+	if g.field(wrp.ServiceNameField, registration, registration) {
+		msg.ServiceName = g.token()
+	}
+	if g.field(wrp.URLField, registration, registration) {
+		msg.URL = "http://" + g.token() + ".example.com/" + g.token()
+	}
+	if g.field(wrp.PartnerIDsField, false, true) {
+		msg.PartnerIDs = g.strings(g.token)
+	}
+	if g.field(wrp.SessionIDField, false, true) {
+		msg.SessionID = g.uuid()
+	}
+	if g.field(wrp.QualityOfServiceField, false, mt.SupportsQOSAck()) {
+		msg.QualityOfService = wrp.QOSValue(g.r.Intn(100))
+	}
+
+	return msg
+}
+
+// invalidate breaks one of the rules that applies to the message.
+func (g generator) invalidate(msg *wrp.Message) {
+	mt := msg.Type
+
+	breakers := []func(){
+		func() {
+			msg.Type = pick(g.r, wrp.Invalid0MessageType, wrp.Invalid1MessageType, wrp.LastMessageType, -1)
+		},
+		func() {
+			msg.Source = g.invalidLocator()
+		},
+		func() {
+			msg.Destination = g.invalidLocator()
+		},
+		func() {
+			msg.SessionID = g.text() + "\xff\xfe"
+		},
+	}
+
+	if mt.RequiresTransaction() {
+		breakers = append(breakers, func() {
+			msg.TransactionUUID = ""
+		})
+	} else {
+		breakers = append(breakers, func() {
+			msg.Accept = "application/json"
+		})
+	}
+
+	if wrp.IsCRUD(mt) {
+		breakers = append(breakers, func() {
+			msg.Path = ""
+		})
+	}
+
+	if mt != wrp.AuthorizationMessageType && !mt.RequiresTransaction() {
+		breakers = append(breakers, func() {
+			status := int64(200)
+			msg.Status = &status
+		})
+	}
+
+	if mt != wrp.ServiceRegistrationMessageType {
+		breakers = append(breakers, func() {
+			msg.ServiceName = g.token()
+		})
+	}
+
+	breakers[g.r.Intn(len(breakers))]()
+}
+
+func pick[T any](r *rand.Rand, choices ...T) T {
+	return choices[r.Intn(len(choices))]
+}
+
+const (
+	hexDigits   = "0123456789abcdef"
+	tokenRunes  = "abcdefghijklmnopqrstuvwxyz0123456789-"
+	letterRunes = "abcdefghijklmnopqrstuvwxyz"
+)
+
+// textRunes includes multibyte characters to exercise UTF-8 handling.
+var textRunes = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 -_.,:;é世界😀")
+
+func (g generator) hex(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		b.WriteByte(hexDigits[g.r.Intn(len(hexDigits))])
+	}
+
+	return b.String()
+}
+
+// token returns a short string that is safe to use in locators, header names
+// and metadata keys.
+func (g generator) token() string {
+	var b strings.Builder
+	b.WriteByte(letterRunes[g.r.Intn(len(letterRunes))])
+	for n := g.r.Intn(12); n > 0; n-- {
+		b.WriteByte(tokenRunes[g.r.Intn(len(tokenRunes))])
+	}
+
+	return b.String()
+}
+
+// text returns an arbitrary, possibly empty, valid UTF-8 string.
+func (g generator) text() string {
+	runes := make([]rune, g.r.Intn(24))
+	for i := range runes {
+		runes[i] = textRunes[g.r.Intn(len(textRunes))]
+	}
+
+	return string(runes)
+}
+
+func (g generator) uuid() string {
+	return fmt.Sprintf("%s-%s-%s-%s-%s", g.hex(8), g.hex(4), g.hex(4), g.hex(4), g.hex(12))
+}
+
+func (g generator) strings(f func() string) []string {
+	s := make([]string, 1+g.r.Intn(3))
+	for i := range s {
+		s[i] = f()
+	}
+
+	return s
+}
+
+func (g generator) payload() []byte {
+	max := g.opts.MaxPayload
+	if max <= 0 {
+		max = DefaultMaxPayload
+	}
+
+	p := make([]byte, 1+g.r.Intn(max))
+	g.r.Read(p)
+	return p
+}
+
+// locator returns a valid locator of any scheme, with an optional service
+// and ignored suffix.
+func (g generator) locator() string {
+	var l string
+	switch g.r.Intn(5) {
+	case 0:
+		l = wrp.SchemeMAC + ":" + g.hex(12)
+	case 1:
+		l = wrp.SchemeUUID + ":" + g.uuid()
+	case 2:
+		l = wrp.SchemeSerial + ":" + strings.ToUpper(g.hex(10))
+	case 3:
+		l = wrp.SchemeDNS + ":" + g.token() + ".example.com"
+	default:
+		l = wrp.SchemeEvent + ":" + g.token()
+	}
+
+	if g.r.Intn(2) == 0 {
+		l += "/" + g.token()
+		if g.r.Intn(2) == 0 {
+			l += "/" + g.token()
+		}
+	}
+
+	return l
+}
+
+func (g generator) invalidLocator() string {
+	return pick(g.r, "", g.token(), wrp.SchemeMAC+":"+g.hex(5), wrp.SchemeDNS+":", wrp.SchemeEvent+":")
+}
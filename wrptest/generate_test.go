@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrptest
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// validate returns the first rule the message breaks.
+func validate(msg wrp.Message) error {
+	n := wrp.NewNormifier(
+		wrp.ValidateMessageType(),
+		wrp.ValidateSource(),
+		wrp.ValidateDestination(),
+		wrp.ValidateOnlyUTF8Strings(),
+	)
+	if err := n.Normify(&msg); err != nil {
+		return err
+	}
+
+	if err := wrp.CrossFieldRules().ProcessWRP(context.Background(), msg); !errors.Is(err, wrp.ErrNotHandled) {
+		return err
+	}
+
+	if msg.Type.RequiresTransaction() && msg.TransactionUUID == "" {
+		return errors.New("missing transaction uuid")
+	}
+
+	return nil
+}
+
+func TestGenerateMessage_valid(t *testing.T) {
+	r := rand.New(rand.NewSource(1)) // nolint:gosec
+	seen := make(map[wrp.MessageType]bool)
+
+	for i := 0; i < 1000; i++ {
+		msg := GenerateMessage(r, GeneratorOptions{})
+		require.NoError(t, validate(msg), "%+v", msg)
+		seen[msg.Type] = true
+
+		for _, f := range wrp.AllFormats() {
+			var (
+				encoded []byte
+				decoded wrp.Message
+			)
+
+			require.NoError(t, wrp.NewEncoderBytes(&encoded, f).Encode(&msg))
+			require.NoError(t, wrp.NewDecoderBytes(encoded, f).Decode(&decoded))
+			require.Equal(t, msg, decoded, f.String())
+		}
+	}
+
+	assert.Len(t, seen, len(ValidMessageTypes()))
+}
+
+func TestGenerateMessage_invalid(t *testing.T) {
+	r := rand.New(rand.NewSource(1)) // nolint:gosec
+	for i := 0; i < 1000; i++ {
+		msg := GenerateMessage(r, GeneratorOptions{Invalid: true})
+		require.Error(t, validate(msg), "%+v", msg)
+	}
+}
+
+func TestGenerateMessage_deterministic(t *testing.T) {
+	opts := GeneratorOptions{MaxPayload: 16}
+	a := GenerateMessage(rand.New(rand.NewSource(42)), opts) // nolint:gosec
+	b := GenerateMessage(rand.New(rand.NewSource(42)), opts) // nolint:gosec
+	assert.Equal(t, a, b)
+}
+
+func TestGenerateMessage_options(t *testing.T) {
+	tests := []struct {
+		desc  string
+		opts  GeneratorOptions
+		check func(*assert.Assertions, wrp.Message)
+	}{
+		{
+			desc: "types",
+			opts: GeneratorOptions{
+				Types: []wrp.MessageType{wrp.CreateMessageType, wrp.DeleteMessageType},
+			},
+			check: func(assert *assert.Assertions, msg wrp.Message) {
+				assert.Contains([]wrp.MessageType{wrp.CreateMessageType, wrp.DeleteMessageType}, msg.Type)
+				assert.NotEmpty(msg.Path)
+			},
+		}, {
+			desc: "always",
+			opts: GeneratorOptions{
+				Types: []wrp.MessageType{wrp.SimpleEventMessageType},
+				Fields: map[wrp.Field]Presence{
+					wrp.PayloadField:          Always,
+					wrp.MetadataField:         Always,
+					wrp.QualityOfServiceField: Always,
+					wrp.AcceptField:           Always,
+				},
+				MaxPayload: 8,
+			},
+			check: func(assert *assert.Assertions, msg wrp.Message) {
+				assert.NotEmpty(msg.Payload)
+				assert.LessOrEqual(len(msg.Payload), 8)
+				assert.NotEmpty(msg.Metadata)
+				assert.Empty(msg.Accept, "accept is not allowed on events")
+			},
+		}, {
+			desc: "never",
+			opts: GeneratorOptions{
+				Types: []wrp.MessageType{wrp.SimpleRequestResponseMessageType, wrp.ServiceRegistrationMessageType},
+				Fields: map[wrp.Field]Presence{
+					wrp.PayloadField:         Never,
+					wrp.HeadersField:         Never,
+					wrp.TransactionUUIDField: Never,
+					wrp.URLField:             Never,
+				},
+			},
+			check: func(assert *assert.Assertions, msg wrp.Message) {
+				assert.Nil(msg.Payload)
+				assert.Nil(msg.Headers)
+				if msg.Type == wrp.SimpleRequestResponseMessageType {
+					assert.NotEmpty(msg.TransactionUUID, "transaction uuid is required on requests")
+				} else {
+					assert.NotEmpty(msg.URL, "url is required on registrations")
+				}
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+			r := rand.New(rand.NewSource(1)) // nolint:gosec
+
+			for i := 0; i < 100; i++ {
+				msg := GenerateMessage(r, tc.opts)
+				assert.NoError(validate(msg))
+				tc.check(assert, msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+module github.com/xmidt-org/wrp-go/v3/wrptest/internal/compatgen
+
+go 1.21
+
+require github.com/xmidt-org/wrp-go/v3 v3.1.4
+
+require (
+	github.com/google/uuid v1.3.0 // indirect
+	github.com/ugorji/go/codec v1.2.7 // indirect
+	go.uber.org/atomic v1.9.0 // indirect
+	go.uber.org/multierr v1.8.0 // indirect
+)
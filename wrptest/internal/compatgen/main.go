@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+// Command compatgen writes the golden fixtures of a release of wrp-go for
+// wrptest.CompatSuite.  It is a separate module whose go.mod pins the release,
+// so the fixtures are encoded by the release itself rather than by the current
+// library.  To pin a release, change the required version of wrp-go in go.mod
+// and run, from this directory:
+//
+//	go mod tidy
+//	go run .
+//
+// Each vector of ../../vectors is decoded and encoded again with the release.
+// Vectors that use fields the release does not know are skipped, since the
+// release cannot produce them.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime/debug"
+	"strings"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+const modulePath = "github.com/xmidt-org/wrp-go/v3"
+
+func main() {
+	var (
+		vectors = flag.String("vectors", filepath.Join("..", "..", "vectors"), "the directory of the vectors")
+		compat  = flag.String("compat", filepath.Join("..", "..", "compat"), "the directory of the fixtures")
+	)
+
+	flag.Parse()
+	if err := run(*vectors, *compat); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// release returns the version of wrp-go this command is built with.
+func release() (string, error) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "", errors.New("no build information")
+	}
+
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			if dep.Replace != nil {
+				return "", fmt.Errorf("%s is replaced, so it is not a release", modulePath)
+			}
+			return dep.Version, nil
+		}
+	}
+
+	return "", fmt.Errorf("%s is not a dependency", modulePath)
+}
+
+func run(vectors, compat string) error {
+	version, err := release()
+	if err != nil {
+		return err
+	}
+
+	names, err := filepath.Glob(filepath.Join(vectors, "*.msgpack"))
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(compat, version)
+	if err := os.MkdirAll(dir, 0755); err != nil { // nolint:gosec
+		return err
+	}
+
+	for _, name := range names {
+		base := strings.TrimSuffix(filepath.Base(name), ".msgpack")
+		if err := pin(dir, name, base); err != nil {
+			return fmt.Errorf("%s: %w", base, err)
+		}
+	}
+
+	return nil
+}
+
+// pin writes the fixtures of one vector, if the release can encode it.
+func pin(dir, name, base string) error {
+	encoded, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+
+	var msg wrp.Message
+	if err := wrp.NewDecoderBytes(encoded, wrp.Msgpack).Decode(&msg); err != nil {
+		return err
+	}
+
+	fixtures := make(map[wrp.Format][]byte)
+	for _, f := range []wrp.Format{wrp.Msgpack, wrp.JSON} {
+		var b []byte
+		if err := wrp.NewEncoderBytes(&b, f).Encode(&msg); err != nil {
+			return err
+		}
+		fixtures[f] = b
+	}
+
+	if lost := lostFields(encoded, fixtures[wrp.Msgpack]); len(lost) > 0 {
+		fmt.Printf("skipping %s: the release does not know %s\n", base, strings.Join(lost, ", "))
+		return nil
+	}
+
+	for f, ext := range map[wrp.Format]string{wrp.Msgpack: ".msgpack", wrp.JSON: ".json"} {
+		if err := os.WriteFile(filepath.Join(dir, base+ext), fixtures[f], 0644); err != nil { // nolint:gosec
+			return err
+		}
+	}
+
+	fmt.Printf("pinned %s\n", base)
+	return nil
+}
+
+// lostFields returns the fields of the vector with a non-zero value that the
+// release's encoding does not have.
+func lostFields(vector, fixture []byte) []string {
+	fields := func(b []byte) map[string]interface{} {
+		var m map[string]interface{}
+		_ = wrp.NewDecoderBytes(b, wrp.Msgpack).Decode(&m)
+		return m
+	}
+
+	var (
+		lost    []string
+		encoded = fields(fixture)
+	)
+
+	for k, v := range fields(vector) {
+		if _, ok := encoded[k]; ok || isZero(v) {
+			continue
+		}
+		lost = append(lost, k)
+	}
+
+	return lost
+}
+
+func isZero(v interface{}) bool {
+	if b, ok := v.([]byte); ok {
+		return len(bytes.TrimSpace(b)) == 0
+	}
+
+	return v == nil || reflect.ValueOf(v).IsZero()
+}
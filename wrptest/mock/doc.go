@@ -0,0 +1,13 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package mock provides test doubles for the wrp.Observer, wrp.Processor and
+wrp.Modifier interfaces.
+
+Observer, Processor and Modifier are testify mocks, for tests that set
+expectations on the calls made.  Recorder and ScriptedProcessor are simple
+in-memory fakes, for tests that only need to see which messages were passed
+along or to control what is returned.
+*/
+package mock
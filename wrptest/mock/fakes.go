@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package mock
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// Recorder is a wrp.Observer that records every message it observes.  The
+// zero value is ready to use, and a Recorder is safe for concurrent use.
+type Recorder struct {
+	lock     sync.Mutex
+	messages []wrp.Message
+}
+
+var _ wrp.Observer = (*Recorder)(nil)
+
+// ObserveWRP records the message.
+func (r *Recorder) ObserveWRP(_ context.Context, msg wrp.Message) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.messages = append(r.messages, msg)
+}
+
+// Messages returns a copy of the recorded messages, in the order they were
+// observed.
+func (r *Recorder) Messages() []wrp.Message {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return slices.Clone(r.messages)
+}
+
+// Len returns the number of recorded messages.
+func (r *Recorder) Len() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return len(r.messages)
+}
+
+// Reset discards the recorded messages.
+func (r *Recorder) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.messages = nil
+}
+
+// ScriptedProcessor is a wrp.Processor that returns a scripted sequence of
+// results.  The nth call returns Results[n].  Once the script is exhausted,
+// each call returns Default.  Every message processed is recorded.
+//
+// The zero value handles every message successfully.  A ScriptedProcessor is
+// safe for concurrent use, but the fields must not be changed once it is in
+// use.
+type ScriptedProcessor struct {
+	// Results are returned in order, one per call.
+	Results []error
+
+	// Default is returned once Results are exhausted.
+	Default error
+
+	lock     sync.Mutex
+	messages []wrp.Message
+}
+
+var _ wrp.Processor = (*ScriptedProcessor)(nil)
+
+// NotHandled returns a ScriptedProcessor that never handles a message.
+func NotHandled() *ScriptedProcessor {
+	return &ScriptedProcessor{
+		Default: wrp.ErrNotHandled,
+	}
+}
+
+// ProcessWRP records the message and returns the next scripted result.
+func (p *ScriptedProcessor) ProcessWRP(_ context.Context, msg wrp.Message) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	n := len(p.messages)
+	p.messages = append(p.messages, msg)
+	if n < len(p.Results) {
+		return p.Results[n]
+	}
+
+	return p.Default
+}
+
+// Messages returns a copy of the processed messages, in the order they were
+// processed.
+func (p *ScriptedProcessor) Messages() []wrp.Message {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return slices.Clone(p.messages)
+}
+
+// Calls returns the number of times ProcessWRP has been called.
+func (p *ScriptedProcessor) Calls() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return len(p.messages)
+}
+
+// Reset discards the processed messages and restarts the script.
+func (p *ScriptedProcessor) Reset() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.messages = nil
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package mock
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestRecorder(t *testing.T) {
+	assert := assert.New(t)
+
+	var r Recorder
+	assert.Empty(r.Messages())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.ObserveWRP(context.Background(), wrp.Message{Type: wrp.SimpleEventMessageType})
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(10, r.Len())
+
+	messages := r.Messages()
+	assert.Len(messages, 10)
+	messages[0].Source = "changed"
+	assert.Empty(r.Messages()[0].Source)
+
+	r.Reset()
+	assert.Zero(r.Len())
+}
+
+func TestScriptedProcessor(t *testing.T) {
+	errFail := errors.New("fail")
+
+	tests := []struct {
+		desc     string
+		p        *ScriptedProcessor
+		expected []error
+	}{
+		{
+			desc:     "zero value",
+			p:        new(ScriptedProcessor),
+			expected: []error{nil, nil, nil},
+		}, {
+			desc:     "not handled",
+			p:        NotHandled(),
+			expected: []error{wrp.ErrNotHandled, wrp.ErrNotHandled, wrp.ErrNotHandled},
+		}, {
+			desc: "script then default",
+			p: &ScriptedProcessor{
+				Results: []error{errFail, nil},
+				Default: wrp.ErrNotHandled,
+			},
+			expected: []error{errFail, nil, wrp.ErrNotHandled, wrp.ErrNotHandled},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var sent []wrp.Message
+			for i, expected := range tc.expected {
+				msg := wrp.Message{Type: wrp.SimpleEventMessageType, TransactionUUID: string(rune('a' + i))}
+				sent = append(sent, msg)
+				assert.Equal(expected, tc.p.ProcessWRP(context.Background(), msg))
+			}
+
+			assert.Equal(len(tc.expected), tc.p.Calls())
+			assert.Equal(sent, tc.p.Messages())
+
+			tc.p.Reset()
+			assert.Zero(tc.p.Calls())
+			assert.Equal(tc.expected[0], tc.p.ProcessWRP(context.Background(), wrp.Message{}))
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package mock
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// Observer is a testify mock of wrp.Observer.
+type Observer struct {
+	mock.Mock
+}
+
+var _ wrp.Observer = (*Observer)(nil)
+
+func (m *Observer) ObserveWRP(ctx context.Context, msg wrp.Message) {
+	m.Called(ctx, msg)
+}
+
+// Processor is a testify mock of wrp.Processor.  The expectation's first
+// return value is the error, which may be omitted or nil.
+type Processor struct {
+	mock.Mock
+}
+
+var _ wrp.Processor = (*Processor)(nil)
+
+func (m *Processor) ProcessWRP(ctx context.Context, msg wrp.Message) error {
+	args := m.Called(ctx, msg)
+	if len(args) == 0 {
+		return nil
+	}
+	return args.Error(0)
+}
+
+// Modifier is a testify mock of wrp.Modifier.  The expectation's return
+// values are the message and the error.
+type Modifier struct {
+	mock.Mock
+}
+
+var _ wrp.Modifier = (*Modifier)(nil)
+
+func (m *Modifier) ModifyWRP(ctx context.Context, msg wrp.Message) (wrp.Message, error) {
+	args := m.Called(ctx, msg)
+	return args.Get(0).(wrp.Message), args.Error(1)
+}
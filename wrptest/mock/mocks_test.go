@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package mock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestMocks(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		ctx      = context.Background()
+		msg      = wrp.Message{Type: wrp.SimpleEventMessageType, Source: "dns:example.com"}
+		modified = wrp.Message{Type: wrp.SimpleEventMessageType, Source: "dns:other.example.com"}
+		errFail  = errors.New("fail")
+	)
+
+	o := new(Observer)
+	o.On("ObserveWRP", ctx, msg).Once()
+	o.ObserveWRP(ctx, msg)
+	o.AssertExpectations(t)
+
+	p := new(Processor)
+	p.On("ProcessWRP", ctx, msg).Return(errFail).Once()
+	p.On("ProcessWRP", ctx, modified).Once()
+	assert.ErrorIs(p.ProcessWRP(ctx, msg), errFail)
+	assert.NoError(p.ProcessWRP(ctx, modified))
+	p.AssertExpectations(t)
+
+	m := new(Modifier)
+	m.On("ModifyWRP", ctx, mock.Anything).Return(modified, nil).Once()
+	actual, err := m.ModifyWRP(ctx, msg)
+	assert.NoError(err)
+	assert.Equal(modified, actual)
+	m.AssertExpectations(t)
+}
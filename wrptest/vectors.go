@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrptest
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// VectorsDir is the directory, relative to this package, that holds the
+// encoded vectors.  Each vector has a {name}.msgpack and a {name}.json file.
+const VectorsDir = "vectors"
+
+//go:embed vectors
+var vectorFiles embed.FS
+
+// Vector is a single test vector: a message and its canonical encodings.
+type Vector struct {
+	// Name identifies the vector and is the base name of its files.
+	Name string
+
+	// Message is the expected result of decoding the vector.
+	Message wrp.Message
+}
+
+// Filename returns the name of the file, relative to VectorsDir, that holds
+// the vector's encoding in the given format.
+func (v Vector) Filename(f wrp.Format) string {
+	switch f {
+	case wrp.Msgpack:
+		return v.Name + ".msgpack"
+	case wrp.JSON:
+		return v.Name + ".json"
+	}
+
+	panic(fmt.Errorf("invalid format constant: %d", f))
+}
+
+// Encoded returns the vector's canonical encoding in the given format.
+func (v Vector) Encoded(f wrp.Format) []byte {
+	b, err := vectorFiles.ReadFile(path.Join(VectorsDir, v.Filename(f)))
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+// Vectors returns the test vectors.  Each call returns new values, so callers
+// are free to modify them.
+func Vectors() []Vector {
+	return []Vector{
+		{
+			Name: "simple-event",
+			Message: wrp.Message{
+				Type:        wrp.SimpleEventMessageType,
+				Source:      "mac:112233445566/event",
+				Destination: "event:device-status/mac:112233445566/online",
+				ContentType: "application/json",
+				Metadata:    map[string]string{"/boot-time": "1700000000"},
+				Payload:     []byte(`{"ts":"2023-11-14T22:13:20Z"}`),
+				PartnerIDs:  []string{"comcast"},
+				SessionID:   "session-1",
+			},
+		}, {
+			Name: "simple-event-binary-payload",
+			Message: wrp.Message{
+				Type:        wrp.SimpleEventMessageType,
+				Source:      "mac:112233445566",
+				Destination: "event:iot",
+				ContentType: "application/octet-stream",
+				Payload:     []byte{0x00, 0x01, 0x7f, 0x80, 0xfe, 0xff},
+			},
+		}, {
+			Name: "simple-request-response",
+			Message: wrp.Message{
+				Type:                    wrp.SimpleRequestResponseMessageType,
+				Source:                  "dns:scytale.example.com/api",
+				Destination:             "mac:112233445566/config",
+				TransactionUUID:         "c07ee5e1-70be-444c-a156-097c767ad8aa",
+				ContentType:             "application/json",
+				Accept:                  "application/json",
+				Status:                  int64Ptr(200),
+				RequestDeliveryResponse: int64Ptr(0),
+				Headers:                 []string{"X-Test: true"},
+				Payload:                 []byte(`{"command":"GET","names":["Device.DeviceInfo."]}`),
+				PartnerIDs:              []string{"comcast", "sky"},
+				QualityOfService:        75,
+			},
+		}, {
+			Name: "create",
+			Message: wrp.Message{
+				Type:            wrp.CreateMessageType,
+				Source:          "dns:example.com",
+				Destination:     "mac:112233445566/config",
+				TransactionUUID: "1",
+				Path:            "/tags",
+				Payload:         []byte(`{"tag":"a"}`),
+			},
+		}, {
+			Name: "retrieve",
+			Message: wrp.Message{
+				Type:            wrp.RetrieveMessageType,
+				Source:          "dns:example.com",
+				Destination:     "mac:112233445566/config",
+				TransactionUUID: "2",
+				Path:            "/tags",
+			},
+		}, {
+			Name: "update",
+			Message: wrp.Message{
+				Type:            wrp.UpdateMessageType,
+				Source:          "dns:example.com",
+				Destination:     "mac:112233445566/config",
+				TransactionUUID: "3",
+				Path:            "/tags/a",
+				Payload:         []byte(`{"tag":"b"}`),
+			},
+		}, {
+			Name: "delete",
+			Message: wrp.Message{
+				Type:            wrp.DeleteMessageType,
+				Source:          "dns:example.com",
+				Destination:     "mac:112233445566/config",
+				TransactionUUID: "4",
+				Path:            "/tags/b",
+				Status:          int64Ptr(200),
+			},
+		}, {
+			Name: "authorization",
+			Message: wrp.Message{
+				Type:   wrp.AuthorizationMessageType,
+				Status: int64Ptr(200),
+			},
+		}, {
+			Name: "service-registration",
+			Message: wrp.Message{
+				Type:        wrp.ServiceRegistrationMessageType,
+				ServiceName: "config",
+				URL:         "tcp://127.0.0.1:6666",
+			},
+		}, {
+			Name: "service-alive",
+			Message: wrp.Message{
+				Type: wrp.ServiceAliveMessageType,
+			},
+		}, {
+			Name: "unknown",
+			Message: wrp.Message{
+				Type: wrp.UnknownMessageType,
+			},
+		},
+	}
+}
+
+// RunDecoderVectors checks that decoders created by newDecoder decode every
+// vector, in every format, into the vector's Message.  Each vector and format
+// is run as a subtest.
+func RunDecoderVectors(t *testing.T, newDecoder func([]byte, wrp.Format) wrp.Decoder) {
+	for _, v := range Vectors() {
+		for _, f := range wrp.AllFormats() {
+			v, f := v, f
+			t.Run(v.Name+"/"+f.String(), func(t *testing.T) {
+				var actual wrp.Message
+				require.NoError(t, newDecoder(v.Encoded(f), f).Decode(&actual))
+				assert.Equal(t, v.Message, actual)
+			})
+		}
+	}
+}
+
+// RunEncoderVectors checks that encoders created by newEncoder encode every
+// vector, in every format, into output that the reference decoder in the wrp
+// package decodes into the vector's Message.  The output is not compared byte
+// for byte, since implementations may order map keys differently.  Each
+// vector and format is run as a subtest.
+func RunEncoderVectors(t *testing.T, newEncoder func(*[]byte, wrp.Format) wrp.Encoder) {
+	for _, v := range Vectors() {
+		for _, f := range wrp.AllFormats() {
+			v, f := v, f
+			t.Run(v.Name+"/"+f.String(), func(t *testing.T) {
+				var output []byte
+				require.NoError(t, newEncoder(&output, f).Encode(&v.Message))
+
+				var actual wrp.Message
+				require.NoError(t, wrp.NewDecoderBytes(output, f).Decode(&actual))
+				assert.Equal(t, v.Message, actual)
+			})
+		}
+	}
+}
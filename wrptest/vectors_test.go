@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrptest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+var update = flag.Bool("update", false, "rewrite the vector files from the reference encoder")
+
+func TestVectorFiles(t *testing.T) {
+	for _, v := range Vectors() {
+		for _, f := range wrp.AllFormats() {
+			expected := wrp.MustEncode(&v.Message, f)
+			if *update {
+				require.NoError(t, os.WriteFile(filepath.Join(VectorsDir, v.Filename(f)), expected, 0644)) // nolint:gosec
+				continue
+			}
+
+			// the reference encoder must still produce the canonical bytes
+			assert.Equal(t, expected, v.Encoded(f), "%s/%s", v.Name, f)
+		}
+	}
+}
+
+func TestRunDecoderVectors(t *testing.T) {
+	RunDecoderVectors(t, wrp.NewDecoderBytes)
+}
+
+func TestRunEncoderVectors(t *testing.T) {
+	RunEncoderVectors(t, wrp.NewEncoderBytes)
+}
+
+func TestVector_Filename(t *testing.T) {
+	v := Vector{Name: "v"}
+	assert.Equal(t, "v.msgpack", v.Filename(wrp.Msgpack))
+	assert.Equal(t, "v.json", v.Filename(wrp.JSON))
+	assert.Panics(t, func() { v.Filename(wrp.Format(-1)) })
+	assert.Panics(t, func() { v.Encoded(wrp.Msgpack) })
+}
@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package wrptrace carries distributed trace context through WRP messages, so
+traces started by services instrumented with xmidt-org/candlelight, or any
+other OpenTelemetry based tracing, continue across the WRP hops between them.
+
+A Propagator extracts and injects a SpanContext in the W3C trace context and
+B3 formats, the formats candlelight propagates.  The same Propagator works
+with the Metadata of a message and with HTTP headers, through a Carrier, so
+the trace context is written the same way in both.  The keys in Metadata are
+the lower case names of the HTTP headers, e.g. traceparent and b3.
+
+This package does not depend on a tracing library.  Services adapt their
+tracer, e.g. the one configured by candlelight, to the Tracer interface, which
+the tracing middleware of wrpendpoint uses to start a span for each message.
+Such an adapter, and any dependency on candlelight or OpenTelemetry, is out of
+scope for this package; it belongs with the service's tracing setup.
+
+Trace context is parsed by the same code as the trace IDs that a
+wrp.LogObserver logs, so log entries and spans agree on the trace of a
+message.
+*/
+package wrptrace
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrptrace
+
+import (
+	"context"
+	"maps"
+	"net/http"
+
+	"github.com/xmidt-org/wrp-go/v3"
+	"github.com/xmidt-org/wrp-go/v3/internal/tracecontext"
+)
+
+// The keys of the trace context.  The same names are used for HTTP headers
+// and, in lower case, for Metadata keys.
+const (
+	TraceParentKey = tracecontext.TraceParentKey
+	TraceStateKey  = tracecontext.TraceStateKey
+	B3Key          = tracecontext.B3Key
+	B3TraceIDKey   = tracecontext.B3TraceIDKey
+	B3SpanIDKey    = tracecontext.B3SpanIDKey
+	B3SampledKey   = tracecontext.B3SampledKey
+	B3FlagsKey     = tracecontext.B3FlagsKey
+)
+
+// Format is a format of trace context.
+type Format int
+
+const (
+	// W3C is the W3C trace context format, i.e. the traceparent and
+	// tracestate keys.
+	W3C Format = iota
+
+	// B3Single is the single key B3 format, i.e. the b3 key.
+	B3Single
+
+	// B3Multi is the multiple key B3 format, i.e. the x-b3-* keys.
+	B3Multi
+)
+
+// SpanContext identifies a span of a trace.  Its IsValid method returns true
+// if the trace and span IDs are valid and not all zeros.
+type SpanContext = tracecontext.SpanContext
+
+type spanContextKey struct{}
+
+// ContextWithSpanContext returns a context that carries the span context,
+// e.g. of the span of the message being handled, so that the messages sent
+// while handling it can be injected with it.
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// SpanContextFromContext returns the span context carried by the context, if
+// any.
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	sc, ok := ctx.Value(spanContextKey{}).(SpanContext)
+	return sc, ok
+}
+
+// Carrier holds the keys of a trace context.
+type Carrier interface {
+	// Get returns the value of the key, or an empty string.
+	Get(key string) string
+
+	// Set sets the value of the key.
+	Set(key, value string)
+}
+
+// HeaderCarrier is a Carrier of HTTP headers.
+type HeaderCarrier http.Header
+
+func (c HeaderCarrier) Get(key string) string {
+	return http.Header(c).Get(key)
+}
+
+func (c HeaderCarrier) Set(key, value string) {
+	http.Header(c).Set(key, value)
+}
+
+// MetadataCarrier is a Carrier of the Metadata of a message.  The Metadata is
+// copied before it is first changed, so other references to the original
+// Metadata are unaffected.
+type MetadataCarrier struct {
+	msg    *wrp.Message
+	copied bool
+}
+
+// NewMetadataCarrier creates a Carrier of the Metadata of the message.
+func NewMetadataCarrier(msg *wrp.Message) *MetadataCarrier {
+	return &MetadataCarrier{msg: msg}
+}
+
+func (c *MetadataCarrier) Get(key string) string {
+	return c.msg.Metadata[key]
+}
+
+func (c *MetadataCarrier) Set(key, value string) {
+	if !c.copied {
+		metadata := make(map[string]string, len(c.msg.Metadata)+2)
+		maps.Copy(metadata, c.msg.Metadata)
+		c.msg.Metadata = metadata
+		c.copied = true
+	}
+
+	c.msg.Metadata[key] = value
+}
+
+// Propagator extracts and injects span contexts.  The zero value injects the
+// W3C and B3Single formats, which is what candlelight propagates by default.
+type Propagator struct {
+	// Formats are the formats that are injected.  Extraction accepts every
+	// format, in the order W3C, B3Single, B3Multi.
+	Formats []Format
+}
+
+func (p Propagator) formats() []Format {
+	if len(p.Formats) == 0 {
+		return []Format{W3C, B3Single}
+	}
+
+	return p.Formats
+}
+
+// Extract returns the span context of the carrier.  The bool is false if the
+// carrier has no valid span context.  Extraction is shared with the wrp
+// package, so a wrp.LogObserver logs the trace IDs of the same messages.
+func (p Propagator) Extract(c Carrier) (SpanContext, bool) {
+	return tracecontext.Extract(c.Get)
+}
+
+// Inject sets the span context on the carrier in each of the Formats.  An
+// invalid span context is not injected.
+func (p Propagator) Inject(c Carrier, sc SpanContext) {
+	if !sc.IsValid() {
+		return
+	}
+
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+
+	for _, f := range p.formats() {
+		switch f {
+		case W3C:
+			c.Set(TraceParentKey, "00-"+sc.TraceID+"-"+sc.SpanID+"-"+flags)
+			if sc.TraceState != "" {
+				c.Set(TraceStateKey, sc.TraceState)
+			}
+		case B3Single:
+			c.Set(B3Key, sc.TraceID+"-"+sc.SpanID+"-"+flags[1:])
+		case B3Multi:
+			c.Set(B3TraceIDKey, sc.TraceID)
+			c.Set(B3SpanIDKey, sc.SpanID)
+			c.Set(B3SampledKey, flags[1:])
+		}
+	}
+}
+
+// ExtractMessage returns the span context in the Metadata of the message.
+func (p Propagator) ExtractMessage(msg *wrp.Message) (SpanContext, bool) {
+	return p.Extract(NewMetadataCarrier(msg))
+}
+
+// InjectMessage sets the span context in the Metadata of the message.
+func (p Propagator) InjectMessage(msg *wrp.Message, sc SpanContext) {
+	p.Inject(NewMetadataCarrier(msg), sc)
+}
+
+// ExtractHeader returns the span context in the HTTP headers.
+func (p Propagator) ExtractHeader(h http.Header) (SpanContext, bool) {
+	return p.Extract(HeaderCarrier(h))
+}
+
+// InjectHeader sets the span context in the HTTP headers.
+func (p Propagator) InjectHeader(h http.Header, sc SpanContext) {
+	p.Inject(HeaderCarrier(h), sc)
+}
@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrptrace
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+const (
+	traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	spanID  = "00f067aa0ba902b7"
+)
+
+func TestPropagator_Extract(t *testing.T) {
+	tests := []struct {
+		desc     string
+		metadata map[string]string
+		expected SpanContext
+		ok       bool
+	}{
+		{
+			desc: "none",
+		}, {
+			desc: "w3c",
+			metadata: map[string]string{
+				TraceParentKey: "00-" + traceID + "-" + spanID + "-01",
+				TraceStateKey:  "congo=t61rcWkgMzE",
+			},
+			expected: SpanContext{TraceID: traceID, SpanID: spanID, Sampled: true, TraceState: "congo=t61rcWkgMzE"},
+			ok:       true,
+		}, {
+			desc:     "w3c future version",
+			metadata: map[string]string{TraceParentKey: "01-" + traceID + "-" + spanID + "-02-extra"},
+			expected: SpanContext{TraceID: traceID, SpanID: spanID},
+			ok:       true,
+		}, {
+			desc:     "w3c invalid",
+			metadata: map[string]string{TraceParentKey: "00-" + traceID + "-0000000000000000-01"},
+		}, {
+			desc:     "w3c extra fields",
+			metadata: map[string]string{TraceParentKey: "00-" + traceID + "-" + spanID + "-01-extra"},
+		}, {
+			desc:     "b3 single",
+			metadata: map[string]string{B3Key: traceID + "-" + spanID + "-1-05e3ac9a4f6e3b90"},
+			expected: SpanContext{TraceID: traceID, SpanID: spanID, Sampled: true},
+			ok:       true,
+		}, {
+			desc:     "b3 single short trace id",
+			metadata: map[string]string{B3Key: "a3ce929d0e0e4736-" + spanID},
+			expected: SpanContext{TraceID: "0000000000000000a3ce929d0e0e4736", SpanID: spanID},
+			ok:       true,
+		}, {
+			desc:     "b3 single debug",
+			metadata: map[string]string{B3Key: traceID + "-" + spanID + "-d"},
+			expected: SpanContext{TraceID: traceID, SpanID: spanID, Sampled: true},
+			ok:       true,
+		}, {
+			desc:     "b3 single invalid sampled",
+			metadata: map[string]string{B3Key: traceID + "-" + spanID + "-x"},
+		}, {
+			desc:     "b3 single deny",
+			metadata: map[string]string{B3Key: "0"},
+		}, {
+			desc: "b3 multi",
+			metadata: map[string]string{
+				B3TraceIDKey: traceID,
+				B3SpanIDKey:  spanID,
+				B3FlagsKey:   "1",
+			},
+			expected: SpanContext{TraceID: traceID, SpanID: spanID, Sampled: true},
+			ok:       true,
+		}, {
+			desc: "w3c first",
+			metadata: map[string]string{
+				TraceParentKey: "00-" + traceID + "-" + spanID + "-00",
+				B3Key:          traceID + "-05e3ac9a4f6e3b90-1",
+			},
+			expected: SpanContext{TraceID: traceID, SpanID: spanID},
+			ok:       true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var p Propagator
+			sc, ok := p.ExtractMessage(&wrp.Message{Metadata: tc.metadata})
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, sc)
+		})
+	}
+}
+
+func TestPropagator_Inject(t *testing.T) {
+	sc := SpanContext{TraceID: traceID, SpanID: spanID, Sampled: true, TraceState: "congo=t61rcWkgMzE"}
+
+	t.Run("message", func(t *testing.T) {
+		assert := assert.New(t)
+
+		original := map[string]string{"/boot-time": "1"}
+		msg := wrp.Message{Metadata: original}
+
+		var p Propagator
+		p.InjectMessage(&msg, sc)
+		assert.Equal(map[string]string{
+			"/boot-time":   "1",
+			TraceParentKey: "00-" + traceID + "-" + spanID + "-01",
+			TraceStateKey:  "congo=t61rcWkgMzE",
+			B3Key:          traceID + "-" + spanID + "-1",
+		}, msg.Metadata)
+		assert.Len(original, 1)
+
+		actual, ok := p.ExtractMessage(&msg)
+		assert.True(ok)
+		assert.Equal(sc, actual)
+
+		msg = wrp.Message{}
+		p.InjectMessage(&msg, SpanContext{TraceID: traceID})
+		assert.Nil(msg.Metadata)
+	})
+
+	t.Run("header", func(t *testing.T) {
+		assert := assert.New(t)
+
+		h := make(http.Header)
+		p := Propagator{Formats: []Format{B3Multi}}
+		p.InjectHeader(h, SpanContext{TraceID: traceID, SpanID: spanID})
+		assert.Equal(http.Header{
+			"X-B3-Traceid": {traceID},
+			"X-B3-Spanid":  {spanID},
+			"X-B3-Sampled": {"0"},
+		}, h)
+
+		actual, ok := p.ExtractHeader(h)
+		assert.True(ok)
+		assert.Equal(SpanContext{TraceID: traceID, SpanID: spanID}, actual)
+	})
+}
+
+func TestSpanContextFromContext(t *testing.T) {
+	_, ok := SpanContextFromContext(context.Background())
+	assert.False(t, ok)
+
+	sc := SpanContext{TraceID: traceID, SpanID: spanID}
+	actual, ok := SpanContextFromContext(ContextWithSpanContext(context.Background(), sc))
+	require.True(t, ok)
+	assert.Equal(t, sc, actual)
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrptrace
+
+import (
+	"context"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// Span is a span started by a Tracer.
+type Span interface {
+	// SpanContext returns the span context of the span.
+	SpanContext() SpanContext
+
+	// RecordError records that the operation of the span failed.
+	RecordError(err error)
+
+	// End ends the span.
+	End()
+}
+
+// Tracer starts spans.  It is usually an adapter of the tracer of a tracing
+// library, e.g. the OpenTelemetry tracer configured by candlelight.
+type Tracer interface {
+	// Start starts a span, as a child of the parent if the parent is valid.
+	// The returned context carries the span in the way of the tracing library.
+	Start(ctx context.Context, name string, parent SpanContext) (context.Context, Span)
+}
+
+// TracerFunc is a function that implements Tracer.
+type TracerFunc func(context.Context, string, SpanContext) (context.Context, Span)
+
+func (f TracerFunc) Start(ctx context.Context, name string, parent SpanContext) (context.Context, Span) {
+	return f(ctx, name, parent)
+}
+
+// SpanName returns the name of the span of a message, which is its type
+// followed by the authority of its destination, e.g. "SimpleRequestResponse
+// talaria.example.com".  The name is only the type if the destination is not
+// a valid locator.
+func SpanName(msg *wrp.Message) string {
+	name := msg.Type.FriendlyName()
+	if l, err := wrp.ParseLocator(msg.Destination); err == nil && l.Authority != "" {
+		name += " " + l.Authority
+	}
+
+	return name
+}
@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrptrace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestSpanName(t *testing.T) {
+	tests := []struct {
+		desc     string
+		msg      wrp.Message
+		expected string
+	}{
+		{
+			desc:     "service",
+			msg:      wrp.Message{Type: wrp.SimpleRequestResponseMessageType, Destination: "dns:talaria.example.com/api"},
+			expected: "SimpleRequestResponse talaria.example.com",
+		}, {
+			desc:     "event",
+			msg:      wrp.Message{Type: wrp.SimpleEventMessageType, Destination: "event:device-status/mac:112233445566/online"},
+			expected: "SimpleEvent device-status",
+		}, {
+			desc:     "invalid destination",
+			msg:      wrp.Message{Type: wrp.RetrieveMessageType, Destination: "nowhere"},
+			expected: "Retrieve",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expected, SpanName(&tc.msg))
+		})
+	}
+}
@@ -0,0 +1,10 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package wrptransform applies configurable pipelines of field operations to WRP
+messages.  A Pipeline is built from a Config, which is normally unmarshaled
+from a service's configuration, and is a wrp.Modifier.  This makes it suitable
+for tenant specific message mangling at the edge without code changes.
+*/
+package wrptransform
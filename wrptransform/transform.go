@@ -0,0 +1,267 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrptransform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+// MetadataPrefix is the prefix of field names that refer to a single metadata
+// entry, e.g. "metadata//boot-time" refers to the "/boot-time" key.
+const MetadataPrefix = "metadata/"
+
+var (
+	ErrInvalidConfig = errors.New("invalid transform configuration")
+	ErrUnknownField  = errors.New("unknown field")
+	ErrUnknownOp     = errors.New("unknown operation")
+)
+
+// Op is the kind of a field operation.
+type Op string
+
+const (
+	// SetOp sets Field to Value.
+	SetOp Op = "set"
+
+	// CopyOp copies the From field to Field.  If From is a metadata entry that
+	// is not present, Field is unchanged.
+	CopyOp Op = "copy"
+
+	// DeleteOp clears Field, or removes it if Field is a metadata entry.
+	DeleteOp Op = "delete"
+
+	// ReplaceOp replaces every match of Pattern in Field with Replacement.  The
+	// Replacement may refer to capture groups, e.g. "$1".  Field defaults to
+	// "dest".
+	ReplaceOp Op = "replace"
+
+	// MetadataOp adds every entry in Metadata to the message's metadata,
+	// replacing any existing values.
+	MetadataOp Op = "metadata"
+)
+
+// Operation is the configuration of a single step in a Pipeline.
+//
+// Fields are named by the JSON names of the wrp.Message string fields: source,
+// dest, transaction_uuid, content_type, accept, path, service_name, url and
+// session_id.  A single metadata entry is named by MetadataPrefix followed by
+// the metadata key.
+type Operation struct {
+	// Op is the operation to perform.  This field is required.
+	Op Op `json:"op"`
+
+	// Field is the field that is modified.
+	Field string `json:"field,omitempty"`
+
+	// From is the field that is read by a CopyOp.
+	From string `json:"from,omitempty"`
+
+	// Value is the value written by a SetOp.
+	Value string `json:"value,omitempty"`
+
+	// Pattern is the regular expression used by a ReplaceOp.
+	Pattern string `json:"pattern,omitempty"`
+
+	// Replacement is the replacement text used by a ReplaceOp.
+	Replacement string `json:"replacement,omitempty"`
+
+	// Metadata is the set of entries added by a MetadataOp.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Config is the configuration of a Pipeline.
+type Config struct {
+	// Operations are applied in order to each message.
+	Operations []Operation `json:"operations"`
+}
+
+// Pipeline is a wrp.Modifier that applies a sequence of field operations.
+// A Pipeline never modifies the caller's message, including its metadata.
+type Pipeline struct {
+	steps []step
+
+	// metadata is true if any step writes to the metadata.
+	metadata bool
+}
+
+var _ wrp.Modifier = (*Pipeline)(nil)
+
+// step is a compiled Operation.
+type step func(*wrp.Message)
+
+// New compiles the configuration into a Pipeline.  All problems with the
+// configuration are reported in the returned error.
+func New(cfg Config) (*Pipeline, error) {
+	var (
+		p    Pipeline
+		errs []error
+	)
+
+	for i, op := range cfg.Operations {
+		s, writesMetadata, err := compile(op)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: operation %d (%s): %w", ErrInvalidConfig, i, op.Op, err))
+			continue
+		}
+
+		p.steps = append(p.steps, s)
+		p.metadata = p.metadata || writesMetadata
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// ModifyWRP applies each operation, in order, to a copy of the message.
+func (p *Pipeline) ModifyWRP(_ context.Context, msg wrp.Message) (wrp.Message, error) {
+	if p.metadata {
+		metadata := make(map[string]string, len(msg.Metadata))
+		for k, v := range msg.Metadata {
+			metadata[k] = v
+		}
+		msg.Metadata = metadata
+	}
+
+	for _, s := range p.steps {
+		s(&msg)
+	}
+
+	if p.metadata && len(msg.Metadata) == 0 {
+		msg.Metadata = nil
+	}
+
+	return msg, nil
+}
+
+// compile turns an Operation into a step.  The returned bool is true if the
+// step may write to the metadata.
+func compile(op Operation) (step, bool, error) {
+	if op.Op == MetadataOp {
+		entries := op.Metadata
+		return func(msg *wrp.Message) {
+			for k, v := range entries {
+				msg.Metadata[k] = v
+			}
+		}, true, nil
+	}
+
+	if op.Op == ReplaceOp && op.Field == "" {
+		op.Field = "dest"
+	}
+
+	target, err := lookup(op.Field)
+	if err != nil {
+		return nil, false, err
+	}
+
+	switch op.Op {
+	case SetOp:
+		value := op.Value
+		return func(msg *wrp.Message) {
+			target.set(msg, value)
+		}, target.metadata, nil
+
+	case CopyOp:
+		from, err := lookup(op.From)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return func(msg *wrp.Message) {
+			if v, ok := from.get(msg); ok {
+				target.set(msg, v)
+			}
+		}, target.metadata, nil
+
+	case DeleteOp:
+		return target.del, target.metadata, nil
+
+	case ReplaceOp:
+		re, err := regexp.Compile(op.Pattern)
+		if err != nil {
+			return nil, false, err
+		}
+
+		replacement := op.Replacement
+		return func(msg *wrp.Message) {
+			if v, ok := target.get(msg); ok {
+				target.set(msg, re.ReplaceAllString(v, replacement))
+			}
+		}, target.metadata, nil
+	}
+
+	return nil, false, fmt.Errorf("%w: `%s`", ErrUnknownOp, op.Op)
+}
+
+// field provides access to a single string in a message.
+type field struct {
+	get      func(*wrp.Message) (string, bool)
+	set      func(*wrp.Message, string)
+	del      func(*wrp.Message)
+	metadata bool
+}
+
+func stringField(ptr func(*wrp.Message) *string) field {
+	return field{
+		get: func(msg *wrp.Message) (string, bool) {
+			return *ptr(msg), true
+		},
+		set: func(msg *wrp.Message, v string) {
+			*ptr(msg) = v
+		},
+		del: func(msg *wrp.Message) {
+			*ptr(msg) = ""
+		},
+	}
+}
+
+var fields = map[string]field{
+	"source":           stringField(func(msg *wrp.Message) *string { return &msg.Source }),
+	"dest":             stringField(func(msg *wrp.Message) *string { return &msg.Destination }),
+	"transaction_uuid": stringField(func(msg *wrp.Message) *string { return &msg.TransactionUUID }),
+	"content_type":     stringField(func(msg *wrp.Message) *string { return &msg.ContentType }),
+	"accept":           stringField(func(msg *wrp.Message) *string { return &msg.Accept }),
+	"path":             stringField(func(msg *wrp.Message) *string { return &msg.Path }),
+	"service_name":     stringField(func(msg *wrp.Message) *string { return &msg.ServiceName }),
+	"url":              stringField(func(msg *wrp.Message) *string { return &msg.URL }),
+	"session_id":       stringField(func(msg *wrp.Message) *string { return &msg.SessionID }),
+}
+
+// lookup returns the field with the given name.
+func lookup(name string) (field, error) {
+	if key, ok := strings.CutPrefix(name, MetadataPrefix); ok && key != "" {
+		return field{
+			get: func(msg *wrp.Message) (string, bool) {
+				v, ok := msg.Metadata[key]
+				return v, ok
+			},
+			set: func(msg *wrp.Message, v string) {
+				msg.Metadata[key] = v
+			},
+			del: func(msg *wrp.Message) {
+				delete(msg.Metadata, key)
+			},
+			metadata: true,
+		}, nil
+	}
+
+	if f, ok := fields[name]; ok {
+		return f, nil
+	}
+
+	return field{}, &wrp.Error{
+		Code:  wrp.CodeUnsupportedField,
+		Field: name,
+		Err:   fmt.Errorf("%w: `%s`", ErrUnknownField, name),
+	}
+}
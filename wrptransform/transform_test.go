@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrptransform
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func testMessage() wrp.Message {
+	return wrp.Message{
+		Type:            wrp.SimpleRequestResponseMessageType,
+		Source:          "dns:tenant-a.example.com/api",
+		Destination:     "mac:112233445566/config",
+		TransactionUUID: "1234",
+		ContentType:     "application/json",
+		Metadata: map[string]string{
+			"/tenant": "a",
+		},
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	tests := []struct {
+		desc     string
+		ops      []Operation
+		expected func(*wrp.Message)
+	}{
+		{
+			desc:     "empty",
+			expected: func(*wrp.Message) {},
+		}, {
+			desc: "set",
+			ops: []Operation{
+				{Op: SetOp, Field: "service_name", Value: "edge"},
+				{Op: SetOp, Field: "metadata//region", Value: "east"},
+			},
+			expected: func(msg *wrp.Message) {
+				msg.ServiceName = "edge"
+				msg.Metadata["/region"] = "east"
+			},
+		}, {
+			desc: "copy",
+			ops: []Operation{
+				{Op: CopyOp, Field: "metadata//origin", From: "source"},
+				{Op: CopyOp, Field: "session_id", From: "metadata//tenant"},
+				{Op: CopyOp, Field: "path", From: "metadata//missing"},
+			},
+			expected: func(msg *wrp.Message) {
+				msg.Metadata["/origin"] = "dns:tenant-a.example.com/api"
+				msg.SessionID = "a"
+			},
+		}, {
+			desc: "delete",
+			ops: []Operation{
+				{Op: DeleteOp, Field: "content_type"},
+				{Op: DeleteOp, Field: "metadata//tenant"},
+			},
+			expected: func(msg *wrp.Message) {
+				msg.ContentType = ""
+				msg.Metadata = nil
+			},
+		}, {
+			desc: "replace destination by default",
+			ops: []Operation{
+				{Op: ReplaceOp, Pattern: `^mac:([0-9a-f]+)/config$`, Replacement: "mac:${1}/tenant-a/config"},
+			},
+			expected: func(msg *wrp.Message) {
+				msg.Destination = "mac:112233445566/tenant-a/config"
+			},
+		}, {
+			desc: "replace metadata",
+			ops: []Operation{
+				{Op: ReplaceOp, Field: "metadata//tenant", Pattern: `a`, Replacement: "tenant-a"},
+				{Op: ReplaceOp, Field: "metadata//missing", Pattern: `.*`, Replacement: "x"},
+			},
+			expected: func(msg *wrp.Message) {
+				msg.Metadata["/tenant"] = "tenant-a"
+			},
+		}, {
+			desc: "metadata injection",
+			ops: []Operation{
+				{Op: MetadataOp, Metadata: map[string]string{"/tenant": "b", "/edge": "true"}},
+			},
+			expected: func(msg *wrp.Message) {
+				msg.Metadata["/tenant"] = "b"
+				msg.Metadata["/edge"] = "true"
+			},
+		}, {
+			desc: "operations are applied in order",
+			ops: []Operation{
+				{Op: SetOp, Field: "url", Value: "one"},
+				{Op: CopyOp, Field: "accept", From: "url"},
+				{Op: SetOp, Field: "url", Value: "two"},
+			},
+			expected: func(msg *wrp.Message) {
+				msg.URL = "two"
+				msg.Accept = "one"
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			p, err := New(Config{Operations: tc.ops})
+			require.NoError(err)
+
+			original := testMessage()
+			actual, err := p.ModifyWRP(context.Background(), original)
+			require.NoError(err)
+
+			expected := testMessage()
+			tc.expected(&expected)
+			assert.Equal(expected, actual)
+
+			// the caller's message is never modified
+			assert.Equal(testMessage(), original)
+		})
+	}
+}
+
+func TestNew_invalid(t *testing.T) {
+	tests := []struct {
+		desc     string
+		ops      []Operation
+		expected error
+	}{
+		{
+			desc:     "unknown op",
+			ops:      []Operation{{Op: "rename", Field: "dest"}},
+			expected: ErrUnknownOp,
+		}, {
+			desc:     "unknown field",
+			ops:      []Operation{{Op: SetOp, Field: "payload"}},
+			expected: ErrUnknownField,
+		}, {
+			desc:     "missing field",
+			ops:      []Operation{{Op: DeleteOp}},
+			expected: ErrUnknownField,
+		}, {
+			desc:     "empty metadata key",
+			ops:      []Operation{{Op: SetOp, Field: MetadataPrefix}},
+			expected: ErrUnknownField,
+		}, {
+			desc:     "unknown copy source",
+			ops:      []Operation{{Op: CopyOp, Field: "dest", From: "nope"}},
+			expected: ErrUnknownField,
+		}, {
+			desc:     "invalid pattern",
+			ops:      []Operation{{Op: ReplaceOp, Pattern: "("}},
+			expected: ErrInvalidConfig,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			p, err := New(Config{Operations: tc.ops})
+			assert.ErrorIs(t, err, ErrInvalidConfig)
+			assert.ErrorIs(t, err, tc.expected)
+			if errors.Is(tc.expected, ErrUnknownField) {
+				assert.Equal(t, wrp.CodeUnsupportedField, wrp.ErrorCodeOf(err))
+			}
+			assert.Nil(t, p)
+		})
+	}
+}
+
+func TestConfig_json(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var cfg Config
+	require.NoError(json.Unmarshal([]byte(`{
+		"operations": [
+			{"op": "replace", "pattern": "^mac:", "replacement": "MAC:"},
+			{"op": "metadata", "metadata": {"/edge": "true"}}
+		]
+	}`), &cfg))
+
+	p, err := New(cfg)
+	require.NoError(err)
+
+	actual, err := p.ModifyWRP(context.Background(), testMessage())
+	require.NoError(err)
+	assert.Equal("MAC:112233445566/config", actual.Destination)
+	assert.Equal("true", actual.Metadata["/edge"])
+}
@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpvalidator
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/xmidt-org/touchstone"
+	"github.com/xmidt-org/wrp-go/v3"
+	"go.uber.org/multierr"
+)
+
+var (
+	ErrorNotAuthorizationType           = NewValidatorError(errors.New("not authorization message type"), "", []string{"Type"})
+	ErrorMissingAuthorizationStatus     = NewValidatorError(errors.New("missing authorization status"), "", []string{"Status"})
+	ErrorInvalidAuthorizationStatus     = NewValidatorError(errors.New("invalid authorization status"), "", []string{"Status"})
+	ErrorAuthorizationNotFirstOnSession = NewValidatorError(errors.New("authorization is not the first message of the session"), "", []string{"Type", "SessionID"})
+)
+
+// DefaultAuthorizationStatusCodes returns the status codes allowed by
+// AuthorizationStatus: 200 when the device is authorized, and 401 or 403 when
+// it is not.  A new slice is returned each time, so callers may modify it
+// freely.
+func DefaultAuthorizationStatusCodes() []int64 {
+	return []int64{200, 401, 403}
+}
+
+// Authorization ensures messages are valid based on each validator in the
+// list.  Authorization validates the following: UTF8 (all string fields),
+// MessageType is valid, Source, Destination, MessageType is of
+// AuthorizationMessageType, and Status is one of
+// DefaultAuthorizationStatusCodes.
+//
+// The ordering of authorization messages on a session is stateful, so it is
+// validated separately by an AuthorizationOrder.
+func Authorization(tf *touchstone.Factory, labelNames ...string) (Validators, error) {
+	var errs error
+	sv, err := SpecWithMetrics(tf, labelNames...)
+	if err != nil {
+		errs = multierr.Append(errs, err)
+	}
+
+	atv, err := NewAuthorizationTypeWithMetric(tf, labelNames...)
+	if err != nil {
+		errs = multierr.Append(errs, err)
+	}
+
+	asv, err := NewAuthorizationStatusWithMetric(tf, nil, labelNames...)
+	if err != nil {
+		errs = multierr.Append(errs, err)
+	}
+
+	return sv.AddFunc(atv, asv), errs
+}
+
+// NewAuthorizationTypeWithMetric returns an AuthorizationType validator with a metric middleware.
+func NewAuthorizationTypeWithMetric(tf *touchstone.Factory, labelNames ...string) (ValidatorFunc, error) {
+	m, err := newAuthorizationTypeErrorTotal(tf, labelNames...)
+
+	return func(msg wrp.Message, ls prometheus.Labels) error {
+		err := AuthorizationType(msg)
+		if err != nil {
+			m.With(ls).Add(1.0)
+		}
+
+		return err
+	}, err
+}
+
+// NewAuthorizationStatusWithMetric returns an AuthorizationStatus validator for
+// the given status codes with a metric middleware.  If codes is empty,
+// DefaultAuthorizationStatusCodes() is used.
+func NewAuthorizationStatusWithMetric(tf *touchstone.Factory, codes []int64, labelNames ...string) (ValidatorFunc, error) {
+	m, err := newAuthorizationStatusErrorTotal(tf, labelNames...)
+	validate := AuthorizationStatus(codes...)
+
+	return func(msg wrp.Message, ls prometheus.Labels) error {
+		err := validate(msg)
+		if err != nil {
+			m.With(ls).Add(1.0)
+		}
+
+		return err
+	}, err
+}
+
+// AuthorizationType takes messages and validates their Type is of AuthorizationMessageType.
+func AuthorizationType(m wrp.Message) error {
+	if m.Type != wrp.AuthorizationMessageType {
+		return ErrorNotAuthorizationType
+	}
+
+	return nil
+}
+
+// AuthorizationStatus returns a validator that requires the Status of a
+// message to be set to one of the given codes.  If no codes are given,
+// DefaultAuthorizationStatusCodes() is used.
+func AuthorizationStatus(codes ...int64) func(wrp.Message) error {
+	if len(codes) == 0 {
+		codes = DefaultAuthorizationStatusCodes()
+	} else {
+		codes = slices.Clone(codes)
+	}
+
+	return func(m wrp.Message) error {
+		if m.Status == nil {
+			return ErrorMissingAuthorizationStatus
+		}
+
+		if !slices.Contains(codes, *m.Status) {
+			return fmt.Errorf("%w: status %d is not one of %v", ErrorInvalidAuthorizationStatus, *m.Status, codes)
+		}
+
+		return nil
+	}
+}
+
+// AuthorizationOrder is a Validator that requires an authorization message to
+// be the first message of each session, as identified by the SessionID.  A
+// message of any other type on a session that has not been authorized is
+// invalid, as is an authorization message on a session that has already seen
+// messages.  Messages without a SessionID cannot be ordered and are valid.
+//
+// An AuthorizationOrder remembers every session it has seen, so End must be
+// called when a session ends.  It is safe for concurrent use.
+type AuthorizationOrder struct {
+	m        counterVec
+	lock     sync.Mutex
+	sessions map[string]bool
+}
+
+var _ Validator = (*AuthorizationOrder)(nil)
+
+// NewAuthorizationOrder creates an AuthorizationOrder without a metric.
+func NewAuthorizationOrder() *AuthorizationOrder {
+	return &AuthorizationOrder{
+		sessions: make(map[string]bool),
+	}
+}
+
+// NewAuthorizationOrderWithMetric creates an AuthorizationOrder with a metric middleware.
+func NewAuthorizationOrderWithMetric(tf *touchstone.Factory, labelNames ...string) (*AuthorizationOrder, error) {
+	m, err := newAuthorizationOrderErrorTotal(tf, labelNames...)
+	if err != nil {
+		return nil, err
+	}
+
+	ao := NewAuthorizationOrder()
+	ao.m = m
+	return ao, nil
+}
+
+// Validate validates the position of the message on its session.
+func (ao *AuthorizationOrder) Validate(m wrp.Message, ls prometheus.Labels) error {
+	if m.SessionID == "" {
+		return nil
+	}
+
+	ao.lock.Lock()
+	_, seen := ao.sessions[m.SessionID]
+	valid := seen != (m.Type == wrp.AuthorizationMessageType)
+	if !seen && valid {
+		ao.sessions[m.SessionID] = true
+	}
+	ao.lock.Unlock()
+
+	if valid {
+		return nil
+	}
+
+	if ao.m != nil {
+		ao.m.With(ls).Add(1.0)
+	}
+
+	return ErrorAuthorizationNotFirstOnSession
+}
+
+// End forgets the session, e.g. when the device disconnects.
+func (ao *AuthorizationOrder) End(sessionID string) {
+	ao.lock.Lock()
+	delete(ao.sessions, sessionID)
+	ao.lock.Unlock()
+}
+
+// Sessions returns the number of sessions currently remembered.
+func (ao *AuthorizationOrder) Sessions() int {
+	ao.lock.Lock()
+	defer ao.lock.Unlock()
+	return len(ao.sessions)
+}
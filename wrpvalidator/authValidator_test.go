@@ -0,0 +1,265 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpvalidator
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/sallust"
+	"github.com/xmidt-org/touchstone"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func newAuthTestFactory(t *testing.T) *touchstone.Factory {
+	cfg := touchstone.Config{
+		DefaultNamespace: "n",
+		DefaultSubsystem: "s",
+	}
+	_, pr, err := touchstone.New(cfg)
+	require.NoError(t, err)
+
+	return touchstone.NewFactory(cfg, sallust.Default(), pr)
+}
+
+func authStatus(s int64) *int64 {
+	return &s
+}
+
+func TestAuthorizationType(t *testing.T) {
+	tests := []struct {
+		description string
+		msg         wrp.Message
+		expectedErr error
+	}{
+		{
+			description: "AuthorizationMessageType success",
+			msg:         wrp.Message{Type: wrp.AuthorizationMessageType},
+		},
+		{
+			description: "SimpleEventMessageType error",
+			msg:         wrp.Message{Type: wrp.SimpleEventMessageType},
+			expectedErr: ErrorNotAuthorizationType,
+		},
+		{
+			description: "SimpleRequestResponseMessageType error",
+			msg:         wrp.Message{Type: wrp.SimpleRequestResponseMessageType},
+			expectedErr: ErrorNotAuthorizationType,
+		},
+		{
+			description: "Invalid0MessageType error",
+			msg:         wrp.Message{Type: wrp.Invalid0MessageType},
+			expectedErr: ErrorNotAuthorizationType,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			err := AuthorizationType(tc.msg)
+			if expectedErr := tc.expectedErr; expectedErr != nil {
+				var targetErr ValidatorError
+
+				assert.ErrorAs(expectedErr, &targetErr)
+				assert.ErrorIs(err, targetErr.Err)
+				return
+			}
+
+			assert.NoError(err)
+		})
+	}
+}
+
+func TestAuthorizationStatus(t *testing.T) {
+	tests := []struct {
+		description string
+		codes       []int64
+		msg         wrp.Message
+		expectedErr error
+	}{
+		{
+			description: "authorized success",
+			msg:         wrp.Message{Status: authStatus(200)},
+		},
+		{
+			description: "unauthorized success",
+			msg:         wrp.Message{Status: authStatus(401)},
+		},
+		{
+			description: "forbidden success",
+			msg:         wrp.Message{Status: authStatus(403)},
+		},
+		{
+			description: "custom codes success",
+			codes:       []int64{200, 500},
+			msg:         wrp.Message{Status: authStatus(500)},
+		},
+		{
+			description: "missing status error",
+			msg:         wrp.Message{},
+			expectedErr: ErrorMissingAuthorizationStatus,
+		},
+		{
+			description: "unknown status error",
+			msg:         wrp.Message{Status: authStatus(500)},
+			expectedErr: ErrorInvalidAuthorizationStatus,
+		},
+		{
+			description: "custom codes error",
+			codes:       []int64{200},
+			msg:         wrp.Message{Status: authStatus(403)},
+			expectedErr: ErrorInvalidAuthorizationStatus,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			err := AuthorizationStatus(tc.codes...)(tc.msg)
+			if expectedErr := tc.expectedErr; expectedErr != nil {
+				var targetErr ValidatorError
+
+				assert.ErrorAs(expectedErr, &targetErr)
+				assert.ErrorIs(err, targetErr.Err)
+				return
+			}
+
+			assert.NoError(err)
+		})
+	}
+}
+
+func TestAuthorization(t *testing.T) {
+	tests := []struct {
+		description string
+		msg         wrp.Message
+		expectedErr []error
+	}{
+		{
+			description: "valid authorization message success",
+			msg: wrp.Message{
+				Type:        wrp.AuthorizationMessageType,
+				Source:      "dns:talaria.example.com",
+				Destination: "mac:112233445566",
+				Status:      authStatus(200),
+			},
+		},
+		{
+			description: "missing status error",
+			msg: wrp.Message{
+				Type:        wrp.AuthorizationMessageType,
+				Source:      "dns:talaria.example.com",
+				Destination: "mac:112233445566",
+			},
+			expectedErr: []error{ErrorMissingAuthorizationStatus},
+		},
+		{
+			description: "wrong type and invalid locator error",
+			msg: wrp.Message{
+				Type:        wrp.SimpleEventMessageType,
+				Source:      "invalid",
+				Destination: "mac:112233445566",
+				Status:      authStatus(200),
+			},
+			expectedErr: []error{ErrorInvalidSource, ErrorNotAuthorizationType},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+			av, err := Authorization(newAuthTestFactory(t))
+			require.NoError(err)
+			err = av.Validate(tc.msg, prometheus.Labels{})
+			if tc.expectedErr != nil {
+				for _, e := range tc.expectedErr {
+					var targetErr ValidatorError
+
+					assert.ErrorAs(e, &targetErr)
+					assert.ErrorIs(err, targetErr.Err)
+				}
+
+				return
+			}
+
+			assert.NoError(err)
+		})
+	}
+}
+
+func TestAuthorizationOrder(t *testing.T) {
+	var (
+		auth  = wrp.Message{Type: wrp.AuthorizationMessageType, SessionID: "session"}
+		event = wrp.Message{Type: wrp.SimpleEventMessageType, SessionID: "session"}
+	)
+
+	tests := []struct {
+		description string
+		msgs        []wrp.Message
+		end         bool
+		expectedErr []bool
+	}{
+		{
+			description: "authorization first success",
+			msgs:        []wrp.Message{auth, event, event},
+			expectedErr: []bool{false, false, false},
+		},
+		{
+			description: "message before authorization error",
+			msgs:        []wrp.Message{event, auth},
+			expectedErr: []bool{true, false},
+		},
+		{
+			description: "repeated authorization error",
+			msgs:        []wrp.Message{auth, event, auth},
+			expectedErr: []bool{false, false, true},
+		},
+		{
+			description: "no session success",
+			msgs:        []wrp.Message{{Type: wrp.SimpleEventMessageType}, {Type: wrp.AuthorizationMessageType}},
+			expectedErr: []bool{false, false},
+		},
+		{
+			description: "ended session success",
+			msgs:        []wrp.Message{auth, event},
+			end:         true,
+			expectedErr: []bool{false, false},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+			ao, err := NewAuthorizationOrderWithMetric(newAuthTestFactory(t))
+			require.NoError(err)
+
+			var failures float64
+			for i, msg := range tc.msgs {
+				err := ao.Validate(msg, prometheus.Labels{})
+				if !tc.expectedErr[i] {
+					assert.NoError(err)
+					continue
+				}
+
+				failures++
+				var targetErr ValidatorError
+				assert.ErrorAs(ErrorAuthorizationNotFirstOnSession, &targetErr)
+				assert.ErrorIs(err, targetErr.Err)
+			}
+
+			assert.Equal(failures, testutil.ToFloat64(ao.m.With(prometheus.Labels{})))
+
+			if tc.end {
+				ao.End("session")
+				assert.Zero(ao.Sessions())
+				assert.NoError(ao.Validate(auth, prometheus.Labels{}))
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpvalidator
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/xmidt-org/touchstone"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultMetricFlushInterval is the default interval at which a
+	// MetricBatcher adds the accumulated counts to the Prometheus counters.
+	DefaultMetricFlushInterval = time.Second
+)
+
+// metricBatchers holds the MetricBatcher of each factory created by
+// NewBatchedFactory, until the MetricBatcher is stopped.
+var metricBatchers sync.Map // *touchstone.Factory -> *MetricBatcher
+
+// counterVec is the part of *prometheus.CounterVec used by the validators.
+type counterVec interface {
+	With(prometheus.Labels) prometheus.Counter
+}
+
+// MetricBatcherOption is a functional option for a MetricBatcher.
+type MetricBatcherOption interface {
+	apply(*MetricBatcher)
+}
+
+type metricBatcherOptionFunc func(*MetricBatcher)
+
+func (f metricBatcherOptionFunc) apply(b *MetricBatcher) {
+	f(b)
+}
+
+// MetricFlushInterval sets the interval at which the accumulated counts are
+// added to the Prometheus counters.  A non-positive interval disables the
+// periodic flush, leaving it to the caller to call Flush.  The default is
+// DefaultMetricFlushInterval.
+func MetricFlushInterval(d time.Duration) MetricBatcherOption {
+	return metricBatcherOptionFunc(func(b *MetricBatcher) {
+		b.interval = d
+	})
+}
+
+// MetricBatcher accumulates the error counts of validators with atomic
+// operations, and periodically adds them to the Prometheus counters.  This
+// avoids the contention of updating the counters for every message at very
+// high message rates, at the cost of the counters lagging by up to the flush
+// interval.
+//
+// A MetricBatcher is created along with its factory by NewBatchedFactory.
+type MetricBatcher struct {
+	factory  *touchstone.Factory
+	interval time.Duration
+	lock     sync.Mutex
+	counters []*batchedCounter
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewBatchedFactory is like touchstone.NewFactory, but the metrics of the
+// validators created with the returned factory by this package are batched by
+// the returned MetricBatcher.  Other metrics created with the factory are not
+// affected.
+//
+// The MetricBatcher flushes periodically until Stop is called.  Validators
+// created with the factory after Stop are not batched.
+func NewBatchedFactory(cfg touchstone.Config, l *zap.Logger, r prometheus.Registerer, opts ...MetricBatcherOption) (*touchstone.Factory, *MetricBatcher) {
+	b := MetricBatcher{
+		interval: DefaultMetricFlushInterval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt.apply(&b)
+		}
+	}
+
+	if b.interval > 0 {
+		go b.run()
+	} else {
+		close(b.done)
+	}
+
+	tf := touchstone.NewFactory(cfg, l, r)
+	b.factory = tf
+	metricBatchers.Store(tf, &b)
+	return tf, &b
+}
+
+func (b *MetricBatcher) run() {
+	defer close(b.done)
+
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.Flush()
+		case <-b.stop:
+			return
+		}
+	}
+}
+
+// Flush adds the counts accumulated since the last flush to the Prometheus
+// counters.
+func (b *MetricBatcher) Flush() {
+	b.lock.Lock()
+	counters := b.counters
+	b.lock.Unlock()
+
+	for _, c := range counters {
+		c.flush()
+	}
+}
+
+// Stop stops the periodic flush and flushes the accumulated counts.  Counts
+// accumulated after Stop are only added to the counters by calling Flush.
+// Stop also releases the factory, so that neither is kept alive by this
+// package.
+func (b *MetricBatcher) Stop() {
+	b.stopOnce.Do(func() {
+		metricBatchers.Delete(b.factory)
+		close(b.stop)
+	})
+
+	<-b.done
+	b.Flush()
+}
+
+func (b *MetricBatcher) add(c *batchedCounter) {
+	b.lock.Lock()
+	b.counters = append(b.counters, c)
+	b.lock.Unlock()
+}
+
+// newErrorTotal creates the counter of a validator, which is batched if the
+// factory was created by NewBatchedFactory.
+func newErrorTotal(tf *touchstone.Factory, opts prometheus.CounterOpts, labelNames ...string) (counterVec, error) {
+	m, err := tf.NewCounterVec(opts, labelNames...)
+	if err != nil {
+		return nil, err
+	}
+
+	if b, ok := metricBatchers.Load(tf); ok {
+		return &batchedCounterVec{
+			CounterVec: m,
+			batcher:    b.(*MetricBatcher),
+			labelNames: labelNames,
+		}, nil
+	}
+
+	return m, nil
+}
+
+// batchedCounterVec returns batchedCounters in place of the counters of a
+// CounterVec.
+type batchedCounterVec struct {
+	*prometheus.CounterVec
+	batcher    *MetricBatcher
+	labelNames []string
+	counters   sync.Map // label values -> *batchedCounter
+}
+
+func (v *batchedCounterVec) With(ls prometheus.Labels) prometheus.Counter {
+	var key strings.Builder
+	for _, name := range v.labelNames {
+		key.WriteString(ls[name])
+		key.WriteByte(0xff)
+	}
+
+	if c, ok := v.counters.Load(key.String()); ok {
+		return c.(*batchedCounter)
+	}
+
+	c, loaded := v.counters.LoadOrStore(key.String(), &batchedCounter{
+		Counter: v.CounterVec.With(ls),
+	})
+	if !loaded {
+		v.batcher.add(c.(*batchedCounter))
+	}
+
+	return c.(*batchedCounter)
+}
+
+// batchedCounter accumulates the values added to it until they are flushed to
+// the embedded Prometheus counter.
+type batchedCounter struct {
+	prometheus.Counter
+
+	// pending holds the bits of the float64 value added since the last flush.
+	pending atomic.Uint64
+}
+
+func (c *batchedCounter) Inc() {
+	c.Add(1.0)
+}
+
+func (c *batchedCounter) Add(v float64) {
+	for {
+		old := c.pending.Load()
+		if c.pending.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+v)) {
+			return
+		}
+	}
+}
+
+func (c *batchedCounter) flush() {
+	if v := math.Float64frombits(c.pending.Swap(0)); v > 0 {
+		c.Counter.Add(v)
+	}
+}
@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpvalidator
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/sallust"
+	"github.com/xmidt-org/touchstone"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func newBatchedTestFactory(t *testing.T, opts ...MetricBatcherOption) (*touchstone.Factory, *MetricBatcher, *prometheus.Registry) {
+	cfg := touchstone.Config{
+		DefaultNamespace: "n",
+		DefaultSubsystem: "s",
+	}
+	pr := prometheus.NewPedanticRegistry()
+	tf, b := NewBatchedFactory(cfg, sallust.Default(), pr, opts...)
+	t.Cleanup(b.Stop)
+	return tf, b, pr
+}
+
+func TestNewBatchedFactory(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tf, b, pr := newBatchedTestFactory(t, MetricFlushInterval(0), nil)
+	sv, err := NewSourceWithMetric(tf, PartnerIDLabel)
+	require.NoError(err)
+
+	invalid := wrp.Message{Source: "invalid"}
+	comcast := prometheus.Labels{PartnerIDLabel: "comcast"}
+	sky := prometheus.Labels{PartnerIDLabel: "sky"}
+
+	assert.Error(sv(invalid, comcast))
+	assert.Error(sv(invalid, comcast))
+	assert.Error(sv(invalid, sky))
+	assert.NoError(sv(wrp.Message{Source: "dns:example.com"}, sky))
+
+	// nothing is counted before a flush
+	assert.NoError(testutil.GatherAndCompare(pr, strings.NewReader(`
+# HELP n_s_wrp_validator_source the total number of Source metric
+# TYPE n_s_wrp_validator_source counter
+n_s_wrp_validator_source{partner_id="comcast"} 0
+n_s_wrp_validator_source{partner_id="sky"} 0
+`), "n_s_"+sourceValidatorErrorTotalName))
+
+	b.Flush()
+	assert.NoError(testutil.GatherAndCompare(pr, strings.NewReader(`
+# HELP n_s_wrp_validator_source the total number of Source metric
+# TYPE n_s_wrp_validator_source counter
+n_s_wrp_validator_source{partner_id="comcast"} 2
+n_s_wrp_validator_source{partner_id="sky"} 1
+`), "n_s_"+sourceValidatorErrorTotalName))
+
+	// a flush only adds the counts since the last flush
+	assert.Error(sv(invalid, sky))
+	b.Flush()
+	b.Flush()
+	assert.NoError(testutil.GatherAndCompare(pr, strings.NewReader(`
+# HELP n_s_wrp_validator_source the total number of Source metric
+# TYPE n_s_wrp_validator_source counter
+n_s_wrp_validator_source{partner_id="comcast"} 2
+n_s_wrp_validator_source{partner_id="sky"} 2
+`), "n_s_"+sourceValidatorErrorTotalName))
+}
+
+func TestMetricBatcher_concurrent(t *testing.T) {
+	require := require.New(t)
+
+	tf, b, _ := newBatchedTestFactory(t, MetricFlushInterval(time.Millisecond))
+	ao, err := NewAuthorizationOrderWithMetric(tf)
+	require.NoError(err)
+
+	const goroutines, messages = 8, 500
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < messages; j++ {
+				// a non authorization message first on a session is an error
+				_ = ao.Validate(wrp.Message{Type: wrp.SimpleEventMessageType, SessionID: "s"}, prometheus.Labels{})
+			}
+		}()
+	}
+
+	wg.Wait()
+	b.Stop()
+	b.Stop()
+
+	counter := ao.m.With(prometheus.Labels{}).(*batchedCounter)
+	require.Equal(float64(goroutines*messages), testutil.ToFloat64(counter.Counter))
+}
+
+func TestMetricBatcher_periodic(t *testing.T) {
+	tf, _, pr := newBatchedTestFactory(t, MetricFlushInterval(time.Millisecond))
+	v, err := NewAlwaysInvalidWithMetric(tf)
+	require.NoError(t, err)
+
+	assert.Error(t, v(wrp.Message{}, prometheus.Labels{}))
+	assert.Eventually(t, func() bool {
+		return testutil.GatherAndCompare(pr, strings.NewReader(`
+# HELP n_s_wrp_validator_always_invalid the total number of AlwaysInvalid validations
+# TYPE n_s_wrp_validator_always_invalid counter
+n_s_wrp_validator_always_invalid 1
+`), "n_s_"+alwaysInvalidValidatorErrorTotalName) == nil
+	}, time.Second, time.Millisecond)
+}
+
+func TestNewErrorTotal_notBatched(t *testing.T) {
+	tf := newAuthTestFactory(t)
+	m, err := newSpansErrorTotal(tf)
+	require.NoError(t, err)
+	assert.IsType(t, &prometheus.CounterVec{}, m)
+}
+
+func TestMetricBatcher_Stop(t *testing.T) {
+	assert := assert.New(t)
+
+	tf, b, _ := newBatchedTestFactory(t, MetricFlushInterval(0))
+	_, ok := metricBatchers.Load(tf)
+	assert.True(ok)
+
+	b.Stop()
+	_, ok = metricBatchers.Load(tf)
+	assert.False(ok, "a stopped MetricBatcher must not be retained")
+
+	m, err := newSpansErrorTotal(tf)
+	require.NoError(t, err)
+	assert.IsType(&prometheus.CounterVec{}, m)
+}
@@ -42,6 +42,12 @@ const (
 	// utf8ValidatorErrorTotalHelp is the help text for the UTF8 Validator metric.
 	utf8ValidatorErrorTotalHelp = "the total number of UTF8 Validator metric"
 
+	// qualityOfServiceValidatorErrorTotalName is the name of the counter for all QualityOfService validation.
+	qualityOfServiceValidatorErrorTotalName = metricPrefix + "quality_of_service"
+
+	// qualityOfServiceValidatorErrorTotalHelp is the help text for the QualityOfService Validator metric.
+	qualityOfServiceValidatorErrorTotalHelp = "the total number of QualityOfService Validator metric"
+
 	// simpleEventTypeValidatorErrorTotalName is the name of the counter for all SimpleEventType validation.
 	simpleEventTypeValidatorErrorTotalName = metricPrefix + "simple_event_type"
 
@@ -59,6 +65,42 @@ const (
 
 	// spansValidatorErrorTotalHelp is the help text for the Spans Validator metric.
 	spansValidatorErrorTotalHelp = "the total number of Spans Validator metric"
+
+	// authorizationTypeValidatorErrorTotalName is the name of the counter for all AuthorizationType validation.
+	authorizationTypeValidatorErrorTotalName = metricPrefix + "authorization_type"
+
+	// authorizationTypeValidatorErrorTotalHelp is the help text for the AuthorizationType Validator metric.
+	authorizationTypeValidatorErrorTotalHelp = "the total number of AuthorizationType Validator metric"
+
+	// authorizationStatusValidatorErrorTotalName is the name of the counter for all AuthorizationStatus validation.
+	authorizationStatusValidatorErrorTotalName = metricPrefix + "authorization_status"
+
+	// authorizationStatusValidatorErrorTotalHelp is the help text for the AuthorizationStatus Validator metric.
+	authorizationStatusValidatorErrorTotalHelp = "the total number of AuthorizationStatus Validator metric"
+
+	// authorizationOrderValidatorErrorTotalName is the name of the counter for all AuthorizationOrder validation.
+	authorizationOrderValidatorErrorTotalName = metricPrefix + "authorization_order"
+
+	// authorizationOrderValidatorErrorTotalHelp is the help text for the AuthorizationOrder Validator metric.
+	authorizationOrderValidatorErrorTotalHelp = "the total number of AuthorizationOrder Validator metric"
+
+	// sourceSchemeValidatorErrorTotalName is the name of the counter for all SourceSchemes validation.
+	sourceSchemeValidatorErrorTotalName = metricPrefix + "source_scheme"
+
+	// sourceSchemeValidatorErrorTotalHelp is the help text for the SourceSchemes Validator metric.
+	sourceSchemeValidatorErrorTotalHelp = "the total number of SourceSchemes Validator metric"
+
+	// destinationSchemeValidatorErrorTotalName is the name of the counter for all DestinationSchemes validation.
+	destinationSchemeValidatorErrorTotalName = metricPrefix + "destination_scheme"
+
+	// destinationSchemeValidatorErrorTotalHelp is the help text for the DestinationSchemes Validator metric.
+	destinationSchemeValidatorErrorTotalHelp = "the total number of DestinationSchemes Validator metric"
+
+	// toggleValidatorTotalName is the name of the counter for all Toggle validation.
+	toggleValidatorTotalName = metricPrefix + "toggle"
+
+	// toggleValidatorTotalHelp is the help text for the Toggle metric.
+	toggleValidatorTotalHelp = "the total number of Toggle validations by validator, enforcement and outcome"
 )
 
 // Metric label names
@@ -66,10 +108,21 @@ const (
 	PartnerIDLabel   = "partner_id"
 	MessageTypeLabel = "message_type"
 	ClientIDLabel    = "client_id"
+	ValidatorLabel   = "validator"
+	EnforcementLabel = "enforcement"
+	OutcomeLabel     = "outcome"
 )
 
-func newAlwaysInvalidErrorTotal(tf *touchstone.Factory, labelNames ...string) (m *prometheus.CounterVec, err error) {
-	return tf.NewCounterVec(
+// Toggle outcome label values
+const (
+	toggleValid   = "valid"
+	toggleInvalid = "invalid"
+	toggleSkipped = "skipped"
+)
+
+func newAlwaysInvalidErrorTotal(tf *touchstone.Factory, labelNames ...string) (m counterVec, err error) {
+	return newErrorTotal(
+		tf,
 		prometheus.CounterOpts{
 			Name: alwaysInvalidValidatorErrorTotalName,
 			Help: alwaysInvalidValidatorErrorTotalHelp,
@@ -78,8 +131,9 @@ func newAlwaysInvalidErrorTotal(tf *touchstone.Factory, labelNames ...string) (m
 	)
 }
 
-func newDestinationErrorTotal(tf *touchstone.Factory, labelNames ...string) (m *prometheus.CounterVec, err error) {
-	return tf.NewCounterVec(
+func newDestinationErrorTotal(tf *touchstone.Factory, labelNames ...string) (m counterVec, err error) {
+	return newErrorTotal(
+		tf,
 		prometheus.CounterOpts{
 			Name: destinationValidatorErrorTotalName,
 			Help: destinationValidatorErrorTotalHelp,
@@ -88,8 +142,9 @@ func newDestinationErrorTotal(tf *touchstone.Factory, labelNames ...string) (m *
 	)
 }
 
-func newSourceErrorTotal(tf *touchstone.Factory, labelNames ...string) (m *prometheus.CounterVec, err error) {
-	return tf.NewCounterVec(
+func newSourceErrorTotal(tf *touchstone.Factory, labelNames ...string) (m counterVec, err error) {
+	return newErrorTotal(
+		tf,
 		prometheus.CounterOpts{
 			Name: sourceValidatorErrorTotalName,
 			Help: sourceValidatorErrorTotalHelp,
@@ -98,8 +153,9 @@ func newSourceErrorTotal(tf *touchstone.Factory, labelNames ...string) (m *prome
 	)
 }
 
-func newMessageTypeErrorTotal(tf *touchstone.Factory, labelNames ...string) (m *prometheus.CounterVec, err error) {
-	return tf.NewCounterVec(
+func newMessageTypeErrorTotal(tf *touchstone.Factory, labelNames ...string) (m counterVec, err error) {
+	return newErrorTotal(
+		tf,
 		prometheus.CounterOpts{
 			Name: messageTypeValidatorErrorTotalName,
 			Help: messageTypeValidatorErrorTotalHelp,
@@ -108,8 +164,9 @@ func newMessageTypeErrorTotal(tf *touchstone.Factory, labelNames ...string) (m *
 	)
 }
 
-func newUTF8ErrorTotal(tf *touchstone.Factory, labelNames ...string) (m *prometheus.CounterVec, err error) {
-	return tf.NewCounterVec(
+func newUTF8ErrorTotal(tf *touchstone.Factory, labelNames ...string) (m counterVec, err error) {
+	return newErrorTotal(
+		tf,
 		prometheus.CounterOpts{
 			Name: utf8ValidatorErrorTotalName,
 			Help: utf8ValidatorErrorTotalHelp,
@@ -118,8 +175,20 @@ func newUTF8ErrorTotal(tf *touchstone.Factory, labelNames ...string) (m *prometh
 	)
 }
 
-func newSimpleEventTypeErrorTotal(tf *touchstone.Factory, labelNames ...string) (m *prometheus.CounterVec, err error) {
-	return tf.NewCounterVec(
+func newQualityOfServiceErrorTotal(tf *touchstone.Factory, labelNames ...string) (m counterVec, err error) {
+	return newErrorTotal(
+		tf,
+		prometheus.CounterOpts{
+			Name: qualityOfServiceValidatorErrorTotalName,
+			Help: qualityOfServiceValidatorErrorTotalHelp,
+		},
+		labelNames...,
+	)
+}
+
+func newSimpleEventTypeErrorTotal(tf *touchstone.Factory, labelNames ...string) (m counterVec, err error) {
+	return newErrorTotal(
+		tf,
 		prometheus.CounterOpts{
 			Name: simpleEventTypeValidatorErrorTotalName,
 			Help: simpleEventTypeValidatorErrorTotalHelp,
@@ -128,8 +197,9 @@ func newSimpleEventTypeErrorTotal(tf *touchstone.Factory, labelNames ...string)
 	)
 }
 
-func newSimpleRequestResponseMessageTypeErrorTotal(tf *touchstone.Factory, labelNames ...string) (m *prometheus.CounterVec, err error) {
-	return tf.NewCounterVec(
+func newSimpleRequestResponseMessageTypeErrorTotal(tf *touchstone.Factory, labelNames ...string) (m counterVec, err error) {
+	return newErrorTotal(
+		tf,
 		prometheus.CounterOpts{
 			Name: simpleRequestResponseMessageTypeErrorTotalName,
 			Help: simpleRequestResponseMessageTypeErrorTotalHelp,
@@ -138,8 +208,9 @@ func newSimpleRequestResponseMessageTypeErrorTotal(tf *touchstone.Factory, label
 	)
 }
 
-func newSpansErrorTotal(tf *touchstone.Factory, labelNames ...string) (m *prometheus.CounterVec, err error) {
-	return tf.NewCounterVec(
+func newSpansErrorTotal(tf *touchstone.Factory, labelNames ...string) (m counterVec, err error) {
+	return newErrorTotal(
+		tf,
 		prometheus.CounterOpts{
 			Name: spansValidatorErrorTotalName,
 			Help: spansValidatorErrorTotalHelp,
@@ -147,3 +218,69 @@ func newSpansErrorTotal(tf *touchstone.Factory, labelNames ...string) (m *promet
 		labelNames...,
 	)
 }
+
+func newAuthorizationTypeErrorTotal(tf *touchstone.Factory, labelNames ...string) (m counterVec, err error) {
+	return newErrorTotal(
+		tf,
+		prometheus.CounterOpts{
+			Name: authorizationTypeValidatorErrorTotalName,
+			Help: authorizationTypeValidatorErrorTotalHelp,
+		},
+		labelNames...,
+	)
+}
+
+func newAuthorizationStatusErrorTotal(tf *touchstone.Factory, labelNames ...string) (m counterVec, err error) {
+	return newErrorTotal(
+		tf,
+		prometheus.CounterOpts{
+			Name: authorizationStatusValidatorErrorTotalName,
+			Help: authorizationStatusValidatorErrorTotalHelp,
+		},
+		labelNames...,
+	)
+}
+
+func newAuthorizationOrderErrorTotal(tf *touchstone.Factory, labelNames ...string) (m counterVec, err error) {
+	return newErrorTotal(
+		tf,
+		prometheus.CounterOpts{
+			Name: authorizationOrderValidatorErrorTotalName,
+			Help: authorizationOrderValidatorErrorTotalHelp,
+		},
+		labelNames...,
+	)
+}
+
+func newSourceSchemeErrorTotal(tf *touchstone.Factory, labelNames ...string) (m counterVec, err error) {
+	return newErrorTotal(
+		tf,
+		prometheus.CounterOpts{
+			Name: sourceSchemeValidatorErrorTotalName,
+			Help: sourceSchemeValidatorErrorTotalHelp,
+		},
+		labelNames...,
+	)
+}
+
+func newDestinationSchemeErrorTotal(tf *touchstone.Factory, labelNames ...string) (m counterVec, err error) {
+	return newErrorTotal(
+		tf,
+		prometheus.CounterOpts{
+			Name: destinationSchemeValidatorErrorTotalName,
+			Help: destinationSchemeValidatorErrorTotalHelp,
+		},
+		labelNames...,
+	)
+}
+
+func newToggleTotal(tf *touchstone.Factory, labelNames ...string) (m counterVec, err error) {
+	return newErrorTotal(
+		tf,
+		prometheus.CounterOpts{
+			Name: toggleValidatorTotalName,
+			Help: toggleValidatorTotalHelp,
+		},
+		append(labelNames[:len(labelNames):len(labelNames)], ValidatorLabel, EnforcementLabel, OutcomeLabel)...,
+	)
+}
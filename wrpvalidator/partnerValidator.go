@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpvalidator
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/xmidt-org/touchstone"
+	"github.com/xmidt-org/wrp-go/v3"
+	"go.uber.org/multierr"
+)
+
+// PartnerValidator is a WRP validator that validates based on the message's
+// partner IDs, e.g. to apply stricter rules to external partners, or using
+// the defaultValidator if none of the partner IDs are found.
+type PartnerValidator struct {
+	m                map[string]Validator
+	defaultValidator Validator
+}
+
+// Validate validates messages with the validator of each of the message's
+// partner IDs that is found, so a message with several partners must satisfy
+// all of their validators.  The defaultValidator is used if no partner ID is
+// found, including when the message has none.
+func (pv PartnerValidator) Validate(m wrp.Message, ls prometheus.Labels) error {
+	var (
+		err   error
+		found bool
+		seen  = make(map[string]struct{}, len(m.PartnerIDs))
+	)
+
+	for _, id := range m.TrimmedPartnerIDs() {
+		if _, dup := seen[id]; dup {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		if v := pv.m[id]; v != nil {
+			found = true
+			err = multierr.Append(err, v.Validate(m, ls))
+		}
+	}
+
+	if !found {
+		return pv.defaultValidator.Validate(m, ls)
+	}
+
+	return err
+}
+
+// NewPartnerValidator is a PartnerValidator factory.  The map is keyed by
+// partner ID.  If defaultValidator is nil, messages without a partner ID in
+// the map are invalid.
+func NewPartnerValidator(m map[string]Validator, defaultValidator Validator, tf *touchstone.Factory, labelNames ...string) (PartnerValidator, error) {
+	if m == nil {
+		return PartnerValidator{}, ErrorInvalidValidator
+	}
+
+	if defaultValidator == nil {
+		v, err := NewAlwaysInvalidWithMetric(tf, labelNames...)
+		if err != nil {
+			return PartnerValidator{}, err
+		}
+
+		defaultValidator = v
+	}
+
+	return PartnerValidator{
+		m:                m,
+		defaultValidator: defaultValidator,
+	}, nil
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpvalidator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/sallust"
+	"github.com/xmidt-org/touchstone"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func newTestFactory(t *testing.T) *touchstone.Factory {
+	cfg := touchstone.Config{
+		DefaultNamespace: "n",
+		DefaultSubsystem: "s",
+	}
+	_, pr, err := touchstone.New(cfg)
+	require.NoError(t, err)
+
+	return touchstone.NewFactory(cfg, sallust.Default(), pr)
+}
+
+func TestPartnerValidator(t *testing.T) {
+	var (
+		errStrict  = errors.New("strict")
+		errDefault = errors.New("default")
+		errOther   = errors.New("other")
+
+		strict = NewValidatorWithoutMetric(func(wrp.Message) error { return errStrict })
+		other  = NewValidatorWithoutMetric(func(wrp.Message) error { return errOther })
+		lax    = NewValidatorWithoutMetric(AlwaysValid)
+		deflt  = NewValidatorWithoutMetric(func(wrp.Message) error { return errDefault })
+	)
+
+	tests := []struct {
+		description      string
+		m                map[string]Validator
+		defaultValidator Validator
+		partnerIDs       []string
+		expectedErrs     []error
+	}{
+		{
+			description:      "Found success",
+			m:                map[string]Validator{"comcast": lax, "external": strict},
+			defaultValidator: deflt,
+			partnerIDs:       []string{"comcast"},
+		}, {
+			description:      "Found error",
+			m:                map[string]Validator{"comcast": lax, "external": strict},
+			defaultValidator: deflt,
+			partnerIDs:       []string{"external"},
+			expectedErrs:     []error{errStrict},
+		}, {
+			description:      "Multiple partners must all be valid",
+			m:                map[string]Validator{"comcast": lax, "external": strict},
+			defaultValidator: deflt,
+			partnerIDs:       []string{"comcast", "external"},
+			expectedErrs:     []error{errStrict},
+		}, {
+			description:      "Multiple partners errors are combined",
+			m:                map[string]Validator{"other": other, "external": strict},
+			defaultValidator: deflt,
+			partnerIDs:       []string{"other", "external"},
+			expectedErrs:     []error{errStrict, errOther},
+		}, {
+			description: "Duplicate and empty partner IDs are ignored",
+			m: map[string]Validator{
+				"external": ValidatorFunc(func(wrp.Message, prometheus.Labels) error {
+					return errStrict
+				}),
+			},
+			defaultValidator: deflt,
+			partnerIDs:       []string{"", "external", "external"},
+			expectedErrs:     []error{errStrict},
+		}, {
+			description:      "Unfound partner uses the default",
+			m:                map[string]Validator{"comcast": lax},
+			defaultValidator: deflt,
+			partnerIDs:       []string{"unknown"},
+			expectedErrs:     []error{errDefault},
+		}, {
+			description:      "Unfound partner with a found partner skips the default",
+			m:                map[string]Validator{"comcast": lax},
+			defaultValidator: deflt,
+			partnerIDs:       []string{"unknown", "comcast"},
+		}, {
+			description:      "No partner IDs uses the default",
+			m:                map[string]Validator{"comcast": lax},
+			defaultValidator: lax,
+		}, {
+			description:      "Nil validator uses the default",
+			m:                map[string]Validator{"comcast": nil},
+			defaultValidator: deflt,
+			partnerIDs:       []string{"comcast"},
+			expectedErrs:     []error{errDefault},
+		}, {
+			description:  "Nil default is always invalid",
+			m:            map[string]Validator{},
+			partnerIDs:   []string{"comcast"},
+			expectedErrs: []error{ErrorInvalidMsgType.Err},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			pv, err := NewPartnerValidator(tc.m, tc.defaultValidator, newTestFactory(t))
+			require.NoError(err)
+
+			err = pv.Validate(wrp.Message{PartnerIDs: tc.partnerIDs}, prometheus.Labels{})
+			if len(tc.expectedErrs) == 0 {
+				assert.NoError(err)
+				return
+			}
+
+			for _, expected := range tc.expectedErrs {
+				assert.ErrorIs(err, expected)
+			}
+		})
+	}
+}
+
+func TestNewPartnerValidator(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, err := NewPartnerValidator(nil, nil, newTestFactory(t))
+	assert.ErrorIs(err, ErrorInvalidValidator.Err)
+
+	// The metric for the default validator can only be registered once.
+	tf := newTestFactory(t)
+	_, err = NewAlwaysInvalidWithMetric(tf)
+	require.NoError(err)
+	_, err = NewPartnerValidator(map[string]Validator{}, nil, tf)
+	assert.Error(err)
+}
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpvalidator
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/xmidt-org/touchstone"
+	"github.com/xmidt-org/wrp-go/v3"
+	"go.uber.org/multierr"
+)
+
+var (
+	ErrorSourceSchemeNotAllowed      = NewValidatorError(errors.New("scheme of Source not allowed"), "", []string{"Source"})
+	ErrorDestinationSchemeNotAllowed = NewValidatorError(errors.New("scheme of Destination not allowed"), "", []string{"Destination"})
+)
+
+// SchemeRules are the locator schemes allowed for the Source and Destination
+// of messages handled by a service.  The schemes are case insensitive, e.g.
+// wrp.SchemeMAC.  An empty list allows any scheme.
+type SchemeRules struct {
+	// Source are the schemes allowed for the Source.
+	Source []string
+
+	// Destination are the schemes allowed for the Destination.
+	Destination []string
+}
+
+// EdgeSchemeRules returns the rules for a service at the edge, which receives
+// messages from devices.  A message must come from a device, and may be sent
+// to a device, a service or an event.  A new value is returned each time, so
+// callers may modify it freely.
+func EdgeSchemeRules() SchemeRules {
+	return SchemeRules{
+		Source:      []string{wrp.SchemeMAC, wrp.SchemeUUID, wrp.SchemeSerial},
+		Destination: []string{wrp.SchemeMAC, wrp.SchemeUUID, wrp.SchemeSerial, wrp.SchemeDNS, wrp.SchemeEvent},
+	}
+}
+
+// CoreSchemeRules returns the rules for a service in the core, which routes
+// messages to devices.  A message may come from a device or a service, but not
+// an event, and must be sent to a device or an event.  A new value is returned
+// each time, so callers may modify it freely.
+func CoreSchemeRules() SchemeRules {
+	return SchemeRules{
+		Source:      []string{wrp.SchemeMAC, wrp.SchemeUUID, wrp.SchemeSerial, wrp.SchemeDNS},
+		Destination: []string{wrp.SchemeMAC, wrp.SchemeUUID, wrp.SchemeSerial, wrp.SchemeEvent},
+	}
+}
+
+// LocatorSchemes ensures messages are valid based on each validator in the
+// list.  LocatorSchemes validates the following: the scheme of the Source is
+// one of rules.Source, and the scheme of the Destination is one of
+// rules.Destination.
+func LocatorSchemes(tf *touchstone.Factory, rules SchemeRules, labelNames ...string) (Validators, error) {
+	var errs error
+	ssv, err := NewSourceSchemesWithMetric(tf, rules.Source, labelNames...)
+	if err != nil {
+		errs = multierr.Append(errs, err)
+	}
+
+	dsv, err := NewDestinationSchemesWithMetric(tf, rules.Destination, labelNames...)
+	if err != nil {
+		errs = multierr.Append(errs, err)
+	}
+
+	return Validators{}.AddFunc(ssv, dsv), errs
+}
+
+// NewSourceSchemesWithMetric returns a SourceSchemes validator with a metric middleware.
+func NewSourceSchemesWithMetric(tf *touchstone.Factory, schemes []string, labelNames ...string) (ValidatorFunc, error) {
+	m, err := newSourceSchemeErrorTotal(tf, labelNames...)
+	validate := SourceSchemes(schemes...)
+
+	return func(msg wrp.Message, ls prometheus.Labels) error {
+		err := validate(msg)
+		if err != nil {
+			m.With(ls).Add(1.0)
+		}
+
+		return err
+	}, err
+}
+
+// NewDestinationSchemesWithMetric returns a DestinationSchemes validator with a metric middleware.
+func NewDestinationSchemesWithMetric(tf *touchstone.Factory, schemes []string, labelNames ...string) (ValidatorFunc, error) {
+	m, err := newDestinationSchemeErrorTotal(tf, labelNames...)
+	validate := DestinationSchemes(schemes...)
+
+	return func(msg wrp.Message, ls prometheus.Labels) error {
+		err := validate(msg)
+		if err != nil {
+			m.With(ls).Add(1.0)
+		}
+
+		return err
+	}, err
+}
+
+// SourceSchemes returns a validator that requires the scheme of the Source to
+// be one of the given schemes.  If no schemes are given, any scheme is
+// allowed.
+func SourceSchemes(schemes ...string) func(wrp.Message) error {
+	allowed := newSchemeSet(schemes)
+
+	return func(m wrp.Message) error {
+		if err := allowed.validate(m.Source); err != nil {
+			return fmt.Errorf("%w '%s': %v", ErrorSourceSchemeNotAllowed, m.Source, err)
+		}
+
+		return nil
+	}
+}
+
+// DestinationSchemes returns a validator that requires the scheme of the
+// Destination to be one of the given schemes.  If no schemes are given, any
+// scheme is allowed.
+func DestinationSchemes(schemes ...string) func(wrp.Message) error {
+	allowed := newSchemeSet(schemes)
+
+	return func(m wrp.Message) error {
+		if err := allowed.validate(m.Destination); err != nil {
+			return fmt.Errorf("%w '%s': %v", ErrorDestinationSchemeNotAllowed, m.Destination, err)
+		}
+
+		return nil
+	}
+}
+
+// schemeSet is a set of lower case schemes.
+type schemeSet []string
+
+func newSchemeSet(schemes []string) schemeSet {
+	set := make(schemeSet, 0, len(schemes))
+	for _, s := range schemes {
+		set = append(set, strings.ToLower(strings.TrimSpace(s)))
+	}
+
+	return set
+}
+
+// validate returns the reason the locator's scheme is not allowed, or nil.
+func (set schemeSet) validate(locator string) error {
+	if len(set) == 0 {
+		return nil
+	}
+
+	l, err := wrp.ParseLocator(locator)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(set, l.Scheme) {
+		return fmt.Errorf("scheme `%s` is not one of %v", l.Scheme, []string(set))
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpvalidator
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestSourceAndDestinationSchemes(t *testing.T) {
+	tests := []struct {
+		description string
+		schemes     []string
+		msg         wrp.Message
+		expectedErr []error
+	}{
+		{
+			description: "allowed schemes success",
+			schemes:     []string{"mac", "DNS"},
+			msg:         wrp.Message{Source: "dns:talaria.example.com", Destination: "MAC:112233445566/config"},
+		},
+		{
+			description: "no schemes success",
+			msg:         wrp.Message{Source: "event:device-status", Destination: "invalid"},
+		},
+		{
+			description: "source scheme error",
+			schemes:     []string{wrp.SchemeMAC},
+			msg:         wrp.Message{Source: "event:device-status", Destination: "mac:112233445566"},
+			expectedErr: []error{ErrorSourceSchemeNotAllowed},
+		},
+		{
+			description: "destination scheme error",
+			schemes:     []string{wrp.SchemeDNS},
+			msg:         wrp.Message{Source: "dns:talaria.example.com", Destination: "mac:112233445566"},
+			expectedErr: []error{ErrorDestinationSchemeNotAllowed},
+		},
+		{
+			description: "invalid locator error",
+			schemes:     []string{wrp.SchemeDNS},
+			msg:         wrp.Message{Source: "invalid", Destination: "invalid"},
+			expectedErr: []error{ErrorSourceSchemeNotAllowed, ErrorDestinationSchemeNotAllowed},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+			sv, err := LocatorSchemes(newAuthTestFactory(t), SchemeRules{Source: tc.schemes, Destination: tc.schemes})
+			require.NoError(err)
+			err = sv.Validate(tc.msg, prometheus.Labels{})
+			if tc.expectedErr != nil {
+				for _, e := range tc.expectedErr {
+					var targetErr ValidatorError
+
+					assert.ErrorAs(e, &targetErr)
+					assert.ErrorIs(err, targetErr.Err)
+				}
+
+				return
+			}
+
+			assert.NoError(err)
+		})
+	}
+}
+
+func TestSchemeRules(t *testing.T) {
+	tests := []struct {
+		description string
+		rules       SchemeRules
+		msg         wrp.Message
+		expectedErr error
+	}{
+		{
+			description: "edge event from device success",
+			rules:       EdgeSchemeRules(),
+			msg:         wrp.Message{Source: "mac:112233445566", Destination: "event:device-status/online"},
+		},
+		{
+			description: "edge response to service success",
+			rules:       EdgeSchemeRules(),
+			msg:         wrp.Message{Source: "uuid:b1ef2bd4-3e6f-4e0c-9a6e-9c42b4a1c7d0/config", Destination: "dns:scytale.example.com"},
+		},
+		{
+			description: "edge message from service error",
+			rules:       EdgeSchemeRules(),
+			msg:         wrp.Message{Source: "dns:scytale.example.com", Destination: "mac:112233445566"},
+			expectedErr: ErrorSourceSchemeNotAllowed,
+		},
+		{
+			description: "core request to device success",
+			rules:       CoreSchemeRules(),
+			msg:         wrp.Message{Source: "dns:scytale.example.com", Destination: "serial:1234/config"},
+		},
+		{
+			description: "core message from event error",
+			rules:       CoreSchemeRules(),
+			msg:         wrp.Message{Source: "event:device-status", Destination: "mac:112233445566"},
+			expectedErr: ErrorSourceSchemeNotAllowed,
+		},
+		{
+			description: "core message to service error",
+			rules:       CoreSchemeRules(),
+			msg:         wrp.Message{Source: "mac:112233445566", Destination: "dns:scytale.example.com"},
+			expectedErr: ErrorDestinationSchemeNotAllowed,
+		},
+		{
+			description: "core message to self error",
+			rules:       CoreSchemeRules(),
+			msg:         wrp.Message{Source: "mac:112233445566", Destination: "self:/config"},
+			expectedErr: ErrorDestinationSchemeNotAllowed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			err := SourceSchemes(tc.rules.Source...)(tc.msg)
+			if err == nil {
+				err = DestinationSchemes(tc.rules.Destination...)(tc.msg)
+			}
+
+			if expectedErr := tc.expectedErr; expectedErr != nil {
+				var targetErr ValidatorError
+
+				assert.ErrorAs(expectedErr, &targetErr)
+				assert.ErrorIs(err, targetErr.Err)
+				return
+			}
+
+			assert.NoError(err)
+		})
+	}
+}
@@ -19,11 +19,12 @@ const (
 )
 
 var (
-	ErrorInvalidMessageEncoding = NewValidatorError(errors.New("invalid message encoding"), "", nil)
-	ErrorInvalidMessageType     = NewValidatorError(errors.New("invalid message type"), "", []string{"Type"})
-	ErrorInvalidSource          = NewValidatorError(errors.New("invalid Source name"), "", []string{"Source"})
-	ErrorInvalidDestination     = NewValidatorError(errors.New("invalid Destination name"), "", []string{"Destination"})
-	errorInvalidUUID            = errors.New("invalid UUID")
+	ErrorInvalidMessageEncoding  = NewValidatorError(errors.New("invalid message encoding"), "", nil)
+	ErrorInvalidMessageType      = NewValidatorError(errors.New("invalid message type"), "", []string{"Type"})
+	ErrorInvalidSource           = NewValidatorError(errors.New("invalid Source name"), "", []string{"Source"})
+	ErrorInvalidDestination      = NewValidatorError(errors.New("invalid Destination name"), "", []string{"Destination"})
+	ErrorInvalidQualityOfService = NewValidatorError(errors.New("invalid QualityOfService value"), "", []string{"QualityOfService"})
+	errorInvalidUUID             = errors.New("invalid UUID")
 )
 
 // SpecWithMetrics ensures messages are valid based on each spec validator in the list.
@@ -54,6 +55,112 @@ func SpecWithMetrics(tf *touchstone.Factory, labelNames ...string) (Validators,
 	return Validators{}.AddFunc(utf8v, mtv, sv, dv), errs
 }
 
+// SpecOption selects the checks made by SpecWithOptions.
+type SpecOption interface {
+	apply(*specConfig)
+}
+
+type specOptionFunc func(*specConfig)
+
+func (f specOptionFunc) apply(c *specConfig) {
+	f(c)
+}
+
+type specConfig struct {
+	skipUTF8        bool
+	skipMessageType bool
+	skipLocators    bool
+	skipQOS         bool
+	labelNames      []string
+}
+
+// SkipUTF8Validation skips the UTF8 check, which examines every string field
+// and is the most expensive of the spec checks.
+func SkipUTF8Validation() SpecOption {
+	return specOptionFunc(func(c *specConfig) {
+		c.skipUTF8 = true
+	})
+}
+
+// SkipMessageTypeValidation skips the MessageType check.
+func SkipMessageTypeValidation() SpecOption {
+	return specOptionFunc(func(c *specConfig) {
+		c.skipMessageType = true
+	})
+}
+
+// SkipLocatorValidation skips the Source and Destination checks.
+func SkipLocatorValidation() SpecOption {
+	return specOptionFunc(func(c *specConfig) {
+		c.skipLocators = true
+	})
+}
+
+// SkipQOSValidation skips the QualityOfService check.
+func SkipQOSValidation() SpecOption {
+	return specOptionFunc(func(c *specConfig) {
+		c.skipQOS = true
+	})
+}
+
+// NoSpecValidation skips all of the spec checks.  It is the same as using
+// every other Skip option.
+func NoSpecValidation() SpecOption {
+	return specOptionFunc(func(c *specConfig) {
+		c.skipUTF8 = true
+		c.skipMessageType = true
+		c.skipLocators = true
+		c.skipQOS = true
+	})
+}
+
+// SpecLabelNames sets the label names of the metrics.
+func SpecLabelNames(labelNames ...string) SpecOption {
+	return specOptionFunc(func(c *specConfig) {
+		c.labelNames = labelNames
+	})
+}
+
+// SpecWithOptions is like SpecWithMetrics, but each category of checks can be
+// skipped, so performance sensitive paths can keep the cheap checks while
+// disabling the expensive ones.  In addition to the checks of SpecWithMetrics,
+// the QualityOfService is validated.  Metrics are only created for the checks
+// that are made.
+func SpecWithOptions(tf *touchstone.Factory, opts ...SpecOption) (Validators, error) {
+	var c specConfig
+	for _, opt := range opts {
+		if opt != nil {
+			opt.apply(&c)
+		}
+	}
+
+	var (
+		errs error
+		vs   Validators
+	)
+
+	add := func(skip bool, newValidator func(*touchstone.Factory, ...string) (ValidatorFunc, error)) {
+		if skip {
+			return
+		}
+
+		v, err := newValidator(tf, c.labelNames...)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+		}
+
+		vs = vs.AddFunc(v)
+	}
+
+	add(c.skipUTF8, NewUTF8WithMetric)
+	add(c.skipMessageType, NewMessageTypeWithMetric)
+	add(c.skipLocators, NewSourceWithMetric)
+	add(c.skipLocators, NewDestinationWithMetric)
+	add(c.skipQOS, NewQualityOfServiceWithMetric)
+
+	return vs, errs
+}
+
 // NewUTF8WithMetric returns a UTF8 validator with a metric middleware.
 func NewUTF8WithMetric(tf *touchstone.Factory, labelNames ...string) (ValidatorFunc, error) {
 	m, err := newUTF8ErrorTotal(tf, labelNames...)
@@ -110,6 +217,20 @@ func NewDestinationWithMetric(tf *touchstone.Factory, labelNames ...string) (Val
 	}, err
 }
 
+// NewQualityOfServiceWithMetric returns a QualityOfService validator with a metric middleware.
+func NewQualityOfServiceWithMetric(tf *touchstone.Factory, labelNames ...string) (ValidatorFunc, error) {
+	m, err := newQualityOfServiceErrorTotal(tf, labelNames...)
+
+	return func(msg wrp.Message, ls prometheus.Labels) error {
+		err := QualityOfService(msg)
+		if err != nil {
+			m.With(ls).Add(1.0)
+		}
+
+		return err
+	}, err
+}
+
 // UTF8 takes messages and validates that it contains UTF-8 strings.
 func UTF8(m wrp.Message) error {
 	if err := wrp.UTF8(m); err != nil {
@@ -150,11 +271,22 @@ func Destination(m wrp.Message) error {
 	return nil
 }
 
+// QualityOfService takes messages and validates that their QualityOfService
+// is within the range of 0 to 99 defined by the spec.
+func QualityOfService(m wrp.Message) error {
+	if m.QualityOfService < 0 || m.QualityOfService > 99 {
+		return fmt.Errorf("%w: %d", ErrorInvalidQualityOfService, m.QualityOfService)
+	}
+
+	return nil
+}
+
 // validateLocator validates a given locator's scheme and authority (ID).
 // Only mac and uuid schemes' IDs are validated. IDs from serial, event and dns schemes are
-// not validated.
+// not validated, except that locators of registered schemes are checked by the
+// SchemeValidator of their scheme.
 func validateLocator(s string) error {
-	l, err := wrp.ParseLocator(s)
+	l, err := wrp.ValidateLocator(s)
 	if err != nil {
 		return err
 	}
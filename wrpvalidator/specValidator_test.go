@@ -24,6 +24,7 @@ func TestSpecHelperValidators(t *testing.T) {
 		{"MessageType", testMessageType},
 		{"Source", testSource},
 		{"Destination", testDestination},
+		{"QualityOfService", testQualityOfService},
 		{"validateLocator", testValidateLocator},
 	}
 
@@ -750,3 +751,186 @@ func testValidateLocator(t *testing.T) {
 		})
 	}
 }
+
+func testQualityOfService(t *testing.T) {
+	tests := []struct {
+		description string
+		msg         wrp.Message
+		expectedErr error
+	}{
+		// Success case
+		{
+			description: "QualityOfService success",
+			msg:         wrp.Message{QualityOfService: 99},
+		},
+		{
+			description: "QualityOfService zero success",
+			msg:         wrp.Message{},
+		},
+		// Failures
+		{
+			description: "QualityOfService too large",
+			msg:         wrp.Message{QualityOfService: 100},
+			expectedErr: ErrorInvalidQualityOfService,
+		},
+		{
+			description: "QualityOfService negative",
+			msg:         wrp.Message{QualityOfService: -1},
+			expectedErr: ErrorInvalidQualityOfService,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			err := QualityOfService(tc.msg)
+			if expectedErr := tc.expectedErr; expectedErr != nil {
+				var targetErr ValidatorError
+
+				assert.ErrorAs(expectedErr, &targetErr)
+				assert.ErrorIs(err, targetErr.Err)
+				return
+			}
+
+			assert.NoError(err)
+		})
+	}
+}
+
+func TestSpecWithOptions(t *testing.T) {
+	var (
+		invalidUTF8 = string([]byte{0xbf})
+
+		// invalid breaks every spec check.
+		invalid = wrp.Message{
+			Type:             wrp.Invalid0MessageType,
+			Source:           "invalid",
+			Destination:      "invalid",
+			Path:             invalidUTF8,
+			QualityOfService: 100,
+		}
+
+		allErrs = []error{
+			ErrorInvalidMessageEncoding,
+			ErrorInvalidMessageType,
+			ErrorInvalidSource,
+			ErrorInvalidDestination,
+			ErrorInvalidQualityOfService,
+		}
+	)
+
+	tests := []struct {
+		description  string
+		opts         []SpecOption
+		expectedErrs []error
+		skippedErrs  []error
+	}{
+		{
+			description:  "All checks",
+			expectedErrs: allErrs,
+		},
+		{
+			description:  "Nil option",
+			opts:         []SpecOption{nil},
+			expectedErrs: allErrs,
+		},
+		{
+			description:  "Skip UTF8",
+			opts:         []SpecOption{SkipUTF8Validation()},
+			expectedErrs: []error{ErrorInvalidMessageType, ErrorInvalidSource, ErrorInvalidDestination, ErrorInvalidQualityOfService},
+			skippedErrs:  []error{ErrorInvalidMessageEncoding},
+		},
+		{
+			description:  "Skip MessageType",
+			opts:         []SpecOption{SkipMessageTypeValidation()},
+			expectedErrs: []error{ErrorInvalidMessageEncoding, ErrorInvalidSource, ErrorInvalidDestination, ErrorInvalidQualityOfService},
+			skippedErrs:  []error{ErrorInvalidMessageType},
+		},
+		{
+			description:  "Skip locators",
+			opts:         []SpecOption{SkipLocatorValidation()},
+			expectedErrs: []error{ErrorInvalidMessageEncoding, ErrorInvalidMessageType, ErrorInvalidQualityOfService},
+			skippedErrs:  []error{ErrorInvalidSource, ErrorInvalidDestination},
+		},
+		{
+			description:  "Skip QOS",
+			opts:         []SpecOption{SkipQOSValidation()},
+			expectedErrs: []error{ErrorInvalidMessageEncoding, ErrorInvalidMessageType, ErrorInvalidSource, ErrorInvalidDestination},
+			skippedErrs:  []error{ErrorInvalidQualityOfService},
+		},
+		{
+			description:  "Keep only the cheap checks",
+			opts:         []SpecOption{SkipUTF8Validation(), SkipLocatorValidation()},
+			expectedErrs: []error{ErrorInvalidMessageType, ErrorInvalidQualityOfService},
+			skippedErrs:  []error{ErrorInvalidMessageEncoding, ErrorInvalidSource, ErrorInvalidDestination},
+		},
+		{
+			description: "No spec validation",
+			opts:        []SpecOption{NoSpecValidation()},
+			skippedErrs: allErrs,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+			cfg := touchstone.Config{
+				DefaultNamespace: "n",
+				DefaultSubsystem: "s",
+			}
+			_, pr, err := touchstone.New(cfg)
+			require.NoError(err)
+
+			tf := touchstone.NewFactory(cfg, sallust.Default(), pr)
+			sv, err := SpecWithOptions(tf, append(tc.opts, SpecLabelNames(PartnerIDLabel))...)
+			require.NoError(err)
+
+			err = sv.Validate(invalid, prometheus.Labels{PartnerIDLabel: "foo"})
+			for _, e := range tc.expectedErrs {
+				var targetErr ValidatorError
+
+				assert.ErrorAs(e, &targetErr)
+				assert.ErrorIs(err, targetErr.Err)
+			}
+			for _, e := range tc.skippedErrs {
+				var targetErr ValidatorError
+
+				assert.ErrorAs(e, &targetErr)
+				assert.NotErrorIs(err, targetErr.Err)
+			}
+
+			if len(tc.expectedErrs) == 0 {
+				assert.NoError(err)
+			}
+
+			// Valid messages pass.
+			assert.NoError(sv.Validate(wrp.Message{
+				Type:        wrp.SimpleEventMessageType,
+				Source:      "dns:example.com",
+				Destination: "mac:112233445566",
+			}, prometheus.Labels{PartnerIDLabel: "foo"}))
+		})
+	}
+}
+
+func TestSpecWithOptionsDuplicateValidators(t *testing.T) {
+	require := require.New(t)
+	cfg := touchstone.Config{
+		DefaultNamespace: "n",
+		DefaultSubsystem: "s",
+	}
+	_, pr, err := touchstone.New(cfg)
+	require.NoError(err)
+
+	tf := touchstone.NewFactory(cfg, sallust.Default(), pr)
+	_, err = SpecWithOptions(tf, SkipQOSValidation())
+	require.NoError(err)
+
+	// The checks that were skipped register their metrics now.
+	_, err = SpecWithOptions(tf, SkipUTF8Validation(), SkipMessageTypeValidation(), SkipLocatorValidation())
+	require.NoError(err)
+
+	_, err = SpecWithOptions(tf)
+	require.Error(err)
+}
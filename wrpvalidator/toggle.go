@@ -0,0 +1,334 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpvalidator
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/xmidt-org/touchstone"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+var ErrUnknownToggle = errors.New("unknown validator toggle")
+
+// Enforcement is how a Toggle enforces its validator.  The initial
+// Enforcement of the toggle of a MetaValidator is given by its Metadata, see
+// Toggles.AddMeta.
+type Enforcement int32
+
+const (
+	// EnforceMode runs the validator and returns its errors.
+	EnforceMode Enforcement = iota
+
+	// ReportMode runs the validator and counts its errors, but does not return
+	// them, downgrading the validator to a report only check.
+	ReportMode
+
+	// DisableMode does not run the validator.
+	DisableMode
+	lastMode
+)
+
+var (
+	enforcementUnmarshal = map[string]Enforcement{
+		"enforce": EnforceMode,
+		"report":  ReportMode,
+		"disable": DisableMode,
+	}
+	enforcementMarshal = map[Enforcement]string{
+		EnforceMode: "enforce",
+		ReportMode:  "report",
+		DisableMode: "disable",
+	}
+)
+
+// IsValid returns true if the value is a known Enforcement.
+func (e Enforcement) IsValid() bool {
+	return EnforceMode <= e && e < lastMode
+}
+
+// String returns the name of the Enforcement, which is also its metric label
+// value, e.g. "report".
+func (e Enforcement) String() string {
+	if value, ok := enforcementMarshal[e]; ok {
+		return value
+	}
+
+	return "unknown"
+}
+
+// MarshalText marshals an Enforcement as its name.
+func (e Enforcement) MarshalText() ([]byte, error) {
+	if !e.IsValid() {
+		return nil, fmt.Errorf("%w: enforcement %d", ErrValidatorInvalidConfig, e)
+	}
+
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText unmarshals the name of an Enforcement, so the modes of a set of
+// toggles can be read from configuration.
+func (e *Enforcement) UnmarshalText(b []byte) error {
+	s := strings.ToLower(string(b))
+	r, ok := enforcementUnmarshal[s]
+	if !ok {
+		keys := make([]string, 0, len(enforcementUnmarshal))
+		for k := range enforcementUnmarshal {
+			keys = append(keys, "'"+k+"'")
+		}
+
+		sort.Strings(keys)
+		return fmt.Errorf("Enforcement error: '%s' does not match any valid options: %s",
+			s, strings.Join(keys, ", "))
+	}
+
+	*e = r
+	return nil
+}
+
+// Toggle is a Validator whose Enforcement can be changed at runtime, so that
+// operators can disable or downgrade a validator, e.g. a strict locator check,
+// without redeploying.  Toggles are created with Toggles.Add.
+type Toggle struct {
+	name    string
+	v       Validator
+	mode    atomic.Int32
+	toggles *Toggles
+}
+
+var _ Validator = (*Toggle)(nil)
+
+// Name returns the name of the toggle.
+func (t *Toggle) Name() string {
+	return t.name
+}
+
+// Enforcement returns the current Enforcement of the toggle.
+func (t *Toggle) Enforcement() Enforcement {
+	return Enforcement(t.mode.Load())
+}
+
+// Set changes the Enforcement of the toggle.
+func (t *Toggle) Set(e Enforcement) error {
+	if !e.IsValid() {
+		return fmt.Errorf("toggle `%s`: %w: enforcement %d", t.name, ErrValidatorInvalidConfig, e)
+	}
+
+	t.mode.Store(int32(e))
+	return nil
+}
+
+// Validate runs the toggled validator according to the current Enforcement,
+// counting the outcome.
+func (t *Toggle) Validate(m wrp.Message, ls prometheus.Labels) error {
+	e := t.Enforcement()
+
+	var err error
+	outcome := toggleSkipped
+	if e != DisableMode {
+		outcome = toggleValid
+		if err = t.v.Validate(m, ls); err != nil {
+			outcome = toggleInvalid
+		}
+	}
+
+	labels := make(prometheus.Labels, len(ls)+3)
+	for k, v := range ls {
+		labels[k] = v
+	}
+
+	labels[ValidatorLabel] = t.name
+	labels[EnforcementLabel] = e.String()
+	labels[OutcomeLabel] = outcome
+	t.toggles.total.With(labels).Inc()
+
+	if e != EnforceMode {
+		return nil
+	}
+
+	return err
+}
+
+// Toggles is a set of named Toggles that share a metric.  Its Apply method can
+// be used as the hook of a configuration watcher to change the Enforcement of
+// several toggles at once.  Toggles is safe for concurrent use.
+type Toggles struct {
+	total counterVec
+
+	m       sync.RWMutex
+	toggles map[string]*Toggle
+}
+
+// NewToggles creates an empty set of toggles.  labelNames are the names of the
+// labels passed to Validate, to which the toggle metric adds the validator,
+// enforcement and outcome labels.
+//
+// The underlying metric can only be registered once per touchstone.Factory.
+func NewToggles(tf *touchstone.Factory, labelNames ...string) (*Toggles, error) {
+	total, err := newToggleTotal(tf, labelNames...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Toggles{
+		total:   total,
+		toggles: make(map[string]*Toggle),
+	}, nil
+}
+
+// Add creates a Toggle for the validator with the given name and initial
+// Enforcement.  Names must be unique within the set.
+func (ts *Toggles) Add(name string, v Validator, e Enforcement) (*Toggle, error) {
+	t, err := ts.newToggle(name, v, e)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ts.insert(t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// AddMeta creates a Toggle for each MetaValidator, so that validators
+// configured as MetaValidators can be toggled by name.  Each toggle is named
+// by the Type of its validator, e.g. "source", and its initial Enforcement is
+// given by the Metadata:
+//
+//   - a disabled validator is in DisableMode,
+//   - a validator of ErrorLevel is in EnforceMode, and
+//   - a validator of InfoLevel or WarningLevel, whose errors are not meant to
+//     reject messages, is in ReportMode.
+//
+// If any MetaValidator is invalid or any name is taken, no toggles are added.
+func (ts *Toggles) AddMeta(vs ...MetaValidator) ([]*Toggle, error) {
+	toggles := make([]*Toggle, 0, len(vs))
+	for _, v := range vs {
+		if !v.IsValid() {
+			return nil, fmt.Errorf("validator `%s`: invalid configuration: %w", v.Type(), ErrValidatorInvalidConfig)
+		}
+
+		t, err := ts.newToggle(v.Type().String(), v.validator, enforcementOf(v.meta))
+		if err != nil {
+			return nil, err
+		}
+		toggles = append(toggles, t)
+	}
+
+	if err := ts.insert(toggles...); err != nil {
+		return nil, err
+	}
+
+	return toggles, nil
+}
+
+// enforcementOf returns the initial Enforcement of the toggle of a
+// MetaValidator.
+func enforcementOf(m Metadata) Enforcement {
+	switch {
+	case m.Disable:
+		return DisableMode
+	case m.Level == ErrorLevel:
+		return EnforceMode
+	}
+
+	return ReportMode
+}
+
+func (ts *Toggles) newToggle(name string, v Validator, e Enforcement) (*Toggle, error) {
+	switch {
+	case name == "":
+		return nil, fmt.Errorf("%w: toggle without a name", ErrValidatorInvalidConfig)
+	case v == nil:
+		return nil, fmt.Errorf("toggle `%s`: %w: no validator", name, ErrValidatorInvalidConfig)
+	}
+
+	t := Toggle{
+		name:    name,
+		v:       v,
+		toggles: ts,
+	}
+	if err := t.Set(e); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// insert adds the toggles to the set, unless any of their names is taken.
+func (ts *Toggles) insert(toggles ...*Toggle) error {
+	ts.m.Lock()
+	defer ts.m.Unlock()
+
+	names := make(map[string]bool, len(toggles))
+	for _, t := range toggles {
+		if _, dup := ts.toggles[t.name]; dup || names[t.name] {
+			return fmt.Errorf("toggle `%s`: %w: duplicate name", t.name, ErrValidatorInvalidConfig)
+		}
+		names[t.name] = true
+	}
+
+	for _, t := range toggles {
+		ts.toggles[t.name] = t
+	}
+
+	return nil
+}
+
+// Get returns the toggle with the given name.
+func (ts *Toggles) Get(name string) (*Toggle, bool) {
+	ts.m.RLock()
+	defer ts.m.RUnlock()
+
+	t, ok := ts.toggles[name]
+	return t, ok
+}
+
+// Set changes the Enforcement of the named toggle.
+func (ts *Toggles) Set(name string, e Enforcement) error {
+	return ts.Apply(map[string]Enforcement{name: e})
+}
+
+// Apply changes the Enforcement of each named toggle.  Toggles not in modes
+// are left as they are.  If any name is unknown or any Enforcement is
+// invalid, no toggles are changed.
+func (ts *Toggles) Apply(modes map[string]Enforcement) error {
+	ts.m.RLock()
+	defer ts.m.RUnlock()
+
+	for name, e := range modes {
+		if _, ok := ts.toggles[name]; !ok {
+			return fmt.Errorf("%w: `%s`", ErrUnknownToggle, name)
+		}
+		if !e.IsValid() {
+			return fmt.Errorf("toggle `%s`: %w: enforcement %d", name, ErrValidatorInvalidConfig, e)
+		}
+	}
+
+	for name, e := range modes {
+		_ = ts.toggles[name].Set(e)
+	}
+
+	return nil
+}
+
+// Modes returns the current Enforcement of every toggle.
+func (ts *Toggles) Modes() map[string]Enforcement {
+	ts.m.RLock()
+	defer ts.m.RUnlock()
+
+	modes := make(map[string]Enforcement, len(ts.toggles))
+	for name, t := range ts.toggles {
+		modes[name] = t.Enforcement()
+	}
+
+	return modes
+}
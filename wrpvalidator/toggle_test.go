@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2025 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package wrpvalidator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/sallust"
+	"github.com/xmidt-org/touchstone"
+	"github.com/xmidt-org/wrp-go/v3"
+)
+
+func TestToggle(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	cfg := touchstone.Config{
+		DefaultNamespace: "n",
+		DefaultSubsystem: "s",
+	}
+	pr := prometheus.NewPedanticRegistry()
+	ts, err := NewToggles(touchstone.NewFactory(cfg, sallust.Default(), pr), PartnerIDLabel)
+	require.NoError(err)
+
+	source, err := ts.Add("source", NewValidatorWithoutMetric(Source), EnforceMode)
+	require.NoError(err)
+	assert.Equal("source", source.Name())
+
+	invalid := wrp.Message{Source: "invalid"}
+	ls := prometheus.Labels{PartnerIDLabel: "comcast"}
+
+	assert.ErrorIs(source.Validate(invalid, ls), ErrorInvalidSource.Err)
+	assert.NoError(source.Validate(wrp.Message{Source: "dns:example.com"}, ls))
+
+	require.NoError(ts.Set("source", ReportMode))
+	assert.Equal(ReportMode, source.Enforcement())
+	assert.NoError(source.Validate(invalid, ls))
+
+	require.NoError(ts.Apply(map[string]Enforcement{"source": DisableMode}))
+	assert.NoError(source.Validate(invalid, ls))
+	assert.Equal(map[string]Enforcement{"source": DisableMode}, ts.Modes())
+
+	// the labels passed to Validate are not modified
+	assert.Equal(prometheus.Labels{PartnerIDLabel: "comcast"}, ls)
+
+	assert.NoError(testutil.GatherAndCompare(pr, strings.NewReader(`
+# HELP n_s_wrp_validator_toggle the total number of Toggle validations by validator, enforcement and outcome
+# TYPE n_s_wrp_validator_toggle counter
+n_s_wrp_validator_toggle{enforcement="disable",outcome="skipped",partner_id="comcast",validator="source"} 1
+n_s_wrp_validator_toggle{enforcement="enforce",outcome="invalid",partner_id="comcast",validator="source"} 1
+n_s_wrp_validator_toggle{enforcement="enforce",outcome="valid",partner_id="comcast",validator="source"} 1
+n_s_wrp_validator_toggle{enforcement="report",outcome="invalid",partner_id="comcast",validator="source"} 1
+`), "n_s_"+toggleValidatorTotalName))
+}
+
+func TestToggles_invalid(t *testing.T) {
+	ts, err := NewToggles(newAuthTestFactory(t))
+	require.NoError(t, err)
+
+	_, err = ts.Add("source", NewValidatorWithoutMetric(Source), EnforceMode)
+	require.NoError(t, err)
+
+	tests := []struct {
+		description string
+		add         func() error
+		expectedErr error
+	}{
+		{
+			description: "no name",
+			add: func() error {
+				_, err := ts.Add("", NewValidatorWithoutMetric(Source), EnforceMode)
+				return err
+			},
+			expectedErr: ErrValidatorInvalidConfig,
+		}, {
+			description: "no validator",
+			add: func() error {
+				_, err := ts.Add("utf8", nil, EnforceMode)
+				return err
+			},
+			expectedErr: ErrValidatorInvalidConfig,
+		}, {
+			description: "invalid enforcement",
+			add: func() error {
+				_, err := ts.Add("utf8", NewValidatorWithoutMetric(UTF8), lastMode)
+				return err
+			},
+			expectedErr: ErrValidatorInvalidConfig,
+		}, {
+			description: "duplicate name",
+			add: func() error {
+				_, err := ts.Add("source", NewValidatorWithoutMetric(Source), EnforceMode)
+				return err
+			},
+			expectedErr: ErrValidatorInvalidConfig,
+		}, {
+			description: "duplicate meta validator",
+			add: func() error {
+				_, err := ts.AddMeta(newTestMetaValidator(t, `{"type": "utf8", "level": "error"}`), newTestMetaValidator(t, `{"type": "source", "level": "error"}`))
+				return err
+			},
+			expectedErr: ErrValidatorInvalidConfig,
+		}, {
+			description: "invalid meta validator",
+			add: func() error {
+				_, err := ts.AddMeta(MetaValidator{})
+				return err
+			},
+			expectedErr: ErrValidatorInvalidConfig,
+		}, {
+			description: "unknown toggle",
+			add: func() error {
+				return ts.Apply(map[string]Enforcement{"source": DisableMode, "utf8": DisableMode})
+			},
+			expectedErr: ErrUnknownToggle,
+		}, {
+			description: "apply invalid enforcement",
+			add: func() error {
+				return ts.Apply(map[string]Enforcement{"source": lastMode})
+			},
+			expectedErr: ErrValidatorInvalidConfig,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.ErrorIs(t, tc.add(), tc.expectedErr)
+
+			// a failed change leaves the toggles as they were
+			assert.Equal(t, map[string]Enforcement{"source": EnforceMode}, ts.Modes())
+		})
+	}
+
+	_, ok := ts.Get("utf8")
+	assert.False(t, ok)
+}
+
+func newTestMetaValidator(t *testing.T, config string) MetaValidator {
+	var v MetaValidator
+	require.NoError(t, json.Unmarshal([]byte(config), &v))
+	return v
+}
+
+func TestToggles_AddMeta(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	ts, err := NewToggles(newAuthTestFactory(t))
+	require.NoError(err)
+
+	toggles, err := ts.AddMeta(
+		newTestMetaValidator(t, `{"type": "source", "level": "error"}`),
+		newTestMetaValidator(t, `{"type": "destination", "level": "warning"}`),
+		newTestMetaValidator(t, `{"type": "utf8", "level": "error", "disable": true}`),
+	)
+	require.NoError(err)
+	require.Len(toggles, 3)
+	assert.Equal("source", toggles[0].Name())
+	assert.Equal(map[string]Enforcement{
+		"source":      EnforceMode,
+		"destination": ReportMode,
+		"utf8":        DisableMode,
+	}, ts.Modes())
+
+	invalid := wrp.Message{Source: "invalid", Destination: "invalid"}
+	assert.ErrorIs(toggles[0].Validate(invalid, nil), ErrorInvalidSource.Err)
+	assert.NoError(toggles[1].Validate(invalid, nil))
+
+	// a disabled MetaValidator can be enabled through its toggle
+	require.NoError(ts.Set("utf8", EnforceMode))
+	assert.Error(toggles[2].Validate(wrp.Message{Source: "\xff"}, nil))
+}
+
+func TestEnforcement_text(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var modes map[string]Enforcement
+	require.NoError(json.Unmarshal([]byte(`{"source": "Report", "utf8": "disable"}`), &modes))
+	assert.Equal(map[string]Enforcement{"source": ReportMode, "utf8": DisableMode}, modes)
+
+	b, err := json.Marshal(modes)
+	require.NoError(err)
+	assert.JSONEq(`{"source": "report", "utf8": "disable"}`, string(b))
+
+	var e Enforcement
+	assert.Error(e.UnmarshalText([]byte("strict")))
+	assert.Equal("unknown", lastMode.String())
+
+	_, err = lastMode.MarshalText()
+	assert.ErrorIs(err, ErrValidatorInvalidConfig)
+}